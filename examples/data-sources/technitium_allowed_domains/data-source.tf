@@ -0,0 +1,6 @@
+# List every domain of the allowed zones, including those not managed by Terraform
+data "technitium_allowed_domains" "all" {}
+
+output "allowed_domains" {
+  value = data.technitium_allowed_domains.all.domains
+}
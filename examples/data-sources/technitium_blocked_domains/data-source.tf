@@ -0,0 +1,6 @@
+# List every domain of the blocked zones, including those not managed by Terraform
+data "technitium_blocked_domains" "all" {}
+
+output "blocked_domain_count" {
+  value = length(data.technitium_blocked_domains.all.domains)
+}
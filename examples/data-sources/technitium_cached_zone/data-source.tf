@@ -0,0 +1,12 @@
+# Inspect the cached answers for example.com
+data "technitium_cached_zone" "example" {
+  domain = "example.com"
+}
+
+output "cached_addresses" {
+  value = [for record in data.technitium_cached_zone.example.records : record.data if record.type == "A"]
+}
+
+output "cached_subdomains" {
+  value = data.technitium_cached_zone.example.zones
+}
@@ -0,0 +1,17 @@
+# List the catalog zones on the server and their member zones
+data "technitium_catalog_zones" "all" {}
+
+locals {
+  catalogs = { for catalog in data.technitium_catalog_zones.all.catalog_zones : catalog.name => catalog }
+}
+
+# Add the zone to the catalog only when it exists on the server
+resource "technitium_zone" "member" {
+  name    = "example.com"
+  type    = "Primary"
+  catalog = contains(keys(local.catalogs), "catalog.example") ? "catalog.example" : null
+}
+
+output "catalog_members" {
+  value = { for name, catalog in local.catalogs : name => catalog.member_zones }
+}
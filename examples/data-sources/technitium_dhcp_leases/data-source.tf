@@ -0,0 +1,16 @@
+provider "technitium" {
+  experimental_features = ["dhcp"]
+}
+
+# List the current leases of the Default scope
+data "technitium_dhcp_leases" "default" {
+  scope = "Default"
+}
+
+output "dhcp_hosts" {
+  value = { for lease in data.technitium_dhcp_leases.default.leases : lease.hardware_address => lease.address if lease.host_name != "" }
+}
+
+output "reserved_addresses" {
+  value = [for lease in data.technitium_dhcp_leases.default.leases : lease.address if lease.type == "Reserved"]
+}
@@ -0,0 +1,14 @@
+provider "technitium" {
+  experimental_features = ["dhcp"]
+}
+
+# List all DHCP scopes with their address ranges and lease counts
+data "technitium_dhcp_scopes" "all" {}
+
+output "enabled_dhcp_scopes" {
+  value = [for scope in data.technitium_dhcp_scopes.all.scopes : scope.name if scope.enabled]
+}
+
+output "dhcp_lease_usage" {
+  value = { for scope in data.technitium_dhcp_scopes.all.scopes : scope.name => scope.lease_count }
+}
@@ -0,0 +1,23 @@
+# Look up the app that provides an APP record class path
+data "technitium_dns_app_component" "simple_address" {
+  class_path = "SplitHorizon.SimpleAddress"
+}
+
+# Use the server's record data template as the APP record data
+resource "technitium_dns_record" "intranet" {
+  zone       = "corp.example.com"
+  name       = "intranet"
+  type       = "APP"
+  ttl        = 3600
+  app_name   = data.technitium_dns_app_component.simple_address.app_name
+  class_path = data.technitium_dns_app_component.simple_address.class_path
+  data       = data.technitium_dns_app_component.simple_address.record_data_template
+}
+
+output "simple_address_app" {
+  value = {
+    app_name    = data.technitium_dns_app_component.simple_address.app_name
+    app_version = data.technitium_dns_app_component.simple_address.app_version
+    description = data.technitium_dns_app_component.simple_address.description
+  }
+}
@@ -0,0 +1,27 @@
+# Check that the server answers for a record managed in the same run
+data "technitium_dns_client_query" "www" {
+  name = "www.example.com"
+  type = "A"
+
+  depends_on = [technitium_dns_record.www]
+
+  lifecycle {
+    postcondition {
+      condition     = self.rcode == "NoError" && length(self.answers) > 0
+      error_message = "www.example.com does not resolve through the DNS server."
+    }
+  }
+}
+
+output "www_addresses" {
+  value = [for answer in data.technitium_dns_client_query.www.answers : answer.data if answer.type == "A"]
+}
+
+# Resolve through the recursive resolver over DNS-over-TLS with DNSSEC validation
+data "technitium_dns_client_query" "upstream" {
+  name              = "example.org"
+  type              = "AAAA"
+  server            = "recursive-resolver"
+  protocol          = "Tls"
+  dnssec_validation = true
+}
@@ -15,6 +15,18 @@ data "technitium_dns_records" "web_records" {
   record_types = ["A", "AAAA", "CNAME"]
 }
 
+# Data source to select only the records managed by a team in a shared zone
+data "technitium_dns_records" "team_records" {
+  zone              = "example.com"
+  comments_contains = "team-platform"
+}
+
+# Data source to list only the wildcard records of a zone (e.g. *.example.com, *.sub.example.com)
+data "technitium_dns_records" "wildcard_records" {
+  zone     = "example.com"
+  wildcard = true
+}
+
 # Output all records information
 output "all_records" {
   value = {
@@ -48,3 +60,40 @@ resource "technitium_dns_record" "monitoring_records" {
   ttl  = 300
   data = "${each.key}.example.com"
 }
+
+# Example: Use the zone metadata returned with the records
+output "zone_is_signed" {
+  value = data.technitium_dns_records.a_records_only.zone_dnssec_status != "Unsigned"
+}
+
+# Example: Assert the zone is delegated to exactly two name servers
+check "zone_has_two_ns_records" {
+  data "technitium_dns_records" "ns_records" {
+    zone         = "example.com"
+    record_types = ["NS"]
+  }
+
+  assert {
+    condition     = lookup(data.technitium_dns_records.ns_records.record_type_counts, "NS", 0) == 2
+    error_message = "Zone example.com must contain exactly 2 NS records."
+  }
+}
+
+# Find enabled web server records with a TTL below five minutes
+data "technitium_dns_records" "short_lived_web" {
+  zone          = "example.com"
+  name_regex    = "^web[0-9]+\\."
+  disabled      = false
+  ttl_less_than = 300
+}
+
+# Read a large zone one page at a time
+data "technitium_dns_records" "first_page" {
+  zone        = "example.com"
+  page_size   = 500
+  page_number = 1
+}
+
+output "zone_pages" {
+  value = data.technitium_dns_records.first_page.total_pages
+}
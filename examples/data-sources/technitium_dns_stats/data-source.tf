@@ -0,0 +1,29 @@
+# Statistics of the last day
+data "technitium_dns_stats" "last_day" {
+  period = "LastDay"
+}
+
+output "queries_last_day" {
+  value = data.technitium_dns_stats.last_day.total_queries
+}
+
+output "top_domains" {
+  value = [for domain in data.technitium_dns_stats.last_day.top_domains : domain.name]
+}
+
+# Statistics of a custom range
+data "technitium_dns_stats" "october" {
+  period = "Custom"
+  start  = "2026-10-01T00:00:00Z"
+  end    = "2026-10-31T23:59:59Z"
+}
+
+# Fail the run when the server has not answered any query in the last hour
+data "technitium_dns_stats" "last_hour" {
+  lifecycle {
+    postcondition {
+      condition     = self.total_queries > 0
+      error_message = "The DNS server has not received any queries in the last hour."
+    }
+  }
+}
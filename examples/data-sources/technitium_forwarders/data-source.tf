@@ -0,0 +1,10 @@
+# List every conditional forwarder zone and its upstream targets
+data "technitium_forwarders" "all" {}
+
+# Map of forwarded domains to their upstream forwarders
+output "forwarded_domains" {
+  value = {
+    for fwd in data.technitium_forwarders.all.forwarders :
+    fwd.domain => fwd.forwarder...
+  }
+}
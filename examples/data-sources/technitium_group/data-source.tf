@@ -0,0 +1,12 @@
+provider "technitium" {
+  experimental_features = ["admin"]
+}
+
+# Look up a built-in group and its members
+data "technitium_group" "administrators" {
+  name = "Administrators"
+}
+
+output "administrators" {
+  value = data.technitium_group.administrators.members
+}
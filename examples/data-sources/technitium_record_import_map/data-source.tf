@@ -0,0 +1,43 @@
+# Map record sets exported from another DNS provider (e.g. Route 53)
+data "technitium_record_import_map" "migration" {
+  zone = "example.com"
+
+  records = [
+    {
+      name   = "www.example.com."
+      type   = "A"
+      ttl    = 300
+      values = ["192.0.2.10", "192.0.2.11"]
+    },
+    {
+      name   = "example.com."
+      type   = "MX"
+      ttl    = 3600
+      values = ["10 mail1.example.com.", "20 mail2.example.com."]
+    },
+    {
+      name   = "example.com."
+      type   = "TXT"
+      ttl    = 3600
+      values = ["\"v=spf1 include:_spf.example.net -all\""]
+    },
+  ]
+}
+
+# Create one technitium_dns_record per mapped value
+resource "technitium_dns_record" "migrated" {
+  for_each = { for m in data.technitium_record_import_map.migration.mappings : m.key => m }
+
+  zone     = "example.com"
+  name     = each.value.name
+  type     = each.value.type
+  ttl      = each.value.ttl
+  data     = each.value.data
+  priority = each.value.priority
+  weight   = each.value.weight
+  port     = each.value.port
+}
+
+output "unmapped_records" {
+  value = data.technitium_record_import_map.migration.skipped
+}
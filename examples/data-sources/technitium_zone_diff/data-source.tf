@@ -0,0 +1,32 @@
+# Compare the live zone against the records the team expects
+data "technitium_zone_diff" "example" {
+  zone = "example.com"
+
+  desired_records = [
+    {
+      name = "@"
+      type = "A"
+      ttl  = 3600
+      data = "192.168.1.10"
+    },
+    {
+      name = "www"
+      type = "CNAME"
+      data = "example.com"
+    },
+    {
+      name = "@"
+      type = "MX"
+      data = "10 mail.example.com"
+    },
+  ]
+}
+
+# Fail a pipeline check when the zone has drifted
+output "zone_in_sync" {
+  value = data.technitium_zone_diff.example.in_sync
+}
+
+output "unexpected_records" {
+  value = data.technitium_zone_diff.example.removals
+}
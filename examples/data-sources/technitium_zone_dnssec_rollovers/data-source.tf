@@ -0,0 +1,16 @@
+# List the DNSSEC key events due in the next 30 days
+data "technitium_zone_dnssec_rollovers" "example" {
+  zone        = "example.com"
+  within_days = 30
+}
+
+# Events that need the DS records at the registrar updated by hand
+output "ds_updates_due" {
+  value = [
+    for event in data.technitium_zone_dnssec_rollovers.example.events : {
+      key_tag = event.key_tag
+      event   = event.event
+      date    = event.date
+    } if event.requires_ds_update
+  ]
+}
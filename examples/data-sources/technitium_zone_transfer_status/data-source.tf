@@ -0,0 +1,21 @@
+# Check the replication state of a secondary zone
+data "technitium_zone_transfer_status" "secondary" {
+  name = "example.com"
+}
+
+output "secondary_zone_status" {
+  value = {
+    soa_serial    = data.technitium_zone_transfer_status.secondary.soa_serial
+    last_modified = data.technitium_zone_transfer_status.secondary.last_modified
+    expiry        = data.technitium_zone_transfer_status.secondary.expiry
+    sync_failed   = data.technitium_zone_transfer_status.secondary.sync_failed
+  }
+}
+
+# Fail the plan when the secondary has fallen out of sync
+check "secondary_in_sync" {
+  assert {
+    condition     = !data.technitium_zone_transfer_status.secondary.sync_failed && !data.technitium_zone_transfer_status.secondary.is_expired
+    error_message = "The secondary zone failed to refresh from its primary."
+  }
+}
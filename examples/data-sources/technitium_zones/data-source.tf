@@ -0,0 +1,23 @@
+# List every zone on the server
+data "technitium_zones" "all" {}
+
+locals {
+  primary_zones = {
+    for zone in data.technitium_zones.all.zones : zone.name => zone
+    if zone.type == "Primary" && !zone.internal
+  }
+}
+
+# Read the records of every primary zone
+data "technitium_dns_records" "primary" {
+  for_each = local.primary_zones
+  zone     = each.key
+}
+
+output "disabled_zones" {
+  value = [for zone in data.technitium_zones.all.zones : zone.name if zone.disabled]
+}
+
+output "unsigned_primary_zones" {
+  value = [for name, zone in local.primary_zones : name if zone.dnssec_status == "Unsigned"]
+}
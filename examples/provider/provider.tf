@@ -16,4 +16,54 @@ provider "technitium" {
 
   # Alternative: Authentication using API token
   # token = "your-api-token-here"
+
+  # The host and credentials can also be left out and read from the
+  # TECHNITIUM_HOST, TECHNITIUM_TOKEN, TECHNITIUM_USERNAME and
+  # TECHNITIUM_PASSWORD environment variables
+
+  # Optional: tag every record managed by Terraform
+  # default_comment = "managed by terraform"
+
+  # Optional: zone and TTL of technitium_dns_record resources that leave them unset
+  # default_zone = "example.com"
+  # default_ttl  = 3600
+
+  # Optional: fail the apply when the server stores different values than planned
+  # strict_consistency = true
+
+  # Optional: log a summary of changes and API calls after each change
+  # operations_report = true
+
+  # Optional: fail on the first error instead of retrying, e.g. in CI
+  # fail_fast = true
+  # or only stop logging in again when the session token is rejected
+  # retry_on_auth_failure = false
+
+  # Optional: tune the backoff between retries of transient failures
+  # retry_min_delay_ms = 500
+  # retry_max_delay_ms = 10000
+
+  # Optional: throttle API requests when applying many resources at once
+  # max_concurrent_requests = 4
+  # requests_per_second     = 20
+
+  # Optional: disable caching of zone records and app lists, e.g. when other
+  # tools change the server during an apply
+  # response_cache_seconds = 0
+  # or read large zones with a single request instead of one per record
+  # batch_reads = true
+
+  # Optional: disable HTTP/2 when a reverse proxy mishandles it
+  # disable_http2 = true
+
+  # Optional: connect to a different address than the host URL, e.g. when the
+  # provider runs on the Docker host while CI uses the public name
+  # host_aliases = {
+  #   "dns.example.com" = "127.0.0.1:5380"
+  #   # or through a reverse proxy listening on a unix socket
+  #   # "dns.example.com" = "unix:///run/technitium/api.sock"
+  # }
+
+  # Optional: opt in to experimental resources (settings, dhcp, dnssec, admin)
+  # experimental_features = ["dhcp"]
 }
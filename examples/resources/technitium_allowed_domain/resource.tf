@@ -0,0 +1,4 @@
+# Never block a domain and its subdomains, even when a block list contains them
+resource "technitium_allowed_domain" "updates" {
+  domain = "updates.example.com"
+}
@@ -0,0 +1,4 @@
+# Never block the domains of an allow list kept next to the configuration
+resource "technitium_allowed_domains_import" "allowlist" {
+  content = file("${path.module}/allowed-domains.txt")
+}
@@ -0,0 +1,14 @@
+provider "technitium" {
+  experimental_features = ["admin"]
+}
+
+# Create a token for the CI pipeline's automation account
+resource "technitium_api_token" "ci" {
+  user       = "ci"
+  token_name = "github-actions"
+}
+
+output "ci_token" {
+  value     = technitium_api_token.ci.token
+  sensitive = true
+}
@@ -0,0 +1,10 @@
+# Block a domain and all its subdomains
+resource "technitium_blocked_domain" "ads" {
+  domain = "ads.example.com"
+}
+
+resource "technitium_blocked_domain" "trackers" {
+  for_each = toset(["tracker.example.net", "telemetry.example.org"])
+
+  domain = each.value
+}
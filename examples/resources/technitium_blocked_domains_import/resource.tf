@@ -0,0 +1,10 @@
+# Block every domain of a hosts file downloaded by the provider
+resource "technitium_blocked_domains_import" "ads" {
+  url = "https://raw.githubusercontent.com/StevenBlack/hosts/master/alternates/fakenews/hosts"
+}
+
+# Block the domains of a list kept next to the configuration
+resource "technitium_blocked_domains_import" "local" {
+  content    = file("${path.module}/blocked-domains.txt")
+  batch_size = 50
+}
@@ -0,0 +1,19 @@
+# The blocklist resource is experimental and must be enabled in the provider:
+#
+# provider "technitium" {
+#   experimental_features = ["settings"]
+# }
+
+# Pi-hole style blocking with an allow list for false positives
+resource "technitium_blocklist" "main" {
+  block_list_urls = [
+    "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts",
+    "https://big.oisd.nl/",
+  ]
+
+  allow_list_urls = [
+    "https://example.com/allowlist.txt",
+  ]
+
+  update_interval_hours = 24
+}
@@ -0,0 +1,15 @@
+# Delete the cached entries of the migrated zone whenever its records change
+resource "technitium_cache_flush" "example" {
+  domains = ["example.com"]
+
+  triggers = {
+    records = join(",", [for record in technitium_dns_record.migrated : record.id])
+  }
+}
+
+# Flush the whole cache once, e.g. after switching forwarders
+resource "technitium_cache_flush" "all" {
+  triggers = {
+    forwarders = join(",", technitium_dns_settings.main.forwarders)
+  }
+}
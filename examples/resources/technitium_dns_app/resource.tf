@@ -36,4 +36,23 @@ resource "technitium_dns_app" "geo_country" {
   name           = "Geo Country"
   install_method = "url"
   url            = "https://download.technitium.com/dns/apps/GeoCountryApp.zip"
+
+  # Large apps can take longer to download and install than the provider timeout_seconds
+  timeouts {
+    create = "10m"
+    update = "10m"
+  }
+}
+
+# Pin a store app to a version: when the installed version drifts, e.g. after the app was
+# updated outside of Terraform, the app is downloaded and updated again
+resource "technitium_dns_app" "split_horizon" {
+  name           = "Split Horizon"
+  install_method = "url"
+  url            = "https://download.technitium.com/dns/apps/SplitHorizonApp.zip"
+  version        = "8.0"
+}
+
+output "split_horizon_update_available" {
+  value = technitium_dns_app.split_horizon.update_available
 }
@@ -7,6 +7,17 @@ resource "technitium_dns_record" "example_a" {
   data = "192.168.1.100"
 }
 
+# A Record with a matching PTR record in the reverse zone, created when missing
+resource "technitium_dns_record" "example_a_ptr" {
+  zone            = "example.com"
+  name            = "mail"
+  type            = "A"
+  ttl             = 300
+  data            = "192.168.1.25"
+  create_ptr      = true
+  create_ptr_zone = true
+}
+
 # AAAA Record (IPv6)
 resource "technitium_dns_record" "example_aaaa" {
   zone = "example.com"
@@ -16,6 +27,38 @@ resource "technitium_dns_record" "example_aaaa" {
   data = "2001:db8::1"
 }
 
+# Wildcard A Record answering for any otherwise undefined name in the zone (*.example.com)
+resource "technitium_dns_record" "example_wildcard" {
+  zone = "example.com"
+  name = "*"
+  type = "A"
+  ttl  = 300
+  data = "192.168.1.100"
+}
+
+# Wildcard CNAME Record for a subdomain (*.apps.example.com)
+resource "technitium_dns_record" "example_wildcard_sub" {
+  zone = "example.com"
+  name = "*.apps"
+  type = "CNAME"
+  ttl  = 300
+  data = "ingress.example.com"
+}
+
+# Temporary delegation that the server deletes one day after it was last modified
+resource "technitium_dns_record" "example_temporary" {
+  zone       = "example.com"
+  name       = "migration"
+  type       = "NS"
+  ttl        = 300
+  data       = "ns1.partner.example.net"
+  expiry_ttl = 86400
+}
+
+output "temporary_delegation_expires_on" {
+  value = technitium_dns_record.example_temporary.expires_on
+}
+
 # CNAME Record
 resource "technitium_dns_record" "example_cname" {
   zone = "example.com"
@@ -102,3 +145,157 @@ resource "technitium_dns_record" "example_fwd" {
   forwarder = "8.8.8.8"  # Forward to Google DNS
   protocol  = "Udp"
 }
+
+# APP Record (Split Horizon answer inside a conditional forwarder zone)
+resource "technitium_dns_record" "example_app" {
+  zone       = "corp.example.com"
+  name       = "intranet"
+  type       = "APP"
+  ttl        = 3600
+  app_name   = "Split Horizon"
+  class_path = "SplitHorizon.SimpleAddress"
+  data = jsonencode({
+    public  = ["203.0.113.10"]
+    private = ["10.0.0.10"]
+  })
+}
+
+# APP Record wired to an app component looked up by class path, starting from its record data template
+data "technitium_dns_app_component" "split_horizon_cname" {
+  class_path = "SplitHorizon.SimpleCNAME"
+}
+
+resource "technitium_dns_record" "example_app_cname" {
+  zone       = "corp.example.com"
+  name       = "portal"
+  type       = "APP"
+  ttl        = 3600
+  app_name   = data.technitium_dns_app_component.split_horizon_cname.app_name
+  class_path = data.technitium_dns_app_component.split_horizon_cname.class_path
+  data = jsonencode(merge(jsondecode(data.technitium_dns_app_component.split_horizon_cname.record_data_template), {
+    public  = "portal.example.net"
+    private = "portal.corp.internal"
+  }))
+}
+
+# CAA Records (Certificate Authority Authorization)
+resource "technitium_dns_record" "example_caa_issue" {
+  zone = "example.com"
+  name = "@"
+  type = "CAA"
+  ttl  = 3600
+  tag  = "issue"
+  data = "letsencrypt.org"
+}
+
+resource "technitium_dns_record" "example_caa_iodef" {
+  zone  = "example.com"
+  name  = "@"
+  type  = "CAA"
+  ttl   = 3600
+  flags = 128 # Critical: CAs that do not understand the tag must not issue
+  tag   = "iodef"
+  data  = "mailto:security@example.com"
+}
+
+# SSHFP Record (SSH host key fingerprint, e.g. from `ssh-keygen -r host.example.com`)
+resource "technitium_dns_record" "example_sshfp" {
+  zone             = "example.com"
+  name             = "host"
+  type             = "SSHFP"
+  ttl              = 3600
+  algorithm        = "Ed25519"
+  fingerprint_type = "SHA256"
+  fingerprint      = "4e7d3f0a9c2b1d8e6f5a4b3c2d1e0f9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e"
+}
+
+# TLSA Record (DANE-EE for SMTP, pinning the SHA-256 digest of the mail server public key)
+resource "technitium_dns_record" "example_tlsa_smtp" {
+  zone                         = "example.com"
+  name                         = "_25._tcp.mail"
+  type                         = "TLSA"
+  ttl                          = 3600
+  certificate_usage            = "DANE-EE"
+  selector                     = "SPKI"
+  matching_type                = "SHA2-256"
+  certificate_association_data = "8d02536c887482bc34ff54e41d2ba659bf85b341a0a20afadb5813dcfbcf286d"
+}
+
+# TLSA Record for HTTPS derived from a PEM certificate, the server computes the digest
+resource "technitium_dns_record" "example_tlsa_https" {
+  zone                         = "example.com"
+  name                         = "_443._tcp.www"
+  type                         = "TLSA"
+  ttl                          = 3600
+  certificate_usage            = "DANE-EE"
+  selector                     = "SPKI"
+  matching_type                = "SHA2-256"
+  certificate_association_data = file("${path.module}/www.example.com.pem")
+}
+
+# HTTPS Record (service binding advertising HTTP/3 for the zone apex)
+resource "technitium_dns_record" "example_https" {
+  zone     = "example.com"
+  name     = "@"
+  type     = "HTTPS"
+  ttl      = 3600
+  priority = 1
+  data     = "." # The owner name itself serves the service
+  svc_params = {
+    alpn     = "h2,h3"
+    ipv4hint = "192.0.2.10"
+    ipv6hint = "2001:db8::10"
+  }
+}
+
+# SVCB Record in alias mode (priority 0) pointing a service at another name
+resource "technitium_dns_record" "example_svcb_alias" {
+  zone     = "example.com"
+  name     = "_dns"
+  type     = "SVCB"
+  ttl      = 3600
+  priority = 0
+  data     = "dns.example.net"
+}
+
+# Round-robin A records, one resource per address. With allow_overwrite left
+# false, each record is added next to its siblings instead of replacing them.
+resource "technitium_dns_record" "example_round_robin" {
+  for_each = toset(["192.0.2.21", "192.0.2.22", "192.0.2.23"])
+
+  zone            = "example.com"
+  name            = "pool"
+  type            = "A"
+  ttl             = 300
+  data            = each.value
+  allow_overwrite = false
+}
+
+# A record kept in the zone but not served, e.g. while its target is under maintenance
+resource "technitium_dns_record" "example_disabled" {
+  zone     = "example.com"
+  name     = "legacy"
+  type     = "A"
+  ttl      = 300
+  data     = "192.0.2.30"
+  disabled = true
+}
+
+# Adopt an existing record with an import block. The identity holds the data
+# as is, so IPv6 addresses and other values containing colons need no escaping.
+import {
+  to = technitium_dns_record.example_imported
+  identity = {
+    zone = "example.com"
+    name = "www"
+    type = "AAAA"
+    data = "2001:db8::1"
+  }
+}
+
+resource "technitium_dns_record" "example_imported" {
+  zone = "example.com"
+  name = "www"
+  type = "AAAA"
+  data = "2001:db8::1"
+}
@@ -0,0 +1,21 @@
+# Round-robin A records: www.example.com answers with all three addresses
+resource "technitium_dns_record_set" "www" {
+  zone   = "example.com"
+  name   = "www"
+  type   = "A"
+  ttl    = 300
+  values = ["192.168.1.10", "192.168.1.11", "192.168.1.12"]
+}
+
+# Several TXT records at the zone apex
+resource "technitium_dns_record_set" "apex_txt" {
+  zone = "example.com"
+  name = "@"
+  type = "TXT"
+  ttl  = 3600
+  values = [
+    "v=spf1 mx -all",
+    "google-site-verification=abc123",
+  ]
+  comments = "Managed by Terraform"
+}
@@ -0,0 +1,38 @@
+# The settings resource is experimental and must be enabled in the provider:
+#
+# provider "technitium" {
+#   experimental_features = ["settings"]
+# }
+
+# Forward queries over DNS-over-TLS, allow recursion only for private networks,
+# and answer blocked domains with NXDOMAIN. Settings that are not set here keep
+# their current server values.
+resource "technitium_dns_settings" "main" {
+  dns_server_local_end_points = ["0.0.0.0:53", "[::]:53"]
+
+  forwarders            = ["cloudflare-dns.com (1.1.1.1:853)", "dns.quad9.net (9.9.9.9:853)"]
+  forwarder_protocol    = "Tls"
+  concurrent_forwarding = true
+
+  recursion = "AllowOnlyForPrivateNetworks"
+
+  cache_maximum_entries = 20000
+  serve_stale           = true
+
+  enable_blocking     = true
+  blocking_type       = "NxDomain"
+  blocking_answer_ttl = 30
+
+  log_queries       = true
+  max_log_file_days = 30
+}
+
+# Serve DNS-over-TLS and DNS-over-HTTPS with a certificate stored on the server.
+# Add these attributes to the single technitium_dns_settings resource above;
+# they are shown separately here for readability.
+#
+#   enable_dns_over_tls          = true
+#   enable_dns_over_https        = true
+#   dns_over_https_port          = 443
+#   dns_tls_certificate_path     = "/etc/dns/certs/dns.example.com.pfx"
+#   dns_tls_certificate_password = var.dns_tls_certificate_password
@@ -0,0 +1,10 @@
+provider "technitium" {
+  experimental_features = ["admin"]
+}
+
+# Create a group for the on-call DNS operators
+resource "technitium_group" "dns_operators" {
+  name        = "DNS Operators"
+  description = "On-call DNS operators"
+  members     = ["alice", "bob"]
+}
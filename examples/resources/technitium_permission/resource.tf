@@ -0,0 +1,20 @@
+provider "technitium" {
+  experimental_features = ["admin"]
+}
+
+# Let the DNS operators view and edit zones
+resource "technitium_permission" "operators_zones" {
+  section    = "Zones"
+  group      = "DNS Operators"
+  can_view   = true
+  can_modify = true
+}
+
+# Give a single user full access to one zone
+resource "technitium_permission" "alice_example_com" {
+  zone       = "example.com"
+  user       = "alice"
+  can_view   = true
+  can_modify = true
+  can_delete = true
+}
@@ -0,0 +1,20 @@
+resource "technitium_dns_record" "server" {
+  zone = "example.com"
+  name = "server"
+  type = "A"
+  ttl  = 3600
+  data = "192.168.1.10"
+}
+
+# Create 1.168.192.in-addr.arpa unless it already exists
+resource "technitium_ptr_zone_auto" "server" {
+  address = technitium_dns_record.server.data
+}
+
+resource "technitium_dns_record" "server_ptr" {
+  zone = technitium_ptr_zone_auto.server.zone
+  name = "10"
+  type = "PTR"
+  ttl  = 3600
+  data = "server.example.com"
+}
@@ -0,0 +1,34 @@
+provider "technitium" {
+  experimental_features = ["settings"]
+}
+
+# Generate a key for zone transfers to the secondary servers
+resource "technitium_tsig_key" "xfr" {
+  name      = "xfr.example.com"
+  algorithm = "hmac-sha256"
+}
+
+# Import a key shared with the primary server
+resource "technitium_tsig_key" "primary" {
+  name          = "primary.example.com"
+  algorithm     = "hmac-sha512"
+  shared_secret = var.primary_tsig_secret
+}
+
+variable "primary_tsig_secret" {
+  type      = string
+  sensitive = true
+}
+
+resource "technitium_zone" "example" {
+  name                         = "example.com"
+  type                         = "Primary"
+  zone_transfer_tsig_key_names = [technitium_tsig_key.xfr.name]
+}
+
+resource "technitium_zone" "secondary" {
+  name                          = "example.net"
+  type                          = "Secondary"
+  primary_name_server_addresses = "192.0.2.1"
+  tsig_key_name                 = technitium_tsig_key.primary.name
+}
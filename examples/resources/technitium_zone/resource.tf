@@ -11,6 +11,9 @@ resource "technitium_zone" "example_primary" {
   soa_retry               = 1800
   soa_expire              = 1209600
   soa_minimum             = 300
+
+  # Optional: stop serving the zone, e.g. during a maintenance window
+  disabled = false
 }
 
 # Secondary DNS Zone
@@ -23,6 +26,9 @@ resource "technitium_zone" "example_secondary" {
 
   # Optional: TSIG key for secure zone transfers
   # tsig_key_name = "example-key"
+
+  # Optional: change to transfer the zone from the primary again
+  resync_trigger = "2024-06-01"
 }
 
 # Conditional Forwarder Zone
@@ -37,3 +43,129 @@ resource "technitium_zone" "example_forwarder" {
   # Optional: Enable DNSSEC validation
   dnssec_validation = true
 }
+
+# Conditional Forwarder Zone forwarding to several upstream servers
+resource "technitium_zone" "example_forwarder_pool" {
+  name = "ad.company.com"
+  type = "Forwarder"
+
+  forwarders = ["10.0.1.10", "10.0.1.11", "10.0.1.12"]
+  protocol   = "Udp"
+}
+
+# Primary DNS Zone with records created together with the zone
+resource "technitium_zone" "example_bootstrapped" {
+  name = "bootstrapped.example.com"
+  type = "Primary"
+
+  bootstrap_records = [
+    {
+      name = "@"
+      type = "A"
+      ttl  = 3600
+      data = "192.168.1.10"
+    },
+    {
+      name     = "@"
+      type     = "MX"
+      ttl      = 3600
+      data     = "mail.bootstrapped.example.com"
+      priority = 10
+    },
+  ]
+}
+
+# Scratch zone that may be destroyed even while it still contains records
+resource "technitium_zone" "example_scratch" {
+  name = "scratch.example.com"
+  type = "Primary"
+
+  force_destroy = true
+}
+
+# Force a SOA serial increment and NOTIFY to secondaries by changing the trigger value
+resource "technitium_zone" "example_migrating" {
+  name = "migrating.example.com"
+  type = "Primary"
+
+  serial_bump_trigger = "2024-06-01-cutover"
+}
+
+# Only allow zone transfers signed with one of the listed TSIG keys
+resource "technitium_zone" "example_transfer_secured" {
+  name = "secured.example.com"
+  type = "Primary"
+
+  zone_transfer_tsig_key_names = ["xfr.example.com"]
+  zone_transfer_require_tsig   = true
+}
+
+# Allow zone transfers only from the secondaries, and notify them of updates
+resource "technitium_zone" "example_replicated" {
+  name = "replicated.example.com"
+  type = "Primary"
+
+  zone_transfer             = "UseSpecifiedNetworkACL"
+  zone_transfer_network_acl = ["192.168.10.5", "192.168.10.6"]
+  notify                    = "SpecifiedNameServers"
+  notify_name_servers       = ["192.168.10.5", "192.168.10.6"]
+}
+
+# Answer queries from private networks only, and accept dynamic updates signed with a TSIG key
+resource "technitium_zone" "example_dynamic" {
+  name = "dynamic.example.com"
+  type = "Primary"
+
+  query_access = "AllowOnlyPrivateNetworks"
+  update       = "Allow"
+
+  update_security_policies = [
+    {
+      tsig_key_name = "ddns.example.com"
+      domain        = "*.dynamic.example.com"
+      allowed_types = ["A", "AAAA"]
+    },
+  ]
+}
+
+# Sign the zone with DNSSEC. Signing is experimental and must be enabled in the provider:
+#
+# provider "technitium" {
+#   experimental_features = ["dnssec"]
+# }
+resource "technitium_zone" "example_signed" {
+  name = "signed.example.com"
+  type = "Primary"
+
+  dnssec_signed           = true
+  dnssec_algorithm        = "ECDSA"
+  dnssec_curve            = "P256"
+  dnssec_nx_proof         = "NSEC3"
+  dnssec_nsec3_iterations = 0
+  dnssec_dnskey_ttl       = 3600
+}
+
+# Adopt an existing zone with an import block
+import {
+  to = technitium_zone.example_imported
+  identity = {
+    name = "imported.example.com"
+  }
+}
+
+resource "technitium_zone" "example_imported" {
+  name = "imported.example.com"
+  type = "Primary"
+}
+
+# Staging copy of a production zone, with names in record data moved into the copy
+resource "technitium_zone" "example_staging" {
+  name = "staging.example.com"
+  type = "Primary"
+
+  clone_from           = technitium_zone.example_primary.name
+  clone_rewrite_origin = true
+
+  # The copy holds records of its own, so allow destroying it
+  force_destroy = true
+}
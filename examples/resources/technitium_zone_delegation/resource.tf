@@ -0,0 +1,18 @@
+# Delegate lab.example.com to name servers inside the subdomain, which need glue, and to an
+# external name server, which does not
+resource "technitium_zone_delegation" "lab" {
+  zone = "example.com"
+  name = "lab"
+  ttl  = 3600
+
+  name_servers = [
+    "ns1.lab.example.com",
+    "ns2.lab.example.com",
+    "ns.example.net",
+  ]
+
+  glue = {
+    "ns1.lab.example.com" = ["192.0.2.53", "2001:db8::53"]
+    "ns2.lab.example.com" = ["198.51.100.53"]
+  }
+}
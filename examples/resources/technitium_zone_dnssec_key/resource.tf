@@ -0,0 +1,31 @@
+# Managing DNSSEC keys is experimental and must be enabled in the provider:
+#
+# provider "technitium" {
+#   experimental_features = ["dnssec"]
+# }
+
+# Generate a Key Signing Key for a signed zone
+resource "technitium_zone_dnssec_key" "ksk" {
+  zone      = technitium_zone.example_signed.name
+  key_type  = "KeySigningKey"
+  algorithm = "ECDSA"
+  curve     = "P256"
+
+  # Change to roll the key over; publish the DS records of the successor at the registrar
+  rollover_trigger = "2026-01"
+}
+
+# Generate an RSA Zone Signing Key rolled over automatically every 30 days
+resource "technitium_zone_dnssec_key" "zsk" {
+  zone           = technitium_zone.example_signed.name
+  key_type       = "ZoneSigningKey"
+  algorithm      = "RSA"
+  hash_algorithm = "SHA256"
+  key_size       = 1024
+  rollover_days  = 30
+}
+
+# DS records to publish at the registrar
+output "ds_records" {
+  value = technitium_zone_dnssec_key.ksk.ds_records[*].record
+}
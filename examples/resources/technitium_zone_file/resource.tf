@@ -0,0 +1,17 @@
+resource "technitium_zone" "example" {
+  name = "example.com"
+  type = "Primary"
+}
+
+# Manage all records of the zone from a zone file kept next to the configuration
+resource "technitium_zone_file" "example" {
+  zone                    = technitium_zone.example.name
+  content                 = file("${path.module}/example.com.zone")
+  delete_unlisted_records = true
+}
+
+# Add the records of a migrated zone file, keeping records created elsewhere
+resource "technitium_zone_file" "legacy" {
+  zone    = "legacy.example.net"
+  content = file("${path.module}/legacy.example.net.zone")
+}
@@ -0,0 +1,267 @@
+// Package apicoverage builds the matrix of Technitium API endpoints documented in the API docs
+// versus the endpoints called by the API client, so coverage gaps are visible and resources can
+// be checked against endpoints the client actually implements.
+package apicoverage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Wildcard stands for a path segment the client builds at runtime, such as the zone list name
+const Wildcard = "*"
+
+// Matrix is the machine-readable API coverage report
+type Matrix struct {
+	// Endpoints lists every documented endpoint with the client methods calling it
+	Endpoints []Endpoint `json:"endpoints"`
+	// Undocumented lists endpoints called by the client that the API docs do not mention
+	Undocumented []string `json:"undocumented"`
+	// Implemented and Documented count the endpoints, for a quick coverage overview
+	Implemented int `json:"implemented"`
+	Documented  int `json:"documented"`
+}
+
+// Endpoint is a documented API endpoint and its support by the client
+type Endpoint struct {
+	Path        string   `json:"path"`
+	Section     string   `json:"section"`
+	Title       string   `json:"title"`
+	Implemented bool     `json:"implemented"`
+	Methods     []string `json:"methods,omitempty"`
+}
+
+// Call is an endpoint path found in Go source and the function using it
+type Call struct {
+	Path     string
+	Function string
+}
+
+// docEndpointPattern matches the example URLs of the API docs
+var docEndpointPattern = regexp.MustCompile(`localhost:5380(/api/[A-Za-z0-9/]+)`)
+
+// DocumentedEndpoints parses the markdown API docs in dir and returns the endpoints they describe
+func DocumentedEndpoints(dir string) ([]Endpoint, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	seen := map[string]bool{}
+	var endpoints []Endpoint
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var section, title string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "# "):
+				section = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			case strings.HasPrefix(line, "### "):
+				title = strings.TrimSpace(strings.TrimPrefix(line, "### "))
+			}
+
+			for _, match := range docEndpointPattern.FindAllStringSubmatch(line, -1) {
+				path := strings.TrimSuffix(match[1], "/")
+				if seen[path] {
+					continue
+				}
+				seen[path] = true
+				endpoints = append(endpoints, Endpoint{Path: path, Section: section, Title: title})
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+	}
+
+	return endpoints, nil
+}
+
+// SourceCalls parses the non-test Go files in dir and returns every API path they contain.
+// Paths concatenated from literals and runtime values get a wildcard for each runtime part.
+func SourceCalls(dir string) ([]Call, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var calls []Call
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		parsed, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range parsed.Decls {
+			function := declName(decl)
+
+			ast.Inspect(decl, func(node ast.Node) bool {
+				expr, ok := node.(ast.Expr)
+				if !ok {
+					return true
+				}
+				path, ok := foldPath(expr)
+				if !ok {
+					return true
+				}
+				if strings.HasPrefix(path, "/api/") && strings.Count(path, "/") >= 3 {
+					calls = append(calls, Call{Path: path, Function: function})
+				}
+				// Do not descend into the parts of a folded path
+				return false
+			})
+		}
+	}
+
+	return calls, nil
+}
+
+// declName names a declaration for the coverage report: the function name, or the name of the
+// first variable or constant of a package level declaration
+func declName(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		return d.Name.Name
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			if value, ok := spec.(*ast.ValueSpec); ok && len(value.Names) > 0 {
+				return value.Names[0].Name
+			}
+		}
+	}
+	return "package"
+}
+
+// foldPath renders string literals and concatenations of them, replacing runtime values by wildcards
+func foldPath(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, leftOK := foldPath(e.X)
+		right, rightOK := foldPath(e.Y)
+		if !leftOK && !rightOK {
+			return "", false
+		}
+		if !leftOK {
+			left = Wildcard
+		}
+		if !rightOK {
+			right = Wildcard
+		}
+		return left + right, true
+	case *ast.ParenExpr:
+		return foldPath(e.X)
+	}
+	return "", false
+}
+
+// Matches reports whether a documented path is matched by a client path, in which wildcards
+// match exactly one segment
+func Matches(pattern, path string) bool {
+	patternSegments := strings.Split(pattern, "/")
+	pathSegments := strings.Split(path, "/")
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		if segment != Wildcard && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Build combines the documented endpoints with the client calls into the coverage matrix
+func Build(documented []Endpoint, calls []Call) *Matrix {
+	matrix := &Matrix{Endpoints: make([]Endpoint, 0, len(documented)), Undocumented: []string{}}
+
+	matched := map[string]bool{}
+	for _, endpoint := range documented {
+		methods := map[string]bool{}
+		for _, call := range calls {
+			if Matches(call.Path, endpoint.Path) {
+				methods[call.Function] = true
+				matched[call.Path] = true
+			}
+		}
+
+		endpoint.Methods = sortedKeys(methods)
+		endpoint.Implemented = len(methods) > 0
+		if endpoint.Implemented {
+			matrix.Implemented++
+		}
+		matrix.Endpoints = append(matrix.Endpoints, endpoint)
+	}
+	matrix.Documented = len(matrix.Endpoints)
+
+	undocumented := map[string]bool{}
+	for _, call := range calls {
+		if !matched[call.Path] {
+			undocumented[call.Path] = true
+		}
+	}
+	matrix.Undocumented = append(matrix.Undocumented, sortedKeys(undocumented)...)
+
+	sort.Slice(matrix.Endpoints, func(i, j int) bool { return matrix.Endpoints[i].Path < matrix.Endpoints[j].Path })
+	return matrix
+}
+
+// Implements reports whether the client calls an endpoint matching path
+func (m *Matrix) Implements(path string) bool {
+	for _, endpoint := range m.Endpoints {
+		if endpoint.Implemented && (Matches(path, endpoint.Path) || Matches(endpoint.Path, path)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Marshal renders the matrix as indented JSON with a trailing newline
+func (m *Matrix) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+package apicoverage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	docsDir      = "../../.ai/docs/technitium-api"
+	clientDir    = "../client"
+	providerDir  = "../provider"
+	coverageFile = "../client/api_coverage.json"
+)
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, Matches("/api/zones/list", "/api/zones/list"))
+	require.True(t, Matches("/api/*/list", "/api/allowed/list"))
+	require.False(t, Matches("/api/*/list", "/api/zones/records/list"))
+	require.False(t, Matches("/api/zones/list", "/api/zones/create"))
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	matrix := Build(
+		[]Endpoint{{Path: "/api/zones/list"}, {Path: "/api/zones/create"}},
+		[]Call{{Path: "/api/zones/list", Function: "ListZones"}, {Path: "/api/zones/unknown", Function: "Unknown"}},
+	)
+
+	require.Equal(t, 2, matrix.Documented)
+	require.Equal(t, 1, matrix.Implemented)
+	require.Equal(t, []string{"/api/zones/unknown"}, matrix.Undocumented)
+	require.True(t, matrix.Implements("/api/zones/list"))
+	require.False(t, matrix.Implements("/api/zones/create"))
+}
+
+// clientMatrix builds the coverage matrix of the API client
+func clientMatrix(t *testing.T) *Matrix {
+	t.Helper()
+
+	documented, err := DocumentedEndpoints(docsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, documented)
+
+	calls, err := SourceCalls(clientDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, calls)
+
+	return Build(documented, calls)
+}
+
+func TestCoverageFileUpToDate(t *testing.T) {
+	t.Parallel()
+
+	expected, err := clientMatrix(t).Marshal()
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile(coverageFile)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual), "the API coverage matrix is outdated, run go generate ./internal/client")
+}
+
+func TestClientEndpointsDocumented(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, clientMatrix(t).Undocumented, "the client calls endpoints missing from the API docs, check for typos")
+}
+
+func TestProviderEndpointsImplemented(t *testing.T) {
+	t.Parallel()
+
+	matrix := clientMatrix(t)
+
+	calls, err := SourceCalls(providerDir)
+	require.NoError(t, err)
+
+	// Resources must go through client methods, so any endpoint they reference must be implemented
+	for _, call := range calls {
+		require.True(t, matrix.Implements(call.Path), "%s references %s, which the client does not implement", call.Function, call.Path)
+	}
+}
@@ -0,0 +1,37 @@
+// Command apicoverage writes the API coverage matrix of the Technitium API client.
+// It is run by go generate in internal/client.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/apicoverage"
+)
+
+func main() {
+	docs := flag.String("docs", "../../.ai/docs/technitium-api", "directory of the markdown API docs")
+	source := flag.String("client", ".", "directory of the API client sources")
+	out := flag.String("out", "api_coverage.json", "file to write the coverage matrix to")
+	flag.Parse()
+
+	documented, err := apicoverage.DocumentedEndpoints(*docs)
+	if err != nil {
+		log.Fatalf("failed to parse API docs: %v", err)
+	}
+
+	calls, err := apicoverage.SourceCalls(*source)
+	if err != nil {
+		log.Fatalf("failed to parse client sources: %v", err)
+	}
+
+	data, err := apicoverage.Build(documented, calls).Marshal()
+	if err != nil {
+		log.Fatalf("failed to render coverage matrix: %v", err)
+	}
+
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", *out, err)
+	}
+}
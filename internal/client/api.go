@@ -0,0 +1,119 @@
+package client
+
+// The API coverage matrix lists the documented Technitium endpoints the client implements
+//go:generate go run ../apicoverage/cmd/apicoverage -docs ../../.ai/docs/technitium-api -client . -out api_coverage.json
+
+import "context"
+
+// ClientAPI is the set of client operations used by the provider's resources and data sources.
+// Resources depend on this interface rather than *Client so their CRUD logic can be unit tested
+// against a mock without a running DNS server.
+type ClientAPI interface {
+	// Generic request access for endpoints without a dedicated method
+	DoRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error
+
+	// Zones
+	ListZones(ctx context.Context) ([]Zone, error)
+	ListZonesPage(ctx context.Context, pageNumber, zonesPerPage int) (*ZoneListResponse, error)
+	GetZone(ctx context.Context, zoneName string) (*ZoneInfo, error)
+	ZoneExists(ctx context.Context, zoneName string) (bool, error)
+	CreateZone(ctx context.Context, zoneName, zoneType string) error
+	DeleteZone(ctx context.Context, zoneName string) error
+	EnableZone(ctx context.Context, zoneName string) error
+	DisableZone(ctx context.Context, zoneName string) error
+	ResyncZone(ctx context.Context, zoneName string) error
+	GetZoneOptions(ctx context.Context, zoneName string) (*ZoneOptions, error)
+	SetZoneOptions(ctx context.Context, zoneName string, options map[string]string) error
+	BumpZoneSerial(ctx context.Context, zoneName string, useSerialDateScheme bool) (uint32, error)
+	GetZoneSOA(ctx context.Context, zoneName string) (*DNSRecord, error)
+	ExportZone(ctx context.Context, zoneName string) (string, error)
+	ImportZone(ctx context.Context, zoneName, content string, options ImportZoneOptions) error
+
+	// DNSSEC
+	GetDNSSECProperties(ctx context.Context, zoneName string) (*DNSSECProperties, error)
+	SignZone(ctx context.Context, zoneName string, options DNSSECSignOptions) error
+	UnsignZone(ctx context.Context, zoneName string) error
+	ConvertZoneToNSEC(ctx context.Context, zoneName string) error
+	ConvertZoneToNSEC3(ctx context.Context, zoneName string) error
+	UpdateNSEC3Params(ctx context.Context, zoneName string, iterations, saltLength int64) error
+	UpdateDNSKeyTTL(ctx context.Context, zoneName string, ttl int64) error
+	AddDNSSECPrivateKey(ctx context.Context, zoneName string, options DNSSECKeyOptions) error
+	UpdateDNSSECPrivateKey(ctx context.Context, zoneName string, keyTag, rolloverDays int64) error
+	DeleteDNSSECPrivateKey(ctx context.Context, zoneName string, keyTag int64) error
+	PublishAllDNSSECPrivateKeys(ctx context.Context, zoneName string) error
+	RolloverDNSKey(ctx context.Context, zoneName string, keyTag int64) error
+	RetireDNSKey(ctx context.Context, zoneName string, keyTag int64) error
+	GetDSInfo(ctx context.Context, zoneName string) (*DSInfo, error)
+
+	// DHCP
+	ListDHCPScopes(ctx context.Context) ([]DHCPScope, error)
+	ListDHCPLeases(ctx context.Context) ([]DHCPLease, error)
+
+	// Settings
+	GetDNSSettings(ctx context.Context) (*DNSSettings, error)
+	SetDNSSettings(ctx context.Context, settings map[string]string) (*DNSSettings, error)
+
+	// Allowed and blocked zones
+	ZoneListContains(ctx context.Context, list ZoneList, domain string) (bool, error)
+	ListZoneListDomains(ctx context.Context, list ZoneList) ([]string, error)
+	AddToZoneList(ctx context.Context, list ZoneList, domain string) error
+	DeleteFromZoneList(ctx context.Context, list ZoneList, domain string) error
+	ImportToZoneList(ctx context.Context, list ZoneList, domains []string) error
+	ExportZoneList(ctx context.Context, list ZoneList) ([]string, error)
+
+	// Dashboard
+	GetDashboardStats(ctx context.Context, period StatsPeriod, start, end string) (*DashboardStatsResponse, error)
+
+	// DNS client
+	ResolveQuery(ctx context.Context, options DNSClientQueryOptions) (*DNSClientResult, error)
+
+	// DNS cache
+	BrowseCache(ctx context.Context, domain string) (*BrowseCacheResponse, error)
+	DeleteCachedZone(ctx context.Context, domain string) error
+	FlushCache(ctx context.Context) error
+
+	// Groups
+	ListGroups(ctx context.Context) ([]Group, error)
+	GetGroup(ctx context.Context, name string) (*Group, error)
+	CreateGroup(ctx context.Context, name, description string) (*Group, error)
+	SetGroup(ctx context.Context, name, description string, members []string) (*Group, error)
+	DeleteGroup(ctx context.Context, name string) error
+
+	// Permissions
+	GetPermissions(ctx context.Context, section PermissionSection) (*Permissions, error)
+	SetPermissions(ctx context.Context, section PermissionSection, permissions *Permissions) (*Permissions, error)
+	GetZonePermissions(ctx context.Context, zoneName string) (*Permissions, error)
+	SetZonePermissions(ctx context.Context, zoneName string, permissions *Permissions) (*Permissions, error)
+
+	// Sessions
+	ListSessions(ctx context.Context) ([]Session, error)
+	CreateAPIToken(ctx context.Context, username, tokenName string) (*APIToken, error)
+	DeleteSession(ctx context.Context, partialToken string) error
+
+	// Records
+	AddRecord(ctx context.Context, record AddRecordRequest) (*AddRecordResponse, error)
+	GetRecords(ctx context.Context, zone, domain string, listZone bool) (*GetRecordsResponse, error)
+	UpdateRecord(ctx context.Context, record UpdateRecordRequest) (*UpdateRecordResponse, error)
+	DeleteRecord(ctx context.Context, record DeleteRecordRequest) error
+
+	// Apps
+	ListApps(ctx context.Context) ([]App, error)
+	ListStoreApps(ctx context.Context) ([]StoreApp, error)
+	DownloadAndInstallApp(ctx context.Context, name, appURL string) (*App, error)
+	DownloadAndUpdateApp(ctx context.Context, name, appURL string) (*App, error)
+	InstallApp(ctx context.Context, name string, appData []byte) (*App, error)
+	UpdateApp(ctx context.Context, name string, appData []byte) (*App, error)
+	UninstallApp(ctx context.Context, name string) error
+	GetAppConfig(ctx context.Context, name string) (*string, error)
+	SetAppConfig(ctx context.Context, name, config string) error
+
+	// Provider behaviour and server capabilities
+	StrictConsistency() bool
+	DefaultZone() string
+	DefaultTTL() (int64, bool)
+	SupportsFeature(ctx context.Context, feature Feature) (bool, string, string, error)
+	ExperimentEnabled(feature ExperimentalFeature) bool
+}
+
+// Ensure the client satisfies the interface used by the provider
+var _ ClientAPI = &Client{}
@@ -4,13 +4,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// appFolderLockedRetries is how many times an install or update is retried while the app folder
+// is still locked, e.g. by the previous version of the app being unloaded
+const appFolderLockedRetries = 3
+
+// appFolderLockedDelay is the delay before the first retry; later retries wait longer
+var appFolderLockedDelay = 2 * time.Second
+
 // DNSApp represents a single DNS application within an app package
 type DNSApp struct {
 	ClassPath                     string  `json:"classPath"`
@@ -90,14 +101,12 @@ func (c *Client) ListStoreApps(ctx context.Context) ([]StoreApp, error) {
 
 // DownloadAndInstallApp downloads an app zip file from URL and installs it
 func (c *Client) DownloadAndInstallApp(ctx context.Context, name, appURL string) (*App, error) {
-	params := url.Values{}
-	params.Set("name", name)
-	params.Set("url", appURL)
-
-	endpoint := "/api/apps/downloadAndInstall?" + params.Encode()
+	endpoint := NewRequest().Path("/api/apps/downloadAndInstall").Param("name", name).Param("url", appURL).Endpoint()
 
 	var response InstallAppResponse
-	if err := c.DoRequest(ctx, "GET", endpoint, nil, &response); err != nil {
+	if err := c.retryAppFolderLocked(ctx, name, func() error {
+		return c.DoRequest(ctx, "POST", endpoint, nil, &response)
+	}); err != nil {
 		return nil, fmt.Errorf("failed to download and install app: %w", err)
 	}
 
@@ -106,14 +115,12 @@ func (c *Client) DownloadAndInstallApp(ctx context.Context, name, appURL string)
 
 // DownloadAndUpdateApp downloads an app zip file from URL and updates an existing app
 func (c *Client) DownloadAndUpdateApp(ctx context.Context, name, appURL string) (*App, error) {
-	params := url.Values{}
-	params.Set("name", name)
-	params.Set("url", appURL)
-
-	endpoint := "/api/apps/downloadAndUpdate?" + params.Encode()
+	endpoint := NewRequest().Path("/api/apps/downloadAndUpdate").Param("name", name).Param("url", appURL).Endpoint()
 
 	var response InstallAppResponse
-	if err := c.DoRequest(ctx, "GET", endpoint, nil, &response); err != nil {
+	if err := c.retryAppFolderLocked(ctx, name, func() error {
+		return c.DoRequest(ctx, "POST", endpoint, nil, &response)
+	}); err != nil {
 		return nil, fmt.Errorf("failed to download and update app: %w", err)
 	}
 
@@ -122,18 +129,18 @@ func (c *Client) DownloadAndUpdateApp(ctx context.Context, name, appURL string)
 
 // InstallApp installs a DNS application from uploaded zip file
 func (c *Client) InstallApp(ctx context.Context, name string, appData []byte) (*App, error) {
-	params := url.Values{}
-	params.Set("name", name)
-
-	endpoint := "/api/apps/install?" + params.Encode()
+	request := NewRequest().Path("/api/apps/install").Param("name", name)
 
 	// Add token to URL if we have one
-	if c.Token != "" {
-		endpoint += "&token=" + url.QueryEscape(c.Token)
+	if token := c.currentToken(); token != "" {
+		request.Param("token", token)
 	}
+	endpoint := request.Endpoint()
 
 	var response InstallAppResponse
-	if err := c.makeMultipartRequest(ctx, "POST", endpoint, "app.zip", appData, &response); err != nil {
+	if err := c.retryAppFolderLocked(ctx, name, func() error {
+		return c.makeMultipartRequest(ctx, "POST", endpoint, "app.zip", appData, &response)
+	}); err != nil {
 		return nil, fmt.Errorf("failed to install app: %w", err)
 	}
 
@@ -142,18 +149,18 @@ func (c *Client) InstallApp(ctx context.Context, name string, appData []byte) (*
 
 // UpdateApp updates an installed app using a provided app zip file
 func (c *Client) UpdateApp(ctx context.Context, name string, appData []byte) (*App, error) {
-	params := url.Values{}
-	params.Set("name", name)
-
-	endpoint := "/api/apps/update?" + params.Encode()
+	request := NewRequest().Path("/api/apps/update").Param("name", name)
 
 	// Add token to URL if we have one
-	if c.Token != "" {
-		endpoint += "&token=" + url.QueryEscape(c.Token)
+	if token := c.currentToken(); token != "" {
+		request.Param("token", token)
 	}
+	endpoint := request.Endpoint()
 
 	var response InstallAppResponse
-	if err := c.makeMultipartRequest(ctx, "POST", endpoint, "app.zip", appData, &response); err != nil {
+	if err := c.retryAppFolderLocked(ctx, name, func() error {
+		return c.makeMultipartRequest(ctx, "POST", endpoint, "app.zip", appData, &response)
+	}); err != nil {
 		return nil, fmt.Errorf("failed to update app: %w", err)
 	}
 
@@ -162,12 +169,9 @@ func (c *Client) UpdateApp(ctx context.Context, name string, appData []byte) (*A
 
 // UninstallApp uninstalls an app from the DNS server
 func (c *Client) UninstallApp(ctx context.Context, name string) error {
-	params := url.Values{}
-	params.Set("name", name)
-
-	endpoint := "/api/apps/uninstall?" + params.Encode()
+	endpoint := NewRequest().Path("/api/apps/uninstall").Param("name", name).Endpoint()
 
-	if err := c.DoRequest(ctx, "GET", endpoint, nil, nil); err != nil {
+	if err := c.DoRequest(ctx, "POST", endpoint, nil, nil); err != nil {
 		return fmt.Errorf("failed to uninstall app: %w", err)
 	}
 
@@ -176,10 +180,7 @@ func (c *Client) UninstallApp(ctx context.Context, name string) error {
 
 // GetAppConfig retrieves the DNS application config from the dnsApp.config file
 func (c *Client) GetAppConfig(ctx context.Context, name string) (*string, error) {
-	params := url.Values{}
-	params.Set("name", name)
-
-	endpoint := "/api/apps/config/get?" + params.Encode()
+	endpoint := NewRequest().Path("/api/apps/config/get").Param("name", name).Endpoint()
 
 	var response GetAppConfigResponse
 	if err := c.DoRequest(ctx, "GET", endpoint, nil, &response); err != nil {
@@ -191,15 +192,13 @@ func (c *Client) GetAppConfig(ctx context.Context, name string) (*string, error)
 
 // SetAppConfig saves the provided DNS application config into the dnsApp.config file
 func (c *Client) SetAppConfig(ctx context.Context, name, config string) error {
-	params := url.Values{}
-	params.Set("name", name)
-
-	endpoint := "/api/apps/config/set?" + params.Encode()
+	request := NewRequest().Path("/api/apps/config/set").Param("name", name)
 
 	// Add token to URL if we have one
-	if c.Token != "" {
-		endpoint += "&token=" + url.QueryEscape(c.Token)
+	if token := c.currentToken(); token != "" {
+		request.Param("token", token)
 	}
+	endpoint := request.Endpoint()
 
 	// Pretty-format the JSON config with 2-space indentation before sending
 	formattedConfig := config
@@ -224,6 +223,43 @@ func (c *Client) SetAppConfig(ctx context.Context, name, config string) error {
 	return nil
 }
 
+// retryAppFolderLocked runs an app install or update, retrying it while the server reports the
+// app folder as locked
+func (c *Client) retryAppFolderLocked(ctx context.Context, name string, install func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := install()
+		if err == nil || attempt > appFolderLockedRetries || !isAppFolderLocked(err) {
+			return err
+		}
+
+		delay := time.Duration(attempt) * appFolderLockedDelay
+		tflog.Debug(ctx, "App folder locked, retrying after delay", map[string]interface{}{
+			"name":    name,
+			"attempt": attempt,
+			"delay":   delay.String(),
+		})
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		c.operations.countRetry()
+	}
+}
+
+// isAppFolderLocked reports whether an install or update failed because files of the app folder
+// were still in use, which clears once the server has unloaded the previous version of the app
+func isAppFolderLocked(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	message := strings.ToLower(apiErr.Message + " " + apiErr.InnerErrorMessage)
+	return strings.Contains(message, "being used by another process") || strings.Contains(message, "folder is locked")
+}
+
 // makeMultipartRequest performs a multipart form-data HTTP request for file uploads
 func (c *Client) makeMultipartRequest(ctx context.Context, method, endpoint, fileName string, fileData []byte, result interface{}) error {
 	// Prepare request URL
@@ -256,6 +292,9 @@ func (c *Client) makeMultipartRequest(ctx context.Context, method, endpoint, fil
 	// Set content type header
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
+	// Installing or updating an app changes the app list
+	defer c.responses.invalidate(endpoint)
+
 	// Make request using the same pattern as other requests
 	return c.executeRequest(ctx, req, result)
 }
@@ -277,58 +316,35 @@ func (c *Client) makeFormRequest(ctx context.Context, method, endpoint string, f
 	// Set content type header
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
+	// Form requests are mutations, e.g. saving an app config
+	defer c.responses.invalidate(endpoint)
+
 	// Make request using the same pattern as other requests
 	return c.executeRequest(ctx, req, result)
 }
 
 // executeRequest executes an HTTP request and handles the response
 func (c *Client) executeRequest(ctx context.Context, req *http.Request, result interface{}) error {
-	// Make request
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
+	requestCompression(req)
 
-	// Read response
-	responseBody, err := io.ReadAll(resp.Body)
+	// Wait for the request limits before the timeout starts
+	release, err := c.limiter.acquire(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return err
 	}
+	defer release()
 
-	// Check HTTP status
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
-	}
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
 
-	// Parse API response
-	var apiResp APIResponse
-	if err := json.Unmarshal(responseBody, &apiResp); err != nil {
-		return fmt.Errorf("failed to parse API response: %w", err)
+	// Make request
+	c.operations.countRequest()
+	resp, err := c.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
 	}
+	defer resp.Body.Close()
 
-	// Check API status
-	switch apiResp.Status {
-	case "ok":
-		// Success - unmarshal the response into result if provided
-		if result != nil && apiResp.Response != nil {
-			if err := json.Unmarshal(apiResp.Response, result); err != nil {
-				return fmt.Errorf("failed to parse response data: %w", err)
-			}
-		}
-		return nil
-	case "error":
-		errorMsg := apiResp.ErrorMessage
-		if errorMsg == "" {
-			errorMsg = apiResp.Error
-		}
-		if errorMsg == "" {
-			errorMsg = "unknown error"
-		}
-		return fmt.Errorf("API error: %s", errorMsg)
-	case "invalid-token":
-		return fmt.Errorf("invalid-token: session expired or invalid token")
-	default:
-		return fmt.Errorf("unexpected API status: %s", apiResp.Status)
-	}
+	_, err = decodeAPIResponse(resp, result)
+	return err
 }
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestListApps(t *testing.T) {
@@ -175,8 +176,8 @@ func TestDownloadAndInstallApp(t *testing.T) {
 			t.Errorf("Expected path /api/apps/downloadAndInstall, got %s", r.URL.Path)
 		}
 
-		name := r.URL.Query().Get("name")
-		url := r.URL.Query().Get("url")
+		name := requestParams(r).Get("name")
+		url := requestParams(r).Get("url")
 
 		if name != "test-app" {
 			t.Errorf("Expected name 'test-app', got '%s'", name)
@@ -243,8 +244,8 @@ func TestDownloadAndUpdateApp(t *testing.T) {
 			t.Errorf("Expected path /api/apps/downloadAndUpdate, got %s", r.URL.Path)
 		}
 
-		name := r.URL.Query().Get("name")
-		url := r.URL.Query().Get("url")
+		name := requestParams(r).Get("name")
+		url := requestParams(r).Get("url")
 
 		if name != "test-app" {
 			t.Errorf("Expected name 'test-app', got '%s'", name)
@@ -376,6 +377,48 @@ func TestInstallApp(t *testing.T) {
 	}
 }
 
+func TestInstallAppRetriesLockedAppFolder(t *testing.T) {
+	appFolderLockedDelay = time.Millisecond
+
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		if attempts < 3 {
+			_, _ = w.Write([]byte(`{"status": "error", "errorMessage": "The process cannot access the file 'app.dll' because it is being used by another process."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {"installedApp": {"name": "Locked App", "version": "1.0"}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	app, err := client.InstallApp(context.Background(), "locked-app", []byte("mock app archive data"))
+	if err != nil {
+		t.Fatalf("InstallApp failed: %v", err)
+	}
+	if app.Name != "Locked App" || attempts != 3 {
+		t.Errorf("Expected the install to succeed on the third attempt, got %q after %d attempts", app.Name, attempts)
+	}
+
+	// Other errors are not retried
+	attempts = 0
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "error", "errorMessage": "Invalid app package"}`))
+	})
+	if _, err := client.InstallApp(context.Background(), "broken-app", []byte("mock app archive data")); err == nil || attempts != 1 {
+		t.Errorf("Expected a single failed attempt, got %d attempts and error %v", attempts, err)
+	}
+}
+
 func TestUpdateApp(t *testing.T) {
 	// Create mock response
 	mockResponse := APIResponse{
@@ -483,7 +526,7 @@ func TestUninstallApp(t *testing.T) {
 			t.Errorf("Expected path /api/apps/uninstall, got %s", r.URL.Path)
 		}
 
-		name := r.URL.Query().Get("name")
+		name := requestParams(r).Get("name")
 		if name != "test-app" {
 			t.Errorf("Expected name 'test-app', got '%s'", name)
 		}
@@ -525,7 +568,7 @@ func TestGetAppConfig(t *testing.T) {
 			t.Errorf("Expected path /api/apps/config/get, got %s", r.URL.Path)
 		}
 
-		name := r.URL.Query().Get("name")
+		name := requestParams(r).Get("name")
 		if name != "test-app" {
 			t.Errorf("Expected name 'test-app', got '%s'", name)
 		}
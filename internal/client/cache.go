@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CacheRecord is a record held in the DNS cache
+type CacheRecord struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// TTL is the remaining time to live followed by a human readable form, e.g. "283 (4 mins 43 sec)"
+	TTL          string        `json:"ttl"`
+	RData        DNSRecordData `json:"rData"`
+	DnssecStatus string        `json:"dnssecStatus,omitempty"`
+}
+
+// TTLSeconds returns the remaining time to live of the record in seconds, 0 when it cannot be parsed
+func (r CacheRecord) TTLSeconds() int64 {
+	return parseTTLSeconds(r.TTL)
+}
+
+// parseTTLSeconds parses a TTL the server formats for display, e.g. "283 (4 mins 43 sec)", into
+// seconds. It returns 0 when the TTL cannot be parsed.
+func parseTTLSeconds(value string) int64 {
+	seconds, _, _ := strings.Cut(strings.TrimSpace(value), " ")
+	ttl, err := strconv.ParseInt(seconds, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return ttl
+}
+
+// BrowseCacheResponse represents the response from the cache/list API, which browses the cache
+// one domain level at a time
+type BrowseCacheResponse struct {
+	Domain  string        `json:"domain"`
+	Zones   []string      `json:"zones"`
+	Records []CacheRecord `json:"records"`
+}
+
+// BrowseCache lists the cached subdomains and records of a domain. An empty domain lists the
+// top level.
+func (c *Client) BrowseCache(ctx context.Context, domain string) (*BrowseCacheResponse, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/cache/list").Param("domain", domain).Endpoint()
+
+	var response BrowseCacheResponse
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list cached zones: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DeleteCachedZone removes a domain and its subdomains from the DNS cache
+func (c *Client) DeleteCachedZone(ctx context.Context, domain string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/cache/delete").Param("domain", domain).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete cached zone %s: %w", domain, err)
+	}
+
+	return nil
+}
+
+// FlushCache clears the whole DNS cache, so the server resolves every name again
+func (c *Client) FlushCache(ctx context.Context) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/cache/flush").Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to flush DNS cache: %w", err)
+	}
+
+	return nil
+}
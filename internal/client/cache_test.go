@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	var deleted string
+	var flushed bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/api/cache/list":
+			if domain := requestParams(r).Get("domain"); domain != "google.com" {
+				t.Errorf("Expected domain google.com, got %q", domain)
+			}
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"domain": "google.com", "zones": ["www.google.com"], "records": [
+				{"name": "google.com", "type": "A", "ttl": "283 (4 mins 43 sec)", "rData": {"ipAddress": "216.58.199.174"}, "dnssecStatus": "Insecure"}
+			]}}`))
+		case "/api/cache/delete":
+			deleted = requestParams(r).Get("domain")
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		case "/api/cache/flush":
+			flushed = true
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	response, err := client.BrowseCache(context.Background(), "google.com")
+	if err != nil {
+		t.Fatalf("BrowseCache failed: %v", err)
+	}
+	if len(response.Zones) != 1 || response.Zones[0] != "www.google.com" {
+		t.Errorf("Expected zone www.google.com, got %v", response.Zones)
+	}
+	if len(response.Records) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(response.Records))
+	}
+	if record := response.Records[0]; record.TTLSeconds() != 283 || record.RData.IPAddress != "216.58.199.174" {
+		t.Errorf("Unexpected record %+v", record)
+	}
+
+	if err := client.DeleteCachedZone(context.Background(), "example.com"); err != nil || deleted != "example.com" {
+		t.Errorf("DeleteCachedZone failed: %v (deleted %q)", err, deleted)
+	}
+	if err := client.FlushCache(context.Background()); err != nil || !flushed {
+		t.Errorf("FlushCache failed: %v (flushed %v)", err, flushed)
+	}
+}
+
+func TestCacheRecordTTLSeconds(t *testing.T) {
+	tests := map[string]int64{
+		"283 (4 mins 43 sec)": 283,
+		"60":                  60,
+		"":                    0,
+		"soon":                0,
+	}
+	for ttl, expected := range tests {
+		if got := (CacheRecord{TTL: ttl}).TTLSeconds(); got != expected {
+			t.Errorf("TTLSeconds(%q) = %d, expected %d", ttl, got, expected)
+		}
+	}
+}
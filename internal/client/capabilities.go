@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ServerInfo represents the server details returned with a login or session
+type ServerInfo struct {
+	Version          string `json:"version"`
+	DNSServerDomain  string `json:"dnsServerDomain"`
+	DefaultRecordTTL int    `json:"defaultRecordTtl"`
+}
+
+// SessionResponse represents the user/session/get API response
+type SessionResponse struct {
+	Username string     `json:"username"`
+	Info     ServerInfo `json:"info"`
+}
+
+// Feature identifies a server capability that is only available from a given version
+type Feature string
+
+const (
+	// FeatureQUIC covers DNS-over-QUIC forwarding and zone transfers over QUIC
+	FeatureQUIC Feature = "DNS-over-QUIC"
+	// FeatureCatalogZones covers Catalog and Secondary Catalog zones and zone catalog membership
+	FeatureCatalogZones Feature = "catalog zones"
+	// FeatureSecondaryForwarderZones covers the Secondary Forwarder zone type
+	FeatureSecondaryForwarderZones Feature = "secondary forwarder zones"
+	// FeatureSVCB covers SVCB and HTTPS service binding records
+	FeatureSVCB Feature = "SVCB and HTTPS records"
+	// FeatureSoaSerialDateScheme covers the date based SOA serial scheme of zones
+	FeatureSoaSerialDateScheme Feature = "SOA serial date scheme"
+	// FeatureZoneValidation covers ZONEMD validation of Secondary zones
+	FeatureZoneValidation Feature = "zone validation"
+)
+
+// featureMinVersions maps each feature to the first server version supporting it
+var featureMinVersions = map[Feature]string{
+	FeatureQUIC:                    "11.0",
+	FeatureCatalogZones:            "12.0",
+	FeatureSecondaryForwarderZones: "12.0",
+	FeatureSVCB:                    "11.0",
+	FeatureSoaSerialDateScheme:     "11.0",
+	FeatureZoneValidation:          "11.0",
+}
+
+// ServerVersion returns the version of the connected server. The version is captured on login
+// and otherwise looked up once from the current session.
+func (c *Client) ServerVersion(ctx context.Context) (string, error) {
+	if version := c.cachedServerVersion(); version != "" {
+		return version, nil
+	}
+
+	if err := c.Authenticate(ctx); err != nil {
+		return "", err
+	}
+
+	// Logging in may already have captured the version
+	if version := c.cachedServerVersion(); version != "" {
+		return version, nil
+	}
+
+	endpoint := NewRequest().Path("/api/user/session/get").Param("token", c.currentToken()).Endpoint()
+
+	// The session endpoint returns data directly, not wrapped in APIResponse
+	var response SessionResponse
+	if err := c.makeLoginRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return "", fmt.Errorf("failed to get session info: %w", err)
+	}
+
+	if response.Info.Version == "" {
+		return "", fmt.Errorf("server did not report its version")
+	}
+
+	c.serverInfoMu.Lock()
+	c.serverVersion = response.Info.Version
+	c.serverInfoMu.Unlock()
+
+	return response.Info.Version, nil
+}
+
+// cachedServerVersion returns the server version detected so far, if any
+func (c *Client) cachedServerVersion() string {
+	c.serverInfoMu.Lock()
+	defer c.serverInfoMu.Unlock()
+	return c.serverVersion
+}
+
+// SupportsFeature reports whether the connected server supports the feature, along with the
+// detected server version and the minimum version required
+func (c *Client) SupportsFeature(ctx context.Context, feature Feature) (bool, string, string, error) {
+	minVersion, ok := featureMinVersions[feature]
+	if !ok {
+		return true, "", "", nil
+	}
+
+	// A response holding a field of the feature settles it, whatever version the server reports
+	if c.featureObserved(feature) {
+		return true, c.cachedServerVersion(), minVersion, nil
+	}
+
+	version, err := c.ServerVersion(ctx)
+	if err != nil {
+		return false, "", minVersion, err
+	}
+
+	return compareVersions(version, minVersion) >= 0, version, minVersion, nil
+}
+
+// compareVersions compares dotted version strings numerically, returning -1, 0 or 1.
+// Missing or non-numeric components are treated as zero.
+func compareVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aValue, bValue int
+		if i < len(aParts) {
+			aValue, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bValue, _ = strconv.Atoi(bParts[i])
+		}
+
+		if aValue < bValue {
+			return -1
+		}
+		if aValue > bValue {
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"11.0", "11.0", 0},
+		{"11.5", "11.0", 1},
+		{"10.0.1", "11.0", -1},
+		{"13.6", "12.0", 1},
+		{"12", "12.0", 0},
+		{"v12.1", "12.0", 1},
+	}
+
+	for _, tt := range tests {
+		if got := compareVersions(tt.a, tt.b); got != tt.expected {
+			t.Errorf("compareVersions(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSupportsFeature(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/api/user/session/get" {
+			t.Errorf("Expected path /api/user/session/get, got %s", r.URL.Path)
+		}
+		if requestParams(r).Get("token") != "test-token" {
+			t.Errorf("Expected token test-token, got %s", requestParams(r).Get("token"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"username": "admin", "info": {"version": "11.5", "defaultRecordTtl": 3600}, "status": "ok"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	supported, version, minVersion, err := client.SupportsFeature(context.Background(), FeatureQUIC)
+	if err != nil {
+		t.Fatalf("SupportsFeature failed: %v", err)
+	}
+	if !supported || version != "11.5" || minVersion != "11.0" {
+		t.Errorf("Expected QUIC to be supported on 11.5, got supported=%v version=%s min=%s", supported, version, minVersion)
+	}
+
+	supported, _, minVersion, err = client.SupportsFeature(context.Background(), FeatureCatalogZones)
+	if err != nil {
+		t.Fatalf("SupportsFeature failed: %v", err)
+	}
+	if supported || minVersion != "12.0" {
+		t.Errorf("Expected catalog zones to be unsupported on 11.5, got supported=%v min=%s", supported, minVersion)
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected the server version to be fetched once, got %d requests", requests)
+	}
+}
@@ -8,21 +8,75 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// Session lifetime of tokens obtained by logging in. The server expires sessions after 30 minutes
+// by default; the client logs in again a few minutes before that so long applies keep working.
+const (
+	defaultSessionLifetime = 30 * time.Minute
+	sessionRefreshMargin   = 5 * time.Minute
+)
+
 // Client represents the Technitium DNS API client
 type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
-	Token      string
-	username   string
-	password   string
-	retries    int
+	// Token is the API token or the session token of the last login, guarded by authMu once the
+	// client is in use
+	Token    string
+	username string
+	password string
+	retries  int
+	// retryMinDelay and retryMaxDelay bound the backoff between retries, the defaults when zero
+	retryMinDelay time.Duration
+	retryMaxDelay time.Duration
+	// timeout bounds each API request unless the request context overrides it
+	timeout time.Duration
+	// noReloginOnAuthFailure makes requests rejected with an invalid token fail instead of logging in again
+	noReloginOnAuthFailure bool
+	// limiter throttles the requests of all resources, nil when requests are not limited
+	limiter *requestLimiter
+	// responses caches the payloads of repeated read-only calls, nil when caching is disabled
+	responses *responseCache
+	// batchReads reads the records of single domains from a cached listing of their whole zone
+	batchReads bool
+
+	// authMu serializes logins and guards Token and sessionExpires, so concurrent requests
+	// holding an expired session log in once
+	authMu sync.Mutex
+	// sessionLifetime is how long sessions obtained by logging in stay valid
+	sessionLifetime time.Duration
+	// sessionExpires is when the session of the last login expires, zero for API tokens
+	sessionExpires time.Time
+
+	// defaultComment is appended to the comments of every record mutation
+	defaultComment string
+	// strictConsistency makes resources fail when the server stores different values than planned
+	strictConsistency bool
+	// defaultZone and defaultTTL are used by records that leave their zone or TTL unset
+	defaultZone string
+	defaultTTL  *int64
+	// experimentalFeatures holds the experimental feature flags enabled in the provider configuration
+	experimentalFeatures map[ExperimentalFeature]bool
+	// operations tallies changes and API usage for the operations report, nil when it is disabled
+	operations *OperationsReport
+
+	// serverVersion caches the detected server version, guarded by serverInfoMu
+	serverVersion string
+	// observedFeatures holds the features whose optional fields appeared in a response, guarded by serverInfoMu
+	observedFeatures map[Feature]bool
+	serverInfoMu     sync.Mutex
+
+	// soaCache caches zone SOA records for plan-time validation, guarded by soaCacheMu
+	soaCache   map[string]DNSRecord
+	soaCacheMu sync.Mutex
 }
 
 // Config holds the configuration for creating a new client
@@ -34,6 +88,48 @@ type Config struct {
 	TimeoutSeconds     int64
 	RetryAttempts      int64
 	InsecureSkipVerify bool
+	DefaultComment     string
+	StrictConsistency  bool
+	DisableHTTP2       bool
+
+	// DefaultZone and DefaultTTL are used by records that leave their zone or TTL unset
+	DefaultZone string
+	DefaultTTL  *int64
+
+	// DisableAuthRetry fails requests rejected with an invalid token instead of logging in again and retrying
+	DisableAuthRetry bool
+	// RetryMinDelay is the delay before the first retry and RetryMaxDelay the longest delay between
+	// retries, including delays requested by the server. Zero uses 1 and 30 seconds.
+	RetryMinDelay time.Duration
+	RetryMaxDelay time.Duration
+
+	// FailFast disables all retries, including re-login, so the first failure is reported immediately
+	FailFast bool
+
+	// MaxConcurrentRequests limits the API requests in flight and RequestsPerSecond the requests
+	// started per second, shared by all resources. Zero leaves them unlimited.
+	MaxConcurrentRequests int64
+	RequestsPerSecond     int64
+
+	// ResponseCacheTTL is how long the payloads of zone options, records and app lists are cached
+	// for repeated reads. Mutations invalidate them early. Zero disables the cache.
+	ResponseCacheTTL time.Duration
+	// BatchReads reads the records of a domain from a single listing of its zone shared by all
+	// reads of the zone, which requires the response cache
+	BatchReads bool
+
+	// HostAliases maps hosts (host or host:port) of the API URL to the address actually dialed
+	HostAliases map[string]string
+
+	// ExperimentalFeatures lists the opt-in flags enabling unstable resources
+	ExperimentalFeatures []string
+
+	// OperationsReport enables tallying changes and API usage for the operations report
+	OperationsReport bool
+
+	// SessionLifetime is how long the server keeps sessions obtained by logging in, 30 minutes
+	// when zero. Sessions are renewed shortly before they expire.
+	SessionLifetime time.Duration
 }
 
 // APIResponse represents the standard API response format
@@ -47,9 +143,10 @@ type APIResponse struct {
 
 // LoginResponse represents the login API response
 type LoginResponse struct {
-	DisplayName string `json:"displayName"`
-	Username    string `json:"username"`
-	Token       string `json:"token"`
+	DisplayName string      `json:"displayName"`
+	Username    string      `json:"username"`
+	Token       string      `json:"token"`
+	Info        *ServerInfo `json:"info,omitempty"`
 }
 
 // NewClient creates a new Technitium DNS API client
@@ -66,58 +163,155 @@ func NewClient(config Config) (*Client, error) {
 	if config.Host == "" {
 		return nil, fmt.Errorf("host is required")
 	}
+	if config.MaxConcurrentRequests < 0 || config.RequestsPerSecond < 0 {
+		return nil, fmt.Errorf("request limits must not be negative")
+	}
+	if config.BatchReads && config.ResponseCacheTTL <= 0 {
+		return nil, fmt.Errorf("batched reads require the response cache")
+	}
+	if config.RetryMinDelay < 0 || config.RetryMaxDelay < 0 {
+		return nil, fmt.Errorf("retry delays must not be negative")
+	}
+	if config.RetryMinDelay > 0 && config.RetryMaxDelay > 0 && config.RetryMaxDelay < config.RetryMinDelay {
+		return nil, fmt.Errorf("the maximum retry delay must not be shorter than the minimum retry delay")
+	}
+
+	baseURL, err := normalizeHost(config.Host)
+	if err != nil {
+		return nil, err
+	}
 
 	// Ensure we have authentication
 	if config.Token == "" && (config.Username == "" || config.Password == "") {
 		return nil, fmt.Errorf("either token or username/password must be provided")
 	}
 
-	// Create HTTP client
+	// Create HTTP client. A custom transport disables HTTP/2 unless explicitly requested, so
+	// attempt it by default for ingresses that only speak HTTP/2 and allow opting out for buggy proxies.
 	transport := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			//nolint:gosec // G402: InsecureSkipVerify is an intentional user-configurable option for development/testing
 			InsecureSkipVerify: config.InsecureSkipVerify,
+			// Resume TLS sessions to avoid a full handshake for every new connection
+			ClientSessionCache: tls.NewLRUClientSessionCache(0),
 		},
+		ForceAttemptHTTP2: !config.DisableHTTP2,
+	}
+	if config.DisableHTTP2 {
+		// A non-nil, empty map prevents the transport from negotiating HTTP/2 via ALPN
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
 	}
 
+	if len(config.HostAliases) > 0 {
+		dial, err := hostAliasDialer(config.HostAliases)
+		if err != nil {
+			return nil, err
+		}
+		transport.DialContext = dial
+	}
+
+	// The timeout is applied per request rather than on the HTTP client, so callers can override it
 	httpClient := &http.Client{
-		Timeout:   time.Duration(config.TimeoutSeconds) * time.Second,
 		Transport: transport,
 	}
 
+	if config.FailFast {
+		config.RetryAttempts = 0
+	}
+
 	client := &Client{
-		BaseURL:    strings.TrimSuffix(config.Host, "/"),
+		BaseURL:    baseURL,
 		HTTPClient: httpClient,
 		Token:      config.Token,
 		username:   config.Username,
 		password:   config.Password,
 		retries:    int(config.RetryAttempts),
+
+		retryMinDelay: config.RetryMinDelay,
+		retryMaxDelay: config.RetryMaxDelay,
+		timeout:       time.Duration(config.TimeoutSeconds) * time.Second,
+
+		noReloginOnAuthFailure: config.DisableAuthRetry || config.FailFast,
+		sessionLifetime:        config.SessionLifetime,
+		limiter:                newRequestLimiter(config.MaxConcurrentRequests, config.RequestsPerSecond),
+		responses:              newResponseCache(config.ResponseCacheTTL),
+		batchReads:             config.BatchReads,
+
+		defaultComment:    config.DefaultComment,
+		strictConsistency: config.StrictConsistency,
+		defaultZone:       config.DefaultZone,
+		defaultTTL:        config.DefaultTTL,
+	}
+
+	if len(config.ExperimentalFeatures) > 0 {
+		client.experimentalFeatures = make(map[ExperimentalFeature]bool, len(config.ExperimentalFeatures))
+		for _, feature := range config.ExperimentalFeatures {
+			client.experimentalFeatures[ExperimentalFeature(feature)] = true
+		}
+	}
+
+	if config.OperationsReport {
+		client.operations = NewOperationsReport("records", "zones")
 	}
 
 	return client, nil
 }
 
+// OperationsReport returns the report tallying this run's changes and API usage,
+// or nil when the operations report is disabled
+func (c *Client) OperationsReport() *OperationsReport {
+	return c.operations
+}
+
+// StrictConsistency reports whether resources should fail when the server
+// normalizes values differently than planned instead of adopting them
+func (c *Client) StrictConsistency() bool {
+	return c.strictConsistency
+}
+
+// DefaultZone returns the zone of records that do not set one, or an empty string when the
+// provider configures no default zone
+func (c *Client) DefaultZone() string {
+	return c.defaultZone
+}
+
+// DefaultTTL returns the TTL of records that do not set one, and false when the provider
+// configures no default TTL
+func (c *Client) DefaultTTL() (int64, bool) {
+	if c.defaultTTL == nil {
+		return 0, false
+	}
+	return *c.defaultTTL, true
+}
+
 // Login authenticates with the Technitium DNS server using username/password
 func (c *Client) Login(ctx context.Context) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.login(ctx)
+}
+
+// login performs a login, the caller must hold authMu
+func (c *Client) login(ctx context.Context) error {
 	if c.username == "" || c.password == "" {
 		return fmt.Errorf("username and password are required for login")
 	}
 
-	params := url.Values{}
-	params.Set("user", c.username)
-	params.Set("pass", c.password)
-	params.Set("includeInfo", "true")
-
-	endpoint := "/api/user/login?" + params.Encode()
+	request := NewRequest().Path("/api/user/login").
+		Param("user", c.username).
+		Param("pass", c.password).
+		BoolParam("includeInfo", true)
+	endpoint := request.Endpoint()
 
+	// The endpoint holds the password, only log its path
 	tflog.Debug(ctx, "Attempting login to", map[string]interface{}{
-		"endpoint": endpoint,
+		"endpoint": request.path,
 		"username": c.username,
 	})
 
 	// Login endpoint returns data directly, not wrapped in APIResponse
 	var response LoginResponse
-	if err := c.makeLoginRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+	if err := c.makeLoginRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
 
@@ -129,6 +323,16 @@ func (c *Client) Login(ctx context.Context) error {
 	})
 
 	c.Token = response.Token
+	lifetime := c.sessionLifetime
+	if lifetime <= 0 {
+		lifetime = defaultSessionLifetime
+	}
+	c.sessionExpires = time.Now().Add(lifetime)
+	if response.Info != nil && response.Info.Version != "" {
+		c.serverInfoMu.Lock()
+		c.serverVersion = response.Info.Version
+		c.serverInfoMu.Unlock()
+	}
 	tflog.Debug(ctx, "Successfully authenticated with Technitium DNS server", map[string]interface{}{
 		"username":     response.Username,
 		"displayName":  response.DisplayName,
@@ -139,50 +343,106 @@ func (c *Client) Login(ctx context.Context) error {
 	return nil
 }
 
-// doRequest performs an HTTP request with retry logic
+// doRequest performs an HTTP request, answering repeated read-only calls from the response cache
+// and invalidating the cached responses a mutation may change
 func (c *Client) doRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
-	var lastErr error
-
-	for attempt := 0; attempt <= c.retries; attempt++ {
-		if attempt > 0 {
-			// Exponential backoff
-			backoff := time.Duration(attempt) * time.Second
-			tflog.Debug(ctx, "Retrying request after backoff", map[string]interface{}{
-				"attempt":  attempt,
-				"backoff":  backoff.String(),
-				"endpoint": endpoint,
-			})
+	if method != http.MethodGet {
+		// Whether it succeeded or not, the mutation may have changed cached responses
+		defer c.responses.invalidate(endpoint)
+	} else if body == nil && c.responses.caches(endpoint, result) {
+		return c.doCachedRequest(ctx, endpoint, result)
+	}
+	return c.retryRequest(ctx, method, endpoint, body, result)
+}
 
-			select {
-			case <-time.After(backoff):
-			case <-ctx.Done():
-				return ctx.Err()
-			}
-		}
+// doCachedRequest performs a read-only call whose payload is cached
+func (c *Client) doCachedRequest(ctx context.Context, endpoint string, result interface{}) error {
+	payload, cached, err := c.responses.fetch(ctx, endpoint, func() (json.RawMessage, error) {
+		var raw rawPayload
+		err := c.retryRequest(ctx, http.MethodGet, endpoint, nil, &raw)
+		return json.RawMessage(raw), err
+	})
+	if err != nil {
+		return err
+	}
+	if cached {
+		tflog.Debug(ctx, "Using cached API response", map[string]interface{}{
+			"endpoint": endpoint,
+		})
+	}
+
+	if len(payload) == 0 {
+		return nil
+	}
+	if err := decodePayload(json.NewDecoder(bytes.NewReader(payload)), result); err != nil {
+		return fmt.Errorf("failed to parse response data: %w", err)
+	}
+	return nil
+}
 
+// retryRequest performs an HTTP request, retrying transient failures with exponential backoff and
+// logging in again when the server rejects the session. Mutations are only retried when they
+// cannot have reached the server.
+func (c *Client) retryRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
+	retryable := isRetryable
+	if method != http.MethodGet {
+		retryable = isRetryableMutation
+	}
+
+	for attempt := 0; ; attempt++ {
+		token := c.currentToken()
 		err := c.makeRequest(ctx, method, endpoint, body, result)
 		if err == nil {
 			return nil
 		}
 
-		lastErr = err
 		tflog.Debug(ctx, "Request failed", map[string]interface{}{
 			"attempt":  attempt + 1,
 			"error":    err.Error(),
 			"endpoint": endpoint,
 		})
 
-		// Don't retry on certain errors
-		if strings.Contains(err.Error(), "invalid-token") && c.username != "" && c.password != "" {
-			// Try to re-authenticate
-			if loginErr := c.Login(ctx); loginErr != nil {
+		invalidToken := strings.Contains(err.Error(), "invalid-token")
+		if invalidToken && c.noReloginOnAuthFailure {
+			return fmt.Errorf("%w (re-login on authentication failures is disabled)", err)
+		}
+		if attempt >= c.retries || ctx.Err() != nil {
+			return err
+		}
+
+		if invalidToken {
+			if c.username == "" || c.password == "" {
+				return err
+			}
+			// Try to re-authenticate, unless a concurrent request already did, and retry right away
+			if loginErr := c.relogin(ctx, token); loginErr != nil {
 				return fmt.Errorf("authentication failed: %w", loginErr)
 			}
+			c.operations.countRetry()
 			continue
 		}
-	}
 
-	return lastErr
+		// Don't retry errors that would fail again, e.g. validation errors reported by the API
+		if !retryable(err) {
+			return err
+		}
+
+		backoff := c.retryDelay(attempt+1, err)
+		tflog.Debug(ctx, "Retrying request after backoff", map[string]interface{}{
+			"attempt":  attempt + 1,
+			"backoff":  backoff.String(),
+			"endpoint": endpoint,
+		})
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		c.operations.countRetry()
+	}
 }
 
 // makeLoginRequest performs a single HTTP request for login (which returns data directly)
@@ -192,13 +452,31 @@ func (c *Client) makeLoginRequest(ctx context.Context, method, endpoint string,
 
 	// Prepare request body
 	var requestBody io.Reader
+	contentType := ""
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		requestBody = bytes.NewBuffer(jsonBody)
+		contentType = "application/json"
+	} else if method == http.MethodPost {
+		// Send the credentials as form data, so they do not show up in access logs
+		path, form := formRequestBody(endpoint, "")
+		requestURL = c.BaseURL + path
+		requestBody = form
+		contentType = formContentType
+	}
+
+	// Wait for the request limits before the timeout starts
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return err
 	}
+	defer release()
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
 
 	// Create request
 	req, err := http.NewRequestWithContext(ctx, method, requestURL, requestBody)
@@ -207,8 +485,8 @@ func (c *Client) makeLoginRequest(ctx context.Context, method, endpoint string,
 	}
 
 	// Set headers
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	// Log request
@@ -218,6 +496,7 @@ func (c *Client) makeLoginRequest(ctx context.Context, method, endpoint string,
 	})
 
 	// Make request
+	c.operations.countRequest()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
@@ -239,7 +518,7 @@ func (c *Client) makeLoginRequest(ctx context.Context, method, endpoint string,
 
 	// Check HTTP status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
+		return newHTTPStatusError(resp, responseBody)
 	}
 
 	// Login endpoint returns data directly, not wrapped in APIResponse
@@ -252,40 +531,112 @@ func (c *Client) makeLoginRequest(ctx context.Context, method, endpoint string,
 	return nil
 }
 
+// withConnectionTrace attaches an HTTP trace to the context that logs connection and TLS
+// session reuse, which helps diagnosing proxies that break keep-alive or HTTP/2
+func withConnectionTrace(ctx context.Context) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			tflog.Debug(ctx, "Obtained API connection", map[string]interface{}{
+				"reused":    info.Reused,
+				"was_idle":  info.WasIdle,
+				"idle_time": info.IdleTime.String(),
+			})
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			fields := map[string]interface{}{
+				"did_resume":          state.DidResume,
+				"negotiated_protocol": state.NegotiatedProtocol,
+			}
+			if err != nil {
+				fields["error"] = err.Error()
+			}
+			tflog.Debug(ctx, "Completed TLS handshake", fields)
+		},
+	})
+}
+
+// formContentType is the content type of POST requests sending their parameters as form data
+const formContentType = "application/x-www-form-urlencoded"
+
+// textContentType is the content type of POST requests uploading a textBody
+const textContentType = "text/plain"
+
+// textBody is a request body sent as plain text instead of JSON, e.g. an imported zone file
+type textBody string
+
+// formRequestBody splits an endpoint into its path and a form-encoded body holding its query
+// parameters and, unless the endpoint sets one, the token
+func formRequestBody(endpoint, token string) (string, io.Reader) {
+	path, query, _ := strings.Cut(endpoint, "?")
+	// Endpoints built by Request always parse
+	form, _ := url.ParseQuery(query)
+	if token != "" && !form.Has("token") {
+		form.Set("token", token)
+	}
+	return path, strings.NewReader(form.Encode())
+}
+
 // makeRequest performs a single HTTP request
 func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
 	// Prepare request URL
 	requestURL := c.BaseURL + endpoint
 
-	// Add token to URL if we have one and it's not already in the endpoint
-	if c.Token != "" && !strings.Contains(endpoint, "token=") {
-		separator := "?"
-		if strings.Contains(endpoint, "?") {
-			separator = "&"
-		}
-		requestURL += separator + "token=" + url.QueryEscape(c.Token)
-	}
-
 	// Prepare request body
 	var requestBody io.Reader
-	if body != nil {
+	contentType := ""
+	switch b := body.(type) {
+	case textBody:
+		// Files uploaded as plain text, the parameters and the token stay in the query
+		requestBody = strings.NewReader(string(b))
+		contentType = textContentType
+	case nil:
+		if method == http.MethodPost {
+			// Mutations send their parameters and the token as form data, so passwords, keys and
+			// record data do not show up in the access logs of proxies and the server
+			path, form := formRequestBody(endpoint, c.currentToken())
+			requestURL = c.BaseURL + path
+			requestBody = form
+			contentType = formContentType
+		}
+	default:
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		requestBody = bytes.NewBuffer(jsonBody)
+		contentType = "application/json"
+	}
+
+	// Add token to URL if we have one and it's not already in the endpoint or the form
+	if token := c.currentToken(); token != "" && contentType != formContentType && !strings.Contains(endpoint, "token=") {
+		separator := "?"
+		if strings.Contains(endpoint, "?") {
+			separator = "&"
+		}
+		requestURL += separator + "token=" + url.QueryEscape(token)
 	}
 
+	// Wait for the request limits before the timeout starts
+	release, err := c.limiter.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, requestBody)
+	req, err := http.NewRequestWithContext(withConnectionTrace(ctx), method, requestURL, requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
+	requestCompression(req)
 
 	// Log request
 	tflog.Debug(ctx, "Making API request", map[string]interface{}{
@@ -294,74 +645,77 @@ func (c *Client) makeRequest(ctx context.Context, method, endpoint string, body
 	})
 
 	// Make request
+	c.operations.countRequest()
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	responseBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
-	}
+	// Decode the response while reading it, so large zones are not buffered in full
+	responseLength, err := decodeAPIResponse(resp, result)
 
 	// Log response
 	tflog.Debug(ctx, "Received API response", map[string]interface{}{
-		"status_code":     resp.StatusCode,
-		"response_length": len(responseBody),
+		"status_code":      resp.StatusCode,
+		"response_length":  responseLength,
+		"content_encoding": resp.Header.Get("Content-Encoding"),
+		"protocol":         resp.Proto,
 	})
 
-	// Check HTTP status
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(responseBody))
-	}
+	return err
+}
 
-	// Parse API response
-	var apiResp APIResponse
-	if err := json.Unmarshal(responseBody, &apiResp); err != nil {
-		return fmt.Errorf("failed to parse API response: %w", err)
-	}
+// Authenticate ensures the client is authenticated, logging in again when the session is about to expire
+func (c *Client) Authenticate(ctx context.Context) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
 
-	// Check API status
-	switch apiResp.Status {
-	case "ok":
-		// Success - unmarshal the response into result if provided
-		if result != nil && apiResp.Response != nil {
-			if err := json.Unmarshal(apiResp.Response, result); err != nil {
-				return fmt.Errorf("failed to parse response data: %w", err)
-			}
-		}
+	// If we already have a token that stays valid, we're good
+	if c.Token != "" && !c.sessionExpiring() {
 		return nil
-	case "error":
-		errorMsg := apiResp.ErrorMessage
-		if errorMsg == "" {
-			errorMsg = apiResp.Error
-		}
-		if errorMsg == "" {
-			errorMsg = "unknown error"
+	}
+
+	// If we have username/password, login
+	if c.username != "" && c.password != "" {
+		if c.Token != "" {
+			tflog.Debug(ctx, "Session is about to expire, logging in again", map[string]interface{}{
+				"expires": c.sessionExpires.Format(time.RFC3339),
+			})
 		}
-		return fmt.Errorf("API error: %s", errorMsg)
-	case "invalid-token":
-		return fmt.Errorf("invalid-token: session expired or invalid token")
-	default:
-		return fmt.Errorf("unexpected API status: %s", apiResp.Status)
+		return c.login(ctx)
 	}
-}
 
-// Authenticate ensures the client is authenticated
-func (c *Client) Authenticate(ctx context.Context) error {
-	// If we already have a token, we're good
 	if c.Token != "" {
 		return nil
 	}
 
-	// If we have username/password, login
-	if c.username != "" && c.password != "" {
-		return c.Login(ctx)
+	return fmt.Errorf("no authentication method available")
+}
+
+// sessionExpiring reports whether the session of the last login expires within the refresh
+// margin. API tokens do not expire. The caller must hold authMu.
+func (c *Client) sessionExpiring() bool {
+	return !c.sessionExpires.IsZero() && time.Until(c.sessionExpires) < sessionRefreshMargin
+}
+
+// relogin logs in again after the server rejected staleToken. Concurrent requests rejected with
+// the same token share a single login: once one of them replaced the token, the others reuse it.
+func (c *Client) relogin(ctx context.Context, staleToken string) error {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+
+	if c.Token != staleToken {
+		return nil
 	}
+	return c.login(ctx)
+}
 
-	return fmt.Errorf("no authentication method available")
+// currentToken returns the token to authenticate requests with
+func (c *Client) currentToken() string {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.Token
 }
 
 // DoRequest performs an HTTP request with authentication and retry logic
@@ -1,10 +1,25 @@
 package client
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
+// requestParams returns the parameters of a request, sent in the query string or as form data
+func requestParams(r *http.Request) url.Values {
+	_ = r.ParseForm()
+	return r.Form
+}
+
 // This is a simple test to verify the client authentication works
 // This test will be skipped unless TF_ACC is set
 func TestClientAuthentication(t *testing.T) {
@@ -35,3 +50,306 @@ func TestClientAuthentication(t *testing.T) {
 	// Don't actually try to authenticate since we don't have a running server
 	// This test just verifies the client creation works
 }
+
+func TestNewClientHTTP2(t *testing.T) {
+	config := Config{
+		Host:     "https://localhost:53443",
+		Username: "admin",
+		Password: "admin",
+	}
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	transport, ok := client.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected *http.Transport, got %T", client.HTTPClient.Transport)
+	}
+	if !transport.ForceAttemptHTTP2 {
+		t.Error("HTTP/2 should be attempted by default")
+	}
+	if transport.TLSClientConfig.ClientSessionCache == nil {
+		t.Error("TLS session cache should be configured")
+	}
+
+	config.DisableHTTP2 = true
+	client, err = NewClient(config)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	transport = client.HTTPClient.Transport.(*http.Transport)
+	if transport.ForceAttemptHTTP2 {
+		t.Error("HTTP/2 should not be attempted when disabled")
+	}
+	if transport.TLSNextProto == nil {
+		t.Error("TLSNextProto should be set to disable HTTP/2 negotiation")
+	}
+}
+
+func TestResolveHostAlias(t *testing.T) {
+	aliases := map[string]string{}
+	for from, to := range map[string]string{
+		"dns.example.com":      "127.0.0.1",
+		"api.example.com:443":  "10.0.0.5:5380",
+		"sock.example.com":     "unix:///run/technitium.sock",
+		"ipv6.example.com":     "::1",
+		"other.example.com:80": "10.0.0.6",
+		"[fd00::1]:53443":      "[fd00::2]:5380",
+		"[fd00::3]":            "[fd00::4]",
+	} {
+		aliases[aliasKey(from)] = to
+	}
+
+	tests := []struct {
+		addr     string
+		expected string
+	}{
+		{"dns.example.com:5380", "127.0.0.1:5380"},
+		{"DNS.example.com:5380", "127.0.0.1:5380"},
+		{"api.example.com:443", "10.0.0.5:5380"},
+		{"api.example.com:8443", "api.example.com:8443"},
+		{"sock.example.com:443", "unix:///run/technitium.sock"},
+		{"ipv6.example.com:5380", "[::1]:5380"},
+		{"other.example.com:80", "10.0.0.6:80"},
+		{"unrelated.example.com:5380", "unrelated.example.com:5380"},
+		{"[fd00::1]:53443", "[fd00::2]:5380"},
+		{"[FD00::3]:53443", "[fd00::4]:53443"},
+	}
+
+	for _, tt := range tests {
+		if got := resolveHostAlias(aliases, tt.addr); got != tt.expected {
+			t.Errorf("resolveHostAlias(%q) = %q, expected %q", tt.addr, got, tt.expected)
+		}
+	}
+}
+
+func TestNewClientHostAliases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "technitium.internal:5380" {
+			t.Errorf("Expected Host header technitium.internal:5380, got %s", r.Host)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(APIResponse{Status: "ok", Response: json.RawMessage(`{"zones": []}`)})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Host:        "http://technitium.internal:5380",
+		Token:       "test-token",
+		HostAliases: map[string]string{"technitium.internal": strings.TrimPrefix(server.URL, "http://")},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if _, err := client.ListZones(context.Background()); err != nil {
+		t.Fatalf("ListZones through host alias failed: %v", err)
+	}
+
+	if _, err := NewClient(Config{
+		Host:        "http://technitium.internal:5380",
+		Token:       "test-token",
+		HostAliases: map[string]string{"technitium.internal": "unix://"},
+	}); err == nil {
+		t.Error("Expected an error for an empty unix socket path")
+	}
+}
+
+func TestAuthFailureRetry(t *testing.T) {
+	var logins int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/user/login" {
+			logins++
+			_, _ = w.Write([]byte(`{"status": "ok", "token": "fresh-token"}`))
+			return
+		}
+		if requestParams(r).Get("token") != "fresh-token" {
+			_, _ = w.Write([]byte(`{"status": "invalid-token"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {"zones": []}}`))
+	}))
+	defer server.Close()
+
+	for name, tc := range map[string]struct {
+		config         Config
+		expectError    bool
+		expectedLogins int
+	}{
+		"re-login by default": {config: Config{}, expectedLogins: 1},
+		"re-login disabled":   {config: Config{DisableAuthRetry: true}, expectError: true},
+		"fail fast":           {config: Config{FailFast: true}, expectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			logins = 0
+			tc.config.Host = server.URL
+			tc.config.Username = "admin"
+			tc.config.Password = "admin"
+
+			client, err := NewClient(tc.config)
+			if err != nil {
+				t.Fatalf("Failed to create client: %v", err)
+			}
+			client.Token = "expired-token"
+
+			_, err = client.ListZones(context.Background())
+			if tc.expectError != (err != nil) {
+				t.Errorf("Expected error %v, got %v", tc.expectError, err)
+			}
+			if logins != tc.expectedLogins {
+				t.Errorf("Expected %d logins, got %d", tc.expectedLogins, logins)
+			}
+			if tc.config.FailFast && client.retries != 0 {
+				t.Errorf("Expected fail_fast to disable retries, got %d", client.retries)
+			}
+		})
+	}
+}
+
+func TestSessionRefresh(t *testing.T) {
+	var logins atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/user/login" {
+			logins.Add(1)
+			_, _ = w.Write([]byte(`{"status": "ok", "token": "fresh-token"}`))
+			return
+		}
+		if requestParams(r).Get("token") != "fresh-token" {
+			_, _ = w.Write([]byte(`{"status": "invalid-token"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {"zones": []}}`))
+	}))
+	defer server.Close()
+
+	newClient := func(t *testing.T) *Client {
+		t.Helper()
+
+		client, err := NewClient(Config{Host: server.URL, Username: "admin", Password: "admin", RetryAttempts: 1})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		logins.Store(0)
+		return client
+	}
+
+	t.Run("logs in again before the session expires", func(t *testing.T) {
+		client := newClient(t)
+		client.Token = "expiring-token"
+		client.sessionExpires = time.Now().Add(time.Minute)
+
+		if _, err := client.ListZones(context.Background()); err != nil {
+			t.Fatalf("ListZones failed: %v", err)
+		}
+		if logins.Load() != 1 {
+			t.Errorf("Expected 1 login, got %d", logins.Load())
+		}
+		if time.Until(client.sessionExpires) < defaultSessionLifetime-time.Minute {
+			t.Errorf("Expected the session expiry to be renewed, got %s", client.sessionExpires)
+		}
+	})
+
+	t.Run("keeps a valid session", func(t *testing.T) {
+		client := newClient(t)
+		client.Token = "fresh-token"
+		client.sessionExpires = time.Now().Add(20 * time.Minute)
+
+		if _, err := client.ListZones(context.Background()); err != nil {
+			t.Fatalf("ListZones failed: %v", err)
+		}
+		if logins.Load() != 0 {
+			t.Errorf("Expected no login, got %d", logins.Load())
+		}
+	})
+
+	t.Run("concurrent requests share a login", func(t *testing.T) {
+		client := newClient(t)
+		client.Token = "expired-token"
+
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.ListZones(context.Background()); err != nil {
+					t.Errorf("ListZones failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if logins.Load() != 1 {
+			t.Errorf("Expected 1 login, got %d", logins.Load())
+		}
+	})
+}
+
+func TestFormRequests(t *testing.T) {
+	var method, contentType, rawQuery string
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		contentType = r.Header.Get("Content-Type")
+		rawQuery = r.URL.RawQuery
+		_ = r.ParseForm()
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	t.Run("mutations send parameters as form data", func(t *testing.T) {
+		endpoint := NewRequest().Path("/api/zones/create").Param("zone", "example.com").Param("proxyPassword", "s3cret").Endpoint()
+		if err := client.DoRequest(context.Background(), http.MethodPost, endpoint, nil, nil); err != nil {
+			t.Fatalf("Expected the request to succeed, got %v", err)
+		}
+
+		if method != http.MethodPost || contentType != formContentType {
+			t.Errorf("Expected a form POST, got %s with %q", method, contentType)
+		}
+		if rawQuery != "" {
+			t.Errorf("Expected no query string, got %s", rawQuery)
+		}
+		if form.Get("zone") != "example.com" || form.Get("proxyPassword") != "s3cret" || form.Get("token") != "test-token" {
+			t.Errorf("Expected the parameters and token in the form, got %v", form)
+		}
+	})
+
+	t.Run("reads keep the query string", func(t *testing.T) {
+		endpoint := NewRequest().Path("/api/zones/list").Param("pageNumber", "1").Endpoint()
+		if err := client.DoRequest(context.Background(), http.MethodGet, endpoint, nil, nil); err != nil {
+			t.Fatalf("Expected the request to succeed, got %v", err)
+		}
+
+		if method != http.MethodGet || len(form) != 0 {
+			t.Errorf("Expected a GET without form, got %s with %v", method, form)
+		}
+		if rawQuery != "pageNumber=1&token=test-token" {
+			t.Errorf("Expected parameters and token in the query string, got %s", rawQuery)
+		}
+	})
+
+	t.Run("login sends the credentials as form data", func(t *testing.T) {
+		login := &Client{BaseURL: server.URL, HTTPClient: server.Client(), username: "admin", password: "s3cret"}
+		_ = login.Login(context.Background())
+
+		if method != http.MethodPost || rawQuery != "" {
+			t.Errorf("Expected a POST without query string, got %s with %s", method, rawQuery)
+		}
+		if form.Get("user") != "admin" || form.Get("pass") != "s3cret" {
+			t.Errorf("Expected the credentials in the form, got %v", form)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DashboardStats holds the query counters of the dashboard over a period, along with the current
+// size of the zones, cache and lists
+type DashboardStats struct {
+	TotalQueries       int64 `json:"totalQueries"`
+	TotalNoError       int64 `json:"totalNoError"`
+	TotalServerFailure int64 `json:"totalServerFailure"`
+	TotalNxDomain      int64 `json:"totalNxDomain"`
+	TotalRefused       int64 `json:"totalRefused"`
+	TotalAuthoritative int64 `json:"totalAuthoritative"`
+	TotalRecursive     int64 `json:"totalRecursive"`
+	TotalCached        int64 `json:"totalCached"`
+	TotalBlocked       int64 `json:"totalBlocked"`
+	TotalDropped       int64 `json:"totalDropped"`
+	TotalClients       int64 `json:"totalClients"`
+	Zones              int64 `json:"zones"`
+	CachedEntries      int64 `json:"cachedEntries"`
+	AllowedZones       int64 `json:"allowedZones"`
+	BlockedZones       int64 `json:"blockedZones"`
+	AllowListZones     int64 `json:"allowListZones"`
+	BlockListZones     int64 `json:"blockListZones"`
+}
+
+// TopStat is an entry of the top clients, domains or blocked domains of the dashboard
+type TopStat struct {
+	Name string `json:"name"`
+	// Domain is the reverse lookup of a client address, empty for domains
+	Domain      string `json:"domain,omitempty"`
+	Hits        int64  `json:"hits"`
+	RateLimited bool   `json:"rateLimited,omitempty"`
+}
+
+// DashboardStatsResponse represents the response from the dashboard/stats/get API. The chart
+// data is left out.
+type DashboardStatsResponse struct {
+	Stats             DashboardStats `json:"stats"`
+	TopClients        []TopStat      `json:"topClients"`
+	TopDomains        []TopStat      `json:"topDomains"`
+	TopBlockedDomains []TopStat      `json:"topBlockedDomains"`
+}
+
+// GetDashboardStats returns the dashboard statistics of a period. Start and end are ISO 8601
+// dates and only apply to the Custom period.
+func (c *Client) GetDashboardStats(ctx context.Context, period StatsPeriod, start, end string) (*DashboardStatsResponse, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	request := NewRequest().Path("/api/dashboard/stats/get").Param("type", string(period)).BoolParam("utc", true)
+	if period == StatsPeriodCustom {
+		request = request.Param("start", start).Param("end", end)
+	}
+
+	var response DashboardStatsResponse
+	if err := c.doRequest(ctx, http.MethodGet, request.Endpoint(), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get dashboard stats: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetDashboardStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/dashboard/stats/get" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		query := requestParams(r)
+		switch query.Get("type") {
+		case "LastDay":
+			if query.Has("start") || query.Has("end") {
+				t.Errorf("Expected no start or end for LastDay, got %s", r.URL.RawQuery)
+			}
+		case "Custom":
+			if query.Get("start") != "2026-10-01T00:00:00Z" || query.Get("end") != "2026-10-02T00:00:00Z" {
+				t.Errorf("Unexpected custom range %s", r.URL.RawQuery)
+			}
+		default:
+			t.Errorf("Unexpected type %q", query.Get("type"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {
+			"stats": {"totalQueries": 925, "totalBlocked": 49, "totalClients": 6, "zones": 19},
+			"mainChartData": {"labelFormat": "HH:mm", "labels": [], "datasets": []},
+			"topClients": [{"name": "192.168.10.5", "domain": "server1.home", "hits": 463, "rateLimited": false}],
+			"topDomains": [{"name": "edge.microsoft.com", "hits": 52}],
+			"topBlockedDomains": [{"name": "ads.example.com", "hits": 10}]
+		}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	stats, err := client.GetDashboardStats(context.Background(), StatsPeriodLastDay, "2026-10-01T00:00:00Z", "")
+	if err != nil {
+		t.Fatalf("GetDashboardStats failed: %v", err)
+	}
+	if stats.Stats.TotalQueries != 925 || stats.Stats.TotalBlocked != 49 || stats.Stats.TotalClients != 6 {
+		t.Errorf("Unexpected stats %+v", stats.Stats)
+	}
+	if len(stats.TopClients) != 1 || stats.TopClients[0].Domain != "server1.home" || stats.TopClients[0].Hits != 463 {
+		t.Errorf("Unexpected top clients %+v", stats.TopClients)
+	}
+	if len(stats.TopDomains) != 1 || stats.TopDomains[0].Name != "edge.microsoft.com" {
+		t.Errorf("Unexpected top domains %+v", stats.TopDomains)
+	}
+
+	if _, err := client.GetDashboardStats(context.Background(), StatsPeriodCustom, "2026-10-01T00:00:00Z", "2026-10-02T00:00:00Z"); err != nil {
+		t.Fatalf("GetDashboardStats with a custom range failed: %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DHCPScope represents a DHCP scope returned by the dhcp/scopes/list API
+type DHCPScope struct {
+	Name             string `json:"name"`
+	Enabled          bool   `json:"enabled"`
+	StartingAddress  string `json:"startingAddress"`
+	EndingAddress    string `json:"endingAddress"`
+	SubnetMask       string `json:"subnetMask"`
+	NetworkAddress   string `json:"networkAddress"`
+	BroadcastAddress string `json:"broadcastAddress"`
+}
+
+// DHCPLease represents a dynamic or reserved lease returned by the dhcp/leases/list API
+type DHCPLease struct {
+	Scope            string `json:"scope"`
+	Type             string `json:"type"`
+	HardwareAddress  string `json:"hardwareAddress"`
+	ClientIdentifier string `json:"clientIdentifier"`
+	Address          string `json:"address"`
+	HostName         string `json:"hostName"`
+	LeaseObtained    string `json:"leaseObtained"`
+	LeaseExpires     string `json:"leaseExpires"`
+}
+
+// ListDHCPScopesResponse represents the response from dhcp/scopes/list API
+type ListDHCPScopesResponse struct {
+	Scopes []DHCPScope `json:"scopes"`
+}
+
+// ListDHCPLeasesResponse represents the response from dhcp/leases/list API
+type ListDHCPLeasesResponse struct {
+	Leases []DHCPLease `json:"leases"`
+}
+
+// ListDHCPScopes lists all DHCP scopes of the built-in DHCP server
+func (c *Client) ListDHCPScopes(ctx context.Context) ([]DHCPScope, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/dhcp/scopes/list").Endpoint()
+
+	var response ListDHCPScopesResponse
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list DHCP scopes: %w", err)
+	}
+
+	return response.Scopes, nil
+}
+
+// ListDHCPLeases lists the dynamic and reserved leases of all DHCP scopes
+func (c *Client) ListDHCPLeases(ctx context.Context) ([]DHCPLease, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/dhcp/leases/list").Endpoint()
+
+	var response ListDHCPLeasesResponse
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list DHCP leases: %w", err)
+	}
+
+	return response.Leases, nil
+}
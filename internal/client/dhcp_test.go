@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListDHCPScopesAndLeases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/dhcp/scopes/list":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"scopes": [
+				{"name": "Default", "enabled": true, "startingAddress": "192.168.1.1", "endingAddress": "192.168.1.254",
+				 "subnetMask": "255.255.255.0", "networkAddress": "192.168.1.0", "broadcastAddress": "192.168.1.255"}
+			]}}`))
+		case "/api/dhcp/leases/list":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"leases": [
+				{"scope": "Default", "type": "Reserved", "hardwareAddress": "00-00-00-00-00-01", "clientIdentifier": "1-000000000001",
+				 "address": "192.168.1.5", "hostName": "server1.local", "leaseObtained": "08/25/2020 17:52:51", "leaseExpires": "09/26/2020 14:27:12"},
+				{"scope": "Default", "type": "Dynamic", "hardwareAddress": "00-00-00-00-00-02", "clientIdentifier": "1-000000000002",
+				 "address": "192.168.1.13", "hostName": null, "leaseObtained": "06/15/2020 16:41:46", "leaseExpires": "09/25/2020 12:39:54"}
+			]}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	scopes, err := client.ListDHCPScopes(context.Background())
+	if err != nil {
+		t.Fatalf("ListDHCPScopes failed: %v", err)
+	}
+	if len(scopes) != 1 || scopes[0].Name != "Default" || !scopes[0].Enabled || scopes[0].BroadcastAddress != "192.168.1.255" {
+		t.Errorf("Unexpected scopes: %+v", scopes)
+	}
+
+	leases, err := client.ListDHCPLeases(context.Background())
+	if err != nil {
+		t.Fatalf("ListDHCPLeases failed: %v", err)
+	}
+	if len(leases) != 2 {
+		t.Fatalf("Expected 2 leases, got %d", len(leases))
+	}
+	if leases[0].HostName != "server1.local" || leases[1].HostName != "" {
+		t.Errorf("Unexpected lease host names %q and %q", leases[0].HostName, leases[1].HostName)
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// unixSocketPrefix marks a host alias that connects through a unix domain socket
+const unixSocketPrefix = "unix://"
+
+// hostAliasDialer returns a dial function that connects to the aliased address of a host while
+// the request URL, Host header and TLS server name keep using the configured host. This lets the
+// same configuration run from CI and on the Docker host itself, where the API is only reachable
+// through a published port, the host network or a socket mounted from a reverse proxy.
+func hostAliasDialer(aliases map[string]string) (func(ctx context.Context, network, addr string) (net.Conn, error), error) {
+	normalized := make(map[string]string, len(aliases))
+	for from, to := range aliases {
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("host alias %q => %q must not be empty", from, to)
+		}
+		if strings.HasPrefix(to, unixSocketPrefix) && strings.TrimPrefix(to, unixSocketPrefix) == "" {
+			return nil, fmt.Errorf("host alias %q has an empty unix socket path", from)
+		}
+		normalized[aliasKey(from)] = to
+	}
+
+	dialer := &net.Dialer{}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		target := resolveHostAlias(normalized, addr)
+		if path, ok := strings.CutPrefix(target, unixSocketPrefix); ok {
+			return dialer.DialContext(ctx, "unix", path)
+		}
+		return dialer.DialContext(ctx, network, target)
+	}, nil
+}
+
+// resolveHostAlias maps a host:port address to its alias. Aliases keyed by host:port take
+// precedence over aliases keyed by host only, and an alias without a port keeps the original port.
+func resolveHostAlias(aliases map[string]string, addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	to, ok := aliases[strings.ToLower(addr)]
+	if !ok {
+		if to, ok = aliases[strings.ToLower(host)]; !ok {
+			return addr
+		}
+	}
+
+	if strings.HasPrefix(to, unixSocketPrefix) {
+		return to
+	}
+	if _, _, err := net.SplitHostPort(to); err != nil {
+		return net.JoinHostPort(strings.Trim(to, "[]"), port)
+	}
+	return to
+}
+
+// aliasKey normalizes a host alias key. Bracketed IPv6 literals without a port, such as
+// "[fd00::1]", are unbracketed to match the host split from dialed addresses.
+func aliasKey(from string) string {
+	from = strings.ToLower(from)
+	if strings.HasPrefix(from, "[") && strings.HasSuffix(from, "]") {
+		return from[1 : len(from)-1]
+	}
+	return from
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DNSClientQueryOptions describes a query sent with the DNS client of the server
+type DNSClientQueryOptions struct {
+	// Server is the name server to query: this-server, recursive-resolver, system-dns or the
+	// address of a name server
+	Server   string
+	Domain   string
+	Type     string
+	Protocol ForwarderProtocol
+	DNSSEC   bool
+
+	// EDNSClientSubnet is the network address sent in the EDNS Client Subnet option, if any
+	EDNSClientSubnet string
+}
+
+// DNSClientMetadata describes how a DNS client query was answered
+type DNSClientMetadata struct {
+	NameServer    string `json:"NameServer"`
+	Protocol      string `json:"Protocol"`
+	RoundTripTime string `json:"RoundTripTime"`
+}
+
+// DNSClientRecord is a resource record of a DNS client response. Unlike zone records, its data
+// holds the fields of the wire format, named as the server's DNS library names them.
+type DNSClientRecord struct {
+	Name  string                     `json:"Name"`
+	Type  string                     `json:"Type"`
+	Class string                     `json:"Class"`
+	TTL   string                     `json:"TTL"`
+	RData map[string]json.RawMessage `json:"RDATA"`
+}
+
+// TTLSeconds returns the time to live of the record in seconds, 0 when it cannot be parsed
+func (r DNSClientRecord) TTLSeconds() int64 {
+	return parseTTLSeconds(r.TTL)
+}
+
+// RDataFields returns the fields of the record data as strings. Strings are unquoted and other
+// values keep their JSON form.
+func (r DNSClientRecord) RDataFields() map[string]string {
+	fields := make(map[string]string, len(r.RData))
+	for name, raw := range r.RData {
+		var text string
+		if err := json.Unmarshal(raw, &text); err == nil {
+			fields[name] = text
+			continue
+		}
+		fields[name] = strings.TrimSpace(string(raw))
+	}
+	return fields
+}
+
+// DNSClientResult is the DNS response received by the DNS client
+type DNSClientResult struct {
+	Metadata            DNSClientMetadata `json:"Metadata"`
+	RCODE               string            `json:"RCODE"`
+	AuthoritativeAnswer bool              `json:"AuthoritativeAnswer"`
+	Truncation          bool              `json:"Truncation"`
+	RecursionAvailable  bool              `json:"RecursionAvailable"`
+	AuthenticData       bool              `json:"AuthenticData"`
+	Answer              []DNSClientRecord `json:"Answer"`
+	Authority           []DNSClientRecord `json:"Authority"`
+	Additional          []DNSClientRecord `json:"Additional"`
+}
+
+// dnsClientResponse represents the response from the dnsClient/resolve API
+type dnsClientResponse struct {
+	Result DNSClientResult `json:"result"`
+}
+
+// ResolveQuery performs a live DNS query through the DNS client of the server. The response is
+// never imported into a zone.
+func (c *Client) ResolveQuery(ctx context.Context, options DNSClientQueryOptions) (*DNSClientResult, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	request := NewRequest().Path("/api/dnsClient/resolve").
+		Param("server", options.Server).
+		Param("domain", options.Domain).
+		Param("type", options.Type)
+	if options.Protocol != "" {
+		request.Param("protocol", string(options.Protocol))
+	}
+	if options.DNSSEC {
+		request.BoolParam("dnssec", true)
+	}
+	if options.EDNSClientSubnet != "" {
+		request.Param("eDnsClientSubnet", options.EDNSClientSubnet)
+	}
+
+	var response dnsClientResponse
+	if err := c.doRequest(ctx, http.MethodGet, request.Endpoint(), nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to resolve %s %s using %s: %w", options.Domain, options.Type, options.Server, err)
+	}
+
+	return &response.Result, nil
+}
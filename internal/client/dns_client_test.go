@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/dnsClient/resolve" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		query := requestParams(r)
+		if query.Get("server") != "this-server" || query.Get("domain") != "example.com" || query.Get("type") != "MX" ||
+			query.Get("protocol") != "Tcp" || query.Get("dnssec") != "true" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		if query.Has("import") || query.Has("eDnsClientSubnet") {
+			t.Errorf("Expected no import or client subnet, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {"result": {
+			"Metadata": {"NameServer": "server1:53 (127.0.0.1:53)", "Protocol": "Tcp", "DatagramSize": "45 bytes", "RoundTripTime": "1.42 ms"},
+			"RCODE": "NoError",
+			"AuthoritativeAnswer": true,
+			"Answer": [
+				{"Name": "example.com", "Type": "MX", "Class": "IN", "TTL": "3600 (1 hour)", "RDLENGTH": "20 bytes",
+				 "RDATA": {"Preference": 10, "Exchange": "mail.example.com"}}
+			],
+			"Authority": [],
+			"Additional": []
+		}, "rawResponses": []}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	result, err := client.ResolveQuery(context.Background(), DNSClientQueryOptions{
+		Server:   "this-server",
+		Domain:   "example.com",
+		Type:     "MX",
+		Protocol: ForwarderProtocolTcp,
+		DNSSEC:   true,
+	})
+	if err != nil {
+		t.Fatalf("ResolveQuery failed: %v", err)
+	}
+	if result.RCODE != "NoError" || !result.AuthoritativeAnswer || result.Metadata.RoundTripTime != "1.42 ms" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if len(result.Answer) != 1 {
+		t.Fatalf("Expected 1 answer, got %d", len(result.Answer))
+	}
+
+	answer := result.Answer[0]
+	if answer.TTLSeconds() != 3600 {
+		t.Errorf("Expected TTL 3600, got %d", answer.TTLSeconds())
+	}
+	fields := answer.RDataFields()
+	if fields["Preference"] != "10" || fields["Exchange"] != "mail.example.com" {
+		t.Errorf("Unexpected record data %v", fields)
+	}
+}
@@ -0,0 +1,365 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DNSSECPrivateKey represents a DNSSEC private key of a signed primary zone
+type DNSSECPrivateKey struct {
+	KeyTag         int    `json:"keyTag"`
+	KeyType        string `json:"keyType"`
+	Algorithm      string `json:"algorithm"`
+	State          string `json:"state"`
+	StateChangedOn string `json:"stateChangedOn"`
+	StateReadyBy   string `json:"stateReadyBy,omitempty"`
+	IsRetiring     bool   `json:"isRetiring"`
+	RolloverDays   int    `json:"rolloverDays"`
+}
+
+// DNSSECProperties represents the response from the zones/dnssec/properties/get API
+type DNSSECProperties struct {
+	Name         string             `json:"name"`
+	Type         string             `json:"type"`
+	Internal     bool               `json:"internal"`
+	Disabled     bool               `json:"disabled"`
+	DnssecStatus string             `json:"dnssecStatus"`
+	DNSKeyTTL    int                `json:"dnsKeyTtl"`
+	PrivateKeys  []DNSSECPrivateKey `json:"dnssecPrivateKeys"`
+}
+
+// GetDNSSECProperties retrieves the DNSSEC status and private keys of a primary zone
+func (c *Client) GetDNSSECProperties(ctx context.Context, zoneName string) (*DNSSECProperties, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/get").Param("zone", zoneName).Endpoint()
+
+	var response DNSSECProperties
+	if err := c.DoRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get DNSSEC properties for zone %s: %w", zoneName, err)
+	}
+
+	return &response, nil
+}
+
+// DNSSECSignOptions holds the parameters of the zones/dnssec/sign API. Zero values are left out,
+// so the server applies its defaults.
+type DNSSECSignOptions struct {
+	Algorithm DNSSECAlgorithm
+
+	// RSA keys
+	HashAlgorithm DNSSECHashAlgorithm
+	KSKKeySize    int64
+	ZSKKeySize    int64
+
+	// ECDSA and EDDSA keys
+	Curve DNSSECCurve
+
+	DNSKeyTTL       int64
+	ZSKRolloverDays *int64
+	NxProof         DNSSECNxProof
+
+	// NSEC3 hashing
+	Iterations int64
+	SaltLength int64
+}
+
+// SignZone signs a primary zone with newly generated keys
+func (c *Client) SignZone(ctx context.Context, zoneName string, options DNSSECSignOptions) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	request := NewRequest().Path("/api/zones/dnssec/sign").
+		Param("zone", zoneName).
+		Param("algorithm", string(options.Algorithm))
+	if options.HashAlgorithm != "" {
+		request.Param("hashAlgorithm", string(options.HashAlgorithm))
+	}
+	if options.KSKKeySize > 0 {
+		request.IntParam("kskKeySize", options.KSKKeySize)
+	}
+	if options.ZSKKeySize > 0 {
+		request.IntParam("zskKeySize", options.ZSKKeySize)
+	}
+	if options.Curve != "" {
+		request.Param("curve", string(options.Curve))
+	}
+	if options.DNSKeyTTL > 0 {
+		request.IntParam("dnsKeyTtl", options.DNSKeyTTL)
+	}
+	if options.ZSKRolloverDays != nil {
+		request.IntParam("zskRolloverDays", *options.ZSKRolloverDays)
+	}
+	if options.NxProof != "" {
+		request.Param("nxProof", string(options.NxProof))
+	}
+	if options.NxProof == DNSSECNxProofNSEC3 {
+		request.IntParam("iterations", options.Iterations).IntParam("saltLength", options.SaltLength)
+	}
+
+	if err := c.doRequest(ctx, http.MethodPost, request.Endpoint(), nil, nil); err != nil {
+		return fmt.Errorf("failed to sign zone %s: %w", zoneName, err)
+	}
+
+	return nil
+}
+
+// UnsignZone removes DNSSEC signing and all keys from a primary zone
+func (c *Client) UnsignZone(ctx context.Context, zoneName string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/unsign").Param("zone", zoneName).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to unsign zone %s: %w", zoneName, err)
+	}
+
+	return nil
+}
+
+// ConvertZoneToNSEC switches a signed zone from NSEC3 to NSEC proofs of non-existence
+func (c *Client) ConvertZoneToNSEC(ctx context.Context, zoneName string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/convertToNSEC").Param("zone", zoneName).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to convert zone %s to NSEC: %w", zoneName, err)
+	}
+
+	return nil
+}
+
+// ConvertZoneToNSEC3 switches a signed zone from NSEC to NSEC3 proofs of non-existence
+func (c *Client) ConvertZoneToNSEC3(ctx context.Context, zoneName string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/convertToNSEC3").Param("zone", zoneName).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to convert zone %s to NSEC3: %w", zoneName, err)
+	}
+
+	return nil
+}
+
+// UpdateNSEC3Params updates the NSEC3 hashing parameters of a zone signed with NSEC3
+func (c *Client) UpdateNSEC3Params(ctx context.Context, zoneName string, iterations, saltLength int64) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/updateNSEC3Params").
+		Param("zone", zoneName).
+		IntParam("iterations", iterations).
+		IntParam("saltLength", saltLength).
+		Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to update NSEC3 parameters of zone %s: %w", zoneName, err)
+	}
+
+	return nil
+}
+
+// UpdateDNSKeyTTL updates the TTL of the DNSKEY records of a signed zone. The server only allows
+// it while every key is ready or active.
+func (c *Client) UpdateDNSKeyTTL(ctx context.Context, zoneName string, ttl int64) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/updateDnsKeyTtl").
+		Param("zone", zoneName).
+		IntParam("ttl", ttl).
+		Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to update DNSKEY TTL of zone %s: %w", zoneName, err)
+	}
+
+	return nil
+}
+
+// DNSSECKeyOptions holds the parameters of the zones/dnssec/properties/addPrivateKey API. The
+// hash algorithm and key size are used with RSA, the curve with ECDSA and EDDSA.
+type DNSSECKeyOptions struct {
+	KeyType       DNSSECKeyType
+	Algorithm     DNSSECAlgorithm
+	HashAlgorithm DNSSECHashAlgorithm
+	KeySize       int64
+	Curve         DNSSECCurve
+	RolloverDays  *int64
+}
+
+// AddDNSSECPrivateKey generates a new private key for a signed primary zone. The key is in the
+// Generated state until published.
+func (c *Client) AddDNSSECPrivateKey(ctx context.Context, zoneName string, options DNSSECKeyOptions) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	request := NewRequest().Path("/api/zones/dnssec/properties/addPrivateKey").
+		Param("zone", zoneName).
+		Param("keyType", string(options.KeyType)).
+		Param("algorithm", string(options.Algorithm))
+	if options.HashAlgorithm != "" {
+		request.Param("hashAlgorithm", string(options.HashAlgorithm))
+	}
+	if options.KeySize > 0 {
+		request.IntParam("keySize", options.KeySize)
+	}
+	if options.Curve != "" {
+		request.Param("curve", string(options.Curve))
+	}
+	if options.RolloverDays != nil {
+		request.IntParam("rolloverDays", *options.RolloverDays)
+	}
+
+	if err := c.doRequest(ctx, http.MethodPost, request.Endpoint(), nil, nil); err != nil {
+		return fmt.Errorf("failed to add %s to zone %s: %w", options.KeyType, zoneName, err)
+	}
+
+	return nil
+}
+
+// UpdateDNSSECPrivateKey sets the automatic rollover frequency of a private key, 0 disabling it
+func (c *Client) UpdateDNSSECPrivateKey(ctx context.Context, zoneName string, keyTag, rolloverDays int64) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/updatePrivateKey").
+		Param("zone", zoneName).
+		IntParam("keyTag", keyTag).
+		IntParam("rolloverDays", rolloverDays).
+		Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to update DNSSEC key %d of zone %s: %w", keyTag, zoneName, err)
+	}
+
+	return nil
+}
+
+// DeleteDNSSECPrivateKey deletes a private key that was generated but not yet published
+func (c *Client) DeleteDNSSECPrivateKey(ctx context.Context, zoneName string, keyTag int64) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/deletePrivateKey").
+		Param("zone", zoneName).
+		IntParam("keyTag", keyTag).
+		Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete DNSSEC key %d of zone %s: %w", keyTag, zoneName, err)
+	}
+
+	return nil
+}
+
+// PublishAllDNSSECPrivateKeys publishes the DNSKEY records of every generated private key of a
+// zone. Published keys are activated automatically once ready.
+func (c *Client) PublishAllDNSSECPrivateKeys(ctx context.Context, zoneName string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/publishAllPrivateKeys").Param("zone", zoneName).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to publish the DNSSEC keys of zone %s: %w", zoneName, err)
+	}
+
+	return nil
+}
+
+// RolloverDNSKey generates and publishes a successor for a key, which is retired once the
+// successor is active
+func (c *Client) RolloverDNSKey(ctx context.Context, zoneName string, keyTag int64) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/rolloverDnsKey").
+		Param("zone", zoneName).
+		IntParam("keyTag", keyTag).
+		Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to roll over DNSSEC key %d of zone %s: %w", keyTag, zoneName, err)
+	}
+
+	return nil
+}
+
+// RetireDNSKey retires a key and safely removes its DNSKEY record. Another active key of the same
+// type must remain.
+func (c *Client) RetireDNSKey(ctx context.Context, zoneName string, keyTag int64) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/properties/retireDnsKey").
+		Param("zone", zoneName).
+		IntParam("keyTag", keyTag).
+		Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to retire DNSSEC key %d of zone %s: %w", keyTag, zoneName, err)
+	}
+
+	return nil
+}
+
+// DSDigest is a digest of a Key Signing Key for a DS record
+type DSDigest struct {
+	DigestType string `json:"digestType"`
+	Digest     string `json:"digest"`
+}
+
+// DSRecord holds the values of the DS records of one Key Signing Key
+type DSRecord struct {
+	KeyTag             int        `json:"keyTag"`
+	DNSKeyState        string     `json:"dnsKeyState"`
+	DNSKeyStateReadyBy string     `json:"dnsKeyStateReadyBy,omitempty"`
+	Algorithm          string     `json:"algorithm"`
+	PublicKey          string     `json:"publicKey"`
+	Digests            []DSDigest `json:"digests"`
+}
+
+// DSInfo represents the response from the zones/dnssec/viewDS API
+type DSInfo struct {
+	Name         string     `json:"name"`
+	DnssecStatus string     `json:"dnssecStatus"`
+	DSRecords    []DSRecord `json:"dsRecords"`
+}
+
+// GetDSInfo returns the DS record values of the Key Signing Keys of a signed primary zone, for
+// publication at the parent zone
+func (c *Client) GetDSInfo(ctx context.Context, zoneName string) (*DSInfo, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/dnssec/viewDS").Param("zone", zoneName).Endpoint()
+
+	var response DSInfo
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get DS info for zone %s: %w", zoneName, err)
+	}
+
+	return &response, nil
+}
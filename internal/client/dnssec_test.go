@@ -0,0 +1,210 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGetDNSSECProperties(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/zones/dnssec/properties/get" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if requestParams(r).Get("zone") != "example.com" {
+			t.Errorf("Expected zone example.com, got %s", requestParams(r).Get("zone"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {
+			"name": "example.com", "type": "Primary", "dnssecStatus": "SignedWithNSEC", "dnsKeyTtl": 3600,
+			"dnssecPrivateKeys": [
+				{"keyTag": 15048, "keyType": "KeySigningKey", "algorithm": "ECDSAP256SHA256", "state": "Published",
+				 "stateChangedOn": "2022-12-18T14:39:50.0328321Z", "stateReadyBy": "2022-12-18T16:14:50.0328321Z", "isRetiring": false, "rolloverDays": 0},
+				{"keyTag": 46152, "keyType": "ZoneSigningKey", "algorithm": "ECDSAP256SHA256", "state": "Active",
+				 "stateChangedOn": "2022-12-18T14:39:50.0661173Z", "isRetiring": false, "rolloverDays": 90}
+			]}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	properties, err := client.GetDNSSECProperties(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetDNSSECProperties failed: %v", err)
+	}
+	if properties.DNSKeyTTL != 3600 {
+		t.Errorf("Expected DNSKEY TTL 3600, got %d", properties.DNSKeyTTL)
+	}
+	if len(properties.PrivateKeys) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(properties.PrivateKeys))
+	}
+	if properties.PrivateKeys[0].StateReadyBy != "2022-12-18T16:14:50.0328321Z" {
+		t.Errorf("Unexpected ready by %q", properties.PrivateKeys[0].StateReadyBy)
+	}
+	if properties.PrivateKeys[1].RolloverDays != 90 {
+		t.Errorf("Expected 90 rollover days, got %d", properties.PrivateKeys[1].RolloverDays)
+	}
+}
+
+func TestDNSSECSigning(t *testing.T) {
+	queries := map[string]url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries[r.URL.Path] = requestParams(r)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	rollover := int64(0)
+	err := client.SignZone(context.Background(), "example.com", DNSSECSignOptions{
+		Algorithm:       DNSSECAlgorithmECDSA,
+		Curve:           DNSSECCurveP384,
+		DNSKeyTTL:       3600,
+		ZSKRolloverDays: &rollover,
+		NxProof:         DNSSECNxProofNSEC3,
+		Iterations:      0,
+		SaltLength:      8,
+	})
+	if err != nil {
+		t.Fatalf("SignZone failed: %v", err)
+	}
+
+	sign := queries["/api/zones/dnssec/sign"]
+	for key, expected := range map[string]string{
+		"zone": "example.com", "algorithm": "ECDSA", "curve": "P384", "dnsKeyTtl": "3600",
+		"zskRolloverDays": "0", "nxProof": "NSEC3", "iterations": "0", "saltLength": "8",
+	} {
+		if sign.Get(key) != expected {
+			t.Errorf("Expected sign parameter %s=%s, got %q", key, expected, sign.Get(key))
+		}
+	}
+	for _, key := range []string{"hashAlgorithm", "kskKeySize", "zskKeySize"} {
+		if sign.Has(key) {
+			t.Errorf("Unexpected sign parameter %s", key)
+		}
+	}
+
+	if err := client.UpdateNSEC3Params(context.Background(), "example.com", 5, 4); err != nil {
+		t.Fatalf("UpdateNSEC3Params failed: %v", err)
+	}
+	if params := queries["/api/zones/dnssec/properties/updateNSEC3Params"]; params.Get("iterations") != "5" || params.Get("saltLength") != "4" {
+		t.Errorf("Unexpected NSEC3 parameters %v", params)
+	}
+
+	if err := client.UpdateDNSKeyTTL(context.Background(), "example.com", 7200); err != nil {
+		t.Fatalf("UpdateDNSKeyTTL failed: %v", err)
+	}
+	if params := queries["/api/zones/dnssec/properties/updateDnsKeyTtl"]; params.Get("ttl") != "7200" {
+		t.Errorf("Unexpected DNSKEY TTL parameters %v", params)
+	}
+
+	for path, call := range map[string]func(context.Context, string) error{
+		"/api/zones/dnssec/unsign":                    client.UnsignZone,
+		"/api/zones/dnssec/properties/convertToNSEC":  client.ConvertZoneToNSEC,
+		"/api/zones/dnssec/properties/convertToNSEC3": client.ConvertZoneToNSEC3,
+	} {
+		if err := call(context.Background(), "example.com"); err != nil {
+			t.Fatalf("%s failed: %v", path, err)
+		}
+		if queries[path].Get("zone") != "example.com" {
+			t.Errorf("Expected %s to be called for example.com, got %v", path, queries[path])
+		}
+	}
+}
+
+func TestDNSSECKeys(t *testing.T) {
+	queries := map[string]url.Values{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries[r.URL.Path] = requestParams(r)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/zones/dnssec/viewDS" {
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"name": "example.com", "dnssecStatus": "SignedWithNSEC", "dsRecords": [
+				{"keyTag": 47972, "dnsKeyState": "Published", "algorithm": "ECDSAP256SHA256", "publicKey": "TK5a",
+				 "digests": [{"digestType": "SHA256", "digest": "D59EBB41"}]}
+			]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	rollover := int64(60)
+	err := client.AddDNSSECPrivateKey(context.Background(), "example.com", DNSSECKeyOptions{
+		KeyType:       DNSSECKeyTypeZSK,
+		Algorithm:     DNSSECAlgorithmRSA,
+		HashAlgorithm: DNSSECHashAlgorithmSHA256,
+		KeySize:       1024,
+		RolloverDays:  &rollover,
+	})
+	if err != nil {
+		t.Fatalf("AddDNSSECPrivateKey failed: %v", err)
+	}
+
+	add := queries["/api/zones/dnssec/properties/addPrivateKey"]
+	for key, expected := range map[string]string{
+		"zone": "example.com", "keyType": "ZoneSigningKey", "algorithm": "RSA", "hashAlgorithm": "SHA256", "keySize": "1024", "rolloverDays": "60",
+	} {
+		if add.Get(key) != expected {
+			t.Errorf("Expected addPrivateKey parameter %s=%s, got %q", key, expected, add.Get(key))
+		}
+	}
+	if add.Has("curve") {
+		t.Error("Unexpected addPrivateKey parameter curve")
+	}
+
+	if err := client.UpdateDNSSECPrivateKey(context.Background(), "example.com", 1234, 90); err != nil {
+		t.Fatalf("UpdateDNSSECPrivateKey failed: %v", err)
+	}
+	if params := queries["/api/zones/dnssec/properties/updatePrivateKey"]; params.Get("keyTag") != "1234" || params.Get("rolloverDays") != "90" {
+		t.Errorf("Unexpected updatePrivateKey parameters %v", params)
+	}
+
+	for path, call := range map[string]func(context.Context, string, int64) error{
+		"/api/zones/dnssec/properties/deletePrivateKey": client.DeleteDNSSECPrivateKey,
+		"/api/zones/dnssec/properties/rolloverDnsKey":   client.RolloverDNSKey,
+		"/api/zones/dnssec/properties/retireDnsKey":     client.RetireDNSKey,
+	} {
+		if err := call(context.Background(), "example.com", 1234); err != nil {
+			t.Fatalf("%s failed: %v", path, err)
+		}
+		if queries[path].Get("zone") != "example.com" || queries[path].Get("keyTag") != "1234" {
+			t.Errorf("Unexpected %s parameters %v", path, queries[path])
+		}
+	}
+
+	if err := client.PublishAllDNSSECPrivateKeys(context.Background(), "example.com"); err != nil {
+		t.Fatalf("PublishAllDNSSECPrivateKeys failed: %v", err)
+	}
+	if queries["/api/zones/dnssec/properties/publishAllPrivateKeys"].Get("zone") != "example.com" {
+		t.Error("Expected publishAllPrivateKeys to be called for example.com")
+	}
+
+	info, err := client.GetDSInfo(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetDSInfo failed: %v", err)
+	}
+	if len(info.DSRecords) != 1 || info.DSRecords[0].KeyTag != 47972 || info.DSRecords[0].Digests[0].Digest != "D59EBB41" {
+		t.Errorf("Unexpected DS info %+v", info)
+	}
+}
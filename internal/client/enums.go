@@ -0,0 +1,330 @@
+package client
+
+// ForwarderProtocol is the DNS transport used to reach a forwarder, as serialized by the API
+type ForwarderProtocol string
+
+const (
+	ForwarderProtocolUdp   ForwarderProtocol = "Udp"
+	ForwarderProtocolTcp   ForwarderProtocol = "Tcp"
+	ForwarderProtocolTls   ForwarderProtocol = "Tls"
+	ForwarderProtocolHttps ForwarderProtocol = "Https"
+	ForwarderProtocolQuic  ForwarderProtocol = "Quic"
+
+	// DefaultForwarderProtocol is used by the server when no protocol is given
+	DefaultForwarderProtocol = ForwarderProtocolUdp
+)
+
+// ForwarderProtocols lists every forwarder protocol accepted by the API
+func ForwarderProtocols() []ForwarderProtocol {
+	return []ForwarderProtocol{ForwarderProtocolUdp, ForwarderProtocolTcp, ForwarderProtocolTls, ForwarderProtocolHttps, ForwarderProtocolQuic}
+}
+
+// ZoneTransferProtocol is the transport used for zone transfers from a primary server
+type ZoneTransferProtocol string
+
+const (
+	ZoneTransferProtocolTcp  ZoneTransferProtocol = "Tcp"
+	ZoneTransferProtocolTls  ZoneTransferProtocol = "Tls"
+	ZoneTransferProtocolQuic ZoneTransferProtocol = "Quic"
+
+	// DefaultZoneTransferProtocol is used by the server when no protocol is given
+	DefaultZoneTransferProtocol = ZoneTransferProtocolTcp
+)
+
+// ZoneTransferProtocols lists every zone transfer protocol accepted by the API
+func ZoneTransferProtocols() []ZoneTransferProtocol {
+	return []ZoneTransferProtocol{ZoneTransferProtocolTcp, ZoneTransferProtocolTls, ZoneTransferProtocolQuic}
+}
+
+// ZoneTransferAccess controls which servers may transfer a zone out
+type ZoneTransferAccess string
+
+const (
+	ZoneTransferAccessDeny                                          ZoneTransferAccess = "Deny"
+	ZoneTransferAccessAllow                                         ZoneTransferAccess = "Allow"
+	ZoneTransferAccessAllowOnlyZoneNameServers                      ZoneTransferAccess = "AllowOnlyZoneNameServers"
+	ZoneTransferAccessUseSpecifiedNetworkACL                        ZoneTransferAccess = "UseSpecifiedNetworkACL"
+	ZoneTransferAccessAllowZoneNameServersAndUseSpecifiedNetworkACL ZoneTransferAccess = "AllowZoneNameServersAndUseSpecifiedNetworkACL"
+)
+
+// ZoneTransferAccesses lists every zone transfer access policy accepted by the API
+func ZoneTransferAccesses() []ZoneTransferAccess {
+	return []ZoneTransferAccess{
+		ZoneTransferAccessDeny,
+		ZoneTransferAccessAllow,
+		ZoneTransferAccessAllowOnlyZoneNameServers,
+		ZoneTransferAccessUseSpecifiedNetworkACL,
+		ZoneTransferAccessAllowZoneNameServersAndUseSpecifiedNetworkACL,
+	}
+}
+
+// ZoneQueryAccess controls which clients may query a zone
+type ZoneQueryAccess string
+
+const (
+	ZoneQueryAccessDeny                                          ZoneQueryAccess = "Deny"
+	ZoneQueryAccessAllow                                         ZoneQueryAccess = "Allow"
+	ZoneQueryAccessAllowOnlyPrivateNetworks                      ZoneQueryAccess = "AllowOnlyPrivateNetworks"
+	ZoneQueryAccessAllowOnlyZoneNameServers                      ZoneQueryAccess = "AllowOnlyZoneNameServers"
+	ZoneQueryAccessUseSpecifiedNetworkACL                        ZoneQueryAccess = "UseSpecifiedNetworkACL"
+	ZoneQueryAccessAllowZoneNameServersAndUseSpecifiedNetworkACL ZoneQueryAccess = "AllowZoneNameServersAndUseSpecifiedNetworkACL"
+)
+
+// ZoneQueryAccesses lists every query access policy accepted by the API
+func ZoneQueryAccesses() []ZoneQueryAccess {
+	return []ZoneQueryAccess{
+		ZoneQueryAccessDeny,
+		ZoneQueryAccessAllow,
+		ZoneQueryAccessAllowOnlyPrivateNetworks,
+		ZoneQueryAccessAllowOnlyZoneNameServers,
+		ZoneQueryAccessUseSpecifiedNetworkACL,
+		ZoneQueryAccessAllowZoneNameServersAndUseSpecifiedNetworkACL,
+	}
+}
+
+// ZoneUpdate controls which clients may update a zone dynamically (RFC 2136)
+type ZoneUpdate string
+
+const (
+	ZoneUpdateDeny                                          ZoneUpdate = "Deny"
+	ZoneUpdateAllow                                         ZoneUpdate = "Allow"
+	ZoneUpdateAllowOnlyZoneNameServers                      ZoneUpdate = "AllowOnlyZoneNameServers"
+	ZoneUpdateUseSpecifiedNetworkACL                        ZoneUpdate = "UseSpecifiedNetworkACL"
+	ZoneUpdateAllowZoneNameServersAndUseSpecifiedNetworkACL ZoneUpdate = "AllowZoneNameServersAndUseSpecifiedNetworkACL"
+)
+
+// ZoneUpdates lists every dynamic update policy accepted by the API
+func ZoneUpdates() []ZoneUpdate {
+	return []ZoneUpdate{
+		ZoneUpdateDeny,
+		ZoneUpdateAllow,
+		ZoneUpdateAllowOnlyZoneNameServers,
+		ZoneUpdateUseSpecifiedNetworkACL,
+		ZoneUpdateAllowZoneNameServersAndUseSpecifiedNetworkACL,
+	}
+}
+
+// ZoneNotify controls which servers are notified of zone updates
+type ZoneNotify string
+
+const (
+	ZoneNotifyNone                                        ZoneNotify = "None"
+	ZoneNotifyZoneNameServers                             ZoneNotify = "ZoneNameServers"
+	ZoneNotifySpecifiedNameServers                        ZoneNotify = "SpecifiedNameServers"
+	ZoneNotifyBothZoneAndSpecifiedNameServers             ZoneNotify = "BothZoneAndSpecifiedNameServers"
+	ZoneNotifySeparateNameServersForCatalogAndMemberZones ZoneNotify = "SeparateNameServersForCatalogAndMemberZones"
+)
+
+// ZoneNotifies lists every notify policy accepted by the API
+func ZoneNotifies() []ZoneNotify {
+	return []ZoneNotify{
+		ZoneNotifyNone,
+		ZoneNotifyZoneNameServers,
+		ZoneNotifySpecifiedNameServers,
+		ZoneNotifyBothZoneAndSpecifiedNameServers,
+		ZoneNotifySeparateNameServersForCatalogAndMemberZones,
+	}
+}
+
+// ProxyType is the proxy used to reach a forwarder
+type ProxyType string
+
+const (
+	ProxyTypeNone    ProxyType = "NoProxy"
+	ProxyTypeDefault ProxyType = "DefaultProxy"
+	ProxyTypeHttp    ProxyType = "Http"
+	ProxyTypeSocks5  ProxyType = "Socks5"
+
+	// DefaultProxyType is used by the server when no proxy type is given
+	DefaultProxyType = ProxyTypeDefault
+)
+
+// ProxyTypes lists every proxy type accepted by the API
+func ProxyTypes() []ProxyType {
+	return []ProxyType{ProxyTypeNone, ProxyTypeDefault, ProxyTypeHttp, ProxyTypeSocks5}
+}
+
+// RecursionPolicy controls which clients the server resolves recursively for
+type RecursionPolicy string
+
+const (
+	RecursionDeny                        RecursionPolicy = "Deny"
+	RecursionAllow                       RecursionPolicy = "Allow"
+	RecursionAllowOnlyForPrivateNetworks RecursionPolicy = "AllowOnlyForPrivateNetworks"
+	RecursionUseSpecifiedNetworkACL      RecursionPolicy = "UseSpecifiedNetworkACL"
+)
+
+// RecursionPolicies lists every recursion policy accepted by the settings API
+func RecursionPolicies() []RecursionPolicy {
+	return []RecursionPolicy{RecursionDeny, RecursionAllow, RecursionAllowOnlyForPrivateNetworks, RecursionUseSpecifiedNetworkACL}
+}
+
+// BlockingType is how the server answers requests for blocked domains
+type BlockingType string
+
+const (
+	BlockingTypeAnyAddress    BlockingType = "AnyAddress"
+	BlockingTypeNxDomain      BlockingType = "NxDomain"
+	BlockingTypeCustomAddress BlockingType = "CustomAddress"
+)
+
+// BlockingTypes lists every blocking type accepted by the settings API
+func BlockingTypes() []BlockingType {
+	return []BlockingType{BlockingTypeAnyAddress, BlockingTypeNxDomain, BlockingTypeCustomAddress}
+}
+
+// PermissionSection is a section of the web console and API that permissions are granted for
+type PermissionSection string
+
+const (
+	PermissionSectionDashboard      PermissionSection = "Dashboard"
+	PermissionSectionZones          PermissionSection = "Zones"
+	PermissionSectionCache          PermissionSection = "Cache"
+	PermissionSectionAllowed        PermissionSection = "Allowed"
+	PermissionSectionBlocked        PermissionSection = "Blocked"
+	PermissionSectionApps           PermissionSection = "Apps"
+	PermissionSectionDnsClient      PermissionSection = "DnsClient"
+	PermissionSectionSettings       PermissionSection = "Settings"
+	PermissionSectionDhcpServer     PermissionSection = "DhcpServer"
+	PermissionSectionAdministration PermissionSection = "Administration"
+	PermissionSectionLogs           PermissionSection = "Logs"
+)
+
+// PermissionSections lists every section accepted by the permissions API
+func PermissionSections() []PermissionSection {
+	return []PermissionSection{
+		PermissionSectionDashboard, PermissionSectionZones, PermissionSectionCache, PermissionSectionAllowed, PermissionSectionBlocked,
+		PermissionSectionApps, PermissionSectionDnsClient, PermissionSectionSettings, PermissionSectionDhcpServer,
+		PermissionSectionAdministration, PermissionSectionLogs,
+	}
+}
+
+// TsigAlgorithm is the HMAC algorithm of a TSIG key
+type TsigAlgorithm string
+
+const (
+	TsigAlgorithmHmacMd5       TsigAlgorithm = "hmac-md5.sig-alg.reg.int"
+	TsigAlgorithmHmacSha1      TsigAlgorithm = "hmac-sha1"
+	TsigAlgorithmHmacSha256    TsigAlgorithm = "hmac-sha256"
+	TsigAlgorithmHmacSha256128 TsigAlgorithm = "hmac-sha256-128"
+	TsigAlgorithmHmacSha384    TsigAlgorithm = "hmac-sha384"
+	TsigAlgorithmHmacSha384192 TsigAlgorithm = "hmac-sha384-192"
+	TsigAlgorithmHmacSha512    TsigAlgorithm = "hmac-sha512"
+	TsigAlgorithmHmacSha512256 TsigAlgorithm = "hmac-sha512-256"
+
+	// DefaultTsigAlgorithm is the algorithm offered by default by the web console
+	DefaultTsigAlgorithm = TsigAlgorithmHmacSha256
+)
+
+// TsigAlgorithms lists every TSIG algorithm accepted by the settings API
+func TsigAlgorithms() []TsigAlgorithm {
+	return []TsigAlgorithm{
+		TsigAlgorithmHmacMd5, TsigAlgorithmHmacSha1, TsigAlgorithmHmacSha256, TsigAlgorithmHmacSha256128,
+		TsigAlgorithmHmacSha384, TsigAlgorithmHmacSha384192, TsigAlgorithmHmacSha512, TsigAlgorithmHmacSha512256,
+	}
+}
+
+// DNSSECAlgorithm is the family of the keys a zone is signed with
+type DNSSECAlgorithm string
+
+const (
+	DNSSECAlgorithmRSA   DNSSECAlgorithm = "RSA"
+	DNSSECAlgorithmECDSA DNSSECAlgorithm = "ECDSA"
+	DNSSECAlgorithmEDDSA DNSSECAlgorithm = "EDDSA"
+
+	// DefaultDNSSECAlgorithm is offered by default by the web console
+	DefaultDNSSECAlgorithm = DNSSECAlgorithmECDSA
+)
+
+// DNSSECAlgorithms lists every signing algorithm accepted by the sign API
+func DNSSECAlgorithms() []DNSSECAlgorithm {
+	return []DNSSECAlgorithm{DNSSECAlgorithmRSA, DNSSECAlgorithmECDSA, DNSSECAlgorithmEDDSA}
+}
+
+// DNSSECHashAlgorithm is the hash algorithm of RSA keys
+type DNSSECHashAlgorithm string
+
+const (
+	DNSSECHashAlgorithmMD5    DNSSECHashAlgorithm = "MD5"
+	DNSSECHashAlgorithmSHA1   DNSSECHashAlgorithm = "SHA1"
+	DNSSECHashAlgorithmSHA256 DNSSECHashAlgorithm = "SHA256"
+	DNSSECHashAlgorithmSHA512 DNSSECHashAlgorithm = "SHA512"
+)
+
+// DNSSECHashAlgorithms lists every RSA hash algorithm accepted by the sign API
+func DNSSECHashAlgorithms() []DNSSECHashAlgorithm {
+	return []DNSSECHashAlgorithm{DNSSECHashAlgorithmMD5, DNSSECHashAlgorithmSHA1, DNSSECHashAlgorithmSHA256, DNSSECHashAlgorithmSHA512}
+}
+
+// DNSSECCurve is the curve of ECDSA (P256, P384) and EDDSA (ED25519, ED448) keys
+type DNSSECCurve string
+
+const (
+	DNSSECCurveP256    DNSSECCurve = "P256"
+	DNSSECCurveP384    DNSSECCurve = "P384"
+	DNSSECCurveED25519 DNSSECCurve = "ED25519"
+	DNSSECCurveED448   DNSSECCurve = "ED448"
+)
+
+// DNSSECCurves lists every curve accepted by the sign API
+func DNSSECCurves() []DNSSECCurve {
+	return []DNSSECCurve{DNSSECCurveP256, DNSSECCurveP384, DNSSECCurveED25519, DNSSECCurveED448}
+}
+
+// DNSSECNxProof is the proof of non-existence of a signed zone
+type DNSSECNxProof string
+
+const (
+	DNSSECNxProofNSEC  DNSSECNxProof = "NSEC"
+	DNSSECNxProofNSEC3 DNSSECNxProof = "NSEC3"
+
+	// DefaultDNSSECNxProof is used by the server when no proof is given
+	DefaultDNSSECNxProof = DNSSECNxProofNSEC
+)
+
+// DNSSECNxProofs lists every proof of non-existence accepted by the sign API
+func DNSSECNxProofs() []DNSSECNxProof {
+	return []DNSSECNxProof{DNSSECNxProofNSEC, DNSSECNxProofNSEC3}
+}
+
+// DNSSECKeyType is the role of a DNSSEC private key
+type DNSSECKeyType string
+
+const (
+	DNSSECKeyTypeKSK DNSSECKeyType = "KeySigningKey"
+	DNSSECKeyTypeZSK DNSSECKeyType = "ZoneSigningKey"
+)
+
+// DNSSECKeyTypes lists every key type accepted by the addPrivateKey API
+func DNSSECKeyTypes() []DNSSECKeyType {
+	return []DNSSECKeyType{DNSSECKeyTypeKSK, DNSSECKeyTypeZSK}
+}
+
+// StatsPeriod is the period the dashboard statistics cover
+type StatsPeriod string
+
+const (
+	StatsPeriodLastHour  StatsPeriod = "LastHour"
+	StatsPeriodLastDay   StatsPeriod = "LastDay"
+	StatsPeriodLastWeek  StatsPeriod = "LastWeek"
+	StatsPeriodLastMonth StatsPeriod = "LastMonth"
+	StatsPeriodLastYear  StatsPeriod = "LastYear"
+	StatsPeriodCustom    StatsPeriod = "Custom"
+
+	// DefaultStatsPeriod is used by the server when no period is given
+	DefaultStatsPeriod = StatsPeriodLastHour
+)
+
+// StatsPeriods lists every period accepted by the dashboard stats API
+func StatsPeriods() []StatsPeriod {
+	return []StatsPeriod{StatsPeriodLastHour, StatsPeriodLastDay, StatsPeriodLastWeek, StatsPeriodLastMonth, StatsPeriodLastYear, StatsPeriodCustom}
+}
+
+// EnumValues converts a list of enum values to their API string form
+func EnumValues[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestEnumSerialization(t *testing.T) {
+	// Every allowed protocol and proxy type must survive the record data round trip unchanged
+	for _, protocol := range ForwarderProtocols() {
+		for _, proxyType := range ProxyTypes() {
+			encoded, err := json.Marshal(DNSRecordData{Protocol: string(protocol), ProxyType: string(proxyType)})
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			var decoded DNSRecordData
+			if err := json.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if decoded.Protocol != string(protocol) || decoded.ProxyType != string(proxyType) {
+				t.Errorf("Expected %s/%s after round trip, got %s/%s", protocol, proxyType, decoded.Protocol, decoded.ProxyType)
+			}
+		}
+	}
+
+	// Values as returned by the records API must be members of the allowed sets
+	var record DNSRecord
+	if err := json.Unmarshal([]byte(`{"name": "example.com", "type": "FWD", "rData": {"protocol": "Https", "forwarder": "https://dns.example/dns-query", "proxyType": "NoProxy"}}`), &record); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !slices.Contains(ForwarderProtocols(), ForwarderProtocol(record.RData.Protocol)) {
+		t.Errorf("Protocol %q is not an allowed forwarder protocol", record.RData.Protocol)
+	}
+	if !slices.Contains(ProxyTypes(), ProxyType(record.RData.ProxyType)) {
+		t.Errorf("Proxy type %q is not an allowed proxy type", record.RData.ProxyType)
+	}
+
+	// Defaults must be allowed values
+	if !slices.Contains(ForwarderProtocols(), DefaultForwarderProtocol) {
+		t.Errorf("Default forwarder protocol %q is not allowed", DefaultForwarderProtocol)
+	}
+	if !slices.Contains(ZoneTransferProtocols(), DefaultZoneTransferProtocol) {
+		t.Errorf("Default zone transfer protocol %q is not allowed", DefaultZoneTransferProtocol)
+	}
+	if !slices.Contains(ProxyTypes(), DefaultProxyType) {
+		t.Errorf("Default proxy type %q is not allowed", DefaultProxyType)
+	}
+	if !slices.Contains(TsigAlgorithms(), DefaultTsigAlgorithm) {
+		t.Errorf("Default TSIG algorithm %q is not allowed", DefaultTsigAlgorithm)
+	}
+	if !slices.Contains(DNSSECAlgorithms(), DefaultDNSSECAlgorithm) {
+		t.Errorf("Default DNSSEC algorithm %q is not allowed", DefaultDNSSECAlgorithm)
+	}
+	if !slices.Contains(DNSSECNxProofs(), DefaultDNSSECNxProof) {
+		t.Errorf("Default DNSSEC proof of non-existence %q is not allowed", DefaultDNSSECNxProof)
+	}
+}
+
+func TestEnumValues(t *testing.T) {
+	expected := []string{"Udp", "Tcp", "Tls", "Https", "Quic"}
+	if values := EnumValues(ForwarderProtocols()); !slices.Equal(values, expected) {
+		t.Errorf("Expected %v, got %v", expected, values)
+	}
+}
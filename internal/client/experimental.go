@@ -0,0 +1,25 @@
+package client
+
+// ExperimentalFeature is a flag gating a resource or subsystem that is still unstable
+type ExperimentalFeature string
+
+const (
+	// ExperimentalSettings gates the server settings resources
+	ExperimentalSettings ExperimentalFeature = "settings"
+	// ExperimentalDHCP gates the DHCP scope and lease resources
+	ExperimentalDHCP ExperimentalFeature = "dhcp"
+	// ExperimentalDNSSEC gates the DNSSEC signing and key management resources
+	ExperimentalDNSSEC ExperimentalFeature = "dnssec"
+	// ExperimentalAdmin gates the administration resources managing groups, users and permissions
+	ExperimentalAdmin ExperimentalFeature = "admin"
+)
+
+// ExperimentalFeatures lists every known experimental feature flag
+func ExperimentalFeatures() []ExperimentalFeature {
+	return []ExperimentalFeature{ExperimentalSettings, ExperimentalDHCP, ExperimentalDNSSEC, ExperimentalAdmin}
+}
+
+// ExperimentEnabled reports whether the experimental feature was enabled in the provider configuration
+func (c *Client) ExperimentEnabled(feature ExperimentalFeature) bool {
+	return c.experimentalFeatures[feature]
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"encoding/json"
+)
+
+// fieldFeatures maps optional response fields to the feature introducing them. Servers older
+// than the feature leave these fields out of their responses instead of returning a default.
+var fieldFeatures = map[string]Feature{
+	"useSoaSerialDateScheme": FeatureSoaSerialDateScheme,
+	"validateZone":           FeatureZoneValidation,
+}
+
+// FieldSet holds the top-level fields present in an API response
+type FieldSet map[string]bool
+
+// Has reports whether the response contained the field
+func (f FieldSet) Has(field string) bool {
+	return f[field]
+}
+
+// responseFields returns the top-level fields of a JSON object, or nil when data is not an object
+func responseFields(data []byte) FieldSet {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+
+	fields := make(FieldSet, len(raw))
+	for field := range raw {
+		fields[field] = true
+	}
+	return fields
+}
+
+// observeFields records the features a response proves the server supports, so later checks do
+// not depend on the reported version alone
+func (c *Client) observeFields(fields FieldSet) {
+	c.serverInfoMu.Lock()
+	defer c.serverInfoMu.Unlock()
+
+	for field := range fields {
+		feature, ok := fieldFeatures[field]
+		if !ok {
+			continue
+		}
+		if c.observedFeatures == nil {
+			c.observedFeatures = make(map[Feature]bool)
+		}
+		c.observedFeatures[feature] = true
+	}
+}
+
+// featureObserved reports whether a response already contained a field of the feature
+func (c *Client) featureObserved(feature Feature) bool {
+	c.serverInfoMu.Lock()
+	defer c.serverInfoMu.Unlock()
+	return c.observedFeatures[feature]
+}
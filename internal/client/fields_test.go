@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestZoneOptionsFields(t *testing.T) {
+	var options ZoneOptions
+	if err := json.Unmarshal([]byte(`{"name": "example.com", "type": "Primary", "useSoaSerialDateScheme": false}`), &options); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if options.Name != "example.com" || options.UseSoaSerialDateScheme == nil || *options.UseSoaSerialDateScheme {
+		t.Errorf("Unexpected zone options: %+v", options)
+	}
+	if !options.Fields.Has("useSoaSerialDateScheme") {
+		t.Error("Expected useSoaSerialDateScheme to be recorded as present")
+	}
+	if options.Fields.Has("validateZone") {
+		t.Error("Expected validateZone to be recorded as missing")
+	}
+}
+
+func TestSupportsFeatureObservedField(t *testing.T) {
+	// The reported version predates the feature, but a response already contained its field
+	client := &Client{serverVersion: "10.0"}
+	client.observeFields(FieldSet{"name": true, "useSoaSerialDateScheme": true})
+
+	supported, _, _, err := client.SupportsFeature(context.Background(), FeatureSoaSerialDateScheme)
+	if err != nil {
+		t.Fatalf("SupportsFeature failed: %v", err)
+	}
+	if !supported {
+		t.Error("Expected the observed field to mark the feature as supported")
+	}
+
+	supported, _, _, err = client.SupportsFeature(context.Background(), FeatureZoneValidation)
+	if err != nil {
+		t.Fatalf("SupportsFeature failed: %v", err)
+	}
+	if supported {
+		t.Error("Expected zone validation to be unsupported on 10.0")
+	}
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Group represents a group of users of the DNS server's web console and API
+type Group struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Members     []string `json:"members"`
+}
+
+// ListGroupsResponse represents the response from the admin/groups/list API
+type ListGroupsResponse struct {
+	Groups []Group `json:"groups"`
+}
+
+// ListGroups lists every group. Members are not included.
+func (c *Client) ListGroups(ctx context.Context) ([]Group, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/groups/list").Endpoint()
+
+	var response ListGroupsResponse
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	return response.Groups, nil
+}
+
+// GetGroup retrieves a group with its members
+func (c *Client) GetGroup(ctx context.Context, name string) (*Group, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/groups/get").Param("group", name).Endpoint()
+
+	var response Group
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get group %s: %w", name, err)
+	}
+
+	return &response, nil
+}
+
+// CreateGroup creates a group without members
+func (c *Client) CreateGroup(ctx context.Context, name, description string) (*Group, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/groups/create").
+		Param("group", name).
+		Param("description", description).
+		Endpoint()
+
+	var response Group
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to create group %s: %w", name, err)
+	}
+
+	return &response, nil
+}
+
+// SetGroup updates the description and members of a group, replacing the current members
+func (c *Client) SetGroup(ctx context.Context, name, description string, members []string) (*Group, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/groups/set").
+		Param("group", name).
+		Param("description", description).
+		Param("members", strings.Join(members, ",")).
+		Endpoint()
+
+	var response Group
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to set group %s: %w", name, err)
+	}
+
+	return &response, nil
+}
+
+// DeleteGroup deletes a group
+func (c *Client) DeleteGroup(ctx context.Context, name string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/groups/delete").Param("group", name).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete group %s: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	var setQuery, deleted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := requestParams(r)
+
+		switch r.URL.Path {
+		case "/api/admin/groups/list":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"groups": [
+				{"name": "Administrators", "description": "Super administrators"},
+				{"name": "DNS Operators", "description": "On-call"}
+			]}}`))
+		case "/api/admin/groups/get":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"name": "` + query.Get("group") + `", "description": "On-call", "members": ["alice", "bob"]}}`))
+		case "/api/admin/groups/create":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"name": "` + query.Get("group") + `", "description": "` + query.Get("description") + `"}}`))
+		case "/api/admin/groups/set":
+			setQuery = query.Encode()
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"name": "DNS Operators", "description": "On-call", "members": []}}`))
+		case "/api/admin/groups/delete":
+			deleted = query.Get("group")
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	groups, err := client.ListGroups(context.Background())
+	if err != nil || len(groups) != 2 || groups[1].Name != "DNS Operators" {
+		t.Fatalf("ListGroups returned %+v (%v)", groups, err)
+	}
+
+	group, err := client.GetGroup(context.Background(), "DNS Operators")
+	if err != nil || !slices.Equal(group.Members, []string{"alice", "bob"}) {
+		t.Errorf("GetGroup returned %+v (%v)", group, err)
+	}
+
+	group, err = client.CreateGroup(context.Background(), "DNS Operators", "On-call")
+	if err != nil || group.Description != "On-call" {
+		t.Errorf("CreateGroup returned %+v (%v)", group, err)
+	}
+
+	// An empty member list is sent explicitly so it clears the members
+	if _, err := client.SetGroup(context.Background(), "DNS Operators", "On-call", nil); err != nil {
+		t.Errorf("SetGroup failed: %v", err)
+	}
+	if expected := "description=On-call&group=DNS+Operators&members=&token=test-token"; setQuery != expected {
+		t.Errorf("Expected query %s, got %s", expected, setQuery)
+	}
+
+	if err := client.DeleteGroup(context.Background(), "DNS Operators"); err != nil || deleted != "DNS Operators" {
+		t.Errorf("DeleteGroup failed: %v (deleted %q)", err, deleted)
+	}
+}
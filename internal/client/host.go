@@ -0,0 +1,73 @@
+package client
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+// normalizeHost turns the configured host into the base URL of the API. A missing scheme
+// defaults to http, and IPv6 literals are bracketed with their zone escaped, so all of
+// "fd00::1", "[fd00::1]:5380" and "https://[fe80::1%eth0]:53443/" yield valid URLs.
+func normalizeHost(host string) (string, error) {
+	host = strings.TrimSpace(host)
+
+	scheme, rest, ok := strings.Cut(host, "://")
+	if !ok {
+		scheme, rest = "http", host
+	}
+	rest = strings.TrimSuffix(rest, "/")
+
+	authority, path := rest, ""
+	if i := strings.Index(rest, "/"); i >= 0 {
+		authority, path = rest[:i], rest[i:]
+	}
+
+	authority, err := bracketIPv6(authority)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", host, err)
+	}
+
+	baseURL := scheme + "://" + authority + path
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid host %q: %w", host, err)
+	}
+	if parsed.Hostname() == "" {
+		return "", fmt.Errorf("invalid host %q: missing host name", host)
+	}
+
+	return baseURL, nil
+}
+
+// bracketIPv6 brackets an IPv6 literal in a host[:port] authority and escapes its zone, leaving
+// host names and IPv4 addresses untouched. A bare literal such as "fd00::1" is taken as an
+// address without a port, since its last group cannot be told apart from one.
+func bracketIPv6(authority string) (string, error) {
+	literal, port := "", ""
+	switch {
+	case strings.HasPrefix(authority, "["):
+		end := strings.Index(authority, "]")
+		if end < 0 {
+			return "", fmt.Errorf("missing ']' in IPv6 literal")
+		}
+		literal, port = authority[1:end], authority[end+1:]
+		if port != "" && !strings.HasPrefix(port, ":") {
+			return "", fmt.Errorf("unexpected %q after IPv6 literal", port)
+		}
+	case strings.Count(authority, ":") > 1:
+		literal = authority
+	default:
+		return authority, nil
+	}
+
+	// The zone may already be escaped as %25, as URLs require
+	literal = strings.Replace(literal, "%25", "%", 1)
+	addr, err := netip.ParseAddr(literal)
+	if err != nil || !addr.Is6() {
+		return "", fmt.Errorf("invalid IPv6 literal %q", literal)
+	}
+
+	return "[" + strings.Replace(addr.String(), "%", "%25", 1) + "]" + port, nil
+}
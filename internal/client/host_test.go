@@ -0,0 +1,45 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestNormalizeHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{"http://localhost:5380", "http://localhost:5380"},
+		{"https://dns.example.com/", "https://dns.example.com"},
+		{"localhost:5380", "http://localhost:5380"},
+		{"https://[fd00::1]:53443", "https://[fd00::1]:53443"},
+		{"https://[FD00:0::1]:53443/", "https://[fd00::1]:53443"},
+		{"https://fd00::1", "https://[fd00::1]"},
+		{"fd00::1", "http://[fd00::1]"},
+		{"[fd00::1]:5380", "http://[fd00::1]:5380"},
+		{"https://[fe80::1%eth0]:53443", "https://[fe80::1%25eth0]:53443"},
+		{"https://[fe80::1%25eth0]:53443", "https://[fe80::1%25eth0]:53443"},
+		{"http://[::1]:5380/technitium", "http://[::1]:5380/technitium"},
+	}
+
+	for _, tt := range tests {
+		got, err := normalizeHost(tt.host)
+		if err != nil {
+			t.Errorf("normalizeHost(%q) failed: %v", tt.host, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("normalizeHost(%q) = %q, expected %q", tt.host, got, tt.expected)
+		}
+		if _, err := url.Parse(got + "/api/user/session/get"); err != nil {
+			t.Errorf("normalizeHost(%q) produced an unparsable URL: %v", tt.host, err)
+		}
+	}
+
+	for _, host := range []string{"https://[fd00::1:53443", "https://[fd00::1]x", "https://[dns.example.com]", "https://[10.0.0.1]:5380", "https://"} {
+		if got, err := normalizeHost(host); err == nil {
+			t.Errorf("normalizeHost(%q) = %q, expected an error", host, got)
+		}
+	}
+}
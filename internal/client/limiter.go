@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// requestLimiter throttles the API requests of a client. Terraform runs resources concurrently
+// and they all share one client, so the limits apply to the whole run.
+type requestLimiter struct {
+	// slots holds a value for every request in flight, nil when concurrency is unlimited
+	slots chan struct{}
+	// interval is the minimum time between the starts of two requests, zero when unlimited
+	interval time.Duration
+
+	// next is the earliest start of the next request, guarded by mu
+	next time.Time
+	mu   sync.Mutex
+}
+
+// newRequestLimiter returns a limiter allowing maxConcurrent requests in flight and starting at
+// most perSecond requests per second, or nil when neither limit is set
+func newRequestLimiter(maxConcurrent, perSecond int64) *requestLimiter {
+	if maxConcurrent <= 0 && perSecond <= 0 {
+		return nil
+	}
+
+	limiter := &requestLimiter{}
+	if maxConcurrent > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrent)
+	}
+	if perSecond > 0 {
+		limiter.interval = time.Second / time.Duration(perSecond)
+	}
+	return limiter
+}
+
+// acquire waits until a request may start and returns the function to call once it completed.
+// Waiting is bounded by the context, not by the request timeout.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = func() { <-l.slots }
+	}
+
+	if l.interval > 0 {
+		l.mu.Lock()
+		start := time.Now()
+		if l.next.After(start) {
+			start = l.next
+		}
+		l.next = start.Add(l.interval)
+		l.mu.Unlock()
+
+		if wait := time.Until(start); wait > 0 {
+			tflog.Debug(ctx, "Throttling API request", map[string]interface{}{
+				"wait": wait.String(),
+			})
+
+			timer := time.NewTimer(wait)
+			defer timer.Stop()
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				release()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	return release, nil
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	t.Run("limits requests in flight", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				seen := maxInFlight.Load()
+				if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		}))
+		defer server.Close()
+
+		client := &Client{
+			BaseURL:    server.URL,
+			HTTPClient: server.Client(),
+			Token:      "test-token",
+			limiter:    newRequestLimiter(2, 0),
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := client.DoRequest(context.Background(), http.MethodGet, "/api/zones/list", nil, nil); err != nil {
+					t.Errorf("Expected the request to succeed, got %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := maxInFlight.Load(); got > 2 {
+			t.Errorf("Expected at most 2 requests in flight, got %d", got)
+		}
+	})
+
+	t.Run("spaces requests by the rate", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		}))
+		defer server.Close()
+
+		client := &Client{
+			BaseURL:    server.URL,
+			HTTPClient: server.Client(),
+			Token:      "test-token",
+			limiter:    newRequestLimiter(0, 20),
+		}
+
+		start := time.Now()
+		for i := 0; i < 4; i++ {
+			if err := client.DoRequest(context.Background(), http.MethodGet, "/api/zones/list", nil, nil); err != nil {
+				t.Fatalf("Expected the request to succeed, got %v", err)
+			}
+		}
+
+		// The first request starts immediately, the other three wait 50ms each
+		if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+			t.Errorf("Expected 4 requests at 20 per second to take at least 150ms, took %s", elapsed)
+		}
+	})
+
+	t.Run("stops waiting when the context is done", func(t *testing.T) {
+		limiter := newRequestLimiter(1, 0)
+		release, err := limiter.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("Expected the first request to start, got %v", err)
+		}
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := limiter.acquire(ctx); err == nil {
+			t.Error("Expected waiting for a slot to end with the context")
+		}
+	})
+
+	t.Run("is disabled without limits", func(t *testing.T) {
+		if limiter := newRequestLimiter(0, 0); limiter != nil {
+			t.Errorf("Expected no limiter, got %+v", limiter)
+		}
+	})
+}
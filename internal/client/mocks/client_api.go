@@ -0,0 +1,442 @@
+// Package mocks provides test doubles for the Technitium client.
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// ClientAPI is a testify mock implementing client.ClientAPI
+type ClientAPI struct {
+	mock.Mock
+}
+
+// Ensure the mock satisfies the client interface
+var _ client.ClientAPI = &ClientAPI{}
+
+// NewClientAPI creates a mock and registers its expectations to be asserted when the test ends
+func NewClientAPI(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ClientAPI {
+	m := &ClientAPI{}
+	m.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+
+func (m *ClientAPI) DoRequest(ctx context.Context, method, endpoint string, body interface{}, result interface{}) error {
+	args := m.Called(ctx, method, endpoint, body, result)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) ListZones(ctx context.Context) ([]client.Zone, error) {
+	args := m.Called(ctx)
+	zones, _ := args.Get(0).([]client.Zone)
+	return zones, args.Error(1)
+}
+
+func (m *ClientAPI) ListZonesPage(ctx context.Context, pageNumber, zonesPerPage int) (*client.ZoneListResponse, error) {
+	args := m.Called(ctx, pageNumber, zonesPerPage)
+	page, _ := args.Get(0).(*client.ZoneListResponse)
+	return page, args.Error(1)
+}
+
+func (m *ClientAPI) GetZone(ctx context.Context, zoneName string) (*client.ZoneInfo, error) {
+	args := m.Called(ctx, zoneName)
+	zone, _ := args.Get(0).(*client.ZoneInfo)
+	return zone, args.Error(1)
+}
+
+func (m *ClientAPI) ZoneExists(ctx context.Context, zoneName string) (bool, error) {
+	args := m.Called(ctx, zoneName)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *ClientAPI) CreateZone(ctx context.Context, zoneName, zoneType string) error {
+	args := m.Called(ctx, zoneName, zoneType)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) DeleteZone(ctx context.Context, zoneName string) error {
+	args := m.Called(ctx, zoneName)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) EnableZone(ctx context.Context, zoneName string) error {
+	args := m.Called(ctx, zoneName)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) DisableZone(ctx context.Context, zoneName string) error {
+	args := m.Called(ctx, zoneName)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) ResyncZone(ctx context.Context, zoneName string) error {
+	args := m.Called(ctx, zoneName)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) GetZoneOptions(ctx context.Context, zoneName string) (*client.ZoneOptions, error) {
+	args := m.Called(ctx, zoneName)
+	options, _ := args.Get(0).(*client.ZoneOptions)
+	return options, args.Error(1)
+}
+
+func (m *ClientAPI) SetZoneOptions(ctx context.Context, zoneName string, options map[string]string) error {
+	args := m.Called(ctx, zoneName, options)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) BumpZoneSerial(ctx context.Context, zoneName string, useSerialDateScheme bool) (uint32, error) {
+	args := m.Called(ctx, zoneName, useSerialDateScheme)
+	serial, _ := args.Get(0).(uint32)
+	return serial, args.Error(1)
+}
+
+func (m *ClientAPI) GetZoneSOA(ctx context.Context, zoneName string) (*client.DNSRecord, error) {
+	args := m.Called(ctx, zoneName)
+	soa, _ := args.Get(0).(*client.DNSRecord)
+	return soa, args.Error(1)
+}
+
+func (m *ClientAPI) ExportZone(ctx context.Context, zoneName string) (string, error) {
+	args := m.Called(ctx, zoneName)
+	return args.String(0), args.Error(1)
+}
+
+func (m *ClientAPI) ImportZone(ctx context.Context, zoneName, content string, options client.ImportZoneOptions) error {
+	args := m.Called(ctx, zoneName, content, options)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) GetDNSSECProperties(ctx context.Context, zoneName string) (*client.DNSSECProperties, error) {
+	args := m.Called(ctx, zoneName)
+	properties, _ := args.Get(0).(*client.DNSSECProperties)
+	return properties, args.Error(1)
+}
+
+func (m *ClientAPI) SignZone(ctx context.Context, zoneName string, options client.DNSSECSignOptions) error {
+	args := m.Called(ctx, zoneName, options)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) UnsignZone(ctx context.Context, zoneName string) error {
+	args := m.Called(ctx, zoneName)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) ConvertZoneToNSEC(ctx context.Context, zoneName string) error {
+	args := m.Called(ctx, zoneName)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) ConvertZoneToNSEC3(ctx context.Context, zoneName string) error {
+	args := m.Called(ctx, zoneName)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) UpdateNSEC3Params(ctx context.Context, zoneName string, iterations, saltLength int64) error {
+	args := m.Called(ctx, zoneName, iterations, saltLength)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) UpdateDNSKeyTTL(ctx context.Context, zoneName string, ttl int64) error {
+	args := m.Called(ctx, zoneName, ttl)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) AddDNSSECPrivateKey(ctx context.Context, zoneName string, options client.DNSSECKeyOptions) error {
+	args := m.Called(ctx, zoneName, options)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) UpdateDNSSECPrivateKey(ctx context.Context, zoneName string, keyTag, rolloverDays int64) error {
+	args := m.Called(ctx, zoneName, keyTag, rolloverDays)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) DeleteDNSSECPrivateKey(ctx context.Context, zoneName string, keyTag int64) error {
+	args := m.Called(ctx, zoneName, keyTag)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) PublishAllDNSSECPrivateKeys(ctx context.Context, zoneName string) error {
+	args := m.Called(ctx, zoneName)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) RolloverDNSKey(ctx context.Context, zoneName string, keyTag int64) error {
+	args := m.Called(ctx, zoneName, keyTag)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) RetireDNSKey(ctx context.Context, zoneName string, keyTag int64) error {
+	args := m.Called(ctx, zoneName, keyTag)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) GetDSInfo(ctx context.Context, zoneName string) (*client.DSInfo, error) {
+	args := m.Called(ctx, zoneName)
+	info, _ := args.Get(0).(*client.DSInfo)
+	return info, args.Error(1)
+}
+
+func (m *ClientAPI) ListDHCPScopes(ctx context.Context) ([]client.DHCPScope, error) {
+	args := m.Called(ctx)
+	scopes, _ := args.Get(0).([]client.DHCPScope)
+	return scopes, args.Error(1)
+}
+
+func (m *ClientAPI) ListDHCPLeases(ctx context.Context) ([]client.DHCPLease, error) {
+	args := m.Called(ctx)
+	leases, _ := args.Get(0).([]client.DHCPLease)
+	return leases, args.Error(1)
+}
+
+func (m *ClientAPI) GetDNSSettings(ctx context.Context) (*client.DNSSettings, error) {
+	args := m.Called(ctx)
+	settings, _ := args.Get(0).(*client.DNSSettings)
+	return settings, args.Error(1)
+}
+
+func (m *ClientAPI) SetDNSSettings(ctx context.Context, settings map[string]string) (*client.DNSSettings, error) {
+	args := m.Called(ctx, settings)
+	updated, _ := args.Get(0).(*client.DNSSettings)
+	return updated, args.Error(1)
+}
+
+func (m *ClientAPI) ZoneListContains(ctx context.Context, list client.ZoneList, domain string) (bool, error) {
+	args := m.Called(ctx, list, domain)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *ClientAPI) ListZoneListDomains(ctx context.Context, list client.ZoneList) ([]string, error) {
+	args := m.Called(ctx, list)
+	domains, _ := args.Get(0).([]string)
+	return domains, args.Error(1)
+}
+
+func (m *ClientAPI) AddToZoneList(ctx context.Context, list client.ZoneList, domain string) error {
+	args := m.Called(ctx, list, domain)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) DeleteFromZoneList(ctx context.Context, list client.ZoneList, domain string) error {
+	args := m.Called(ctx, list, domain)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) ImportToZoneList(ctx context.Context, list client.ZoneList, domains []string) error {
+	args := m.Called(ctx, list, domains)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) ExportZoneList(ctx context.Context, list client.ZoneList) ([]string, error) {
+	args := m.Called(ctx, list)
+	domains, _ := args.Get(0).([]string)
+	return domains, args.Error(1)
+}
+
+func (m *ClientAPI) GetDashboardStats(ctx context.Context, period client.StatsPeriod, start, end string) (*client.DashboardStatsResponse, error) {
+	args := m.Called(ctx, period, start, end)
+	stats, _ := args.Get(0).(*client.DashboardStatsResponse)
+	return stats, args.Error(1)
+}
+
+func (m *ClientAPI) ResolveQuery(ctx context.Context, options client.DNSClientQueryOptions) (*client.DNSClientResult, error) {
+	args := m.Called(ctx, options)
+	result, _ := args.Get(0).(*client.DNSClientResult)
+	return result, args.Error(1)
+}
+
+func (m *ClientAPI) BrowseCache(ctx context.Context, domain string) (*client.BrowseCacheResponse, error) {
+	args := m.Called(ctx, domain)
+	response, _ := args.Get(0).(*client.BrowseCacheResponse)
+	return response, args.Error(1)
+}
+
+func (m *ClientAPI) DeleteCachedZone(ctx context.Context, domain string) error {
+	args := m.Called(ctx, domain)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) FlushCache(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) ListGroups(ctx context.Context) ([]client.Group, error) {
+	args := m.Called(ctx)
+	groups, _ := args.Get(0).([]client.Group)
+	return groups, args.Error(1)
+}
+
+func (m *ClientAPI) GetGroup(ctx context.Context, name string) (*client.Group, error) {
+	args := m.Called(ctx, name)
+	group, _ := args.Get(0).(*client.Group)
+	return group, args.Error(1)
+}
+
+func (m *ClientAPI) CreateGroup(ctx context.Context, name, description string) (*client.Group, error) {
+	args := m.Called(ctx, name, description)
+	group, _ := args.Get(0).(*client.Group)
+	return group, args.Error(1)
+}
+
+func (m *ClientAPI) SetGroup(ctx context.Context, name, description string, members []string) (*client.Group, error) {
+	args := m.Called(ctx, name, description, members)
+	group, _ := args.Get(0).(*client.Group)
+	return group, args.Error(1)
+}
+
+func (m *ClientAPI) DeleteGroup(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) GetPermissions(ctx context.Context, section client.PermissionSection) (*client.Permissions, error) {
+	args := m.Called(ctx, section)
+	permissions, _ := args.Get(0).(*client.Permissions)
+	return permissions, args.Error(1)
+}
+
+func (m *ClientAPI) SetPermissions(ctx context.Context, section client.PermissionSection, permissions *client.Permissions) (*client.Permissions, error) {
+	args := m.Called(ctx, section, permissions)
+	updated, _ := args.Get(0).(*client.Permissions)
+	return updated, args.Error(1)
+}
+
+func (m *ClientAPI) GetZonePermissions(ctx context.Context, zoneName string) (*client.Permissions, error) {
+	args := m.Called(ctx, zoneName)
+	permissions, _ := args.Get(0).(*client.Permissions)
+	return permissions, args.Error(1)
+}
+
+func (m *ClientAPI) SetZonePermissions(ctx context.Context, zoneName string, permissions *client.Permissions) (*client.Permissions, error) {
+	args := m.Called(ctx, zoneName, permissions)
+	updated, _ := args.Get(0).(*client.Permissions)
+	return updated, args.Error(1)
+}
+
+func (m *ClientAPI) ListSessions(ctx context.Context) ([]client.Session, error) {
+	args := m.Called(ctx)
+	sessions, _ := args.Get(0).([]client.Session)
+	return sessions, args.Error(1)
+}
+
+func (m *ClientAPI) CreateAPIToken(ctx context.Context, username, tokenName string) (*client.APIToken, error) {
+	args := m.Called(ctx, username, tokenName)
+	token, _ := args.Get(0).(*client.APIToken)
+	return token, args.Error(1)
+}
+
+func (m *ClientAPI) DeleteSession(ctx context.Context, partialToken string) error {
+	args := m.Called(ctx, partialToken)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) AddRecord(ctx context.Context, record client.AddRecordRequest) (*client.AddRecordResponse, error) {
+	args := m.Called(ctx, record)
+	resp, _ := args.Get(0).(*client.AddRecordResponse)
+	return resp, args.Error(1)
+}
+
+func (m *ClientAPI) GetRecords(ctx context.Context, zone, domain string, listZone bool) (*client.GetRecordsResponse, error) {
+	args := m.Called(ctx, zone, domain, listZone)
+	resp, _ := args.Get(0).(*client.GetRecordsResponse)
+	return resp, args.Error(1)
+}
+
+func (m *ClientAPI) UpdateRecord(ctx context.Context, record client.UpdateRecordRequest) (*client.UpdateRecordResponse, error) {
+	args := m.Called(ctx, record)
+	resp, _ := args.Get(0).(*client.UpdateRecordResponse)
+	return resp, args.Error(1)
+}
+
+func (m *ClientAPI) DeleteRecord(ctx context.Context, record client.DeleteRecordRequest) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) ListApps(ctx context.Context) ([]client.App, error) {
+	args := m.Called(ctx)
+	apps, _ := args.Get(0).([]client.App)
+	return apps, args.Error(1)
+}
+
+func (m *ClientAPI) ListStoreApps(ctx context.Context) ([]client.StoreApp, error) {
+	args := m.Called(ctx)
+	apps, _ := args.Get(0).([]client.StoreApp)
+	return apps, args.Error(1)
+}
+
+func (m *ClientAPI) DownloadAndInstallApp(ctx context.Context, name, appURL string) (*client.App, error) {
+	args := m.Called(ctx, name, appURL)
+	app, _ := args.Get(0).(*client.App)
+	return app, args.Error(1)
+}
+
+func (m *ClientAPI) DownloadAndUpdateApp(ctx context.Context, name, appURL string) (*client.App, error) {
+	args := m.Called(ctx, name, appURL)
+	app, _ := args.Get(0).(*client.App)
+	return app, args.Error(1)
+}
+
+func (m *ClientAPI) InstallApp(ctx context.Context, name string, appData []byte) (*client.App, error) {
+	args := m.Called(ctx, name, appData)
+	app, _ := args.Get(0).(*client.App)
+	return app, args.Error(1)
+}
+
+func (m *ClientAPI) UpdateApp(ctx context.Context, name string, appData []byte) (*client.App, error) {
+	args := m.Called(ctx, name, appData)
+	app, _ := args.Get(0).(*client.App)
+	return app, args.Error(1)
+}
+
+func (m *ClientAPI) UninstallApp(ctx context.Context, name string) error {
+	args := m.Called(ctx, name)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) GetAppConfig(ctx context.Context, name string) (*string, error) {
+	args := m.Called(ctx, name)
+	config, _ := args.Get(0).(*string)
+	return config, args.Error(1)
+}
+
+func (m *ClientAPI) SetAppConfig(ctx context.Context, name, config string) error {
+	args := m.Called(ctx, name, config)
+	return args.Error(0)
+}
+
+func (m *ClientAPI) StrictConsistency() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *ClientAPI) DefaultZone() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *ClientAPI) DefaultTTL() (int64, bool) {
+	args := m.Called()
+	ttl, _ := args.Get(0).(int64)
+	return ttl, args.Bool(1)
+}
+
+func (m *ClientAPI) SupportsFeature(ctx context.Context, feature client.Feature) (bool, string, string, error) {
+	args := m.Called(ctx, feature)
+	return args.Bool(0), args.String(1), args.String(2), args.Error(3)
+}
+
+func (m *ClientAPI) ExperimentEnabled(feature client.ExperimentalFeature) bool {
+	args := m.Called(feature)
+	return args.Bool(0)
+}
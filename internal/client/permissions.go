@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// UserPermission is the access of a user to a section or zone
+type UserPermission struct {
+	Username  string `json:"username"`
+	CanView   bool   `json:"canView"`
+	CanModify bool   `json:"canModify"`
+	CanDelete bool   `json:"canDelete"`
+}
+
+// GroupPermission is the access of a group to a section or zone
+type GroupPermission struct {
+	Name      string `json:"name"`
+	CanView   bool   `json:"canView"`
+	CanModify bool   `json:"canModify"`
+	CanDelete bool   `json:"canDelete"`
+}
+
+// Permissions holds the user and group permissions of a section, or of a zone when SubItem is set
+type Permissions struct {
+	Section          string            `json:"section"`
+	SubItem          string            `json:"subItem,omitempty"`
+	UserPermissions  []UserPermission  `json:"userPermissions"`
+	GroupPermissions []GroupPermission `json:"groupPermissions"`
+}
+
+// GetPermissions retrieves the permissions of a section
+func (c *Client) GetPermissions(ctx context.Context, section PermissionSection) (*Permissions, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/permissions/get").Param("section", string(section)).Endpoint()
+
+	var response Permissions
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get permissions of section %s: %w", section, err)
+	}
+
+	return &response, nil
+}
+
+// SetPermissions replaces the permissions of a section
+func (c *Client) SetPermissions(ctx context.Context, section PermissionSection, permissions *Permissions) (*Permissions, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/permissions/set").
+		Param("section", string(section)).
+		Params(permissionTables(permissions)).
+		Endpoint()
+
+	var response Permissions
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to set permissions of section %s: %w", section, err)
+	}
+
+	return &response, nil
+}
+
+// GetZonePermissions retrieves the permissions of a zone
+func (c *Client) GetZonePermissions(ctx context.Context, zoneName string) (*Permissions, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/permissions/get").Param("zone", zoneName).Endpoint()
+
+	var response Permissions
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get permissions of zone %s: %w", zoneName, err)
+	}
+
+	return &response, nil
+}
+
+// SetZonePermissions replaces the permissions of a zone
+func (c *Client) SetZonePermissions(ctx context.Context, zoneName string, permissions *Permissions) (*Permissions, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/permissions/set").
+		Param("zone", zoneName).
+		Params(permissionTables(permissions)).
+		Endpoint()
+
+	var response Permissions
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to set permissions of zone %s: %w", zoneName, err)
+	}
+
+	return &response, nil
+}
+
+// permissionTables renders the permissions as the pipe separated tables of the set APIs, in which
+// each row is a name followed by the view, modify and delete flags. Both tables are always sent,
+// so removing the last row clears the table.
+func permissionTables(permissions *Permissions) map[string]string {
+	var users, groups []string
+	for _, p := range permissions.UserPermissions {
+		users = append(users, permissionRow(p.Username, p.CanView, p.CanModify, p.CanDelete))
+	}
+	for _, p := range permissions.GroupPermissions {
+		groups = append(groups, permissionRow(p.Name, p.CanView, p.CanModify, p.CanDelete))
+	}
+
+	return map[string]string{
+		"userPermissions":  strings.Join(users, "|"),
+		"groupPermissions": strings.Join(groups, "|"),
+	}
+}
+
+func permissionRow(name string, canView, canModify, canDelete bool) string {
+	return strings.Join([]string{name, strconv.FormatBool(canView), strconv.FormatBool(canModify), strconv.FormatBool(canDelete)}, "|")
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPermissions(t *testing.T) {
+	var setQuery map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := requestParams(r)
+
+		switch r.URL.Path {
+		case "/api/admin/permissions/get":
+			if query.Get("section") != "Zones" {
+				t.Errorf("Expected section Zones, got %s", query.Get("section"))
+			}
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"section": "Zones", "userPermissions": [],
+				"groupPermissions": [{"name": "Administrators", "canView": true, "canModify": true, "canDelete": true}]}}`))
+		case "/api/zones/permissions/get":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"section": "Zones", "subItem": "example.com",
+				"userPermissions": [{"username": "alice", "canView": true, "canModify": false, "canDelete": false}], "groupPermissions": []}}`))
+		case "/api/admin/permissions/set", "/api/zones/permissions/set":
+			setQuery = map[string]string{
+				"userPermissions":  query.Get("userPermissions"),
+				"groupPermissions": query.Get("groupPermissions"),
+			}
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"section": "Zones", "userPermissions": [], "groupPermissions": []}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	permissions, err := client.GetPermissions(context.Background(), PermissionSectionZones)
+	if err != nil || len(permissions.GroupPermissions) != 1 || !permissions.GroupPermissions[0].CanDelete {
+		t.Fatalf("GetPermissions returned %+v (%v)", permissions, err)
+	}
+
+	zonePermissions, err := client.GetZonePermissions(context.Background(), "example.com")
+	if err != nil || zonePermissions.SubItem != "example.com" || zonePermissions.UserPermissions[0].Username != "alice" {
+		t.Fatalf("GetZonePermissions returned %+v (%v)", zonePermissions, err)
+	}
+
+	permissions.UserPermissions = []UserPermission{{Username: "alice", CanView: true}}
+	permissions.GroupPermissions = append(permissions.GroupPermissions, GroupPermission{Name: "DNS Operators", CanView: true, CanModify: true})
+	if _, err := client.SetPermissions(context.Background(), PermissionSectionZones, permissions); err != nil {
+		t.Fatalf("SetPermissions failed: %v", err)
+	}
+	if setQuery["userPermissions"] != "alice|true|false|false" ||
+		setQuery["groupPermissions"] != "Administrators|true|true|true|DNS Operators|true|true|false" {
+		t.Errorf("Unexpected permission tables: %v", setQuery)
+	}
+
+	// An empty table is sent so the last permission is removed
+	if _, err := client.SetZonePermissions(context.Background(), "example.com", &Permissions{}); err != nil {
+		t.Fatalf("SetZonePermissions failed: %v", err)
+	}
+	if setQuery["userPermissions"] != "" || setQuery["groupPermissions"] != "" {
+		t.Errorf("Expected empty permission tables, got %v", setQuery)
+	}
+}
@@ -0,0 +1,452 @@
+package client
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RecordData is the type specific data of a record, e.g. ARecordData or MXRecordData. It encodes
+// the parameters identifying an existing record and the values of a new record, so the parameter
+// names of every record type are kept in one place instead of at each call site.
+type RecordData interface {
+	// RecordType returns the type of the record, e.g. A or MX
+	RecordType() string
+
+	// encode sets the parameters of the data for the given use
+	encode(params url.Values, mode recordParamMode)
+}
+
+// recordParamMode selects which parameters RecordData encodes
+type recordParamMode int
+
+const (
+	// recordParamsAdd encodes the values of a record being added
+	recordParamsAdd recordParamMode = iota
+	// recordParamsCurrent encodes the values identifying the record to update or delete
+	recordParamsCurrent
+	// recordParamsNew encodes the new values of an updated record, prefixed with "new"
+	recordParamsNew
+)
+
+// name returns the parameter name for the mode, e.g. newIpAddress for the new value of ipAddress
+func (m recordParamMode) name(param string) string {
+	if m != recordParamsNew {
+		return param
+	}
+	return "new" + strings.ToUpper(param[:1]) + param[1:]
+}
+
+// setsValues reports whether the mode sets record values rather than identifying a record
+func (m recordParamMode) setsValues() bool {
+	return m != recordParamsCurrent
+}
+
+// setOptionalInt sets an integer parameter unless value is nil
+func setOptionalInt(params url.Values, key string, value *int64) {
+	if value != nil {
+		params.Set(key, strconv.FormatInt(*value, 10))
+	}
+}
+
+// setOptionalString sets a string parameter unless value is empty
+func setOptionalString(params url.Values, key, value string) {
+	if value != "" {
+		params.Set(key, value)
+	}
+}
+
+// ARecordData is the data of an A record
+type ARecordData struct {
+	IPAddress string
+	// Ptr adds or updates the reverse PTR record, CreatePtrZone creates its reverse zone if missing
+	Ptr           bool
+	CreatePtrZone bool
+}
+
+func (d ARecordData) RecordType() string { return "A" }
+
+func (d ARecordData) encode(params url.Values, mode recordParamMode) {
+	encodeAddress(params, mode, d.IPAddress, d.Ptr, d.CreatePtrZone)
+}
+
+// AAAARecordData is the data of an AAAA record
+type AAAARecordData struct {
+	IPAddress string
+	// Ptr adds or updates the reverse PTR record, CreatePtrZone creates its reverse zone if missing
+	Ptr           bool
+	CreatePtrZone bool
+}
+
+func (d AAAARecordData) RecordType() string { return "AAAA" }
+
+func (d AAAARecordData) encode(params url.Values, mode recordParamMode) {
+	encodeAddress(params, mode, d.IPAddress, d.Ptr, d.CreatePtrZone)
+}
+
+// encodeAddress encodes the data of A and AAAA records. The server maintains the reverse record
+// on add and update only.
+func encodeAddress(params url.Values, mode recordParamMode, ipAddress string, ptr, createPtrZone bool) {
+	params.Set(mode.name("ipAddress"), ipAddress)
+	if mode.setsValues() && ptr {
+		params.Set("ptr", "true")
+		if createPtrZone {
+			params.Set("createPtrZone", "true")
+		}
+	}
+}
+
+// CNAMERecordData is the data of a CNAME record
+type CNAMERecordData struct {
+	CNAME string
+}
+
+func (d CNAMERecordData) RecordType() string { return "CNAME" }
+
+func (d CNAMERecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("cname"), d.CNAME)
+}
+
+// MXRecordData is the data of an MX record
+type MXRecordData struct {
+	Exchange   string
+	Preference *int64
+}
+
+func (d MXRecordData) RecordType() string { return "MX" }
+
+func (d MXRecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("exchange"), d.Exchange)
+	setOptionalInt(params, mode.name("preference"), d.Preference)
+}
+
+// TXTRecordData is the data of a TXT record. The server adds the quotes around the text itself.
+type TXTRecordData struct {
+	Text string
+	// SplitText splits Text on new lines into several character strings
+	SplitText bool
+}
+
+func (d TXTRecordData) RecordType() string { return "TXT" }
+
+func (d TXTRecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("text"), d.Text)
+	if d.SplitText {
+		params.Set(mode.name("splitText"), "true")
+	}
+}
+
+// PTRRecordData is the data of a PTR record
+type PTRRecordData struct {
+	PTRName string
+}
+
+func (d PTRRecordData) RecordType() string { return "PTR" }
+
+func (d PTRRecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("ptrName"), d.PTRName)
+}
+
+// NSRecordData is the data of an NS record
+type NSRecordData struct {
+	NameServer string
+}
+
+func (d NSRecordData) RecordType() string { return "NS" }
+
+func (d NSRecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("nameServer"), d.NameServer)
+}
+
+// SRVRecordData is the data of an SRV record
+type SRVRecordData struct {
+	Target   string
+	Priority *int64
+	Weight   *int64
+	Port     *int64
+}
+
+func (d SRVRecordData) RecordType() string { return "SRV" }
+
+func (d SRVRecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("target"), d.Target)
+	setOptionalInt(params, mode.name("priority"), d.Priority)
+	setOptionalInt(params, mode.name("weight"), d.Weight)
+	setOptionalInt(params, mode.name("port"), d.Port)
+}
+
+// CAARecordData is the data of a CAA record
+type CAARecordData struct {
+	Flags int64
+	Tag   string
+	Value string
+}
+
+func (d CAARecordData) RecordType() string { return "CAA" }
+
+func (d CAARecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("flags"), strconv.FormatInt(d.Flags, 10))
+	params.Set(mode.name("tag"), d.Tag)
+	params.Set(mode.name("value"), d.Value)
+}
+
+// SVCBRecordData is the data of an SVCB record, a priority of 0 selecting alias mode
+type SVCBRecordData struct {
+	Priority   int64
+	TargetName string
+	Params     map[string]string
+}
+
+func (d SVCBRecordData) RecordType() string { return "SVCB" }
+
+func (d SVCBRecordData) encode(params url.Values, mode recordParamMode) {
+	encodeServiceBinding(params, mode, d.Priority, d.TargetName, d.Params)
+}
+
+// HTTPSRecordData is the data of an HTTPS record, a priority of 0 selecting alias mode
+type HTTPSRecordData struct {
+	Priority   int64
+	TargetName string
+	Params     map[string]string
+}
+
+func (d HTTPSRecordData) RecordType() string { return "HTTPS" }
+
+func (d HTTPSRecordData) encode(params url.Values, mode recordParamMode) {
+	encodeServiceBinding(params, mode, d.Priority, d.TargetName, d.Params)
+}
+
+// encodeServiceBinding encodes the data of SVCB and HTTPS records
+func encodeServiceBinding(params url.Values, mode recordParamMode, priority int64, targetName string, svcParams map[string]string) {
+	params.Set(mode.name("svcPriority"), strconv.FormatInt(priority, 10))
+	params.Set(mode.name("svcTargetName"), targetName)
+	params.Set(mode.name("svcParams"), FormatSvcParams(svcParams))
+}
+
+// FormatSvcParams encodes SVCB and HTTPS service parameters in the pipe separated key and value
+// format of the API, ordered by key. An empty set is sent as "false", which clears the parameters.
+func FormatSvcParams(params map[string]string) string {
+	if len(params) == 0 {
+		return "false"
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(params)*2)
+	for _, key := range keys {
+		parts = append(parts, key, params[key])
+	}
+	return strings.Join(parts, "|")
+}
+
+// SSHFPRecordData is the data of an SSHFP record
+type SSHFPRecordData struct {
+	Algorithm       string
+	FingerprintType string
+	Fingerprint     string
+}
+
+func (d SSHFPRecordData) RecordType() string { return "SSHFP" }
+
+func (d SSHFPRecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("sshfpAlgorithm"), d.Algorithm)
+	params.Set(mode.name("sshfpFingerprintType"), d.FingerprintType)
+	params.Set(mode.name("sshfpFingerprint"), d.Fingerprint)
+}
+
+// TLSARecordData is the data of a TLSA record
+type TLSARecordData struct {
+	CertificateUsage           string
+	Selector                   string
+	MatchingType               string
+	CertificateAssociationData string
+}
+
+func (d TLSARecordData) RecordType() string { return "TLSA" }
+
+func (d TLSARecordData) encode(params url.Values, mode recordParamMode) {
+	params.Set(mode.name("tlsaCertificateUsage"), d.CertificateUsage)
+	params.Set(mode.name("tlsaSelector"), d.Selector)
+	params.Set(mode.name("tlsaMatchingType"), d.MatchingType)
+	params.Set(mode.name("tlsaCertificateAssociationData"), d.CertificateAssociationData)
+}
+
+// FWDRecordData is the data of an FWD record. The protocol defaults to DefaultForwarderProtocol.
+// Only the protocol and forwarder identify the record, the other values are set as they are.
+type FWDRecordData struct {
+	Protocol         ForwarderProtocol
+	Forwarder        string
+	Priority         *int64
+	DNSSECValidation *bool
+	ProxyType        ProxyType
+	ProxyAddress     string
+	ProxyPort        *int64
+	ProxyUsername    string
+	ProxyPassword    string
+}
+
+func (d FWDRecordData) RecordType() string { return "FWD" }
+
+func (d FWDRecordData) encode(params url.Values, mode recordParamMode) {
+	protocol := d.Protocol
+	if protocol == "" {
+		protocol = DefaultForwarderProtocol
+	}
+	params.Set(mode.name("protocol"), string(protocol))
+	params.Set(mode.name("forwarder"), d.Forwarder)
+
+	setOptionalInt(params, "forwarderPriority", d.Priority)
+	if d.DNSSECValidation != nil {
+		params.Set("dnssecValidation", strconv.FormatBool(*d.DNSSECValidation))
+	}
+	setOptionalString(params, "proxyType", string(d.ProxyType))
+	setOptionalString(params, "proxyAddress", d.ProxyAddress)
+	setOptionalInt(params, "proxyPort", d.ProxyPort)
+	setOptionalString(params, "proxyUsername", d.ProxyUsername)
+	setOptionalString(params, "proxyPassword", d.ProxyPassword)
+}
+
+// APPRecordData is the data of an APP record. Only one APP record exists per name, so the record
+// is identified by its name and type and the app values are only sent to set them.
+type APPRecordData struct {
+	AppName    string
+	ClassPath  string
+	RecordData string
+}
+
+func (d APPRecordData) RecordType() string { return "APP" }
+
+func (d APPRecordData) encode(params url.Values, mode recordParamMode) {
+	if !mode.setsValues() {
+		return
+	}
+	params.Set("appName", d.AppName)
+	params.Set("classPath", d.ClassPath)
+	params.Set("recordData", d.RecordData)
+}
+
+// SOARecordData is the data of an SOA record. Every zone has a single SOA record, so it is
+// identified by the zone alone and its values are set without the "new" prefix.
+type SOARecordData struct {
+	PrimaryNameServer string
+	ResponsiblePerson string
+	Serial            uint32
+	Refresh           int64
+	Retry             int64
+	Expire            int64
+	Minimum           int64
+	// UseSerialDateScheme switches the serial to the YYYYMMDDNN date scheme when set
+	UseSerialDateScheme *bool
+}
+
+func (d SOARecordData) RecordType() string { return "SOA" }
+
+func (d SOARecordData) encode(params url.Values, mode recordParamMode) {
+	if !mode.setsValues() {
+		return
+	}
+	params.Set("primaryNameServer", d.PrimaryNameServer)
+	params.Set("responsiblePerson", d.ResponsiblePerson)
+	params.Set("serial", strconv.FormatUint(uint64(d.Serial), 10))
+	params.Set("refresh", strconv.FormatInt(d.Refresh, 10))
+	params.Set("retry", strconv.FormatInt(d.Retry, 10))
+	params.Set("expire", strconv.FormatInt(d.Expire, 10))
+	params.Set("minimum", strconv.FormatInt(d.Minimum, 10))
+	if d.UseSerialDateScheme != nil {
+		params.Set("useSerialDateScheme", strconv.FormatBool(*d.UseSerialDateScheme))
+	}
+}
+
+// AddRecordRequest describes a record to add
+type AddRecordRequest struct {
+	Zone   string
+	Domain string
+	TTL    int64
+	Data   RecordData
+
+	Comments string
+	// ExpiryTTL schedules the deletion of the record, in seconds after it was added
+	ExpiryTTL *int64
+	// Overwrite replaces all existing records of the same name and type
+	Overwrite bool
+}
+
+// encode adds the parameters of the records/add call to the request
+func (r AddRecordRequest) encode(request *Request) *Request {
+	request.Param("domain", r.Domain).
+		Param("zone", r.Zone).
+		Param("type", r.Data.RecordType()).
+		IntParam("ttl", r.TTL).
+		BoolParam("overwrite", r.Overwrite)
+	r.Data.encode(request.Values(), recordParamsAdd)
+	setOptionalString(request.Values(), "comments", r.Comments)
+	setOptionalInt(request.Values(), "expiryTtl", r.ExpiryTTL)
+	return request
+}
+
+// UpdateRecordRequest describes the update of a record from its current data to new data. Current
+// may be nil for record types identified by name and type alone, such as SOA; New may be nil to
+// only change the TTL, comments or state of the record.
+type UpdateRecordRequest struct {
+	Zone    string
+	Domain  string
+	TTL     int64
+	Current RecordData
+	New     RecordData
+
+	// Comments, Disable and ExpiryTTL keep their current value when nil
+	Comments  *string
+	Disable   *bool
+	ExpiryTTL *int64
+}
+
+// recordType returns the type of the updated record
+func (r UpdateRecordRequest) recordType() string {
+	if r.New != nil {
+		return r.New.RecordType()
+	}
+	return r.Current.RecordType()
+}
+
+// encode adds the parameters of the records/update call to the request
+func (r UpdateRecordRequest) encode(request *Request) *Request {
+	request.Param("domain", r.Domain).
+		Param("zone", r.Zone).
+		Param("type", r.recordType()).
+		IntParam("ttl", r.TTL)
+	if r.Current != nil {
+		r.Current.encode(request.Values(), recordParamsCurrent)
+	}
+	if r.New != nil {
+		r.New.encode(request.Values(), recordParamsNew)
+	}
+	if r.Comments != nil {
+		request.Param("comments", *r.Comments)
+	}
+	if r.Disable != nil {
+		request.BoolParam("disable", *r.Disable)
+	}
+	setOptionalInt(request.Values(), "expiryTtl", r.ExpiryTTL)
+	return request
+}
+
+// DeleteRecordRequest identifies a record to delete
+type DeleteRecordRequest struct {
+	Zone   string
+	Domain string
+	Data   RecordData
+}
+
+// encode adds the parameters of the records/delete call to the request
+func (r DeleteRecordRequest) encode(request *Request) *Request {
+	request.Param("domain", r.Domain).
+		Param("zone", r.Zone).
+		Param("type", r.Data.RecordType())
+	r.Data.encode(request.Values(), recordParamsCurrent)
+	return request
+}
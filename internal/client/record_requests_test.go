@@ -0,0 +1,175 @@
+package client
+
+import (
+	"net/url"
+	"testing"
+)
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestAddRecordRequestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  AddRecordRequest
+		expected string
+	}{
+		{
+			name: "A record with reverse record",
+			request: AddRecordRequest{
+				Zone: "example.com", Domain: "www.example.com", TTL: 300,
+				Data: ARecordData{IPAddress: "192.0.2.1", Ptr: true, CreatePtrZone: true},
+			},
+			expected: "createPtrZone=true&domain=www.example.com&ipAddress=192.0.2.1&overwrite=false&ptr=true&ttl=300&type=A&zone=example.com",
+		},
+		{
+			name: "MX record with comments and expiry",
+			request: AddRecordRequest{
+				Zone: "example.com", Domain: "example.com", TTL: 3600,
+				Data:     MXRecordData{Exchange: "mail.example.com", Preference: int64Ptr(10)},
+				Comments: "mail", ExpiryTTL: int64Ptr(86400), Overwrite: true,
+			},
+			expected: "comments=mail&domain=example.com&exchange=mail.example.com&expiryTtl=86400&overwrite=true&preference=10&ttl=3600&type=MX&zone=example.com",
+		},
+		{
+			name: "HTTPS record without service parameters",
+			request: AddRecordRequest{
+				Zone: "example.com", Domain: "example.com", TTL: 3600,
+				Data: HTTPSRecordData{Priority: 0, TargetName: "cdn.example.net"},
+			},
+			expected: "domain=example.com&overwrite=false&svcParams=false&svcPriority=0&svcTargetName=cdn.example.net&ttl=3600&type=HTTPS&zone=example.com",
+		},
+		{
+			name: "FWD record defaults to UDP",
+			request: AddRecordRequest{
+				Zone: "corp.example.com", Domain: "corp.example.com", TTL: 300,
+				Data: FWDRecordData{Forwarder: "192.0.2.53", DNSSECValidation: boolPtr(true)},
+			},
+			expected: "dnssecValidation=true&domain=corp.example.com&forwarder=192.0.2.53&overwrite=false&protocol=Udp&ttl=300&type=FWD&zone=corp.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.request.encode(NewRequest()).Values().Encode(); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUpdateRecordRequestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  UpdateRecordRequest
+		expected url.Values
+	}{
+		{
+			name: "prefixes the new values",
+			request: UpdateRecordRequest{
+				Zone: "example.com", Domain: "www.example.com", TTL: 600,
+				Current: CNAMERecordData{CNAME: "old.example.com"},
+				New:     CNAMERecordData{CNAME: "new.example.com"},
+			},
+			expected: url.Values{
+				"zone": {"example.com"}, "domain": {"www.example.com"}, "type": {"CNAME"}, "ttl": {"600"},
+				"cname": {"old.example.com"}, "newCname": {"new.example.com"},
+			},
+		},
+		{
+			name: "only prefixes the values identifying FWD records",
+			request: UpdateRecordRequest{
+				Zone: "corp.example.com", Domain: "corp.example.com", TTL: 300,
+				Current: FWDRecordData{Protocol: ForwarderProtocolTls, Forwarder: "192.0.2.53"},
+				New:     FWDRecordData{Protocol: ForwarderProtocolHttps, Forwarder: "https://dns.example/dns-query", Priority: int64Ptr(5)},
+				Disable: boolPtr(false), ExpiryTTL: int64Ptr(0),
+			},
+			expected: url.Values{
+				"zone": {"corp.example.com"}, "domain": {"corp.example.com"}, "type": {"FWD"}, "ttl": {"300"},
+				"protocol": {"Tls"}, "forwarder": {"192.0.2.53"},
+				"newProtocol": {"Https"}, "newForwarder": {"https://dns.example/dns-query"}, "forwarderPriority": {"5"},
+				"disable": {"false"}, "expiryTtl": {"0"},
+			},
+		},
+		{
+			name: "sets SOA values without prefix",
+			request: UpdateRecordRequest{
+				Zone: "example.com", Domain: "example.com", TTL: 900,
+				New: SOARecordData{
+					PrimaryNameServer: "ns1.example.com", ResponsiblePerson: "hostmaster.example.com",
+					Serial: 7, Refresh: 900, Retry: 300, Expire: 604800, Minimum: 900,
+				},
+				Comments: stringPtr(""),
+			},
+			expected: url.Values{
+				"zone": {"example.com"}, "domain": {"example.com"}, "type": {"SOA"}, "ttl": {"900"},
+				"primaryNameServer": {"ns1.example.com"}, "responsiblePerson": {"hostmaster.example.com"},
+				"serial": {"7"}, "refresh": {"900"}, "retry": {"300"}, "expire": {"604800"}, "minimum": {"900"},
+				"comments": {""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.request.encode(NewRequest()).Values(); got.Encode() != tt.expected.Encode() {
+				t.Errorf("Expected %s, got %s", tt.expected.Encode(), got.Encode())
+			}
+		})
+	}
+}
+
+func TestDeleteRecordRequestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     RecordData
+		expected string
+	}{
+		{"TLSA", TLSARecordData{CertificateUsage: "DANE-EE", Selector: "SPKI", MatchingType: "SHA2-256", CertificateAssociationData: "8D02"},
+			"domain=_25._tcp.example.com&tlsaCertificateAssociationData=8D02&tlsaCertificateUsage=DANE-EE&tlsaMatchingType=SHA2-256&tlsaSelector=SPKI&type=TLSA&zone=example.com"},
+		{"A without reverse record options", ARecordData{IPAddress: "192.0.2.1", Ptr: true},
+			"domain=_25._tcp.example.com&ipAddress=192.0.2.1&type=A&zone=example.com"},
+		{"APP identified by name and type", APPRecordData{AppName: "Split Horizon", ClassPath: "SplitHorizon.SimpleAddress"},
+			"domain=_25._tcp.example.com&type=APP&zone=example.com"},
+		{"TXT of several strings", TXTRecordData{Text: "v=DKIM1;\np=MIIB", SplitText: true},
+			"domain=_25._tcp.example.com&splitText=true&text=v%3DDKIM1%3B%0Ap%3DMIIB&type=TXT&zone=example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := DeleteRecordRequest{Zone: "example.com", Domain: "_25._tcp.example.com", Data: tt.data}
+			if got := request.encode(NewRequest()).Values().Encode(); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatSvcParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   map[string]string
+		expected string
+	}{
+		{name: "empty clears params", params: nil, expected: "false"},
+		{name: "single param", params: map[string]string{"port": "8443"}, expected: "port|8443"},
+		{name: "ordered by key", params: map[string]string{"port": "8443", "alpn": "h2,h3", "ipv6hint": "2001:db8::1"}, expected: "alpn|h2,h3|ipv6hint|2001:db8::1|port|8443"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSvcParams(tt.params); got != tt.expected {
+				t.Errorf("FormatSvcParams() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,9 +2,12 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 // DNSRecord represents a DNS record
@@ -17,6 +20,10 @@ type DNSRecord struct {
 	DnssecStatus string        `json:"dnssecStatus"`
 	Comments     string        `json:"comments,omitempty"`
 	LastUsedOn   string        `json:"lastUsedOn,omitempty"`
+	LastModified string        `json:"lastModified,omitempty"`
+	// ExpiryTTL is the number of seconds after its last modification at which the server
+	// deletes the record, 0 when the record does not expire
+	ExpiryTTL int `json:"expiryTtl,omitempty"`
 }
 
 // DNSRecordData represents the record-specific data for a DNS record
@@ -59,6 +66,33 @@ type DNSRecordData struct {
 	ProxyUsername     string `json:"proxyUsername,omitempty"`
 	ProxyPassword     string `json:"proxyPassword,omitempty"`
 
+	// CAA record
+	Flags RecordFlags `json:"flags,omitempty"`
+	Tag   string      `json:"tag,omitempty"`
+	Value string      `json:"value,omitempty"`
+
+	// SSHFP record
+	Algorithm       string `json:"algorithm,omitempty"`
+	FingerprintType string `json:"fingerprintType,omitempty"`
+	Fingerprint     string `json:"fingerprint,omitempty"`
+
+	// TLSA record
+	CertificateUsage           string `json:"certificateUsage,omitempty"`
+	Selector                   string `json:"selector,omitempty"`
+	MatchingType               string `json:"matchingType,omitempty"`
+	CertificateAssociationData string `json:"certificateAssociationData,omitempty"`
+
+	// SVCB and HTTPS records
+	SvcPriority   int               `json:"svcPriority,omitempty"`
+	SvcMode       string            `json:"svcMode,omitempty"`
+	SvcTargetName string            `json:"svcTargetName,omitempty"`
+	SvcParams     map[string]string `json:"svcParams,omitempty"`
+
+	// APP record
+	AppName   string `json:"appName,omitempty"`
+	ClassPath string `json:"classPath,omitempty"`
+	Data      string `json:"data,omitempty"`
+
 	// SOA record
 	PrimaryNameServer string `json:"primaryNameServer,omitempty"`
 	ResponsiblePerson string `json:"responsiblePerson,omitempty"`
@@ -69,6 +103,35 @@ type DNSRecordData struct {
 	Minimum           int    `json:"minimum,omitempty"`
 }
 
+// RecordFlags holds the flags of a record. The API returns a number for CAA records and a
+// comma separated flag list for DNSKEY records, so both are decoded into their string form.
+type RecordFlags string
+
+// UnmarshalJSON accepts both numeric and string flags
+func (f *RecordFlags) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*f = RecordFlags(text)
+		return nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("invalid record flags %s: %w", string(data), err)
+	}
+	*f = RecordFlags(number.String())
+	return nil
+}
+
+// Int returns numeric flags, such as the CAA flags, as an integer
+func (f RecordFlags) Int() int {
+	value, err := strconv.Atoi(string(f))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
 // AddRecordResponse represents the API response when adding a DNS record
 type AddRecordResponse struct {
 	Zone        ZoneInfo  `json:"zone"`
@@ -88,76 +151,185 @@ type GetRecordsResponse struct {
 }
 
 // AddRecord adds a new DNS record
-func (c *Client) AddRecord(ctx context.Context, zone, domain, recordType string, ttl int, options map[string]string) (*AddRecordResponse, error) {
+func (c *Client) AddRecord(ctx context.Context, record AddRecordRequest) (*AddRecordResponse, error) {
 	if err := c.Authenticate(ctx); err != nil {
 		return nil, err
 	}
 
-	params := url.Values{}
-	params.Set("domain", domain)
-	params.Set("zone", zone)
-	params.Set("type", recordType)
-	params.Set("ttl", fmt.Sprintf("%d", ttl))
-
-	// Add additional options based on record type
-	for key, value := range options {
-		params.Set(key, value)
+	if record.Data == nil {
+		return nil, fmt.Errorf("failed to add DNS record: no record data")
 	}
 
-	endpoint := "/api/zones/records/add?" + params.Encode()
+	request := record.encode(NewRequest().Path("/api/zones/records/add"))
+	c.applyDefaultComment(request.Values())
+
+	endpoint := request.Endpoint()
 
 	var response AddRecordResponse
-	if err := c.DoRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+	if err := c.DoRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
 		return nil, fmt.Errorf("failed to add DNS record: %w", err)
 	}
 
 	return &response, nil
 }
 
+// recordsRequest builds the records/get request shared by GetRecords and StreamRecords
+func recordsRequest(zone, domain string, listZone bool) *Request {
+	request := NewRequest().Path("/api/zones/records/get").Param("domain", domain).Param("zone", zone)
+	if listZone {
+		request.BoolParam("listZone", true)
+	}
+	return request
+}
+
 // GetRecords retrieves DNS records for a zone or domain
 func (c *Client) GetRecords(ctx context.Context, zone, domain string, listZone bool) (*GetRecordsResponse, error) {
 	if err := c.Authenticate(ctx); err != nil {
 		return nil, err
 	}
 
-	params := url.Values{}
-	params.Set("domain", domain)
-	params.Set("zone", zone)
+	if c.batchReads && !listZone && zone != "" {
+		return c.getBatchedRecords(ctx, zone, domain)
+	}
+
+	endpoint := recordsRequest(zone, domain, listZone).Endpoint()
 
-	if listZone {
-		params.Set("listZone", "true")
+	var response GetRecordsResponse
+	if err := c.DoRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get DNS records: %w", err)
 	}
 
-	endpoint := "/api/zones/records/get?" + params.Encode()
+	return &response, nil
+}
+
+// getBatchedRecords reads the records of domain from the listing of its whole zone. The listing is
+// requested once and shared by the reads of all domains of the zone while it is cached, so
+// hundreds of records in a zone need a single request instead of one each.
+func (c *Client) getBatchedRecords(ctx context.Context, zone, domain string) (*GetRecordsResponse, error) {
+	endpoint := recordsRequest(zone, zone, true).Endpoint()
 
 	var response GetRecordsResponse
 	if err := c.DoRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
 		return nil, fmt.Errorf("failed to get DNS records: %w", err)
 	}
 
+	domain = strings.TrimSuffix(domain, ".")
+	records := response.Records[:0]
+	for _, record := range response.Records {
+		if strings.EqualFold(strings.TrimSuffix(record.Name, "."), domain) {
+			records = append(records, record)
+		}
+	}
+	response.Records = records
+
 	return &response, nil
 }
 
-// UpdateRecord updates an existing DNS record
-func (c *Client) UpdateRecord(ctx context.Context, zone, domain, recordType string, options map[string]string) (*UpdateRecordResponse, error) {
+// StreamRecords retrieves DNS records for a zone or domain like GetRecords, but decodes the records
+// one at a time and passes each to fn instead of collecting them, keeping memory flat for very large
+// zones. It returns the zone information from the response. When a request is retried after a
+// failure, fn sees the records of the retried response again from the start.
+func (c *Client) StreamRecords(ctx context.Context, zone, domain string, listZone bool, fn func(DNSRecord) error) (*ZoneInfo, error) {
 	if err := c.Authenticate(ctx); err != nil {
 		return nil, err
 	}
 
-	params := url.Values{}
-	params.Set("domain", domain)
-	params.Set("zone", zone)
-	params.Set("type", recordType)
+	endpoint := recordsRequest(zone, domain, listZone).Endpoint()
 
-	// Add additional options based on record type and update operation
-	for key, value := range options {
-		params.Set(key, value)
+	stream := &recordStream{fn: fn}
+	if err := c.DoRequest(ctx, http.MethodGet, endpoint, nil, stream); err != nil {
+		return nil, fmt.Errorf("failed to get DNS records: %w", err)
 	}
 
-	endpoint := "/api/zones/records/update?" + params.Encode()
+	return &stream.zone, nil
+}
+
+// recordStream decodes a records/get payload, handing each record to fn as soon as it is decoded
+type recordStream struct {
+	fn   func(DNSRecord) error
+	zone ZoneInfo
+}
+
+func (s *recordStream) decodeStream(dec *json.Decoder) error {
+	// Start from a clean state, the request may have been retried
+	s.zone = ZoneInfo{}
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if d, ok := token.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("expected records object, got %v", token)
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+
+		switch key {
+		case "zone":
+			err = dec.Decode(&s.zone)
+		case "records":
+			err = s.decodeRecords(dec)
+		default:
+			err = dec.Decode(&json.RawMessage{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+func (s *recordStream) decodeRecords(dec *json.Decoder) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if d, ok := token.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("expected records array, got %v", token)
+	}
+
+	for dec.More() {
+		var record DNSRecord
+		if err := dec.Decode(&record); err != nil {
+			return err
+		}
+		if err := s.fn(record); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+// UpdateRecord updates an existing DNS record
+func (c *Client) UpdateRecord(ctx context.Context, record UpdateRecordRequest) (*UpdateRecordResponse, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	if record.Current == nil && record.New == nil {
+		return nil, fmt.Errorf("failed to update DNS record: no record data")
+	}
+
+	request := record.encode(NewRequest().Path("/api/zones/records/update"))
+	c.applyDefaultComment(request.Values())
+
+	endpoint := request.Endpoint()
 
 	var response UpdateRecordResponse
-	if err := c.DoRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+	if err := c.DoRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
 		return nil, fmt.Errorf("failed to update DNS record: %w", err)
 	}
 
@@ -165,26 +337,51 @@ func (c *Client) UpdateRecord(ctx context.Context, zone, domain, recordType stri
 }
 
 // DeleteRecord deletes a DNS record
-func (c *Client) DeleteRecord(ctx context.Context, zone, domain, recordType string, options map[string]string) error {
+func (c *Client) DeleteRecord(ctx context.Context, record DeleteRecordRequest) error {
 	if err := c.Authenticate(ctx); err != nil {
 		return err
 	}
 
-	params := url.Values{}
-	params.Set("domain", domain)
-	params.Set("zone", zone)
-	params.Set("type", recordType)
-
-	// Add record-specific options required for deletion
-	for key, value := range options {
-		params.Set(key, value)
+	if record.Data == nil {
+		return fmt.Errorf("failed to delete DNS record: no record data")
 	}
 
-	endpoint := "/api/zones/records/delete?" + params.Encode()
+	endpoint := record.encode(NewRequest().Path("/api/zones/records/delete")).Endpoint()
 
-	if err := c.DoRequest(ctx, http.MethodGet, endpoint, nil, nil); err != nil {
+	if err := c.DoRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
 		return fmt.Errorf("failed to delete DNS record: %w", err)
 	}
 
 	return nil
 }
+
+// applyDefaultComment appends the configured default comment to the record comments,
+// so that Terraform managed records are recognizable in the Technitium web console
+func (c *Client) applyDefaultComment(params url.Values) {
+	if c.defaultComment == "" {
+		return
+	}
+
+	comments := params.Get("comments")
+	if strings.Contains(comments, c.defaultComment) {
+		return
+	}
+
+	if comments == "" {
+		params.Set("comments", c.defaultComment)
+	} else {
+		params.Set("comments", comments+" "+c.defaultComment)
+	}
+}
+
+// StripDefaultComment removes the default comment appended by applyDefaultComment from record
+// comments read from the server, so they compare equal to the configured comments
+func (c *Client) StripDefaultComment(comments string) string {
+	if c.defaultComment == "" {
+		return comments
+	}
+	if comments == c.defaultComment {
+		return ""
+	}
+	return strings.TrimSuffix(comments, " "+c.defaultComment)
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAddRecordDefaultComment(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultComment string
+		comments       string
+		expected       string
+	}{
+		{"No default comment", "", "user comment", "user comment"},
+		{"Default comment only", "managed by terraform", "", "managed by terraform"},
+		{"Appended to user comment", "managed by terraform", "web server", "web server managed by terraform"},
+		{"Not duplicated", "managed by terraform", "web server managed by terraform", "web server managed by terraform"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/zones/records/add" {
+					t.Errorf("Expected path /api/zones/records/add, got %s", r.URL.Path)
+				}
+				if comments := requestParams(r).Get("comments"); comments != tt.expected {
+					t.Errorf("Expected comments '%s', got '%s'", tt.expected, comments)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(APIResponse{Status: "ok", Response: json.RawMessage(`{}`)})
+			}))
+			defer server.Close()
+
+			client := &Client{
+				BaseURL:        server.URL,
+				HTTPClient:     server.Client(),
+				Token:          "test-token",
+				retries:        1,
+				defaultComment: tt.defaultComment,
+			}
+
+			record := AddRecordRequest{
+				Zone:     "example.com",
+				Domain:   "www.example.com",
+				TTL:      300,
+				Data:     ARecordData{IPAddress: "192.168.1.1"},
+				Comments: tt.comments,
+			}
+
+			if _, err := client.AddRecord(context.Background(), record); err != nil {
+				t.Fatalf("AddRecord failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestStripDefaultComment(t *testing.T) {
+	tests := []struct {
+		name           string
+		defaultComment string
+		comments       string
+		expected       string
+	}{
+		{"No default comment", "", "user comment", "user comment"},
+		{"Default comment only", "managed by terraform", "managed by terraform", ""},
+		{"Appended to user comment", "managed by terraform", "web server managed by terraform", "web server"},
+		{"Set outside of Terraform", "managed by terraform", "changed by hand", "changed by hand"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{defaultComment: tt.defaultComment}
+			if comments := client.StripDefaultComment(tt.comments); comments != tt.expected {
+				t.Errorf("Expected comments '%s', got '%s'", tt.expected, comments)
+			}
+		})
+	}
+}
+
+func TestGetRecordsZoneInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/zones/records/get" {
+			t.Errorf("Expected path /api/zones/records/get, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(APIResponse{Status: "ok", Response: json.RawMessage(`{
+			"zone": {"name": "example.com", "type": "Primary", "internal": false, "dnssecStatus": "SignedWithNSEC3", "disabled": true},
+			"records": [{"disabled": false, "name": "example.com", "type": "A", "ttl": 3600, "rData": {"ipAddress": "1.1.1.1"}}]
+		}`)})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	response, err := client.GetRecords(context.Background(), "example.com", "example.com", true)
+	if err != nil {
+		t.Fatalf("GetRecords failed: %v", err)
+	}
+
+	if response.Zone.Type != "Primary" || response.Zone.DnssecStatus != "SignedWithNSEC3" || !response.Zone.Disabled {
+		t.Errorf("Unexpected zone info: %+v", response.Zone)
+	}
+	if len(response.Records) != 1 || response.Records[0].RData.IPAddress != "1.1.1.1" {
+		t.Errorf("Unexpected records: %+v", response.Records)
+	}
+}
+
+func TestGetRecordsBatched(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		params := r.URL.Query()
+		if params.Get("listZone") != "true" || params.Get("domain") != "example.com" {
+			t.Errorf("Expected a listing of the whole zone, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(APIResponse{Status: "ok", Response: json.RawMessage(`{
+			"zone": {"name": "example.com", "type": "Primary"},
+			"records": [
+				{"name": "example.com", "type": "NS", "ttl": 3600, "rData": {"nameServer": "ns1.example.com"}},
+				{"name": "www.example.com", "type": "A", "ttl": 300, "rData": {"ipAddress": "192.0.2.1"}},
+				{"name": "www.example.com", "type": "A", "ttl": 300, "rData": {"ipAddress": "192.0.2.2"}},
+				{"name": "mail.example.com", "type": "A", "ttl": 300, "rData": {"ipAddress": "192.0.2.3"}}
+			]
+		}`)})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		responses:  newResponseCache(time.Minute),
+		batchReads: true,
+	}
+
+	var wg sync.WaitGroup
+	for domain, expected := range map[string]int{"www.example.com": 2, "WWW.example.com.": 2, "mail.example.com": 1, "missing.example.com": 0} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := client.GetRecords(context.Background(), "example.com", domain, false)
+			if err != nil {
+				t.Errorf("GetRecords failed: %v", err)
+				return
+			}
+			if len(response.Records) != expected || response.Zone.Type != "Primary" {
+				t.Errorf("Expected %d records of %s, got %+v", expected, domain, response)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected a single listing of the zone, got %d requests", got)
+	}
+
+	if _, err := NewClient(Config{Host: server.URL, Token: "test-token", BatchReads: true}); err == nil {
+		t.Error("Expected batched reads without the response cache to be rejected")
+	}
+}
+
+func TestRecordFlagsUnmarshal(t *testing.T) {
+	var records []DNSRecord
+	err := json.Unmarshal([]byte(`[
+		{"name": "example.com", "type": "CAA", "rData": {"flags": 128, "tag": "issue", "value": "letsencrypt.org"}},
+		{"name": "example.com", "type": "DNSKEY", "rData": {"flags": "SecureEntryPoint, ZoneKey"}}
+	]`), &records)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if records[0].RData.Flags.Int() != 128 || records[0].RData.Tag != "issue" || records[0].RData.Value != "letsencrypt.org" {
+		t.Errorf("Unexpected CAA record data: %+v", records[0].RData)
+	}
+	if records[1].RData.Flags != "SecureEntryPoint, ZoneKey" || records[1].RData.Flags.Int() != 0 {
+		t.Errorf("Unexpected DNSKEY flags: %q", records[1].RData.Flags)
+	}
+}
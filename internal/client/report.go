@@ -0,0 +1,103 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Operation actions tallied by the operations report
+const (
+	OperationCreated = "created"
+	OperationUpdated = "updated"
+	OperationDeleted = "deleted"
+)
+
+// operationActions orders the actions in the report summary
+var operationActions = []string{OperationCreated, OperationUpdated, OperationDeleted}
+
+// OperationsReport tallies the changes a provider run made and the API calls it needed.
+// It is only kept when the operations report is enabled in the provider configuration.
+type OperationsReport struct {
+	mu       sync.Mutex
+	changes  map[string]map[string]int
+	kinds    []string
+	apiCalls int
+	retries  int
+}
+
+// NewOperationsReport returns an empty report tallying changes of the given kinds, in summary order
+func NewOperationsReport(kinds ...string) *OperationsReport {
+	report := &OperationsReport{changes: make(map[string]map[string]int), kinds: kinds}
+	for _, kind := range kinds {
+		report.changes[kind] = make(map[string]int)
+	}
+	return report
+}
+
+// RecordChange counts a change of the given kind (e.g. "records") and action
+func (r *OperationsReport) RecordChange(kind, action string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.changes[kind]; !ok {
+		r.changes[kind] = make(map[string]int)
+		r.kinds = append(r.kinds, kind)
+	}
+	r.changes[kind][action]++
+}
+
+// countRequest counts a request sent to the API
+func (r *OperationsReport) countRequest() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.apiCalls++
+}
+
+// countRetry counts a retried request
+func (r *OperationsReport) countRetry() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.retries++
+}
+
+// Changes returns the total number of changes counted so far
+func (r *OperationsReport) Changes() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	total := 0
+	for _, actions := range r.changes {
+		for _, count := range actions {
+			total += count
+		}
+	}
+	return total
+}
+
+// Summary describes the counted changes and API usage, e.g.
+// "records: 2 created, 1 updated, 0 deleted; zones: 1 created, 0 updated, 0 deleted; API calls: 14; retries: 1"
+func (r *OperationsReport) Summary() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	parts := make([]string, 0, len(r.kinds)+2)
+	for _, kind := range r.kinds {
+		counts := make([]string, 0, len(operationActions))
+		for _, action := range operationActions {
+			counts = append(counts, fmt.Sprintf("%d %s", r.changes[kind][action], action))
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", kind, strings.Join(counts, ", ")))
+	}
+	parts = append(parts, fmt.Sprintf("API calls: %d", r.apiCalls), fmt.Sprintf("retries: %d", r.retries))
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOperationsReportSummary(t *testing.T) {
+	report := NewOperationsReport("records", "zones")
+	report.RecordChange("records", OperationCreated)
+	report.RecordChange("records", OperationCreated)
+	report.RecordChange("zones", OperationDeleted)
+	report.RecordChange("apps", OperationUpdated)
+	report.countRequest()
+	report.countRetry()
+
+	expected := "records: 2 created, 0 updated, 0 deleted; zones: 0 created, 0 updated, 1 deleted; " +
+		"apps: 0 created, 1 updated, 0 deleted; API calls: 1; retries: 1"
+	if got := report.Summary(); got != expected {
+		t.Errorf("Summary() = %q, expected %q", got, expected)
+	}
+	if report.Changes() != 4 {
+		t.Errorf("Expected 4 changes, got %d", report.Changes())
+	}
+}
+
+func TestOperationsReportCountsRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// Fail the first request so it is retried
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {"zones": []}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Host: server.URL, Token: "test-token", RetryAttempts: 1, OperationsReport: true})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	client.HTTPClient = server.Client()
+
+	if _, err := client.ListZones(context.Background()); err != nil {
+		t.Fatalf("ListZones failed: %v", err)
+	}
+
+	expected := "records: 0 created, 0 updated, 0 deleted; zones: 0 created, 0 updated, 0 deleted; API calls: 2; retries: 1"
+	if got := client.OperationsReport().Summary(); got != expected {
+		t.Errorf("Summary() = %q, expected %q", got, expected)
+	}
+}
+
+func TestOperationsReportDisabled(t *testing.T) {
+	client, err := NewClient(Config{Host: "http://localhost:5380", Token: "test-token"})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if client.OperationsReport() != nil {
+		t.Error("Expected no operations report unless enabled")
+	}
+
+	// A disabled report ignores counts
+	client.OperationsReport().countRequest()
+	client.OperationsReport().RecordChange("records", OperationCreated)
+}
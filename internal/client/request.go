@@ -0,0 +1,63 @@
+package client
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Request builds the endpoint of an API call from its path and query parameters, so every call
+// site escapes parameters the same way. For example:
+//
+//	endpoint := NewRequest().Path("/api/zones/create").Param("zone", zone).Param("type", "Primary").Endpoint()
+type Request struct {
+	path   string
+	params url.Values
+}
+
+// NewRequest returns an empty request builder
+func NewRequest() *Request {
+	return &Request{params: url.Values{}}
+}
+
+// Path sets the API path of the request (e.g. "/api/zones/create")
+func (r *Request) Path(path string) *Request {
+	r.path = path
+	return r
+}
+
+// Param sets a query parameter, replacing any previous value
+func (r *Request) Param(key, value string) *Request {
+	r.params.Set(key, value)
+	return r
+}
+
+// BoolParam sets a boolean query parameter as "true" or "false"
+func (r *Request) BoolParam(key string, value bool) *Request {
+	return r.Param(key, strconv.FormatBool(value))
+}
+
+// IntParam sets an integer query parameter
+func (r *Request) IntParam(key string, value int64) *Request {
+	return r.Param(key, strconv.FormatInt(value, 10))
+}
+
+// Params sets all parameters of the map, e.g. record type specific options
+func (r *Request) Params(params map[string]string) *Request {
+	for key, value := range params {
+		r.params.Set(key, value)
+	}
+	return r
+}
+
+// Values returns the query parameters set so far
+func (r *Request) Values() url.Values {
+	return r.params
+}
+
+// Endpoint returns the path with the encoded query string, sorted by parameter name
+func (r *Request) Endpoint() string {
+	if len(r.params) == 0 {
+		return r.path
+	}
+	return r.path + "?" + r.params.Encode()
+}
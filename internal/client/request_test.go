@@ -0,0 +1,47 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestRequestEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *Request
+		expected string
+	}{
+		{
+			name:     "no parameters",
+			request:  NewRequest().Path("/api/zones/list"),
+			expected: "/api/zones/list",
+		},
+		{
+			name:     "parameters sorted by name",
+			request:  NewRequest().Path("/api/zones/create").Param("zone", "example.com").Param("type", "Primary"),
+			expected: "/api/zones/create?type=Primary&zone=example.com",
+		},
+		{
+			name:     "values are escaped",
+			request:  NewRequest().Path("/api/zones/records/add").Param("text", "v=spf1 a&b token=x").Param("domain", "*.example.com"),
+			expected: "/api/zones/records/add?domain=%2A.example.com&text=v%3Dspf1+a%26b+token%3Dx",
+		},
+		{
+			name:     "typed parameters",
+			request:  NewRequest().Path("/api/zones/records/get").BoolParam("listZone", true).IntParam("ttl", 3600),
+			expected: "/api/zones/records/get?listZone=true&ttl=3600",
+		},
+		{
+			name:     "later values replace earlier ones",
+			request:  NewRequest().Path("/api/apps/uninstall").Param("name", "old").Params(map[string]string{"name": "new"}),
+			expected: "/api/apps/uninstall?name=new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.request.Endpoint(); got != tt.expected {
+				t.Errorf("Endpoint() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
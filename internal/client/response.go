@@ -0,0 +1,199 @@
+package client
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBodyLength limits how much of a failed response body is included in error messages
+const maxErrorBodyLength = 64 * 1024
+
+// streamDecoder is implemented by results that consume the response payload incrementally
+// instead of having it decoded into memory as a whole
+type streamDecoder interface {
+	decodeStream(dec *json.Decoder) error
+}
+
+// textDecoder is implemented by results of calls that download a plain text file instead of
+// returning the JSON envelope. Errors are still reported through the envelope.
+type textDecoder interface {
+	decodeText(r io.Reader) error
+}
+
+// APIError is an error reported by the server through the API envelope. Besides the message meant
+// for users, the server reports the exception it failed with, which often names the actual cause.
+type APIError struct {
+	Message           string
+	InnerErrorMessage string
+	StackTrace        string
+}
+
+func (e *APIError) Error() string {
+	return "API error: " + e.Message
+}
+
+// Details returns the inner error message and stack trace reported by the server, or an empty
+// string when it reported neither
+func (e *APIError) Details() string {
+	var details []string
+	if e.InnerErrorMessage != "" && e.InnerErrorMessage != e.Message {
+		details = append(details, "Inner error: "+e.InnerErrorMessage)
+	}
+	if e.StackTrace != "" {
+		details = append(details, "Server stack trace:\n"+e.StackTrace)
+	}
+	return strings.Join(details, "\n\n")
+}
+
+// countingReader counts the bytes read through it for logging
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// requestCompression asks the server for a gzip encoded response. Setting the header explicitly
+// disables the transport's own decompression, so responses must be read with responseBody.
+func requestCompression(req *http.Request) {
+	req.Header.Set("Accept-Encoding", "gzip")
+}
+
+// responseBody returns a reader over the decoded response body, transparently decompressing gzip
+func responseBody(resp *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+
+	gz, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response: %w", err)
+	}
+	return gz, nil
+}
+
+// decodeAPIResponse checks the HTTP status of resp and stream-decodes the API envelope, unmarshaling
+// the response payload of successful calls into result. It returns the number of decoded body bytes.
+func decodeAPIResponse(resp *http.Response, result interface{}) (int64, error) {
+	body, err := responseBody(resp)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	counter := &countingReader{r: body}
+
+	// Check HTTP status
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errorBody, err := io.ReadAll(io.LimitReader(counter, maxErrorBodyLength))
+		if err != nil {
+			return counter.n, fmt.Errorf("failed to read response: %w", err)
+		}
+		return counter.n, newHTTPStatusError(resp, errorBody)
+	}
+
+	if text, ok := result.(textDecoder); ok && !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return counter.n, text.decodeText(counter)
+	}
+
+	// Walk the envelope token by token so the payload is decoded straight into result
+	status, apiErr, err := decodeEnvelope(json.NewDecoder(counter), result)
+	if err != nil {
+		return counter.n, err
+	}
+
+	// Check API status
+	switch status {
+	case "ok":
+		return counter.n, nil
+	case "error":
+		if apiErr.Message == "" {
+			apiErr.Message = "unknown error"
+		}
+		return counter.n, apiErr
+	case "invalid-token":
+		return counter.n, fmt.Errorf("invalid-token: session expired or invalid token")
+	default:
+		return counter.n, fmt.Errorf("unexpected API status: %s", status)
+	}
+}
+
+// decodeEnvelope decodes the {"status", "response", "errorMessage"} API envelope, decoding the
+// response payload into result without buffering the raw payload first
+func decodeEnvelope(dec *json.Decoder, result interface{}) (status string, apiErr *APIError, err error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return "", nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	apiErr = &APIError{}
+	var errorField string
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse API response: %w", err)
+		}
+		key, _ := token.(string)
+
+		switch key {
+		case "status":
+			err = dec.Decode(&status)
+		case "errorMessage":
+			err = dec.Decode(&apiErr.Message)
+		case "innerErrorMessage":
+			err = dec.Decode(&apiErr.InnerErrorMessage)
+		case "stackTrace":
+			err = dec.Decode(&apiErr.StackTrace)
+		case "error":
+			err = dec.Decode(&errorField)
+		case "response":
+			if err := decodePayload(dec, result); err != nil {
+				return "", nil, fmt.Errorf("failed to parse response data: %w", err)
+			}
+		default:
+			err = dec.Decode(&json.RawMessage{})
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse API response: %w", err)
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return "", nil, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = errorField
+	}
+	return status, apiErr, nil
+}
+
+// decodePayload decodes the response payload into result, skipping it when result is nil
+func decodePayload(dec *json.Decoder, result interface{}) error {
+	switch r := result.(type) {
+	case nil:
+		return dec.Decode(&json.RawMessage{})
+	case streamDecoder:
+		return r.decodeStream(dec)
+	default:
+		return dec.Decode(result)
+	}
+}
+
+// expectDelim reads the next token and checks it is the given delimiter
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := token.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %q, got %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// appsCacheScope is the scope of cached app lists, invalidated by any app mutation
+const appsCacheScope = "apps"
+
+// responseCache caches the payloads of read-only calls that resources repeat many times during a
+// run, e.g. every record resource reading the records of its zone and every app resource listing
+// all apps. Mutations invalidate the cached payloads of the zone or app they change.
+type responseCache struct {
+	ttl time.Duration
+
+	// entries holds the cached payloads by endpoint and inflight the loads in progress, guarded by mu
+	entries  map[string]cachedResponse
+	inflight map[string]*inflightResponse
+	// generation counts invalidations, so responses requested before one are not stored
+	generation uint64
+	mu         sync.Mutex
+}
+
+// cachedResponse is a cached payload with the scope invalidating it
+type cachedResponse struct {
+	payload json.RawMessage
+	scope   string
+	expires time.Time
+}
+
+// inflightResponse is a payload being loaded, shared by all calls missing the cache meanwhile
+type inflightResponse struct {
+	scope   string
+	done    chan struct{}
+	payload json.RawMessage
+	err     error
+}
+
+// newResponseCache returns a cache keeping payloads for ttl, or nil when ttl disables caching
+func newResponseCache(ttl time.Duration) *responseCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &responseCache{
+		ttl:      ttl,
+		entries:  make(map[string]cachedResponse),
+		inflight: make(map[string]*inflightResponse),
+	}
+}
+
+// cacheScope returns the scope of a read-only call and whether its responses are cached. Zone
+// options and records are scoped by their zone, so only mutations of that zone invalidate them.
+func cacheScope(endpoint string) (string, bool) {
+	section, call, query := splitEndpoint(endpoint)
+	switch {
+	case section == "apps" && (call == "list" || call == "listStoreApps"):
+		return appsCacheScope, true
+	case section == "zones" && (call == "options/get" || call == "records/get"):
+		params, err := url.ParseQuery(query)
+		if err != nil || params.Get("zone") == "" {
+			return "", false
+		}
+		return zoneCacheScope(params.Get("zone")), true
+	default:
+		return "", false
+	}
+}
+
+// mutationScope returns the scope changed by a mutation, or an empty string when it may change
+// any cached response. Address records may also change the PTR record in whichever reverse zone
+// the server picks, so their mutations change any cached response.
+func mutationScope(endpoint string) string {
+	section, call, query := splitEndpoint(endpoint)
+	if section == "apps" {
+		return appsCacheScope
+	}
+	params, err := url.ParseQuery(query)
+	if err != nil || params.Get("zone") == "" || section == "zones" && changesReverseZone(call, params) {
+		return ""
+	}
+	return zoneCacheScope(params.Get("zone"))
+}
+
+// changesReverseZone reports whether a record mutation may change a PTR record: adding or updating
+// an address record with ptr set, or deleting an address record, whose PTR record goes with it
+func changesReverseZone(call string, params url.Values) bool {
+	switch call {
+	case "records/add", "records/update":
+		return params.Get("ptr") == "true"
+	case "records/delete":
+		recordType := strings.ToUpper(params.Get("type"))
+		return recordType == "A" || recordType == "AAAA"
+	default:
+		return false
+	}
+}
+
+// splitEndpoint splits an endpoint into the API section, e.g. zones, the call within the section,
+// e.g. records/get, and the query
+func splitEndpoint(endpoint string) (section, call, query string) {
+	path, query, _ := strings.Cut(endpoint, "?")
+	section, call, _ = strings.Cut(strings.TrimPrefix(path, "/api/"), "/")
+	return section, call, query
+}
+
+// zoneCacheScope returns the scope of a zone; zone names are case-insensitive
+func zoneCacheScope(zone string) string {
+	return "zone:" + strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+// caches reports whether the responses of a call to endpoint decoded into result are cached.
+// Streamed results are never cached, as that would hold the very payloads they avoid buffering.
+func (rc *responseCache) caches(endpoint string, result interface{}) bool {
+	if rc == nil {
+		return false
+	}
+	if _, ok := result.(streamDecoder); ok {
+		return false
+	}
+	_, ok := cacheScope(endpoint)
+	return ok
+}
+
+// fetch returns the cached payload of endpoint, or loads and caches it. Concurrent calls missing
+// the cache share a single load, so resources read in parallel do not all request the same payload.
+// The payload is not cached when a mutation invalidated the cache while it was loaded.
+func (rc *responseCache) fetch(ctx context.Context, endpoint string, load func() (json.RawMessage, error)) (payload json.RawMessage, cached bool, err error) {
+	scope, ok := cacheScope(endpoint)
+	if !ok {
+		payload, err = load()
+		return payload, false, err
+	}
+
+	rc.mu.Lock()
+	if entry, ok := rc.entries[endpoint]; ok {
+		if time.Now().Before(entry.expires) {
+			rc.mu.Unlock()
+			return entry.payload, true, nil
+		}
+		delete(rc.entries, endpoint)
+	}
+	if call, ok := rc.inflight[endpoint]; ok {
+		rc.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.payload, true, call.err
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+
+	call := &inflightResponse{scope: scope, done: make(chan struct{})}
+	rc.inflight[endpoint] = call
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	call.payload, call.err = load()
+
+	rc.mu.Lock()
+	if rc.inflight[endpoint] == call {
+		delete(rc.inflight, endpoint)
+	}
+	if call.err == nil && rc.generation == generation {
+		rc.entries[endpoint] = cachedResponse{payload: call.payload, scope: scope, expires: time.Now().Add(rc.ttl)}
+	}
+	rc.mu.Unlock()
+	close(call.done)
+
+	return call.payload, false, call.err
+}
+
+// invalidate drops the cached payloads a mutation of endpoint may have changed
+func (rc *responseCache) invalidate(endpoint string) {
+	if rc == nil {
+		return
+	}
+
+	scope := mutationScope(endpoint)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.generation++
+	for key, entry := range rc.entries {
+		if scope == "" || entry.scope == scope {
+			delete(rc.entries, key)
+		}
+	}
+	// Later calls must not join loads that may have read the state before the mutation
+	for key, call := range rc.inflight {
+		if scope == "" || call.scope == scope {
+			delete(rc.inflight, key)
+		}
+	}
+}
+
+// rawPayload captures the response payload undecoded, so it can be cached and decoded for
+// every caller
+type rawPayload json.RawMessage
+
+func (p *rawPayload) decodeStream(dec *json.Decoder) error {
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	*p = rawPayload(raw)
+	return nil
+}
@@ -0,0 +1,221 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/zones/records/get":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"zone": {"name": "example.com"}, "records": [{"name": "www.example.com", "type": "A", "ttl": 300}]}}`))
+		case "/api/apps/list":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"apps": [{"name": "Split Horizon"}]}}`))
+		case "/api/apps/listStoreApps":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"storeApps": [{"name": "Split Horizon"}]}}`))
+		default:
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		}
+	}))
+	defer server.Close()
+
+	newClient := func() *Client {
+		requests.Store(0)
+		return &Client{
+			BaseURL:    server.URL,
+			HTTPClient: server.Client(),
+			Token:      "test-token",
+			responses:  newResponseCache(time.Minute),
+		}
+	}
+	ctx := context.Background()
+
+	t.Run("answers repeated reads from the cache", func(t *testing.T) {
+		client := newClient()
+		for i := 0; i < 3; i++ {
+			response, err := client.GetRecords(ctx, "example.com", "www.example.com", false)
+			if err != nil {
+				t.Fatalf("GetRecords failed: %v", err)
+			}
+			if len(response.Records) != 1 || response.Records[0].Name != "www.example.com" {
+				t.Fatalf("Expected the cached record, got %+v", response.Records)
+			}
+			// Callers own the decoded response
+			response.Records[0].Name = "modified"
+		}
+		if got := requests.Load(); got != 1 {
+			t.Errorf("Expected 1 request, got %d", got)
+		}
+	})
+
+	t.Run("mutations invalidate their zone only", func(t *testing.T) {
+		client := newClient()
+		for _, zone := range []string{"example.com", "example.org"} {
+			if _, err := client.GetRecords(ctx, zone, zone, false); err != nil {
+				t.Fatalf("GetRecords failed: %v", err)
+			}
+		}
+		if err := client.DeleteRecord(ctx, DeleteRecordRequest{Zone: "Example.com.", Domain: "www.example.com", Data: TXTRecordData{Text: "v=spf1 -all"}}); err != nil {
+			t.Fatalf("DeleteRecord failed: %v", err)
+		}
+		for _, zone := range []string{"example.com", "example.org"} {
+			if _, err := client.GetRecords(ctx, zone, zone, false); err != nil {
+				t.Fatalf("GetRecords failed: %v", err)
+			}
+		}
+		// Two reads, the deletion and the read of the changed zone
+		if got := requests.Load(); got != 4 {
+			t.Errorf("Expected 4 requests, got %d", got)
+		}
+	})
+
+	t.Run("address record mutations invalidate reverse zones", func(t *testing.T) {
+		for name, mutate := range map[string]func(*Client) error{
+			"add with PTR": func(client *Client) error {
+				_, err := client.AddRecord(ctx, AddRecordRequest{Zone: "example.com", Domain: "www.example.com", TTL: 300,
+					Data: ARecordData{IPAddress: "192.0.2.1", Ptr: true}})
+				return err
+			},
+			"delete": func(client *Client) error {
+				return client.DeleteRecord(ctx, DeleteRecordRequest{Zone: "example.com", Domain: "www.example.com",
+					Data: AAAARecordData{IPAddress: "2001:db8::1"}})
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				client := newClient()
+				if _, err := client.GetRecords(ctx, "2.0.192.in-addr.arpa", "1.2.0.192.in-addr.arpa", false); err != nil {
+					t.Fatalf("GetRecords failed: %v", err)
+				}
+				if err := mutate(client); err != nil {
+					t.Fatalf("Mutation failed: %v", err)
+				}
+				if _, err := client.GetRecords(ctx, "2.0.192.in-addr.arpa", "1.2.0.192.in-addr.arpa", false); err != nil {
+					t.Fatalf("GetRecords failed: %v", err)
+				}
+				// The reverse zone is read again after the mutation
+				if got := requests.Load(); got != 3 {
+					t.Errorf("Expected 3 requests, got %d", got)
+				}
+			})
+		}
+	})
+
+	t.Run("app mutations invalidate the app lists", func(t *testing.T) {
+		client := newClient()
+		for i := 0; i < 2; i++ {
+			apps, err := client.ListApps(ctx)
+			if err != nil || len(apps) != 1 {
+				t.Fatalf("ListApps returned %+v, %v", apps, err)
+			}
+			storeApps, err := client.ListStoreApps(ctx)
+			if err != nil || len(storeApps) != 1 {
+				t.Fatalf("ListStoreApps returned %+v, %v", storeApps, err)
+			}
+		}
+		if err := client.UninstallApp(ctx, "Split Horizon"); err != nil {
+			t.Fatalf("UninstallApp failed: %v", err)
+		}
+		if _, err := client.ListApps(ctx); err != nil {
+			t.Fatalf("ListApps failed: %v", err)
+		}
+		if _, err := client.ListStoreApps(ctx); err != nil {
+			t.Fatalf("ListStoreApps failed: %v", err)
+		}
+		if got := requests.Load(); got != 5 {
+			t.Errorf("Expected 5 requests, got %d", got)
+		}
+	})
+
+	t.Run("is disabled without a TTL", func(t *testing.T) {
+		client := newClient()
+		client.responses = newResponseCache(0)
+		for i := 0; i < 2; i++ {
+			if _, err := client.ListApps(ctx); err != nil {
+				t.Fatalf("ListApps failed: %v", err)
+			}
+		}
+		if got := requests.Load(); got != 2 {
+			t.Errorf("Expected 2 requests, got %d", got)
+		}
+	})
+}
+
+func TestResponseCacheFetch(t *testing.T) {
+	ctx := context.Background()
+	endpoint := "/api/zones/options/get?zone=example.com"
+
+	t.Run("does not cache payloads loaded during a mutation", func(t *testing.T) {
+		cache := newResponseCache(time.Minute)
+		var loads int
+		load := func() (json.RawMessage, error) {
+			loads++
+			if loads == 1 {
+				cache.invalidate("/api/settings/set")
+			}
+			return json.RawMessage(`{}`), nil
+		}
+
+		for i := 0; i < 3; i++ {
+			if _, _, err := cache.fetch(ctx, endpoint, load); err != nil {
+				t.Fatalf("fetch failed: %v", err)
+			}
+		}
+		if loads != 2 {
+			t.Errorf("Expected 2 loads, got %d", loads)
+		}
+	})
+
+	t.Run("shares concurrent loads", func(t *testing.T) {
+		cache := newResponseCache(time.Minute)
+		var loads atomic.Int32
+		release := make(chan struct{})
+		load := func() (json.RawMessage, error) {
+			loads.Add(1)
+			<-release
+			return json.RawMessage(`{}`), nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if payload, _, err := cache.fetch(ctx, endpoint, load); err != nil || string(payload) != "{}" {
+					t.Errorf("Expected the shared payload, got %s, %v", payload, err)
+				}
+			}()
+		}
+		// Let the goroutines join the load before it completes
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		if got := loads.Load(); got != 1 {
+			t.Errorf("Expected 1 load, got %d", got)
+		}
+	})
+
+	t.Run("does not cache other calls", func(t *testing.T) {
+		cache := newResponseCache(time.Minute)
+		var loads int
+		for i := 0; i < 2; i++ {
+			_, _, _ = cache.fetch(ctx, "/api/zones/list", func() (json.RawMessage, error) {
+				loads++
+				return json.RawMessage(`{}`), nil
+			})
+		}
+		if loads != 2 {
+			t.Errorf("Expected the zone list not to be cached, got %d loads", loads)
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDecodeAPIResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		wantErr    string
+		wantName   string
+	}{
+		{
+			name:       "ok",
+			statusCode: http.StatusOK,
+			body:       `{"response": {"name": "example.com"}, "status": "ok"}`,
+			wantName:   "example.com",
+		},
+		{
+			name:       "ok without response",
+			statusCode: http.StatusOK,
+			body:       `{"status": "ok"}`,
+		},
+		{
+			name:       "api error",
+			statusCode: http.StatusOK,
+			body:       `{"status": "error", "errorMessage": "Zone does not exist"}`,
+			wantErr:    "API error: Zone does not exist",
+		},
+		{
+			name:       "invalid token",
+			statusCode: http.StatusOK,
+			body:       `{"status": "invalid-token"}`,
+			wantErr:    "invalid-token",
+		},
+		{
+			name:       "mismatched response data",
+			statusCode: http.StatusOK,
+			body:       `{"status": "ok", "response": {"name": 42}}`,
+			wantErr:    "failed to parse response data",
+		},
+		{
+			name:       "malformed body",
+			statusCode: http.StatusOK,
+			body:       `<html>`,
+			wantErr:    "failed to parse API response",
+		},
+		{
+			name:       "http error",
+			statusCode: http.StatusBadGateway,
+			body:       `bad gateway`,
+			wantErr:    "API request failed with status 502: bad gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+
+			var result struct {
+				Name string `json:"name"`
+			}
+			n, err := decodeAPIResponse(resp, &result)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeAPIResponse failed: %v", err)
+			}
+			if result.Name != tt.wantName {
+				t.Errorf("Expected name %q, got %q", tt.wantName, result.Name)
+			}
+			if n != int64(len(tt.body)) {
+				t.Errorf("Expected %d decoded bytes, got %d", len(tt.body), n)
+			}
+		})
+	}
+}
+
+func TestDecodeAPIResponseErrorDetails(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body: io.NopCloser(strings.NewReader(`{"status": "error", "errorMessage": "Failed to load app",` +
+			` "innerErrorMessage": "Could not load file or assembly", "stackTrace": "at DnsServerCore.Dns.Applications.DnsApplication..ctor()"}`)),
+	}
+
+	_, err := decodeAPIResponse(resp, nil)
+	var apiErr *APIError
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &apiErr) {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+	if err.Error() != "API error: Failed to load app" {
+		t.Errorf("Expected the error message of the server, got %q", err.Error())
+	}
+
+	expected := "Inner error: Could not load file or assembly\n\nServer stack trace:\nat DnsServerCore.Dns.Applications.DnsApplication..ctor()"
+	if details := apiErr.Details(); details != expected {
+		t.Errorf("Expected details %q, got %q", expected, details)
+	}
+	if details := (&APIError{Message: "Zone does not exist"}).Details(); details != "" {
+		t.Errorf("Expected no details, got %q", details)
+	}
+}
+
+func TestGetRecordsGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Expected Accept-Encoding gzip, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		_, _ = gz.Write(largeZoneResponse(3))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	records, err := client.GetRecords(context.Background(), "example.com", "example.com", true)
+	if err != nil {
+		t.Fatalf("GetRecords failed: %v", err)
+	}
+	if len(records.Records) != 3 {
+		t.Fatalf("Expected 3 records, got %d", len(records.Records))
+	}
+	if records.Records[2].RData.IPAddress != "10.0.0.2" {
+		t.Errorf("Expected 10.0.0.2, got %s", records.Records[2].RData.IPAddress)
+	}
+}
+
+func TestStreamRecords(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(largeZoneResponse(5))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    0,
+	}
+
+	names := make([]string, 0)
+	zone, err := client.StreamRecords(context.Background(), "example.com", "example.com", true, func(record DNSRecord) error {
+		names = append(names, record.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamRecords failed: %v", err)
+	}
+	if zone.Type != "Primary" {
+		t.Errorf("Expected zone type Primary, got %s", zone.Type)
+	}
+	if len(names) != 5 || names[4] != "host4.example.com" {
+		t.Errorf("Unexpected streamed records: %v", names)
+	}
+
+	// Errors returned by the callback stop the stream
+	seen := 0
+	_, err = client.StreamRecords(context.Background(), "example.com", "example.com", true, func(record DNSRecord) error {
+		seen++
+		return fmt.Errorf("stop")
+	})
+	if err == nil || !strings.Contains(err.Error(), "stop") {
+		t.Fatalf("Expected callback error, got %v", err)
+	}
+	if seen != 1 {
+		t.Errorf("Expected the stream to stop after the first record, saw %d", seen)
+	}
+}
+
+// largeZoneResponse builds a records/get response body holding count A records
+func largeZoneResponse(count int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"status": "ok", "response": {"zone": {"name": "example.com", "type": "Primary"}, "records": [`)
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, `{"disabled": false, "name": "host%d.example.com", "type": "A", "ttl": 3600, "rData": {"ipAddress": "10.0.%d.%d"}, "dnssecStatus": "Unknown", "lastUsedOn": "0001-01-01T00:00:00"}`,
+			i, i/256, i%256)
+	}
+	buf.WriteString(`]}}`)
+	return buf.Bytes()
+}
+
+// BenchmarkGetRecordsLargeZone compares decoding a 50k record zone the way the client used to
+// (buffering the body and the raw response payload) with the streaming envelope decoder, and with
+// streaming the records one at a time as StreamRecords does, with and without gzip.
+// Run with -benchmem to compare the bytes allocated per operation.
+func BenchmarkGetRecordsLargeZone(b *testing.B) {
+	body := largeZoneResponse(50000)
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, _ = gz.Write(body)
+	_ = gz.Close()
+
+	newResponse := func(gzipped bool) *http.Response {
+		resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+		if gzipped {
+			resp.Header.Set("Content-Encoding", "gzip")
+			resp.Body = io.NopCloser(bytes.NewReader(compressed.Bytes()))
+		} else {
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		return resp
+	}
+
+	b.Run("buffered", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			responseBody, err := io.ReadAll(newResponse(false).Body)
+			if err != nil {
+				b.Fatal(err)
+			}
+			var apiResp APIResponse
+			if err := json.Unmarshal(responseBody, &apiResp); err != nil {
+				b.Fatal(err)
+			}
+			var result GetRecordsResponse
+			if err := json.Unmarshal(apiResp.Response, &result); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var result GetRecordsResponse
+			if _, err := decodeAPIResponse(newResponse(false), &result); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	countRecords := func(count *int) *recordStream {
+		return &recordStream{fn: func(DNSRecord) error {
+			*count++
+			return nil
+		}}
+	}
+
+	b.Run("streaming_records", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			count := 0
+			if _, err := decodeAPIResponse(newResponse(false), countRecords(&count)); err != nil {
+				b.Fatal(err)
+			}
+			if count != 50000 {
+				b.Fatalf("Expected 50000 records, got %d", count)
+			}
+		}
+	})
+
+	b.Run("streaming_records_gzip", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			count := 0
+			if _, err := decodeAPIResponse(newResponse(true), countRecords(&count)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
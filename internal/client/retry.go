@@ -0,0 +1,140 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Default bounds of the retry backoff. The delay before the first retry is the minimum; it
+// doubles with every further retry up to the maximum.
+const (
+	defaultRetryMinDelay = time.Second
+	defaultRetryMaxDelay = 30 * time.Second
+)
+
+// HTTPStatusError is returned when the server or a proxy in front of it answers with a non-2xx
+// HTTP status instead of the API envelope
+type HTTPStatusError struct {
+	StatusCode int
+	Body       string
+	// RetryAfter is the delay requested by the Retry-After header, zero when it was not sent
+	RetryAfter time.Duration
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// newHTTPStatusError returns the error of a response with a non-2xx status and the given body
+func newHTTPStatusError(resp *http.Response, body []byte) *HTTPStatusError {
+	return &HTTPStatusError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+}
+
+// parseRetryAfter parses a Retry-After header holding either seconds or an HTTP date. It returns
+// zero for missing, malformed or past values.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(min(seconds, int64(time.Hour/time.Second))) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// isRetryable reports whether a failed request may succeed when it is sent again. Refused and
+// reset connections, responses cut short, timeouts of single attempts and overloaded or restarting
+// servers are transient. Errors reported by the API, e.g. validation failures, other 4xx statuses,
+// invalid URLs and certificate errors are permanent.
+func isRetryable(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return isRetryableStatus(statusErr.StatusCode)
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isRetryableMutation reports whether a failed mutation may be sent again. Unlike a read, a
+// mutation is only resent when the server cannot have applied it: the connection was refused, or
+// the server turned the request away with 429 or 503 and asked for a retry with Retry-After. After
+// a timeout, a reset connection or a gateway error the server may have applied the change, and
+// resending e.g. records/add could add the record twice.
+func isRetryableMutation(err error) bool {
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			return statusErr.RetryAfter > 0
+		default:
+			return false
+		}
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// isRetryableStatus reports whether an HTTP status signals a transient failure
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusRequestTimeout, http.StatusTooEarly, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay returns how long to wait before retry number attempt, counted from 1, of a request
+// that failed with err. A delay requested by the server with Retry-After is honored up to the
+// maximum delay; otherwise the delay grows exponentially with jitter, so requests that failed
+// together do not retry in lockstep.
+func (c *Client) retryDelay(attempt int, err error) time.Duration {
+	minDelay, maxDelay := c.retryMinDelay, c.retryMaxDelay
+	if minDelay <= 0 {
+		minDelay = defaultRetryMinDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+	if maxDelay < minDelay {
+		maxDelay = minDelay
+	}
+
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return min(statusErr.RetryAfter, maxDelay)
+	}
+
+	delay := minDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+
+	// Wait at least half the delay, so retries still back off under unlucky draws
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
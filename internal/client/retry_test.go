@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		status           int
+		retryAfter       string
+		body             string
+		expectedRequests int32
+		expectError      bool
+	}{
+		{name: "retries unavailable servers", method: http.MethodGet, status: http.StatusServiceUnavailable, expectedRequests: 3},
+		{name: "retries reads after gateway errors", method: http.MethodGet, status: http.StatusBadGateway, expectedRequests: 3},
+		{name: "retries mutations turned away with Retry-After", method: http.MethodPost, status: http.StatusServiceUnavailable, retryAfter: "1", expectedRequests: 3},
+		{name: "retries rate limited requests", method: http.MethodPost, status: http.StatusTooManyRequests, retryAfter: "1", expectedRequests: 3},
+		{name: "does not retry mutations without Retry-After", method: http.MethodPost, status: http.StatusServiceUnavailable, expectedRequests: 1, expectError: true},
+		{name: "does not retry mutations after gateway errors", method: http.MethodPost, status: http.StatusBadGateway, retryAfter: "1", expectedRequests: 1, expectError: true},
+		{name: "does not retry client errors", method: http.MethodPost, status: http.StatusBadRequest, expectedRequests: 1, expectError: true},
+		{name: "does not retry API errors", method: http.MethodPost, status: http.StatusOK, body: `{"status": "error", "errorMessage": "Invalid domain name"}`, expectedRequests: 1, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if requests.Add(1) < 3 || tt.expectError {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.status)
+					_, _ = w.Write([]byte(tt.body))
+					return
+				}
+				_, _ = w.Write([]byte(`{"status": "ok"}`))
+			}))
+			defer server.Close()
+
+			client := &Client{
+				BaseURL:       server.URL,
+				HTTPClient:    server.Client(),
+				Token:         "test-token",
+				retries:       3,
+				retryMinDelay: time.Millisecond,
+				retryMaxDelay: 5 * time.Millisecond,
+			}
+
+			endpoint := "/api/zones/records/add?zone=example.com"
+			if tt.method == http.MethodGet {
+				endpoint = "/api/zones/records/get?zone=example.com&domain=example.com"
+			}
+			err := client.DoRequest(context.Background(), tt.method, endpoint, nil, nil)
+			if tt.expectError != (err != nil) {
+				t.Errorf("Expected error %v, got %v", tt.expectError, err)
+			}
+			if got := requests.Load(); got != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, got)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"bad gateway", &HTTPStatusError{StatusCode: http.StatusBadGateway}, true},
+		{"wrapped gateway timeout", fmt.Errorf("failed to list zones: %w", &HTTPStatusError{StatusCode: http.StatusGatewayTimeout}), true},
+		{"not found", &HTTPStatusError{StatusCode: http.StatusNotFound}, false},
+		{"not implemented", &HTTPStatusError{StatusCode: http.StatusNotImplemented}, false},
+		{"API error", &APIError{Message: "Zone already exists"}, false},
+		{"other error", errors.New("failed to marshal request body"), false},
+		{"connection refused", &url.Error{Op: "Post", URL: "http://localhost:5380", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}, true},
+		{"connection reset", &url.Error{Op: "Post", URL: "http://localhost:5380", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}, true},
+		{"timeout", &url.Error{Op: "Post", URL: "http://localhost:5380", Err: timeoutError{}}, true},
+		{"response cut short", &url.Error{Op: "Post", URL: "http://localhost:5380", Err: io.ErrUnexpectedEOF}, true},
+		{"certificate error", &url.Error{Op: "Post", URL: "https://localhost:5380", Err: x509.UnknownAuthorityError{}}, false},
+		{"invalid URL", &url.Error{Op: "parse", URL: "http://local host", Err: errors.New("invalid character \" \" in host name")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.expected {
+				t.Errorf("isRetryable(%v) = %v, expected %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryTimedOutMutation(t *testing.T) {
+	var requests atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		// Answer only after the client gave up on the attempt
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	httpClient := server.Client()
+	httpClient.Timeout = 50 * time.Millisecond
+	client := &Client{
+		BaseURL:       server.URL,
+		HTTPClient:    httpClient,
+		Token:         "test-token",
+		retries:       3,
+		retryMinDelay: time.Millisecond,
+		retryMaxDelay: 5 * time.Millisecond,
+	}
+
+	// The server may have added the record, so the request is not sent again
+	err := client.DoRequest(context.Background(), http.MethodPost, "/api/zones/records/add?zone=example.com", nil, nil)
+	if err == nil {
+		t.Fatal("Expected the timed out request to fail")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected the timed out POST to be sent once, got %d requests", got)
+	}
+
+	// Reads are safe to resend
+	requests.Store(0)
+	err = client.DoRequest(context.Background(), http.MethodGet, "/api/zones/records/get?zone=example.com&domain=example.com", nil, nil)
+	if err == nil {
+		t.Fatal("Expected the timed out request to fail")
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("Expected the timed out GET to be sent 4 times, got %d requests", got)
+	}
+}
+
+func TestIsRetryableMutation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"connection refused", &url.Error{Op: "Post", URL: "http://localhost:5380", Err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}}, true},
+		{"rate limited with Retry-After", &HTTPStatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second}, true},
+		{"unavailable with Retry-After", fmt.Errorf("failed to add record: %w", &HTTPStatusError{StatusCode: http.StatusServiceUnavailable, RetryAfter: time.Second}), true},
+		{"unavailable", &HTTPStatusError{StatusCode: http.StatusServiceUnavailable}, false},
+		{"bad gateway", &HTTPStatusError{StatusCode: http.StatusBadGateway, RetryAfter: time.Second}, false},
+		{"gateway timeout", &HTTPStatusError{StatusCode: http.StatusGatewayTimeout}, false},
+		{"connection reset", &url.Error{Op: "Post", URL: "http://localhost:5380", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}, false},
+		{"timeout", &url.Error{Op: "Post", URL: "http://localhost:5380", Err: timeoutError{}}, false},
+		{"response cut short", &url.Error{Op: "Post", URL: "http://localhost:5380", Err: io.ErrUnexpectedEOF}, false},
+		{"API error", &APIError{Message: "Zone already exists"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryableMutation(tt.err); got != tt.expected {
+				t.Errorf("isRetryableMutation(%v) = %v, expected %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+// timeoutError is a network error reporting a timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"120", 2 * time.Minute},
+		{"-1", 0},
+		{"Sat, 17 Oct 2026 08:00:30 GMT", 30 * time.Second},
+		{"Sat, 17 Oct 2026 07:59:00 GMT", 0},
+		{"soon", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.expected {
+			t.Errorf("parseRetryAfter(%q) = %s, expected %s", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	client := &Client{retryMinDelay: 100 * time.Millisecond, retryMaxDelay: time.Second}
+	transient := &HTTPStatusError{StatusCode: http.StatusServiceUnavailable}
+
+	for attempt, expectedMax := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		10: time.Second,
+	} {
+		for i := 0; i < 20; i++ {
+			if delay := client.retryDelay(attempt, transient); delay < expectedMax/2 || delay > expectedMax {
+				t.Errorf("Expected the delay of attempt %d between %s and %s, got %s", attempt, expectedMax/2, expectedMax, delay)
+			}
+		}
+	}
+
+	if delay := client.retryDelay(1, &HTTPStatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: 500 * time.Millisecond}); delay != 500*time.Millisecond {
+		t.Errorf("Expected the Retry-After delay of 500ms, got %s", delay)
+	}
+	if delay := client.retryDelay(1, &HTTPStatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour}); delay != time.Second {
+		t.Errorf("Expected Retry-After to be capped at the maximum delay, got %s", delay)
+	}
+}
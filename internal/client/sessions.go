@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// SessionTypeAPIToken is the type of sessions created with the createToken API
+const SessionTypeAPIToken = "ApiToken"
+
+// partialTokenLength is the number of leading characters of a token that identify its session
+const partialTokenLength = 16
+
+// Session represents a logged in session or API token of the DNS server
+type Session struct {
+	Username              string `json:"username"`
+	IsCurrentSession      bool   `json:"isCurrentSession"`
+	PartialToken          string `json:"partialToken"`
+	Type                  string `json:"type"`
+	TokenName             string `json:"tokenName"`
+	LastSeen              string `json:"lastSeen"`
+	LastSeenRemoteAddress string `json:"lastSeenRemoteAddress"`
+	LastSeenUserAgent     string `json:"lastSeenUserAgent"`
+}
+
+// ListSessionsResponse represents the response from the admin/sessions/list API
+type ListSessionsResponse struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// APIToken represents a non-expiring API token created for a user
+type APIToken struct {
+	Username  string `json:"username"`
+	TokenName string `json:"tokenName"`
+	Token     string `json:"token"`
+}
+
+// PartialToken returns the partial token identifying the token's session
+func (t *APIToken) PartialToken() string {
+	if len(t.Token) < partialTokenLength {
+		return t.Token
+	}
+	return t.Token[:partialTokenLength]
+}
+
+// ListSessions lists the sessions and API tokens of every user
+func (c *Client) ListSessions(ctx context.Context) ([]Session, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/sessions/list").Endpoint()
+
+	var response ListSessionsResponse
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return response.Sessions, nil
+}
+
+// CreateAPIToken creates a non-expiring API token for a user. The token is only returned once.
+func (c *Client) CreateAPIToken(ctx context.Context, username, tokenName string) (*APIToken, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/sessions/createToken").
+		Param("user", username).
+		Param("tokenName", tokenName).
+		Endpoint()
+
+	var response APIToken
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to create API token %s for user %s: %w", tokenName, username, err)
+	}
+
+	return &response, nil
+}
+
+// DeleteSession deletes the session or API token identified by its partial token
+func (c *Client) DeleteSession(ctx context.Context, partialToken string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/admin/sessions/delete").Param("partialToken", partialToken).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete session %s: %w", partialToken, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestSessions(t *testing.T) {
+	var createQuery url.Values
+	var deleted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := requestParams(r)
+
+		switch r.URL.Path {
+		case "/api/admin/sessions/list":
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"sessions": [
+				{"username": "admin", "isCurrentSession": true, "partialToken": "272f4890427b9ab5", "type": "Standard", "tokenName": null},
+				{"username": "terraform", "isCurrentSession": false, "partialToken": "ddfaecb8e9325e77", "type": "ApiToken", "tokenName": "ci"}
+			]}}`))
+		case "/api/admin/sessions/createToken":
+			createQuery = query
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {"username": "terraform", "tokenName": "ci",
+				"token": "ddfaecb8e9325e77865ee7e100f89596a65d3eae0e6dddcb33172355b95a64af"}}`))
+		case "/api/admin/sessions/delete":
+			deleted = query.Get("partialToken")
+			_, _ = w.Write([]byte(`{"status": "ok", "response": {}}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	sessions, err := client.ListSessions(context.Background())
+	if err != nil || len(sessions) != 2 || sessions[1].Type != SessionTypeAPIToken || sessions[1].TokenName != "ci" {
+		t.Fatalf("ListSessions returned %+v (%v)", sessions, err)
+	}
+
+	token, err := client.CreateAPIToken(context.Background(), "terraform", "ci")
+	if err != nil {
+		t.Fatalf("CreateAPIToken failed: %v", err)
+	}
+	if createQuery.Get("user") != "terraform" || createQuery.Get("tokenName") != "ci" {
+		t.Errorf("Unexpected createToken query %v", createQuery)
+	}
+	if token.PartialToken() != "ddfaecb8e9325e77" {
+		t.Errorf("Expected partial token ddfaecb8e9325e77, got %s", token.PartialToken())
+	}
+
+	if err := client.DeleteSession(context.Background(), "ddfaecb8e9325e77"); err != nil || deleted != "ddfaecb8e9325e77" {
+		t.Errorf("DeleteSession failed: %v (deleted %q)", err, deleted)
+	}
+}
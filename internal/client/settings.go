@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TsigKey is a TSIG key used to authenticate zone transfers and dynamic updates
+type TsigKey struct {
+	KeyName       string `json:"keyName"`
+	SharedSecret  string `json:"sharedSecret"`
+	AlgorithmName string `json:"algorithmName"`
+}
+
+// DNSSettings represents the global server settings returned by the settings/get API. Only the
+// settings managed by the provider are decoded.
+type DNSSettings struct {
+	Version                 string   `json:"version"`
+	DnsServerDomain         string   `json:"dnsServerDomain"`
+	DnsServerLocalEndPoints []string `json:"dnsServerLocalEndPoints"`
+
+	// Optional protocols
+	EnableDnsOverHttp         bool   `json:"enableDnsOverHttp"`
+	EnableDnsOverTls          bool   `json:"enableDnsOverTls"`
+	EnableDnsOverHttps        bool   `json:"enableDnsOverHttps"`
+	EnableDnsOverQuic         bool   `json:"enableDnsOverQuic"`
+	DnsOverHttpPort           int64  `json:"dnsOverHttpPort"`
+	DnsOverTlsPort            int64  `json:"dnsOverTlsPort"`
+	DnsOverHttpsPort          int64  `json:"dnsOverHttpsPort"`
+	DnsOverQuicPort           int64  `json:"dnsOverQuicPort"`
+	DnsTlsCertificatePath     string `json:"dnsTlsCertificatePath"`
+	DnsTlsCertificatePassword string `json:"dnsTlsCertificatePassword"` // Masked by the API
+
+	// TSIG keys, with their shared secrets
+	TsigKeys []TsigKey `json:"tsigKeys"`
+
+	// Recursion
+	Recursion           string   `json:"recursion"`
+	RecursionNetworkACL []string `json:"recursionNetworkACL"`
+
+	// Forwarders
+	Forwarders           []string `json:"forwarders"`
+	ForwarderProtocol    string   `json:"forwarderProtocol"`
+	ConcurrentForwarding bool     `json:"concurrentForwarding"`
+
+	// Cache
+	CacheMaximumEntries   int64 `json:"cacheMaximumEntries"`
+	CacheMinimumRecordTTL int64 `json:"cacheMinimumRecordTtl"`
+	CacheMaximumRecordTTL int64 `json:"cacheMaximumRecordTtl"`
+	ServeStale            bool  `json:"serveStale"`
+
+	// Blocking
+	EnableBlocking          bool     `json:"enableBlocking"`
+	BlockingType            string   `json:"blockingType"`
+	BlockingAnswerTTL       int64    `json:"blockingAnswerTtl"`
+	CustomBlockingAddresses []string `json:"customBlockingAddresses"`
+	BlockingBypassList      []string `json:"blockingBypassList"`
+
+	// Block lists. Allow list URLs are listed with a "!" prefix.
+	BlockListUrls                []string `json:"blockListUrls"`
+	BlockListUpdateIntervalHours int64    `json:"blockListUpdateIntervalHours"`
+	BlockListNextUpdatedOn       string   `json:"blockListNextUpdatedOn"`
+
+	// Logging
+	EnableLogging      bool   `json:"enableLogging"`
+	IgnoreResolverLogs bool   `json:"ignoreResolverLogs"`
+	LogQueries         bool   `json:"logQueries"`
+	UseLocalTime       bool   `json:"useLocalTime"`
+	LogFolder          string `json:"logFolder"`
+	MaxLogFileDays     int64  `json:"maxLogFileDays"`
+}
+
+// GetDNSSettings retrieves the global server settings
+func (c *Client) GetDNSSettings(ctx context.Context) (*DNSSettings, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/settings/get").Endpoint()
+
+	var response DNSSettings
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get DNS settings: %w", err)
+	}
+
+	return &response, nil
+}
+
+// SetDNSSettings updates the global server settings and returns the settings as saved by the
+// server. The settings map holds API parameters (e.g. "forwarders" or "recursion"); settings
+// left out keep their current value.
+func (c *Client) SetDNSSettings(ctx context.Context, settings map[string]string) (*DNSSettings, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/settings/set").Params(settings).Endpoint()
+
+	var response DNSSettings
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to set DNS settings: %w", err)
+	}
+
+	return &response, nil
+}
+
+// TsigKeysParam renders TSIG keys as the pipe separated rows of key name, shared secret and
+// algorithm expected by the tsigKeys setting. The setting replaces every key, and "false" removes
+// them all.
+func TsigKeysParam(keys []TsigKey) string {
+	if len(keys) == 0 {
+		return "false"
+	}
+
+	cells := make([]string, 0, len(keys)*3)
+	for _, key := range keys {
+		cells = append(cells, key.KeyName, key.SharedSecret, key.AlgorithmName)
+	}
+	return strings.Join(cells, "|")
+}
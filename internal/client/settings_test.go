@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestGetDNSSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/settings/get" {
+			t.Errorf("Expected path /api/settings/get, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {
+			"version": "13.5", "dnsServerDomain": "server1", "dnsServerLocalEndPoints": ["0.0.0.0:53", "[::]:53"],
+			"enableDnsOverTls": true, "enableDnsOverHttps": false, "dnsOverTlsPort": 853, "dnsOverHttpsPort": 443,
+			"dnsTlsCertificatePath": null, "dnsTlsCertificatePassword": "************",
+			"recursion": "AllowOnlyForPrivateNetworks", "recursionNetworkACL": [],
+			"forwarders": null, "forwarderProtocol": "Udp", "concurrentForwarding": true,
+			"cacheMaximumEntries": 10000, "cacheMinimumRecordTtl": 10, "cacheMaximumRecordTtl": 604800, "serveStale": true,
+			"enableBlocking": true, "blockingType": "NxDomain", "blockingAnswerTtl": 30, "customBlockingAddresses": ["127.0.0.1"],
+			"enableLogging": true, "logQueries": false, "logFolder": "logs", "maxLogFileDays": 30,
+			"tsigKeys": [{"keyName": "home", "sharedSecret": "E9crgbHbzgEI+e+/pBmARRif70ScKf2sc/FjrgnCWyc=", "algorithmName": "hmac-sha256"}]
+		}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	settings, err := client.GetDNSSettings(context.Background())
+	if err != nil {
+		t.Fatalf("GetDNSSettings failed: %v", err)
+	}
+	if settings.DnsServerDomain != "server1" || len(settings.DnsServerLocalEndPoints) != 2 {
+		t.Errorf("Unexpected server settings: %+v", settings)
+	}
+	if !settings.EnableDnsOverTls || settings.EnableDnsOverHttps || settings.DnsOverTlsPort != 853 || settings.DnsTlsCertificatePath != "" {
+		t.Errorf("Unexpected optional protocol settings: %+v", settings)
+	}
+	if settings.Forwarders != nil || settings.ForwarderProtocol != "Udp" || !settings.ConcurrentForwarding {
+		t.Errorf("Unexpected forwarder settings: %+v", settings)
+	}
+	if len(settings.TsigKeys) != 1 || settings.TsigKeys[0].KeyName != "home" || settings.TsigKeys[0].AlgorithmName != "hmac-sha256" {
+		t.Errorf("Unexpected TSIG keys: %+v", settings.TsigKeys)
+	}
+	if settings.CacheMaximumRecordTTL != 604800 || settings.BlockingType != "NxDomain" || settings.MaxLogFileDays != 30 {
+		t.Errorf("Unexpected cache, blocking or log settings: %+v", settings)
+	}
+}
+
+func TestSetDNSSettings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/settings/set" {
+			t.Errorf("Expected path /api/settings/set, got %s", r.URL.Path)
+		}
+		query := requestParams(r)
+		if query.Get("forwarders") != "1.1.1.1,8.8.8.8" {
+			t.Errorf("Expected forwarders 1.1.1.1,8.8.8.8, got %q", query.Get("forwarders"))
+		}
+		if query.Get("recursion") != "Deny" {
+			t.Errorf("Expected recursion Deny, got %q", query.Get("recursion"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok", "response": {"forwarders": ["1.1.1.1", "8.8.8.8"], "recursion": "Deny"}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	settings, err := client.SetDNSSettings(context.Background(), map[string]string{
+		"forwarders": "1.1.1.1,8.8.8.8",
+		"recursion":  "Deny",
+	})
+	if err != nil {
+		t.Fatalf("SetDNSSettings failed: %v", err)
+	}
+	if !slices.Equal(settings.Forwarders, []string{"1.1.1.1", "8.8.8.8"}) || settings.Recursion != "Deny" {
+		t.Errorf("Unexpected updated settings: %+v", settings)
+	}
+}
+
+func TestTsigKeysParam(t *testing.T) {
+	keys := []TsigKey{
+		{KeyName: "home", SharedSecret: "E9crgbHbzgEI+e+/pBmARRif70ScKf2sc/FjrgnCWyc=", AlgorithmName: "hmac-sha256"},
+		{KeyName: "xfr.example.com", SharedSecret: "c2VjcmV0", AlgorithmName: "hmac-sha512"},
+	}
+
+	expected := "home|E9crgbHbzgEI+e+/pBmARRif70ScKf2sc/FjrgnCWyc=|hmac-sha256|xfr.example.com|c2VjcmV0|hmac-sha512"
+	if param := TsigKeysParam(keys); param != expected {
+		t.Errorf("Expected %s, got %s", expected, param)
+	}
+	if param := TsigKeysParam(nil); param != "false" {
+		t.Errorf("Expected false for no keys, got %s", param)
+	}
+}
@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// requestTimeoutKey is the context key of the per-request timeout override
+type requestTimeoutKey struct{}
+
+// WithRequestTimeout returns a context overriding the client's timeout for every API request made
+// with it, so long operations such as app installs can wait longer than record reads.
+// Each attempt gets the full timeout; the deadline of the context itself still bounds all retries.
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey{}, timeout)
+}
+
+// requestContext bounds a single API request by the timeout of the context, falling back to
+// the client's configured timeout
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.timeout
+	if override, ok := ctx.Value(requestTimeoutKey{}).(time.Duration); ok && override > 0 {
+		timeout = override
+	}
+
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		timeout:    20 * time.Millisecond,
+	}
+
+	if err := client.DoRequest(context.Background(), http.MethodGet, "/api/slow", nil, nil); err == nil {
+		t.Error("Expected the client timeout to abort the request")
+	}
+
+	ctx := WithRequestTimeout(context.Background(), 5*time.Second)
+	if err := client.DoRequest(ctx, http.MethodGet, "/api/slow", nil, nil); err != nil {
+		t.Errorf("Expected the overridden timeout to allow the request, got %v", err)
+	}
+}
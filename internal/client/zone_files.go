@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ImportZoneOptions controls how a zone file is merged into an existing zone
+type ImportZoneOptions struct {
+	// Overwrite replaces the existing record sets of the names and types in the file instead of
+	// adding the imported records to them
+	Overwrite bool
+	// OverwriteSOASerial applies the SOA serial of the file. A serial lower than the current one
+	// breaks zone transfers to secondaries.
+	OverwriteSOASerial bool
+}
+
+// zoneFileExport holds an exported zone file
+type zoneFileExport struct {
+	content string
+}
+
+func (e *zoneFileExport) decodeText(r io.Reader) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	e.content = string(content)
+	return nil
+}
+
+// ExportZone returns the records of a zone as an RFC 1035 zone file
+func (c *Client) ExportZone(ctx context.Context, zoneName string) (string, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return "", err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/export").Param("zone", zoneName).Endpoint()
+
+	var export zoneFileExport
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &export); err != nil {
+		return "", fmt.Errorf("failed to export zone %s: %w", zoneName, err)
+	}
+
+	return export.content, nil
+}
+
+// ImportZone imports the records of an RFC 1035 zone file into an existing zone. Records of the
+// zone missing from the file are kept.
+func (c *Client) ImportZone(ctx context.Context, zoneName, content string, options ImportZoneOptions) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/import").
+		Param("zone", zoneName).
+		Param("importType", "Text").
+		BoolParam("overwrite", options.Overwrite).
+		BoolParam("overwriteSoaSerial", options.OverwriteSOASerial).
+		Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, textBody(content), nil); err != nil {
+		return fmt.Errorf("failed to import zone %s: %w", zoneName, err)
+	}
+
+	return nil
+}
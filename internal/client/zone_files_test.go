@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestZoneFiles(t *testing.T) {
+	zoneFile := "$ORIGIN example.com.\n@ 3600 IN NS ns1.example.com.\nwww 300 IN A 192.0.2.1\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("token") != "test-token" || query.Get("zone") != "example.com" {
+			t.Errorf("Expected the token and zone in the query, got %s", r.URL.RawQuery)
+		}
+
+		switch r.URL.Path {
+		case "/api/zones/export":
+			w.Header().Set("Content-Type", "text/plain")
+			w.Header().Set("Content-Disposition", "attachment; filename=example.com.zone")
+			_, _ = w.Write([]byte(zoneFile))
+		case "/api/zones/import":
+			if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "text/plain" {
+				t.Errorf("Expected a plain text POST, got %s %s", r.Method, r.Header.Get("Content-Type"))
+			}
+			if query.Get("overwrite") != "true" || query.Get("overwriteSoaSerial") != "false" || query.Get("importType") != "Text" {
+				t.Errorf("Unexpected import parameters: %s", r.URL.RawQuery)
+			}
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != zoneFile {
+				t.Errorf("Expected the zone file as body, got %q", body)
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	exported, err := client.ExportZone(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ExportZone failed: %v", err)
+	}
+	if exported != zoneFile {
+		t.Errorf("Expected the exported zone file, got %q", exported)
+	}
+
+	if err := client.ImportZone(context.Background(), "example.com", zoneFile, ImportZoneOptions{Overwrite: true}); err != nil {
+		t.Fatalf("ImportZone failed: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestZoneLists(t *testing.T) {
+	var added, deleted, imported string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		domain := requestParams(r).Get("domain")
+
+		switch r.URL.Path {
+		case "/api/blocked/list":
+			switch domain {
+			case "":
+				_, _ = w.Write([]byte(`{"status": "ok", "response": {"domain": "", "zones": ["com", "net"], "records": []}}`))
+			case "com":
+				_, _ = w.Write([]byte(`{"status": "ok", "response": {"domain": "com", "zones": ["ads.com", "tracker"], "records": []}}`))
+			case "ads.com", "tracker.com", "net":
+				_, _ = w.Write([]byte(`{"status": "ok", "response": {"domain": "` + domain + `", "zones": [], "records": [
+					{"name": "` + domain + `", "type": "NS", "ttl": "14400 (4 hours)", "rData": {"value": "server1"}},
+					{"name": "` + domain + `", "type": "SOA", "ttl": "14400 (4 hours)", "rData": {"primaryNameServer": "server1"}}
+				]}}`))
+			default:
+				_, _ = w.Write([]byte(`{"status": "ok", "response": {"domain": "` + domain + `", "zones": [], "records": []}}`))
+			}
+		case "/api/allowed/add":
+			added = domain
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		case "/api/allowed/delete":
+			deleted = domain
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		case "/api/blocked/import":
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST, got %s", r.Method)
+			}
+			imported = requestParams(r).Get("blockedZones")
+			_, _ = w.Write([]byte(`{"status": "ok"}`))
+		case "/api/blocked/export":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("tracker.com\r\nAds.com\r\n\r\nnet\r\n"))
+		case "/api/allowed/export":
+			_, _ = w.Write([]byte(`{"status": "error", "errorMessage": "access denied"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	domains, err := client.ListZoneListDomains(context.Background(), BlockedZoneList)
+	if err != nil {
+		t.Fatalf("ListZoneListDomains failed: %v", err)
+	}
+	// Subdomains are listed both as full names and as single labels
+	if expected := []string{"ads.com", "net", "tracker.com"}; !slices.Equal(domains, expected) {
+		t.Errorf("Expected %v, got %v", expected, domains)
+	}
+
+	found, err := client.ZoneListContains(context.Background(), BlockedZoneList, "ads.com")
+	if err != nil || !found {
+		t.Errorf("Expected ads.com to be blocked, got %v (%v)", found, err)
+	}
+	found, err = client.ZoneListContains(context.Background(), BlockedZoneList, "example.com")
+	if err != nil || found {
+		t.Errorf("Expected example.com not to be blocked, got %v (%v)", found, err)
+	}
+
+	if err := client.AddToZoneList(context.Background(), AllowedZoneList, "example.com"); err != nil || added != "example.com" {
+		t.Errorf("AddToZoneList failed: %v (added %q)", err, added)
+	}
+	if err := client.DeleteFromZoneList(context.Background(), AllowedZoneList, "example.com"); err != nil || deleted != "example.com" {
+		t.Errorf("DeleteFromZoneList failed: %v (deleted %q)", err, deleted)
+	}
+
+	if err := client.ImportToZoneList(context.Background(), BlockedZoneList, []string{"ads.com", "tracker.com"}); err != nil || imported != "ads.com,tracker.com" {
+		t.Errorf("ImportToZoneList failed: %v (imported %q)", err, imported)
+	}
+
+	exported, err := client.ExportZoneList(context.Background(), BlockedZoneList)
+	if err != nil {
+		t.Fatalf("ExportZoneList failed: %v", err)
+	}
+	if expected := []string{"ads.com", "net", "tracker.com"}; !slices.Equal(exported, expected) {
+		t.Errorf("Expected %v, got %v", expected, exported)
+	}
+
+	// Errors are still returned in the JSON envelope
+	if _, err := client.ExportZoneList(context.Background(), AllowedZoneList); err == nil || !strings.Contains(err.Error(), "access denied") {
+		t.Errorf("Expected the export error to be returned, got %v", err)
+	}
+}
@@ -2,22 +2,32 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
-	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Zone represents a DNS zone
 type Zone struct {
-	Name         string `json:"name"`
-	Type         string `json:"type"`
-	Internal     bool   `json:"internal"`
-	Disabled     bool   `json:"disabled"`
-	DnssecStatus string `json:"dnssecStatus,omitempty"`
-	NotifyFailed bool   `json:"notifyFailed,omitempty"`
-	Expiry       string `json:"expiry,omitempty"`
-	LastModified string `json:"lastModified,omitempty"`
+	Name            string   `json:"name"`
+	Type            string   `json:"type"`
+	Internal        bool     `json:"internal"`
+	Disabled        bool     `json:"disabled"`
+	DnssecStatus    string   `json:"dnssecStatus,omitempty"`
+	SoaSerial       uint32   `json:"soaSerial,omitempty"`
+	NotifyFailed    bool     `json:"notifyFailed,omitempty"`
+	NotifyFailedFor []string `json:"notifyFailedFor,omitempty"`
+	Expiry          string   `json:"expiry,omitempty"`
+	IsExpired       bool     `json:"isExpired,omitempty"`
+	SyncFailed      bool     `json:"syncFailed,omitempty"`
+	LastModified    string   `json:"lastModified,omitempty"`
+	Catalog         string   `json:"catalog,omitempty"`
 }
 
 // ZoneInfo represents detailed zone information
@@ -33,6 +43,54 @@ type ZoneInfo struct {
 	LastModified string `json:"lastModified"`
 }
 
+// ZoneOptions represents the response from zones/options/get API
+type ZoneOptions struct {
+	Name                           string   `json:"name"`
+	Type                           string   `json:"type"`
+	Internal                       bool     `json:"internal"`
+	DnssecStatus                   string   `json:"dnssecStatus"`
+	Disabled                       bool     `json:"disabled"`
+	Catalog                        string   `json:"catalog,omitempty"`
+	UseSoaSerialDateScheme         *bool    `json:"useSoaSerialDateScheme,omitempty"`
+	PrimaryNameServerAddresses     []string `json:"primaryNameServerAddresses,omitempty"`
+	PrimaryZoneTransferProtocol    string   `json:"primaryZoneTransferProtocol,omitempty"`
+	PrimaryZoneTransferTsigKeyName string   `json:"primaryZoneTransferTsigKeyName,omitempty"`
+	ValidateZone                   *bool    `json:"validateZone,omitempty"`
+	ZoneTransferTsigKeyNames       []string `json:"zoneTransferTsigKeyNames,omitempty"`
+	ZoneTransfer                   string   `json:"zoneTransfer,omitempty"`
+	ZoneTransferNetworkACL         []string `json:"zoneTransferNetworkACL,omitempty"`
+	Notify                         string   `json:"notify,omitempty"`
+	NotifyNameServers              []string `json:"notifyNameServers,omitempty"`
+	QueryAccess                    string   `json:"queryAccess,omitempty"`
+	QueryAccessNetworkACL          []string `json:"queryAccessNetworkACL,omitempty"`
+	Update                         string   `json:"update,omitempty"`
+	UpdateNetworkACL               []string `json:"updateNetworkACL,omitempty"`
+
+	UpdateSecurityPolicies []ZoneUpdateSecurityPolicy `json:"updateSecurityPolicies,omitempty"`
+
+	// Fields holds the fields present in the response, as older servers leave out optional ones
+	Fields FieldSet `json:"-"`
+}
+
+// UnmarshalJSON decodes the zone options and records which fields the server returned
+func (o *ZoneOptions) UnmarshalJSON(data []byte) error {
+	type zoneOptions ZoneOptions
+	if err := json.Unmarshal(data, (*zoneOptions)(o)); err != nil {
+		return err
+	}
+
+	o.Fields = responseFields(data)
+	return nil
+}
+
+// ZoneUpdateSecurityPolicy allows the holder of a TSIG key to dynamically update records of the
+// given types at a domain name, or at every subdomain with a wildcard name
+type ZoneUpdateSecurityPolicy struct {
+	TsigKeyName  string   `json:"tsigKeyName"`
+	Domain       string   `json:"domain"`
+	AllowedTypes []string `json:"allowedTypes"`
+}
+
 // ZoneListResponse represents the response from zones/list API
 type ZoneListResponse struct {
 	PageNumber int    `json:"pageNumber"`
@@ -63,6 +121,26 @@ func (c *Client) ListZones(ctx context.Context) ([]Zone, error) {
 	return response.Zones, nil
 }
 
+// ListZonesPage retrieves one page of zones, numbered from 1. Servers return all zones on a single
+// page when they do not support paging.
+func (c *Client) ListZonesPage(ctx context.Context, pageNumber, zonesPerPage int) (*ZoneListResponse, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/list").
+		Param("pageNumber", strconv.Itoa(pageNumber)).
+		Param("zonesPerPage", strconv.Itoa(zonesPerPage)).
+		Endpoint()
+
+	var response ZoneListResponse
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to list zones page %d: %w", pageNumber, err)
+	}
+
+	return &response, nil
+}
+
 // GetZone retrieves information about a specific zone
 func (c *Client) GetZone(ctx context.Context, zoneName string) (*ZoneInfo, error) {
 	if err := c.Authenticate(ctx); err != nil {
@@ -71,10 +149,7 @@ func (c *Client) GetZone(ctx context.Context, zoneName string) (*ZoneInfo, error
 
 	// For getting zone details, we can use the list endpoint with pagination
 	// to find our specific zone, or we can use zone/options to get zone info
-	params := url.Values{}
-	params.Set("zone", zoneName)
-
-	endpoint := "/api/zones/options/get?" + params.Encode()
+	endpoint := NewRequest().Path("/api/zones/options/get").Param("zone", zoneName).Endpoint()
 
 	var response ZoneInfo
 	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
@@ -84,19 +159,48 @@ func (c *Client) GetZone(ctx context.Context, zoneName string) (*ZoneInfo, error
 	return &response, nil
 }
 
+// GetZoneOptions retrieves the options of a zone
+func (c *Client) GetZoneOptions(ctx context.Context, zoneName string) (*ZoneOptions, error) {
+	if err := c.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/options/get").Param("zone", zoneName).Endpoint()
+
+	var response ZoneOptions
+	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get options of zone %s: %w", zoneName, err)
+	}
+	c.observeFields(response.Fields)
+
+	return &response, nil
+}
+
+// SetZoneOptions updates the options of a zone. The options map holds API parameters
+// (e.g. "catalog" or "primaryZoneTransferProtocol"); options left out keep their current value.
+func (c *Client) SetZoneOptions(ctx context.Context, zoneName string, options map[string]string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/options/set").Param("zone", zoneName).Params(options).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to set options of zone %s: %w", zoneName, err)
+	}
+
+	return nil
+}
+
 // CreateZone creates a new DNS zone
 func (c *Client) CreateZone(ctx context.Context, zoneName, zoneType string) error {
 	if err := c.Authenticate(ctx); err != nil {
 		return err
 	}
 
-	params := url.Values{}
-	params.Set("zone", zoneName)
-	params.Set("type", zoneType)
-
-	endpoint := "/api/zones/create?" + params.Encode()
+	endpoint := NewRequest().Path("/api/zones/create").Param("zone", zoneName).Param("type", zoneType).Endpoint()
 
-	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, nil); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
 		return fmt.Errorf("failed to create zone %s: %w", zoneName, err)
 	}
 
@@ -109,12 +213,9 @@ func (c *Client) DeleteZone(ctx context.Context, zoneName string) error {
 		return err
 	}
 
-	params := url.Values{}
-	params.Set("zone", zoneName)
+	endpoint := NewRequest().Path("/api/zones/delete").Param("zone", zoneName).Endpoint()
 
-	endpoint := "/api/zones/delete?" + params.Encode()
-
-	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, nil); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
 		return fmt.Errorf("failed to delete zone %s: %w", zoneName, err)
 	}
 
@@ -127,12 +228,9 @@ func (c *Client) EnableZone(ctx context.Context, zoneName string) error {
 		return err
 	}
 
-	params := url.Values{}
-	params.Set("zone", zoneName)
-
-	endpoint := "/api/zones/enable?" + params.Encode()
+	endpoint := NewRequest().Path("/api/zones/enable").Param("zone", zoneName).Endpoint()
 
-	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, nil); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
 		return fmt.Errorf("failed to enable zone %s: %w", zoneName, err)
 	}
 
@@ -145,18 +243,120 @@ func (c *Client) DisableZone(ctx context.Context, zoneName string) error {
 		return err
 	}
 
-	params := url.Values{}
-	params.Set("zone", zoneName)
+	endpoint := NewRequest().Path("/api/zones/disable").Param("zone", zoneName).Endpoint()
 
-	endpoint := "/api/zones/disable?" + params.Encode()
-
-	if err := c.doRequest(ctx, http.MethodGet, endpoint, nil, nil); err != nil {
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
 		return fmt.Errorf("failed to disable zone %s: %w", zoneName, err)
 	}
 
 	return nil
 }
 
+// ResyncZone makes the server transfer a Secondary or Stub zone from its primary again
+func (c *Client) ResyncZone(ctx context.Context, zoneName string) error {
+	if err := c.Authenticate(ctx); err != nil {
+		return err
+	}
+
+	endpoint := NewRequest().Path("/api/zones/resync").Param("zone", zoneName).Endpoint()
+
+	if err := c.doRequest(ctx, http.MethodPost, endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to resync zone %s: %w", zoneName, err)
+	}
+
+	return nil
+}
+
+// DeleteZonesOptions controls how DeleteZonesByPrefix spreads its requests
+type DeleteZonesOptions struct {
+	// Concurrency is the maximum number of deletions in flight. Defaults to 4.
+	Concurrency int
+	// Interval is the minimum delay between starting two deletions. Defaults to no delay.
+	Interval time.Duration
+}
+
+// ListZonesByPrefix lists the zones whose name starts with the prefix, ignoring case
+func (c *Client) ListZonesByPrefix(ctx context.Context, prefix string) ([]Zone, error) {
+	zones, err := c.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix = strings.ToLower(prefix)
+	matching := make([]Zone, 0)
+	for _, zone := range zones {
+		if strings.HasPrefix(strings.ToLower(zone.Name), prefix) {
+			matching = append(matching, zone)
+		}
+	}
+
+	return matching, nil
+}
+
+// DeleteZonesByPrefix deletes every zone whose name starts with the prefix, e.g. orphaned test
+// zones or zones of a decommissioned workspace. Deletions run concurrently and are rate limited
+// according to opts. It returns the names of the deleted zones together with any deletion errors.
+func (c *Client) DeleteZonesByPrefix(ctx context.Context, prefix string, opts DeleteZonesOptions) ([]string, error) {
+	if prefix == "" {
+		return nil, fmt.Errorf("refusing to delete zones with an empty prefix")
+	}
+
+	zones, err := c.ListZonesByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	var ticker *time.Ticker
+	if opts.Interval > 0 {
+		ticker = time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		deleted = make([]string, 0, len(zones))
+		errs    []error
+	)
+	semaphore := make(chan struct{}, concurrency)
+
+	for _, zone := range zones {
+		if ticker != nil {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				wg.Wait()
+				return deleted, errors.Join(append(errs, ctx.Err())...)
+			}
+		}
+
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func(zoneName string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			err := c.DeleteZone(ctx, zoneName)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			deleted = append(deleted, zoneName)
+		}(zone.Name)
+	}
+	wg.Wait()
+
+	return deleted, errors.Join(errs...)
+}
+
 // ZoneExists checks if a zone exists
 func (c *Client) ZoneExists(ctx context.Context, zoneName string) (bool, error) {
 	zones, err := c.ListZones(ctx)
@@ -172,3 +372,92 @@ func (c *Client) ZoneExists(ctx context.Context, zoneName string) (bool, error)
 
 	return false, nil
 }
+
+// GetZoneSOA returns the SOA record of a zone. Results are cached for the lifetime of the client,
+// so the SOA is only fetched once per zone when validating many records during a plan.
+func (c *Client) GetZoneSOA(ctx context.Context, zoneName string) (*DNSRecord, error) {
+	key := strings.ToLower(strings.TrimSuffix(zoneName, "."))
+
+	c.soaCacheMu.Lock()
+	soa, ok := c.soaCache[key]
+	c.soaCacheMu.Unlock()
+	if ok {
+		return &soa, nil
+	}
+
+	recordsResponse, err := c.GetRecords(ctx, zoneName, zoneName, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SOA record of zone %s: %w", zoneName, err)
+	}
+
+	for _, record := range recordsResponse.Records {
+		if record.Type != "SOA" {
+			continue
+		}
+
+		c.soaCacheMu.Lock()
+		if c.soaCache == nil {
+			c.soaCache = make(map[string]DNSRecord)
+		}
+		c.soaCache[key] = record
+		c.soaCacheMu.Unlock()
+
+		return &record, nil
+	}
+
+	return nil, fmt.Errorf("zone %s has no SOA record", zoneName)
+}
+
+// nextSOASerial returns the serial following serial in RFC 1982 serial number arithmetic, where
+// the largest serial wraps around. It wraps to 1 rather than 0, which is an equally valid
+// increment, since a zero serial reads as unset.
+func nextSOASerial(serial uint32) uint32 {
+	if serial == math.MaxUint32 {
+		return 1
+	}
+	return serial + 1
+}
+
+// BumpZoneSerial increments the SOA serial of a zone by rewriting its SOA record with the next
+// serial. The zone change makes the server send NOTIFY to its configured secondaries. Returns
+// the new serial.
+func (c *Client) BumpZoneSerial(ctx context.Context, zoneName string, useSerialDateScheme bool) (uint32, error) {
+	recordsResponse, err := c.GetRecords(ctx, zoneName, zoneName, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read SOA record of zone %s: %w", zoneName, err)
+	}
+
+	var soa *DNSRecord
+	for i, record := range recordsResponse.Records {
+		if record.Type == "SOA" {
+			soa = &recordsResponse.Records[i]
+			break
+		}
+	}
+	if soa == nil {
+		return 0, fmt.Errorf("zone %s has no SOA record", zoneName)
+	}
+
+	endpoint := UpdateRecordRequest{
+		Zone:   zoneName,
+		Domain: zoneName,
+		TTL:    int64(soa.TTL),
+		New: SOARecordData{
+			PrimaryNameServer:   soa.RData.PrimaryNameServer,
+			ResponsiblePerson:   soa.RData.ResponsiblePerson,
+			Serial:              nextSOASerial(soa.RData.Serial),
+			Refresh:             int64(soa.RData.Refresh),
+			Retry:               int64(soa.RData.Retry),
+			Expire:              int64(soa.RData.Expire),
+			Minimum:             int64(soa.RData.Minimum),
+			UseSerialDateScheme: &useSerialDateScheme,
+		},
+	}.encode(NewRequest().Path("/api/zones/records/update")).Endpoint()
+
+	var response UpdateRecordResponse
+	if err := c.DoRequest(ctx, http.MethodPost, endpoint, nil, &response); err != nil {
+		return 0, fmt.Errorf("failed to bump SOA serial of zone %s: %w", zoneName, err)
+	}
+
+	return response.UpdatedRecord.RData.Serial, nil
+}
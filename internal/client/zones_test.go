@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBumpZoneSerial(t *testing.T) {
+	// The largest serial wraps around, skipping zero
+	for current, next := range map[uint32]uint32{41: 42, math.MaxUint32: 1} {
+		t.Run(strconv.FormatUint(uint64(current), 10), func(t *testing.T) {
+			testBumpZoneSerial(t, current, next)
+		})
+	}
+}
+
+func testBumpZoneSerial(t *testing.T, current, next uint32) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var mockResponse APIResponse
+		switch r.URL.Path {
+		case "/api/zones/records/get":
+			mockResponse = APIResponse{
+				Status: "ok",
+				Response: json.RawMessage(fmt.Sprintf(`{
+					"records": [
+						{"name": "example.com", "type": "NS", "ttl": 3600, "rData": {"nameServer": "ns1.example.com"}},
+						{"name": "example.com", "type": "SOA", "ttl": 900, "rData": {
+							"primaryNameServer": "ns1.example.com",
+							"responsiblePerson": "hostadmin.example.com",
+							"serial": %d,
+							"refresh": 900,
+							"retry": 300,
+							"expire": 604800,
+							"minimum": 900
+						}}
+					]
+				}`, current)),
+			}
+		case "/api/zones/records/update":
+			query := requestParams(r)
+			expected := map[string]string{
+				"zone":                "example.com",
+				"domain":              "example.com",
+				"type":                "SOA",
+				"ttl":                 "900",
+				"primaryNameServer":   "ns1.example.com",
+				"responsiblePerson":   "hostadmin.example.com",
+				"serial":              strconv.FormatUint(uint64(next), 10),
+				"refresh":             "900",
+				"retry":               "300",
+				"expire":              "604800",
+				"minimum":             "900",
+				"useSerialDateScheme": "false",
+			}
+			for key, value := range expected {
+				if query.Get(key) != value {
+					t.Errorf("Expected %s=%s, got %s", key, value, query.Get(key))
+				}
+			}
+			mockResponse = APIResponse{
+				Status:   "ok",
+				Response: json.RawMessage(fmt.Sprintf(`{"updatedRecord": {"name": "example.com", "type": "SOA", "rData": {"serial": %d}}}`, next)),
+			}
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	serial, err := client.BumpZoneSerial(context.Background(), "example.com", false)
+	if err != nil {
+		t.Fatalf("BumpZoneSerial failed: %v", err)
+	}
+	if serial != next {
+		t.Errorf("Expected serial %d, got %d", next, serial)
+	}
+}
+
+func TestGetZoneSOACache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		mockResponse := APIResponse{
+			Status:   "ok",
+			Response: json.RawMessage(`{"records": [{"name": "example.com", "type": "SOA", "ttl": 900, "rData": {"serial": 7, "minimum": 300}}]}`),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	for _, zone := range []string{"example.com", "Example.com."} {
+		soa, err := client.GetZoneSOA(context.Background(), zone)
+		if err != nil {
+			t.Fatalf("GetZoneSOA failed: %v", err)
+		}
+		if soa.RData.Minimum != 300 {
+			t.Errorf("Expected SOA minimum 300, got %d", soa.RData.Minimum)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("Expected the SOA to be fetched once, got %d requests", requests)
+	}
+}
+
+func TestDeleteZonesByPrefix(t *testing.T) {
+	var mu sync.Mutex
+	deletedOnServer := make(map[string]bool)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mockResponse := APIResponse{Status: "ok"}
+		switch r.URL.Path {
+		case "/api/zones/list":
+			mockResponse.Response = json.RawMessage(`{"zones": [
+				{"name": "tf-acc-one.example.com", "type": "Primary"},
+				{"name": "TF-ACC-two.example.com", "type": "Forwarder"},
+				{"name": "production.example.com", "type": "Primary"}
+			]}`)
+		case "/api/zones/delete":
+			mu.Lock()
+			deletedOnServer[requestParams(r).Get("zone")] = true
+			mu.Unlock()
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	zones, err := client.ListZonesByPrefix(context.Background(), "tf-acc-")
+	if err != nil {
+		t.Fatalf("ListZonesByPrefix failed: %v", err)
+	}
+	if len(zones) != 2 {
+		t.Fatalf("Expected 2 matching zones, got %d", len(zones))
+	}
+
+	deleted, err := client.DeleteZonesByPrefix(context.Background(), "tf-acc-", DeleteZonesOptions{Concurrency: 2, Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("DeleteZonesByPrefix failed: %v", err)
+	}
+	if len(deleted) != 2 {
+		t.Errorf("Expected 2 deleted zones, got %v", deleted)
+	}
+	if deletedOnServer["production.example.com"] {
+		t.Error("Zone without the prefix should not be deleted")
+	}
+	if !deletedOnServer["tf-acc-one.example.com"] || !deletedOnServer["TF-ACC-two.example.com"] {
+		t.Errorf("Expected prefixed zones to be deleted, got %v", deletedOnServer)
+	}
+
+	if _, err := client.DeleteZonesByPrefix(context.Background(), "", DeleteZonesOptions{}); err == nil {
+		t.Error("Expected an error for an empty prefix")
+	}
+}
+
+func TestGetZoneOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/zones/options/get" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if requestParams(r).Get("zone") != "example.com" {
+			t.Errorf("Expected zone=example.com, got %s", requestParams(r).Get("zone"))
+		}
+
+		mockResponse := APIResponse{
+			Status: "ok",
+			Response: json.RawMessage(`{
+				"name": "example.com",
+				"type": "Secondary",
+				"internal": false,
+				"dnssecStatus": "Unsigned",
+				"disabled": false,
+				"primaryNameServerAddresses": ["192.168.10.5", "192.168.10.6"],
+				"primaryZoneTransferProtocol": "Tls",
+				"primaryZoneTransferTsigKeyName": "key1",
+				"validateZone": true
+			}`),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(mockResponse)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	options, err := client.GetZoneOptions(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetZoneOptions failed: %v", err)
+	}
+	if options.Type != "Secondary" || options.PrimaryZoneTransferProtocol != "Tls" || options.PrimaryZoneTransferTsigKeyName != "key1" {
+		t.Errorf("Unexpected zone options: %+v", options)
+	}
+	if len(options.PrimaryNameServerAddresses) != 2 {
+		t.Errorf("Expected 2 primary name server addresses, got %v", options.PrimaryNameServerAddresses)
+	}
+	if options.ValidateZone == nil || !*options.ValidateZone {
+		t.Errorf("Expected validateZone to be true")
+	}
+	if options.UseSoaSerialDateScheme != nil {
+		t.Errorf("Expected useSoaSerialDateScheme to be absent")
+	}
+}
+
+func TestSetZoneOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/zones/options/set" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		query := requestParams(r)
+		expected := map[string]string{
+			"zone":                        "example.com",
+			"primaryZoneTransferProtocol": "Tcp",
+			"validateZone":                "false",
+		}
+		for key, value := range expected {
+			if query.Get(key) != value {
+				t.Errorf("Expected %s=%s, got %s", key, value, query.Get(key))
+			}
+		}
+		if query.Has("catalog") {
+			t.Errorf("Expected options left out to not be sent, got catalog=%s", query.Get("catalog"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(APIResponse{Status: "ok", Response: json.RawMessage(`{}`)})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+		Token:      "test-token",
+		retries:    1,
+	}
+
+	err := client.SetZoneOptions(context.Background(), "example.com", map[string]string{
+		"primaryZoneTransferProtocol": "Tcp",
+		"validateZone":                "false",
+	})
+	if err != nil {
+		t.Fatalf("SetZoneOptions failed: %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// IP address families accepted by ipAddressValidator
+const (
+	ipFamilyAny  = ""
+	ipFamilyIPv4 = "IPv4"
+	ipFamilyIPv6 = "IPv6"
+)
+
+// validateIPAddress checks that value is an IP address of the given family
+func validateIPAddress(value, family string) error {
+	addr, err := netip.ParseAddr(value)
+	if err != nil || addr.Zone() != "" {
+		if family == ipFamilyAny {
+			return fmt.Errorf("%q is not a valid IPv4 or IPv6 address", value)
+		}
+		return fmt.Errorf("%q is not a valid %s address", value, family)
+	}
+
+	switch {
+	case family == ipFamilyIPv4 && !addr.Is4():
+		return fmt.Errorf("%q is not an IPv4 address", value)
+	case family == ipFamilyIPv6 && !addr.Is6():
+		return fmt.Errorf("%q is not an IPv6 address", value)
+	}
+	return nil
+}
+
+// validateForwarderAddress checks a forwarder in one of the forms the server accepts: this-server,
+// an IP address with an optional port, a host name with an optional port, a host name followed by
+// the address to reach it in parentheses (e.g. "cloudflare-dns.com (1.1.1.1:853)"), or a DNS-over-HTTPS URL
+func validateForwarderAddress(value string) error {
+	if value == "this-server" {
+		return nil
+	}
+
+	if open := strings.Index(value, " ("); open >= 0 {
+		end := strings.Index(value[open:], ")")
+		if end < 0 {
+			return fmt.Errorf("%q has an unterminated address in parentheses", value)
+		}
+		address := value[open+2 : open+end]
+		if _, err := netip.ParseAddrPort(address); err == nil {
+			return nil
+		}
+		return validateIPAddress(address, ipFamilyAny)
+	}
+
+	if strings.Contains(value, "://") {
+		if u, err := url.Parse(value); err != nil || u.Host == "" {
+			return fmt.Errorf("%q is not a valid forwarder URL", value)
+		}
+		return nil
+	}
+
+	if _, err := netip.ParseAddrPort(value); err == nil {
+		return nil
+	}
+	if _, err := netip.ParseAddr(value); err == nil {
+		return nil
+	}
+
+	host := value
+	if i := strings.LastIndex(value, ":"); i >= 0 {
+		host = value[:i]
+		if port, err := strconv.Atoi(value[i+1:]); err != nil || port < 1 || port > maxUint16 {
+			return fmt.Errorf("%q is not a valid forwarder address", value)
+		}
+	}
+
+	// Values made of digits and dots only are meant as IPv4 addresses, e.g. 999.1.2.3
+	if strings.Trim(host, "0123456789.") == "" {
+		return validateIPAddress(host, ipFamilyIPv4)
+	}
+	if !domainListNamePattern.MatchString(strings.ToLower(strings.TrimSuffix(host, "."))) {
+		return fmt.Errorf("%q is not a valid forwarder address", value)
+	}
+	return nil
+}
+
+// validateZoneName checks zone names the server interprets as reverse zones: network addresses
+// in CIDR notation and names under in-addr.arpa or ip6.arpa. Other domain names are not checked.
+func validateZoneName(name string) error {
+	if strings.Contains(name, "/") {
+		prefix, err := netip.ParsePrefix(name)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid network address in CIDR notation", name)
+		}
+		if prefix.Masked() != prefix {
+			return fmt.Errorf("%q has host bits set, the network address is %s", name, prefix.Masked())
+		}
+		if prefix.Addr().Is4() && (prefix.Bits() == 0 || prefix.Bits()%8 != 0) {
+			return fmt.Errorf("the prefix length of IPv4 reverse zone %q must be 8, 16, 24 or 32", name)
+		}
+		if prefix.Addr().Is6() && (prefix.Bits() == 0 || prefix.Bits()%4 != 0) {
+			return fmt.Errorf("the prefix length of IPv6 reverse zone %q must be a multiple of 4", name)
+		}
+		return nil
+	}
+
+	lower := strings.ToLower(strings.TrimSuffix(name, "."))
+	switch {
+	case strings.HasSuffix(lower, ".in-addr.arpa"):
+		labels := strings.Split(strings.TrimSuffix(lower, ".in-addr.arpa"), ".")
+		if len(labels) > 4 {
+			return fmt.Errorf("%q has more than 4 address labels", name)
+		}
+		for i, label := range labels {
+			// The leftmost label may name a classless delegation (RFC 2317), e.g. 0-25
+			if i == 0 && strings.ContainsAny(label, "-/") {
+				continue
+			}
+			if octet, err := strconv.Atoi(label); err != nil || octet > 255 || strconv.Itoa(octet) != label {
+				return fmt.Errorf("%q is not a valid IPv4 reverse zone name, %q is not an octet", name, label)
+			}
+		}
+	case strings.HasSuffix(lower, ".ip6.arpa"):
+		labels := strings.Split(strings.TrimSuffix(lower, ".ip6.arpa"), ".")
+		if len(labels) > 32 {
+			return fmt.Errorf("%q has more than 32 address labels", name)
+		}
+		for _, label := range labels {
+			if len(label) != 1 || !strings.Contains("0123456789abcdef", label) {
+				return fmt.Errorf("%q is not a valid IPv6 reverse zone name, %q is not a hex digit", name, label)
+			}
+		}
+	}
+	return nil
+}
+
+// ipAddressValidator checks that a string is an IP address of the given family
+type ipAddressValidator struct {
+	family string
+}
+
+func (v ipAddressValidator) Description(ctx context.Context) string {
+	if v.family == ipFamilyAny {
+		return "value must be an IPv4 or IPv6 address"
+	}
+	return fmt.Sprintf("value must be an %s address", v.family)
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateIPAddress(req.ConfigValue.ValueString(), v.family); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP Address", err.Error())
+	}
+}
+
+// forwarderAddressValidator checks that a string is a forwarder address the server accepts
+type forwarderAddressValidator struct{}
+
+func (v forwarderAddressValidator) Description(ctx context.Context) string {
+	return "value must be this-server, an IP address or host name with an optional port, or a DNS-over-HTTPS URL"
+}
+
+func (v forwarderAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v forwarderAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateForwarderAddress(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Forwarder Address", err.Error())
+	}
+}
+
+// zoneNameValidator checks reverse zone names, see validateZoneName
+type zoneNameValidator struct{}
+
+func (v zoneNameValidator) Description(ctx context.Context) string {
+	return "network addresses must be valid CIDR notation and reverse zone names must consist of address labels"
+}
+
+func (v zoneNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v zoneNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateZoneName(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Zone Name", err.Error())
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestValidateIPAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value  string
+		family string
+		valid  bool
+	}{
+		{value: "192.0.2.1", family: ipFamilyIPv4, valid: true},
+		{value: "999.1.2.3", family: ipFamilyIPv4, valid: false},
+		{value: "192.0.2", family: ipFamilyIPv4, valid: false},
+		{value: "2001:db8::1", family: ipFamilyIPv4, valid: false},
+		{value: "2001:db8::1", family: ipFamilyIPv6, valid: true},
+		{value: "2001:db8::g", family: ipFamilyIPv6, valid: false},
+		{value: "192.0.2.1", family: ipFamilyIPv6, valid: false},
+		{value: "fe80::1%eth0", family: ipFamilyIPv6, valid: false},
+		{value: "192.0.2.1", family: ipFamilyAny, valid: true},
+		{value: "2001:db8::1", family: ipFamilyAny, valid: true},
+		{value: "example.com", family: ipFamilyAny, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.family+" "+tt.value, func(t *testing.T) {
+			if err := validateIPAddress(tt.value, tt.family); (err == nil) != tt.valid {
+				t.Errorf("validateIPAddress(%q, %q) = %v, expected valid %v", tt.value, tt.family, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidateForwarderAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{value: "this-server", valid: true},
+		{value: "8.8.8.8", valid: true},
+		{value: "8.8.8.8:53", valid: true},
+		{value: "2606:4700:4700::1111", valid: true},
+		{value: "[2606:4700:4700::1111]:853", valid: true},
+		{value: "dns.google", valid: true},
+		{value: "dns.google:853", valid: true},
+		{value: "cloudflare-dns.com (1.1.1.1)", valid: true},
+		{value: "cloudflare-dns.com (1.1.1.1:853)", valid: true},
+		{value: "https://cloudflare-dns.com/dns-query", valid: true},
+		{value: "999.1.2.3", valid: false},
+		{value: "8.8.8", valid: false},
+		{value: "dns.google:99999", valid: false},
+		{value: "cloudflare-dns.com (1.1.1.999)", valid: false},
+		{value: "cloudflare-dns.com (1.1.1.1", valid: false},
+		{value: "not a host", valid: false},
+		{value: "https://", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if err := validateForwarderAddress(tt.value); (err == nil) != tt.valid {
+				t.Errorf("validateForwarderAddress(%q) = %v, expected valid %v", tt.value, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestValidateZoneName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "example.com", valid: true},
+		{name: "192.168.1.0/24", valid: true},
+		{name: "10.0.0.0/8", valid: true},
+		{name: "2001:db8::/32", valid: true},
+		{name: "192.168.1.1/24", valid: false},
+		{name: "192.168.1.0/25", valid: false},
+		{name: "999.168.1.0/24", valid: false},
+		{name: "2001:db8::/30", valid: false},
+		{name: "1.168.192.in-addr.arpa", valid: true},
+		{name: "0-25.1.168.192.in-addr.arpa", valid: true},
+		{name: "1.168.300.in-addr.arpa", valid: false},
+		{name: "01.168.192.in-addr.arpa", valid: false},
+		{name: "1.2.3.4.5.in-addr.arpa", valid: false},
+		{name: "8.b.d.0.1.0.0.2.ip6.arpa", valid: true},
+		{name: "8.B.D.0.1.0.0.2.IP6.ARPA.", valid: true},
+		{name: "db8.1.0.0.2.ip6.arpa", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateZoneName(tt.name); (err == nil) != tt.valid {
+				t.Errorf("validateZoneName(%q) = %v, expected valid %v", tt.name, err, tt.valid)
+			}
+		})
+	}
+}
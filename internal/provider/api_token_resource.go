@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &APITokenResource{}
+var _ resource.ResourceWithModifyPlan = &APITokenResource{}
+
+func NewAPITokenResource() resource.Resource {
+	return &APITokenResource{}
+}
+
+// APITokenResource defines the resource implementation.
+type APITokenResource struct {
+	client client.ClientAPI
+}
+
+// APITokenResourceModel describes the resource data model.
+type APITokenResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	User      types.String `tfsdk:"user"`
+	TokenName types.String `tfsdk:"token_name"`
+	Token     types.String `tfsdk:"token"`
+}
+
+func (r *APITokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *APITokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a non-expiring API token for a user of the Technitium DNS Server, for use by automation. " +
+			"The token has the same privileges as the user, so create a dedicated user with limited permissions. " +
+			"The token value is only returned when the token is created, so tokens cannot be imported. " +
+			"This resource is experimental: enable the `admin` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier (the partial token identifying the token's session)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "The username of the user the token is created for",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: replace,
+			},
+			"token_name": schema.StringAttribute{
+				MarkdownDescription: "The name identifying the token's session",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: replace,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The API token",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *APITokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *APITokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the token is being revoked
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	requireExperimentalFeature(ctx, r.client, client.ExperimentalAdmin, "technitium_api_token", &resp.Diagnostics)
+}
+
+func (r *APITokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data APITokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating API token", map[string]interface{}{
+		"user":       data.User.ValueString(),
+		"token_name": data.TokenName.ValueString(),
+	})
+
+	token, err := r.client.CreateAPIToken(ctx, data.User.ValueString(), data.TokenName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API token: %s", err.Error()))
+		return
+	}
+
+	data.ID = types.StringValue(token.PartialToken())
+	data.Token = types.StringValue(token.Token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APITokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data APITokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sessions, err := r.client.ListSessions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sessions: %s", err.Error()))
+		return
+	}
+
+	session := findAPITokenSession(sessions, data.ID.ValueString())
+	if session == nil {
+		tflog.Debug(ctx, "API token not found, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.User = types.StringValue(session.Username)
+	data.TokenName = types.StringValue(session.TokenName)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APITokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every attribute either requires replacement or is computed, so there is nothing to update
+	var data APITokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APITokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data APITokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Revoking API token", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+
+	if err := r.client.DeleteSession(ctx, data.ID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke API token: %s", err.Error()))
+		return
+	}
+}
+
+// findAPITokenSession returns the API token session with the partial token, or nil
+func findAPITokenSession(sessions []client.Session, partialToken string) *client.Session {
+	for i := range sessions {
+		if sessions[i].Type == client.SessionTypeAPIToken && strings.EqualFold(sessions[i].PartialToken, partialToken) {
+			return &sessions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestAPITokenResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewAPITokenResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_api_token" {
+			t.Errorf("Expected TypeName to be technitium_api_token, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewAPITokenResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		token, ok := resp.Schema.Attributes["token"]
+		if !ok || !token.IsSensitive() {
+			t.Error("Schema should have a sensitive 'token' attribute")
+		}
+	})
+}
+
+func TestAPITokenResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := &APITokenResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	m.On("CreateAPIToken", mock.Anything, "ci", "github-actions").Return(&client.APIToken{
+		Username:  "ci",
+		TokenName: "github-actions",
+		Token:     "ddfaecb8e9325e77865ee7e100f89596a65d3eae0e6dddcb33172355b95a64af",
+	}, nil)
+
+	created := createResource(t, r, resourcePlan(t, schemaResp, &APITokenResourceModel{
+		ID:        types.StringUnknown(),
+		User:      types.StringValue("ci"),
+		TokenName: types.StringValue("github-actions"),
+		Token:     types.StringUnknown(),
+	}))
+
+	var state APITokenResourceModel
+	require.False(t, created.Get(context.Background(), &state).HasError())
+	require.Equal(t, "ddfaecb8e9325e77", state.ID.ValueString())
+	require.Equal(t, "ddfaecb8e9325e77865ee7e100f89596a65d3eae0e6dddcb33172355b95a64af", state.Token.ValueString())
+
+	// A logged in session with the same partial token is not mistaken for the API token
+	m.On("ListSessions", mock.Anything).Return([]client.Session{
+		{Username: "ci", PartialToken: "ddfaecb8e9325e77", Type: "Standard"},
+	}, nil).Once()
+
+	readResp := resource.ReadResponse{State: created}
+	r.Read(context.Background(), resource.ReadRequest{State: created}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.True(t, readResp.State.Raw.IsNull())
+
+	m.On("ListSessions", mock.Anything).Return([]client.Session{
+		{Username: "ci", PartialToken: "ddfaecb8e9325e77", Type: client.SessionTypeAPIToken, TokenName: "github-actions"},
+	}, nil).Once()
+
+	readResp = resource.ReadResponse{State: created}
+	r.Read(context.Background(), resource.ReadRequest{State: created}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.False(t, readResp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "ddfaecb8e9325e77865ee7e100f89596a65d3eae0e6dddcb33172355b95a64af", state.Token.ValueString())
+
+	m.On("DeleteSession", mock.Anything, "ddfaecb8e9325e77").Return(nil)
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+}
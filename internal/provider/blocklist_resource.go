@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// blocklistID is the ID of the singleton block list resource
+const blocklistID = "blocklist"
+
+// allowListPrefix marks allow list URLs in the blockListUrls setting
+const allowListPrefix = "!"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BlocklistResource{}
+var _ resource.ResourceWithImportState = &BlocklistResource{}
+var _ resource.ResourceWithModifyPlan = &BlocklistResource{}
+
+func NewBlocklistResource() resource.Resource {
+	return &BlocklistResource{}
+}
+
+// BlocklistResource defines the resource implementation.
+type BlocklistResource struct {
+	client client.ClientAPI
+}
+
+// BlocklistResourceModel describes the resource data model.
+type BlocklistResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	BlockListURLs       types.List   `tfsdk:"block_list_urls"`
+	AllowListURLs       types.List   `tfsdk:"allow_list_urls"`
+	UpdateIntervalHours types.Int64  `tfsdk:"update_interval_hours"`
+	NextUpdateOn        types.String `tfsdk:"next_update_on"`
+}
+
+func (r *BlocklistResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blocklist"
+}
+
+func (r *BlocklistResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	urlValidators := []validator.List{
+		listvalidator.UniqueValues(),
+		listvalidator.ValueStringsAre(
+			stringvalidator.LengthAtLeast(1),
+			stringvalidator.RegexMatches(regexp.MustCompile(`^[^!]`), "must not start with \"!\", list allow list URLs in allow_list_urls instead"),
+		),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the block list URLs the Technitium DNS Server downloads to block domains, and the allow list URLs whose domains are never blocked. " +
+			"The server has a single set of block lists, so declare this resource at most once per server. Destroying the resource removes all block list URLs. " +
+			"Blocking itself is turned on with `enable_blocking` in `technitium_dns_settings`. " +
+			"This resource is experimental: enable the `settings` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier, always `" + blocklistID + "`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"block_list_urls": schema.ListAttribute{
+				MarkdownDescription: "URLs of block lists in hosts file format or plain domain lists. The domains they list are blocked",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				Validators:          urlValidators,
+			},
+			"allow_list_urls": schema.ListAttribute{
+				MarkdownDescription: "URLs of allow lists. The domains they list are never blocked, even when a block list contains them",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				Validators:          urlValidators,
+			},
+			"update_interval_hours": schema.Int64Attribute{
+				MarkdownDescription: "The interval in hours at which the lists are downloaded again. Reflects the server value when not set",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 168),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"next_update_on": schema.StringAttribute{
+				MarkdownDescription: "When the server next downloads the lists",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *BlocklistResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BlocklistResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the block lists are being removed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	requireExperimentalFeature(ctx, r.client, client.ExperimentalSettings, "technitium_blocklist", &resp.Diagnostics)
+}
+
+func (r *BlocklistResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BlocklistResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Setting block list URLs")
+
+	state, err := r.applyBlocklist(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set block list URLs: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *BlocklistResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	tflog.Debug(ctx, "Reading block list URLs")
+
+	settings, err := r.client.GetDNSSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS settings: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, blocklistModel(settings))...)
+}
+
+func (r *BlocklistResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BlocklistResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating block list URLs")
+
+	state, err := r.applyBlocklist(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update block list URLs: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+func (r *BlocklistResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Removing all block list URLs")
+
+	// The API clears the list when it is set to "false"
+	if _, err := r.client.SetDNSSettings(ctx, map[string]string{"blockListUrls": "false"}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove block list URLs: %s", err.Error()))
+		return
+	}
+}
+
+func (r *BlocklistResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// There is a single set of block lists, so any import ID refers to it
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), blocklistID)...)
+}
+
+// applyBlocklist saves the planned block and allow list URLs and returns the resulting state
+func (r *BlocklistResource) applyBlocklist(ctx context.Context, data *BlocklistResourceModel) (*BlocklistResourceModel, error) {
+	var blockURLs, allowURLs []string
+	data.BlockListURLs.ElementsAs(ctx, &blockURLs, false)
+	data.AllowListURLs.ElementsAs(ctx, &allowURLs, false)
+
+	options := map[string]string{"blockListUrls": joinBlockListURLs(blockURLs, allowURLs)}
+	if !data.UpdateIntervalHours.IsNull() && !data.UpdateIntervalHours.IsUnknown() {
+		options["blockListUpdateIntervalHours"] = strconv.FormatInt(data.UpdateIntervalHours.ValueInt64(), 10)
+	}
+
+	settings, err := r.client.SetDNSSettings(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocklistModel(settings), nil
+}
+
+// joinBlockListURLs renders the block and allow list URLs as the blockListUrls setting, in which
+// allow list URLs carry a "!" prefix. An empty result is sent as "false" to clear the setting.
+func joinBlockListURLs(blockURLs, allowURLs []string) string {
+	urls := make([]string, 0, len(blockURLs)+len(allowURLs))
+	urls = append(urls, blockURLs...)
+	for _, url := range allowURLs {
+		urls = append(urls, allowListPrefix+url)
+	}
+	if len(urls) == 0 {
+		return "false"
+	}
+	return strings.Join(urls, ",")
+}
+
+// splitBlockListURLs separates the blockListUrls setting into block and allow list URLs
+func splitBlockListURLs(urls []string) (blockURLs, allowURLs []string) {
+	for _, url := range urls {
+		if allowURL, ok := strings.CutPrefix(url, allowListPrefix); ok {
+			allowURLs = append(allowURLs, allowURL)
+		} else {
+			blockURLs = append(blockURLs, url)
+		}
+	}
+	return blockURLs, allowURLs
+}
+
+// blocklistModel converts the server settings into the resource state
+func blocklistModel(settings *client.DNSSettings) *BlocklistResourceModel {
+	blockURLs, allowURLs := splitBlockListURLs(settings.BlockListUrls)
+
+	return &BlocklistResourceModel{
+		ID:                  types.StringValue(blocklistID),
+		BlockListURLs:       stringListValue(blockURLs),
+		AllowListURLs:       stringListValue(allowURLs),
+		UpdateIntervalHours: types.Int64Value(settings.BlockListUpdateIntervalHours),
+		NextUpdateOn:        types.StringValue(settings.BlockListNextUpdatedOn),
+	}
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestBlocklistResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewBlocklistResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_blocklist" {
+			t.Errorf("Expected TypeName to be technitium_blocklist, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewBlocklistResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "block_list_urls", "allow_list_urls", "update_interval_hours", "next_update_on"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+	})
+}
+
+func TestBlockListURLs(t *testing.T) {
+	t.Parallel()
+
+	joined := joinBlockListURLs([]string{"https://big.oisd.nl/"}, []string{"https://example.com/allow.txt"})
+	require.Equal(t, "https://big.oisd.nl/,!https://example.com/allow.txt", joined)
+	require.Equal(t, "false", joinBlockListURLs(nil, nil))
+
+	blockURLs, allowURLs := splitBlockListURLs([]string{"https://big.oisd.nl/", "!https://example.com/allow.txt"})
+	require.Equal(t, []string{"https://big.oisd.nl/"}, blockURLs)
+	require.Equal(t, []string{"https://example.com/allow.txt"}, allowURLs)
+}
+
+func TestBlocklistResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := &BlocklistResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	m.On("SetDNSSettings", mock.Anything, map[string]string{
+		"blockListUrls":                "https://big.oisd.nl/,!https://example.com/allow.txt",
+		"blockListUpdateIntervalHours": "12",
+	}).Return(&client.DNSSettings{
+		BlockListUrls:                []string{"https://big.oisd.nl/", "!https://example.com/allow.txt"},
+		BlockListUpdateIntervalHours: 12,
+		BlockListNextUpdatedOn:       "2024-02-01T20:15:08.658124Z",
+	}, nil)
+	m.On("SetDNSSettings", mock.Anything, map[string]string{"blockListUrls": "false"}).Return(&client.DNSSettings{}, nil)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(context.Background(), &BlocklistResourceModel{
+		ID:                  types.StringUnknown(),
+		BlockListURLs:       stringListValue([]string{"https://big.oisd.nl/"}),
+		AllowListURLs:       stringListValue([]string{"https://example.com/allow.txt"}),
+		UpdateIntervalHours: types.Int64Value(12),
+		NextUpdateOn:        types.StringUnknown(),
+	}).HasError())
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+	var state BlocklistResourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, blocklistID, state.ID.ValueString())
+	require.Equal(t, stringListValue([]string{"https://example.com/allow.txt"}), state.AllowListURLs)
+	require.Equal(t, "2024-02-01T20:15:08.658124Z", state.NextUpdateOn.ValueString())
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: resp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CacheFlushResource{}
+
+func NewCacheFlushResource() resource.Resource {
+	return &CacheFlushResource{}
+}
+
+// CacheFlushResource defines the resource implementation.
+type CacheFlushResource struct {
+	client client.ClientAPI
+}
+
+// CacheFlushResourceModel describes the resource data model.
+type CacheFlushResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Domains  types.Set    `tfsdk:"domains"`
+	Triggers types.Map    `tfsdk:"triggers"`
+}
+
+func (r *CacheFlushResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cache_flush"
+}
+
+func (r *CacheFlushResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Flushes the DNS cache of the server, or deletes the cached entries of some domains, when the resource is created. " +
+			"Change `triggers`, e.g. to the IDs of migrated records, to flush again. " +
+			"Destroying the resource only removes it from the state.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domains": schema.SetAttribute{
+				MarkdownDescription: "The domains whose cached entries are deleted, along with those of their subdomains. " +
+					"When not set, the whole cache is flushed.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that flush the cache again when they change.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *CacheFlushResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CacheFlushResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CacheFlushResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Domains.IsNull() {
+		tflog.Debug(ctx, "Flushing DNS cache")
+
+		if err := r.client.FlushCache(ctx); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to flush DNS cache: %s", err.Error()))
+			return
+		}
+		data.ID = types.StringValue("cache")
+	} else {
+		var domains []string
+		resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &domains, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		for _, domain := range domains {
+			tflog.Debug(ctx, "Deleting cached zone", map[string]interface{}{
+				"domain": domain,
+			})
+
+			if err := r.client.DeleteCachedZone(ctx, domain); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete cached zone %s: %s", domain, err.Error()))
+				return
+			}
+		}
+		slices.Sort(domains)
+		data.ID = types.StringValue("cache:" + strings.Join(domains, ","))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CacheFlushResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Flushing leaves nothing on the server to read back, so the state is kept as is
+	var data CacheFlushResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CacheFlushResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// The domains and triggers require replacement, so there is nothing to update
+	var data CacheFlushResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CacheFlushResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The cache refills on its own, so there is nothing to undo
+}
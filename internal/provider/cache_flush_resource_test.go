@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestCacheFlushResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	create := func(t *testing.T, r *CacheFlushResource, domains types.Set) CacheFlushResourceModel {
+		t.Helper()
+
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+		require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(context.Background(), &CacheFlushResourceModel{
+			ID:       types.StringUnknown(),
+			Domains:  domains,
+			Triggers: types.MapNull(types.StringType),
+		}).HasError())
+
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state CacheFlushResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		return state
+	}
+
+	t.Run("flushes the whole cache", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("FlushCache", mock.Anything).Return(nil).Once()
+
+		state := create(t, &CacheFlushResource{client: m}, types.SetNull(types.StringType))
+		require.Equal(t, "cache", state.ID.ValueString())
+	})
+
+	t.Run("deletes the cached zones of the domains", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("DeleteCachedZone", mock.Anything, "example.com").Return(nil).Once()
+		m.On("DeleteCachedZone", mock.Anything, "example.net").Return(nil).Once()
+
+		state := create(t, &CacheFlushResource{client: m}, stringSetValue([]string{"example.net", "example.com"}))
+		require.Equal(t, "cache:example.com,example.net", state.ID.ValueString())
+	})
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CachedZoneDataSource{}
+
+func NewCachedZoneDataSource() datasource.DataSource {
+	return &CachedZoneDataSource{}
+}
+
+// CachedZoneDataSource defines the data source implementation.
+type CachedZoneDataSource struct {
+	client client.ClientAPI
+}
+
+// CachedZoneDataSourceModel describes the data source data model.
+type CachedZoneDataSourceModel struct {
+	ID      types.String           `tfsdk:"id"`
+	Domain  types.String           `tfsdk:"domain"`
+	Zones   []types.String         `tfsdk:"zones"`
+	Records []CachedRecordDataItem `tfsdk:"records"`
+}
+
+// CachedRecordDataItem represents a record held in the DNS cache
+type CachedRecordDataItem struct {
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	TTL          types.Int64  `tfsdk:"ttl"`
+	Data         types.String `tfsdk:"data"`
+	DnssecStatus types.String `tfsdk:"dnssec_status"`
+}
+
+func (d *CachedZoneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cached_zone"
+}
+
+func (d *CachedZoneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source inspecting the DNS cache entries of a domain",
+		MarkdownDescription: "Data source inspecting the DNS cache of the server one domain level at a time, e.g. to check which answers the server " +
+			"still serves from its cache after records were migrated. Use `technitium_cache_flush` to remove entries from the cache.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain to inspect, e.g. `example.com`. An empty string lists the top level of the cache.",
+				Required:            true,
+			},
+			"zones": schema.ListAttribute{
+				MarkdownDescription: "The cached subdomains of the domain, which can be inspected in turn.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"records": schema.ListNestedAttribute{
+				MarkdownDescription: "The cached records of the domain.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the record.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the record.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The remaining time to live of the cached record, in seconds.",
+							Computed:            true,
+						},
+						"data": schema.StringAttribute{
+							MarkdownDescription: "The record data, formatted as in `technitium_dns_records`.",
+							Computed:            true,
+						},
+						"dnssec_status": schema.StringAttribute{
+							MarkdownDescription: "The DNSSEC validation status of the record, e.g. Secure or Insecure. Empty when the server does not report one.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CachedZoneDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CachedZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CachedZoneDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+	tflog.Debug(ctx, "Reading cached zone data source", map[string]interface{}{
+		"domain": domain,
+	})
+
+	cached, err := d.client.BrowseCache(ctx, domain)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DNS cache",
+			fmt.Sprintf("Could not list cached zone %q: %s", domain, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("cache:" + domain)
+	data.Zones = make([]types.String, 0, len(cached.Zones))
+	for _, zone := range cached.Zones {
+		data.Zones = append(data.Zones, types.StringValue(zone))
+	}
+	data.Records = make([]CachedRecordDataItem, 0, len(cached.Records))
+	for _, record := range cached.Records {
+		data.Records = append(data.Records, cachedRecordDataItem(record))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// cachedRecordDataItem converts a cached record into a data source item. Records of types the
+// provider does not format are reported with the generic value the server returns for them.
+func cachedRecordDataItem(record client.CacheRecord) CachedRecordDataItem {
+	data := formatRecordData(client.DNSRecord{Type: record.Type, RData: record.RData})
+	if record.RData.Value != "" && record.Type != "CAA" {
+		data = record.RData.Value
+	}
+
+	return CachedRecordDataItem{
+		Name:         types.StringValue(record.Name),
+		Type:         types.StringValue(record.Type),
+		TTL:          types.Int64Value(record.TTLSeconds()),
+		Data:         types.StringValue(data),
+		DnssecStatus: types.StringValue(record.DnssecStatus),
+	}
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestUnitCachedZoneDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &CachedZoneDataSource{client: m}
+
+	m.On("BrowseCache", mock.Anything, "example.com").Return(&client.BrowseCacheResponse{
+		Domain: "example.com",
+		Zones:  []string{"www.example.com"},
+		Records: []client.CacheRecord{
+			{Name: "example.com", Type: "A", TTL: "283 (4 mins 43 sec)", RData: client.DNSRecordData{IPAddress: "192.0.2.1"}, DnssecStatus: "Insecure"},
+			{Name: "example.com", Type: "HINFO", TTL: "60 (1 min)", RData: client.DNSRecordData{Value: "x86 linux"}},
+		},
+	}, nil)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	input := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, input.Set(context.Background(), &CachedZoneDataSourceModel{Domain: types.StringValue("example.com")}).HasError())
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(context.Background(), req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var state CachedZoneDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "cache:example.com", state.ID.ValueString())
+	require.Equal(t, []types.String{types.StringValue("www.example.com")}, state.Zones)
+	require.Len(t, state.Records, 2)
+	require.Equal(t, int64(283), state.Records[0].TTL.ValueInt64())
+	require.Equal(t, "192.0.2.1", state.Records[0].Data.ValueString())
+	require.Equal(t, "Insecure", state.Records[0].DnssecStatus.ValueString())
+	require.Equal(t, "x86 linux", state.Records[1].Data.ValueString())
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// optionalBoolField returns the value of an optional response field. Older servers leave such
+// fields out; the current value (e.g. from the configuration) is kept then, and an attribute
+// without a known value is marked null rather than given an invented default.
+func optionalBoolField(value *bool, current types.Bool) types.Bool {
+	if value != nil {
+		return types.BoolValue(*value)
+	}
+	if current.IsUnknown() {
+		return types.BoolNull()
+	}
+	return current
+}
+
+// requireServerFeature adds a plan-time error on attrPath when the connected server is too old
+// for the feature. When the server version cannot be detected the check is skipped so that
+// restricted API tokens keep working; the API will still reject unsupported requests on apply.
+func requireServerFeature(ctx context.Context, c client.ClientAPI, feature client.Feature, attrPath path.Path, diags *diag.Diagnostics) {
+	if c == nil {
+		return
+	}
+
+	supported, version, minVersion, err := c.SupportsFeature(ctx, feature)
+	if err != nil {
+		tflog.Warn(ctx, "Could not detect server version, skipping capability check", map[string]interface{}{
+			"feature": string(feature),
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if !supported {
+		diags.AddAttributeError(
+			attrPath,
+			"Unsupported by DNS server version",
+			fmt.Sprintf("Support for %s requires Technitium DNS Server %s or later, but the connected server runs version %s.",
+				feature, minVersion, version),
+		)
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CatalogZonesDataSource{}
+
+func NewCatalogZonesDataSource() datasource.DataSource {
+	return &CatalogZonesDataSource{}
+}
+
+// CatalogZonesDataSource defines the data source implementation.
+type CatalogZonesDataSource struct {
+	client client.ClientAPI
+}
+
+// CatalogZonesDataSourceModel describes the data source data model.
+type CatalogZonesDataSourceModel struct {
+	ID           types.String      `tfsdk:"id"`
+	CatalogZones []CatalogZoneItem `tfsdk:"catalog_zones"`
+}
+
+// CatalogZoneItem represents a catalog zone and its member zones
+type CatalogZoneItem struct {
+	Name        types.String `tfsdk:"name"`
+	Disabled    types.Bool   `tfsdk:"disabled"`
+	SoaSerial   types.Int64  `tfsdk:"soa_serial"`
+	MemberZones types.List   `tfsdk:"member_zones"`
+}
+
+func (d *CatalogZonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalog_zones"
+}
+
+func (d *CatalogZonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source listing the catalog zones of the DNS server and their member zones",
+		MarkdownDescription: "Data source listing the Catalog zones of the DNS server and their member zones, e.g. to check the `catalog` of a " +
+			"`technitium_zone` against the catalogs that exist.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"catalog_zones": schema.ListNestedAttribute{
+				MarkdownDescription: "The Catalog zones on the server, in the order the server lists them.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the catalog zone.",
+							Computed:            true,
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the catalog zone is disabled.",
+							Computed:            true,
+						},
+						"soa_serial": schema.Int64Attribute{
+							MarkdownDescription: "The serial number of the catalog zone's SOA record.",
+							Computed:            true,
+						},
+						"member_zones": schema.ListAttribute{
+							MarkdownDescription: "The names of the zones that are members of the catalog zone.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CatalogZonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *CatalogZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CatalogZonesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading catalog zones data source")
+
+	zones, err := listAllZones(ctx, d.client, defaultZonesPageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading catalog zones",
+			fmt.Sprintf("Could not list zones: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("catalog_zones")
+	data.CatalogZones = make([]CatalogZoneItem, 0)
+	for _, zone := range zones {
+		if zone.Type != "Catalog" {
+			continue
+		}
+
+		// Member zones list the catalog they belong to
+		members := make([]string, 0)
+		for _, member := range zones {
+			if member.Catalog != "" && sameZoneName(member.Catalog, zone.Name) {
+				members = append(members, member.Name)
+			}
+		}
+		memberZones, diags := types.ListValueFrom(ctx, types.StringType, members)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.CatalogZones = append(data.CatalogZones, CatalogZoneItem{
+			Name:        types.StringValue(zone.Name),
+			Disabled:    types.BoolValue(zone.Disabled),
+			SoaSerial:   types.Int64Value(int64(zone.SoaSerial)),
+			MemberZones: memberZones,
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestCatalogZonesDataSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	read := func(t *testing.T, m *mocks.ClientAPI) (CatalogZonesDataSourceModel, datasource.ReadResponse) {
+		t.Helper()
+
+		d := &CatalogZonesDataSource{client: m}
+		var schemaResp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+		require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &CatalogZonesDataSourceModel{ID: types.StringNull()}).HasError())
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+
+		var data CatalogZonesDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.State.Get(ctx, &data).HasError())
+		}
+		return data, resp
+	}
+
+	t.Run("lists catalogs with their members", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("ListZonesPage", mock.Anything, 1, defaultZonesPageSize).Return(&client.ZoneListResponse{
+			Zones: []client.Zone{
+				{Name: "catalog.example", Type: "Catalog", SoaSerial: 12},
+				{Name: "empty.example", Type: "Catalog", Disabled: true},
+				{Name: "example.com", Type: "Primary", Catalog: "catalog.example"},
+				{Name: "example.net", Type: "Forwarder", Catalog: "Catalog.Example"},
+				{Name: "example.org", Type: "Primary"},
+			},
+		}, nil).Once()
+
+		data, resp := read(t, m)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Len(t, data.CatalogZones, 2)
+
+		require.Equal(t, "catalog.example", data.CatalogZones[0].Name.ValueString())
+		require.Equal(t, int64(12), data.CatalogZones[0].SoaSerial.ValueInt64())
+		var members []string
+		require.False(t, data.CatalogZones[0].MemberZones.ElementsAs(ctx, &members, false).HasError())
+		require.Equal(t, []string{"example.com", "example.net"}, members)
+
+		require.True(t, data.CatalogZones[1].Disabled.ValueBool())
+		require.Empty(t, data.CatalogZones[1].MemberZones.Elements())
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("ListZonesPage", mock.Anything, 1, defaultZonesPageSize).Return(nil, errors.New("connection refused"))
+
+		_, resp := read(t, m)
+		require.True(t, resp.Diagnostics.HasError())
+	})
+}
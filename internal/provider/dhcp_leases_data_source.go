@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &DHCPLeasesDataSource{}
+
+func NewDHCPLeasesDataSource() datasource.DataSource {
+	return &DHCPLeasesDataSource{}
+}
+
+// DHCPLeasesDataSource defines the data source implementation.
+type DHCPLeasesDataSource struct {
+	client client.ClientAPI
+}
+
+// DHCPLeasesDataSourceModel describes the data source data model.
+type DHCPLeasesDataSourceModel struct {
+	ID     types.String        `tfsdk:"id"`
+	Scope  types.String        `tfsdk:"scope"`
+	Leases []DHCPLeaseDataItem `tfsdk:"leases"`
+}
+
+// DHCPLeaseDataItem represents a DHCP lease
+type DHCPLeaseDataItem struct {
+	Scope            types.String `tfsdk:"scope"`
+	Type             types.String `tfsdk:"type"`
+	HardwareAddress  types.String `tfsdk:"hardware_address"`
+	ClientIdentifier types.String `tfsdk:"client_identifier"`
+	Address          types.String `tfsdk:"address"`
+	HostName         types.String `tfsdk:"host_name"`
+	LeaseObtained    types.String `tfsdk:"lease_obtained"`
+	LeaseExpires     types.String `tfsdk:"lease_expires"`
+}
+
+func (d *DHCPLeasesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_leases"
+}
+
+func (d *DHCPLeasesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source listing the current leases of the built-in DHCP server",
+		MarkdownDescription: "Data source listing the current leases of the built-in DHCP server, e.g. for inventory tooling. " +
+			"This data source is experimental: enable the `dhcp` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"scope": schema.StringAttribute{
+				MarkdownDescription: "Only return the leases of this scope. If not specified, the leases of all scopes are returned.",
+				Optional:            true,
+			},
+			"leases": schema.ListNestedAttribute{
+				MarkdownDescription: "The leases handed out by the server.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"scope": schema.StringAttribute{
+							MarkdownDescription: "The name of the scope the lease belongs to.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the lease: Dynamic or Reserved.",
+							Computed:            true,
+						},
+						"hardware_address": schema.StringAttribute{
+							MarkdownDescription: "The MAC address of the client.",
+							Computed:            true,
+						},
+						"client_identifier": schema.StringAttribute{
+							MarkdownDescription: "The DHCP client identifier of the client.",
+							Computed:            true,
+						},
+						"address": schema.StringAttribute{
+							MarkdownDescription: "The IP address leased to the client.",
+							Computed:            true,
+						},
+						"host_name": schema.StringAttribute{
+							MarkdownDescription: "The host name the client reported, empty when it did not report one.",
+							Computed:            true,
+						},
+						"lease_obtained": schema.StringAttribute{
+							MarkdownDescription: "When the client obtained the lease.",
+							Computed:            true,
+						},
+						"lease_expires": schema.StringAttribute{
+							MarkdownDescription: "When the lease expires.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DHCPLeasesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DHCPLeasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DHCPLeasesDataSourceModel
+
+	requireExperimentalFeature(ctx, d.client, client.ExperimentalDHCP, "technitium_dhcp_leases", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading DHCP leases data source", map[string]interface{}{
+		"scope": data.Scope.ValueString(),
+	})
+
+	leases, err := d.client.ListDHCPLeases(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DHCP leases",
+			fmt.Sprintf("Could not list DHCP leases: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("dhcp_leases")
+	if !data.Scope.IsNull() {
+		data.ID = types.StringValue("dhcp_leases:" + data.Scope.ValueString())
+	}
+	data.Leases = dhcpLeaseDataItems(leases, data.Scope.ValueString())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dhcpLeaseDataItems converts the leases of the given scope, or of all scopes when scope is
+// empty, into data source items
+func dhcpLeaseDataItems(leases []client.DHCPLease, scope string) []DHCPLeaseDataItem {
+	items := make([]DHCPLeaseDataItem, 0, len(leases))
+	for _, lease := range leases {
+		if scope != "" && lease.Scope != scope {
+			continue
+		}
+
+		items = append(items, DHCPLeaseDataItem{
+			Scope:            types.StringValue(lease.Scope),
+			Type:             types.StringValue(lease.Type),
+			HardwareAddress:  types.StringValue(lease.HardwareAddress),
+			ClientIdentifier: types.StringValue(lease.ClientIdentifier),
+			Address:          types.StringValue(lease.Address),
+			HostName:         types.StringValue(lease.HostName),
+			LeaseObtained:    types.StringValue(lease.LeaseObtained),
+			LeaseExpires:     types.StringValue(lease.LeaseExpires),
+		})
+	}
+	return items
+}
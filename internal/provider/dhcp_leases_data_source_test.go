@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestDHCPLeasesDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		ds := NewDHCPLeasesDataSource()
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_dhcp_leases" {
+			t.Errorf("Expected TypeName to be technitium_dhcp_leases, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		ds := NewDHCPLeasesDataSource()
+		var resp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		if attr, ok := resp.Schema.Attributes["scope"]; !ok || !attr.IsOptional() {
+			t.Error("Schema should have an optional 'scope' attribute")
+		}
+		for _, name := range []string{"id", "leases"} {
+			attr, ok := resp.Schema.Attributes[name]
+			if !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+				continue
+			}
+			if !attr.IsComputed() {
+				t.Errorf("'%s' attribute should be computed", name)
+			}
+		}
+	})
+
+	t.Run("Configure", func(t *testing.T) {
+		ds := NewDHCPLeasesDataSource().(*DHCPLeasesDataSource)
+
+		var resp datasource.ConfigureResponse
+		ds.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: "wrong-type"}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Error("Configure should fail with wrong provider data type")
+		}
+	})
+}
+
+func TestUnitDHCPLeasesDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	leases := []client.DHCPLease{
+		{Scope: "Default", Type: "Dynamic", HardwareAddress: "00-11-22-33-44-55", Address: "192.168.1.13", HostName: "laptop", LeaseExpires: "2026-10-18T10:00:00Z"},
+		{Scope: "Guests", Type: "Reserved", HardwareAddress: "66-77-88-99-AA-BB", Address: "192.168.2.20"},
+	}
+
+	newRequest := func(t *testing.T, d *DHCPLeasesDataSource, scope types.String) (datasource.ReadRequest, datasource.ReadResponse) {
+		var schemaResp datasource.SchemaResponse
+		d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+		require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+		input := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, input.Set(context.Background(), &DHCPLeasesDataSourceModel{Scope: scope}).HasError())
+
+		return datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}},
+			datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	}
+
+	t.Run("lists leases of all scopes", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		d := &DHCPLeasesDataSource{client: m}
+
+		m.On("ExperimentEnabled", client.ExperimentalDHCP).Return(true)
+		m.On("ListDHCPLeases", mock.Anything).Return(leases, nil)
+
+		req, resp := newRequest(t, d, types.StringNull())
+		d.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DHCPLeasesDataSourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "dhcp_leases", state.ID.ValueString())
+		require.Len(t, state.Leases, 2)
+		require.Equal(t, "laptop", state.Leases[0].HostName.ValueString())
+		require.Equal(t, "2026-10-18T10:00:00Z", state.Leases[0].LeaseExpires.ValueString())
+		require.Equal(t, "Reserved", state.Leases[1].Type.ValueString())
+	})
+
+	t.Run("filters by scope", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		d := &DHCPLeasesDataSource{client: m}
+
+		m.On("ExperimentEnabled", client.ExperimentalDHCP).Return(true)
+		m.On("ListDHCPLeases", mock.Anything).Return(leases, nil)
+
+		req, resp := newRequest(t, d, types.StringValue("Guests"))
+		d.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DHCPLeasesDataSourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "dhcp_leases:Guests", state.ID.ValueString())
+		require.Len(t, state.Leases, 1)
+		require.Equal(t, "66-77-88-99-AA-BB", state.Leases[0].HardwareAddress.ValueString())
+	})
+
+	t.Run("requires the experimental flag", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		d := &DHCPLeasesDataSource{client: m}
+
+		m.On("ExperimentEnabled", client.ExperimentalDHCP).Return(false)
+
+		req, resp := newRequest(t, d, types.StringNull())
+		d.Read(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Experimental feature not enabled", resp.Diagnostics.Errors()[0].Summary())
+	})
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &DHCPScopesDataSource{}
+
+func NewDHCPScopesDataSource() datasource.DataSource {
+	return &DHCPScopesDataSource{}
+}
+
+// DHCPScopesDataSource defines the data source implementation.
+type DHCPScopesDataSource struct {
+	client client.ClientAPI
+}
+
+// DHCPScopesDataSourceModel describes the data source data model.
+type DHCPScopesDataSourceModel struct {
+	ID     types.String        `tfsdk:"id"`
+	Scopes []DHCPScopeDataItem `tfsdk:"scopes"`
+}
+
+// DHCPScopeDataItem represents a DHCP scope and its lease counts
+type DHCPScopeDataItem struct {
+	Name               types.String `tfsdk:"name"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	StartingAddress    types.String `tfsdk:"starting_address"`
+	EndingAddress      types.String `tfsdk:"ending_address"`
+	SubnetMask         types.String `tfsdk:"subnet_mask"`
+	NetworkAddress     types.String `tfsdk:"network_address"`
+	BroadcastAddress   types.String `tfsdk:"broadcast_address"`
+	LeaseCount         types.Int64  `tfsdk:"lease_count"`
+	DynamicLeaseCount  types.Int64  `tfsdk:"dynamic_lease_count"`
+	ReservedLeaseCount types.Int64  `tfsdk:"reserved_lease_count"`
+}
+
+func (d *DHCPScopesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_scopes"
+}
+
+func (d *DHCPScopesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source listing the DHCP scopes of the built-in DHCP server",
+		MarkdownDescription: "Data source listing the DHCP scopes of the built-in DHCP server with their enabled state, address ranges and lease counts. " +
+			"This data source is experimental: enable the `dhcp` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"scopes": schema.ListNestedAttribute{
+				MarkdownDescription: "The DHCP scopes configured on the server.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the scope.",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the scope is enabled and serving leases.",
+							Computed:            true,
+						},
+						"starting_address": schema.StringAttribute{
+							MarkdownDescription: "The first address of the scope's address range.",
+							Computed:            true,
+						},
+						"ending_address": schema.StringAttribute{
+							MarkdownDescription: "The last address of the scope's address range.",
+							Computed:            true,
+						},
+						"subnet_mask": schema.StringAttribute{
+							MarkdownDescription: "The subnet mask of the scope's network.",
+							Computed:            true,
+						},
+						"network_address": schema.StringAttribute{
+							MarkdownDescription: "The network address of the scope.",
+							Computed:            true,
+						},
+						"broadcast_address": schema.StringAttribute{
+							MarkdownDescription: "The broadcast address of the scope's network.",
+							Computed:            true,
+						},
+						"lease_count": schema.Int64Attribute{
+							MarkdownDescription: "The total number of leases in the scope.",
+							Computed:            true,
+						},
+						"dynamic_lease_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of dynamic leases in the scope.",
+							Computed:            true,
+						},
+						"reserved_lease_count": schema.Int64Attribute{
+							MarkdownDescription: "The number of reserved leases in the scope.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DHCPScopesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DHCPScopesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DHCPScopesDataSourceModel
+
+	requireExperimentalFeature(ctx, d.client, client.ExperimentalDHCP, "technitium_dhcp_scopes", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading DHCP scopes data source")
+
+	scopes, err := d.client.ListDHCPScopes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DHCP scopes",
+			fmt.Sprintf("Could not list DHCP scopes: %s", err.Error()),
+		)
+		return
+	}
+
+	leases, err := d.client.ListDHCPLeases(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DHCP leases",
+			fmt.Sprintf("Could not list DHCP leases: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("dhcp_scopes")
+	data.Scopes = dhcpScopeDataItems(scopes, leases)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dhcpScopeDataItems converts the scopes into data source items, counting the dynamic and
+// reserved leases of each scope
+func dhcpScopeDataItems(scopes []client.DHCPScope, leases []client.DHCPLease) []DHCPScopeDataItem {
+	dynamic := make(map[string]int64)
+	reserved := make(map[string]int64)
+	for _, lease := range leases {
+		switch lease.Type {
+		case "Dynamic":
+			dynamic[lease.Scope]++
+		case "Reserved":
+			reserved[lease.Scope]++
+		}
+	}
+
+	items := make([]DHCPScopeDataItem, 0, len(scopes))
+	for _, scope := range scopes {
+		items = append(items, DHCPScopeDataItem{
+			Name:               types.StringValue(scope.Name),
+			Enabled:            types.BoolValue(scope.Enabled),
+			StartingAddress:    types.StringValue(scope.StartingAddress),
+			EndingAddress:      types.StringValue(scope.EndingAddress),
+			SubnetMask:         types.StringValue(scope.SubnetMask),
+			NetworkAddress:     types.StringValue(scope.NetworkAddress),
+			BroadcastAddress:   types.StringValue(scope.BroadcastAddress),
+			LeaseCount:         types.Int64Value(dynamic[scope.Name] + reserved[scope.Name]),
+			DynamicLeaseCount:  types.Int64Value(dynamic[scope.Name]),
+			ReservedLeaseCount: types.Int64Value(reserved[scope.Name]),
+		})
+	}
+	return items
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestDHCPScopesDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		ds := NewDHCPScopesDataSource()
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_dhcp_scopes" {
+			t.Errorf("Expected TypeName to be technitium_dhcp_scopes, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		ds := NewDHCPScopesDataSource()
+		var resp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "scopes"} {
+			attr, ok := resp.Schema.Attributes[name]
+			if !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+				continue
+			}
+			if !attr.IsComputed() {
+				t.Errorf("'%s' attribute should be computed", name)
+			}
+		}
+	})
+
+	t.Run("Configure", func(t *testing.T) {
+		ds := NewDHCPScopesDataSource().(*DHCPScopesDataSource)
+
+		var resp datasource.ConfigureResponse
+		ds.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: "wrong-type"}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Error("Configure should fail with wrong provider data type")
+		}
+	})
+}
+
+func TestDHCPScopeDataItems(t *testing.T) {
+	t.Parallel()
+
+	scopes := []client.DHCPScope{
+		{Name: "Default", Enabled: true, StartingAddress: "192.168.1.1", EndingAddress: "192.168.1.254"},
+		{Name: "Guests", Enabled: false, StartingAddress: "192.168.2.1", EndingAddress: "192.168.2.254"},
+	}
+	leases := []client.DHCPLease{
+		{Scope: "Default", Type: "Reserved", Address: "192.168.1.5"},
+		{Scope: "Default", Type: "Dynamic", Address: "192.168.1.13"},
+		{Scope: "Default", Type: "Dynamic", Address: "192.168.1.15"},
+		{Scope: "Removed", Type: "Dynamic", Address: "10.0.0.2"},
+	}
+
+	items := dhcpScopeDataItems(scopes, leases)
+	require.Len(t, items, 2)
+
+	require.Equal(t, "Default", items[0].Name.ValueString())
+	require.True(t, items[0].Enabled.ValueBool())
+	require.Equal(t, int64(3), items[0].LeaseCount.ValueInt64())
+	require.Equal(t, int64(2), items[0].DynamicLeaseCount.ValueInt64())
+	require.Equal(t, int64(1), items[0].ReservedLeaseCount.ValueInt64())
+
+	require.Equal(t, "Guests", items[1].Name.ValueString())
+	require.False(t, items[1].Enabled.ValueBool())
+	require.Equal(t, int64(0), items[1].LeaseCount.ValueInt64())
+}
+
+func TestUnitDHCPScopesDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	newRequest := func(t *testing.T, d *DHCPScopesDataSource) (datasource.ReadRequest, datasource.ReadResponse) {
+		var schemaResp datasource.SchemaResponse
+		d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+		require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+		input := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, input.Set(context.Background(), &DHCPScopesDataSourceModel{}).HasError())
+
+		return datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}},
+			datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	}
+
+	t.Run("lists scopes", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		d := &DHCPScopesDataSource{client: m}
+
+		m.On("ExperimentEnabled", client.ExperimentalDHCP).Return(true)
+		m.On("ListDHCPScopes", mock.Anything).Return([]client.DHCPScope{
+			{Name: "Default", Enabled: true, StartingAddress: "192.168.1.1", EndingAddress: "192.168.1.254", SubnetMask: "255.255.255.0"},
+		}, nil)
+		m.On("ListDHCPLeases", mock.Anything).Return([]client.DHCPLease{
+			{Scope: "Default", Type: "Dynamic", Address: "192.168.1.13"},
+		}, nil)
+
+		req, resp := newRequest(t, d)
+		d.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DHCPScopesDataSourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "dhcp_scopes", state.ID.ValueString())
+		require.Len(t, state.Scopes, 1)
+		require.Equal(t, "255.255.255.0", state.Scopes[0].SubnetMask.ValueString())
+		require.Equal(t, int64(1), state.Scopes[0].LeaseCount.ValueInt64())
+	})
+
+	t.Run("requires the experimental flag", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		d := &DHCPScopesDataSource{client: m}
+
+		m.On("ExperimentEnabled", client.ExperimentalDHCP).Return(false)
+
+		req, resp := newRequest(t, d)
+		d.Read(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Experimental feature not enabled", resp.Diagnostics.Errors()[0].Summary())
+	})
+}
@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &DNSAppComponentDataSource{}
+
+func NewDNSAppComponentDataSource() datasource.DataSource {
+	return &DNSAppComponentDataSource{}
+}
+
+// DNSAppComponentDataSource defines the data source implementation.
+type DNSAppComponentDataSource struct {
+	client client.ClientAPI
+}
+
+// DNSAppComponentDataSourceModel describes the data source data model.
+type DNSAppComponentDataSourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	ClassPath                 types.String `tfsdk:"class_path"`
+	AppName                   types.String `tfsdk:"app_name"`
+	AppVersion                types.String `tfsdk:"app_version"`
+	Description               types.String `tfsdk:"description"`
+	IsAppRecordRequestHandler types.Bool   `tfsdk:"is_app_record_request_handler"`
+	RecordDataTemplate        types.String `tfsdk:"record_data_template"`
+}
+
+func (d *DNSAppComponentDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_app_component"
+}
+
+func (d *DNSAppComponentDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source to resolve a DNS app component class path to its installed app",
+		MarkdownDescription: "Data source to resolve a DNS app component class path (e.g. `SplitHorizon.SimpleAddress`) to the installed app " +
+			"that provides it and its APP record data template. Use it to fill `app_name` and a starting `data` value for " +
+			"`technitium_dns_record` APP records.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"class_path": schema.StringAttribute{
+				MarkdownDescription: "Class path of the app component to look up.",
+				Required:            true,
+			},
+			"app_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the app providing the component. Set it to pick an app when several installed apps provide the same class path.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"app_version": schema.StringAttribute{
+				MarkdownDescription: "Version of the app providing the component.",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the app component.",
+				Computed:            true,
+			},
+			"is_app_record_request_handler": schema.BoolAttribute{
+				MarkdownDescription: "Whether the component handles APP records.",
+				Computed:            true,
+			},
+			"record_data_template": schema.StringAttribute{
+				MarkdownDescription: "Record data template for APP records handled by the component, null when the component does not provide one.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *DNSAppComponentDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DNSAppComponentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DNSAppComponentDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading DNS app component", map[string]interface{}{
+		"class_path": data.ClassPath.ValueString(),
+		"app_name":   data.AppName.ValueString(),
+	})
+
+	apps, err := d.client.ListApps(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS apps: %s", err.Error()))
+		return
+	}
+
+	app, component, err := findAppComponent(apps, data.ClassPath.ValueString(), data.AppName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("class_path"), "DNS app component not found", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(app.Name + ":" + component.ClassPath)
+	data.AppName = types.StringValue(app.Name)
+	data.AppVersion = types.StringValue(app.Version)
+	data.Description = types.StringValue(component.Description)
+	data.IsAppRecordRequestHandler = types.BoolValue(component.IsAppRecordRequestHandler)
+	data.RecordDataTemplate = types.StringNull()
+	if component.RecordDataTemplate != nil {
+		data.RecordDataTemplate = types.StringValue(*component.RecordDataTemplate)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findAppComponent finds the installed app providing classPath. When appName is empty the class
+// path must be provided by exactly one installed app.
+func findAppComponent(apps []client.App, classPath, appName string) (*client.App, *client.DNSApp, error) {
+	type match struct {
+		app       *client.App
+		component *client.DNSApp
+	}
+
+	matches := make([]match, 0)
+	available := make([]string, 0)
+	for i := range apps {
+		app := &apps[i]
+		if appName != "" && app.Name != appName {
+			continue
+		}
+		for j := range app.DNSApps {
+			component := &app.DNSApps[j]
+			available = append(available, component.ClassPath)
+			if component.ClassPath == classPath {
+				matches = append(matches, match{app: app, component: component})
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 1:
+		return matches[0].app, matches[0].component, nil
+	case 0:
+		sort.Strings(available)
+		if appName != "" {
+			return nil, nil, fmt.Errorf("DNS app %q does not provide class path %q, available class paths: %s", appName, classPath, strings.Join(available, ", "))
+		}
+		return nil, nil, fmt.Errorf("no installed DNS app provides class path %q, available class paths: %s", classPath, strings.Join(available, ", "))
+	default:
+		names := make([]string, 0, len(matches))
+		for _, m := range matches {
+			names = append(names, m.app.Name)
+		}
+		return nil, nil, fmt.Errorf("class path %q is provided by several installed apps (%s), set app_name to choose one", classPath, strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestDNSAppComponentDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		ds := NewDNSAppComponentDataSource()
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_dns_app_component" {
+			t.Errorf("Expected TypeName to be technitium_dns_app_component, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		ds := NewDNSAppComponentDataSource()
+		var resp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		if !resp.Schema.Attributes["class_path"].IsRequired() {
+			t.Error("class_path attribute should be required")
+		}
+		for _, name := range []string{"id", "app_version", "description", "is_app_record_request_handler", "record_data_template"} {
+			attr, ok := resp.Schema.Attributes[name]
+			if !ok {
+				t.Errorf("Schema should have %q attribute", name)
+				continue
+			}
+			if !attr.IsComputed() {
+				t.Errorf("%s attribute should be computed", name)
+			}
+		}
+	})
+
+	t.Run("Configure", func(t *testing.T) {
+		ds := NewDNSAppComponentDataSource().(*DNSAppComponentDataSource)
+
+		var resp datasource.ConfigureResponse
+		ds.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: nil}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Errorf("Configure should not fail with nil provider data: %v", resp.Diagnostics.Errors())
+		}
+
+		resp = datasource.ConfigureResponse{}
+		ds.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: "wrong-type"}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Error("Configure should fail with wrong provider data type")
+		}
+	})
+}
+
+func TestFindAppComponent(t *testing.T) {
+	t.Parallel()
+
+	template := "{\"public\": [\"192.0.2.1\"]}"
+	apps := []client.App{
+		{
+			Name:    "Split Horizon",
+			Version: "8.0",
+			DNSApps: []client.DNSApp{
+				{ClassPath: "SplitHorizon.SimpleAddress", IsAppRecordRequestHandler: true, RecordDataTemplate: &template},
+				{ClassPath: "SplitHorizon.SimpleCNAME", IsAppRecordRequestHandler: true},
+			},
+		},
+		{
+			Name:    "Query Logs (Sqlite)",
+			Version: "7.0",
+			DNSApps: []client.DNSApp{{ClassPath: "QueryLogsSqlite.App"}},
+		},
+		{
+			Name:    "Split Horizon Fork",
+			Version: "1.0",
+			DNSApps: []client.DNSApp{{ClassPath: "SplitHorizon.SimpleCNAME", IsAppRecordRequestHandler: true}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		classPath string
+		appName   string
+		wantApp   string
+		wantErr   string
+	}{
+		{
+			name:      "unique class path",
+			classPath: "SplitHorizon.SimpleAddress",
+			wantApp:   "Split Horizon",
+		},
+		{
+			name:      "ambiguous class path",
+			classPath: "SplitHorizon.SimpleCNAME",
+			wantErr:   "set app_name",
+		},
+		{
+			name:      "ambiguous class path with app name",
+			classPath: "SplitHorizon.SimpleCNAME",
+			appName:   "Split Horizon Fork",
+			wantApp:   "Split Horizon Fork",
+		},
+		{
+			name:      "unknown class path",
+			classPath: "Geo.Country",
+			wantErr:   "QueryLogsSqlite.App, SplitHorizon.SimpleAddress",
+		},
+		{
+			name:      "class path not provided by app",
+			classPath: "QueryLogsSqlite.App",
+			appName:   "Split Horizon",
+			wantErr:   "does not provide",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, component, err := findAppComponent(apps, tt.classPath, tt.appName)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantApp, app.Name)
+			require.Equal(t, tt.classPath, component.ClassPath)
+		})
+	}
+}
+
+func TestUnitDNSAppComponentDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &DNSAppComponentDataSource{client: m}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	template := "{\"public\": [\"192.0.2.1\"]}"
+	m.On("ListApps", mock.Anything).Return([]client.App{
+		{
+			Name:    "Split Horizon",
+			Version: "8.0",
+			DNSApps: []client.DNSApp{
+				{ClassPath: "SplitHorizon.SimpleAddress", Description: "Returns A or AAAA records", IsAppRecordRequestHandler: true, RecordDataTemplate: &template},
+			},
+		},
+	}, nil)
+
+	// Build the configuration from a state holding the inputs
+	input := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, input.Set(context.Background(), &DNSAppComponentDataSourceModel{
+		ClassPath:          types.StringValue("SplitHorizon.SimpleAddress"),
+		RecordDataTemplate: types.StringNull(),
+	}).HasError())
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var state DNSAppComponentDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "Split Horizon:SplitHorizon.SimpleAddress", state.ID.ValueString())
+	require.Equal(t, "Split Horizon", state.AppName.ValueString())
+	require.Equal(t, "8.0", state.AppVersion.ValueString())
+	require.True(t, state.IsAppRecordRequestHandler.ValueBool())
+	require.Equal(t, template, state.RecordDataTemplate.ValueString())
+}
@@ -25,7 +25,7 @@ func NewDNSAppConfigResource() resource.Resource {
 
 // DNSAppConfigResource defines the resource implementation.
 type DNSAppConfigResource struct {
-	client *client.Client
+	client client.ClientAPI
 }
 
 // DNSAppConfigResourceModel describes the resource data model.
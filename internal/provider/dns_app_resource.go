@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -25,6 +27,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DNSAppResource{}
 var _ resource.ResourceWithImportState = &DNSAppResource{}
+var _ resource.ResourceWithModifyPlan = &DNSAppResource{}
 
 func NewDNSAppResource() resource.Resource {
 	return &DNSAppResource{}
@@ -32,7 +35,7 @@ func NewDNSAppResource() resource.Resource {
 
 // DNSAppResource defines the resource implementation.
 type DNSAppResource struct {
-	client *client.Client
+	client client.ClientAPI
 }
 
 // DNSAppResourceModel describes the resource data model.
@@ -42,10 +45,13 @@ type DNSAppResourceModel struct {
 	InstallMethod types.String `tfsdk:"install_method"`
 	URL           types.String `tfsdk:"url"`
 	FileContent   types.String `tfsdk:"file_content"`
+	Version       types.String `tfsdk:"version"`
 
 	// Computed attributes
-	Version types.String `tfsdk:"version"`
-	DNSApps types.List   `tfsdk:"dns_apps"`
+	UpdateAvailable types.Bool `tfsdk:"update_available"`
+	DNSApps         types.List `tfsdk:"dns_apps"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 // DNSAppInfo represents a single DNS app within an app package for Terraform
@@ -117,8 +123,22 @@ func (r *DNSAppResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Sensitive:           true,
 			},
 			"version": schema.StringAttribute{
-				MarkdownDescription: "Version of the installed app",
-				Computed:            true,
+				MarkdownDescription: "Version of the installed app. Set it to pin the app to a version of the DNS App Store (only when install_method is 'url'): " +
+					"when the installed version differs, e.g. after the app was updated outside of Terraform, the app is downloaded and updated again. " +
+					"The store only offers the latest version of an app, so the apply fails when it does not offer the pinned one.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"update_available": schema.BoolAttribute{
+				MarkdownDescription: "Whether the DNS App Store offers a newer version of the installed app. Always false for apps installed from a file, " +
+					"apps not listed in the store, or when the server cannot reach the store.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"dns_apps": schema.ListNestedAttribute{
 				MarkdownDescription: "List of DNS applications within this app package",
@@ -165,6 +185,9 @@ func (r *DNSAppResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -196,6 +219,13 @@ func (r *DNSAppResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel, diags := operationContext(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Validate install method configuration
 	if err := r.validateInstallMethod(data); err != nil {
 		resp.Diagnostics.AddError("Invalid Configuration", err.Error())
@@ -209,6 +239,11 @@ func (r *DNSAppResource) Create(ctx context.Context, req resource.CreateRequest,
 		"install_method": data.InstallMethod.ValueString(),
 	})
 
+	if err := r.checkStoreVersion(ctx, data); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "Invalid App Version", err.Error())
+		return
+	}
+
 	// Install the app based on the method
 	var app *client.App
 	var err error
@@ -228,13 +263,18 @@ func (r *DNSAppResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError("App Installation Failed", fmt.Sprintf("Unable to install app: %s", err.Error()))
+		resp.Diagnostics.AddError("App Installation Failed", fmt.Sprintf("Unable to install app: %s%s", err.Error(), appErrorDetails(err)))
+		return
+	}
+	if err := checkInstalledVersion(data, app); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "App Version Mismatch", err.Error())
 		return
 	}
 
 	// Update the state with the installed app data
 	data.ID = types.StringValue(name)
 	data.Version = types.StringValue(app.Version)
+	data.UpdateAvailable = r.appUpdateAvailable(ctx, data)
 
 	// Convert DNS apps to Terraform format
 	dnsApps, diags := r.convertDNSAppsToTerraform(ctx, app.DNSApps)
@@ -262,6 +302,13 @@ func (r *DNSAppResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	ctx, cancel, diags := operationContext(ctx, data.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	name := data.Name.ValueString()
 
 	tflog.Debug(ctx, "Reading DNS app", map[string]interface{}{
@@ -293,8 +340,10 @@ func (r *DNSAppResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	// Update computed attributes
+	// Update computed attributes. A version differing from the pinned one shows up as drift
+	// and is updated again on the next apply.
 	data.Version = types.StringValue(app.Version)
+	data.UpdateAvailable = r.appUpdateAvailable(ctx, data)
 
 	// Convert DNS apps to Terraform format
 	dnsApps, diags := r.convertDNSAppsToTerraform(ctx, app.DNSApps)
@@ -308,26 +357,49 @@ func (r *DNSAppResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *DNSAppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data DNSAppResourceModel
+	var data, state DNSAppResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel, diags := operationContext(ctx, data.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	name := data.Name.ValueString()
 
 	tflog.Debug(ctx, "Updating DNS app", map[string]interface{}{
 		"name": name,
 	})
 
+	// Only a new source or a pinned version differing from the installed one, e.g. after drift,
+	// installs the app again; other changes, e.g. of timeouts, leave the app as is.
+	reinstall := appReinstalled(data, state)
+	if !reinstall {
+		data.Version = state.Version
+		data.DNSApps = state.DNSApps
+	} else if err := r.checkStoreVersion(ctx, data); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("version"), "Invalid App Version", err.Error())
+		return
+	}
+
 	// Handle app updates based on install method
-	if !data.URL.IsNull() && !data.URL.IsUnknown() && data.InstallMethod.ValueString() == "url" {
+	if reinstall && !data.URL.IsNull() && !data.URL.IsUnknown() && data.InstallMethod.ValueString() == "url" {
 		url := data.URL.ValueString()
 		app, err := r.client.DownloadAndUpdateApp(ctx, name, url)
 		if err != nil {
-			resp.Diagnostics.AddError("App Update Failed", fmt.Sprintf("Unable to update app: %s", err.Error()))
+			resp.Diagnostics.AddError("App Update Failed", fmt.Sprintf("Unable to update app: %s%s", err.Error(), appErrorDetails(err)))
+			return
+		}
+		if err := checkInstalledVersion(data, app); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("version"), "App Version Mismatch", err.Error())
 			return
 		}
 
@@ -341,7 +413,7 @@ func (r *DNSAppResource) Update(ctx context.Context, req resource.UpdateRequest,
 			return
 		}
 		data.DNSApps = dnsApps
-	} else if !data.FileContent.IsNull() && !data.FileContent.IsUnknown() && data.InstallMethod.ValueString() == "file" {
+	} else if reinstall && !data.FileContent.IsNull() && !data.FileContent.IsUnknown() && data.InstallMethod.ValueString() == "file" {
 		fileContent := data.FileContent.ValueString()
 		fileData, err := decodeBase64(fileContent)
 		if err != nil {
@@ -351,7 +423,11 @@ func (r *DNSAppResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 		app, err := r.client.UpdateApp(ctx, name, fileData)
 		if err != nil {
-			resp.Diagnostics.AddError("App Update Failed", fmt.Sprintf("Unable to update app: %s", err.Error()))
+			resp.Diagnostics.AddError("App Update Failed", fmt.Sprintf("Unable to update app: %s%s", err.Error(), appErrorDetails(err)))
+			return
+		}
+		if err := checkInstalledVersion(data, app); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("version"), "App Version Mismatch", err.Error())
 			return
 		}
 
@@ -367,13 +443,37 @@ func (r *DNSAppResource) Update(ctx context.Context, req resource.UpdateRequest,
 		data.DNSApps = dnsApps
 	}
 
+	if reinstall {
+		data.UpdateAvailable = r.appUpdateAvailable(ctx, data)
+	}
+
 	tflog.Debug(ctx, "Successfully updated DNS app", map[string]interface{}{
-		"name": name,
+		"name":    name,
+		"version": data.Version.ValueString(),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// ModifyPlan keeps update_available from the state unless the app is installed again, which may
+// change it
+func (r *DNSAppResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state DNSAppResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if appReinstalled(plan, state) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("update_available"), types.BoolUnknown())...)
+	}
+}
+
 func (r *DNSAppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data DNSAppResourceModel
 
@@ -383,6 +483,13 @@ func (r *DNSAppResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel, diags := operationContext(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	name := data.Name.ValueString()
 
 	tflog.Debug(ctx, "Deleting DNS app", map[string]interface{}{
@@ -459,6 +566,79 @@ func (r *DNSAppResource) validateInstallMethod(data DNSAppResourceModel) error {
 	return nil
 }
 
+// findStoreApp returns the app of the given name in the DNS App Store, or nil when the store does
+// not list it
+func findStoreApp(ctx context.Context, c client.ClientAPI, name string) (*client.StoreApp, error) {
+	storeApps, err := c.ListStoreApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, storeApp := range storeApps {
+		if storeApp.Name == name {
+			return &storeApps[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// checkStoreVersion checks that the DNS App Store offers the pinned version of an app before it
+// is downloaded. Apps the store does not list are checked once installed.
+func (r *DNSAppResource) checkStoreVersion(ctx context.Context, data DNSAppResourceModel) error {
+	if data.Version.IsNull() || data.Version.IsUnknown() {
+		return nil
+	}
+	if data.InstallMethod.ValueString() != "url" {
+		return fmt.Errorf("'version' can only be pinned when install_method is 'url'")
+	}
+
+	storeApp, err := findStoreApp(ctx, r.client, data.Name.ValueString())
+	if err != nil {
+		return fmt.Errorf("unable to list the DNS App Store to check version %s: %w", data.Version.ValueString(), err)
+	}
+	if storeApp != nil && storeApp.Version != data.Version.ValueString() {
+		return fmt.Errorf("the DNS App Store offers version %s of app '%s', not the pinned version %s",
+			storeApp.Version, storeApp.Name, data.Version.ValueString())
+	}
+	return nil
+}
+
+// appReinstalled reports whether applying plan installs the app again: when its source changed, or
+// the pinned version differs from the installed one
+func appReinstalled(plan, state DNSAppResourceModel) bool {
+	return !plan.URL.Equal(state.URL) || !plan.FileContent.Equal(state.FileContent) ||
+		!plan.Version.IsUnknown() && !plan.Version.Equal(state.Version)
+}
+
+// checkInstalledVersion checks that the installed app has the pinned version, if any
+func checkInstalledVersion(data DNSAppResourceModel, app *client.App) error {
+	if data.Version.IsNull() || data.Version.IsUnknown() || app.Version == data.Version.ValueString() {
+		return nil
+	}
+	return fmt.Errorf("app '%s' was installed with version %s, not the pinned version %s",
+		data.Name.ValueString(), app.Version, data.Version.ValueString())
+}
+
+// appUpdateAvailable reports whether the DNS App Store offers a newer version of an installed app.
+// Apps installed from a file are not updated from the store, so the store is only listed for apps
+// installed from a URL. Not every server can reach the store, so errors listing it are only logged.
+func (r *DNSAppResource) appUpdateAvailable(ctx context.Context, data DNSAppResourceModel) types.Bool {
+	if data.InstallMethod.ValueString() != "url" {
+		return types.BoolValue(false)
+	}
+
+	name := data.Name.ValueString()
+	storeApp, err := findStoreApp(ctx, r.client, name)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to list the DNS App Store, assuming no update is available", map[string]interface{}{
+			"name":  name,
+			"error": err.Error(),
+		})
+		return types.BoolValue(false)
+	}
+	return types.BoolValue(storeApp != nil && storeApp.UpdateAvailable)
+}
+
 func (r *DNSAppResource) convertDNSAppsToTerraform(ctx context.Context, dnsApps []client.DNSApp) (types.List, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -540,6 +720,17 @@ func (r *DNSAppResource) convertDNSAppsToTerraform(ctx context.Context, dnsApps
 
 // Helper functions
 
+// appErrorDetails returns the inner error and stack trace the server reported for a failed
+// install or update, which usually explain why the app could not be loaded (e.g. a missing
+// dependency or an unsupported runtime version)
+func appErrorDetails(err error) string {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Details() == "" {
+		return ""
+	}
+	return "\n\n" + apiErr.Details()
+}
+
 func decodeBase64(encoded string) ([]byte, error) {
 	// Remove any whitespace
 	encoded = strings.ReplaceAll(encoded, " ", "")
@@ -2,9 +2,20 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
 )
 
 func TestDNSAppResource(t *testing.T) {
@@ -158,6 +169,14 @@ func TestDNSAppResource_SchemaValidation(t *testing.T) {
 			attributeName: "version",
 			shouldExist:   true,
 			isRequired:    false,
+			isOptional:    true,
+			isComputed:    true,
+		},
+		{
+			name:          "update_available attribute",
+			attributeName: "update_available",
+			shouldExist:   true,
+			isRequired:    false,
 			isOptional:    false,
 			isComputed:    true,
 		},
@@ -223,3 +242,198 @@ func TestDNSAppResource_SchemaValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestAppErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("failed to install app: %w", &client.APIError{
+		Message:           "Failed to load app",
+		InnerErrorMessage: "Framework 'Microsoft.NETCore.App', version '9.0.0' was not found",
+		StackTrace:        "at DnsServerCore.Dns.Applications.DnsApplication..ctor()",
+	})
+
+	details := appErrorDetails(err)
+	if !strings.Contains(details, "version '9.0.0' was not found") || !strings.Contains(details, "DnsApplication..ctor()") {
+		t.Errorf("Expected the inner error and stack trace, got %q", details)
+	}
+
+	if details := appErrorDetails(fmt.Errorf("request failed: connection refused")); details != "" {
+		t.Errorf("Expected no details for errors not reported by the server, got %q", details)
+	}
+}
+
+func TestDNSAppResourceVersion(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	const appURL = "https://download.technitium.com/dns/apps/WildIpApp.zip"
+
+	r := &DNSAppResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	model := func(version types.String) *DNSAppResourceModel {
+		return &DNSAppResourceModel{
+			ID:              types.StringUnknown(),
+			Name:            types.StringValue("Wild IP"),
+			InstallMethod:   types.StringValue("url"),
+			URL:             types.StringValue(appURL),
+			FileContent:     types.StringNull(),
+			Version:         version,
+			UpdateAvailable: types.BoolUnknown(),
+			DNSApps:         types.ListUnknown(types.ObjectType{AttrTypes: dnsAppAttrTypes(t, schemaResp)}),
+		}
+	}
+	storeApps := func(m *mocks.ClientAPI, version string, updateAvailable bool) {
+		m.On("ListStoreApps", mock.Anything).Return([]client.StoreApp{
+			{Name: "Wild IP", Version: version, URL: appURL, Installed: true, UpdateAvailable: updateAvailable},
+		}, nil).Once()
+	}
+
+	t.Run("pinned version is installed", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		r := &DNSAppResource{client: m}
+
+		storeApps(m, "2.0", false)
+		m.On("DownloadAndInstallApp", mock.Anything, "Wild IP", appURL).Return(&client.App{Name: "Wild IP", Version: "2.0"}, nil).Once()
+		storeApps(m, "2.0", false)
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(ctx, model(types.StringValue("2.0"))).HasError())
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSAppResourceModel
+		require.False(t, resp.State.Get(ctx, &state).HasError())
+		require.Equal(t, "2.0", state.Version.ValueString())
+		require.False(t, state.UpdateAvailable.ValueBool())
+	})
+
+	t.Run("version not offered by the store", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		r := &DNSAppResource{client: m}
+
+		storeApps(m, "3.0", false)
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(ctx, model(types.StringValue("2.0"))).HasError())
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "offers version 3.0")
+	})
+
+	t.Run("drift from the pinned version is updated", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		r := &DNSAppResource{client: m}
+
+		// The app was updated outside of Terraform
+		m.On("ListApps", mock.Anything).Return([]client.App{{Name: "Wild IP", Version: "2.1"}}, nil).Once()
+		m.On("ListStoreApps", mock.Anything).Return(nil, errors.New("store unreachable")).Once()
+
+		pinned := model(types.StringValue("2.0"))
+		pinned.ID = types.StringValue("Wild IP")
+		pinned.UpdateAvailable = types.BoolValue(false)
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, pinned).HasError())
+
+		readResp := resource.ReadResponse{State: state}
+		r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+		require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+
+		var read DNSAppResourceModel
+		require.False(t, readResp.State.Get(ctx, &read).HasError())
+		require.Equal(t, "2.1", read.Version.ValueString())
+		require.False(t, read.UpdateAvailable.ValueBool())
+
+		// Applying the pinned version again downloads and updates the app
+		storeApps(m, "2.0", false)
+		m.On("DownloadAndUpdateApp", mock.Anything, "Wild IP", appURL).Return(&client.App{Name: "Wild IP", Version: "2.0"}, nil).Once()
+		storeApps(m, "2.0", false)
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(ctx, pinned).HasError())
+		updateResp := resource.UpdateResponse{State: readResp.State}
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+		require.False(t, updateResp.Diagnostics.HasError(), "update diagnostics: %v", updateResp.Diagnostics)
+
+		var updated DNSAppResourceModel
+		require.False(t, updateResp.State.Get(ctx, &updated).HasError())
+		require.Equal(t, "2.0", updated.Version.ValueString())
+	})
+
+	t.Run("update available", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		data := *model(types.StringNull())
+		storeApps(m, "3.0", true)
+		require.True(t, (&DNSAppResource{client: m}).appUpdateAvailable(ctx, data).ValueBool())
+
+		m.On("ListStoreApps", mock.Anything).Return([]client.StoreApp{}, nil).Once()
+		require.False(t, (&DNSAppResource{client: m}).appUpdateAvailable(ctx, data).ValueBool())
+
+		// Apps installed from a file are not looked up in the store
+		data.InstallMethod = types.StringValue("file")
+		require.False(t, (&DNSAppResource{client: m}).appUpdateAvailable(ctx, data).ValueBool())
+	})
+
+	t.Run("update without a new source keeps the app", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		r := &DNSAppResource{client: m}
+
+		installed := model(types.StringNull())
+		installed.ID = types.StringValue("Wild IP")
+		installed.Version = types.StringValue("2.0")
+		installed.UpdateAvailable = types.BoolValue(true)
+		installed.DNSApps = types.ListNull(types.ObjectType{AttrTypes: dnsAppAttrTypes(t, schemaResp)})
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, installed).HasError())
+
+		// Only the timeouts changed, so update_available is kept and nothing is installed
+		planned := *installed
+		planned.Version = types.StringUnknown()
+		planned.Timeouts = &TimeoutsModel{Create: types.StringNull(), Read: types.StringNull(), Update: types.StringValue("10m"), Delete: types.StringNull()}
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(ctx, &planned).HasError())
+
+		modifyResp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &modifyResp)
+		require.False(t, modifyResp.Diagnostics.HasError(), "plan diagnostics: %v", modifyResp.Diagnostics)
+		var modified DNSAppResourceModel
+		require.False(t, modifyResp.Plan.Get(ctx, &modified).HasError())
+		require.True(t, modified.UpdateAvailable.Equal(types.BoolValue(true)))
+
+		updateResp := resource.UpdateResponse{State: state}
+		r.Update(ctx, resource.UpdateRequest{Plan: modifyResp.Plan, State: state}, &updateResp)
+		require.False(t, updateResp.Diagnostics.HasError(), "update diagnostics: %v", updateResp.Diagnostics)
+		var updated DNSAppResourceModel
+		require.False(t, updateResp.State.Get(ctx, &updated).HasError())
+		require.Equal(t, "2.0", updated.Version.ValueString())
+		require.True(t, updated.UpdateAvailable.ValueBool())
+
+		// A new URL installs the app again, which may change update_available
+		planned.URL = types.StringValue("https://example.com/WildIpApp.zip")
+		require.False(t, plan.Set(ctx, &planned).HasError())
+		modifyResp = resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, &modifyResp)
+		require.False(t, modifyResp.Plan.Get(ctx, &modified).HasError())
+		require.True(t, modified.UpdateAvailable.IsUnknown())
+	})
+
+	t.Run("version with file install", func(t *testing.T) {
+		data := *model(types.StringValue("2.0"))
+		data.InstallMethod = types.StringValue("file")
+		require.ErrorContains(t, (&DNSAppResource{}).checkStoreVersion(ctx, data), "can only be pinned")
+	})
+}
+
+// dnsAppAttrTypes returns the attribute types of the dns_apps elements
+func dnsAppAttrTypes(t *testing.T, schemaResp resource.SchemaResponse) map[string]attr.Type {
+	t.Helper()
+
+	listType, ok := schemaResp.Schema.Attributes["dns_apps"].GetType().(types.ListType)
+	require.True(t, ok)
+	objectType, ok := listType.ElemType.(types.ObjectType)
+	require.True(t, ok)
+	return objectType.AttrTypes
+}
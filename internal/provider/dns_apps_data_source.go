@@ -22,7 +22,7 @@ func NewDNSAppsDataSource() datasource.DataSource {
 
 // DNSAppsDataSource defines the data source implementation.
 type DNSAppsDataSource struct {
-	client *client.Client
+	client client.ClientAPI
 }
 
 // DNSAppsDataSourceModel describes the data source data model.
@@ -0,0 +1,323 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &DNSClientQueryDataSource{}
+
+// Defaults of the DNS client query
+const (
+	defaultDNSClientServer = "this-server"
+	defaultDNSClientType   = "A"
+)
+
+// dnsClientRDataFields lists the record data fields making up the presentation of common record
+// types, in zone file order
+var dnsClientRDataFields = map[string][]string{
+	"A":     {"IPAddress"},
+	"AAAA":  {"IPAddress"},
+	"CNAME": {"Domain"},
+	"DNAME": {"Domain"},
+	"PTR":   {"Domain"},
+	"NS":    {"NameServer"},
+	"MX":    {"Preference", "Exchange"},
+	"TXT":   {"Text"},
+	"SRV":   {"Priority", "Weight", "Port", "Target"},
+	"CAA":   {"Flags", "Tag", "Value"},
+	"SOA":   {"PrimaryNameServer", "ResponsiblePerson", "Serial", "Refresh", "Retry", "Expire", "Minimum"},
+}
+
+func NewDNSClientQueryDataSource() datasource.DataSource {
+	return &DNSClientQueryDataSource{}
+}
+
+// DNSClientQueryDataSource defines the data source implementation.
+type DNSClientQueryDataSource struct {
+	client client.ClientAPI
+}
+
+// DNSClientQueryDataSourceModel describes the data source data model.
+type DNSClientQueryDataSourceModel struct {
+	ID                  types.String              `tfsdk:"id"`
+	Name                types.String              `tfsdk:"name"`
+	Type                types.String              `tfsdk:"type"`
+	Server              types.String              `tfsdk:"server"`
+	Protocol            types.String              `tfsdk:"protocol"`
+	DnssecValidation    types.Bool                `tfsdk:"dnssec_validation"`
+	EDNSClientSubnet    types.String              `tfsdk:"edns_client_subnet"`
+	Rcode               types.String              `tfsdk:"rcode"`
+	AuthoritativeAnswer types.Bool                `tfsdk:"authoritative_answer"`
+	AuthenticData       types.Bool                `tfsdk:"authentic_data"`
+	NameServer          types.String              `tfsdk:"name_server"`
+	RoundTripTime       types.String              `tfsdk:"round_trip_time"`
+	Answers             []DNSClientRecordDataItem `tfsdk:"answers"`
+	Authority           []DNSClientRecordDataItem `tfsdk:"authority"`
+}
+
+// DNSClientRecordDataItem represents a record of the DNS response
+type DNSClientRecordDataItem struct {
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+	Data  types.String `tfsdk:"data"`
+	RData types.Map    `tfsdk:"rdata"`
+}
+
+func (d *DNSClientQueryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_client_query"
+}
+
+// dnsClientRecordsAttribute returns the schema of a section of the DNS response
+func dnsClientRecordsAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "The name of the record.",
+					Computed:            true,
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "The type of the record.",
+					Computed:            true,
+				},
+				"ttl": schema.Int64Attribute{
+					MarkdownDescription: "The time to live of the record, in seconds.",
+					Computed:            true,
+				},
+				"data": schema.StringAttribute{
+					MarkdownDescription: "The record data in zone file order, e.g. `10 mail.example.com` for MX records.",
+					Computed:            true,
+				},
+				"rdata": schema.MapAttribute{
+					MarkdownDescription: "The fields of the record data as returned by the server, e.g. `IPAddress` or `Exchange`.",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func (d *DNSClientQueryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source resolving a name with the DNS client of the server",
+		MarkdownDescription: "Data source resolving a name live with the DNS client of the server, e.g. for smoke tests after a deployment. " +
+			"The query runs on every plan and its response is never imported into a zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The domain name to resolve, e.g. `www.example.com`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The record type to query, e.g. `AAAA` or `MX`. Defaults to `" + defaultDNSClientType + "`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9]+$`), "must be a record type, e.g. AAAA"),
+				},
+			},
+			"server": schema.StringAttribute{
+				MarkdownDescription: "The name server to query: `this-server`, `recursive-resolver`, `system-dns` or the address of a name server. " +
+					"Defaults to `" + defaultDNSClientServer + "`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					forwarderAddressValidator{},
+				},
+			},
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "The protocol used to reach the name server. Valid values are: " + enumDescription(forwarderProtocolValues) +
+					". Defaults to `" + string(client.DefaultForwarderProtocol) + "`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					enumValidator(forwarderProtocolValues),
+				},
+			},
+			"dnssec_validation": schema.BoolAttribute{
+				MarkdownDescription: "Whether to validate the response with DNSSEC. Defaults to `false`.",
+				Optional:            true,
+			},
+			"edns_client_subnet": schema.StringAttribute{
+				MarkdownDescription: "The network address sent in the EDNS Client Subnet option, e.g. `192.0.2.0/24`.",
+				Optional:            true,
+			},
+			"rcode": schema.StringAttribute{
+				MarkdownDescription: "The response code, e.g. `NoError` or `NxDomain`.",
+				Computed:            true,
+			},
+			"authoritative_answer": schema.BoolAttribute{
+				MarkdownDescription: "Whether the name server is authoritative for the name.",
+				Computed:            true,
+			},
+			"authentic_data": schema.BoolAttribute{
+				MarkdownDescription: "Whether the response was validated with DNSSEC.",
+				Computed:            true,
+			},
+			"name_server": schema.StringAttribute{
+				MarkdownDescription: "The name server that answered the query.",
+				Computed:            true,
+			},
+			"round_trip_time": schema.StringAttribute{
+				MarkdownDescription: "The time the query took, e.g. `1.42 ms`.",
+				Computed:            true,
+			},
+			"answers":   dnsClientRecordsAttribute("The records of the answer section."),
+			"authority": dnsClientRecordsAttribute("The records of the authority section, e.g. the SOA record of negative responses."),
+		},
+	}
+}
+
+func (d *DNSClientQueryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DNSClientQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DNSClientQueryDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Type.IsNull() {
+		data.Type = types.StringValue(defaultDNSClientType)
+	}
+	if data.Server.IsNull() {
+		data.Server = types.StringValue(defaultDNSClientServer)
+	}
+	if data.Protocol.IsNull() {
+		data.Protocol = types.StringValue(string(client.DefaultForwarderProtocol))
+	}
+
+	options := client.DNSClientQueryOptions{
+		Server:           data.Server.ValueString(),
+		Domain:           data.Name.ValueString(),
+		Type:             strings.ToUpper(data.Type.ValueString()),
+		Protocol:         client.ForwarderProtocol(data.Protocol.ValueString()),
+		DNSSEC:           data.DnssecValidation.ValueBool(),
+		EDNSClientSubnet: data.EDNSClientSubnet.ValueString(),
+	}
+
+	tflog.Debug(ctx, "Resolving DNS client query", map[string]interface{}{
+		"name":     options.Domain,
+		"type":     options.Type,
+		"server":   options.Server,
+		"protocol": options.Protocol,
+	})
+
+	result, err := d.client.ResolveQuery(ctx, options)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error resolving DNS query",
+			fmt.Sprintf("Could not resolve %s %s: %s", options.Domain, options.Type, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(formatRecordID(options.Server, options.Domain, options.Type))
+	data.Rcode = types.StringValue(result.RCODE)
+	data.AuthoritativeAnswer = types.BoolValue(result.AuthoritativeAnswer)
+	data.AuthenticData = types.BoolValue(result.AuthenticData)
+	data.NameServer = types.StringValue(result.Metadata.NameServer)
+	data.RoundTripTime = types.StringValue(result.Metadata.RoundTripTime)
+	data.Answers = dnsClientRecordDataItems(result.Answer)
+	data.Authority = dnsClientRecordDataItems(result.Authority)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dnsClientRecordDataItems converts the records of a section of the DNS response into data source items
+func dnsClientRecordDataItems(records []client.DNSClientRecord) []DNSClientRecordDataItem {
+	items := make([]DNSClientRecordDataItem, 0, len(records))
+	for _, record := range records {
+		fields := record.RDataFields()
+
+		rdata := make(map[string]attr.Value, len(fields))
+		for name, value := range fields {
+			rdata[name] = types.StringValue(value)
+		}
+
+		items = append(items, DNSClientRecordDataItem{
+			Name:  types.StringValue(record.Name),
+			Type:  types.StringValue(record.Type),
+			TTL:   types.Int64Value(record.TTLSeconds()),
+			Data:  types.StringValue(formatDNSClientRData(record.Type, fields)),
+			RData: types.MapValueMust(types.StringType, rdata),
+		})
+	}
+	return items
+}
+
+// formatDNSClientRData joins the record data fields in zone file order. Fields of other record
+// types, or records missing an expected field, are joined in name order.
+func formatDNSClientRData(recordType string, fields map[string]string) string {
+	if names, ok := dnsClientRDataFields[recordType]; ok {
+		values := make([]string, 0, len(names))
+		for _, name := range names {
+			value, ok := fields[name]
+			if !ok {
+				values = nil
+				break
+			}
+			values = append(values, value)
+		}
+		if values != nil {
+			return strings.Join(values, " ")
+		}
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, fields[name])
+	}
+	return strings.Join(values, " ")
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestUnitDNSClientQueryDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &DNSClientQueryDataSource{client: m}
+
+	m.On("ResolveQuery", mock.Anything, client.DNSClientQueryOptions{
+		Server:   "this-server",
+		Domain:   "example.com",
+		Type:     "MX",
+		Protocol: client.ForwarderProtocolUdp,
+	}).Return(&client.DNSClientResult{
+		Metadata:            client.DNSClientMetadata{NameServer: "server1:53 (127.0.0.1:53)", RoundTripTime: "1.42 ms"},
+		RCODE:               "NoError",
+		AuthoritativeAnswer: true,
+		Answer: []client.DNSClientRecord{{
+			Name: "example.com", Type: "MX", TTL: "3600 (1 hour)",
+			RData: map[string]json.RawMessage{"Preference": json.RawMessage(`10`), "Exchange": json.RawMessage(`"mail.example.com"`)},
+		}},
+	}, nil)
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	input := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, input.Set(context.Background(), &DNSClientQueryDataSourceModel{
+		Name: types.StringValue("example.com"),
+		Type: types.StringValue("mx"),
+	}).HasError())
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(context.Background(), req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var state DNSClientQueryDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "this-server", state.Server.ValueString())
+	require.Equal(t, "Udp", state.Protocol.ValueString())
+	require.Equal(t, "NoError", state.Rcode.ValueString())
+	require.True(t, state.AuthoritativeAnswer.ValueBool())
+	require.Len(t, state.Answers, 1)
+	require.Equal(t, int64(3600), state.Answers[0].TTL.ValueInt64())
+	require.Equal(t, "10 mail.example.com", state.Answers[0].Data.ValueString())
+	require.Equal(t, types.StringValue("mail.example.com"), state.Answers[0].RData.Elements()["Exchange"])
+	require.Empty(t, state.Authority)
+}
+
+func TestFormatDNSClientRData(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		recordType string
+		fields     map[string]string
+		expected   string
+	}{
+		"A": {
+			recordType: "A",
+			fields:     map[string]string{"IPAddress": "192.0.2.1"},
+			expected:   "192.0.2.1",
+		},
+		"SRV": {
+			recordType: "SRV",
+			fields:     map[string]string{"Target": "sip.example.com", "Port": "5060", "Weight": "5", "Priority": "10"},
+			expected:   "10 5 5060 sip.example.com",
+		},
+		"unknown type": {
+			recordType: "HINFO",
+			fields:     map[string]string{"OS": "linux", "CPU": "x86"},
+			expected:   "x86 linux",
+		},
+		"missing field": {
+			recordType: "MX",
+			fields:     map[string]string{"Exchange": "mail.example.com"},
+			expected:   "mail.example.com",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.expected, formatDNSClientRData(tt.recordType, tt.fields))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &DNSRecordResource{}
+
+// dnsRecordSchemaVersion is the version of the technitium_dns_record schema. Version 1 escapes
+// colons within the values of the resource ID.
+const dnsRecordSchemaVersion = 1
+
+// recordIDEscaper escapes the separator of record ID parts, and the escape character itself
+var recordIDEscaper = strings.NewReplacer("%", "%25", ":", "%3A")
+
+// recordIDUnescaper reverts recordIDEscaper
+var recordIDUnescaper = strings.NewReplacer("%3A", ":", "%3a", ":", "%25", "%")
+
+// formatRecordID joins the parts of a record ID with colons, escaping colons within the parts so
+// values such as IPv6 addresses or URLs can be told apart from the separators. IDs of records
+// whose values hold neither colons nor percent signs are the plain colon-joined values.
+func formatRecordID(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = recordIDEscaper.Replace(part)
+	}
+	return strings.Join(escaped, ":")
+}
+
+// parseRecordID splits a record ID into its unescaped parts
+func parseRecordID(id string) []string {
+	parts := strings.Split(id, ":")
+	for i, part := range parts {
+		parts[i] = recordIDUnescaper.Replace(part)
+	}
+	return parts
+}
+
+// dnsRecordID builds the resource ID of a record from its zone, name and type, followed by the
+// values telling it apart from other records of the same name and type
+func dnsRecordID(data *DNSRecordResourceModel) string {
+	recordType := data.Type.ValueString()
+	parts := []string{data.Zone.ValueString(), data.Name.ValueString(), recordType}
+
+	// For records like MX and SRV that need additional data in the ID to be unique
+	if !data.Priority.IsNull() && !data.Priority.IsUnknown() && recordTypeUsesAttribute(recordType, "priority") {
+		parts = append(parts, strconv.FormatInt(data.Priority.ValueInt64(), 10))
+	}
+
+	switch recordType {
+	case "TXT", "FWD", "APP":
+		// TXT data is free-form text, FWD forwarders are mutable and only one APP record can exist
+		// per name, so the combination of zone, name, and type identifies these records
+	case "CAA":
+		// CAA records are identified by their tag and value
+		parts = append(parts, data.Tag.ValueString(), data.Data.ValueString())
+	case "SSHFP":
+		// SSHFP records are identified by their algorithm, fingerprint type and fingerprint
+		parts = append(parts, data.Algorithm.ValueString(), data.FingerprintType.ValueString(), data.Fingerprint.ValueString())
+	case "TLSA":
+		// TLSA records are identified by their parameters and association data, PEM certificates are stored as hex
+		associationData, err := tlsaAssociationDataHex(data.CertificateAssociationData.ValueString(), data.Selector.ValueString(), data.MatchingType.ValueString())
+		if err != nil {
+			associationData = data.CertificateAssociationData.ValueString()
+		}
+		parts = append(parts, data.CertificateUsage.ValueString(), data.Selector.ValueString(), data.MatchingType.ValueString(), associationData)
+	default:
+		// For other record types, include the data in the ID
+		if data.Data.ValueString() != "" {
+			parts = append(parts, data.Data.ValueString())
+		}
+	}
+
+	return formatRecordID(parts...)
+}
+
+// UpgradeState migrates states written before record IDs were escaped. Version 0 IDs joined the
+// values unescaped, so IPv6 addresses and other values containing colons could not be parsed back.
+// The attributes are unchanged and the ID is rebuilt from the values stored in state.
+func (r *DNSRecordResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	priorSchema := schemaResp.Schema
+	priorSchema.Version = 0
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var data DNSRecordResourceModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				data.ID = types.StringValue(dnsRecordID(&data))
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			},
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		parts []string
+		id    string
+	}{
+		{name: "plain values", parts: []string{"example.com", "www", "A", "192.0.2.1"}, id: "example.com:www:A:192.0.2.1"},
+		{name: "IPv6 address", parts: []string{"example.com", "www", "AAAA", "2001:db8::1"}, id: "example.com:www:AAAA:2001%3Adb8%3A%3A1"},
+		{name: "CAA URL", parts: []string{"example.com", "@", "CAA", "iodef", "mailto:security@example.com"}, id: "example.com:@:CAA:iodef:mailto%3Asecurity@example.com"},
+		{name: "percent sign", parts: []string{"example.com", "www", "CNAME", "100%3A.example.com"}, id: "example.com:www:CNAME:100%253A.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.id, formatRecordID(tt.parts...))
+			require.Equal(t, tt.parts, parseRecordID(tt.id))
+		})
+	}
+}
+
+func TestDNSRecordResourceUpgradeState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &DNSRecordResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.Equal(t, int64(dnsRecordSchemaVersion), schemaResp.Schema.Version)
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	require.True(t, ok, "missing upgrader for schema version 0")
+
+	upgrade := func(t *testing.T, data DNSRecordResourceModel) DNSRecordResourceModel {
+		t.Helper()
+
+		setRecordModelNulls(&data)
+		prior := tfsdk.State{Schema: *upgrader.PriorSchema}
+		require.False(t, prior.Set(ctx, &data).HasError())
+
+		resp := resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{State: &prior}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "upgrade diagnostics: %v", resp.Diagnostics)
+
+		var upgraded DNSRecordResourceModel
+		require.False(t, resp.State.Get(ctx, &upgraded).HasError())
+		return upgraded
+	}
+
+	t.Run("escapes IPv6 data", func(t *testing.T) {
+		upgraded := upgrade(t, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:www:AAAA:2001:db8::1"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("www"),
+			Type: types.StringValue("AAAA"),
+			TTL:  types.Int64Value(300),
+			Data: types.StringValue("2001:db8::1"),
+		})
+		require.Equal(t, "example.com:www:AAAA:2001%3Adb8%3A%3A1", upgraded.ID.ValueString())
+		require.Equal(t, "2001:db8::1", upgraded.Data.ValueString())
+	})
+
+	t.Run("keeps plain IDs", func(t *testing.T) {
+		upgraded := upgrade(t, DNSRecordResourceModel{
+			ID:       types.StringValue("example.com:@:MX:10:mail.example.com"),
+			Zone:     types.StringValue("example.com"),
+			Name:     types.StringValue("@"),
+			Type:     types.StringValue("MX"),
+			TTL:      types.Int64Value(300),
+			Data:     types.StringValue("mail.example.com"),
+			Priority: types.Int64Value(10),
+		})
+		require.Equal(t, "example.com:@:MX:10:mail.example.com", upgraded.ID.ValueString())
+	})
+}
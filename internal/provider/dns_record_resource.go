@@ -2,14 +2,29 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
-	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -17,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
@@ -25,6 +41,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DNSRecordResource{}
 var _ resource.ResourceWithImportState = &DNSRecordResource{}
+var _ resource.ResourceWithModifyPlan = &DNSRecordResource{}
+var _ resource.ResourceWithValidateConfig = &DNSRecordResource{}
+var _ resource.ResourceWithIdentity = &DNSRecordResource{}
 
 func NewDNSRecordResource() resource.Resource {
 	return &DNSRecordResource{}
@@ -32,7 +51,7 @@ func NewDNSRecordResource() resource.Resource {
 
 // DNSRecordResource defines the resource implementation.
 type DNSRecordResource struct {
-	client *client.Client
+	client client.ClientAPI
 }
 
 // DNSRecordResourceModel describes the resource data model.
@@ -48,6 +67,17 @@ type DNSRecordResourceModel struct {
 	Port     types.Int64  `tfsdk:"port"`     // For SRV records
 	Comments types.String `tfsdk:"comments"` // Optional comments
 
+	// Replace existing records of the same name and type on creation
+	AllowOverwrite types.Bool `tfsdk:"allow_overwrite"`
+
+	// Reverse records kept in sync by the server for A and AAAA records
+	CreatePtr     types.Bool `tfsdk:"create_ptr"`
+	CreatePtrZone types.Bool `tfsdk:"create_ptr_zone"`
+
+	// Scheduled deletion of temporary records
+	ExpiryTTL types.Int64  `tfsdk:"expiry_ttl"`
+	ExpiresOn types.String `tfsdk:"expires_on"`
+
 	// FWD record specific fields
 	Protocol          types.String `tfsdk:"protocol"`           // For FWD records
 	Forwarder         types.String `tfsdk:"forwarder"`          // For FWD records
@@ -59,41 +89,130 @@ type DNSRecordResourceModel struct {
 	ProxyUsername     types.String `tfsdk:"proxy_username"`     // For FWD records
 	ProxyPassword     types.String `tfsdk:"proxy_password"`     // For FWD records
 
+	// CAA record specific fields
+	Flags types.Int64  `tfsdk:"flags"` // For CAA records
+	Tag   types.String `tfsdk:"tag"`   // For CAA records
+
+	// SSHFP record specific fields
+	Algorithm       types.String `tfsdk:"algorithm"`        // For SSHFP records
+	FingerprintType types.String `tfsdk:"fingerprint_type"` // For SSHFP records
+	Fingerprint     types.String `tfsdk:"fingerprint"`      // For SSHFP records
+
+	// TLSA record specific fields
+	CertificateUsage           types.String `tfsdk:"certificate_usage"`            // For TLSA records
+	Selector                   types.String `tfsdk:"selector"`                     // For TLSA records
+	MatchingType               types.String `tfsdk:"matching_type"`                // For TLSA records
+	CertificateAssociationData types.String `tfsdk:"certificate_association_data"` // For TLSA records
+
+	// SVCB and HTTPS record specific fields
+	SvcParams types.Map `tfsdk:"svc_params"` // For SVCB and HTTPS records
+
+	// APP record specific fields
+	AppName   types.String `tfsdk:"app_name"`   // For APP records
+	ClassPath types.String `tfsdk:"class_path"` // For APP records
+
 	// Computed attributes
 	Disabled     types.Bool   `tfsdk:"disabled"`
 	DnssecStatus types.String `tfsdk:"dnssec_status"`
 	LastUsedOn   types.String `tfsdk:"last_used_on"`
+
+	Timeouts *TimeoutsModel `tfsdk:"timeouts"`
 }
 
 func (r *DNSRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_dns_record"
+	// The record data and priority are part of the identity and can be updated in place
+	resp.ResourceBehavior.MutableIdentity = true
+}
+
+// DNSRecordIdentityModel describes the identity of a record, used by import blocks
+type DNSRecordIdentityModel struct {
+	Zone     types.String `tfsdk:"zone"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Data     types.String `tfsdk:"data"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+func (r *DNSRecordResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"zone": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The zone containing the record.",
+			},
+			"name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The record name, relative to the zone or @ for the apex.",
+			},
+			"type": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The record type.",
+			},
+			"data": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The record data, telling apart records sharing the name and type.",
+			},
+			"priority": identityschema.Int64Attribute{
+				OptionalForImport: true,
+				Description:       "The priority of MX and SRV records.",
+			},
+		},
+	}
+}
+
+// dnsRecordIdentity returns the identity of the record in the model
+func dnsRecordIdentity(data *DNSRecordResourceModel) DNSRecordIdentityModel {
+	identity := DNSRecordIdentityModel{
+		Zone:     data.Zone,
+		Name:     data.Name,
+		Type:     data.Type,
+		Data:     types.StringNull(),
+		Priority: types.Int64Null(),
+	}
+	if !data.Data.IsUnknown() && data.Data.ValueString() != "" {
+		identity.Data = data.Data
+	}
+	if recordTypeUsesAttribute(data.Type.ValueString(), "priority") && !data.Priority.IsUnknown() {
+		identity.Priority = data.Priority
+	}
+	return identity
 }
 
 func (r *DNSRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Technitium DNS Server record resource",
+		Version:             dnsRecordSchemaVersion,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Resource identifier",
-				Computed:            true,
+				MarkdownDescription: "Resource identifier: the zone, name and type followed by the values identifying the record, joined by colons. " +
+					"Colons within the values are escaped as `%3A` (e.g. `example.com:www:AAAA:2001%3Adb8%3A%3A1`)",
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"zone": schema.StringAttribute{
-				MarkdownDescription: "The zone in which to create the DNS record",
-				Required:            true,
+				MarkdownDescription: "The zone in which to create the DNS record. Defaults to the provider's `default_zone`, and must be set when the provider has none",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The record name (e.g., 'www' for www.example.com)",
-				Required:            true,
+				MarkdownDescription: "The record name (e.g., 'www' for www.example.com). Wildcard records use '*' as the leftmost label " +
+					"(e.g., '*' for *.example.com or '*.sub' for *.sub.example.com)",
+				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wildcardNamePattern,
+						"may only contain an asterisk as the whole leftmost label of a wildcard name (e.g. '*' or '*.sub')"),
+				},
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "The DNS record type (A, AAAA, CNAME, MX, TXT, etc.)",
@@ -104,61 +223,182 @@ func (r *DNSRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				Validators: []validator.String{
 					stringvalidator.OneOf(
 						"A", "AAAA", "CNAME", "MX", "TXT",
-						"PTR", "NS", "SRV", "FWD",
+						"PTR", "NS", "SRV", "CAA", "SSHFP", "TLSA", "SVCB", "HTTPS", "FWD", "APP",
 					),
 				},
 			},
 			"ttl": schema.Int64Attribute{
-				MarkdownDescription: "Time-to-live value in seconds",
-				Required:            true,
+				MarkdownDescription: "Time-to-live value in seconds. Defaults to the provider's `default_ttl`, and must be set when the provider has none",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					ttlValidator(),
+				},
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
 			"data": schema.StringAttribute{
-				MarkdownDescription: "Record data (depends on record type: IP address for A/AAAA, domain for CNAME, text for TXT, etc.)",
-				Required:            true,
+				MarkdownDescription: "Record data (depends on record type: IP address for A/AAAA, domain for CNAME, text for TXT, the target name for SVCB/HTTPS, the property value for CAA, the app record data for APP, usually JSON as described by the `record_data_template` of the `technitium_dns_app_component` data source, etc.). Required for all record types except SSHFP, which uses `fingerprint`, and TLSA, which uses `certificate_association_data`",
+				Optional:            true,
 			},
 			"priority": schema.Int64Attribute{
-				MarkdownDescription: "Priority value (used for MX and SRV records)",
+				MarkdownDescription: "Priority value (used for MX, SRV, SVCB and HTTPS records). For SVCB and HTTPS records 0 selects alias mode",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
+					recordTypeAwareInt64("priority"),
 				},
 			},
 			"weight": schema.Int64Attribute{
 				MarkdownDescription: "Weight value (used for SRV records)",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
+					recordTypeAwareInt64("weight"),
 				},
 			},
 			"port": schema.Int64Attribute{
 				MarkdownDescription: "Port value (used for SRV records)",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 				PlanModifiers: []planmodifier.Int64{
-					int64planmodifier.UseStateForUnknown(),
+					recordTypeAwareInt64("port"),
 				},
 			},
 			"comments": schema.StringAttribute{
 				MarkdownDescription: "Optional comments for the DNS record",
 				Optional:            true,
 			},
+			"expiry_ttl": schema.Int64Attribute{
+				MarkdownDescription: "Number of seconds after its last modification at which the server automatically deletes the record, " +
+					"e.g. for temporary delegations or ACME challenges. Changing it updates the record in place and restarts the countdown. " +
+					"Once the server deleted the record, the next plan recreates it. Remove the attribute to stop the record from expiring",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxTTL),
+				},
+			},
+			"expires_on": schema.StringAttribute{
+				MarkdownDescription: "When the server deletes the record (RFC 3339), computed from its last modification and `expiry_ttl`. " +
+					"Empty when the record does not expire",
+				Computed: true,
+			},
+			// CAA record specific attributes
+			"flags": schema.Int64Attribute{
+				MarkdownDescription: "Flags for CAA records. Set to 128 to mark the property as critical. Defaults to 0",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 255),
+				},
+			},
+			"tag": schema.StringAttribute{
+				MarkdownDescription: "Property tag for CAA records (issue, issuewild, iodef). The property value is set with `data`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("issue", "issuewild", "iodef"),
+				},
+			},
+
+			// SSHFP record specific attributes
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "SSH key algorithm for SSHFP records (RSA, DSA, ECDSA, Ed25519, Ed448)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("RSA", "DSA", "ECDSA", "Ed25519", "Ed448"),
+				},
+			},
+			"fingerprint_type": schema.StringAttribute{
+				MarkdownDescription: "Fingerprint hash type for SSHFP records (SHA1, SHA256)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("SHA1", "SHA256"),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				MarkdownDescription: "Hex encoded SSH host key fingerprint for SSHFP records",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-fA-F]+$`), "must be a hex string"),
+				},
+			},
+
+			// TLSA record specific attributes
+			"certificate_usage": schema.StringAttribute{
+				MarkdownDescription: "Certificate usage for TLSA records (PKIX-TA, PKIX-EE, DANE-TA, DANE-EE)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("PKIX-TA", "PKIX-EE", "DANE-TA", "DANE-EE"),
+				},
+			},
+			"selector": schema.StringAttribute{
+				MarkdownDescription: "Which part of the certificate TLSA records match: the full certificate (Cert) or its public key (SPKI)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Cert", "SPKI"),
+				},
+			},
+			"matching_type": schema.StringAttribute{
+				MarkdownDescription: "How TLSA records present the selected data (Full, SHA2-256, SHA2-512)",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Full", "SHA2-256", "SHA2-512"),
+				},
+			},
+			"certificate_association_data": schema.StringAttribute{
+				MarkdownDescription: "Certificate association data for TLSA records, either as a hex string or as a PEM encoded certificate " +
+					"from which the server derives the value using `selector` and `matching_type`",
+				Optional: true,
+			},
+
+			// SVCB and HTTPS record specific attributes
+			"svc_params": schema.MapAttribute{
+				MarkdownDescription: "Service parameters for SVCB and HTTPS records keyed by parameter name (e.g. alpn, port, ipv4hint, ipv6hint). " +
+					"List values are comma separated, e.g. `alpn = \"h2,h3\"`",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"allow_overwrite": schema.BoolAttribute{
+				MarkdownDescription: "Replace any existing records with the same name and type when the record is created, instead of failing because the record already exists. " +
+					"When false or not set, the record is added next to existing records with the same name and type, so round-robin sets (e.g. several A records " +
+					"for one name) can be managed with one resource per record without the resources overwriting each other.",
+				Optional: true,
+			},
+			"create_ptr": schema.BoolAttribute{
+				MarkdownDescription: "Let the server add or update the matching PTR record in the reverse zone when this A or AAAA record is created or updated, " +
+					"so forward and reverse entries stay in sync without a separate resource. The reverse zone must exist unless `create_ptr_zone` is set. " +
+					"Only valid for A and AAAA records",
+				Optional: true,
+			},
+			"create_ptr_zone": schema.BoolAttribute{
+				MarkdownDescription: "Create the reverse zone for the PTR record when it does not exist yet. Requires `create_ptr`",
+				Optional:            true,
+			},
 
 			// FWD record specific attributes
 			"protocol": schema.StringAttribute{
-				MarkdownDescription: "Protocol for FWD records (Udp, Tcp, Tls, Https, Quic)",
+				MarkdownDescription: "Protocol for FWD records (" + enumDescription(forwarderProtocolValues) + ")",
 				Optional:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("Udp", "Tcp", "Tls", "Https", "Quic"),
+					enumValidator(forwarderProtocolValues),
 				},
 			},
 			"forwarder": schema.StringAttribute{
 				MarkdownDescription: "Forwarder address for FWD records (IP address or 'this-server')",
 				Optional:            true,
+				Validators: []validator.String{
+					forwarderAddressValidator{},
+				},
 			},
 			"forwarder_priority": schema.Int64Attribute{
 				MarkdownDescription: "Priority for FWD records (higher priority = lower value)",
@@ -177,10 +417,10 @@ func (r *DNSRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 			"proxy_type": schema.StringAttribute{
-				MarkdownDescription: "Proxy type for FWD records (NoProxy, DefaultProxy, Http, Socks5)",
+				MarkdownDescription: "Proxy type for FWD records (" + enumDescription(proxyTypeValues) + ")",
 				Optional:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("NoProxy", "DefaultProxy", "Http", "Socks5"),
+					enumValidator(proxyTypeValues),
 				},
 			},
 			"proxy_address": schema.StringAttribute{
@@ -191,6 +431,9 @@ func (r *DNSRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				MarkdownDescription: "Proxy server port for FWD records",
 				Optional:            true,
 				Computed:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
 				},
@@ -205,10 +448,22 @@ func (r *DNSRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				Sensitive:           true,
 			},
 
-			// Computed attributes
+			// APP record specific attributes
+			"app_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the installed DNS app handling APP records (e.g. 'Split Horizon'). Required for APP records.",
+				Optional:            true,
+			},
+			"class_path": schema.StringAttribute{
+				MarkdownDescription: "Class path of the DNS app component handling APP records (e.g. 'SplitHorizon.SimpleAddress'). Required for APP records. " +
+					"APP records can also be used in conditional forwarder zones, e.g. for the Split Horizon and Advanced Forwarding apps.",
+				Optional: true,
+			},
+
 			"disabled": schema.BoolAttribute{
-				MarkdownDescription: "Whether the record is disabled",
-				Computed:            true,
+				MarkdownDescription: "Whether the record is disabled. A disabled record stays in the zone but is not served, so it can be turned off " +
+					"without being deleted. Defaults to the current state of the record, new records are enabled.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.UseStateForUnknown(),
 				},
@@ -228,6 +483,9 @@ func (r *DNSRecordResource) Schema(ctx context.Context, req resource.SchemaReque
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
 	}
 }
 
@@ -258,11 +516,18 @@ func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// Create options map for record creation
-	options := r.buildRecordOptions(ctx, &data, "create")
+	ctx, cancel, diags := operationContext(ctx, data.Timeouts, "create")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the planned values for strict consistency checks
+	planned := data
 
 	// Validate based on record type
-	if err := r.validateRecord(&data, options); err != nil {
+	if err := r.validateRecord(&data); err != nil {
 		resp.Diagnostics.AddError(
 			"Invalid DNS record configuration",
 			err.Error(),
@@ -270,6 +535,14 @@ func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
+	// APP records must reference an installed app component that handles APP records
+	if data.Type.ValueString() == "APP" {
+		if err := r.validateAppRecord(ctx, &data); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("class_path"), "Invalid APP record configuration", err.Error())
+			return
+		}
+	}
+
 	tflog.Debug(ctx, "Creating DNS record", map[string]interface{}{
 		"zone": data.Zone.ValueString(),
 		"name": data.Name.ValueString(),
@@ -278,18 +551,8 @@ func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateReque
 
 	// In Technitium DNS, if the record name doesn't match certain patterns,
 	// we need to use the fully qualified domain name (FQDN)
-	recordName := data.Name.ValueString()
 	zoneName := data.Zone.ValueString()
-
-	// If the record name is not "@" (root), not already the zone name, and doesn't end with the zone name,
-	// we need to append the zone name to create a proper FQDN for Technitium
-	if recordName != "@" && recordName != zoneName {
-		// For short names like "www", we need to append the zone name to make "www.example.com"
-		// But don't do this if it already has a trailing dot or already includes the zone name
-		if !strings.HasSuffix(recordName, ".") && !strings.HasSuffix(recordName, "."+zoneName) && !strings.HasSuffix(recordName, zoneName) {
-			recordName = recordName + "." + zoneName
-		}
-	}
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
 
 	tflog.Debug(ctx, "Creating DNS record with formatted name", map[string]interface{}{
 		"zone":           zoneName,
@@ -297,17 +560,29 @@ func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateReque
 		"formatted_name": recordName,
 	})
 
+	ttl, err := ttlToInt(data.TTL)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid TTL", err.Error())
+		return
+	}
+
 	// Create the record via the API
-	recordResp, err := r.client.AddRecord(
-		ctx,
-		zoneName,
-		recordName,
-		data.Type.ValueString(),
-		int(data.TTL.ValueInt64()),
-		options,
-	)
+	recordResp, err := r.client.AddRecord(ctx, client.AddRecordRequest{
+		Zone:      zoneName,
+		Domain:    recordName,
+		TTL:       int64(ttl),
+		Data:      recordData(&data),
+		Comments:  data.Comments.ValueString(),
+		ExpiryTTL: knownInt64(data.ExpiryTTL),
+		Overwrite: data.AllowOverwrite.ValueBool(),
+	})
 
 	if err != nil {
+		if isRecordExistsError(err) {
+			resp.Diagnostics.AddError("DNS record already exists", r.recordExistsDetail(ctx, &data, recordName, err))
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error creating DNS record",
 			fmt.Sprintf("Could not create %s record %s: %s", data.Type.ValueString(), data.Name.ValueString(), err.Error()),
@@ -315,44 +590,16 @@ func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	// Generate a unique ID for the record
-	recordID := fmt.Sprintf("%s:%s:%s",
-		data.Zone.ValueString(),
-		data.Name.ValueString(),
-		data.Type.ValueString(),
-	)
-
-	// For records like MX and SRV that need additional data in the ID to be unique
-	if !data.Priority.IsNull() && !data.Priority.IsUnknown() {
-		recordID += fmt.Sprintf(":%d", data.Priority.ValueInt64())
-	}
-
-	// For data value that might be crucial to uniquely identify the record
-	// Special handling for TXT and FWD records
-	if data.Type.ValueString() == "TXT" {
-		// TXT records may have spaces and special characters that make IDs problematic
-		// For TXT records, exclude the data from the ID to avoid issues with special characters
-		// The combination of zone, name, and type should be unique enough
+	data.ID = types.StringValue(dnsRecordID(&data))
 
-		// Log TXT record ID generation without including the data
-		tflog.Info(ctx, "Generated TXT record ID without data field", map[string]interface{}{
-			"record_id": recordID,
-			"txt_value": data.Data.ValueString(),
-		})
-	} else if data.Type.ValueString() == "FWD" {
-		// FWD records should not include the forwarder address in the ID because it's mutable
-		// The combination of zone, name, and type should be unique enough
-		tflog.Info(ctx, "Generated FWD record ID without data field", map[string]interface{}{
-			"record_id":     recordID,
-			"fwd_forwarder": data.Data.ValueString(),
-		})
-	} else if data.Data.ValueString() != "" {
-		// For other record types, include the data in the ID
-		recordID += fmt.Sprintf(":%s", data.Data.ValueString())
+	// Records are always added enabled, so a record planned as disabled is disabled right after
+	var disableErr error
+	if data.Disabled.ValueBool() && !recordResp.AddedRecord.Disabled {
+		if disableErr = r.disableRecord(ctx, &data, recordName); disableErr == nil {
+			recordResp.AddedRecord.Disabled = true
+		}
 	}
 
-	data.ID = types.StringValue(recordID)
-
 	// Update model with any computed fields from response
 	data.Disabled = types.BoolValue(recordResp.AddedRecord.Disabled)
 	data.DnssecStatus = types.StringValue(recordResp.AddedRecord.DnssecStatus)
@@ -445,13 +692,38 @@ func (r *DNSRecordResource) Create(ctx context.Context, req resource.CreateReque
 	} else {
 		data.LastUsedOn = types.StringValue("")
 	}
+	data.ExpiresOn = types.StringValue(recordExpiresOn(recordResp.AddedRecord, data.ExpiryTTL))
 
 	tflog.Debug(ctx, "DNS record created successfully", map[string]interface{}{
 		"id": data.ID.ValueString(),
 	})
+	reportOperation(ctx, r.client, "records", client.OperationCreated)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, dnsRecordIdentity(&data))...)
+	}
+
+	// The record exists, so it is saved (and tainted) before reporting that it could not be disabled
+	if disableErr != nil {
+		resp.Diagnostics.AddError(
+			"Error disabling DNS record",
+			fmt.Sprintf("The %s record %s was created, but could not be disabled: %s", data.Type.ValueString(), data.Name.ValueString(), disableErr.Error()),
+		)
+		return
+	}
+
+	// In strict mode, verify the server stored exactly what was planned. The state is saved
+	// first so the record is tracked (and tainted) rather than orphaned on failure.
+	if r.client.StrictConsistency() {
+		if err := r.checkConsistency(ctx, &planned, recordName); err != nil {
+			resp.Diagnostics.AddError(
+				"DNS record inconsistent with plan",
+				fmt.Sprintf("The %s record %s was created, but the server stored different values than planned: %s", planned.Type.ValueString(), planned.Name.ValueString(), err.Error()),
+			)
+		}
+	}
 }
 
 func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -463,17 +735,66 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	ctx, cancel, diags := operationContext(ctx, data.Timeouts, "read")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matches, zone, diags := r.readRecord(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if matches == 0 {
+		// Record not found, remove from state
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, dnsRecordIdentity(&data))...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Report out-of-band modifications field by field, so operators can track down their source
+	if diffs := refreshDiff(req.State.Raw, resp.State.Raw); len(diffs) > 0 {
+		tflog.Warn(ctx, "DNS record modified outside of Terraform", map[string]interface{}{
+			"zone":  zone,
+			"name":  data.Name.ValueString(),
+			"type":  data.Type.ValueString(),
+			"diffs": diffs,
+		})
+		resp.Diagnostics.AddWarning(
+			"DNS record modified outside of Terraform",
+			fmt.Sprintf("The %s record %s in zone %s differs from the Terraform state:\n\n  %s\n\n"+
+				"The next apply restores the configured values unless the configuration is updated.",
+				data.Type.ValueString(), data.Name.ValueString(), zone, strings.Join(diffs, "\n  ")),
+		)
+	}
+}
+
+// readRecord locates the record identified by the ID and the values in data and updates data with
+// the server values. It returns the number of matching records, of which the first one was read,
+// and the zone named by the ID.
+func (r *DNSRecordResource) readRecord(ctx context.Context, data *DNSRecordResourceModel) (matches int, zone string, diags diag.Diagnostics) {
 	// Extract record details from ID (format: zone:name:type[:priority][:data])
-	idParts := strings.Split(data.ID.ValueString(), ":")
+	idParts := parseRecordID(data.ID.ValueString())
 	if len(idParts) < 3 {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Invalid ID format",
 			fmt.Sprintf("Expected at least 3 parts in ID (zone:name:type), got: %s", data.ID.ValueString()),
 		)
-		return
+		return 0, "", diags
 	}
 
-	zone := idParts[0]
+	zone = idParts[0]
 	name := idParts[1]
 	recordType := idParts[2]
 
@@ -492,18 +813,7 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Format the name properly for Technitium DNS
-	recordName := name
-	zoneName := zone
-
-	// If the record name is not "@" (root), not already the zone name, and doesn't end with the zone name,
-	// we need to append the zone name to create a proper FQDN for Technitium
-	if recordName != "@" && recordName != zoneName {
-		// For short names like "www", we need to append the zone name to make "www.example.com"
-		// But don't do this if it already has a trailing dot or already includes the zone name
-		if !strings.HasSuffix(recordName, ".") && !strings.HasSuffix(recordName, "."+zoneName) && !strings.HasSuffix(recordName, zoneName) {
-			recordName = recordName + "." + zoneName
-		}
-	}
+	recordName := formatRecordName(name, zone)
 
 	// Priority or data may be part of the ID for certain record types
 	var priority int64
@@ -522,14 +832,79 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 		recordData = idParts[4]
 	}
 
+	// The values in state take precedence over the ID, so the record is still found after an update
+	// changed its data, and round-robin siblings sharing the name and type are told apart. TXT IDs do
+	// not hold the data at all.
+	if !data.Data.IsNull() && !data.Data.IsUnknown() && data.Data.ValueString() != "" {
+		recordData = data.Data.ValueString()
+	}
+	if recordTypeUsesAttribute(recordType, "priority") && !data.Priority.IsNull() && !data.Priority.IsUnknown() {
+		priority = data.Priority.ValueInt64()
+	}
+
+	// CAA IDs hold the tag and the value, which may itself contain colons (e.g. iodef URLs).
+	// The values in state take precedence so the record is still found after an update.
+	var caaTag string
+	if recordType == "CAA" {
+		if len(idParts) > 3 {
+			caaTag = idParts[3]
+			recordData = strings.Join(idParts[4:], ":")
+		}
+		if !data.Tag.IsNull() && !data.Tag.IsUnknown() {
+			caaTag = data.Tag.ValueString()
+		}
+		if !data.Data.IsNull() && !data.Data.IsUnknown() {
+			recordData = data.Data.ValueString()
+		}
+	}
+
+	// SSHFP IDs hold the algorithm, fingerprint type and fingerprint, with state values taking precedence
+	var sshfpAlgorithm, sshfpFingerprintType, sshfpFingerprint string
+	if recordType == "SSHFP" {
+		if len(idParts) > 5 {
+			sshfpAlgorithm, sshfpFingerprintType, sshfpFingerprint = idParts[3], idParts[4], idParts[5]
+		}
+		if !data.Algorithm.IsNull() && !data.Algorithm.IsUnknown() {
+			sshfpAlgorithm = data.Algorithm.ValueString()
+		}
+		if !data.FingerprintType.IsNull() && !data.FingerprintType.IsUnknown() {
+			sshfpFingerprintType = data.FingerprintType.ValueString()
+		}
+		if !data.Fingerprint.IsNull() && !data.Fingerprint.IsUnknown() {
+			sshfpFingerprint = data.Fingerprint.ValueString()
+		}
+	}
+
+	// TLSA IDs hold the certificate usage, selector, matching type and association data, with state values taking precedence
+	var tlsaUsage, tlsaSelector, tlsaMatchingType, tlsaData string
+	if recordType == "TLSA" {
+		if len(idParts) > 6 {
+			tlsaUsage, tlsaSelector, tlsaMatchingType, tlsaData = idParts[3], idParts[4], idParts[5], idParts[6]
+		}
+		if !data.CertificateUsage.IsNull() && !data.CertificateUsage.IsUnknown() {
+			tlsaUsage = data.CertificateUsage.ValueString()
+		}
+		if !data.Selector.IsNull() && !data.Selector.IsUnknown() {
+			tlsaSelector = data.Selector.ValueString()
+		}
+		if !data.MatchingType.IsNull() && !data.MatchingType.IsUnknown() {
+			tlsaMatchingType = data.MatchingType.ValueString()
+		}
+		if !data.CertificateAssociationData.IsNull() && !data.CertificateAssociationData.IsUnknown() {
+			if associationData, err := tlsaAssociationDataHex(data.CertificateAssociationData.ValueString(), tlsaSelector, tlsaMatchingType); err == nil {
+				tlsaData = associationData
+			}
+		}
+	}
+
 	// Fetch records for this domain in this zone
 	recordsResp, err := r.client.GetRecords(ctx, zone, recordName, false)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		diags.AddError(
 			"Error reading DNS record",
 			fmt.Sprintf("Could not read %s record %s in zone %s: %s", recordType, recordName, zone, err.Error()),
 		)
-		return
+		return 0, zone, diags
 	}
 
 	// Debug log for TXT records
@@ -554,7 +929,6 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Find the specific record we're looking for
-	var found bool
 	for _, record := range recordsResp.Records {
 		// Match on type first
 		if record.Type != recordType {
@@ -563,7 +937,7 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 
 		// For MX records, match on priority and data
 		if recordType == "MX" {
-			if (priority > 0 && (priority < int64(math.MinInt32) || priority > int64(math.MaxInt32) || record.RData.Preference != int(priority))) ||
+			if (priority > 0 && int64(record.RData.Preference) != priority) ||
 				(recordData != "" && record.RData.Exchange != recordData) {
 				continue
 			}
@@ -580,6 +954,42 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 			if recordData != "" && record.RData.CNAME != recordData {
 				continue
 			}
+		} else if recordType == "NS" {
+			if recordData != "" && !strings.EqualFold(strings.TrimSuffix(record.RData.NameServer, "."), strings.TrimSuffix(recordData, ".")) {
+				continue
+			}
+		} else if recordType == "PTR" {
+			if recordData != "" && !strings.EqualFold(strings.TrimSuffix(record.RData.PTRName, "."), strings.TrimSuffix(recordData, ".")) {
+				continue
+			}
+		} else if recordType == "SRV" {
+			if (priority > 0 && int64(record.RData.Priority) != priority) ||
+				(recordData != "" && !strings.EqualFold(strings.TrimSuffix(record.RData.Target, "."), strings.TrimSuffix(recordData, "."))) ||
+				(data.Port.ValueInt64() > 0 && int64(record.RData.Port) != data.Port.ValueInt64()) {
+				continue
+			}
+		} else if recordType == "CAA" {
+			if (caaTag != "" && record.RData.Tag != caaTag) || (recordData != "" && record.RData.Value != recordData) {
+				continue
+			}
+		} else if recordType == "SVCB" || recordType == "HTTPS" {
+			if (priority > 0 && int64(record.RData.SvcPriority) != priority) ||
+				(recordData != "" && !strings.EqualFold(strings.TrimSuffix(record.RData.SvcTargetName, "."), strings.TrimSuffix(recordData, "."))) {
+				continue
+			}
+		} else if recordType == "SSHFP" {
+			if (sshfpAlgorithm != "" && record.RData.Algorithm != sshfpAlgorithm) ||
+				(sshfpFingerprintType != "" && record.RData.FingerprintType != sshfpFingerprintType) ||
+				(sshfpFingerprint != "" && !strings.EqualFold(record.RData.Fingerprint, sshfpFingerprint)) {
+				continue
+			}
+		} else if recordType == "TLSA" {
+			if (tlsaUsage != "" && record.RData.CertificateUsage != tlsaUsage) ||
+				(tlsaSelector != "" && record.RData.Selector != tlsaSelector) ||
+				(tlsaMatchingType != "" && record.RData.MatchingType != tlsaMatchingType) ||
+				(tlsaData != "" && !strings.EqualFold(record.RData.CertificateAssociationData, tlsaData)) {
+				continue
+			}
 		} else if recordType == "TXT" {
 			// Debug log for TXT record comparison
 			tflog.Debug(ctx, "TXT record comparison in Read", map[string]interface{}{
@@ -608,8 +1018,12 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 			}
 		}
 
-		// If we reach here, we've found a match
-		found = true
+		// If we reach here, we've found a match. Only the first one is read, later ones are counted
+		// so imports can reject IDs that do not identify a single record.
+		matches++
+		if matches > 1 {
+			continue
+		}
 
 		// Update the model with values from the record
 		data.Zone = types.StringValue(zone)
@@ -657,6 +1071,19 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 			data.LastUsedOn = types.StringValue("")
 		}
 
+		// Keep expiry_ttl null for records that do not expire unless it was configured
+		if record.ExpiryTTL > 0 || !data.ExpiryTTL.IsNull() {
+			data.ExpiryTTL = types.Int64Value(int64(record.ExpiryTTL))
+		}
+		data.ExpiresOn = types.StringValue(recordExpiresOn(record, data.ExpiryTTL))
+
+		// Keep comments null unless they were configured or set on the server. The provider's
+		// default comment is not part of the configured comments.
+		comments := configuredComments(r.client, record.Comments)
+		if comments != "" || !data.Comments.IsNull() {
+			data.Comments = types.StringValue(comments)
+		}
+
 		// Set record-specific fields
 		switch recordType {
 		case "A", "AAAA":
@@ -712,19 +1139,117 @@ func (r *DNSRecordResource) Read(ctx context.Context, req resource.ReadRequest,
 			if !data.ProxyPassword.IsNull() && !data.ProxyPassword.IsUnknown() && record.RData.ProxyPassword != "" {
 				data.ProxyPassword = types.StringValue(record.RData.ProxyPassword)
 			}
+		case "CAA":
+			data.Data = types.StringValue(record.RData.Value)
+			data.Tag = types.StringValue(record.RData.Tag)
+			// Keep unconfigured flags null unless the server holds non-default flags
+			if !data.Flags.IsNull() || record.RData.Flags.Int() != 0 {
+				data.Flags = types.Int64Value(int64(record.RData.Flags.Int()))
+			}
+		case "SVCB", "HTTPS":
+			data.Data = types.StringValue(record.RData.SvcTargetName)
+			data.Priority = types.Int64Value(int64(record.RData.SvcPriority))
+
+			// Keep unconfigured parameters null when the server holds none
+			if len(record.RData.SvcParams) > 0 || !data.SvcParams.IsNull() {
+				svcParams, svcDiags := types.MapValueFrom(ctx, types.StringType, mergeSvcParams(svcParamsValue(data.SvcParams), record.RData.SvcParams))
+				diags.Append(svcDiags...)
+				data.SvcParams = svcParams
+			}
+		case "SSHFP":
+			data.Algorithm = types.StringValue(record.RData.Algorithm)
+			data.FingerprintType = types.StringValue(record.RData.FingerprintType)
+			// The server returns upper case hex, keep the configured case when the value matches
+			if !strings.EqualFold(data.Fingerprint.ValueString(), record.RData.Fingerprint) {
+				data.Fingerprint = types.StringValue(record.RData.Fingerprint)
+			}
+		case "TLSA":
+			data.CertificateUsage = types.StringValue(record.RData.CertificateUsage)
+			data.Selector = types.StringValue(record.RData.Selector)
+			data.MatchingType = types.StringValue(record.RData.MatchingType)
+			// Keep a configured PEM certificate or hex case when it still yields the server value
+			associationData, err := tlsaAssociationDataHex(data.CertificateAssociationData.ValueString(), record.RData.Selector, record.RData.MatchingType)
+			if err != nil || !strings.EqualFold(associationData, record.RData.CertificateAssociationData) {
+				data.CertificateAssociationData = types.StringValue(record.RData.CertificateAssociationData)
+			}
+		case "APP":
+			// Keep the configured record data when it only differs in JSON formatting
+			if !appRecordDataEqual(data.Data.ValueString(), record.RData.Data) {
+				data.Data = types.StringValue(record.RData.Data)
+			}
+			data.AppName = types.StringValue(record.RData.AppName)
+			data.ClassPath = types.StringValue(record.RData.ClassPath)
 		}
 
-		break
 	}
 
-	if !found {
-		// Record not found, remove from state
-		resp.State.RemoveResource(ctx)
-		return
+	return matches, zone, diags
+}
+
+// refreshIgnoredAttributes lists the computed attributes that change on the server without any
+// modification of the record, so they are not reported as out-of-band changes
+var refreshIgnoredAttributes = map[string]bool{
+	"last_used_on":  true,
+	"expires_on":    true,
+	"dnssec_status": true,
+}
+
+// refreshSensitiveAttributes lists the sensitive attributes, whose values are left out of refresh
+// diffs since they end up in warnings and logs
+var refreshSensitiveAttributes = map[string]bool{
+	"proxy_password": true,
+}
+
+// refreshDiff compares the prior state with the refreshed state and describes every attribute the
+// server reports differently. Attributes that were not set in the prior state, e.g. right after
+// an import, are not reported, and sensitive attributes are reported without their values.
+func refreshDiff(prior, refreshed tftypes.Value) []string {
+	var priorAttrs, refreshedAttrs map[string]tftypes.Value
+	if prior.IsNull() || !prior.IsKnown() || prior.As(&priorAttrs) != nil || refreshed.As(&refreshedAttrs) != nil {
+		return nil
 	}
 
-	// Save updated data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	var diffs []string
+	for name, priorValue := range priorAttrs {
+		refreshedValue, ok := refreshedAttrs[name]
+		if !ok || refreshIgnoredAttributes[name] || priorValue.IsNull() || !priorValue.IsKnown() || priorValue.Equal(refreshedValue) {
+			continue
+		}
+		if refreshSensitiveAttributes[name] {
+			diffs = append(diffs, fmt.Sprintf("%s: (sensitive value) changed", name))
+			continue
+		}
+		diffs = append(diffs, fmt.Sprintf("%s: state %s, server %s", name, formatRefreshValue(priorValue), formatRefreshValue(refreshedValue)))
+	}
+
+	sort.Strings(diffs)
+	return diffs
+}
+
+// formatRefreshValue renders a state value for refresh diffs, quoting strings like HCL does
+func formatRefreshValue(value tftypes.Value) string {
+	if value.IsNull() {
+		return "null"
+	}
+
+	switch {
+	case value.Type().Is(tftypes.String):
+		var v string
+		if value.As(&v) == nil {
+			return strconv.Quote(v)
+		}
+	case value.Type().Is(tftypes.Number):
+		var v *big.Float
+		if value.As(&v) == nil && v != nil {
+			return v.Text('f', -1)
+		}
+	case value.Type().Is(tftypes.Bool):
+		var v bool
+		if value.As(&v) == nil {
+			return strconv.FormatBool(v)
+		}
+	}
+	return value.String()
 }
 
 func (r *DNSRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -738,36 +1263,27 @@ func (r *DNSRecordResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	// Create options map for record update
-	options := r.buildRecordOptions(ctx, &oldData, "current")
-	updateOptions := r.buildRecordOptions(ctx, &data, "new")
-
-	// Merge options for the update call
-	for k, v := range updateOptions {
-		options[k] = v
+	ctx, cancel, diags := operationContext(ctx, data.Timeouts, "update")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Add TTL to options
-	options["ttl"] = strconv.FormatInt(data.TTL.ValueInt64(), 10)
+	// Keep the planned values for strict consistency checks
+	planned := data
 
-	// Add comments if provided
-	if !data.Comments.IsNull() && !data.Comments.IsUnknown() {
-		options["comments"] = data.Comments.ValueString()
+	// APP records must reference an installed app component that handles APP records
+	if data.Type.ValueString() == "APP" {
+		if err := r.validateAppRecord(ctx, &data); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("class_path"), "Invalid APP record configuration", err.Error())
+			return
+		}
 	}
 
 	// Format the name properly for Technitium DNS
-	recordName := data.Name.ValueString()
 	zoneName := data.Zone.ValueString()
-
-	// If the record name is not "@" (root), not already the zone name, and doesn't end with the zone name,
-	// we need to append the zone name to create a proper FQDN for Technitium
-	if recordName != "@" && recordName != zoneName {
-		// For short names like "www", we need to append the zone name to make "www.example.com"
-		// But don't do this if it already has a trailing dot or already includes the zone name
-		if !strings.HasSuffix(recordName, ".") && !strings.HasSuffix(recordName, "."+zoneName) && !strings.HasSuffix(recordName, zoneName) {
-			recordName = recordName + "." + zoneName
-		}
-	}
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
 
 	tflog.Debug(ctx, "Updating DNS record", map[string]interface{}{
 		"id":             data.ID.ValueString(),
@@ -777,14 +1293,33 @@ func (r *DNSRecordResource) Update(ctx context.Context, req resource.UpdateReque
 		"type":           data.Type.ValueString(),
 	})
 
-	// Update the record via the API
-	recordResp, err := r.client.UpdateRecord(
-		ctx,
-		zoneName,
-		recordName,
-		data.Type.ValueString(),
-		options,
-	)
+	ttl, err := ttlToInt(data.TTL)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid TTL", err.Error())
+		return
+	}
+
+	// Comments removed from the configuration are cleared, since leaving out the parameter keeps
+	// the current comments and they would be read back as drift
+	comments := recordComments(&data)
+	if comments == nil && data.Comments.IsNull() && oldData.Comments.ValueString() != "" {
+		cleared := ""
+		comments = &cleared
+	}
+
+	// Update the record via the API, enabling or disabling it along with the update. An expiry
+	// TTL of 0 clears a scheduled deletion.
+	expiryTTL := data.ExpiryTTL.ValueInt64()
+	recordResp, err := r.client.UpdateRecord(ctx, client.UpdateRecordRequest{
+		Zone:      zoneName,
+		Domain:    recordName,
+		TTL:       int64(ttl),
+		Current:   recordData(&oldData),
+		New:       recordData(&data),
+		Comments:  comments,
+		Disable:   knownBool(data.Disabled),
+		ExpiryTTL: &expiryTTL,
+	})
 
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -886,13 +1421,27 @@ func (r *DNSRecordResource) Update(ctx context.Context, req resource.UpdateReque
 	} else {
 		data.LastUsedOn = types.StringValue("")
 	}
+	data.ExpiresOn = types.StringValue(recordExpiresOn(recordResp.UpdatedRecord, data.ExpiryTTL))
 
 	tflog.Debug(ctx, "DNS record updated successfully", map[string]interface{}{
 		"id": data.ID.ValueString(),
 	})
+	reportOperation(ctx, r.client, "records", client.OperationUpdated)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, dnsRecordIdentity(&data))...)
+	}
+
+	if r.client.StrictConsistency() {
+		if err := r.checkConsistency(ctx, &planned, recordName); err != nil {
+			resp.Diagnostics.AddError(
+				"DNS record inconsistent with plan",
+				fmt.Sprintf("The %s record %s was updated, but the server stored different values than planned: %s", planned.Type.ValueString(), planned.Name.ValueString(), err.Error()),
+			)
+		}
+	}
 }
 
 func (r *DNSRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -904,22 +1453,16 @@ func (r *DNSRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	// Create options map for record deletion
-	options := r.buildRecordOptions(ctx, &data, "delete")
+	ctx, cancel, diags := operationContext(ctx, data.Timeouts, "delete")
+	defer cancel()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Format the name properly for Technitium DNS
-	recordName := data.Name.ValueString()
 	zoneName := data.Zone.ValueString()
-
-	// If the record name is not "@" (root), not already the zone name, and doesn't end with the zone name,
-	// we need to append the zone name to create a proper FQDN for Technitium
-	if recordName != "@" && recordName != zoneName {
-		// For short names like "www", we need to append the zone name to make "www.example.com"
-		// But don't do this if it already has a trailing dot or already includes the zone name
-		if !strings.HasSuffix(recordName, ".") && !strings.HasSuffix(recordName, "."+zoneName) && !strings.HasSuffix(recordName, zoneName) {
-			recordName = recordName + "." + zoneName
-		}
-	}
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
 
 	tflog.Debug(ctx, "Deleting DNS record", map[string]interface{}{
 		"id":             data.ID.ValueString(),
@@ -930,13 +1473,11 @@ func (r *DNSRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 	})
 
 	// Delete the record via the API
-	if err := r.client.DeleteRecord(
-		ctx,
-		zoneName,
-		recordName,
-		data.Type.ValueString(),
-		options,
-	); err != nil {
+	if err := r.client.DeleteRecord(ctx, client.DeleteRecordRequest{
+		Zone:   zoneName,
+		Domain: recordName,
+		Data:   recordData(&data),
+	}); err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting DNS record",
 			fmt.Sprintf("Could not delete %s record %s: %s", data.Type.ValueString(), data.Name.ValueString(), err.Error()),
@@ -947,11 +1488,331 @@ func (r *DNSRecordResource) Delete(ctx context.Context, req resource.DeleteReque
 	tflog.Debug(ctx, "DNS record deleted successfully", map[string]interface{}{
 		"id": data.ID.ValueString(),
 	})
+	reportOperation(ctx, r.client, "records", client.OperationDeleted)
+}
+
+// ValidateConfig reports misconfigured records at plan time instead of failing during apply
+func (r *DNSRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data DNSRecordResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() {
+		return
+	}
+
+	// FWD settings configured on other record types would be silently ignored
+	if data.Type.ValueString() != "FWD" {
+		for attribute, value := range map[string]attr.Value{
+			"protocol":           data.Protocol,
+			"forwarder":          data.Forwarder,
+			"forwarder_priority": data.ForwarderPriority,
+			"dnssec_validation":  data.DnssecValidation,
+			"proxy_type":         data.ProxyType,
+			"proxy_address":      data.ProxyAddress,
+			"proxy_port":         data.ProxyPort,
+			"proxy_username":     data.ProxyUsername,
+			"proxy_password":     data.ProxyPassword,
+		} {
+			if !value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(attribute),
+					"Invalid DNS record configuration",
+					fmt.Sprintf("%s is only valid for FWD records, not for %s records", attribute, data.Type.ValueString()),
+				)
+			}
+		}
+	}
+
+	if err := r.validateRecord(&data); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid DNS record configuration",
+			err.Error(),
+		)
+	}
+}
+
+func (r *DNSRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the record is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data DNSRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyRecordDefaults(ctx, req, resp, &data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reject features the connected server version does not support before anything is applied
+	if data.Type.ValueString() == "FWD" && data.Protocol.ValueString() == string(client.ForwarderProtocolQuic) {
+		requireServerFeature(ctx, r.client, client.FeatureQUIC, path.Root("protocol"), &resp.Diagnostics)
+	}
+
+	if data.Type.ValueString() == "SVCB" || data.Type.ValueString() == "HTTPS" {
+		requireServerFeature(ctx, r.client, client.FeatureSVCB, path.Root("type"), &resp.Diagnostics)
+	}
+
+	// Show the server-side records an overwriting create replaces, which are not part of any state
+	if req.State.Raw.IsNull() && data.AllowOverwrite.ValueBool() {
+		if warning := r.overwriteWarning(ctx, &data); warning != "" {
+			resp.Diagnostics.AddAttributeWarning(path.Root("allow_overwrite"), "Existing records will be replaced", warning)
+		}
+	}
+
+	// Warn when the TTL conflicts with the zone SOA, instead of silently rewriting it in state after apply
+	if r.client != nil && !data.TTL.IsNull() && !data.TTL.IsUnknown() && !data.Zone.IsUnknown() {
+		soa, err := r.client.GetZoneSOA(ctx, data.Zone.ValueString())
+		if err != nil {
+			// The zone may not exist yet when it is created in the same apply
+			tflog.Debug(ctx, "Skipping TTL validation, zone SOA not available", map[string]interface{}{
+				"zone":  data.Zone.ValueString(),
+				"error": err.Error(),
+			})
+			return
+		}
+
+		if warning := zoneTTLWarning(data.TTL.ValueInt64(), data.Zone.ValueString(), soa.RData); warning != "" {
+			resp.Diagnostics.AddAttributeWarning(path.Root("ttl"), "TTL outside zone SOA constraints", warning)
+		}
+	}
+}
+
+// applyRecordDefaults plans the provider's default zone and TTL for records that leave them unset.
+// A record whose inherited zone changes is replaced, like one whose zone is changed in its configuration.
+func (r *DNSRecordResource) applyRecordDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, data *DNSRecordResourceModel) {
+	var zone types.String
+	var ttl types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The defaults are unknown until the provider is configured
+	if r.client == nil {
+		return
+	}
+
+	if zone.IsNull() {
+		defaultZone := r.client.DefaultZone()
+		if defaultZone == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("zone"), "Missing Zone",
+				"The record sets no zone and the provider configures no default_zone. Set one of them.")
+			return
+		}
+
+		data.Zone = types.StringValue(defaultZone)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone"), data.Zone)...)
+
+		var stateZone types.String
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("zone"), &stateZone)...)
+			if !stateZone.Equal(data.Zone) {
+				resp.RequiresReplace = append(resp.RequiresReplace, path.Root("zone"))
+			}
+		}
+	}
+
+	if ttl.IsNull() {
+		defaultTTL, ok := r.client.DefaultTTL()
+		if !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Missing TTL",
+				"The record sets no ttl and the provider configures no default_ttl. Set one of them.")
+			return
+		}
+
+		data.TTL = types.Int64Value(defaultTTL)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), data.TTL)...)
+	}
+}
+
+// disableRecord disables a record that was just added, rewriting it with its own values
+func (r *DNSRecordResource) disableRecord(ctx context.Context, data *DNSRecordResourceModel, recordName string) error {
+	tflog.Debug(ctx, "Disabling DNS record", map[string]interface{}{
+		"zone": data.Zone.ValueString(),
+		"name": recordName,
+		"type": data.Type.ValueString(),
+	})
+
+	ttl, err := ttlToInt(data.TTL)
+	if err != nil {
+		return err
+	}
+
+	disable := true
+	expiryTTL := data.ExpiryTTL.ValueInt64()
+	_, err = r.client.UpdateRecord(ctx, client.UpdateRecordRequest{
+		Zone:      data.Zone.ValueString(),
+		Domain:    recordName,
+		TTL:       int64(ttl),
+		Current:   recordData(data),
+		New:       recordData(data),
+		Comments:  recordComments(data),
+		Disable:   &disable,
+		ExpiryTTL: &expiryTTL,
+	})
+	return err
+}
+
+// overwriteWarning reads the records a create with allow_overwrite replaces and describes their
+// current values, or returns an empty string when there is nothing to replace or the records
+// cannot be read yet (e.g. the zone is created in the same apply)
+func (r *DNSRecordResource) overwriteWarning(ctx context.Context, data *DNSRecordResourceModel) string {
+	if r.client == nil || data.Zone.IsUnknown() || data.Name.IsUnknown() || data.Type.IsUnknown() {
+		return ""
+	}
+
+	zoneName := data.Zone.ValueString()
+	recordType := data.Type.ValueString()
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
+
+	recordsResp, err := r.client.GetRecords(ctx, zoneName, recordName, false)
+	if err != nil {
+		tflog.Debug(ctx, "Skipping overwrite preview, records not available", map[string]interface{}{
+			"zone":  zoneName,
+			"name":  recordName,
+			"error": err.Error(),
+		})
+		return ""
+	}
+
+	existing := make([]string, 0)
+	for _, record := range recordsResp.Records {
+		if record.Type != recordType {
+			continue
+		}
+		existing = append(existing, fmt.Sprintf("  - %s (ttl %d)", formatRecordData(record), record.TTL))
+	}
+	if len(existing) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("allow_overwrite is set, so creating this record replaces the %d %s record(s) currently stored for %s in zone %s:\n%s\n\n"+
+		"These records are not managed by Terraform and cannot be restored by destroying this resource.",
+		len(existing), recordType, recordName, zoneName, strings.Join(existing, "\n"))
+}
+
+// recordExpiresOn returns when the server deletes record (RFC 3339), or an empty string when the
+// record does not expire. The server reports the expiry as a TTL relative to the last modification;
+// when the response leaves out the expiry TTL, the configured expiryTTL is used instead.
+func recordExpiresOn(record client.DNSRecord, expiryTTL types.Int64) string {
+	ttl := int64(record.ExpiryTTL)
+	if ttl == 0 && !expiryTTL.IsNull() && !expiryTTL.IsUnknown() {
+		ttl = expiryTTL.ValueInt64()
+	}
+	if ttl <= 0 || record.LastModified == "" {
+		return ""
+	}
+
+	lastModified, err := time.Parse(time.RFC3339Nano, record.LastModified)
+	if err != nil {
+		return ""
+	}
+	return lastModified.Add(time.Duration(ttl) * time.Second).UTC().Format(time.RFC3339)
+}
+
+// isRecordExistsError reports whether an add record error was caused by a conflicting existing record
+func isRecordExistsError(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "already exists")
+}
+
+// recordExistsDetail builds the diagnostic detail for a create that conflicts with existing records,
+// listing the existing values and how to resolve the conflict
+func (r *DNSRecordResource) recordExistsDetail(ctx context.Context, data *DNSRecordResourceModel, recordName string, createErr error) string {
+	zoneName := data.Zone.ValueString()
+	recordType := data.Type.ValueString()
+
+	guidance := fmt.Sprintf("To manage an existing record with Terraform, import it with its ID (zone:name:type[:priority][:data]). "+
+		"To replace the existing %s records instead, set allow_overwrite = true.", recordType)
+
+	recordsResp, err := r.client.GetRecords(ctx, zoneName, recordName, false)
+	if err != nil {
+		tflog.Debug(ctx, "Could not fetch conflicting records", map[string]interface{}{
+			"zone":  zoneName,
+			"name":  recordName,
+			"error": err.Error(),
+		})
+		return fmt.Sprintf("Could not create %s record %s: %s\n\n%s", recordType, data.Name.ValueString(), createErr.Error(), guidance)
+	}
+
+	existing := make([]string, 0)
+	for _, record := range recordsResp.Records {
+		if record.Type != recordType {
+			continue
+		}
+		existing = append(existing, fmt.Sprintf("  - %s (ttl %d), import ID: %s",
+			formatRecordData(record), record.TTL, recordImportID(zoneName, data.Name.ValueString(), record)))
+	}
+	if len(existing) == 0 {
+		// The conflict is with a record of another type, e.g. a CNAME at the same name
+		for _, record := range recordsResp.Records {
+			existing = append(existing, fmt.Sprintf("  - %s %s (ttl %d)", record.Type, formatRecordData(record), record.TTL))
+		}
+	}
+
+	return fmt.Sprintf("A conflicting record already exists for %s in zone %s:\n%s\n\n%s",
+		recordName, zoneName, strings.Join(existing, "\n"), guidance)
+}
+
+// recordImportID builds the resource ID of an existing record using the same scheme as Create
+func recordImportID(zoneName, name string, record client.DNSRecord) string {
+	id := []string{zoneName, name, record.Type}
+
+	switch record.Type {
+	case "MX":
+		id = append(id, strconv.Itoa(record.RData.Preference), record.RData.Exchange)
+	case "SRV":
+		id = append(id, strconv.Itoa(record.RData.Priority), record.RData.Target)
+	case "CAA":
+		id = append(id, record.RData.Tag, record.RData.Value)
+	case "SVCB", "HTTPS":
+		id = append(id, strconv.Itoa(record.RData.SvcPriority), record.RData.SvcTargetName)
+	case "SSHFP":
+		id = append(id, record.RData.Algorithm, record.RData.FingerprintType, record.RData.Fingerprint)
+	case "TLSA":
+		id = append(id, record.RData.CertificateUsage, record.RData.Selector, record.RData.MatchingType, record.RData.CertificateAssociationData)
+	case "TXT", "FWD", "APP":
+		// TXT, FWD and APP IDs do not include the record data
+	default:
+		id = append(id, formatRecordData(record))
+	}
+	return formatRecordID(id...)
+}
+
+// zoneTTLWarning describes how a record TTL conflicts with the zone SOA record, or returns an
+// empty string when it does not. The SOA minimum only bounds negative caching, so record TTLs
+// below it are fine; TTLs above the SOA expire outlive the zone on secondaries.
+func zoneTTLWarning(ttl int64, zoneName string, soa client.DNSRecordData) string {
+	if soa.Expire > 0 && ttl > int64(soa.Expire) {
+		return fmt.Sprintf("TTL %d exceeds the SOA expire value of %d seconds for zone %s. Secondaries stop serving the zone "+
+			"before cached copies of the record expire, and the server may clamp the TTL.", ttl, soa.Expire, zoneName)
+	}
+	return ""
 }
 
 func (r *DNSRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: zone:name:type[:priority][:data]
-	idParts := strings.Split(req.ID, ":")
+	// Import blocks may identify the record by its identity instead of an ID
+	if req.ID == "" && req.Identity != nil {
+		r.importStateFromIdentity(ctx, req, resp)
+	} else {
+		r.importStateFromID(ctx, req, resp)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Fill in every attribute from the server, so the import is validated and plans cleanly
+	r.importRecord(ctx, resp)
+}
+
+// importStateFromID seeds the state with the zone, name, type and values held by an import ID
+func (r *DNSRecordResource) importStateFromID(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: zone:name:type[:priority][:data], colons within values escaped as %3A
+	idParts := parseRecordID(req.ID)
 	if len(idParts) < 3 {
 		resp.Diagnostics.AddError(
 			"Invalid import ID",
@@ -966,251 +1827,663 @@ func (r *DNSRecordResource) ImportState(ctx context.Context, req resource.Import
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), idParts[1])...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), idParts[2])...)
 
-	// For MX records, priority and data may be included
-	if len(idParts) > 3 {
-		// Try to parse as priority first
-		if priority, err := strconv.ParseInt(idParts[3], 10, 64); err == nil {
+	// CAA import IDs hold the tag and the value, which may contain colons itself
+	if idParts[2] == "CAA" {
+		if len(idParts) > 4 {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tag"), idParts[3])...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data"), strings.Join(idParts[4:], ":"))...)
+		}
+		return
+	}
+
+	// SSHFP import IDs hold the algorithm, fingerprint type and fingerprint
+	if idParts[2] == "SSHFP" {
+		if len(idParts) > 5 {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("algorithm"), idParts[3])...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fingerprint_type"), idParts[4])...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("fingerprint"), idParts[5])...)
+		}
+		return
+	}
+
+	// TLSA import IDs hold the certificate usage, selector, matching type and hex association data
+	if idParts[2] == "TLSA" {
+		if len(idParts) > 6 {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("certificate_usage"), idParts[3])...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("selector"), idParts[4])...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("matching_type"), idParts[5])...)
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("certificate_association_data"), idParts[6])...)
+		}
+		return
+	}
+
+	// Records with a priority (MX, SRV, SVCB, HTTPS) hold it in front of the data
+	dataParts := idParts[3:]
+	if len(dataParts) > 0 && recordTypeUsesAttribute(idParts[2], "priority") {
+		if priority, err := strconv.ParseInt(dataParts[0], 10, 64); err == nil {
 			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("priority"), priority)...)
-		} else {
-			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data"), idParts[3])...)
+			dataParts = dataParts[1:]
 		}
 	}
 
-	if len(idParts) > 4 {
-		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data"), idParts[4])...)
+	// Unescaped colons, e.g. of IPv6 addresses in IDs written before values were escaped, are part of the data
+	if len(dataParts) > 0 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data"), strings.Join(dataParts, ":"))...)
 	}
 }
 
-// buildRecordOptions creates a map of options based on record type for API calls
-func (r *DNSRecordResource) buildRecordOptions(ctx context.Context, data *DNSRecordResourceModel, opType string) map[string]string {
-	options := make(map[string]string)
+// importStateFromIdentity imports a record identified by a structured identity. The data is taken
+// as is, so values containing colons (IPv6 addresses, URLs) need no escaping.
+func (r *DNSRecordResource) importStateFromIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity DNSRecordIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Different operation types need different parameter names
-	recordType := data.Type.ValueString()
+	if identity.Zone.ValueString() == "" || identity.Name.ValueString() == "" || identity.Type.ValueString() == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import identity",
+			"The zone, name and type identity attributes must be set to import a DNS record",
+		)
+		return
+	}
 
-	switch recordType {
-	case "A", "AAAA":
-		paramName := "ipAddress"
-		if opType == "new" {
-			paramName = "newIpAddress"
-		}
-		options[paramName] = data.Data.ValueString()
+	idParts := []string{identity.Zone.ValueString(), identity.Name.ValueString(), identity.Type.ValueString()}
+	if !identity.Priority.IsNull() {
+		idParts = append(idParts, strconv.FormatInt(identity.Priority.ValueInt64(), 10))
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("priority"), identity.Priority)...)
+	}
+	if identity.Data.ValueString() != "" {
+		idParts = append(idParts, identity.Data.ValueString())
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("data"), identity.Data)...)
+	}
+	recordID := formatRecordID(idParts...)
 
-	case "CNAME":
-		paramName := "cname"
-		if opType == "new" {
-			paramName = "newCname"
-		}
-		options[paramName] = data.Data.ValueString()
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), recordID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), identity.Zone)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), identity.Type)...)
+}
 
-	case "MX":
-		exchangeParam := "exchange"
-		preferenceParam := "preference"
+// importRecord looks up the record seeded into the import state and replaces the state with the
+// record as stored on the server. IDs matching no record or several records are rejected.
+func (r *DNSRecordResource) importRecord(ctx context.Context, resp *resource.ImportStateResponse) {
+	var data DNSRecordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		if opType == "new" {
-			exchangeParam = "newExchange"
-			preferenceParam = "newPreference"
-		}
+	matches, zone, diags := r.readRecord(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case matches == 0:
+		resp.Diagnostics.AddError(
+			"Cannot import non-existent DNS record",
+			fmt.Sprintf("No %s record %s in zone %s matches the import ID %s.", data.Type.ValueString(), data.Name.ValueString(), zone, data.ID.ValueString()),
+		)
+		return
+	case matches > 1:
+		resp.Diagnostics.AddError(
+			"Import ID matches several DNS records",
+			fmt.Sprintf("%d %s records %s in zone %s match the import ID %s. Add the record data to the import ID "+
+				"(zone:name:type:data or zone:name:type:priority:data) or import by identity to select one of them.",
+				matches, data.Type.ValueString(), data.Name.ValueString(), zone, data.ID.ValueString()),
+		)
+		return
+	}
 
-		options[exchangeParam] = data.Data.ValueString()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, dnsRecordIdentity(&data))...)
+	}
+}
+
+// checkConsistency re-reads the record from the server and returns an error describing the
+// differences when no stored record of the same type matches the planned values
+func (r *DNSRecordResource) checkConsistency(ctx context.Context, planned *DNSRecordResourceModel, recordName string) error {
+	recordsResp, err := r.client.GetRecords(ctx, planned.Zone.ValueString(), recordName, false)
+	if err != nil {
+		return fmt.Errorf("could not re-read record: %w", err)
+	}
 
-		if !data.Priority.IsNull() && !data.Priority.IsUnknown() {
-			options[preferenceParam] = strconv.FormatInt(data.Priority.ValueInt64(), 10)
+	var diffs []string
+	for _, record := range recordsResp.Records {
+		if record.Type != planned.Type.ValueString() {
+			continue
 		}
 
-	case "TXT":
-		textParam := "text"
-		if opType == "new" {
-			textParam = "newText"
+		recordDiffs := recordConsistencyDiff(planned, record)
+		if len(recordDiffs) == 0 {
+			return nil
+		}
+
+		// Report the candidate with the fewest differences
+		if diffs == nil || len(recordDiffs) < len(diffs) {
+			diffs = recordDiffs
 		}
+	}
 
-		// Handle TXT record special formatting
-		txtValue := data.Data.ValueString()
+	if diffs == nil {
+		return fmt.Errorf("no %s record found for %s", planned.Type.ValueString(), recordName)
+	}
 
-		// Remove quotes if already present in the string, Technitium API will add them if needed
-		txtValue = strings.Trim(txtValue, "\"")
+	tflog.Debug(ctx, "DNS record differs from plan", map[string]interface{}{
+		"name":  recordName,
+		"type":  planned.Type.ValueString(),
+		"diffs": diffs,
+	})
 
-		options[textParam] = txtValue
+	return fmt.Errorf("%s", strings.Join(diffs, "; "))
+}
 
-	case "PTR":
-		ptrParam := "ptrName"
-		if opType == "new" {
-			ptrParam = "newPtrName"
-		}
-		options[ptrParam] = data.Data.ValueString()
+// recordConsistencyDiff compares planned values with a record returned by the server and
+// returns a human readable description of every difference
+func recordConsistencyDiff(planned *DNSRecordResourceModel, record client.DNSRecord) []string {
+	var diffs []string
 
-	case "NS":
-		nsParam := "nameServer"
-		if opType == "new" {
-			nsParam = "newNameServer"
-		}
-		options[nsParam] = data.Data.ValueString()
+	if !planned.TTL.IsNull() && !planned.TTL.IsUnknown() && record.TTL > 0 && int64(record.TTL) != planned.TTL.ValueInt64() {
+		diffs = append(diffs, fmt.Sprintf("ttl: planned %d, server %d", planned.TTL.ValueInt64(), record.TTL))
+	}
+	if !planned.ExpiryTTL.IsNull() && !planned.ExpiryTTL.IsUnknown() && planned.ExpiryTTL.ValueInt64() != int64(record.ExpiryTTL) {
+		diffs = append(diffs, fmt.Sprintf("expiry_ttl: planned %d, server %d", planned.ExpiryTTL.ValueInt64(), record.ExpiryTTL))
+	}
 
+	var plannedData, serverData string
+	switch record.Type {
+	case "A", "AAAA":
+		plannedData, serverData = planned.Data.ValueString(), record.RData.IPAddress
+	case "CNAME":
+		plannedData, serverData = planned.Data.ValueString(), record.RData.CNAME
+	case "MX":
+		plannedData, serverData = planned.Data.ValueString(), record.RData.Exchange
+	case "TXT":
+		plannedData, serverData = strings.Trim(planned.Data.ValueString(), "\""), strings.Trim(record.RData.Text, "\"")
+	case "PTR":
+		plannedData, serverData = planned.Data.ValueString(), record.RData.PTRName
+	case "NS":
+		plannedData, serverData = planned.Data.ValueString(), record.RData.NameServer
 	case "SRV":
-		targetParam := "target"
-		priorityParam := "priority"
-		weightParam := "weight"
-		portParam := "port"
-
-		if opType == "new" {
-			targetParam = "newTarget"
-			priorityParam = "newPriority"
-			weightParam = "newWeight"
-			portParam = "newPort"
+		plannedData, serverData = planned.Data.ValueString(), record.RData.Target
+	case "FWD":
+		plannedData = planned.Data.ValueString()
+		if !planned.Forwarder.IsNull() && !planned.Forwarder.IsUnknown() {
+			plannedData = planned.Forwarder.ValueString()
+		}
+		serverData = record.RData.Forwarder
+	case "SVCB", "HTTPS":
+		plannedData, serverData = planned.Data.ValueString(), record.RData.SvcTargetName
+	case "CAA":
+		plannedData, serverData = planned.Data.ValueString(), record.RData.Value
+	case "APP":
+		plannedData, serverData = planned.Data.ValueString(), record.RData.Data
+		if appRecordDataEqual(plannedData, serverData) {
+			serverData = plannedData
 		}
+	}
+	if plannedData != serverData {
+		diffs = append(diffs, fmt.Sprintf("data: planned %q, server %q", plannedData, serverData))
+	}
 
-		options[targetParam] = data.Data.ValueString()
-
-		if !data.Priority.IsNull() && !data.Priority.IsUnknown() {
-			options[priorityParam] = strconv.FormatInt(data.Priority.ValueInt64(), 10)
+	switch record.Type {
+	case "MX":
+		if !planned.Priority.IsNull() && !planned.Priority.IsUnknown() && planned.Priority.ValueInt64() != int64(record.RData.Preference) {
+			diffs = append(diffs, fmt.Sprintf("priority: planned %d, server %d", planned.Priority.ValueInt64(), record.RData.Preference))
 		}
-
-		if !data.Weight.IsNull() && !data.Weight.IsUnknown() {
-			options[weightParam] = strconv.FormatInt(data.Weight.ValueInt64(), 10)
+	case "SRV":
+		if !planned.Priority.IsNull() && !planned.Priority.IsUnknown() && planned.Priority.ValueInt64() != int64(record.RData.Priority) {
+			diffs = append(diffs, fmt.Sprintf("priority: planned %d, server %d", planned.Priority.ValueInt64(), record.RData.Priority))
 		}
-
-		if !data.Port.IsNull() && !data.Port.IsUnknown() {
-			options[portParam] = strconv.FormatInt(data.Port.ValueInt64(), 10)
+		if !planned.Weight.IsNull() && !planned.Weight.IsUnknown() && planned.Weight.ValueInt64() != int64(record.RData.Weight) {
+			diffs = append(diffs, fmt.Sprintf("weight: planned %d, server %d", planned.Weight.ValueInt64(), record.RData.Weight))
+		}
+		if !planned.Port.IsNull() && !planned.Port.IsUnknown() && planned.Port.ValueInt64() != int64(record.RData.Port) {
+			diffs = append(diffs, fmt.Sprintf("port: planned %d, server %d", planned.Port.ValueInt64(), record.RData.Port))
 		}
-
 	case "FWD":
-		// Protocol parameter
-		protocolParam := "protocol"
-		if opType == "new" {
-			protocolParam = "newProtocol"
+		if !planned.Protocol.IsNull() && !planned.Protocol.IsUnknown() && planned.Protocol.ValueString() != record.RData.Protocol {
+			diffs = append(diffs, fmt.Sprintf("protocol: planned %q, server %q", planned.Protocol.ValueString(), record.RData.Protocol))
 		}
-		if !data.Protocol.IsNull() && !data.Protocol.IsUnknown() {
-			options[protocolParam] = data.Protocol.ValueString()
-		} else {
-			// Default to Udp if not specified
-			options[protocolParam] = "Udp"
+	case "CAA":
+		if planned.Tag.ValueString() != record.RData.Tag {
+			diffs = append(diffs, fmt.Sprintf("tag: planned %q, server %q", planned.Tag.ValueString(), record.RData.Tag))
 		}
-
-		// Forwarder parameter (required)
-		forwarderParam := "forwarder"
-		if opType == "new" {
-			forwarderParam = "newForwarder"
+		if planned.Flags.ValueInt64() != int64(record.RData.Flags.Int()) {
+			diffs = append(diffs, fmt.Sprintf("flags: planned %d, server %d", planned.Flags.ValueInt64(), record.RData.Flags.Int()))
 		}
-		if !data.Forwarder.IsNull() && !data.Forwarder.IsUnknown() {
-			options[forwarderParam] = data.Forwarder.ValueString()
-		} else {
-			// Use data field as forwarder if forwarder field is not set
-			options[forwarderParam] = data.Data.ValueString()
+	case "SVCB", "HTTPS":
+		if !planned.Priority.IsNull() && !planned.Priority.IsUnknown() && planned.Priority.ValueInt64() != int64(record.RData.SvcPriority) {
+			diffs = append(diffs, fmt.Sprintf("priority: planned %d, server %d", planned.Priority.ValueInt64(), record.RData.SvcPriority))
+		}
+		plannedParams := svcParamsValue(planned.SvcParams)
+		if client.FormatSvcParams(plannedParams) != client.FormatSvcParams(mergeSvcParams(plannedParams, record.RData.SvcParams)) {
+			diffs = append(diffs, fmt.Sprintf("svc_params: planned %q, server %q", client.FormatSvcParams(plannedParams), client.FormatSvcParams(record.RData.SvcParams)))
 		}
+	case "SSHFP":
+		if planned.Algorithm.ValueString() != record.RData.Algorithm {
+			diffs = append(diffs, fmt.Sprintf("algorithm: planned %q, server %q", planned.Algorithm.ValueString(), record.RData.Algorithm))
+		}
+		if planned.FingerprintType.ValueString() != record.RData.FingerprintType {
+			diffs = append(diffs, fmt.Sprintf("fingerprint_type: planned %q, server %q", planned.FingerprintType.ValueString(), record.RData.FingerprintType))
+		}
+		if !strings.EqualFold(planned.Fingerprint.ValueString(), record.RData.Fingerprint) {
+			diffs = append(diffs, fmt.Sprintf("fingerprint: planned %q, server %q", planned.Fingerprint.ValueString(), record.RData.Fingerprint))
+		}
+	case "TLSA":
+		if planned.CertificateUsage.ValueString() != record.RData.CertificateUsage {
+			diffs = append(diffs, fmt.Sprintf("certificate_usage: planned %q, server %q", planned.CertificateUsage.ValueString(), record.RData.CertificateUsage))
+		}
+		if planned.Selector.ValueString() != record.RData.Selector {
+			diffs = append(diffs, fmt.Sprintf("selector: planned %q, server %q", planned.Selector.ValueString(), record.RData.Selector))
+		}
+		if planned.MatchingType.ValueString() != record.RData.MatchingType {
+			diffs = append(diffs, fmt.Sprintf("matching_type: planned %q, server %q", planned.MatchingType.ValueString(), record.RData.MatchingType))
+		}
+		associationData, err := tlsaAssociationDataHex(planned.CertificateAssociationData.ValueString(), planned.Selector.ValueString(), planned.MatchingType.ValueString())
+		if err != nil || !strings.EqualFold(associationData, record.RData.CertificateAssociationData) {
+			diffs = append(diffs, fmt.Sprintf("certificate_association_data: planned %q, server %q", associationData, record.RData.CertificateAssociationData))
+		}
+	case "APP":
+		if planned.ClassPath.ValueString() != record.RData.ClassPath {
+			diffs = append(diffs, fmt.Sprintf("class_path: planned %q, server %q", planned.ClassPath.ValueString(), record.RData.ClassPath))
+		}
+	}
+
+	return diffs
+}
 
-		// Optional forwarder priority
-		if !data.ForwarderPriority.IsNull() && !data.ForwarderPriority.IsUnknown() {
-			options["forwarderPriority"] = strconv.FormatInt(data.ForwarderPriority.ValueInt64(), 10)
+// formatRecordName converts a relative record name into the FQDN expected by Technitium.
+// If the record name is not "@" (root), not already the zone name, and doesn't end with the
+// zone name, the zone name is appended (e.g. "www" becomes "www.example.com").
+func formatRecordName(recordName, zoneName string) string {
+	if recordName == "@" || recordName == zoneName {
+		return recordName
+	}
+
+	// Don't append the zone if the name has a trailing dot or already includes the zone name
+	if strings.HasSuffix(recordName, ".") || strings.HasSuffix(recordName, "."+zoneName) || strings.HasSuffix(recordName, zoneName) {
+		return recordName
+	}
+
+	return recordName + "." + zoneName
+}
+
+// wildcardNamePattern matches record names that either contain no asterisk or are wildcard
+// names with the asterisk as the whole leftmost label, as defined by RFC 4592
+var wildcardNamePattern = regexp.MustCompile(`^([^*]*|\*(\.[^*]+)?)$`)
+
+// isWildcardName reports whether a record name is a wildcard owner name such as "*" or "*.sub.example.com"
+func isWildcardName(name string) bool {
+	return name == "*" || strings.HasPrefix(name, "*.")
+}
+
+// recordData converts the type specific attributes of a record into the data sent to the API
+func recordData(data *DNSRecordResourceModel) client.RecordData {
+	switch data.Type.ValueString() {
+	case "A":
+		return client.ARecordData{IPAddress: data.Data.ValueString(), Ptr: data.CreatePtr.ValueBool(), CreatePtrZone: data.CreatePtrZone.ValueBool()}
+	case "AAAA":
+		return client.AAAARecordData{IPAddress: data.Data.ValueString(), Ptr: data.CreatePtr.ValueBool(), CreatePtrZone: data.CreatePtrZone.ValueBool()}
+	case "CNAME":
+		return client.CNAMERecordData{CNAME: data.Data.ValueString()}
+	case "MX":
+		return client.MXRecordData{Exchange: data.Data.ValueString(), Preference: knownInt64(data.Priority)}
+	case "TXT":
+		// Remove quotes if already present in the string, Technitium API will add them if needed
+		return client.TXTRecordData{Text: strings.Trim(data.Data.ValueString(), "\"")}
+	case "PTR":
+		return client.PTRRecordData{PTRName: data.Data.ValueString()}
+	case "NS":
+		return client.NSRecordData{NameServer: data.Data.ValueString()}
+	case "SRV":
+		return client.SRVRecordData{
+			Target:   data.Data.ValueString(),
+			Priority: knownInt64(data.Priority),
+			Weight:   knownInt64(data.Weight),
+			Port:     knownInt64(data.Port),
+		}
+	case "CAA":
+		// Flags default to 0 when not configured
+		return client.CAARecordData{Flags: data.Flags.ValueInt64(), Tag: data.Tag.ValueString(), Value: data.Data.ValueString()}
+	case "SVCB":
+		return client.SVCBRecordData{Priority: data.Priority.ValueInt64(), TargetName: data.Data.ValueString(), Params: svcParamsValue(data.SvcParams)}
+	case "HTTPS":
+		return client.HTTPSRecordData{Priority: data.Priority.ValueInt64(), TargetName: data.Data.ValueString(), Params: svcParamsValue(data.SvcParams)}
+	case "SSHFP":
+		return client.SSHFPRecordData{
+			Algorithm:       data.Algorithm.ValueString(),
+			FingerprintType: data.FingerprintType.ValueString(),
+			Fingerprint:     data.Fingerprint.ValueString(),
+		}
+	case "TLSA":
+		return client.TLSARecordData{
+			CertificateUsage:           data.CertificateUsage.ValueString(),
+			Selector:                   data.Selector.ValueString(),
+			MatchingType:               data.MatchingType.ValueString(),
+			CertificateAssociationData: data.CertificateAssociationData.ValueString(),
 		}
+	case "FWD":
+		fwd := client.FWDRecordData{
+			Protocol:         client.ForwarderProtocol(data.Protocol.ValueString()),
+			Forwarder:        data.Forwarder.ValueString(),
+			Priority:         knownInt64(data.ForwarderPriority),
+			DNSSECValidation: knownBool(data.DnssecValidation),
+			ProxyType:        client.ProxyType(data.ProxyType.ValueString()),
+			ProxyAddress:     data.ProxyAddress.ValueString(),
+			ProxyPort:        knownInt64(data.ProxyPort),
+			ProxyUsername:    data.ProxyUsername.ValueString(),
+			ProxyPassword:    data.ProxyPassword.ValueString(),
+		}
+		// Use data field as forwarder if forwarder field is not set
+		if data.Forwarder.IsNull() || data.Forwarder.IsUnknown() {
+			fwd.Forwarder = data.Data.ValueString()
+		}
+		return fwd
+	case "APP":
+		return client.APPRecordData{AppName: data.AppName.ValueString(), ClassPath: data.ClassPath.ValueString(), RecordData: data.Data.ValueString()}
+	}
+	return nil
+}
+
+// defaultCommenter is implemented by clients appending a default comment to record comments. It is
+// not part of client.ClientAPI so that mocked clients in unit tests do not need to expect the calls.
+type defaultCommenter interface {
+	StripDefaultComment(comments string) string
+}
+
+// configuredComments returns the comments of a record read from the server without the default
+// comment the client appends to every record mutation
+func configuredComments(c client.ClientAPI, comments string) string {
+	if commenter, ok := c.(defaultCommenter); ok {
+		return commenter.StripDefaultComment(comments)
+	}
+	return comments
+}
+
+// recordComments returns the comments of a record to send with an update, nil keeping the current comments
+func recordComments(data *DNSRecordResourceModel) *string {
+	if data.Comments.IsNull() || data.Comments.IsUnknown() {
+		return nil
+	}
+	return data.Comments.ValueStringPointer()
+}
+
+// knownInt64 returns a pointer to the value of an integer attribute, or nil when it is null or unknown
+func knownInt64(value types.Int64) *int64 {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueInt64Pointer()
+}
+
+// knownBool returns a pointer to the value of a boolean attribute, or nil when it is null or unknown
+func knownBool(value types.Bool) *bool {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueBoolPointer()
+}
 
-		// Optional DNSSEC validation
-		if !data.DnssecValidation.IsNull() && !data.DnssecValidation.IsUnknown() {
-			options["dnssecValidation"] = strconv.FormatBool(data.DnssecValidation.ValueBool())
+// validateAppRecord checks that the app referenced by an APP record is installed and that the
+// class path names one of its components able to handle APP records
+func (r *DNSRecordResource) validateAppRecord(ctx context.Context, data *DNSRecordResourceModel) error {
+	apps, err := r.client.ListApps(ctx)
+	if err != nil {
+		return fmt.Errorf("could not list installed DNS apps: %w", err)
+	}
+
+	return findAppRecordHandler(apps, data.AppName.ValueString(), data.ClassPath.ValueString())
+}
+
+// findAppRecordHandler verifies that appName is installed and provides an APP record handler at classPath
+func findAppRecordHandler(apps []client.App, appName, classPath string) error {
+	for _, app := range apps {
+		if app.Name != appName {
+			continue
 		}
 
-		// Optional proxy configuration
-		if !data.ProxyType.IsNull() && !data.ProxyType.IsUnknown() {
-			options["proxyType"] = data.ProxyType.ValueString()
+		handlers := make([]string, 0)
+		for _, dnsApp := range app.DNSApps {
+			if !dnsApp.IsAppRecordRequestHandler {
+				continue
+			}
+			if dnsApp.ClassPath == classPath {
+				return nil
+			}
+			handlers = append(handlers, dnsApp.ClassPath)
 		}
 
-		if !data.ProxyAddress.IsNull() && !data.ProxyAddress.IsUnknown() {
-			options["proxyAddress"] = data.ProxyAddress.ValueString()
+		if len(handlers) == 0 {
+			return fmt.Errorf("DNS app %q does not provide any APP record handlers", appName)
 		}
+		return fmt.Errorf("DNS app %q has no APP record handler %q, available class paths: %s", appName, classPath, strings.Join(handlers, ", "))
+	}
+
+	return fmt.Errorf("DNS app %q is not installed on the server", appName)
+}
+
+// appRecordDataEqual reports whether two APP record data values are equal. Most apps take JSON
+// record data, which is compared semantically so that whitespace and key order do not show as drift.
+func appRecordDataEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var aValue, bValue interface{}
+	if json.Unmarshal([]byte(a), &aValue) != nil || json.Unmarshal([]byte(b), &bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
 
-		if !data.ProxyPort.IsNull() && !data.ProxyPort.IsUnknown() {
-			options["proxyPort"] = strconv.FormatInt(data.ProxyPort.ValueInt64(), 10)
+// svcParamsValue converts the svc_params attribute into a plain map, treating null and unknown as empty
+func svcParamsValue(value types.Map) map[string]string {
+	params := make(map[string]string)
+	if value.IsNull() || value.IsUnknown() {
+		return params
+	}
+	for key, element := range value.Elements() {
+		if str, ok := element.(types.String); ok {
+			params[key] = str.ValueString()
 		}
+	}
+	return params
+}
 
-		if !data.ProxyUsername.IsNull() && !data.ProxyUsername.IsUnknown() {
-			options["proxyUsername"] = data.ProxyUsername.ValueString()
+// mergeSvcParams returns the service parameters stored on the server, keeping the configured value
+// of parameters the server only formats differently (e.g. "h2, h3" for "h2,h3")
+func mergeSvcParams(configured, server map[string]string) map[string]string {
+	normalize := func(value string) string {
+		return strings.ToLower(strings.ReplaceAll(value, " ", ""))
+	}
+
+	merged := make(map[string]string, len(server))
+	for key, value := range server {
+		if configuredValue, ok := configured[key]; ok && normalize(configuredValue) == normalize(value) {
+			value = configuredValue
 		}
+		merged[key] = value
+	}
+	return merged
+}
 
-		if !data.ProxyPassword.IsNull() && !data.ProxyPassword.IsUnknown() {
-			options["proxyPassword"] = data.ProxyPassword.ValueString()
+// tlsaAssociationDataHex returns the hex association data the server stores for a TLSA record.
+// Hex values are returned as is, PEM certificates are reduced to the selected certificate or
+// public key and hashed according to the matching type, as the server does when adding the record.
+func tlsaAssociationDataHex(value, selector, matchingType string) (string, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(value)))
+	if block == nil {
+		if _, err := hex.DecodeString(value); err != nil {
+			return "", fmt.Errorf("must be a hex string or a PEM encoded certificate")
 		}
+		return value, nil
 	}
 
-	// Add comments for create and update operations
-	if (opType == "create" || opType == "new") && !data.Comments.IsNull() && !data.Comments.IsUnknown() {
-		options["comments"] = data.Comments.ValueString()
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse PEM certificate: %w", err)
+	}
+
+	selected := cert.Raw
+	if selector == "SPKI" {
+		selected = cert.RawSubjectPublicKeyInfo
 	}
 
-	return options
+	switch matchingType {
+	case "SHA2-256":
+		sum := sha256.Sum256(selected)
+		selected = sum[:]
+	case "SHA2-512":
+		sum := sha512.Sum512(selected)
+		selected = sum[:]
+	}
+	return strings.ToUpper(hex.EncodeToString(selected)), nil
 }
 
-// validateRecord performs validation based on record type
-func (r *DNSRecordResource) validateRecord(data *DNSRecordResourceModel, options map[string]string) error {
+// validateRecord performs validation based on record type. Values that are not known yet, e.g.
+// references to resources created in the same apply, are not checked.
+func (r *DNSRecordResource) validateRecord(data *DNSRecordResourceModel) error {
 	recordType := data.Type.ValueString()
 
+	// SSHFP and TLSA records carry their data in dedicated attributes and FWD records may use forwarder instead
+	if recordType != "SSHFP" && recordType != "TLSA" && recordType != "FWD" && stringUnset(data.Data) {
+		return fmt.Errorf("data is required for %s records", recordType)
+	}
+
+	if data.CreatePtr.ValueBool() && recordType != "A" && recordType != "AAAA" {
+		return fmt.Errorf("create_ptr is only valid for A and AAAA records")
+	}
+	if data.CreatePtrZone.ValueBool() && !data.CreatePtr.ValueBool() {
+		return fmt.Errorf("create_ptr_zone requires create_ptr")
+	}
+
 	switch recordType {
 	case "A":
-		// Validate IPv4 address format - basic validation only
-		if !strings.Contains(data.Data.ValueString(), ".") {
-			return fmt.Errorf("invalid IPv4 address format for A record: %s", data.Data.ValueString())
+		if !data.Data.IsUnknown() {
+			if err := validateIPAddress(data.Data.ValueString(), ipFamilyIPv4); err != nil {
+				return fmt.Errorf("invalid IPv4 address for A record: %w", err)
+			}
 		}
 
 	case "AAAA":
-		// Validate IPv6 address format - basic validation only
-		if !strings.Contains(data.Data.ValueString(), ":") {
-			return fmt.Errorf("invalid IPv6 address format for AAAA record: %s", data.Data.ValueString())
+		if !data.Data.IsUnknown() {
+			if err := validateIPAddress(data.Data.ValueString(), ipFamilyIPv6); err != nil {
+				return fmt.Errorf("invalid IPv6 address for AAAA record: %w", err)
+			}
+		}
+
+	case "APP":
+		// Ensure the app handling the record is set
+		if stringUnset(data.AppName) {
+			return fmt.Errorf("app_name is required for APP records")
+		}
+		if stringUnset(data.ClassPath) {
+			return fmt.Errorf("class_path is required for APP records")
+		}
+
+	case "CAA":
+		// Ensure the property tag is set for CAA records
+		if stringUnset(data.Tag) {
+			return fmt.Errorf("tag is required for CAA records")
+		}
+
+	case "SVCB", "HTTPS":
+		// Ensure the service priority is set, 0 selects alias mode
+		if data.Priority.IsNull() {
+			return fmt.Errorf("priority is required for %s records", recordType)
+		}
+		if !data.Priority.IsUnknown() && data.Priority.ValueInt64() == 0 && !data.SvcParams.IsNull() && len(data.SvcParams.Elements()) > 0 {
+			return fmt.Errorf("svc_params must not be set for %s records in alias mode (priority 0)", recordType)
+		}
+
+	case "SSHFP":
+		// SSHFP records are defined by the algorithm, fingerprint type and fingerprint instead of data
+		if !data.Data.IsNull() && data.Data.ValueString() != "" {
+			return fmt.Errorf("data is not used for SSHFP records, set fingerprint instead")
+		}
+		if stringUnset(data.Algorithm) {
+			return fmt.Errorf("algorithm is required for SSHFP records")
+		}
+		if stringUnset(data.FingerprintType) {
+			return fmt.Errorf("fingerprint_type is required for SSHFP records")
+		}
+		if stringUnset(data.Fingerprint) {
+			return fmt.Errorf("fingerprint is required for SSHFP records")
+		}
+
+	case "TLSA":
+		// TLSA records are defined by the certificate usage, selector, matching type and association data instead of data
+		if !data.Data.IsNull() && data.Data.ValueString() != "" {
+			return fmt.Errorf("data is not used for TLSA records, set certificate_association_data instead")
+		}
+		if stringUnset(data.CertificateUsage) {
+			return fmt.Errorf("certificate_usage is required for TLSA records")
+		}
+		if stringUnset(data.Selector) {
+			return fmt.Errorf("selector is required for TLSA records")
+		}
+		if stringUnset(data.MatchingType) {
+			return fmt.Errorf("matching_type is required for TLSA records")
+		}
+		if stringUnset(data.CertificateAssociationData) {
+			return fmt.Errorf("certificate_association_data is required for TLSA records")
+		}
+		if data.CertificateAssociationData.IsUnknown() || data.Selector.IsUnknown() || data.MatchingType.IsUnknown() {
+			break
+		}
+		if _, err := tlsaAssociationDataHex(data.CertificateAssociationData.ValueString(), data.Selector.ValueString(), data.MatchingType.ValueString()); err != nil {
+			return fmt.Errorf("invalid certificate_association_data for TLSA record: %w", err)
 		}
 
 	case "MX":
 		// Ensure priority is set for MX records
-		if data.Priority.IsNull() || data.Priority.IsUnknown() {
+		if data.Priority.IsNull() {
 			return fmt.Errorf("priority is required for MX records")
 		}
 
 	case "SRV":
 		// Ensure all required fields are set for SRV records
-		if data.Priority.IsNull() || data.Priority.IsUnknown() {
+		if data.Priority.IsNull() {
 			return fmt.Errorf("priority is required for SRV records")
 		}
 
-		if data.Weight.IsNull() || data.Weight.IsUnknown() {
+		if data.Weight.IsNull() {
 			return fmt.Errorf("weight is required for SRV records")
 		}
 
-		if data.Port.IsNull() || data.Port.IsUnknown() {
+		if data.Port.IsNull() {
 			return fmt.Errorf("port is required for SRV records")
 		}
 
 	case "FWD":
 		// Ensure forwarder is set for FWD records (either in forwarder field or data field)
-		if (data.Forwarder.IsNull() || data.Forwarder.IsUnknown()) &&
-			(data.Data.IsNull() || data.Data.IsUnknown() || data.Data.ValueString() == "") {
+		if stringUnset(data.Forwarder) && stringUnset(data.Data) {
 			return fmt.Errorf("forwarder address is required for FWD records (use either 'forwarder' or 'data' field)")
 		}
+		if !data.Data.IsUnknown() && data.Data.ValueString() != "" {
+			if err := validateForwarderAddress(data.Data.ValueString()); err != nil {
+				return fmt.Errorf("invalid forwarder address for FWD record: %w", err)
+			}
+		}
 
 		// Validate protocol if specified
 		if !data.Protocol.IsNull() && !data.Protocol.IsUnknown() {
 			protocol := data.Protocol.ValueString()
-			validProtocols := []string{"Udp", "Tcp", "Tls", "Https", "Quic"}
-			valid := false
-			for _, p := range validProtocols {
-				if p == protocol {
-					valid = true
-					break
-				}
-			}
-			if !valid {
-				return fmt.Errorf("invalid protocol for FWD record: %s (must be one of: Udp, Tcp, Tls, Https, Quic)", protocol)
+			if !slices.Contains(forwarderProtocolValues, protocol) {
+				return fmt.Errorf("invalid protocol for FWD record: %s (must be one of: %s)", protocol, enumDescription(forwarderProtocolValues))
 			}
 		}
 
 		// Validate proxy type if specified
 		if !data.ProxyType.IsNull() && !data.ProxyType.IsUnknown() {
 			proxyType := data.ProxyType.ValueString()
-			validProxyTypes := []string{"NoProxy", "DefaultProxy", "Http", "Socks5"}
-			valid := false
-			for _, pt := range validProxyTypes {
-				if pt == proxyType {
-					valid = true
-					break
-				}
-			}
-			if !valid {
-				return fmt.Errorf("invalid proxy type for FWD record: %s (must be one of: NoProxy, DefaultProxy, Http, Socks5)", proxyType)
+			if !slices.Contains(proxyTypeValues, proxyType) {
+				return fmt.Errorf("invalid proxy type for FWD record: %s (must be one of: %s)", proxyType, enumDescription(proxyTypeValues))
 			}
 		}
 
@@ -1218,7 +2491,7 @@ func (r *DNSRecordResource) validateRecord(data *DNSRecordResourceModel, options
 		if !data.ProxyType.IsNull() && !data.ProxyType.IsUnknown() {
 			proxyType := data.ProxyType.ValueString()
 			if proxyType == "Http" || proxyType == "Socks5" {
-				if data.ProxyAddress.IsNull() || data.ProxyAddress.IsUnknown() || data.ProxyAddress.ValueString() == "" {
+				if stringUnset(data.ProxyAddress) {
 					return fmt.Errorf("proxy_address is required when proxy_type is %s", proxyType)
 				}
 			}
@@ -1227,3 +2500,8 @@ func (r *DNSRecordResource) validateRecord(data *DNSRecordResourceModel, options
 
 	return nil
 }
+
+// stringUnset reports whether a string attribute is null or known to be empty
+func stringUnset(value types.String) bool {
+	return value.IsNull() || (!value.IsUnknown() && value.ValueString() == "")
+}
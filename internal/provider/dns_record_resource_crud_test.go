@@ -0,0 +1,1609 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+// newMockedDNSRecordResource returns a record resource backed by a mock client and its schema
+func newMockedDNSRecordResource(t *testing.T) (*DNSRecordResource, *mocks.ClientAPI, resource.SchemaResponse) {
+	t.Helper()
+
+	m := mocks.NewClientAPI(t)
+	r := &DNSRecordResource{client: m}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	return r, m, schemaResp
+}
+
+// recordPlan builds a plan holding the given model
+func recordPlan(t *testing.T, schemaResp resource.SchemaResponse, data DNSRecordResourceModel) tfsdk.Plan {
+	t.Helper()
+
+	setRecordModelNulls(&data)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	diags := plan.Set(context.Background(), &data)
+	require.False(t, diags.HasError(), "plan diagnostics: %v", diags)
+	return plan
+}
+
+// setRecordModelNulls gives unset collection attributes a typed null, which the zero value lacks
+func setRecordModelNulls(data *DNSRecordResourceModel) {
+	if data.SvcParams.ElementType(context.Background()) == nil {
+		data.SvcParams = types.MapNull(types.StringType)
+	}
+}
+
+// recordState builds a state holding the given model
+func recordState(t *testing.T, schemaResp resource.SchemaResponse, data DNSRecordResourceModel) tfsdk.State {
+	t.Helper()
+
+	setRecordModelNulls(&data)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags := state.Set(context.Background(), &data)
+	require.False(t, diags.HasError(), "state diagnostics: %v", diags)
+	return state
+}
+
+func TestDNSRecordResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("A record", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Zone == "example.com" && record.Domain == "www.example.com" && record.TTL == 3600 &&
+				record.Data == client.ARecordData{IPAddress: "192.0.2.10"}
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 3600, DnssecStatus: "Disabled"}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("www"),
+			Type: types.StringValue("A"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("192.0.2.10"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "example.com:www:A:192.0.2.10", state.ID.ValueString())
+		require.Equal(t, int64(0), state.Priority.ValueInt64())
+		require.Equal(t, "Disabled", state.DnssecStatus.ValueString())
+		require.False(t, state.Disabled.ValueBool())
+	})
+
+	t.Run("FWD record populates computed fields", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Zone == "corp.example.com" && record.Domain == "@" && record.TTL == 300 && record.Data.RecordType() == "FWD"
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{
+				Name: "corp.example.com",
+				Type: "FWD",
+				TTL:  300,
+				RData: client.DNSRecordData{
+					Protocol:          "Udp",
+					Forwarder:         "10.0.0.53",
+					ForwarderPriority: 5,
+					DnssecValidation:  true,
+				},
+			}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone: types.StringValue("corp.example.com"),
+			Name: types.StringValue("@"),
+			Type: types.StringValue("FWD"),
+			TTL:  types.Int64Value(300),
+			Data: types.StringValue("10.0.0.53"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		// FWD IDs leave out the mutable forwarder address
+		require.Equal(t, "corp.example.com:@:FWD", state.ID.ValueString())
+		require.Equal(t, "Udp", state.Protocol.ValueString())
+		require.Equal(t, "10.0.0.53", state.Forwarder.ValueString())
+		// Unset FWD fields default to zero values before the API response is considered
+		require.Equal(t, int64(0), state.ForwarderPriority.ValueInt64())
+		require.True(t, state.ProxyType.IsNull(), "unconfigured proxy type should stay null")
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Domain == "www.example.com" && record.Data.RecordType() == "CNAME"
+		})).
+			Return(nil, errors.New("zone does not exist"))
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("www"),
+			Type: types.StringValue("CNAME"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("target.example.com"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "zone does not exist")
+	})
+
+	t.Run("record already exists", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Domain == "www.example.com" && record.Data.RecordType() == "A"
+		})).
+			Return(nil, errors.New("Cannot add record: record already exists."))
+		m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "www.example.com", Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.0.2.99"}},
+			}}, nil)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("www"),
+			Type: types.StringValue("A"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("192.0.2.10"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+
+		diagnostic := resp.Diagnostics.Errors()[0]
+		require.Equal(t, "DNS record already exists", diagnostic.Summary())
+		require.Contains(t, diagnostic.Detail(), "192.0.2.99 (ttl 300)")
+		require.Contains(t, diagnostic.Detail(), "example.com:www:A:192.0.2.99")
+		require.Contains(t, diagnostic.Detail(), "allow_overwrite = true")
+	})
+
+	t.Run("allow_overwrite sets overwrite option", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool { return record.Overwrite == true })).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:           types.StringValue("example.com"),
+			Name:           types.StringValue("www"),
+			Type:           types.StringValue("A"),
+			TTL:            types.Int64Value(3600),
+			Data:           types.StringValue("192.0.2.10"),
+			AllowOverwrite: types.BoolValue(true),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+	})
+
+	t.Run("allow_overwrite false adds a round-robin sibling", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool { return record.Overwrite == false })).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:           types.StringValue("example.com"),
+			Name:           types.StringValue("www"),
+			Type:           types.StringValue("A"),
+			TTL:            types.Int64Value(3600),
+			Data:           types.StringValue("192.0.2.11"),
+			AllowOverwrite: types.BoolValue(false),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+	})
+}
+
+func TestDNSRecordResourceCreateAPP(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordResource(t)
+
+	recordData := `{"public": ["203.0.113.10"], "private": ["10.0.0.10"]}`
+	m.On("ListApps", mock.Anything).Return([]client.App{
+		{Name: "Split Horizon", DNSApps: []client.DNSApp{{ClassPath: "SplitHorizon.SimpleAddress", IsAppRecordRequestHandler: true}}},
+	}, nil)
+	m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+		return record.Zone == "corp.example.com" && record.Domain == "app.corp.example.com" && record.TTL == 3600 &&
+			record.Data == client.APPRecordData{AppName: "Split Horizon", ClassPath: "SplitHorizon.SimpleAddress", RecordData: recordData}
+	})).
+		Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "app.corp.example.com", Type: "APP", TTL: 3600}}, nil)
+	m.On("StrictConsistency").Return(false)
+
+	req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+		Zone:      types.StringValue("corp.example.com"),
+		Name:      types.StringValue("app"),
+		Type:      types.StringValue("APP"),
+		TTL:       types.Int64Value(3600),
+		Data:      types.StringValue(recordData),
+		AppName:   types.StringValue("Split Horizon"),
+		ClassPath: types.StringValue("SplitHorizon.SimpleAddress"),
+	})}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+	var state DNSRecordResourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	// APP IDs leave out the free-form record data
+	require.Equal(t, "corp.example.com:app:APP", state.ID.ValueString())
+}
+
+func TestDNSRecordResourceReadAPP(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordResource(t)
+
+	configured := `{"public":["203.0.113.10"],"private":["10.0.0.10"]}`
+	m.On("GetRecords", mock.Anything, "corp.example.com", "app.corp.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "app.corp.example.com", Type: "APP", TTL: 3600, RData: client.DNSRecordData{
+				AppName: "Split Horizon", ClassPath: "SplitHorizon.SimpleAddress", Data: "{\r\n  \"public\": [\"203.0.113.10\"],\r\n  \"private\": [\"10.0.0.10\"]\r\n}",
+			}},
+		}}, nil)
+
+	req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+		ID:        types.StringValue("corp.example.com:app:APP"),
+		Zone:      types.StringValue("corp.example.com"),
+		Name:      types.StringValue("app"),
+		Type:      types.StringValue("APP"),
+		TTL:       types.Int64Value(3600),
+		Data:      types.StringValue(configured),
+		AppName:   types.StringValue("Split Horizon"),
+		ClassPath: types.StringValue("SplitHorizon.SimpleAddress"),
+	})}
+	resp := resource.ReadResponse{State: req.State}
+	r.Read(context.Background(), req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var state DNSRecordResourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, configured, state.Data.ValueString(), "reformatted JSON should not show as drift")
+	require.Equal(t, "SplitHorizon.SimpleAddress", state.ClassPath.ValueString())
+}
+
+func TestDNSRecordResourceRead(t *testing.T) {
+	t.Parallel()
+
+	t.Run("TXT record trims quotes", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "txt.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "txt.example.com", Type: "A", TTL: 60, RData: client.DNSRecordData{IPAddress: "192.0.2.1"}},
+				{Name: "txt.example.com", Type: "TXT", TTL: 120, RData: client.DNSRecordData{Text: "\"v=spf1 -all\""}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:txt:TXT"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("txt"),
+			Type: types.StringValue("TXT"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("v=spf1 -all"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "v=spf1 -all", state.Data.ValueString())
+		require.Equal(t, int64(120), state.TTL.ValueInt64())
+	})
+
+	t.Run("reports out-of-band modifications", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "www.example.com", Type: "A", TTL: 60, Disabled: true, LastUsedOn: "2024-02-01T20:15:08Z", RData: client.DNSRecordData{IPAddress: "192.0.2.10"}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:         types.StringValue("example.com:www:A:192.0.2.10"),
+			Zone:       types.StringValue("example.com"),
+			Name:       types.StringValue("www"),
+			Type:       types.StringValue("A"),
+			TTL:        types.Int64Value(3600),
+			Data:       types.StringValue("192.0.2.10"),
+			Disabled:   types.BoolValue(false),
+			LastUsedOn: types.StringValue("2024-01-01T00:00:00Z"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		require.Len(t, resp.Diagnostics.Warnings(), 1)
+		detail := resp.Diagnostics.Warnings()[0].Detail()
+		require.Contains(t, detail, "disabled: state false, server true")
+		require.Contains(t, detail, "ttl: state 3600, server 60")
+		require.NotContains(t, detail, "last_used_on")
+	})
+
+	t.Run("redacts sensitive values in out-of-band modifications", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "corp.example.com", "@", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "corp.example.com", Type: "FWD", TTL: 300, RData: client.DNSRecordData{
+					Forwarder: "192.0.2.53", ProxyType: "Socks5", ProxyAddress: "proxy.example.com", ProxyPassword: "server-secret",
+				}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:            types.StringValue("corp.example.com:@:FWD"),
+			Zone:          types.StringValue("corp.example.com"),
+			Name:          types.StringValue("@"),
+			Type:          types.StringValue("FWD"),
+			TTL:           types.Int64Value(300),
+			Forwarder:     types.StringValue("192.0.2.53"),
+			ProxyType:     types.StringValue("Socks5"),
+			ProxyAddress:  types.StringValue("proxy.example.com"),
+			ProxyPassword: types.StringValue("state-secret"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		require.Len(t, resp.Diagnostics.Warnings(), 1)
+		detail := resp.Diagnostics.Warnings()[0].Detail()
+		require.Contains(t, detail, "proxy_password: (sensitive value) changed")
+		require.NotContains(t, detail, "secret")
+	})
+
+	t.Run("does not warn about unchanged records", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "www.example.com", Type: "A", TTL: 3600, RData: client.DNSRecordData{IPAddress: "192.0.2.10"}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:www:A:192.0.2.10"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("www"),
+			Type: types.StringValue("A"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("192.0.2.10"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Empty(t, resp.Diagnostics.Warnings())
+	})
+
+	t.Run("MX record matches priority and exchange", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "@", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "example.com", Type: "MX", TTL: 3600, RData: client.DNSRecordData{Preference: 10, Exchange: "mx1.example.com"}},
+				{Name: "example.com", Type: "MX", TTL: 3600, RData: client.DNSRecordData{Preference: 20, Exchange: "mx2.example.com"}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:@:MX:20:mx2.example.com"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("@"),
+			Type: types.StringValue("MX"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "mx2.example.com", state.Data.ValueString())
+		require.Equal(t, int64(20), state.Priority.ValueInt64())
+	})
+
+	t.Run("round-robin A record matches the data in state", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "www.example.com", Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.0.2.10"}},
+				{Name: "www.example.com", Type: "A", TTL: 600, RData: client.DNSRecordData{IPAddress: "192.0.2.11"}},
+			}}, nil)
+
+		// The ID still holds the data from before an update
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:www:A:192.0.2.12"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("www"),
+			Type: types.StringValue("A"),
+			Data: types.StringValue("192.0.2.11"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "192.0.2.11", state.Data.ValueString())
+		require.Equal(t, int64(600), state.TTL.ValueInt64())
+	})
+
+	t.Run("round-robin TXT record matches the data in state", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "@", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "example.com", Type: "TXT", TTL: 300, RData: client.DNSRecordData{Text: "v=spf1 -all"}},
+				{Name: "example.com", Type: "TXT", TTL: 600, RData: client.DNSRecordData{Text: "google-site-verification=abc"}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:@:TXT"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("@"),
+			Type: types.StringValue("TXT"),
+			Data: types.StringValue("google-site-verification=abc"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, int64(600), state.TTL.ValueInt64())
+	})
+
+	t.Run("NS and SRV records match their target", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "sub.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "sub.example.com", Type: "NS", TTL: 300, RData: client.DNSRecordData{NameServer: "ns1.example.net"}},
+				{Name: "sub.example.com", Type: "NS", TTL: 600, RData: client.DNSRecordData{NameServer: "ns2.example.net"}},
+			}}, nil)
+		m.On("GetRecords", mock.Anything, "example.com", "_sip._tcp.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 300, RData: client.DNSRecordData{Priority: 10, Weight: 5, Port: 5060, Target: "sip1.example.com"}},
+				{Name: "_sip._tcp.example.com", Type: "SRV", TTL: 600, RData: client.DNSRecordData{Priority: 10, Weight: 5, Port: 5060, Target: "sip2.example.com"}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:sub:NS:ns2.example.net"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("sub"),
+			Type: types.StringValue("NS"),
+			Data: types.StringValue("ns2.example.net."),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, int64(600), state.TTL.ValueInt64())
+
+		req = resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:       types.StringValue("example.com:_sip._tcp:SRV:10:sip2.example.com"),
+			Zone:     types.StringValue("example.com"),
+			Name:     types.StringValue("_sip._tcp"),
+			Type:     types.StringValue("SRV"),
+			Data:     types.StringValue("sip2.example.com"),
+			Priority: types.Int64Value(10),
+			Weight:   types.Int64Value(5),
+			Port:     types.Int64Value(5060),
+		})}
+		resp = resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, int64(600), state.TTL.ValueInt64())
+	})
+
+	t.Run("missing record is removed from state", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "gone.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:gone:A:192.0.2.10"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("gone"),
+			Type: types.StringValue("A"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.True(t, resp.State.Raw.IsNull(), "missing record should be removed from state")
+	})
+}
+
+// defaultCommentClient is a mocked client with a default comment, stripped like *client.Client does
+type defaultCommentClient struct {
+	*mocks.ClientAPI
+	comments *client.Client
+}
+
+func (c defaultCommentClient) StripDefaultComment(comments string) string {
+	return c.comments.StripDefaultComment(comments)
+}
+
+func TestDNSRecordResourceDefaultComment(t *testing.T) {
+	t.Parallel()
+
+	commenter, err := client.NewClient(client.Config{Host: "http://localhost:5380", Token: "test-token", DefaultComment: "managed by terraform"})
+	require.NoError(t, err)
+
+	for name, tt := range map[string]struct {
+		configured types.String
+		server     string
+	}{
+		"with comments":    {configured: types.StringValue("web server"), server: "web server managed by terraform"},
+		"without comments": {configured: types.StringNull(), server: "managed by terraform"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, m, schemaResp := newMockedDNSRecordResource(t)
+			r := &DNSRecordResource{client: defaultCommentClient{ClientAPI: m, comments: commenter}}
+
+			m.On("AddRecord", mock.Anything, mock.Anything).
+				Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 3600, Comments: tt.server}}, nil)
+			m.On("StrictConsistency").Return(false)
+			m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+				Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+					{Name: "www.example.com", Type: "A", TTL: 3600, Comments: tt.server, RData: client.DNSRecordData{IPAddress: "192.0.2.10"}},
+				}}, nil)
+
+			createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			r.Create(context.Background(), resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+				Zone:     types.StringValue("example.com"),
+				Name:     types.StringValue("www"),
+				Type:     types.StringValue("A"),
+				TTL:      types.Int64Value(3600),
+				Data:     types.StringValue("192.0.2.10"),
+				Comments: tt.configured,
+			})}, &createResp)
+			require.False(t, createResp.Diagnostics.HasError(), "create diagnostics: %v", createResp.Diagnostics)
+
+			// The default comment the server stored does not show up as drift
+			readResp := resource.ReadResponse{State: createResp.State}
+			r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, &readResp)
+			require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+
+			var state DNSRecordResourceModel
+			require.False(t, readResp.State.Get(context.Background(), &state).HasError())
+			require.True(t, tt.configured.Equal(state.Comments), "comments: %s", state.Comments)
+			require.Empty(t, readResp.Diagnostics.Warnings())
+		})
+	}
+}
+
+func TestDNSRecordResourceUpdate(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordResource(t)
+
+	m.On("UpdateRecord", mock.Anything, mock.MatchedBy(func(record client.UpdateRecordRequest) bool {
+		return record.Zone == "example.com" && record.Domain == "www.example.com" && record.TTL == 600 &&
+			record.Current == client.ARecordData{IPAddress: "192.0.2.10"} && record.New == client.ARecordData{IPAddress: "192.0.2.20"}
+	})).
+		Return(&client.UpdateRecordResponse{UpdatedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 600}}, nil)
+	m.On("StrictConsistency").Return(false)
+
+	prior := DNSRecordResourceModel{
+		ID:   types.StringValue("example.com:www:A:192.0.2.10"),
+		Zone: types.StringValue("example.com"),
+		Name: types.StringValue("www"),
+		Type: types.StringValue("A"),
+		TTL:  types.Int64Value(3600),
+		Data: types.StringValue("192.0.2.10"),
+	}
+	planned := prior
+	planned.TTL = types.Int64Value(600)
+	planned.Data = types.StringValue("192.0.2.20")
+
+	req := resource.UpdateRequest{
+		Plan:  recordPlan(t, schemaResp, planned),
+		State: recordState(t, schemaResp, prior),
+	}
+	resp := resource.UpdateResponse{State: req.State}
+	r.Update(context.Background(), req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "update diagnostics: %v", resp.Diagnostics)
+
+	var state DNSRecordResourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "192.0.2.20", state.Data.ValueString())
+	require.Equal(t, int64(600), state.TTL.ValueInt64())
+}
+
+func TestDNSRecordResourceUpdateRemovedComments(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordResource(t)
+
+	// Removing the comments from the configuration clears them on the server
+	m.On("UpdateRecord", mock.Anything, mock.MatchedBy(func(record client.UpdateRecordRequest) bool {
+		return record.Comments != nil && *record.Comments == ""
+	})).
+		Return(&client.UpdateRecordResponse{UpdatedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 3600}}, nil)
+	m.On("StrictConsistency").Return(false)
+	m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "www.example.com", Type: "A", TTL: 3600, RData: client.DNSRecordData{IPAddress: "192.0.2.10"}},
+		}}, nil)
+
+	prior := DNSRecordResourceModel{
+		ID:       types.StringValue("example.com:www:A:192.0.2.10"),
+		Zone:     types.StringValue("example.com"),
+		Name:     types.StringValue("www"),
+		Type:     types.StringValue("A"),
+		TTL:      types.Int64Value(3600),
+		Data:     types.StringValue("192.0.2.10"),
+		Comments: types.StringValue("web server"),
+	}
+	planned := prior
+	planned.Comments = types.StringNull()
+
+	req := resource.UpdateRequest{
+		Plan:  recordPlan(t, schemaResp, planned),
+		State: recordState(t, schemaResp, prior),
+	}
+	resp := resource.UpdateResponse{State: req.State}
+	r.Update(context.Background(), req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "update diagnostics: %v", resp.Diagnostics)
+
+	// The next refresh reads no comments back, so there is no drift
+	readResp := resource.ReadResponse{State: resp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: resp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+
+	var state DNSRecordResourceModel
+	require.False(t, readResp.State.Get(context.Background(), &state).HasError())
+	require.True(t, state.Comments.IsNull(), "comments: %s", state.Comments)
+}
+
+func TestDNSRecordResourceDisabled(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create disabled", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Domain == "www.example.com" && record.Data.RecordType() == "A"
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 3600}}, nil)
+		m.On("UpdateRecord", mock.Anything, mock.MatchedBy(func(record client.UpdateRecordRequest) bool {
+			return record.Current == client.ARecordData{IPAddress: "192.0.2.10"} && record.New == client.ARecordData{IPAddress: "192.0.2.10"} &&
+				record.Disable != nil && *record.Disable
+		})).
+			Return(&client.UpdateRecordResponse{UpdatedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 3600, Disabled: true}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:     types.StringValue("example.com"),
+			Name:     types.StringValue("www"),
+			Type:     types.StringValue("A"),
+			TTL:      types.Int64Value(3600),
+			Data:     types.StringValue("192.0.2.10"),
+			Disabled: types.BoolValue(true),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.True(t, state.Disabled.ValueBool())
+	})
+
+	t.Run("enable on update", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("UpdateRecord", mock.Anything, mock.MatchedBy(func(record client.UpdateRecordRequest) bool {
+			return record.Disable != nil && !*record.Disable
+		})).
+			Return(&client.UpdateRecordResponse{UpdatedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		prior := DNSRecordResourceModel{
+			ID:       types.StringValue("example.com:www:A:192.0.2.10"),
+			Zone:     types.StringValue("example.com"),
+			Name:     types.StringValue("www"),
+			Type:     types.StringValue("A"),
+			TTL:      types.Int64Value(3600),
+			Data:     types.StringValue("192.0.2.10"),
+			Disabled: types.BoolValue(true),
+		}
+		planned := prior
+		planned.Disabled = types.BoolValue(false)
+
+		req := resource.UpdateRequest{
+			Plan:  recordPlan(t, schemaResp, planned),
+			State: recordState(t, schemaResp, prior),
+		}
+		resp := resource.UpdateResponse{State: req.State}
+		r.Update(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "update diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.False(t, state.Disabled.ValueBool())
+	})
+}
+
+func TestDNSRecordResourceExpiry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("update sets expiry", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("UpdateRecord", mock.Anything, mock.MatchedBy(func(record client.UpdateRecordRequest) bool {
+			return record.Domain == "_acme-challenge.example.com" && record.ExpiryTTL != nil && *record.ExpiryTTL == 86400
+		})).
+			Return(&client.UpdateRecordResponse{UpdatedRecord: client.DNSRecord{
+				Name: "_acme-challenge.example.com", Type: "TXT", TTL: 300,
+				LastModified: "2026-10-17T08:00:00.1234567Z", ExpiryTTL: 86400,
+			}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		prior := DNSRecordResourceModel{
+			ID:        types.StringValue("example.com:_acme-challenge:TXT"),
+			Zone:      types.StringValue("example.com"),
+			Name:      types.StringValue("_acme-challenge"),
+			Type:      types.StringValue("TXT"),
+			TTL:       types.Int64Value(300),
+			Data:      types.StringValue("token"),
+			ExpiresOn: types.StringValue(""),
+		}
+		planned := prior
+		planned.ExpiryTTL = types.Int64Value(86400)
+		planned.ExpiresOn = types.StringUnknown()
+
+		req := resource.UpdateRequest{
+			Plan:  recordPlan(t, schemaResp, planned),
+			State: recordState(t, schemaResp, prior),
+		}
+		resp := resource.UpdateResponse{State: req.State}
+		r.Update(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "update diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, int64(86400), state.ExpiryTTL.ValueInt64())
+		require.Equal(t, "2026-10-18T08:00:00Z", state.ExpiresOn.ValueString())
+	})
+
+	t.Run("update clears removed expiry", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("UpdateRecord", mock.Anything, mock.MatchedBy(func(record client.UpdateRecordRequest) bool {
+			return record.Domain == "ns1.example.com" && record.ExpiryTTL != nil && *record.ExpiryTTL == 0
+		})).
+			Return(&client.UpdateRecordResponse{UpdatedRecord: client.DNSRecord{Name: "ns1.example.com", Type: "A", TTL: 300}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		prior := DNSRecordResourceModel{
+			ID:        types.StringValue("example.com:ns1:A:192.0.2.53"),
+			Zone:      types.StringValue("example.com"),
+			Name:      types.StringValue("ns1"),
+			Type:      types.StringValue("A"),
+			TTL:       types.Int64Value(300),
+			Data:      types.StringValue("192.0.2.53"),
+			ExpiryTTL: types.Int64Value(3600),
+			ExpiresOn: types.StringValue("2026-10-17T09:00:00Z"),
+		}
+		planned := prior
+		planned.ExpiryTTL = types.Int64Null()
+		planned.ExpiresOn = types.StringUnknown()
+
+		req := resource.UpdateRequest{
+			Plan:  recordPlan(t, schemaResp, planned),
+			State: recordState(t, schemaResp, prior),
+		}
+		resp := resource.UpdateResponse{State: req.State}
+		r.Update(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "update diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.True(t, state.ExpiryTTL.IsNull())
+		require.Equal(t, "", state.ExpiresOn.ValueString())
+	})
+
+	t.Run("read surfaces expiry set outside terraform", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "tmp.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "tmp.example.com", Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.0.2.7"},
+					LastModified: "2026-10-17T08:00:00Z", ExpiryTTL: 600},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:tmp:A:192.0.2.7"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("tmp"),
+			Type: types.StringValue("A"),
+			Data: types.StringValue("192.0.2.7"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, int64(600), state.ExpiryTTL.ValueInt64())
+		require.Equal(t, "2026-10-17T08:10:00Z", state.ExpiresOn.ValueString())
+	})
+}
+
+func TestDNSRecordResourceDelete(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordResource(t)
+
+	m.On("DeleteRecord", mock.Anything, mock.MatchedBy(func(record client.DeleteRecordRequest) bool {
+		return record.Zone == "example.com" && record.Domain == "www.example.com" &&
+			record.Data == client.CNAMERecordData{CNAME: "target.example.com"}
+	})).
+		Return(nil)
+
+	req := resource.DeleteRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+		ID:   types.StringValue("example.com:www:CNAME:target.example.com"),
+		Zone: types.StringValue("example.com"),
+		Name: types.StringValue("www"),
+		Type: types.StringValue("CNAME"),
+		TTL:  types.Int64Value(3600),
+		Data: types.StringValue("target.example.com"),
+	})}
+	resp := resource.DeleteResponse{State: req.State}
+	r.Delete(context.Background(), req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "delete diagnostics: %v", resp.Diagnostics)
+}
+
+func TestDNSRecordResourceModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordResource(t)
+
+	m.On("GetZoneSOA", mock.Anything, "example.com").
+		Return(&client.DNSRecord{Name: "example.com", Type: "SOA", RData: client.DNSRecordData{Minimum: 300, Expire: 604800}}, nil)
+
+	plan := recordPlan(t, schemaResp, DNSRecordResourceModel{
+		Zone: types.StringValue("example.com"),
+		Name: types.StringValue("www"),
+		Type: types.StringValue("A"),
+		TTL:  types.Int64Value(700000),
+		Data: types.StringValue("192.0.2.10"),
+	})
+	req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}, Plan: plan}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), req, &resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	require.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "exceeds the SOA expire value")
+}
+
+func TestDNSRecordResourceModifyPlanOverwrite(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordResource(t)
+
+	m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "www.example.com", Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.0.2.1"}},
+			{Name: "www.example.com", Type: "TXT", TTL: 300, RData: client.DNSRecordData{Text: "v=spf1 -all"}},
+		}}, nil)
+	m.On("GetZoneSOA", mock.Anything, "example.com").Return(nil, errors.New("zone not found"))
+
+	plan := recordPlan(t, schemaResp, DNSRecordResourceModel{
+		Zone:           types.StringValue("example.com"),
+		Name:           types.StringValue("www"),
+		Type:           types.StringValue("A"),
+		TTL:            types.Int64Value(300),
+		Data:           types.StringValue("192.0.2.10"),
+		AllowOverwrite: types.BoolValue(true),
+	})
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}, Plan: plan}, &resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	require.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "replaces the 1 A record(s)")
+	require.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "192.0.2.1 (ttl 300)")
+	require.NotContains(t, resp.Diagnostics.Warnings()[0].Detail(), "v=spf1")
+}
+
+func TestDNSRecordResourceModifyPlanDefaults(t *testing.T) {
+	t.Parallel()
+
+	modifyPlan := func(t *testing.T, r *DNSRecordResource, schemaResp resource.SchemaResponse, prior *DNSRecordResourceModel) resource.ModifyPlanResponse {
+		t.Helper()
+
+		config := recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone: types.StringNull(),
+			Name: types.StringValue("www"),
+			Type: types.StringValue("TXT"),
+			TTL:  types.Int64Null(),
+			Data: types.StringValue("hello"),
+		})
+		req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}, Plan: config}
+		req.State = tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}
+		if prior != nil {
+			req.State = recordState(t, schemaResp, *prior)
+		}
+
+		resp := resource.ModifyPlanResponse{Plan: config}
+		r.ModifyPlan(context.Background(), req, &resp)
+		return resp
+	}
+
+	t.Run("inherits the provider defaults", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+		m.On("DefaultZone").Return("example.com")
+		m.On("DefaultTTL").Return(int64(600), true)
+		m.On("GetZoneSOA", mock.Anything, "example.com").Return(nil, errors.New("zone not found"))
+
+		resp := modifyPlan(t, r, schemaResp, nil)
+		require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+		require.Empty(t, resp.RequiresReplace)
+
+		var planned DNSRecordResourceModel
+		require.False(t, resp.Plan.Get(context.Background(), &planned).HasError())
+		require.Equal(t, "example.com", planned.Zone.ValueString())
+		require.Equal(t, int64(600), planned.TTL.ValueInt64())
+	})
+
+	t.Run("replaces the record when the default zone changes", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+		m.On("DefaultZone").Return("example.net")
+		m.On("DefaultTTL").Return(int64(600), true)
+		m.On("GetZoneSOA", mock.Anything, "example.net").Return(nil, errors.New("zone not found"))
+
+		resp := modifyPlan(t, r, schemaResp, &DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:www:TXT"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("www"),
+			Type: types.StringValue("TXT"),
+			TTL:  types.Int64Value(600),
+			Data: types.StringValue("hello"),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+		require.Equal(t, path.Paths{path.Root("zone")}, resp.RequiresReplace)
+	})
+
+	t.Run("requires a zone without a default", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+		m.On("DefaultZone").Return("")
+
+		resp := modifyPlan(t, r, schemaResp, nil)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Missing Zone", resp.Diagnostics.Errors()[0].Summary())
+	})
+
+	t.Run("requires a TTL without a default", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+		m.On("DefaultZone").Return("example.com")
+		m.On("DefaultTTL").Return(int64(0), false)
+
+		resp := modifyPlan(t, r, schemaResp, nil)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Missing TTL", resp.Diagnostics.Errors()[0].Summary())
+	})
+}
+
+func TestDNSRecordResourceWildcard(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create zone wildcard", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Domain == "*.example.com" && record.Data == client.ARecordData{IPAddress: "192.0.2.10"}
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "*.example.com", Type: "A", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("*"),
+			Type: types.StringValue("A"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("192.0.2.10"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "example.com:*:A:192.0.2.10", state.ID.ValueString())
+		require.Equal(t, "*", state.Name.ValueString())
+	})
+
+	t.Run("read subdomain wildcard", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "*.sub.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "*.sub.example.com", Type: "CNAME", TTL: 300, RData: client.DNSRecordData{CNAME: "lb.example.com"}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:*.sub:CNAME:lb.example.com"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("*.sub"),
+			Type: types.StringValue("CNAME"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("lb.example.com"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "*.sub", state.Name.ValueString())
+		require.Equal(t, int64(300), state.TTL.ValueInt64())
+	})
+
+	t.Run("delete subdomain wildcard", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("DeleteRecord", mock.Anything, mock.MatchedBy(func(record client.DeleteRecordRequest) bool {
+			return record.Domain == "*.sub.example.com" && record.Data == client.TXTRecordData{Text: "catch-all"}
+		})).
+			Return(nil)
+
+		req := resource.DeleteRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:*.sub:TXT"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("*.sub"),
+			Type: types.StringValue("TXT"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("catch-all"),
+		})}
+		resp := resource.DeleteResponse{State: req.State}
+		r.Delete(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "delete diagnostics: %v", resp.Diagnostics)
+	})
+}
+
+func TestDNSRecordResourceCAA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Domain == "@" && record.Data == client.CAARecordData{Flags: 0, Tag: "iodef", Value: "mailto:security@example.com"}
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "example.com", Type: "CAA", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("@"),
+			Type: types.StringValue("CAA"),
+			TTL:  types.Int64Value(3600),
+			Tag:  types.StringValue("iodef"),
+			Data: types.StringValue("mailto:security@example.com"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "example.com:@:CAA:iodef:mailto%3Asecurity@example.com", state.ID.ValueString())
+		require.True(t, state.Flags.IsNull(), "unconfigured flags should stay null")
+	})
+
+	t.Run("create requires tag", func(t *testing.T) {
+		r, _, schemaResp := newMockedDNSRecordResource(t)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("@"),
+			Type: types.StringValue("CAA"),
+			TTL:  types.Int64Value(3600),
+			Data: types.StringValue("letsencrypt.org"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "tag is required for CAA records")
+	})
+
+	t.Run("read matches tag and value", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "@", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "example.com", Type: "CAA", TTL: 3600, RData: client.DNSRecordData{Flags: "0", Tag: "issue", Value: "letsencrypt.org"}},
+				{Name: "example.com", Type: "CAA", TTL: 3600, RData: client.DNSRecordData{Flags: "128", Tag: "iodef", Value: "mailto:security@example.com"}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:@:CAA:iodef:mailto:security@example.com"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("@"),
+			Type: types.StringValue("CAA"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "iodef", state.Tag.ValueString())
+		require.Equal(t, "mailto:security@example.com", state.Data.ValueString())
+		require.Equal(t, int64(128), state.Flags.ValueInt64())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("UpdateRecord", mock.Anything, mock.MatchedBy(func(record client.UpdateRecordRequest) bool {
+			return record.Domain == "@" &&
+				record.Current == client.CAARecordData{Flags: 0, Tag: "issue", Value: "letsencrypt.org"} &&
+				record.New == client.CAARecordData{Flags: 128, Tag: "issue", Value: "pki.goog"}
+		})).
+			Return(&client.UpdateRecordResponse{UpdatedRecord: client.DNSRecord{Name: "example.com", Type: "CAA", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		prior := DNSRecordResourceModel{
+			ID:   types.StringValue("example.com:@:CAA:issue:letsencrypt.org"),
+			Zone: types.StringValue("example.com"),
+			Name: types.StringValue("@"),
+			Type: types.StringValue("CAA"),
+			TTL:  types.Int64Value(3600),
+			Tag:  types.StringValue("issue"),
+			Data: types.StringValue("letsencrypt.org"),
+		}
+		planned := prior
+		planned.Data = types.StringValue("pki.goog")
+		planned.Flags = types.Int64Value(128)
+
+		req := resource.UpdateRequest{
+			Plan:  recordPlan(t, schemaResp, planned),
+			State: recordState(t, schemaResp, prior),
+		}
+		resp := resource.UpdateResponse{State: req.State}
+		r.Update(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "update diagnostics: %v", resp.Diagnostics)
+	})
+}
+
+func TestDNSRecordResourceSSHFP(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Domain == "host.example.com" &&
+				record.Data == client.SSHFPRecordData{Algorithm: "Ed25519", FingerprintType: "SHA256", Fingerprint: "4e7d3f0a9c"}
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "host.example.com", Type: "SSHFP", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:            types.StringValue("example.com"),
+			Name:            types.StringValue("host"),
+			Type:            types.StringValue("SSHFP"),
+			TTL:             types.Int64Value(3600),
+			Algorithm:       types.StringValue("Ed25519"),
+			FingerprintType: types.StringValue("SHA256"),
+			Fingerprint:     types.StringValue("4e7d3f0a9c"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "example.com:host:SSHFP:Ed25519:SHA256:4e7d3f0a9c", state.ID.ValueString())
+		require.True(t, state.Data.IsNull())
+	})
+
+	t.Run("create requires fingerprint", func(t *testing.T) {
+		r, _, schemaResp := newMockedDNSRecordResource(t)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:            types.StringValue("example.com"),
+			Name:            types.StringValue("host"),
+			Type:            types.StringValue("SSHFP"),
+			TTL:             types.Int64Value(3600),
+			Algorithm:       types.StringValue("RSA"),
+			FingerprintType: types.StringValue("SHA1"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "fingerprint is required for SSHFP records")
+	})
+
+	t.Run("create rejects data", func(t *testing.T) {
+		r, _, schemaResp := newMockedDNSRecordResource(t)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:            types.StringValue("example.com"),
+			Name:            types.StringValue("host"),
+			Type:            types.StringValue("SSHFP"),
+			TTL:             types.Int64Value(3600),
+			Data:            types.StringValue("4e7d3f0a9c"),
+			Algorithm:       types.StringValue("RSA"),
+			FingerprintType: types.StringValue("SHA1"),
+			Fingerprint:     types.StringValue("4e7d3f0a9c"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "data is not used for SSHFP records")
+	})
+
+	t.Run("read matches fingerprint ignoring case", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "host.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "host.example.com", Type: "SSHFP", TTL: 3600, RData: client.DNSRecordData{Algorithm: "RSA", FingerprintType: "SHA256", Fingerprint: "0A1B2C"}},
+				{Name: "host.example.com", Type: "SSHFP", TTL: 3600, RData: client.DNSRecordData{Algorithm: "Ed25519", FingerprintType: "SHA256", Fingerprint: "4E7D3F0A9C"}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:          types.StringValue("example.com:host:SSHFP:Ed25519:SHA256:4e7d3f0a9c"),
+			Zone:        types.StringValue("example.com"),
+			Name:        types.StringValue("host"),
+			Type:        types.StringValue("SSHFP"),
+			Fingerprint: types.StringValue("4e7d3f0a9c"),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "Ed25519", state.Algorithm.ValueString())
+		require.Equal(t, "SHA256", state.FingerprintType.ValueString())
+		require.Equal(t, "4e7d3f0a9c", state.Fingerprint.ValueString(), "configured case should be kept")
+		require.True(t, state.Data.IsNull())
+	})
+}
+
+func TestDNSRecordResourceTLSA(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.Domain == "_25._tcp.mail.example.com" && record.Data == client.TLSARecordData{
+				CertificateUsage: "DANE-EE", Selector: "SPKI", MatchingType: "SHA2-256", CertificateAssociationData: "8d02536c88",
+			}
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "_25._tcp.mail.example.com", Type: "TLSA", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:                       types.StringValue("example.com"),
+			Name:                       types.StringValue("_25._tcp.mail"),
+			Type:                       types.StringValue("TLSA"),
+			TTL:                        types.Int64Value(3600),
+			CertificateUsage:           types.StringValue("DANE-EE"),
+			Selector:                   types.StringValue("SPKI"),
+			MatchingType:               types.StringValue("SHA2-256"),
+			CertificateAssociationData: types.StringValue("8d02536c88"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "example.com:_25._tcp.mail:TLSA:DANE-EE:SPKI:SHA2-256:8d02536c88", state.ID.ValueString())
+		require.True(t, state.Data.IsNull())
+	})
+
+	t.Run("create requires matching type", func(t *testing.T) {
+		r, _, schemaResp := newMockedDNSRecordResource(t)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:                       types.StringValue("example.com"),
+			Name:                       types.StringValue("_443._tcp.www"),
+			Type:                       types.StringValue("TLSA"),
+			TTL:                        types.Int64Value(3600),
+			CertificateUsage:           types.StringValue("DANE-TA"),
+			Selector:                   types.StringValue("Cert"),
+			CertificateAssociationData: types.StringValue("8d02536c88"),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "matching_type is required for TLSA records")
+	})
+
+	t.Run("read keeps configured PEM certificate", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		certPEM, cert := testTLSACertificate(t)
+		digest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+		serverData := strings.ToUpper(hex.EncodeToString(digest[:]))
+
+		m.On("GetRecords", mock.Anything, "example.com", "_443._tcp.www.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "_443._tcp.www.example.com", Type: "TLSA", TTL: 3600, RData: client.DNSRecordData{CertificateUsage: "DANE-EE", Selector: "SPKI", MatchingType: "SHA2-256", CertificateAssociationData: "0A1B2C"}},
+				{Name: "_443._tcp.www.example.com", Type: "TLSA", TTL: 3600, RData: client.DNSRecordData{CertificateUsage: "DANE-EE", Selector: "SPKI", MatchingType: "SHA2-256", CertificateAssociationData: serverData}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:                         types.StringValue("example.com:_443._tcp.www:TLSA:DANE-EE:SPKI:SHA2-256:" + serverData),
+			Zone:                       types.StringValue("example.com"),
+			Name:                       types.StringValue("_443._tcp.www"),
+			Type:                       types.StringValue("TLSA"),
+			CertificateUsage:           types.StringValue("DANE-EE"),
+			Selector:                   types.StringValue("SPKI"),
+			MatchingType:               types.StringValue("SHA2-256"),
+			CertificateAssociationData: types.StringValue(certPEM),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, certPEM, state.CertificateAssociationData.ValueString(), "configured certificate should be kept")
+		require.True(t, state.Data.IsNull())
+	})
+}
+
+func TestDNSRecordResourceHTTPS(t *testing.T) {
+	t.Parallel()
+
+	svcParams := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"alpn":     types.StringValue("h2,h3"),
+		"ipv4hint": types.StringValue("192.0.2.1"),
+	})
+
+	t.Run("create", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			data, ok := record.Data.(client.HTTPSRecordData)
+			return ok && data.Priority == 1 && data.TargetName == "." &&
+				client.FormatSvcParams(data.Params) == "alpn|h2,h3|ipv4hint|192.0.2.1"
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "example.com", Type: "HTTPS", TTL: 3600}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:      types.StringValue("example.com"),
+			Name:      types.StringValue("@"),
+			Type:      types.StringValue("HTTPS"),
+			TTL:       types.Int64Value(3600),
+			Priority:  types.Int64Value(1),
+			Data:      types.StringValue("."),
+			SvcParams: svcParams,
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "example.com:@:HTTPS:1:.", state.ID.ValueString())
+	})
+
+	t.Run("alias mode rejects params", func(t *testing.T) {
+		r, _, schemaResp := newMockedDNSRecordResource(t)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:      types.StringValue("example.com"),
+			Name:      types.StringValue("@"),
+			Type:      types.StringValue("HTTPS"),
+			TTL:       types.Int64Value(3600),
+			Priority:  types.Int64Value(0),
+			Data:      types.StringValue("cdn.example.net"),
+			SvcParams: svcParams,
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "alias mode")
+	})
+
+	t.Run("read matches priority and target", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "svc.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "svc.example.com", Type: "SVCB", TTL: 3600, RData: client.DNSRecordData{SvcPriority: 2, SvcTargetName: "backup.example.com"}},
+				{Name: "svc.example.com", Type: "SVCB", TTL: 3600, RData: client.DNSRecordData{
+					SvcPriority:   1,
+					SvcTargetName: "primary.example.com",
+					SvcParams:     map[string]string{"alpn": "h2, h3", "port": "8443"},
+				}},
+			}}, nil)
+
+		req := resource.ReadRequest{State: recordState(t, schemaResp, DNSRecordResourceModel{
+			ID:        types.StringValue("example.com:svc:SVCB:1:primary.example.com"),
+			Zone:      types.StringValue("example.com"),
+			Name:      types.StringValue("svc"),
+			Type:      types.StringValue("SVCB"),
+			SvcParams: types.MapValueMust(types.StringType, map[string]attr.Value{"alpn": types.StringValue("h2,h3")}),
+		})}
+		resp := resource.ReadResponse{State: req.State}
+		r.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "primary.example.com", state.Data.ValueString())
+		require.Equal(t, int64(1), state.Priority.ValueInt64())
+		require.Equal(t, map[string]string{"alpn": "h2,h3", "port": "8443"}, svcParamsValue(state.SvcParams),
+			"configured formatting should be kept and server-only params added")
+	})
+}
+
+func TestDNSRecordResourceCreatePtr(t *testing.T) {
+	t.Parallel()
+
+	t.Run("requests the reverse record", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+
+		m.On("AddRecord", mock.Anything, mock.MatchedBy(func(record client.AddRecordRequest) bool {
+			return record.TTL == 300 && record.Data == client.ARecordData{IPAddress: "192.0.2.10", Ptr: true, CreatePtrZone: true}
+		})).
+			Return(&client.AddRecordResponse{AddedRecord: client.DNSRecord{Name: "www.example.com", Type: "A", TTL: 300}}, nil)
+		m.On("StrictConsistency").Return(false)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:          types.StringValue("example.com"),
+			Name:          types.StringValue("www"),
+			Type:          types.StringValue("A"),
+			TTL:           types.Int64Value(300),
+			Data:          types.StringValue("192.0.2.10"),
+			CreatePtr:     types.BoolValue(true),
+			CreatePtrZone: types.BoolValue(true),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+	})
+
+	t.Run("rejects other record types", func(t *testing.T) {
+		r, _, schemaResp := newMockedDNSRecordResource(t)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:      types.StringValue("example.com"),
+			Name:      types.StringValue("www"),
+			Type:      types.StringValue("CNAME"),
+			TTL:       types.Int64Value(300),
+			Data:      types.StringValue("web.example.com"),
+			CreatePtr: types.BoolValue(true),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "create_ptr is only valid for A and AAAA records")
+	})
+
+	t.Run("rejects create_ptr_zone without create_ptr", func(t *testing.T) {
+		r, _, schemaResp := newMockedDNSRecordResource(t)
+
+		req := resource.CreateRequest{Plan: recordPlan(t, schemaResp, DNSRecordResourceModel{
+			Zone:          types.StringValue("example.com"),
+			Name:          types.StringValue("www"),
+			Type:          types.StringValue("A"),
+			TTL:           types.Int64Value(300),
+			Data:          types.StringValue("192.0.2.10"),
+			CreatePtrZone: types.BoolValue(true),
+		})}
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "create_ptr_zone requires create_ptr")
+	})
+}
+
+func TestDNSRecordResourceImportState(t *testing.T) {
+	t.Parallel()
+
+	importID := func(t *testing.T, id string, records ...client.DNSRecord) (DNSRecordResourceModel, diag.Diagnostics) {
+		t.Helper()
+
+		r, m, schemaResp := newMockedDNSRecordResource(t)
+		m.On("GetRecords", mock.Anything, "example.com", mock.Anything, false).
+			Return(&client.GetRecordsResponse{Records: records}, nil)
+
+		resp := resource.ImportStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)},
+		}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+
+		var state DNSRecordResourceModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		}
+		return state, resp.Diagnostics
+	}
+
+	t.Run("populates attributes from the server", func(t *testing.T) {
+		state, diags := importID(t, "example.com:www:A:192.0.2.11",
+			client.DNSRecord{Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.0.2.10"}},
+			client.DNSRecord{Type: "A", TTL: 600, Comments: "web server", RData: client.DNSRecordData{IPAddress: "192.0.2.11"}},
+		)
+		require.False(t, diags.HasError(), "import diagnostics: %v", diags)
+		require.Equal(t, "192.0.2.11", state.Data.ValueString())
+		require.Equal(t, int64(600), state.TTL.ValueInt64())
+		require.Equal(t, "web server", state.Comments.ValueString())
+		require.False(t, state.Disabled.ValueBool())
+	})
+
+	t.Run("populates FWD fields", func(t *testing.T) {
+		state, diags := importID(t, "example.com:@:FWD",
+			client.DNSRecord{Type: "FWD", TTL: 300, RData: client.DNSRecordData{
+				Protocol: "Tls", Forwarder: "1.1.1.1", ForwarderPriority: 5, DnssecValidation: true,
+			}},
+		)
+		require.False(t, diags.HasError(), "import diagnostics: %v", diags)
+		require.Equal(t, "1.1.1.1", state.Forwarder.ValueString())
+		require.Equal(t, "Tls", state.Protocol.ValueString())
+		require.Equal(t, int64(5), state.ForwarderPriority.ValueInt64())
+		require.True(t, state.DnssecValidation.ValueBool())
+		require.True(t, state.Comments.IsNull())
+	})
+
+	t.Run("rejects missing records", func(t *testing.T) {
+		_, diags := importID(t, "example.com:www:A:192.0.2.12",
+			client.DNSRecord{Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.0.2.10"}},
+		)
+		require.True(t, diags.HasError())
+		require.Contains(t, diags.Errors()[0].Summary(), "non-existent")
+	})
+
+	t.Run("rejects ambiguous IDs", func(t *testing.T) {
+		_, diags := importID(t, "example.com:www:A",
+			client.DNSRecord{Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.0.2.10"}},
+			client.DNSRecord{Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.0.2.11"}},
+		)
+		require.True(t, diags.HasError())
+		require.Contains(t, diags.Errors()[0].Detail(), "2 A records")
+	})
+}
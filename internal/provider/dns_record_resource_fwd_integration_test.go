@@ -16,7 +16,7 @@ func TestAccDNSRecordResource_FWD(t *testing.T) {
 
 	// Setup test container
 	config := setupTestContainer(t)
-	zoneName := "testfwdrecord.example.com"
+	zoneName := sweepZonePrefix + "fwdrecord.example.com"
 	recordName := "forward"
 
 	resource.Test(t, resource.TestCase{
@@ -62,7 +62,7 @@ func TestAccDNSRecordResource_FWD_Advanced(t *testing.T) {
 
 	// Setup test container
 	config := setupTestContainer(t)
-	zoneName := "testfwdadvanced.example.com"
+	zoneName := sweepZonePrefix + "fwdadvanced.example.com"
 	recordName := "advanced"
 
 	resource.Test(t, resource.TestCase{
@@ -88,6 +88,81 @@ func TestAccDNSRecordResource_FWD_Advanced(t *testing.T) {
 	})
 }
 
+func TestAccDNSRecordResource_FWD_Lifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	// Setup test container
+	config := setupTestContainer(t)
+	zoneName := sweepZonePrefix + "fwdlifecycle.example.com"
+	recordName := "lifecycle"
+	dohForwarder := "https://cloudflare-dns.com/dns-query"
+	socksProxy := `
+  proxy_type    = "Socks5"
+  proxy_address = "192.0.2.50"
+  proxy_port    = 1080`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"technitium": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		CheckDestroy: testAccCheckDNSRecordDestroy(config),
+		Steps: []resource.TestStep{
+			// Plain UDP forwarder without a proxy
+			{
+				Config: testAccDNSRecordConfig_FWDLifecycle(config, zoneName, recordName, "8.8.8.8", "Udp", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordExists(config, "technitium_dns_record.test"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "protocol", "Udp"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "forwarder", "8.8.8.8"),
+					resource.TestCheckNoResourceAttr("technitium_dns_record.test", "proxy_type"),
+				),
+			},
+			// Switch the protocol and the forwarder to DNS-over-HTTPS in place
+			{
+				Config: testAccDNSRecordConfig_FWDLifecycle(config, zoneName, recordName, dohForwarder, "Https", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordExists(config, "technitium_dns_record.test"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "protocol", "Https"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "forwarder", dohForwarder),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "data", dohForwarder),
+				),
+			},
+			// Add a SOCKS5 proxy
+			{
+				Config: testAccDNSRecordConfig_FWDLifecycle(config, zoneName, recordName, dohForwarder, "Https", socksProxy),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordExists(config, "technitium_dns_record.test"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "protocol", "Https"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "proxy_type", "Socks5"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "proxy_address", "192.0.2.50"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "proxy_port", "1080"),
+				),
+			},
+			// Remove the proxy again
+			{
+				Config: testAccDNSRecordConfig_FWDLifecycle(config, zoneName, recordName, dohForwarder, "Https", `
+  proxy_type = "NoProxy"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordExists(config, "technitium_dns_record.test"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "proxy_type", "NoProxy"),
+					resource.TestCheckNoResourceAttr("technitium_dns_record.test", "proxy_address"),
+				),
+			},
+			// Back to a plain UDP forwarder
+			{
+				Config: testAccDNSRecordConfig_FWDLifecycle(config, zoneName, recordName, "1.1.1.1", "Udp", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDNSRecordExists(config, "technitium_dns_record.test"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "protocol", "Udp"),
+					resource.TestCheckResourceAttr("technitium_dns_record.test", "forwarder", "1.1.1.1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccDNSRecordConfig_FWD(config *testAccConfig, zoneName, recordName, forwarder, protocol string) string {
 	return config.getProviderConfig() + fmt.Sprintf(`
 resource "technitium_zone" "test_zone" {
@@ -131,3 +206,24 @@ resource "technitium_dns_record" "test" {
 }
 `, zoneName, recordName)
 }
+
+func testAccDNSRecordConfig_FWDLifecycle(config *testAccConfig, zoneName, recordName, forwarder, protocol, proxy string) string {
+	return config.getProviderConfig() + fmt.Sprintf(`
+resource "technitium_zone" "test_zone" {
+  name = "%s"
+  type = "Forwarder"
+  forwarder = "8.8.8.8"
+  protocol = "Udp"
+}
+
+resource "technitium_dns_record" "test" {
+  zone      = technitium_zone.test_zone.name
+  name      = "%s"
+  type      = "FWD"
+  ttl       = 3600
+  data      = "%s"
+  protocol  = "%s"
+  forwarder = "%s"%s
+}
+`, zoneName, recordName, forwarder, protocol, forwarder, proxy)
+}
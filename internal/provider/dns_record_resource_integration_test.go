@@ -21,7 +21,7 @@ func TestAccDNSRecordResource_A(t *testing.T) {
 
 	// Setup test container
 	config := setupTestContainer(t)
-	zoneName := "testarecord.example.com"
+	zoneName := sweepZonePrefix + "arecord.example.com"
 	recordName := "www"
 
 	resource.Test(t, resource.TestCase{
@@ -53,9 +53,9 @@ func TestAccDNSRecordResource_CNAME(t *testing.T) {
 
 	// Setup test container
 	config := setupTestContainer(t)
-	zoneName := "testcnamerecord.example.com"
+	zoneName := sweepZonePrefix + "cnamerecord.example.com"
 	recordName := "blog"
-	targetName := "www.testcnamerecord.example.com"
+	targetName := "www." + zoneName
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
@@ -85,9 +85,9 @@ func TestAccDNSRecordResource_MX(t *testing.T) {
 
 	// Setup test container
 	config := setupTestContainer(t)
-	zoneName := "testmxrecord.example.com"
-	recordName := "testmxrecord.example.com" // Use the zone name for root domain records
-	exchangeName := "mail.testmxrecord.example.com"
+	zoneName := sweepZonePrefix + "mxrecord.example.com"
+	recordName := zoneName // Use the zone name for root domain records
+	exchangeName := "mail." + zoneName
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
@@ -118,7 +118,7 @@ func TestAccDNSRecordResource_TXT(t *testing.T) {
 
 	// Setup test container
 	config := setupTestContainer(t)
-	zoneName := "testtxtrecord.example.com"
+	zoneName := sweepZonePrefix + "txtrecord.example.com"
 	recordName := "_spf"
 	txtValue := "v=spf1 include:_spf.google.com ~all"
 
@@ -172,9 +172,9 @@ func TestAccDNSRecordResource_SRV(t *testing.T) {
 
 	// Setup test container
 	config := setupTestContainer(t)
-	zoneName := "testsrvrecord.example.com"
+	zoneName := sweepZonePrefix + "srvrecord.example.com"
 	recordName := "_sip._tcp"
-	targetName := "sip.testsrvrecord.example.com"
+	targetName := "sip." + zoneName
 
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
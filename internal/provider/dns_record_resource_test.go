@@ -2,10 +2,29 @@ package provider
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
 )
 
 func TestDNSRecordResource(t *testing.T) {
@@ -127,9 +146,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Type: types.StringValue("A"),
 				Data: types.StringValue("192.168.1.1"),
 			}
-			options := map[string]string{"ipAddress": "192.168.1.1"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err != nil {
 				t.Errorf("Expected no error for valid A record, got: %v", err)
 			}
@@ -140,9 +157,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Type: types.StringValue("A"),
 				Data: types.StringValue("invalid-ip"),
 			}
-			options := map[string]string{"ipAddress": "invalid-ip"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err == nil {
 				t.Error("Expected error for invalid A record, got nil")
 			}
@@ -155,9 +170,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Data: types.StringValue("mail.example.com"),
 				// Priority is missing
 			}
-			options := map[string]string{"exchange": "mail.example.com"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err == nil {
 				t.Error("Expected error for MX record without priority, got nil")
 			}
@@ -169,12 +182,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Data:     types.StringValue("mail.example.com"),
 				Priority: types.Int64Value(10),
 			}
-			options := map[string]string{
-				"exchange":   "mail.example.com",
-				"preference": "10",
-			}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err != nil {
 				t.Errorf("Expected no error for valid MX record, got: %v", err)
 			}
@@ -188,12 +196,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Priority: types.Int64Value(10),
 				// Weight and Port are missing
 			}
-			options := map[string]string{
-				"target":   "sip.example.com",
-				"priority": "10",
-			}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err == nil {
 				t.Error("Expected error for SRV record with missing fields, got nil")
 			}
@@ -207,14 +210,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Weight:   types.Int64Value(5),
 				Port:     types.Int64Value(5060),
 			}
-			options := map[string]string{
-				"target":   "sip.example.com",
-				"priority": "10",
-				"weight":   "5",
-				"port":     "5060",
-			}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err != nil {
 				t.Errorf("Expected no error for valid SRV record, got: %v", err)
 			}
@@ -226,9 +222,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Type: types.StringValue("FWD"),
 				// Both forwarder and data are missing
 			}
-			options := map[string]string{}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err == nil {
 				t.Error("Expected error for FWD record without forwarder, got nil")
 			}
@@ -239,9 +233,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Type: types.StringValue("FWD"),
 				Data: types.StringValue("8.8.8.8"),
 			}
-			options := map[string]string{"forwarder": "8.8.8.8"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err != nil {
 				t.Errorf("Expected no error for valid FWD record with data field, got: %v", err)
 			}
@@ -252,9 +244,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Type:      types.StringValue("FWD"),
 				Forwarder: types.StringValue("8.8.8.8"),
 			}
-			options := map[string]string{"forwarder": "8.8.8.8"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err != nil {
 				t.Errorf("Expected no error for valid FWD record with forwarder field, got: %v", err)
 			}
@@ -266,9 +256,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Data:     types.StringValue("8.8.8.8"),
 				Protocol: types.StringValue("Invalid"),
 			}
-			options := map[string]string{"forwarder": "8.8.8.8"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err == nil {
 				t.Error("Expected error for FWD record with invalid protocol, got nil")
 			}
@@ -280,9 +268,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Data:     types.StringValue("8.8.8.8"),
 				Protocol: types.StringValue("Https"),
 			}
-			options := map[string]string{"forwarder": "8.8.8.8"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err != nil {
 				t.Errorf("Expected no error for valid FWD record with valid protocol, got: %v", err)
 			}
@@ -294,9 +280,7 @@ func TestDNSRecordResource(t *testing.T) {
 				Data:      types.StringValue("8.8.8.8"),
 				ProxyType: types.StringValue("InvalidProxy"),
 			}
-			options := map[string]string{"forwarder": "8.8.8.8"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err == nil {
 				t.Error("Expected error for FWD record with invalid proxy type, got nil")
 			}
@@ -309,9 +293,7 @@ func TestDNSRecordResource(t *testing.T) {
 				ProxyType: types.StringValue("Http"),
 				// ProxyAddress is missing
 			}
-			options := map[string]string{"forwarder": "8.8.8.8"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err == nil {
 				t.Error("Expected error for FWD record with Http proxy type but missing address, got nil")
 			}
@@ -325,35 +307,27 @@ func TestDNSRecordResource(t *testing.T) {
 				ProxyAddress: types.StringValue("proxy.example.com"),
 				ProxyPort:    types.Int64Value(8080),
 			}
-			options := map[string]string{"forwarder": "8.8.8.8"}
-
-			err := r.validateRecord(data, options)
+			err := r.validateRecord(data)
 			if err != nil {
 				t.Errorf("Expected no error for valid FWD record with proxy, got: %v", err)
 			}
 		})
 	})
 
-	// Test the buildRecordOptions function
-	t.Run("BuildRecordOptions", func(t *testing.T) {
-		r := &DNSRecordResource{}
-		ctx := context.Background()
-
+	// Test the recordData function
+	t.Run("RecordData", func(t *testing.T) {
 		// Test A record
-		t.Run("A Record Options", func(t *testing.T) {
+		t.Run("A Record Data", func(t *testing.T) {
 			data := &DNSRecordResourceModel{
 				Type: types.StringValue("A"),
 				Data: types.StringValue("192.168.1.1"),
 			}
 
-			options := r.buildRecordOptions(ctx, data, "create")
-			if ip, ok := options["ipAddress"]; !ok || ip != "192.168.1.1" {
-				t.Errorf("Expected ipAddress=192.168.1.1, got %v", options)
-			}
+			require.Equal(t, client.ARecordData{IPAddress: "192.168.1.1"}, recordData(data))
 		})
 
 		// Test MX record
-		t.Run("MX Record Options", func(t *testing.T) {
+		t.Run("MX Record Data", func(t *testing.T) {
 			data := &DNSRecordResourceModel{
 				Type:     types.StringValue("MX"),
 				Data:     types.StringValue("mail.example.com"),
@@ -361,78 +335,42 @@ func TestDNSRecordResource(t *testing.T) {
 				Comments: types.StringValue("Mail server"),
 			}
 
-			options := r.buildRecordOptions(ctx, data, "create")
-			if exchange, ok := options["exchange"]; !ok || exchange != "mail.example.com" {
-				t.Errorf("Expected exchange=mail.example.com, got %v", options)
-			}
-			if preference, ok := options["preference"]; !ok || preference != "10" {
-				t.Errorf("Expected preference=10, got %v", options)
-			}
-			if comments, ok := options["comments"]; !ok || comments != "Mail server" {
-				t.Errorf("Expected comments='Mail server', got %v", options)
-			}
+			preference := int64(10)
+			require.Equal(t, client.MXRecordData{Exchange: "mail.example.com", Preference: &preference}, recordData(data))
 		})
 
-		// Test update operation (new values)
-		t.Run("Update Options", func(t *testing.T) {
+		t.Run("TXT Record Data Without Quotes", func(t *testing.T) {
 			data := &DNSRecordResourceModel{
-				Type: types.StringValue("A"),
-				Data: types.StringValue("192.168.1.2"),
+				Type: types.StringValue("TXT"),
+				Data: types.StringValue(`"v=spf1 -all"`),
 			}
 
-			options := r.buildRecordOptions(ctx, data, "new")
-			if ip, ok := options["newIpAddress"]; !ok || ip != "192.168.1.2" {
-				t.Errorf("Expected newIpAddress=192.168.1.2, got %v", options)
-			}
+			require.Equal(t, client.TXTRecordData{Text: "v=spf1 -all"}, recordData(data))
 		})
 
-		// Test FWD record options
-		t.Run("FWD Record Options Basic", func(t *testing.T) {
+		// Test FWD record data
+		t.Run("FWD Record Data Basic", func(t *testing.T) {
 			data := &DNSRecordResourceModel{
 				Type:     types.StringValue("FWD"),
 				Data:     types.StringValue("8.8.8.8"),
 				Protocol: types.StringValue("Https"),
 			}
 
-			options := r.buildRecordOptions(ctx, data, "create")
-			if forwarder, ok := options["forwarder"]; !ok || forwarder != "8.8.8.8" {
-				t.Errorf("Expected forwarder=8.8.8.8, got %v", options)
-			}
-			if protocol, ok := options["protocol"]; !ok || protocol != "Https" {
-				t.Errorf("Expected protocol=Https, got %v", options)
-			}
+			require.Equal(t, client.FWDRecordData{Protocol: client.ForwarderProtocolHttps, Forwarder: "8.8.8.8"}, recordData(data))
 		})
 
-		t.Run("FWD Record Options with Forwarder Field", func(t *testing.T) {
+		t.Run("FWD Record Data with Forwarder Field", func(t *testing.T) {
 			data := &DNSRecordResourceModel{
 				Type:      types.StringValue("FWD"),
+				Data:      types.StringValue("8.8.8.8"),
 				Forwarder: types.StringValue("1.1.1.1"),
 				Protocol:  types.StringValue("Tls"),
 			}
 
-			options := r.buildRecordOptions(ctx, data, "create")
-			if forwarder, ok := options["forwarder"]; !ok || forwarder != "1.1.1.1" {
-				t.Errorf("Expected forwarder=1.1.1.1, got %v", options)
-			}
-			if protocol, ok := options["protocol"]; !ok || protocol != "Tls" {
-				t.Errorf("Expected protocol=Tls, got %v", options)
-			}
-		})
-
-		t.Run("FWD Record Options Default Protocol", func(t *testing.T) {
-			data := &DNSRecordResourceModel{
-				Type: types.StringValue("FWD"),
-				Data: types.StringValue("8.8.8.8"),
-				// No protocol specified
-			}
-
-			options := r.buildRecordOptions(ctx, data, "create")
-			if protocol, ok := options["protocol"]; !ok || protocol != "Udp" {
-				t.Errorf("Expected default protocol=Udp, got %v", options)
-			}
+			require.Equal(t, client.FWDRecordData{Protocol: client.ForwarderProtocolTls, Forwarder: "1.1.1.1"}, recordData(data))
 		})
 
-		t.Run("FWD Record Options with All Fields", func(t *testing.T) {
+		t.Run("FWD Record Data with All Fields", func(t *testing.T) {
 			data := &DNSRecordResourceModel{
 				Type:              types.StringValue("FWD"),
 				Forwarder:         types.StringValue("8.8.8.8"),
@@ -446,41 +384,489 @@ func TestDNSRecordResource(t *testing.T) {
 				ProxyPassword:     types.StringValue("pass"),
 			}
 
-			options := r.buildRecordOptions(ctx, data, "create")
+			priority, port, dnssecValidation := int64(10), int64(8080), true
+			require.Equal(t, client.FWDRecordData{
+				Protocol:         client.ForwarderProtocolHttps,
+				Forwarder:        "8.8.8.8",
+				Priority:         &priority,
+				DNSSECValidation: &dnssecValidation,
+				ProxyType:        client.ProxyType("Http"),
+				ProxyAddress:     "proxy.example.com",
+				ProxyPort:        &port,
+				ProxyUsername:    "user",
+				ProxyPassword:    "pass",
+			}, recordData(data))
+		})
+	})
+}
+
+func TestFormatRecordName(t *testing.T) {
+	t.Parallel()
 
-			expectedOptions := map[string]string{
-				"forwarder":         "8.8.8.8",
-				"protocol":          "Https",
-				"forwarderPriority": "10",
-				"dnssecValidation":  "true",
-				"proxyType":         "Http",
-				"proxyAddress":      "proxy.example.com",
-				"proxyPort":         "8080",
-				"proxyUsername":     "user",
-				"proxyPassword":     "pass",
+	tests := []struct {
+		name     string
+		record   string
+		zone     string
+		expected string
+	}{
+		{"Apex", "@", "example.com", "@"},
+		{"Zone name", "example.com", "example.com", "example.com"},
+		{"Relative name", "www", "example.com", "www.example.com"},
+		{"Already qualified", "www.example.com", "example.com", "www.example.com"},
+		{"Trailing dot", "www.other.com.", "example.com", "www.other.com."},
+		{"Zone wildcard", "*", "example.com", "*.example.com"},
+		{"Subdomain wildcard", "*.sub", "example.com", "*.sub.example.com"},
+		{"Qualified wildcard", "*.example.com", "example.com", "*.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := formatRecordName(tt.record, tt.zone); actual != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, actual)
 			}
+		})
+	}
+}
+
+func TestRecordConsistencyDiff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Matching A record", func(t *testing.T) {
+		planned := &DNSRecordResourceModel{
+			Type: types.StringValue("A"),
+			TTL:  types.Int64Value(300),
+			Data: types.StringValue("192.168.1.1"),
+		}
+		record := client.DNSRecord{Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.168.1.1"}}
+
+		if diffs := recordConsistencyDiff(planned, record); len(diffs) != 0 {
+			t.Errorf("Expected no differences, got: %v", diffs)
+		}
+	})
+
+	t.Run("Normalized AAAA record", func(t *testing.T) {
+		planned := &DNSRecordResourceModel{
+			Type: types.StringValue("AAAA"),
+			TTL:  types.Int64Value(300),
+			Data: types.StringValue("2001:0db8::0001"),
+		}
+		record := client.DNSRecord{Type: "AAAA", TTL: 600, RData: client.DNSRecordData{IPAddress: "2001:db8::1"}}
+
+		diffs := recordConsistencyDiff(planned, record)
+		if len(diffs) != 2 {
+			t.Fatalf("Expected 2 differences, got: %v", diffs)
+		}
+		if !strings.HasPrefix(diffs[0], "ttl:") || !strings.HasPrefix(diffs[1], "data:") {
+			t.Errorf("Unexpected differences: %v", diffs)
+		}
+	})
+
+	t.Run("TXT quotes ignored", func(t *testing.T) {
+		planned := &DNSRecordResourceModel{
+			Type: types.StringValue("TXT"),
+			TTL:  types.Int64Value(300),
+			Data: types.StringValue("v=spf1 -all"),
+		}
+		record := client.DNSRecord{Type: "TXT", TTL: 300, RData: client.DNSRecordData{Text: "\"v=spf1 -all\""}}
+
+		if diffs := recordConsistencyDiff(planned, record); len(diffs) != 0 {
+			t.Errorf("Expected no differences, got: %v", diffs)
+		}
+	})
 
-			for key, expected := range expectedOptions {
-				if actual, ok := options[key]; !ok || actual != expected {
-					t.Errorf("Expected %s=%s, got %s=%s", key, expected, key, actual)
+	t.Run("SRV fields", func(t *testing.T) {
+		planned := &DNSRecordResourceModel{
+			Type:     types.StringValue("SRV"),
+			TTL:      types.Int64Value(300),
+			Data:     types.StringValue("sip.example.com"),
+			Priority: types.Int64Value(10),
+			Weight:   types.Int64Value(5),
+			Port:     types.Int64Value(5060),
+		}
+		record := client.DNSRecord{Type: "SRV", TTL: 300, RData: client.DNSRecordData{Target: "sip.example.com", Priority: 10, Weight: 5, Port: 5061}}
+
+		diffs := recordConsistencyDiff(planned, record)
+		if len(diffs) != 1 || !strings.HasPrefix(diffs[0], "port:") {
+			t.Errorf("Expected a single port difference, got: %v", diffs)
+		}
+	})
+}
+
+func TestZoneTTLWarning(t *testing.T) {
+	t.Parallel()
+
+	soa := client.DNSRecordData{Minimum: 300, Expire: 604800}
+
+	tests := []struct {
+		name     string
+		ttl      int64
+		soa      client.DNSRecordData
+		contains string
+	}{
+		{name: "within bounds", ttl: 3600, soa: soa},
+		{name: "equal to minimum", ttl: 300, soa: soa},
+		{name: "below minimum", ttl: 60, soa: soa},
+		{name: "above expire", ttl: 700000, soa: soa, contains: "exceeds the SOA expire value of 604800"},
+		{name: "no SOA constraints", ttl: 1, soa: client.DNSRecordData{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := zoneTTLWarning(tt.ttl, "example.com", tt.soa)
+			if tt.contains == "" && warning != "" {
+				t.Errorf("Expected no warning, got %q", warning)
+			}
+			if tt.contains != "" && !strings.Contains(warning, tt.contains) {
+				t.Errorf("Expected warning containing %q, got %q", tt.contains, warning)
+			}
+		})
+	}
+}
+
+func TestFindAppRecordHandler(t *testing.T) {
+	t.Parallel()
+
+	apps := []client.App{
+		{
+			Name: "Split Horizon",
+			DNSApps: []client.DNSApp{
+				{ClassPath: "SplitHorizon.SimpleAddress", IsAppRecordRequestHandler: true},
+				{ClassPath: "SplitHorizon.SimpleCNAME", IsAppRecordRequestHandler: true},
+				{ClassPath: "SplitHorizon.AddressTranslation", IsAppRecordRequestHandler: false},
+			},
+		},
+		{
+			Name:    "Query Logs (Sqlite)",
+			DNSApps: []client.DNSApp{{ClassPath: "QueryLogsSqlite.App"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		appName   string
+		classPath string
+		errorText string
+	}{
+		{name: "valid handler", appName: "Split Horizon", classPath: "SplitHorizon.SimpleAddress"},
+		{name: "app not installed", appName: "Geo Country", classPath: "GeoCountry.Address", errorText: "is not installed"},
+		{name: "not an APP record handler", appName: "Split Horizon", classPath: "SplitHorizon.AddressTranslation", errorText: "available class paths: SplitHorizon.SimpleAddress, SplitHorizon.SimpleCNAME"},
+		{name: "app without handlers", appName: "Query Logs (Sqlite)", classPath: "QueryLogsSqlite.App", errorText: "does not provide any APP record handlers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := findAppRecordHandler(apps, tt.appName, tt.classPath)
+			if tt.errorText == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
 				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorText) {
+				t.Errorf("Expected error containing %q, got %v", tt.errorText, err)
 			}
 		})
+	}
+}
 
-		t.Run("FWD Record Update Options", func(t *testing.T) {
-			data := &DNSRecordResourceModel{
-				Type:      types.StringValue("FWD"),
-				Forwarder: types.StringValue("9.9.9.9"),
-				Protocol:  types.StringValue("Quic"),
+func TestMergeSvcParams(t *testing.T) {
+	t.Parallel()
+
+	configured := map[string]string{"alpn": "h2,h3", "port": "443"}
+	server := map[string]string{"alpn": "h2, h3", "port": "8443", "ipv4hint": "192.0.2.1"}
+
+	merged := mergeSvcParams(configured, server)
+	expected := map[string]string{"alpn": "h2,h3", "port": "8443", "ipv4hint": "192.0.2.1"}
+	for key, value := range expected {
+		if merged[key] != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, merged[key])
+		}
+	}
+	if len(merged) != len(expected) {
+		t.Errorf("Expected %d params, got %d", len(expected), len(merged))
+	}
+}
+
+func TestTLSAAssociationDataHex(t *testing.T) {
+	t.Parallel()
+
+	certPEM, cert := testTLSACertificate(t)
+	spkiDigest := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	certDigest := sha512.Sum512(cert.Raw)
+
+	tests := []struct {
+		name         string
+		value        string
+		selector     string
+		matchingType string
+		expected     string
+		wantErr      bool
+	}{
+		{name: "hex is kept as is", value: "8d02536c88", selector: "SPKI", matchingType: "SHA2-256", expected: "8d02536c88"},
+		{name: "full certificate", value: certPEM, selector: "Cert", matchingType: "Full", expected: strings.ToUpper(hex.EncodeToString(cert.Raw))},
+		{name: "public key digest", value: certPEM, selector: "SPKI", matchingType: "SHA2-256", expected: strings.ToUpper(hex.EncodeToString(spkiDigest[:]))},
+		{name: "certificate digest", value: certPEM, selector: "Cert", matchingType: "SHA2-512", expected: strings.ToUpper(hex.EncodeToString(certDigest[:]))},
+		{name: "invalid value", value: "not hex", selector: "Cert", matchingType: "Full", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tlsaAssociationDataHex(tt.value, tt.selector, tt.matchingType)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error for %q", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("tlsaAssociationDataHex() failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("tlsaAssociationDataHex() = %q, expected %q", got, tt.expected)
 			}
+		})
+	}
+}
 
-			options := r.buildRecordOptions(ctx, data, "new")
-			if forwarder, ok := options["newForwarder"]; !ok || forwarder != "9.9.9.9" {
-				t.Errorf("Expected newForwarder=9.9.9.9, got %v", options)
+// testTLSACertificate returns a self-signed PEM certificate and its parsed form
+func testTLSACertificate(t *testing.T) (string, *x509.Certificate) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "www.example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, publicKey, privateKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), cert
+}
+
+func TestWildcardNames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		valid    bool
+		wildcard bool
+	}{
+		{name: "www", valid: true},
+		{name: "*", valid: true, wildcard: true},
+		{name: "*.sub", valid: true, wildcard: true},
+		{name: "*.sub.example.com", valid: true, wildcard: true},
+		{name: "sub.*", valid: false},
+		{name: "*foo", valid: false},
+		{name: "*.*.sub", valid: false},
+		{name: "a*b.sub", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if valid := wildcardNamePattern.MatchString(tt.name); valid != tt.valid {
+				t.Errorf("wildcardNamePattern.MatchString(%q) = %v, expected %v", tt.name, valid, tt.valid)
+			}
+			if tt.valid && isWildcardName(tt.name) != tt.wildcard {
+				t.Errorf("isWildcardName(%q) = %v, expected %v", tt.name, !tt.wildcard, tt.wildcard)
 			}
-			if protocol, ok := options["newProtocol"]; !ok || protocol != "Quic" {
-				t.Errorf("Expected newProtocol=Quic, got %v", options)
+		})
+	}
+}
+
+func TestAppRecordDataEqual(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{name: "identical", a: "plain", b: "plain", expected: true},
+		{name: "different text", a: "plain", b: "other", expected: false},
+		{name: "JSON formatting", a: `{"public":["203.0.113.10"],"private":["10.0.0.10"]}`, b: "{\n  \"private\": [\"10.0.0.10\"],\n  \"public\": [\"203.0.113.10\"]\n}", expected: true},
+		{name: "JSON values differ", a: `{"public":["203.0.113.10"]}`, b: `{"public":["203.0.113.11"]}`, expected: false},
+		{name: "JSON and text", a: `{"public":[]}`, b: "public", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := appRecordDataEqual(tt.a, tt.b); got != tt.expected {
+				t.Errorf("appRecordDataEqual(%q, %q) = %v, expected %v", tt.a, tt.b, got, tt.expected)
 			}
 		})
+	}
+}
+
+func TestDNSRecordResourceImportIdentity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &DNSRecordResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identityResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+	require.False(t, identityResp.Diagnostics.HasError(), "identity schema diagnostics: %v", identityResp.Diagnostics)
+
+	importIdentity := func(t *testing.T, identity DNSRecordIdentityModel, records ...client.DNSRecord) (DNSRecordResourceModel, diag.Diagnostics) {
+		t.Helper()
+		m := mocks.NewClientAPI(t)
+		m.On("GetRecords", mock.Anything, identity.Zone.ValueString(), mock.Anything, false).
+			Return(&client.GetRecordsResponse{Records: records}, nil).Maybe()
+		r := &DNSRecordResource{client: m}
+
+		reqIdentity := &tfsdk.ResourceIdentity{
+			Schema: identityResp.IdentitySchema,
+			Raw:    tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil),
+		}
+		require.False(t, reqIdentity.Set(ctx, identity).HasError())
+
+		resp := resource.ImportStateResponse{
+			State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+		}
+		r.ImportState(ctx, resource.ImportStateRequest{Identity: reqIdentity}, &resp)
+
+		var state DNSRecordResourceModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.State.Get(ctx, &state).HasError())
+		}
+		return state, resp.Diagnostics
+	}
+
+	t.Run("IPv6 data", func(t *testing.T) {
+		state, diags := importIdentity(t, DNSRecordIdentityModel{
+			Zone:     types.StringValue("example.com"),
+			Name:     types.StringValue("www"),
+			Type:     types.StringValue("AAAA"),
+			Data:     types.StringValue("2001:db8::1"),
+			Priority: types.Int64Null(),
+		}, client.DNSRecord{Type: "AAAA", TTL: 300, RData: client.DNSRecordData{IPAddress: "2001:db8::1"}})
+		require.False(t, diags.HasError(), "import diagnostics: %v", diags)
+		require.Equal(t, "example.com:www:AAAA:2001%3Adb8%3A%3A1", state.ID.ValueString())
+		require.Equal(t, "2001:db8::1", state.Data.ValueString())
+		require.Equal(t, int64(300), state.TTL.ValueInt64())
+	})
+
+	t.Run("MX priority", func(t *testing.T) {
+		state, diags := importIdentity(t, DNSRecordIdentityModel{
+			Zone:     types.StringValue("example.com"),
+			Name:     types.StringValue("@"),
+			Type:     types.StringValue("MX"),
+			Data:     types.StringValue("mail.example.com"),
+			Priority: types.Int64Value(10),
+		}, client.DNSRecord{Type: "MX", TTL: 300, RData: client.DNSRecordData{Exchange: "mail.example.com", Preference: 10}})
+		require.False(t, diags.HasError(), "import diagnostics: %v", diags)
+		require.Equal(t, "example.com:@:MX:10:mail.example.com", state.ID.ValueString())
+		require.Equal(t, int64(10), state.Priority.ValueInt64())
 	})
+
+	t.Run("missing type", func(t *testing.T) {
+		_, diags := importIdentity(t, DNSRecordIdentityModel{
+			Zone:     types.StringValue("example.com"),
+			Name:     types.StringValue("www"),
+			Type:     types.StringNull(),
+			Data:     types.StringNull(),
+			Priority: types.Int64Null(),
+		})
+		require.True(t, diags.HasError())
+	})
+
+	t.Run("identity from model", func(t *testing.T) {
+		identity := dnsRecordIdentity(&DNSRecordResourceModel{
+			Zone:     types.StringValue("example.com"),
+			Name:     types.StringValue("www"),
+			Type:     types.StringValue("A"),
+			Data:     types.StringValue("192.0.2.1"),
+			Priority: types.Int64Value(0),
+		})
+		require.Equal(t, "192.0.2.1", identity.Data.ValueString())
+		require.True(t, identity.Priority.IsNull(), "A records have no priority")
+	})
+}
+
+func TestDNSRecordResourceValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &DNSRecordResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	validate := func(t *testing.T, data DNSRecordResourceModel) diag.Diagnostics {
+		t.Helper()
+
+		setRecordModelNulls(&data)
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &data).HasError())
+
+		var resp resource.ValidateConfigResponse
+		r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+		return resp.Diagnostics
+	}
+
+	tests := []struct {
+		name  string
+		data  DNSRecordResourceModel
+		error string
+	}{
+		{
+			name: "valid MX record",
+			data: DNSRecordResourceModel{Zone: types.StringValue("example.com"), Name: types.StringValue("@"), Type: types.StringValue("MX"),
+				TTL: types.Int64Value(300), Data: types.StringValue("mail.example.com"), Priority: types.Int64Value(10)},
+		},
+		{
+			name: "MX without priority",
+			data: DNSRecordResourceModel{Zone: types.StringValue("example.com"), Name: types.StringValue("@"), Type: types.StringValue("MX"),
+				TTL: types.Int64Value(300), Data: types.StringValue("mail.example.com")},
+			error: "priority is required for MX records",
+		},
+		{
+			name: "SRV without port",
+			data: DNSRecordResourceModel{Zone: types.StringValue("example.com"), Name: types.StringValue("_sip._tcp"), Type: types.StringValue("SRV"),
+				TTL: types.Int64Value(300), Data: types.StringValue("sip.example.com"), Priority: types.Int64Value(10), Weight: types.Int64Value(5)},
+			error: "port is required for SRV records",
+		},
+		{
+			name: "FWD proxy without address",
+			data: DNSRecordResourceModel{Zone: types.StringValue("corp.example.com"), Name: types.StringValue("@"), Type: types.StringValue("FWD"),
+				TTL: types.Int64Value(300), Forwarder: types.StringValue("10.0.0.53"), ProxyType: types.StringValue("Socks5")},
+			error: "proxy_address is required when proxy_type is Socks5",
+		},
+		{
+			name: "protocol on A record",
+			data: DNSRecordResourceModel{Zone: types.StringValue("example.com"), Name: types.StringValue("www"), Type: types.StringValue("A"),
+				TTL: types.Int64Value(300), Data: types.StringValue("192.0.2.1"), Protocol: types.StringValue("Tls")},
+			error: "protocol is only valid for FWD records",
+		},
+		{
+			name: "unknown data",
+			data: DNSRecordResourceModel{Zone: types.StringValue("example.com"), Name: types.StringValue("www"), Type: types.StringValue("A"),
+				TTL: types.Int64Value(300), Data: types.StringUnknown()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validate(t, tt.data)
+			if tt.error == "" {
+				require.False(t, diags.HasError(), "validate diagnostics: %v", diags)
+				return
+			}
+			require.True(t, diags.HasError())
+			require.Contains(t, diags.Errors()[0].Detail(), tt.error)
+		})
+	}
 }
@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSRecordSetResource{}
+var _ resource.ResourceWithImportState = &DNSRecordSetResource{}
+
+// recordSetTypes lists the record types a record set supports, those whose records hold a single
+// value, see recordSetData
+var recordSetTypes = []string{"A", "AAAA", "NS", "PTR", "TXT"}
+
+func NewDNSRecordSetResource() resource.Resource {
+	return &DNSRecordSetResource{}
+}
+
+// DNSRecordSetResource defines the resource implementation.
+type DNSRecordSetResource struct {
+	client client.ClientAPI
+}
+
+// DNSRecordSetResourceModel describes the resource data model.
+type DNSRecordSetResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Zone     types.String `tfsdk:"zone"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Values   types.Set    `tfsdk:"values"`
+	Comments types.String `tfsdk:"comments"`
+}
+
+func (r *DNSRecordSetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record_set"
+}
+
+func (r *DNSRecordSetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all records of one name and type as a set, e.g. the A records of a round-robin name. The set is authoritative: " +
+			"records of the name and type that are not in `values` are removed, and changes to `values` add and delete only the records that differ. " +
+			"Do not manage the same name and type with `technitium_dns_record` as well.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Record set identifier (zone:name:type)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone containing the records",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The record name, relative to the zone or @ for the apex",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The record type (" + strings.Join(recordSetTypes, ", ") + ")",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(recordSetTypes...),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "Time-to-live value in seconds, shared by all records of the set",
+				Required:            true,
+				Validators: []validator.Int64{
+					ttlValidator(),
+				},
+			},
+			"values": schema.SetAttribute{
+				MarkdownDescription: "The record values, e.g. the IP addresses of A records or the texts of TXT records",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"comments": schema.StringAttribute{
+				MarkdownDescription: "Comments attached to every record of the set",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (r *DNSRecordSetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DNSRecordSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DNSRecordSetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var values []string
+	resp.Diagnostics.Append(data.Values.ElementsAs(ctx, &values, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(values)
+
+	// The first record replaces any existing records of the name and type, the others are added next to it
+	if err := r.addRecords(ctx, &data, values, true); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating DNS record set",
+			fmt.Sprintf("Could not create the %s records of %s: %s", data.Type.ValueString(), data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(recordSetID(data.Zone.ValueString(), data.Name.ValueString(), data.Type.ValueString()))
+	reportOperation(ctx, r.client, "records", client.OperationCreated)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSRecordSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DNSRecordSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := data.Zone.ValueString()
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
+
+	recordsResp, err := r.client.GetRecords(ctx, zoneName, recordName, false)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading DNS record set",
+			fmt.Sprintf("Could not read the %s records of %s: %s", data.Type.ValueString(), data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	records := recordSetRecords(recordsResp.Records, data.Type.ValueString())
+	if len(records) == 0 {
+		// Every record of the set was deleted outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	values := make([]string, 0, len(records))
+	for _, record := range records {
+		values = append(values, recordSetValue(record))
+	}
+	data.Values = stringSetValue(values)
+	data.TTL = types.Int64Value(int64(records[0].TTL))
+	if !data.Comments.IsNull() || records[0].Comments != "" {
+		data.Comments = types.StringValue(records[0].Comments)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSRecordSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state DNSRecordSetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planned, current []string
+	resp.Diagnostics.Append(data.Values.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.Values.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+	if !data.TTL.Equal(state.TTL) || !data.Comments.Equal(state.Comments) {
+		// Every record carries the TTL and comments, so the whole set is written again
+		sort.Strings(planned)
+		err = r.addRecords(ctx, &data, planned, true)
+	} else {
+		added, removed := diffDomains(current, planned)
+		if err = r.addRecords(ctx, &data, added, false); err == nil {
+			err = r.deleteRecords(ctx, &data, removed)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating DNS record set",
+			fmt.Sprintf("Could not update the %s records of %s: %s", data.Type.ValueString(), data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+	reportOperation(ctx, r.client, "records", client.OperationUpdated)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DNSRecordSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DNSRecordSetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var values []string
+	resp.Diagnostics.Append(data.Values.ElementsAs(ctx, &values, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.deleteRecords(ctx, &data, values); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting DNS record set",
+			fmt.Sprintf("Could not delete the %s records of %s: %s", data.Type.ValueString(), data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+	reportOperation(ctx, r.client, "records", client.OperationDeleted)
+}
+
+func (r *DNSRecordSetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: zone:name:type
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in the format zone:name:type",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), idParts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), idParts[2])...)
+}
+
+// addRecords adds a record per value. With overwrite set, the first record replaces all existing
+// records of the name and type.
+func (r *DNSRecordSetResource) addRecords(ctx context.Context, data *DNSRecordSetResourceModel, values []string, overwrite bool) error {
+	zoneName := data.Zone.ValueString()
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
+
+	ttl, err := ttlToInt(data.TTL)
+	if err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		tflog.Debug(ctx, "Adding record set member", map[string]interface{}{
+			"zone":  zoneName,
+			"name":  recordName,
+			"type":  data.Type.ValueString(),
+			"value": value,
+		})
+
+		record := client.AddRecordRequest{
+			Zone:      zoneName,
+			Domain:    recordName,
+			TTL:       int64(ttl),
+			Data:      recordSetData(data.Type.ValueString(), value),
+			Comments:  data.Comments.ValueString(),
+			Overwrite: overwrite && i == 0,
+		}
+		if _, err := r.client.AddRecord(ctx, record); err != nil {
+			return fmt.Errorf("could not add %s: %w", value, err)
+		}
+	}
+	return nil
+}
+
+// deleteRecords deletes the record of each value
+func (r *DNSRecordSetResource) deleteRecords(ctx context.Context, data *DNSRecordSetResourceModel, values []string) error {
+	zoneName := data.Zone.ValueString()
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
+
+	for _, value := range values {
+		tflog.Debug(ctx, "Deleting record set member", map[string]interface{}{
+			"zone":  zoneName,
+			"name":  recordName,
+			"type":  data.Type.ValueString(),
+			"value": value,
+		})
+
+		record := client.DeleteRecordRequest{Zone: zoneName, Domain: recordName, Data: recordSetData(data.Type.ValueString(), value)}
+		if err := r.client.DeleteRecord(ctx, record); err != nil {
+			return fmt.Errorf("could not delete %s: %w", value, err)
+		}
+	}
+	return nil
+}
+
+// recordSetData returns the data of the record of a value
+func recordSetData(recordType, value string) client.RecordData {
+	switch recordType {
+	case "A":
+		return client.ARecordData{IPAddress: value}
+	case "AAAA":
+		return client.AAAARecordData{IPAddress: value}
+	case "NS":
+		return client.NSRecordData{NameServer: value}
+	case "PTR":
+		return client.PTRRecordData{PTRName: value}
+	case "TXT":
+		// The API adds the quotes itself
+		return client.TXTRecordData{Text: strings.Trim(value, "\"")}
+	}
+	return nil
+}
+
+// recordSetRecords returns the records of the given type
+func recordSetRecords(records []client.DNSRecord, recordType string) []client.DNSRecord {
+	var matching []client.DNSRecord
+	for _, record := range records {
+		if record.Type == recordType {
+			matching = append(matching, record)
+		}
+	}
+	return matching
+}
+
+// recordSetValue returns the value of a record as it is configured in a record set
+func recordSetValue(record client.DNSRecord) string {
+	if record.Type == "TXT" {
+		return strings.Trim(record.RData.Text, "\"")
+	}
+	return formatRecordData(record)
+}
+
+// recordSetID returns the resource ID of a record set
+func recordSetID(zoneName, name, recordType string) string {
+	return fmt.Sprintf("%s:%s:%s", zoneName, name, recordType)
+}
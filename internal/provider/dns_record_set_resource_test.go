@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+// newMockedDNSRecordSetResource returns a record set resource backed by a mock client and its schema
+func newMockedDNSRecordSetResource(t *testing.T) (*DNSRecordSetResource, *mocks.ClientAPI, resource.SchemaResponse) {
+	t.Helper()
+
+	m := mocks.NewClientAPI(t)
+	r := &DNSRecordSetResource{client: m}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	return r, m, schemaResp
+}
+
+// recordSetModel returns a model of the A records of www.example.com with the given values
+func recordSetModel(ttl int64, values ...string) DNSRecordSetResourceModel {
+	return DNSRecordSetResourceModel{
+		ID:       types.StringValue("example.com:www:A"),
+		Zone:     types.StringValue("example.com"),
+		Name:     types.StringValue("www"),
+		Type:     types.StringValue("A"),
+		TTL:      types.Int64Value(ttl),
+		Values:   stringSetValue(values),
+		Comments: types.StringNull(),
+	}
+}
+
+// addedAddress matches the request adding the given address to www.example.com
+func addedAddress(ttl int64, address string, overwrite bool) interface{} {
+	return mock.MatchedBy(func(record client.AddRecordRequest) bool {
+		return record.Zone == "example.com" && record.Domain == "www.example.com" && record.TTL == ttl &&
+			record.Data == client.ARecordData{IPAddress: address} && record.Overwrite == overwrite
+	})
+}
+
+// deletedAddress matches the request deleting the given address from www.example.com
+func deletedAddress(address string) interface{} {
+	return mock.MatchedBy(func(record client.DeleteRecordRequest) bool {
+		return record.Zone == "example.com" && record.Domain == "www.example.com" &&
+			record.Data == client.ARecordData{IPAddress: address}
+	})
+}
+
+func TestDNSRecordSetResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordSetResource(t)
+
+	m.On("AddRecord", mock.Anything, addedAddress(300, "192.0.2.1", true)).Return(&client.AddRecordResponse{}, nil).Once()
+	m.On("AddRecord", mock.Anything, addedAddress(300, "192.0.2.2", false)).Return(&client.AddRecordResponse{}, nil).Once()
+
+	model := recordSetModel(300, "192.0.2.2", "192.0.2.1")
+	model.ID = types.StringUnknown()
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(context.Background(), &model).HasError())
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+	var state DNSRecordSetResourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "example.com:www:A", state.ID.ValueString())
+}
+
+func TestDNSRecordSetResourceRead(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads members of the type", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordSetResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+			Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+				{Name: "www.example.com", Type: "A", TTL: 600, RData: client.DNSRecordData{IPAddress: "192.0.2.1"}},
+				{Name: "www.example.com", Type: "AAAA", TTL: 600, RData: client.DNSRecordData{IPAddress: "2001:db8::1"}},
+				{Name: "www.example.com", Type: "A", TTL: 600, RData: client.DNSRecordData{IPAddress: "192.0.2.3"}},
+			}}, nil)
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		model := recordSetModel(300, "192.0.2.1", "192.0.2.2")
+		require.False(t, state.Set(context.Background(), &model).HasError())
+
+		resp := resource.ReadResponse{State: state}
+		r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var got DNSRecordSetResourceModel
+		require.False(t, resp.State.Get(context.Background(), &got).HasError())
+		require.Equal(t, int64(600), got.TTL.ValueInt64())
+		require.True(t, got.Values.Equal(stringSetValue([]string{"192.0.2.1", "192.0.2.3"})))
+	})
+
+	t.Run("removes the set when no members are left", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordSetResource(t)
+
+		m.On("GetRecords", mock.Anything, "example.com", "www.example.com", false).
+			Return(&client.GetRecordsResponse{}, nil)
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		model := recordSetModel(300, "192.0.2.1")
+		require.False(t, state.Set(context.Background(), &model).HasError())
+
+		resp := resource.ReadResponse{State: state}
+		r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.True(t, resp.State.Raw.IsNull())
+	})
+}
+
+func TestDNSRecordSetResourceUpdate(t *testing.T) {
+	t.Parallel()
+
+	update := func(t *testing.T, r *DNSRecordSetResource, schemaResp resource.SchemaResponse, prior, planned DNSRecordSetResourceModel) resource.UpdateResponse {
+		t.Helper()
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(context.Background(), &prior).HasError())
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(context.Background(), &planned).HasError())
+
+		resp := resource.UpdateResponse{State: state}
+		r.Update(context.Background(), resource.UpdateRequest{Plan: plan, State: state}, &resp)
+		return resp
+	}
+
+	t.Run("adds and deletes only changed members", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordSetResource(t)
+
+		m.On("AddRecord", mock.Anything, addedAddress(300, "192.0.2.3", false)).
+			Return(&client.AddRecordResponse{}, nil).Once()
+		m.On("DeleteRecord", mock.Anything, deletedAddress("192.0.2.1")).
+			Return(nil).Once()
+
+		resp := update(t, r, schemaResp,
+			recordSetModel(300, "192.0.2.1", "192.0.2.2"),
+			recordSetModel(300, "192.0.2.2", "192.0.2.3"))
+		require.False(t, resp.Diagnostics.HasError(), "update diagnostics: %v", resp.Diagnostics)
+	})
+
+	t.Run("rewrites the set when the TTL changes", func(t *testing.T) {
+		r, m, schemaResp := newMockedDNSRecordSetResource(t)
+
+		m.On("AddRecord", mock.Anything, addedAddress(900, "192.0.2.1", true)).Return(&client.AddRecordResponse{}, nil).Once()
+		m.On("AddRecord", mock.Anything, addedAddress(900, "192.0.2.2", false)).Return(&client.AddRecordResponse{}, nil).Once()
+
+		resp := update(t, r, schemaResp,
+			recordSetModel(300, "192.0.2.1", "192.0.2.2"),
+			recordSetModel(900, "192.0.2.1", "192.0.2.2"))
+		require.False(t, resp.Diagnostics.HasError(), "update diagnostics: %v", resp.Diagnostics)
+	})
+}
+
+func TestDNSRecordSetResourceDelete(t *testing.T) {
+	t.Parallel()
+
+	r, m, schemaResp := newMockedDNSRecordSetResource(t)
+
+	m.On("DeleteRecord", mock.Anything, deletedAddress("192.0.2.1")).Return(nil).Once()
+	m.On("DeleteRecord", mock.Anything, deletedAddress("192.0.2.2")).Return(nil).Once()
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	model := recordSetModel(300, "192.0.2.1", "192.0.2.2")
+	require.False(t, state.Set(context.Background(), &model).HasError())
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(context.Background(), resource.DeleteRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "delete diagnostics: %v", resp.Diagnostics)
+}
@@ -3,9 +3,15 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -21,7 +27,7 @@ func NewDNSRecordsDataSource() datasource.DataSource {
 
 // DNSRecordsDataSource defines the data source implementation.
 type DNSRecordsDataSource struct {
-	client *client.Client
+	client client.ClientAPI
 }
 
 // DNSRecordsDataSourceModel describes the data source data model.
@@ -30,22 +36,38 @@ type DNSRecordsDataSourceModel struct {
 	Zone types.String `tfsdk:"zone"`
 
 	// Optional inputs
-	Domain      types.String   `tfsdk:"domain"`
-	RecordTypes []types.String `tfsdk:"record_types"`
+	Domain           types.String   `tfsdk:"domain"`
+	RecordTypes      []types.String `tfsdk:"record_types"`
+	CommentsContains types.String   `tfsdk:"comments_contains"`
+	Wildcard         types.Bool     `tfsdk:"wildcard"`
+	NameRegex        types.String   `tfsdk:"name_regex"`
+	Disabled         types.Bool     `tfsdk:"disabled"`
+	TTLLessThan      types.Int64    `tfsdk:"ttl_less_than"`
+	PageSize         types.Int64    `tfsdk:"page_size"`
+	PageNumber       types.Int64    `tfsdk:"page_number"`
 
 	// Computed outputs
-	ID      types.String        `tfsdk:"id"`
-	Records []DNSRecordDataItem `tfsdk:"records"`
+	ID               types.String           `tfsdk:"id"`
+	ZoneType         types.String           `tfsdk:"zone_type"`
+	ZoneDnssecStatus types.String           `tfsdk:"zone_dnssec_status"`
+	ZoneDisabled     types.Bool             `tfsdk:"zone_disabled"`
+	ZoneInternal     types.Bool             `tfsdk:"zone_internal"`
+	Records          []DNSRecordDataItem    `tfsdk:"records"`
+	RecordCount      types.Int64            `tfsdk:"record_count"`
+	RecordTypeCounts map[string]types.Int64 `tfsdk:"record_type_counts"`
+	TotalCount       types.Int64            `tfsdk:"total_count"`
+	TotalPages       types.Int64            `tfsdk:"total_pages"`
 }
 
 // DNSRecordDataItem represents an individual DNS record
 type DNSRecordDataItem struct {
-	Name     types.String `tfsdk:"name"`
-	Type     types.String `tfsdk:"type"`
-	TTL      types.Int64  `tfsdk:"ttl"`
-	Data     types.String `tfsdk:"data"`
-	Disabled types.Bool   `tfsdk:"disabled"`
-	Comments types.String `tfsdk:"comments"`
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	TTL       types.Int64  `tfsdk:"ttl"`
+	Data      types.String `tfsdk:"data"`
+	Disabled  types.Bool   `tfsdk:"disabled"`
+	Comments  types.String `tfsdk:"comments"`
+	ExpiryTTL types.Int64  `tfsdk:"expiry_ttl"`
 }
 
 func (d *DNSRecordsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -66,20 +88,95 @@ func (d *DNSRecordsDataSource) Schema(ctx context.Context, req datasource.Schema
 
 			// Optional inputs
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "The specific domain to retrieve records for. If not specified, all records in the zone will be returned.",
-				Optional:            true,
+				MarkdownDescription: "The specific domain to retrieve records for, either fully qualified or relative to the zone " +
+					"(e.g. 'www', '*' or '*.sub'). If not specified, all records in the zone will be returned.",
+				Optional: true,
 			},
 			"record_types": schema.ListAttribute{
 				MarkdownDescription: "Filter records by type (e.g., ['A', 'AAAA', 'CNAME']). If not specified, all record types will be returned.",
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"comments_contains": schema.StringAttribute{
+				MarkdownDescription: "Only return records whose comments contain this value (case-insensitive). " +
+					"Useful with the provider `default_comment` or a team label to select your own records out of a shared zone.",
+				Optional: true,
+			},
+			"wildcard": schema.BoolAttribute{
+				MarkdownDescription: "Filter records by wildcard owner names (e.g. '*.example.com'). When true only wildcard records are returned, " +
+					"when false wildcard records are excluded. If not specified, records are returned regardless of their owner name.",
+				Optional: true,
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return records whose fully qualified name matches this regular expression (RE2 syntax, e.g. `^web[0-9]+\\.`).",
+				Optional:            true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "When true only disabled records are returned, when false only enabled records. If not specified, both are returned.",
+				Optional:            true,
+			},
+			"ttl_less_than": schema.Int64Attribute{
+				MarkdownDescription: "Only return records with a TTL below this number of seconds, e.g. to find records with unusually short TTLs.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					ttlValidator(),
+				},
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "Return at most this many of the filtered records, so large zones can be read in pages without storing every record in state. " +
+					"Paging happens in the provider: every page still reads the whole zone from the server. If not specified, all filtered records are returned.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"page_number": schema.Int64Attribute{
+				MarkdownDescription: "The page of filtered records to return when `page_size` is set, starting at 1. Defaults to 1.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+					int64validator.AlsoRequires(path.MatchRoot("page_size")),
+				},
+			},
 
 			// Computed outputs
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique identifier for the data source.",
 				Computed:            true,
 			},
+			"zone_type": schema.StringAttribute{
+				MarkdownDescription: "The type of the zone the records belong to (e.g., Primary, Forwarder).",
+				Computed:            true,
+			},
+			"zone_dnssec_status": schema.StringAttribute{
+				MarkdownDescription: "The DNSSEC status of the zone (e.g., Unsigned, SignedWithNSEC3).",
+				Computed:            true,
+			},
+			"zone_disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone is disabled.",
+				Computed:            true,
+			},
+			"zone_internal": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone is an internal zone.",
+				Computed:            true,
+			},
+			"record_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of records returned after filtering and paging.",
+				Computed:            true,
+			},
+			"total_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of records matching the filters across all pages.",
+				Computed:            true,
+			},
+			"total_pages": schema.Int64Attribute{
+				MarkdownDescription: "The number of pages of filtered records, 1 when `page_size` is not set.",
+				Computed:            true,
+			},
+			"record_type_counts": schema.MapAttribute{
+				MarkdownDescription: "The number of records matching the filters across all pages, keyed by record type.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
 			"records": schema.ListNestedAttribute{
 				MarkdownDescription: "List of DNS records in the zone.",
 				Computed:            true,
@@ -109,6 +206,10 @@ func (d *DNSRecordsDataSource) Schema(ctx context.Context, req datasource.Schema
 							MarkdownDescription: "Any comments attached to the record.",
 							Computed:            true,
 						},
+						"expiry_ttl": schema.Int64Attribute{
+							MarkdownDescription: "Number of seconds after its last modification at which the server automatically deletes the record, e.g. a temporary record created by CI. Zero when the record does not expire.",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -147,8 +248,9 @@ func (d *DNSRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	zoneName := data.Zone.ValueString()
 	domain := zoneName // Default to the zone name
 
-	if !data.Domain.IsNull() {
-		domain = data.Domain.ValueString()
+	if !data.Domain.IsNull() && data.Domain.ValueString() != "@" {
+		// Qualify relative names such as "www" or "*.sub" with the zone
+		domain = formatRecordName(data.Domain.ValueString(), zoneName)
 	}
 
 	// Determine if we need to list all records in the zone
@@ -170,6 +272,19 @@ func (d *DNSRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name_regex",
+				fmt.Sprintf("Could not compile %q: %s", data.NameRegex.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
 	// Create a set to check if a record type should be included
 	includeRecordTypes := make(map[string]bool)
 	if len(data.RecordTypes) > 0 {
@@ -180,12 +295,35 @@ func (d *DNSRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 
 	// Process records and convert to Terraform model
 	records := make([]DNSRecordDataItem, 0)
+	typeCounts := make(map[string]int64)
 	for _, record := range recordsResponse.Records {
 		// Skip record if type filtering is enabled and this type isn't in the filter
 		if len(includeRecordTypes) > 0 && !includeRecordTypes[record.Type] {
 			continue
 		}
 
+		// Skip record if comment filtering is enabled and the comments don't match
+		if !data.CommentsContains.IsNull() && !matchesCommentsFilter(record.Comments, data.CommentsContains.ValueString()) {
+			continue
+		}
+
+		// Skip record if wildcard filtering is enabled and the owner name does not match
+		if !data.Wildcard.IsNull() && isWildcardName(record.Name) != data.Wildcard.ValueBool() {
+			continue
+		}
+
+		if nameRegex != nil && !nameRegex.MatchString(record.Name) {
+			continue
+		}
+
+		if !data.Disabled.IsNull() && record.Disabled != data.Disabled.ValueBool() {
+			continue
+		}
+
+		if !data.TTLLessThan.IsNull() && int64(record.TTL) >= data.TTLLessThan.ValueInt64() {
+			continue
+		}
+
 		// Format record data based on the record type
 		formattedData := formatRecordData(record)
 
@@ -196,18 +334,55 @@ func (d *DNSRecordsDataSource) Read(ctx context.Context, req datasource.ReadRequ
 			Data:     types.StringValue(formattedData),
 			Disabled: types.BoolValue(record.Disabled),
 			Comments: types.StringValue(record.Comments),
+			// Records created with expiry_ttl are deleted by the server once it elapses
+			ExpiryTTL: types.Int64Value(int64(record.ExpiryTTL)),
 		}
 
 		records = append(records, recordItem)
 	}
 
+	// Count the filtered records of every page, then keep only the requested page in state
+	for _, record := range records {
+		typeCounts[record.Type.ValueString()]++
+	}
+	totalCount := int64(len(records))
+	totalPages := int64(1)
+	if !data.PageSize.IsNull() {
+		pageSize := data.PageSize.ValueInt64()
+		pageNumber := int64(1)
+		if !data.PageNumber.IsNull() {
+			pageNumber = data.PageNumber.ValueInt64()
+		}
+
+		totalPages = max((totalCount+pageSize-1)/pageSize, 1)
+		start := min((pageNumber-1)*pageSize, totalCount)
+		records = records[start:min(start+pageSize, totalCount)]
+	}
+
+	// The records response includes the zone block, so no separate zone lookup is needed
 	data.ID = types.StringValue(zoneName)
+	data.ZoneType = types.StringValue(recordsResponse.Zone.Type)
+	data.ZoneDnssecStatus = types.StringValue(recordsResponse.Zone.DnssecStatus)
+	data.ZoneDisabled = types.BoolValue(recordsResponse.Zone.Disabled)
+	data.ZoneInternal = types.BoolValue(recordsResponse.Zone.Internal)
 	data.Records = records
+	data.RecordCount = types.Int64Value(int64(len(records)))
+	data.TotalCount = types.Int64Value(totalCount)
+	data.TotalPages = types.Int64Value(totalPages)
+	data.RecordTypeCounts = make(map[string]types.Int64, len(typeCounts))
+	for recordType, count := range typeCounts {
+		data.RecordTypeCounts[recordType] = types.Int64Value(count)
+	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// matchesCommentsFilter reports whether the record comments contain the filter value, ignoring case
+func matchesCommentsFilter(comments, filter string) bool {
+	return strings.Contains(strings.ToLower(comments), strings.ToLower(filter))
+}
+
 // formatRecordData formats the record data based on the record type
 func formatRecordData(record client.DNSRecord) string {
 	switch record.Type {
@@ -225,6 +400,16 @@ func formatRecordData(record client.DNSRecord) string {
 		return record.RData.NameServer
 	case "SRV":
 		return fmt.Sprintf("%d %d %d %s", record.RData.Priority, record.RData.Weight, record.RData.Port, record.RData.Target)
+	case "CAA":
+		return fmt.Sprintf("%d %s %q", record.RData.Flags.Int(), record.RData.Tag, record.RData.Value)
+	case "SVCB", "HTTPS":
+		return strings.TrimSpace(fmt.Sprintf("%d %s %s", record.RData.SvcPriority, record.RData.SvcTargetName, formatSvcParamsPresentation(record.RData.SvcParams)))
+	case "SSHFP":
+		return fmt.Sprintf("%s %s %s", record.RData.Algorithm, record.RData.FingerprintType, record.RData.Fingerprint)
+	case "TLSA":
+		return fmt.Sprintf("%s %s %s %s", record.RData.CertificateUsage, record.RData.Selector, record.RData.MatchingType, record.RData.CertificateAssociationData)
+	case "APP":
+		return fmt.Sprintf("%s %s", record.RData.ClassPath, record.RData.Data)
 	case "SOA":
 		return fmt.Sprintf("%s %s %d %d %d %d %d",
 			record.RData.PrimaryNameServer,
@@ -239,3 +424,22 @@ func formatRecordData(record client.DNSRecord) string {
 		return fmt.Sprintf("[%s record]", record.Type)
 	}
 }
+
+// formatSvcParamsPresentation renders service parameters in zone file presentation format, ordered by key
+func formatSvcParamsPresentation(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if params[key] == "" {
+			parts = append(parts, key)
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s", key, params[key]))
+	}
+	return strings.Join(parts, " ")
+}
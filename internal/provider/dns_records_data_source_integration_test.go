@@ -27,7 +27,7 @@ func TestAccDNSRecordsDataSource_Basic(t *testing.T) {
 	config := setupTestContainer(t)
 
 	// Generate a random zone name for testing
-	testZoneName := fmt.Sprintf("test-records-%d.example.com", randomInt(1000, 9999))
+	testZoneName := fmt.Sprintf("%srecords-%d.example.com", sweepZonePrefix, randomInt(1000, 9999))
 
 	// Create a zone and some records first
 	resource.Test(t, resource.TestCase{
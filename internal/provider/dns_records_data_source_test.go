@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,12 +9,17 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
 )
 
 // TestDNSRecordsDataSource tests the technitium_dns_records data source.
@@ -372,15 +378,27 @@ func TestUnitDNSRecordsDataSourceFormatRecordData(t *testing.T) {
 			},
 			expected: "ns1.example.com admin.example.com 1 3600 600 86400 3600",
 		},
+		{
+			name: "CAA record",
+			record: client.DNSRecord{
+				Type: "CAA",
+				RData: client.DNSRecordData{
+					Flags: "0",
+					Tag:   "issue",
+					Value: "letsencrypt.org",
+				},
+			},
+			expected: `0 issue "letsencrypt.org"`,
+		},
 		{
 			name: "Unknown record",
 			record: client.DNSRecord{
-				Type:  "CAA",
+				Type:  "URI",
 				RData: client.DNSRecordData{
-					// CAA record fields not specifically handled
+					// URI record fields not specifically handled
 				},
 			},
-			expected: "[CAA record]",
+			expected: "[URI record]",
 		},
 	}
 
@@ -391,3 +409,210 @@ func TestUnitDNSRecordsDataSourceFormatRecordData(t *testing.T) {
 		})
 	}
 }
+
+// TestUnitDNSRecordsDataSourceMatchesCommentsFilter tests the matchesCommentsFilter function
+func TestUnitDNSRecordsDataSourceMatchesCommentsFilter(t *testing.T) {
+	cases := []struct {
+		name     string
+		comments string
+		filter   string
+		expected bool
+	}{
+		{name: "Exact match", comments: "team-a", filter: "team-a", expected: true},
+		{name: "Substring match", comments: "web server managed by terraform", filter: "managed by terraform", expected: true},
+		{name: "Case insensitive", comments: "Team-A", filter: "team-a", expected: true},
+		{name: "No match", comments: "team-b", filter: "team-a", expected: false},
+		{name: "Empty comments", comments: "", filter: "team-a", expected: false},
+		{name: "Empty filter", comments: "team-a", filter: "", expected: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, matchesCommentsFilter(tc.comments, tc.filter))
+		})
+	}
+}
+
+func TestUnitDNSRecordsDataSourceReadZoneInfo(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &DNSRecordsDataSource{client: m}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	m.On("GetRecords", mock.Anything, "example.com", "example.com", true).Return(&client.GetRecordsResponse{
+		Zone: client.ZoneInfo{Name: "example.com", Type: "Primary", DnssecStatus: "SignedWithNSEC3", Disabled: true},
+		Records: []client.DNSRecord{
+			{Name: "example.com", Type: "A", TTL: 3600, ExpiryTTL: 600, RData: client.DNSRecordData{IPAddress: "192.0.2.1"}},
+		},
+	}, nil)
+
+	// Build the configuration from a state holding the inputs
+	input := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, input.Set(context.Background(), &DNSRecordsDataSourceModel{Zone: types.StringValue("example.com")}).HasError())
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var state DNSRecordsDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "Primary", state.ZoneType.ValueString())
+	require.Equal(t, "SignedWithNSEC3", state.ZoneDnssecStatus.ValueString())
+	require.True(t, state.ZoneDisabled.ValueBool())
+	require.False(t, state.ZoneInternal.ValueBool())
+	require.Len(t, state.Records, 1)
+	require.Equal(t, int64(600), state.Records[0].ExpiryTTL.ValueInt64())
+	require.Equal(t, int64(1), state.RecordCount.ValueInt64())
+	require.Equal(t, map[string]types.Int64{"A": types.Int64Value(1)}, state.RecordTypeCounts)
+}
+
+func TestUnitDNSRecordsDataSourceReadWildcard(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &DNSRecordsDataSource{client: m}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	m.On("GetRecords", mock.Anything, "example.com", "example.com", true).Return(&client.GetRecordsResponse{
+		Zone: client.ZoneInfo{Name: "example.com", Type: "Primary"},
+		Records: []client.DNSRecord{
+			{Name: "example.com", Type: "A", TTL: 3600, RData: client.DNSRecordData{IPAddress: "192.0.2.1"}},
+			{Name: "*.example.com", Type: "A", TTL: 3600, RData: client.DNSRecordData{IPAddress: "192.0.2.2"}},
+			{Name: "*.sub.example.com", Type: "CNAME", TTL: 3600, RData: client.DNSRecordData{CNAME: "example.com"}},
+		},
+	}, nil)
+	m.On("GetRecords", mock.Anything, "example.com", "*.sub.example.com", false).Return(&client.GetRecordsResponse{
+		Zone: client.ZoneInfo{Name: "example.com", Type: "Primary"},
+		Records: []client.DNSRecord{
+			{Name: "*.sub.example.com", Type: "CNAME", TTL: 3600, RData: client.DNSRecordData{CNAME: "example.com"}},
+		},
+	}, nil)
+
+	read := func(t *testing.T, config DNSRecordsDataSourceModel) DNSRecordsDataSourceModel {
+		input := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, input.Set(context.Background(), &config).HasError())
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state DNSRecordsDataSourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		return state
+	}
+
+	t.Run("only wildcards", func(t *testing.T) {
+		state := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), Wildcard: types.BoolValue(true)})
+		require.Len(t, state.Records, 2)
+		require.Equal(t, "*.example.com", state.Records[0].Name.ValueString())
+		require.Equal(t, "*.sub.example.com", state.Records[1].Name.ValueString())
+		require.Equal(t, int64(2), state.RecordCount.ValueInt64())
+		require.Equal(t, map[string]types.Int64{"A": types.Int64Value(1), "CNAME": types.Int64Value(1)}, state.RecordTypeCounts)
+	})
+
+	t.Run("without wildcards", func(t *testing.T) {
+		state := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), Wildcard: types.BoolValue(false)})
+		require.Len(t, state.Records, 1)
+		require.Equal(t, "example.com", state.Records[0].Name.ValueString())
+	})
+
+	t.Run("relative wildcard domain", func(t *testing.T) {
+		state := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), Domain: types.StringValue("*.sub")})
+		require.Len(t, state.Records, 1)
+		require.Equal(t, "CNAME", state.Records[0].Type.ValueString())
+	})
+}
+
+func TestUnitDNSRecordsDataSourceReadFilters(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &DNSRecordsDataSource{client: m}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	m.On("GetRecords", mock.Anything, "example.com", "example.com", true).Return(&client.GetRecordsResponse{
+		Zone: client.ZoneInfo{Name: "example.com", Type: "Primary"},
+		Records: []client.DNSRecord{
+			{Name: "web1.example.com", Type: "A", TTL: 60, RData: client.DNSRecordData{IPAddress: "192.0.2.1"}},
+			{Name: "web2.example.com", Type: "A", TTL: 3600, RData: client.DNSRecordData{IPAddress: "192.0.2.2"}},
+			{Name: "web3.example.com", Type: "A", TTL: 300, Disabled: true, RData: client.DNSRecordData{IPAddress: "192.0.2.3"}},
+			{Name: "mail.example.com", Type: "A", TTL: 60, RData: client.DNSRecordData{IPAddress: "192.0.2.4"}},
+			{Name: "example.com", Type: "MX", TTL: 3600, RData: client.DNSRecordData{Exchange: "mail.example.com", Preference: 10}},
+		},
+	}, nil)
+
+	read := func(t *testing.T, config DNSRecordsDataSourceModel) (DNSRecordsDataSourceModel, datasource.ReadResponse) {
+		t.Helper()
+
+		input := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, input.Set(context.Background(), &config).HasError())
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}, &resp)
+
+		var state DNSRecordsDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		}
+		return state, resp
+	}
+
+	names := func(state DNSRecordsDataSourceModel) []string {
+		var names []string
+		for _, record := range state.Records {
+			names = append(names, record.Name.ValueString())
+		}
+		return names
+	}
+
+	t.Run("name regex", func(t *testing.T) {
+		state, resp := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), NameRegex: types.StringValue(`^web[0-9]+\.`)})
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Equal(t, []string{"web1.example.com", "web2.example.com", "web3.example.com"}, names(state))
+	})
+
+	t.Run("invalid name regex", func(t *testing.T) {
+		_, resp := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), NameRegex: types.StringValue(`web(`)})
+		require.True(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		state, resp := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), Disabled: types.BoolValue(true)})
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Equal(t, []string{"web3.example.com"}, names(state))
+	})
+
+	t.Run("ttl less than", func(t *testing.T) {
+		state, resp := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), TTLLessThan: types.Int64Value(300)})
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Equal(t, []string{"web1.example.com", "mail.example.com"}, names(state))
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		state, resp := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), PageSize: types.Int64Value(2), PageNumber: types.Int64Value(3)})
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Equal(t, []string{"example.com"}, names(state))
+		require.Equal(t, int64(1), state.RecordCount.ValueInt64())
+		require.Equal(t, int64(5), state.TotalCount.ValueInt64())
+		require.Equal(t, int64(3), state.TotalPages.ValueInt64())
+		require.Equal(t, map[string]types.Int64{"A": types.Int64Value(4), "MX": types.Int64Value(1)}, state.RecordTypeCounts)
+	})
+
+	t.Run("page past the end", func(t *testing.T) {
+		state, resp := read(t, DNSRecordsDataSourceModel{Zone: types.StringValue("example.com"), PageSize: types.Int64Value(10), PageNumber: types.Int64Value(2)})
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Empty(t, state.Records)
+		require.Equal(t, int64(5), state.TotalCount.ValueInt64())
+		require.Equal(t, int64(1), state.TotalPages.ValueInt64())
+	})
+}
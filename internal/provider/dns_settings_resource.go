@@ -0,0 +1,491 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// dnsSettingsID is the ID of the singleton settings resource
+const dnsSettingsID = "dns_settings"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSSettingsResource{}
+var _ resource.ResourceWithImportState = &DNSSettingsResource{}
+var _ resource.ResourceWithModifyPlan = &DNSSettingsResource{}
+
+func NewDNSSettingsResource() resource.Resource {
+	return &DNSSettingsResource{}
+}
+
+// DNSSettingsResource defines the resource implementation.
+type DNSSettingsResource struct {
+	client client.ClientAPI
+}
+
+// DNSSettingsResourceModel describes the resource data model.
+type DNSSettingsResourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	DnsServerDomain           types.String `tfsdk:"dns_server_domain"`
+	DnsServerLocalEndPoints   types.List   `tfsdk:"dns_server_local_end_points"`
+	EnableDnsOverHttp         types.Bool   `tfsdk:"enable_dns_over_http"`
+	EnableDnsOverTls          types.Bool   `tfsdk:"enable_dns_over_tls"`
+	EnableDnsOverHttps        types.Bool   `tfsdk:"enable_dns_over_https"`
+	EnableDnsOverQuic         types.Bool   `tfsdk:"enable_dns_over_quic"`
+	DnsOverHttpPort           types.Int64  `tfsdk:"dns_over_http_port"`
+	DnsOverTlsPort            types.Int64  `tfsdk:"dns_over_tls_port"`
+	DnsOverHttpsPort          types.Int64  `tfsdk:"dns_over_https_port"`
+	DnsOverQuicPort           types.Int64  `tfsdk:"dns_over_quic_port"`
+	DnsTlsCertificatePath     types.String `tfsdk:"dns_tls_certificate_path"`
+	DnsTlsCertificatePassword types.String `tfsdk:"dns_tls_certificate_password"`
+	Recursion                 types.String `tfsdk:"recursion"`
+	RecursionNetworkACL       types.List   `tfsdk:"recursion_network_acl"`
+	Forwarders                types.List   `tfsdk:"forwarders"`
+	ForwarderProtocol         types.String `tfsdk:"forwarder_protocol"`
+	ConcurrentForwarding      types.Bool   `tfsdk:"concurrent_forwarding"`
+	CacheMaximumEntries       types.Int64  `tfsdk:"cache_maximum_entries"`
+	CacheMinimumRecordTTL     types.Int64  `tfsdk:"cache_minimum_record_ttl"`
+	CacheMaximumRecordTTL     types.Int64  `tfsdk:"cache_maximum_record_ttl"`
+	ServeStale                types.Bool   `tfsdk:"serve_stale"`
+	EnableBlocking            types.Bool   `tfsdk:"enable_blocking"`
+	BlockingType              types.String `tfsdk:"blocking_type"`
+	BlockingAnswerTTL         types.Int64  `tfsdk:"blocking_answer_ttl"`
+	CustomBlockingAddresses   types.List   `tfsdk:"custom_blocking_addresses"`
+	BlockingBypassList        types.List   `tfsdk:"blocking_bypass_list"`
+	EnableLogging             types.Bool   `tfsdk:"enable_logging"`
+	IgnoreResolverLogs        types.Bool   `tfsdk:"ignore_resolver_logs"`
+	LogQueries                types.Bool   `tfsdk:"log_queries"`
+	UseLocalTime              types.Bool   `tfsdk:"use_local_time"`
+	LogFolder                 types.String `tfsdk:"log_folder"`
+	MaxLogFileDays            types.Int64  `tfsdk:"max_log_file_days"`
+}
+
+func (r *DNSSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_settings"
+}
+
+func (r *DNSSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the global settings of the Technitium DNS Server. The server has a single set of settings, so declare this resource at most once per server. " +
+			"Only the attributes set in the configuration are managed; the others are read from the server and left untouched. " +
+			"Destroying the resource removes it from the state without changing the server settings. " +
+			"This resource is experimental: enable the `settings` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier, always `" + dnsSettingsID + "`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dns_server_domain": settingsStringAttribute("The primary domain name used by the DNS server to identify itself"),
+			"dns_server_local_end_points": settingsListAttribute(
+				"The local IP addresses and ports the DNS server listens on for requests, e.g. `0.0.0.0:53` or `[::]:53`",
+				listvalidator.SizeAtLeast(1),
+			),
+			"enable_dns_over_http": settingsBoolAttribute(
+				"Accept DNS-over-HTTP requests. Only use it behind a TLS terminating reverse proxy; it also allows renewing the TLS certificate with an HTTP challenge",
+			),
+			"enable_dns_over_tls":   settingsBoolAttribute("Accept DNS-over-TLS requests"),
+			"enable_dns_over_https": settingsBoolAttribute("Accept DNS-over-HTTPS requests"),
+			"enable_dns_over_quic":  settingsBoolAttribute("Accept DNS-over-QUIC requests. Requires Technitium DNS Server 11.0 or later"),
+			"dns_over_http_port": settingsInt64Attribute(
+				"The TCP port for DNS-over-HTTP requests",
+				int64validator.Between(1, 65535),
+			),
+			"dns_over_tls_port": settingsInt64Attribute(
+				"The TCP port for DNS-over-TLS requests",
+				int64validator.Between(1, 65535),
+			),
+			"dns_over_https_port": settingsInt64Attribute(
+				"The TCP port for DNS-over-HTTPS requests",
+				int64validator.Between(1, 65535),
+			),
+			"dns_over_quic_port": settingsInt64Attribute(
+				"The UDP port for DNS-over-QUIC requests",
+				int64validator.Between(1, 65535),
+			),
+			"dns_tls_certificate_path": settingsStringAttribute(
+				"The path on the server of the PKCS #12 (.pfx) certificate, including its private key, used by the DNS-over-TLS, DNS-over-HTTPS and DNS-over-QUIC protocols",
+			),
+			"dns_tls_certificate_password": schema.StringAttribute{
+				MarkdownDescription: "The password of the TLS certificate file. The API never returns it, so changes made outside of Terraform are not detected",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"recursion": settingsStringAttribute(
+				"The recursion policy of the DNS server. Valid values: "+enumDescription(recursionPolicyValues),
+				enumValidator(recursionPolicyValues),
+			),
+			"recursion_network_acl": settingsListAttribute(
+				"The networks allowed to use recursion when `recursion` is `UseSpecifiedNetworkACL`. Prefix an entry with `!` to deny it; entries are processed in order",
+			),
+			"forwarders": settingsListAttribute(
+				"The forwarders the DNS server sends queries to. An empty list removes the forwarders so the server resolves recursively by itself",
+				listvalidator.ValueStringsAre(forwarderAddressValidator{}),
+			),
+			"forwarder_protocol": settingsStringAttribute(
+				"The DNS transport used to reach the forwarders. Valid values: "+enumDescription(forwarderProtocolValues),
+				enumValidator(forwarderProtocolValues),
+			),
+			"concurrent_forwarding": settingsBoolAttribute("Query two or more forwarders concurrently and use the fastest response, instead of querying them in order"),
+			"cache_maximum_entries": settingsInt64Attribute(
+				"The maximum number of entries kept in the cache, `0` for no limit",
+				int64validator.AtLeast(0),
+			),
+			"cache_minimum_record_ttl": settingsInt64Attribute(
+				"The minimum TTL in seconds of a record in the cache",
+				int64validator.AtLeast(0),
+			),
+			"cache_maximum_record_ttl": settingsInt64Attribute(
+				"The maximum TTL in seconds of a record in the cache",
+				int64validator.AtLeast(0),
+			),
+			"serve_stale":     settingsBoolAttribute("Answer from expired cache records when the upstream or authoritative name servers cannot be reached"),
+			"enable_blocking": settingsBoolAttribute("Block domain names using the blocked zones and block lists"),
+			"blocking_type": settingsStringAttribute(
+				"How blocked domain requests are answered. Valid values: "+enumDescription(blockingTypeValues),
+				enumValidator(blockingTypeValues),
+			),
+			"blocking_answer_ttl": settingsInt64Attribute(
+				"The TTL in seconds of the records in a blocking response",
+				int64validator.AtLeast(0),
+			),
+			"custom_blocking_addresses": settingsListAttribute("The addresses returned for blocked domains when `blocking_type` is `CustomAddress`"),
+			"blocking_bypass_list":      settingsListAttribute("The client IP addresses or networks allowed to bypass blocking"),
+			"enable_logging":            settingsBoolAttribute("Write the error and audit logs"),
+			"ignore_resolver_logs":      settingsBoolAttribute("Leave domain name resolution errors out of the logs"),
+			"log_queries":               settingsBoolAttribute("Log every query received and the answers sent back"),
+			"use_local_time":            settingsBoolAttribute("Use the local time instead of UTC in the logs"),
+			"log_folder":                settingsStringAttribute("The folder the log files are written to, relative to the server config folder unless absolute"),
+			"max_log_file_days": settingsInt64Attribute(
+				"The number of days log files are kept, `0` to keep them forever",
+				int64validator.AtLeast(0),
+			),
+		},
+	}
+}
+
+// settingsStringAttribute returns an optional string setting that reflects the server value when not configured
+func settingsStringAttribute(description string, validators ...validator.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Computed:            true,
+		Validators:          validators,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// settingsBoolAttribute returns an optional bool setting that reflects the server value when not configured
+func settingsBoolAttribute(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// settingsInt64Attribute returns an optional number setting that reflects the server value when not configured
+func settingsInt64Attribute(description string, validators ...validator.Int64) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Computed:            true,
+		Validators:          validators,
+		PlanModifiers: []planmodifier.Int64{
+			int64planmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+// settingsListAttribute returns an optional string list setting that reflects the server value when not configured
+func settingsListAttribute(description string, validators ...validator.List) schema.ListAttribute {
+	return schema.ListAttribute{
+		MarkdownDescription: description,
+		ElementType:         types.StringType,
+		Optional:            true,
+		Computed:            true,
+		Validators:          validators,
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func (r *DNSSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DNSSettingsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Removing the resource from state needs no experimental flag
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	requireExperimentalFeature(ctx, r.client, client.ExperimentalSettings, "technitium_dns_settings", &resp.Diagnostics)
+
+	var config DNSSettingsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reject features the connected server version does not support before anything is applied
+	if config.EnableDnsOverQuic.ValueBool() {
+		requireServerFeature(ctx, r.client, client.FeatureQUIC, path.Root("enable_dns_over_quic"), &resp.Diagnostics)
+	}
+}
+
+func (r *DNSSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var config DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Applying DNS settings")
+
+	data, err := r.applySettings(ctx, &config)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to apply DNS settings: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *DNSSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading DNS settings")
+
+	settings, err := r.client.GetDNSSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS settings: %s", err.Error()))
+		return
+	}
+
+	data := dnsSettingsModel(settings)
+	// The API masks the certificate password, keep the known value
+	data.DnsTlsCertificatePassword = state.DnsTlsCertificatePassword
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *DNSSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var config DNSSettingsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating DNS settings")
+
+	data, err := r.applySettings(ctx, &config)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update DNS settings: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+func (r *DNSSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The server always has settings, so there is nothing to delete
+	tflog.Debug(ctx, "Removing DNS settings from state, the server settings are left unchanged")
+}
+
+func (r *DNSSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// There is a single set of settings, so any import ID refers to it
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dnsSettingsID)...)
+}
+
+// applySettings saves the configured settings and returns the resulting state
+func (r *DNSSettingsResource) applySettings(ctx context.Context, config *DNSSettingsResourceModel) (*DNSSettingsResourceModel, error) {
+	options := dnsSettingsOptions(ctx, config)
+
+	var settings *client.DNSSettings
+	var err error
+	if len(options) == 0 {
+		settings, err = r.client.GetDNSSettings(ctx)
+	} else {
+		settings, err = r.client.SetDNSSettings(ctx, options)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data := dnsSettingsModel(settings)
+	// The API masks the certificate password, keep the configured value
+	data.DnsTlsCertificatePassword = config.DnsTlsCertificatePassword
+
+	return data, nil
+}
+
+// dnsSettingsOptions converts the configured settings into settings/set API parameters. Settings
+// missing from the configuration are left out so their server values are kept.
+func dnsSettingsOptions(ctx context.Context, config *DNSSettingsResourceModel) map[string]string {
+	options := make(map[string]string)
+
+	setString := func(name string, value types.String) {
+		if !value.IsNull() && !value.IsUnknown() {
+			options[name] = value.ValueString()
+		}
+	}
+	setBool := func(name string, value types.Bool) {
+		if !value.IsNull() && !value.IsUnknown() {
+			options[name] = strconv.FormatBool(value.ValueBool())
+		}
+	}
+	setInt64 := func(name string, value types.Int64) {
+		if !value.IsNull() && !value.IsUnknown() {
+			options[name] = strconv.FormatInt(value.ValueInt64(), 10)
+		}
+	}
+	setList := func(name string, value types.List) {
+		if value.IsNull() || value.IsUnknown() {
+			return
+		}
+		var values []string
+		value.ElementsAs(ctx, &values, false)
+		if len(values) == 0 {
+			// The API clears a list setting when it is set to "false"
+			options[name] = "false"
+			return
+		}
+		options[name] = strings.Join(values, ",")
+	}
+
+	setString("dnsServerDomain", config.DnsServerDomain)
+	setList("dnsServerLocalEndPoints", config.DnsServerLocalEndPoints)
+	setBool("enableDnsOverHttp", config.EnableDnsOverHttp)
+	setBool("enableDnsOverTls", config.EnableDnsOverTls)
+	setBool("enableDnsOverHttps", config.EnableDnsOverHttps)
+	setBool("enableDnsOverQuic", config.EnableDnsOverQuic)
+	setInt64("dnsOverHttpPort", config.DnsOverHttpPort)
+	setInt64("dnsOverTlsPort", config.DnsOverTlsPort)
+	setInt64("dnsOverHttpsPort", config.DnsOverHttpsPort)
+	setInt64("dnsOverQuicPort", config.DnsOverQuicPort)
+	setString("dnsTlsCertificatePath", config.DnsTlsCertificatePath)
+	setString("dnsTlsCertificatePassword", config.DnsTlsCertificatePassword)
+	setString("recursion", config.Recursion)
+	setList("recursionNetworkACL", config.RecursionNetworkACL)
+	setList("forwarders", config.Forwarders)
+	setString("forwarderProtocol", config.ForwarderProtocol)
+	setBool("concurrentForwarding", config.ConcurrentForwarding)
+	setInt64("cacheMaximumEntries", config.CacheMaximumEntries)
+	setInt64("cacheMinimumRecordTtl", config.CacheMinimumRecordTTL)
+	setInt64("cacheMaximumRecordTtl", config.CacheMaximumRecordTTL)
+	setBool("serveStale", config.ServeStale)
+	setBool("enableBlocking", config.EnableBlocking)
+	setString("blockingType", config.BlockingType)
+	setInt64("blockingAnswerTtl", config.BlockingAnswerTTL)
+	setList("customBlockingAddresses", config.CustomBlockingAddresses)
+	setList("blockingBypassList", config.BlockingBypassList)
+	setBool("enableLogging", config.EnableLogging)
+	setBool("ignoreResolverLogs", config.IgnoreResolverLogs)
+	setBool("logQueries", config.LogQueries)
+	setBool("useLocalTime", config.UseLocalTime)
+	setString("logFolder", config.LogFolder)
+	setInt64("maxLogFileDays", config.MaxLogFileDays)
+
+	return options
+}
+
+// dnsSettingsModel converts the server settings into the resource state
+func dnsSettingsModel(settings *client.DNSSettings) *DNSSettingsResourceModel {
+	return &DNSSettingsResourceModel{
+		ID:                        types.StringValue(dnsSettingsID),
+		DnsServerDomain:           types.StringValue(settings.DnsServerDomain),
+		DnsServerLocalEndPoints:   stringListValue(settings.DnsServerLocalEndPoints),
+		EnableDnsOverHttp:         types.BoolValue(settings.EnableDnsOverHttp),
+		EnableDnsOverTls:          types.BoolValue(settings.EnableDnsOverTls),
+		EnableDnsOverHttps:        types.BoolValue(settings.EnableDnsOverHttps),
+		EnableDnsOverQuic:         types.BoolValue(settings.EnableDnsOverQuic),
+		DnsOverHttpPort:           types.Int64Value(settings.DnsOverHttpPort),
+		DnsOverTlsPort:            types.Int64Value(settings.DnsOverTlsPort),
+		DnsOverHttpsPort:          types.Int64Value(settings.DnsOverHttpsPort),
+		DnsOverQuicPort:           types.Int64Value(settings.DnsOverQuicPort),
+		DnsTlsCertificatePath:     types.StringValue(settings.DnsTlsCertificatePath),
+		DnsTlsCertificatePassword: types.StringNull(),
+		Recursion:                 types.StringValue(settings.Recursion),
+		RecursionNetworkACL:       stringListValue(settings.RecursionNetworkACL),
+		Forwarders:                stringListValue(settings.Forwarders),
+		ForwarderProtocol:         types.StringValue(settings.ForwarderProtocol),
+		ConcurrentForwarding:      types.BoolValue(settings.ConcurrentForwarding),
+		CacheMaximumEntries:       types.Int64Value(settings.CacheMaximumEntries),
+		CacheMinimumRecordTTL:     types.Int64Value(settings.CacheMinimumRecordTTL),
+		CacheMaximumRecordTTL:     types.Int64Value(settings.CacheMaximumRecordTTL),
+		ServeStale:                types.BoolValue(settings.ServeStale),
+		EnableBlocking:            types.BoolValue(settings.EnableBlocking),
+		BlockingType:              types.StringValue(settings.BlockingType),
+		BlockingAnswerTTL:         types.Int64Value(settings.BlockingAnswerTTL),
+		CustomBlockingAddresses:   stringListValue(settings.CustomBlockingAddresses),
+		BlockingBypassList:        stringListValue(settings.BlockingBypassList),
+		EnableLogging:             types.BoolValue(settings.EnableLogging),
+		IgnoreResolverLogs:        types.BoolValue(settings.IgnoreResolverLogs),
+		LogQueries:                types.BoolValue(settings.LogQueries),
+		UseLocalTime:              types.BoolValue(settings.UseLocalTime),
+		LogFolder:                 types.StringValue(settings.LogFolder),
+		MaxLogFileDays:            types.Int64Value(settings.MaxLogFileDays),
+	}
+}
+
+// stringListValue converts a string slice into a list value, treating nil as an empty list
+func stringListValue(values []string) types.List {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+// nullDNSSettingsModel returns a settings model with every setting left out of the configuration
+func nullDNSSettingsModel() DNSSettingsResourceModel {
+	return DNSSettingsResourceModel{
+		ID:                        types.StringNull(),
+		DnsServerDomain:           types.StringNull(),
+		DnsServerLocalEndPoints:   types.ListNull(types.StringType),
+		EnableDnsOverHttp:         types.BoolNull(),
+		EnableDnsOverTls:          types.BoolNull(),
+		EnableDnsOverHttps:        types.BoolNull(),
+		EnableDnsOverQuic:         types.BoolNull(),
+		DnsOverHttpPort:           types.Int64Null(),
+		DnsOverTlsPort:            types.Int64Null(),
+		DnsOverHttpsPort:          types.Int64Null(),
+		DnsOverQuicPort:           types.Int64Null(),
+		DnsTlsCertificatePath:     types.StringNull(),
+		DnsTlsCertificatePassword: types.StringNull(),
+		Recursion:                 types.StringNull(),
+		RecursionNetworkACL:       types.ListNull(types.StringType),
+		Forwarders:                types.ListNull(types.StringType),
+		ForwarderProtocol:         types.StringNull(),
+		ConcurrentForwarding:      types.BoolNull(),
+		CacheMaximumEntries:       types.Int64Null(),
+		CacheMinimumRecordTTL:     types.Int64Null(),
+		CacheMaximumRecordTTL:     types.Int64Null(),
+		ServeStale:                types.BoolNull(),
+		EnableBlocking:            types.BoolNull(),
+		BlockingType:              types.StringNull(),
+		BlockingAnswerTTL:         types.Int64Null(),
+		CustomBlockingAddresses:   types.ListNull(types.StringType),
+		BlockingBypassList:        types.ListNull(types.StringType),
+		EnableLogging:             types.BoolNull(),
+		IgnoreResolverLogs:        types.BoolNull(),
+		LogQueries:                types.BoolNull(),
+		UseLocalTime:              types.BoolNull(),
+		LogFolder:                 types.StringNull(),
+		MaxLogFileDays:            types.Int64Null(),
+	}
+}
+
+func TestDNSSettingsResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewDNSSettingsResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_dns_settings" {
+			t.Errorf("Expected TypeName to be technitium_dns_settings, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewDNSSettingsResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "forwarders", "forwarder_protocol", "recursion", "cache_maximum_entries", "enable_blocking", "log_queries", "dns_server_local_end_points"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+	})
+
+	t.Run("Configure", func(t *testing.T) {
+		r := NewDNSSettingsResource().(*DNSSettingsResource)
+		var resp resource.ConfigureResponse
+
+		r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: nil}, &resp)
+		if resp.Diagnostics.HasError() {
+			t.Error("Configure should not error with nil provider data")
+		}
+
+		r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: "wrong type"}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Error("Configure should error with wrong provider data type")
+		}
+	})
+}
+
+func TestDNSSettingsOptions(t *testing.T) {
+	t.Parallel()
+
+	config := nullDNSSettingsModel()
+	config.Forwarders = stringListValue([]string{"1.1.1.1", "8.8.8.8"})
+	config.RecursionNetworkACL = stringListValue(nil)
+	config.Recursion = types.StringValue("UseSpecifiedNetworkACL")
+	config.LogQueries = types.BoolValue(true)
+	config.CacheMaximumEntries = types.Int64Value(0)
+	config.EnableDnsOverHttps = types.BoolValue(true)
+	config.DnsOverHttpsPort = types.Int64Value(8443)
+	config.DnsTlsCertificatePath = types.StringValue("/etc/dns/cert.pfx")
+
+	options := dnsSettingsOptions(context.Background(), &config)
+	require.Equal(t, map[string]string{
+		"forwarders":            "1.1.1.1,8.8.8.8",
+		"recursionNetworkACL":   "false",
+		"recursion":             "UseSpecifiedNetworkACL",
+		"logQueries":            "true",
+		"cacheMaximumEntries":   "0",
+		"enableDnsOverHttps":    "true",
+		"dnsOverHttpsPort":      "8443",
+		"dnsTlsCertificatePath": "/etc/dns/cert.pfx",
+	}, options)
+}
+
+func TestDNSSettingsResourceCreate(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := &DNSSettingsResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	m.On("SetDNSSettings", mock.Anything, map[string]string{"forwarders": "9.9.9.9", "forwarderProtocol": "Tls", "dnsTlsCertificatePassword": "secret"}).
+		Return(&client.DNSSettings{
+			DnsTlsCertificatePassword: "************",
+			DnsServerDomain:           "server1",
+			DnsServerLocalEndPoints:   []string{"0.0.0.0:53"},
+			Recursion:                 "AllowOnlyForPrivateNetworks",
+			Forwarders:                []string{"9.9.9.9"},
+			ForwarderProtocol:         "Tls",
+			BlockingType:              "NxDomain",
+			LogFolder:                 "logs",
+		}, nil)
+
+	config := nullDNSSettingsModel()
+	config.Forwarders = stringListValue([]string{"9.9.9.9"})
+	config.ForwarderProtocol = types.StringValue("Tls")
+	config.DnsTlsCertificatePassword = types.StringValue("secret")
+	input := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, input.Set(context.Background(), &config).HasError())
+
+	req := resource.CreateRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), req, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+	var state DNSSettingsResourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, dnsSettingsID, state.ID.ValueString())
+	require.Equal(t, "server1", state.DnsServerDomain.ValueString())
+	require.Equal(t, "AllowOnlyForPrivateNetworks", state.Recursion.ValueString())
+	require.Len(t, state.RecursionNetworkACL.Elements(), 0)
+	require.Equal(t, "secret", state.DnsTlsCertificatePassword.ValueString(), "the masked password must not replace the configured one")
+}
+
+func TestDNSSettingsResourceModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	modifyPlan := func(t *testing.T, r *DNSSettingsResource, model DNSSettingsResourceModel) resource.ModifyPlanResponse {
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(context.Background(), &model).HasError())
+		config := tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}
+
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Plan: plan, Config: config}, &resp)
+		return resp
+	}
+
+	t.Run("requires the settings feature flag", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("ExperimentEnabled", client.ExperimentalSettings).Return(false)
+
+		resp := modifyPlan(t, &DNSSettingsResource{client: m}, nullDNSSettingsModel())
+		require.True(t, resp.Diagnostics.HasError(), "expected the settings feature flag to be required")
+		require.Equal(t, "Experimental feature not enabled", resp.Diagnostics.Errors()[0].Summary())
+	})
+
+	t.Run("DNS-over-QUIC requires a supporting server", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("ExperimentEnabled", client.ExperimentalSettings).Return(true)
+		m.On("SupportsFeature", mock.Anything, client.FeatureQUIC).Return(false, "10.0", "11.0", nil)
+
+		model := nullDNSSettingsModel()
+		model.EnableDnsOverQuic = types.BoolValue(true)
+		resp := modifyPlan(t, &DNSSettingsResource{client: m}, model)
+		require.True(t, resp.Diagnostics.HasError(), "expected DNS-over-QUIC to be rejected")
+		require.Equal(t, "Unsupported by DNS server version", resp.Diagnostics.Errors()[0].Summary())
+	})
+}
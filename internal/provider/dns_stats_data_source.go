@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &DNSStatsDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &DNSStatsDataSource{}
+
+func NewDNSStatsDataSource() datasource.DataSource {
+	return &DNSStatsDataSource{}
+}
+
+// DNSStatsDataSource defines the data source implementation.
+type DNSStatsDataSource struct {
+	client client.ClientAPI
+}
+
+// DNSStatsDataSourceModel describes the data source data model.
+type DNSStatsDataSourceModel struct {
+	ID                 types.String      `tfsdk:"id"`
+	Period             types.String      `tfsdk:"period"`
+	Start              types.String      `tfsdk:"start"`
+	End                types.String      `tfsdk:"end"`
+	TotalQueries       types.Int64       `tfsdk:"total_queries"`
+	TotalNoError       types.Int64       `tfsdk:"total_no_error"`
+	TotalServerFailure types.Int64       `tfsdk:"total_server_failure"`
+	TotalNxDomain      types.Int64       `tfsdk:"total_nx_domain"`
+	TotalRefused       types.Int64       `tfsdk:"total_refused"`
+	TotalAuthoritative types.Int64       `tfsdk:"total_authoritative"`
+	TotalRecursive     types.Int64       `tfsdk:"total_recursive"`
+	TotalCached        types.Int64       `tfsdk:"total_cached"`
+	TotalBlocked       types.Int64       `tfsdk:"total_blocked"`
+	TotalDropped       types.Int64       `tfsdk:"total_dropped"`
+	TotalClients       types.Int64       `tfsdk:"total_clients"`
+	Zones              types.Int64       `tfsdk:"zones"`
+	CachedEntries      types.Int64       `tfsdk:"cached_entries"`
+	AllowedZones       types.Int64       `tfsdk:"allowed_zones"`
+	BlockedZones       types.Int64       `tfsdk:"blocked_zones"`
+	TopClients         []TopStatDataItem `tfsdk:"top_clients"`
+	TopDomains         []TopStatDataItem `tfsdk:"top_domains"`
+	TopBlockedDomains  []TopStatDataItem `tfsdk:"top_blocked_domains"`
+}
+
+// TopStatDataItem represents an entry of the top clients or domains
+type TopStatDataItem struct {
+	Name   types.String `tfsdk:"name"`
+	Domain types.String `tfsdk:"domain"`
+	Hits   types.Int64  `tfsdk:"hits"`
+}
+
+func (d *DNSStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dns_stats"
+}
+
+// topStatsAttribute returns the schema of a top clients or domains list
+func topStatsAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "The client address or the domain name.",
+					Computed:            true,
+				},
+				"domain": schema.StringAttribute{
+					MarkdownDescription: "The reverse lookup of the client address. Empty for domains and clients without one.",
+					Computed:            true,
+				},
+				"hits": schema.Int64Attribute{
+					MarkdownDescription: "The number of queries.",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func (d *DNSStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	counter := func(description string) schema.Int64Attribute {
+		return schema.Int64Attribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Data source reading the dashboard statistics of the DNS server",
+		MarkdownDescription: "Data source reading the dashboard statistics of the DNS server over a period, e.g. so monitoring stacks " +
+			"bootstrapped with Terraform can check that the server is serving traffic.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"period": schema.StringAttribute{
+				MarkdownDescription: "The period the statistics cover. Valid values are: " + enumDescription(statsPeriodValues) +
+					". Defaults to `" + string(client.DefaultStatsPeriod) + "`. `Custom` requires `start` and `end`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					enumValidator(statsPeriodValues),
+				},
+			},
+			"start": schema.StringAttribute{
+				MarkdownDescription: "The start of a `Custom` period as an RFC 3339 date, e.g. `2026-10-01T00:00:00Z`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("end")),
+				},
+			},
+			"end": schema.StringAttribute{
+				MarkdownDescription: "The end of a `Custom` period as an RFC 3339 date.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("start")),
+				},
+			},
+			"total_queries":        counter("The number of queries received."),
+			"total_no_error":       counter("The number of queries answered with NOERROR."),
+			"total_server_failure": counter("The number of queries answered with SERVFAIL."),
+			"total_nx_domain":      counter("The number of queries answered with NXDOMAIN."),
+			"total_refused":        counter("The number of queries answered with REFUSED."),
+			"total_authoritative":  counter("The number of queries answered from authoritative zones."),
+			"total_recursive":      counter("The number of queries answered by recursive resolution."),
+			"total_cached":         counter("The number of queries answered from the cache."),
+			"total_blocked":        counter("The number of blocked queries."),
+			"total_dropped":        counter("The number of dropped queries."),
+			"total_clients":        counter("The number of distinct clients."),
+			"zones":                counter("The current number of authoritative zones."),
+			"cached_entries":       counter("The current number of cache entries."),
+			"allowed_zones":        counter("The current number of allowed zones."),
+			"blocked_zones":        counter("The current number of blocked zones."),
+			"top_clients":          topStatsAttribute("The clients that sent the most queries, in descending order."),
+			"top_domains":          topStatsAttribute("The most queried domains, in descending order."),
+			"top_blocked_domains":  topStatsAttribute("The most queried blocked domains, in descending order."),
+		},
+	}
+}
+
+func (d *DNSStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DNSStatsDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data DNSStatsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Period.IsUnknown() {
+		return
+	}
+
+	custom := data.Period.ValueString() == string(client.StatsPeriodCustom)
+	if custom && (data.Start.IsNull() || data.End.IsNull()) {
+		resp.Diagnostics.AddAttributeError(path.Root("period"), "Missing Custom Period",
+			"The Custom period requires start and end.")
+	}
+	if !custom && (!data.Start.IsNull() || !data.End.IsNull()) {
+		resp.Diagnostics.AddAttributeError(path.Root("start"), "Unexpected Period Range",
+			"start and end only apply to the Custom period.")
+	}
+
+	for _, attribute := range []struct {
+		name  string
+		value types.String
+	}{{"start", data.Start}, {"end", data.End}} {
+		if attribute.value.IsNull() || attribute.value.IsUnknown() {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, attribute.value.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute.name), "Invalid Date",
+				fmt.Sprintf("%q is not an RFC 3339 date: %s", attribute.value.ValueString(), err.Error()))
+		}
+	}
+}
+
+func (d *DNSStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DNSStatsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Period.IsNull() {
+		data.Period = types.StringValue(string(client.DefaultStatsPeriod))
+	}
+	period := client.StatsPeriod(data.Period.ValueString())
+
+	tflog.Debug(ctx, "Reading DNS stats data source", map[string]interface{}{
+		"period": period,
+	})
+
+	stats, err := d.client.GetDashboardStats(ctx, period, data.Start.ValueString(), data.End.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DNS stats",
+			fmt.Sprintf("Could not read dashboard stats: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("stats:" + string(period))
+	data.TotalQueries = types.Int64Value(stats.Stats.TotalQueries)
+	data.TotalNoError = types.Int64Value(stats.Stats.TotalNoError)
+	data.TotalServerFailure = types.Int64Value(stats.Stats.TotalServerFailure)
+	data.TotalNxDomain = types.Int64Value(stats.Stats.TotalNxDomain)
+	data.TotalRefused = types.Int64Value(stats.Stats.TotalRefused)
+	data.TotalAuthoritative = types.Int64Value(stats.Stats.TotalAuthoritative)
+	data.TotalRecursive = types.Int64Value(stats.Stats.TotalRecursive)
+	data.TotalCached = types.Int64Value(stats.Stats.TotalCached)
+	data.TotalBlocked = types.Int64Value(stats.Stats.TotalBlocked)
+	data.TotalDropped = types.Int64Value(stats.Stats.TotalDropped)
+	data.TotalClients = types.Int64Value(stats.Stats.TotalClients)
+	data.Zones = types.Int64Value(stats.Stats.Zones)
+	data.CachedEntries = types.Int64Value(stats.Stats.CachedEntries)
+	data.AllowedZones = types.Int64Value(stats.Stats.AllowedZones)
+	data.BlockedZones = types.Int64Value(stats.Stats.BlockedZones)
+	data.TopClients = topStatDataItems(stats.TopClients)
+	data.TopDomains = topStatDataItems(stats.TopDomains)
+	data.TopBlockedDomains = topStatDataItems(stats.TopBlockedDomains)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// topStatDataItems converts top clients or domains into data source items
+func topStatDataItems(stats []client.TopStat) []TopStatDataItem {
+	items := make([]TopStatDataItem, 0, len(stats))
+	for _, stat := range stats {
+		items = append(items, TopStatDataItem{
+			Name:   types.StringValue(stat.Name),
+			Domain: types.StringValue(stat.Domain),
+			Hits:   types.Int64Value(stat.Hits),
+		})
+	}
+	return items
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+// dnsStatsConfig returns a configuration of the stats data source with the given period and range
+func dnsStatsConfig(t *testing.T, d *DNSStatsDataSource, period, start, end types.String) tfsdk.Config {
+	t.Helper()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	input := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, input.Set(context.Background(), &DNSStatsDataSourceModel{Period: period, Start: start, End: end}).HasError())
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}
+}
+
+func TestUnitDNSStatsDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &DNSStatsDataSource{client: m}
+
+	m.On("GetDashboardStats", mock.Anything, client.StatsPeriodLastHour, "", "").Return(&client.DashboardStatsResponse{
+		Stats:             client.DashboardStats{TotalQueries: 925, TotalBlocked: 49, TotalClients: 6, Zones: 19},
+		TopClients:        []client.TopStat{{Name: "192.168.10.5", Domain: "server1.home", Hits: 463}},
+		TopDomains:        []client.TopStat{{Name: "example.com", Hits: 52}},
+		TopBlockedDomains: []client.TopStat{},
+	}, nil)
+
+	config := dnsStatsConfig(t, d, types.StringNull(), types.StringNull(), types.StringNull())
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: config.Schema}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: config}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var state DNSStatsDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "LastHour", state.Period.ValueString())
+	require.Equal(t, int64(925), state.TotalQueries.ValueInt64())
+	require.Equal(t, int64(49), state.TotalBlocked.ValueInt64())
+	require.Equal(t, int64(6), state.TotalClients.ValueInt64())
+	require.Len(t, state.TopClients, 1)
+	require.Equal(t, "server1.home", state.TopClients[0].Domain.ValueString())
+	require.Equal(t, "", state.TopDomains[0].Domain.ValueString())
+	require.Empty(t, state.TopBlockedDomains)
+}
+
+func TestDNSStatsDataSourceValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		period, start, end types.String
+		expectError        bool
+	}{
+		"default period": {
+			period: types.StringNull(), start: types.StringNull(), end: types.StringNull(),
+		},
+		"custom period": {
+			period: types.StringValue("Custom"), start: types.StringValue("2026-10-01T00:00:00Z"), end: types.StringValue("2026-10-02T00:00:00Z"),
+		},
+		"custom period without range": {
+			period: types.StringValue("Custom"), start: types.StringNull(), end: types.StringNull(),
+			expectError: true,
+		},
+		"range without custom period": {
+			period: types.StringValue("LastDay"), start: types.StringValue("2026-10-01T00:00:00Z"), end: types.StringValue("2026-10-02T00:00:00Z"),
+			expectError: true,
+		},
+		"invalid date": {
+			period: types.StringValue("Custom"), start: types.StringValue("2026-10-01"), end: types.StringValue("2026-10-02T00:00:00Z"),
+			expectError: true,
+		},
+		"unknown range": {
+			period: types.StringValue("Custom"), start: types.StringUnknown(), end: types.StringUnknown(),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := &DNSStatsDataSource{}
+			resp := datasource.ValidateConfigResponse{}
+			d.ValidateConfig(context.Background(), datasource.ValidateConfigRequest{Config: dnsStatsConfig(t, d, tt.period, tt.start, tt.end)}, &resp)
+			require.Equal(t, tt.expectError, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+		})
+	}
+}
@@ -22,7 +22,7 @@ func NewDNSStoreAppsDataSource() datasource.DataSource {
 
 // DNSStoreAppsDataSource defines the data source implementation.
 type DNSStoreAppsDataSource struct {
-	client *client.Client
+	client client.ClientAPI
 }
 
 // DNSStoreAppsDataSourceModel describes the data source data model.
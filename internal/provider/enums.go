@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// The allowed values of enumerated attributes are defined once in the client, which serializes
+// them to the API, so the zone, record and settings resources cannot drift apart.
+
+var (
+	forwarderProtocolValues    = client.EnumValues(client.ForwarderProtocols())
+	zoneTransferProtocolValues = client.EnumValues(client.ZoneTransferProtocols())
+	zoneTransferAccessValues   = client.EnumValues(client.ZoneTransferAccesses())
+	zoneNotifyValues           = client.EnumValues(client.ZoneNotifies())
+	zoneQueryAccessValues      = client.EnumValues(client.ZoneQueryAccesses())
+	zoneUpdateValues           = client.EnumValues(client.ZoneUpdates())
+	proxyTypeValues            = client.EnumValues(client.ProxyTypes())
+	recursionPolicyValues      = client.EnumValues(client.RecursionPolicies())
+	blockingTypeValues         = client.EnumValues(client.BlockingTypes())
+	permissionSectionValues    = client.EnumValues(client.PermissionSections())
+	tsigAlgorithmValues        = client.EnumValues(client.TsigAlgorithms())
+	dnssecAlgorithmValues      = client.EnumValues(client.DNSSECAlgorithms())
+	dnssecHashAlgorithmValues  = client.EnumValues(client.DNSSECHashAlgorithms())
+	dnssecCurveValues          = client.EnumValues(client.DNSSECCurves())
+	dnssecNxProofValues        = client.EnumValues(client.DNSSECNxProofs())
+	dnssecKeyTypeValues        = client.EnumValues(client.DNSSECKeyTypes())
+	statsPeriodValues          = client.EnumValues(client.StatsPeriods())
+)
+
+// enumValidator validates that a string attribute holds one of the given values
+func enumValidator(values []string) validator.String {
+	return stringvalidator.OneOf(values...)
+}
+
+// enumDescription renders the allowed values for attribute descriptions
+func enumDescription(values []string) string {
+	return strings.Join(values, ", ")
+}
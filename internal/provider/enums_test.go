@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestEnumValidatorsInSync checks that the zone, record and settings resources accept exactly the shared values
+func TestEnumValidatorsInSync(t *testing.T) {
+	t.Parallel()
+
+	var recordSchema, zoneSchema, settingsSchema resource.SchemaResponse
+	NewDNSRecordResource().Schema(context.Background(), resource.SchemaRequest{}, &recordSchema)
+	NewZoneResource().Schema(context.Background(), resource.SchemaRequest{}, &zoneSchema)
+	NewDNSSettingsResource().Schema(context.Background(), resource.SchemaRequest{}, &settingsSchema)
+
+	tests := []struct {
+		name       string
+		validators []validator.String
+		allowed    []string
+	}{
+		{"record protocol", stringValidators(t, recordSchema, "protocol"), forwarderProtocolValues},
+		{"record proxy_type", stringValidators(t, recordSchema, "proxy_type"), proxyTypeValues},
+		{"zone protocol", stringValidators(t, zoneSchema, "protocol"), forwarderProtocolValues},
+		{"zone proxy_type", stringValidators(t, zoneSchema, "proxy_type"), proxyTypeValues},
+		{"zone zone_transfer_protocol", stringValidators(t, zoneSchema, "zone_transfer_protocol"), zoneTransferProtocolValues},
+		{"settings forwarder_protocol", stringValidators(t, settingsSchema, "forwarder_protocol"), forwarderProtocolValues},
+		{"settings recursion", stringValidators(t, settingsSchema, "recursion"), recursionPolicyValues},
+		{"settings blocking_type", stringValidators(t, settingsSchema, "blocking_type"), blockingTypeValues},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, value := range append(slices.Clone(tt.allowed), "invalid") {
+				resp := &validator.StringResponse{}
+				for _, v := range tt.validators {
+					v.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringValue(value)}, resp)
+				}
+
+				expectValid := value != "invalid"
+				if resp.Diagnostics.HasError() == expectValid {
+					t.Errorf("Value %q: expected valid=%v, got diagnostics %v", value, expectValid, resp.Diagnostics)
+				}
+			}
+		})
+	}
+}
+
+// stringValidators returns the validators of a string attribute in a resource schema
+func stringValidators(t *testing.T, schemaResp resource.SchemaResponse, name string) []validator.String {
+	t.Helper()
+
+	attr, ok := schemaResp.Schema.Attributes[name]
+	if !ok {
+		t.Fatalf("Schema should have '%s' attribute", name)
+	}
+	stringAttr, ok := attr.(interface{ StringValidators() []validator.String })
+	if !ok {
+		t.Fatalf("'%s' attribute should be a string attribute", name)
+	}
+	return stringAttr.StringValidators()
+}
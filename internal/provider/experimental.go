@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// experimentalFeatureNames renders the known experimental feature flags as a comma separated list
+func experimentalFeatureNames() string {
+	features := client.ExperimentalFeatures()
+	names := make([]string, 0, len(features))
+	for _, feature := range features {
+		names = append(names, string(feature))
+	}
+	return strings.Join(names, ", ")
+}
+
+// requireExperimentalFeature adds an error naming the provider flag needed to use an experimental
+// resource or data source. Resources call it from ModifyPlan so that destroying an existing
+// resource keeps working after the flag has been removed again.
+func requireExperimentalFeature(ctx context.Context, c client.ClientAPI, feature client.ExperimentalFeature, typeName string, diags *diag.Diagnostics) {
+	if c == nil {
+		return
+	}
+
+	if c.ExperimentEnabled(feature) {
+		tflog.Debug(ctx, "Using experimental feature", map[string]interface{}{
+			"feature": string(feature),
+			"type":    typeName,
+		})
+		return
+	}
+
+	diags.AddError(
+		"Experimental feature not enabled",
+		fmt.Sprintf("%s is experimental and may change in backwards incompatible ways before it is considered stable. "+
+			"To use it, enable the %q feature in the provider configuration:\n\n"+
+			"provider \"technitium\" {\n  experimental_features = [%q]\n}", typeName, feature, feature),
+	)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestRequireExperimentalFeature(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	m.On("ExperimentEnabled", client.ExperimentalDHCP).Return(true)
+	m.On("ExperimentEnabled", client.ExperimentalDNSSEC).Return(false)
+
+	var diags diag.Diagnostics
+	requireExperimentalFeature(context.Background(), m, client.ExperimentalDHCP, "technitium_dhcp_scope", &diags)
+	if diags.HasError() {
+		t.Fatalf("Expected no error for an enabled feature, got %v", diags)
+	}
+
+	requireExperimentalFeature(context.Background(), m, client.ExperimentalDNSSEC, "technitium_dnssec_signing", &diags)
+	if !diags.HasError() {
+		t.Fatal("Expected an error for a disabled feature")
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, `experimental_features = ["dnssec"]`) {
+		t.Errorf("Expected the error to name the required flag, got %q", detail)
+	}
+}
+
+func TestExperimentEnabled(t *testing.T) {
+	t.Parallel()
+
+	c, err := client.NewClient(client.Config{
+		Host:                 "http://localhost:5380",
+		Token:                "test-token",
+		ExperimentalFeatures: []string{"settings"},
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+
+	if !c.ExperimentEnabled(client.ExperimentalSettings) {
+		t.Error("Expected the settings feature to be enabled")
+	}
+	if c.ExperimentEnabled(client.ExperimentalDHCP) {
+		t.Error("Expected the dhcp feature to be disabled")
+	}
+	if names := experimentalFeatureNames(); names != "settings, dhcp, dnssec, admin" {
+		t.Errorf("Unexpected feature names: %s", names)
+	}
+}
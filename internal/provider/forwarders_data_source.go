@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// forwarderFetchConcurrency limits the number of zones whose records are fetched in parallel
+const forwarderFetchConcurrency = 8
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ForwardersDataSource{}
+
+func NewForwardersDataSource() datasource.DataSource {
+	return &ForwardersDataSource{}
+}
+
+// ForwardersDataSource defines the data source implementation.
+type ForwardersDataSource struct {
+	client client.ClientAPI
+}
+
+// ForwardersDataSourceModel describes the data source data model.
+type ForwardersDataSourceModel struct {
+	ID         types.String        `tfsdk:"id"`
+	Forwarders []ForwarderDataItem `tfsdk:"forwarders"`
+}
+
+// ForwarderDataItem represents a single FWD record of a conditional forwarder zone
+type ForwarderDataItem struct {
+	Zone              types.String `tfsdk:"zone"`
+	Domain            types.String `tfsdk:"domain"`
+	Forwarder         types.String `tfsdk:"forwarder"`
+	Protocol          types.String `tfsdk:"protocol"`
+	ForwarderPriority types.Int64  `tfsdk:"forwarder_priority"`
+	DnssecValidation  types.Bool   `tfsdk:"dnssec_validation"`
+	ProxyType         types.String `tfsdk:"proxy_type"`
+	ZoneDisabled      types.Bool   `tfsdk:"zone_disabled"`
+	Disabled          types.Bool   `tfsdk:"disabled"`
+}
+
+func (d *ForwardersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_forwarders"
+}
+
+func (d *ForwardersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Data source listing all conditional forwarder zones and their upstream targets",
+		MarkdownDescription: "Data source listing all conditional forwarder zones and their upstream targets across the server, giving a single view of which domains are forwarded where.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"forwarders": schema.ListNestedAttribute{
+				MarkdownDescription: "List of FWD records found in conditional forwarder zones, ordered by zone.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"zone": schema.StringAttribute{
+							MarkdownDescription: "The conditional forwarder zone name.",
+							Computed:            true,
+						},
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain the forwarder applies to. Differs from the zone for per-domain overrides inside the zone.",
+							Computed:            true,
+						},
+						"forwarder": schema.StringAttribute{
+							MarkdownDescription: "The upstream forwarder address, or 'this-server' to resolve recursively.",
+							Computed:            true,
+						},
+						"protocol": schema.StringAttribute{
+							MarkdownDescription: "The protocol used to reach the forwarder (" + enumDescription(forwarderProtocolValues) + ").",
+							Computed:            true,
+						},
+						"forwarder_priority": schema.Int64Attribute{
+							MarkdownDescription: "The priority of the forwarder. Lower values are used first.",
+							Computed:            true,
+						},
+						"dnssec_validation": schema.BoolAttribute{
+							MarkdownDescription: "Whether DNSSEC validation is enabled for the forwarder.",
+							Computed:            true,
+						},
+						"proxy_type": schema.StringAttribute{
+							MarkdownDescription: "The proxy type used to reach the forwarder.",
+							Computed:            true,
+						},
+						"zone_disabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the forwarder zone is disabled.",
+							Computed:            true,
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the FWD record is disabled.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ForwardersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ForwardersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ForwardersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zones, err := d.client.ListZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing zones",
+			fmt.Sprintf("Could not list zones: %s", err.Error()),
+		)
+		return
+	}
+
+	forwarderZones := make([]client.Zone, 0)
+	for _, zone := range zones {
+		if zone.Type == "Forwarder" {
+			forwarderZones = append(forwarderZones, zone)
+		}
+	}
+
+	tflog.Debug(ctx, "Reading forwarders data source", map[string]interface{}{
+		"zones":           len(zones),
+		"forwarder_zones": len(forwarderZones),
+	})
+
+	// Fetch the records of every forwarder zone concurrently, keeping results in zone order
+	results := make([][]client.DNSRecord, len(forwarderZones))
+	errs := make([]error, len(forwarderZones))
+	semaphore := make(chan struct{}, forwarderFetchConcurrency)
+
+	var wg sync.WaitGroup
+	for i, zone := range forwarderZones {
+		wg.Add(1)
+		go func(i int, zoneName string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			recordsResponse, err := d.client.GetRecords(ctx, zoneName, zoneName, true)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = recordsResponse.Records
+		}(i, zone.Name)
+	}
+	wg.Wait()
+
+	forwarders := make([]ForwarderDataItem, 0)
+	for i, zone := range forwarderZones {
+		if errs[i] != nil {
+			resp.Diagnostics.AddError(
+				"Error reading DNS records",
+				fmt.Sprintf("Could not read DNS records for zone %s: %s", zone.Name, errs[i].Error()),
+			)
+			continue
+		}
+		forwarders = append(forwarders, forwarderEntries(zone, results[i])...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue("forwarders")
+	data.Forwarders = forwarders
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// forwarderEntries converts the FWD records of a forwarder zone into data source items
+func forwarderEntries(zone client.Zone, records []client.DNSRecord) []ForwarderDataItem {
+	entries := make([]ForwarderDataItem, 0)
+	for _, record := range records {
+		if record.Type != "FWD" {
+			continue
+		}
+
+		entries = append(entries, ForwarderDataItem{
+			Zone:              types.StringValue(zone.Name),
+			Domain:            types.StringValue(record.Name),
+			Forwarder:         types.StringValue(record.RData.Forwarder),
+			Protocol:          types.StringValue(record.RData.Protocol),
+			ForwarderPriority: types.Int64Value(int64(record.RData.ForwarderPriority)),
+			DnssecValidation:  types.BoolValue(record.RData.DnssecValidation),
+			ProxyType:         types.StringValue(record.RData.ProxyType),
+			ZoneDisabled:      types.BoolValue(zone.Disabled),
+			Disabled:          types.BoolValue(record.Disabled),
+		})
+	}
+	return entries
+}
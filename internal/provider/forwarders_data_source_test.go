@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+func TestForwardersDataSource(t *testing.T) {
+	t.Parallel()
+
+	// Unit test - verify data source creation
+	t.Run("NewForwardersDataSource", func(t *testing.T) {
+		ds := NewForwardersDataSource()
+		if ds == nil {
+			t.Fatal("NewForwardersDataSource should return a non-nil data source")
+		}
+
+		// Test metadata
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{
+			ProviderTypeName: "technitium",
+		}, &resp)
+
+		if resp.TypeName != "technitium_forwarders" {
+			t.Errorf("Expected TypeName to be technitium_forwarders, got %s", resp.TypeName)
+		}
+	})
+
+	// Unit test - verify schema
+	t.Run("Schema", func(t *testing.T) {
+		ds := NewForwardersDataSource()
+		var resp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "forwarders"} {
+			attr, ok := resp.Schema.Attributes[name]
+			if !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+				continue
+			}
+			if !attr.IsComputed() {
+				t.Errorf("'%s' attribute should be computed", name)
+			}
+		}
+	})
+
+	// Unit test - verify configure method
+	t.Run("Configure", func(t *testing.T) {
+		ds := NewForwardersDataSource().(*ForwardersDataSource)
+
+		var resp datasource.ConfigureResponse
+		ds.Configure(context.Background(), datasource.ConfigureRequest{
+			ProviderData: "wrong-type",
+		}, &resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Error("Configure should fail with wrong provider data type")
+		}
+	})
+}
+
+func TestForwarderEntries(t *testing.T) {
+	t.Parallel()
+
+	zone := client.Zone{Name: "corp.example.com", Type: "Forwarder", Disabled: true}
+	records := []client.DNSRecord{
+		{Name: "corp.example.com", Type: "SOA"},
+		{Name: "corp.example.com", Type: "FWD", RData: client.DNSRecordData{Forwarder: "10.0.0.53", Protocol: "Udp", ForwarderPriority: 1}},
+		{Name: "lab.corp.example.com", Type: "FWD", Disabled: true, RData: client.DNSRecordData{Forwarder: "dns.lab.local:853", Protocol: "Tls", DnssecValidation: true}},
+	}
+
+	entries := forwarderEntries(zone, records)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	if entries[0].Domain.ValueString() != "corp.example.com" || entries[0].Forwarder.ValueString() != "10.0.0.53" {
+		t.Errorf("Unexpected first entry: %+v", entries[0])
+	}
+	if entries[0].ForwarderPriority.ValueInt64() != 1 || !entries[0].ZoneDisabled.ValueBool() {
+		t.Errorf("Unexpected first entry attributes: %+v", entries[0])
+	}
+	if entries[1].Domain.ValueString() != "lab.corp.example.com" || entries[1].Protocol.ValueString() != "Tls" {
+		t.Errorf("Unexpected second entry: %+v", entries[1])
+	}
+	if !entries[1].Disabled.ValueBool() || !entries[1].DnssecValidation.ValueBool() {
+		t.Errorf("Unexpected second entry flags: %+v", entries[1])
+	}
+}
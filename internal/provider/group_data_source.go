@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &GroupDataSource{}
+
+func NewGroupDataSource() datasource.DataSource {
+	return &GroupDataSource{}
+}
+
+// GroupDataSource defines the data source implementation.
+type GroupDataSource struct {
+	client client.ClientAPI
+}
+
+// GroupDataSourceModel describes the data source data model.
+type GroupDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Members     types.Set    `tfsdk:"members"`
+}
+
+func (d *GroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source to look up an existing group",
+		MarkdownDescription: "Data source to look up an existing group of the Technitium DNS Server, such as the built-in `Administrators` group, with its members. " +
+			"This data source is experimental: enable the `admin` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the group to look up, matched case-insensitively.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the group.",
+				Computed:            true,
+			},
+			"members": schema.SetAttribute{
+				MarkdownDescription: "The usernames of the group members.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *GroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data GroupDataSourceModel
+
+	requireExperimentalFeature(ctx, d.client, client.ExperimentalAdmin, "technitium_group", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	tflog.Debug(ctx, "Reading group data source", map[string]interface{}{
+		"name": name,
+	})
+
+	groups, err := d.client.ListGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading groups", fmt.Sprintf("Could not list groups: %s", err.Error()))
+		return
+	}
+
+	listed := findGroup(groups, name)
+	if listed == nil {
+		resp.Diagnostics.AddError("Group not found", fmt.Sprintf("No group named %q exists on the server", name))
+		return
+	}
+
+	group, err := d.client.GetGroup(ctx, listed.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading group", fmt.Sprintf("Could not read group %s: %s", listed.Name, err.Error()))
+		return
+	}
+
+	data.ID = types.StringValue(group.Name)
+	data.Description = types.StringValue(group.Description)
+	data.Members = stringSetValue(group.Members)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
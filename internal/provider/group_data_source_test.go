@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestGroupDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		ds := NewGroupDataSource()
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_group" {
+			t.Errorf("Expected TypeName to be technitium_group, got %s", resp.TypeName)
+		}
+	})
+
+	newRequest := func(t *testing.T, d *GroupDataSource, name string) (datasource.ReadRequest, datasource.ReadResponse) {
+		var schemaResp datasource.SchemaResponse
+		d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+		require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+		input := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, input.Set(context.Background(), &GroupDataSourceModel{
+			Name:    types.StringValue(name),
+			Members: types.SetNull(types.StringType),
+		}).HasError())
+
+		return datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}},
+			datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	}
+
+	t.Run("looks up a group", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		d := &GroupDataSource{client: m}
+
+		m.On("ExperimentEnabled", client.ExperimentalAdmin).Return(true)
+		m.On("ListGroups", mock.Anything).Return([]client.Group{{Name: "Administrators", Description: "Super administrators"}}, nil)
+		m.On("GetGroup", mock.Anything, "Administrators").
+			Return(&client.Group{Name: "Administrators", Description: "Super administrators", Members: []string{"admin"}}, nil)
+
+		req, resp := newRequest(t, d, "administrators")
+		d.Read(context.Background(), req, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state GroupDataSourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "Administrators", state.ID.ValueString())
+		require.Equal(t, stringSetValue([]string{"admin"}), state.Members)
+	})
+
+	t.Run("reports unknown groups", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		d := &GroupDataSource{client: m}
+
+		m.On("ExperimentEnabled", client.ExperimentalAdmin).Return(true)
+		m.On("ListGroups", mock.Anything).Return([]client.Group{{Name: "Administrators"}}, nil)
+
+		req, resp := newRequest(t, d, "Operators")
+		d.Read(context.Background(), req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Group not found", resp.Diagnostics.Errors()[0].Summary())
+	})
+}
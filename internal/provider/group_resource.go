@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GroupResource{}
+var _ resource.ResourceWithImportState = &GroupResource{}
+var _ resource.ResourceWithModifyPlan = &GroupResource{}
+
+func NewGroupResource() resource.Resource {
+	return &GroupResource{}
+}
+
+// GroupResource defines the resource implementation.
+type GroupResource struct {
+	client client.ClientAPI
+}
+
+// GroupResourceModel describes the resource data model.
+type GroupResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Members     types.Set    `tfsdk:"members"`
+}
+
+func (r *GroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (r *GroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a group of users of the Technitium DNS Server web console and API, including its members. " +
+			"Permissions are granted to groups per section or zone. The built-in groups can be imported to manage their members. " +
+			"This resource is experimental: enable the `admin` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier (the group name)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the group",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The description of the group",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"members": schema.SetAttribute{
+				MarkdownDescription: "The usernames of the group members. The list is authoritative: users added to the group outside of Terraform are removed",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *GroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the group is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	requireExperimentalFeature(ctx, r.client, client.ExperimentalAdmin, "technitium_group", &resp.Diagnostics)
+}
+
+func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data GroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	tflog.Debug(ctx, "Creating group", map[string]interface{}{
+		"name": name,
+	})
+
+	if _, err := r.client.CreateGroup(ctx, name, data.Description.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create group: %s", err.Error()))
+		return
+	}
+
+	// Members can only be set once the group exists
+	group, err := r.setGroup(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set members of group %s: %s", name, err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, groupModel(group))...)
+}
+
+func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data GroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+
+	// Look the group up in the list first, so a deleted group is told apart from other errors
+	groups, err := r.client.ListGroups(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read groups: %s", err.Error()))
+		return
+	}
+
+	if findGroup(groups, name) == nil {
+		tflog.Debug(ctx, "Group not found, removing from state", map[string]interface{}{
+			"name": name,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	group, err := r.client.GetGroup(ctx, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read group %s: %s", name, err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, groupModel(group))...)
+}
+
+func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data GroupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating group", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+
+	group, err := r.setGroup(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update group: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, groupModel(group))...)
+}
+
+func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data GroupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting group", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+
+	if err := r.client.DeleteGroup(ctx, data.Name.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete group: %s", err.Error()))
+		return
+	}
+}
+
+func (r *GroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using the group name as the ID
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// setGroup saves the planned description and members of the group
+func (r *GroupResource) setGroup(ctx context.Context, data *GroupResourceModel) (*client.Group, error) {
+	var members []string
+	data.Members.ElementsAs(ctx, &members, false)
+
+	return r.client.SetGroup(ctx, data.Name.ValueString(), data.Description.ValueString(), members)
+}
+
+// findGroup returns the group with the given name, matched case-insensitively like the server does
+func findGroup(groups []client.Group, name string) *client.Group {
+	for i := range groups {
+		if strings.EqualFold(groups[i].Name, name) {
+			return &groups[i]
+		}
+	}
+	return nil
+}
+
+// groupModel converts a group returned by the server into the resource state
+func groupModel(group *client.Group) *GroupResourceModel {
+	return &GroupResourceModel{
+		ID:          types.StringValue(group.Name),
+		Name:        types.StringValue(group.Name),
+		Description: types.StringValue(group.Description),
+		Members:     stringSetValue(group.Members),
+	}
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestGroupResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewGroupResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_group" {
+			t.Errorf("Expected TypeName to be technitium_group, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewGroupResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "name", "description", "members"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+	})
+}
+
+func TestGroupResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	newResource := func(t *testing.T) (*GroupResource, *mocks.ClientAPI, resource.SchemaResponse) {
+		m := mocks.NewClientAPI(t)
+		r := &GroupResource{client: m}
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+		return r, m, schemaResp
+	}
+
+	operators := &client.Group{Name: "DNS Operators", Description: "On-call", Members: []string{"alice", "bob"}}
+
+	t.Run("create sets the members", func(t *testing.T) {
+		r, m, schemaResp := newResource(t)
+
+		m.On("CreateGroup", mock.Anything, "DNS Operators", "On-call").Return(&client.Group{Name: "DNS Operators", Description: "On-call"}, nil)
+		m.On("SetGroup", mock.Anything, "DNS Operators", "On-call", []string{"alice", "bob"}).Return(operators, nil)
+
+		created := createResource(t, r, resourcePlan(t, schemaResp, &GroupResourceModel{
+			ID:          types.StringUnknown(),
+			Name:        types.StringValue("DNS Operators"),
+			Description: types.StringValue("On-call"),
+			Members:     stringSetValue([]string{"alice", "bob"}),
+		}))
+
+		var state GroupResourceModel
+		require.False(t, created.Get(context.Background(), &state).HasError())
+		require.Equal(t, "DNS Operators", state.ID.ValueString())
+		require.Equal(t, stringSetValue([]string{"alice", "bob"}), state.Members)
+	})
+
+	t.Run("read removes deleted groups", func(t *testing.T) {
+		r, m, schemaResp := newResource(t)
+
+		m.On("ListGroups", mock.Anything).Return([]client.Group{{Name: "Administrators"}}, nil)
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(context.Background(), groupModel(operators)).HasError())
+
+		resp := resource.ReadResponse{State: state}
+		r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.True(t, resp.State.Raw.IsNull())
+	})
+
+	t.Run("read refreshes the members", func(t *testing.T) {
+		r, m, schemaResp := newResource(t)
+
+		m.On("ListGroups", mock.Anything).Return([]client.Group{{Name: "DNS Operators"}}, nil)
+		m.On("GetGroup", mock.Anything, "DNS Operators").Return(&client.Group{Name: "DNS Operators", Description: "On-call", Members: []string{"alice"}}, nil)
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(context.Background(), groupModel(operators)).HasError())
+
+		resp := resource.ReadResponse{State: state}
+		r.Read(context.Background(), resource.ReadRequest{State: state}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var refreshed GroupResourceModel
+		require.False(t, resp.State.Get(context.Background(), &refreshed).HasError())
+		require.Equal(t, stringSetValue([]string{"alice"}), refreshed.Members)
+	})
+
+	t.Run("requires the experimental flag", func(t *testing.T) {
+		r, m, schemaResp := newResource(t)
+
+		m.On("ExperimentEnabled", client.ExperimentalAdmin).Return(false)
+
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(context.Background(), groupModel(operators)).HasError())
+
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Plan: plan}, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+	})
+}
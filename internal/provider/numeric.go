@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Numeric attributes are int64 in the schema, while the server stores TTLs, priorities and ports
+// in 32 and 16 bit fields. The schema validators reject values outside those ranges at plan time,
+// and values are converted for the client with toInt, which fails instead of wrapping around.
+
+const (
+	// maxTTL is the largest TTL allowed by RFC 2181
+	maxTTL = math.MaxInt32
+
+	// maxUint16 bounds record priorities, weights and ports as well as proxy ports
+	maxUint16 = math.MaxUint16
+)
+
+// ttlValidator validates TTLs in seconds
+func ttlValidator() validator.Int64 {
+	return int64validator.Between(0, maxTTL)
+}
+
+// uint16Validator validates priorities, weights and ports
+func uint16Validator() validator.Int64 {
+	return int64validator.Between(0, maxUint16)
+}
+
+// toInt converts the value of a numeric attribute to an int, failing when it is outside [min, max]
+func toInt(name string, value types.Int64, min, max int64) (int, error) {
+	v := value.ValueInt64()
+	if v < min || v > max {
+		return 0, fmt.Errorf("%s must be between %d and %d, got %d", name, min, max, v)
+	}
+	return int(v), nil
+}
+
+// ttlToInt converts a TTL attribute to the int the client takes
+func ttlToInt(value types.Int64) (int, error) {
+	return toInt("ttl", value, 0, maxTTL)
+}
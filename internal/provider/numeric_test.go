@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToInt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       types.Int64
+		expected    int
+		expectError bool
+	}{
+		{name: "zero", value: types.Int64Value(0), expected: 0},
+		{name: "largest TTL", value: types.Int64Value(math.MaxInt32), expected: math.MaxInt32},
+		{name: "null is zero", value: types.Int64Null(), expected: 0},
+		{name: "negative", value: types.Int64Value(-1), expectError: true},
+		{name: "beyond 32 bits", value: types.Int64Value(math.MaxInt32 + 1), expectError: true},
+		{name: "beyond 64 bit ints", value: types.Int64Value(math.MaxInt64), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ttlToInt(tt.value)
+			if tt.expectError {
+				require.ErrorContains(t, err, "ttl must be between 0 and 2147483647")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// operationsReporter is implemented by clients that keep an operations report. It is not part of
+// client.ClientAPI so that mocked clients in unit tests do not need to expect the calls.
+type operationsReporter interface {
+	OperationsReport() *client.OperationsReport
+}
+
+// reportOperation counts a successful change of the given kind and action and, when the operations
+// report is enabled, logs the totals of the provider run so far. Terraform does not tell providers
+// when an apply ends, so the last report logged holds the totals of the whole run.
+func reportOperation(ctx context.Context, c client.ClientAPI, kind, action string) {
+	reporter, ok := c.(operationsReporter)
+	if !ok {
+		return
+	}
+
+	report := reporter.OperationsReport()
+	if report == nil {
+		return
+	}
+
+	report.RecordChange(kind, action)
+	tflog.Info(ctx, "Technitium operations report", map[string]interface{}{
+		"changes": report.Changes(),
+		"totals":  report.Summary(),
+	})
+}
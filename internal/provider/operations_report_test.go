@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestReportOperation(t *testing.T) {
+	t.Parallel()
+
+	// reportedTotals returns the totals of the operations reports logged to output
+	reportedTotals := func(t *testing.T, output *bytes.Buffer) []string {
+		entries, err := tflogtest.MultilineJSONDecode(output)
+		if err != nil {
+			t.Fatalf("Failed to decode log entries: %v", err)
+		}
+		var totals []string
+		for _, entry := range entries {
+			if entry["@message"] == "Technitium operations report" {
+				totals = append(totals, entry["totals"].(string))
+			}
+		}
+		return totals
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		c, err := client.NewClient(client.Config{Host: "http://localhost:5380", Token: "test-token", OperationsReport: true})
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+
+		var output bytes.Buffer
+		ctx := tflogtest.RootLogger(context.Background(), &output)
+		reportOperation(ctx, c, "records", client.OperationCreated)
+		reportOperation(ctx, c, "zones", client.OperationDeleted)
+
+		totals := reportedTotals(t, &output)
+		if len(totals) != 2 {
+			t.Fatalf("Expected two reports, got %v", totals)
+		}
+		if !strings.Contains(totals[1], "records: 1 created, 0 updated, 0 deleted; zones: 0 created, 0 updated, 1 deleted") {
+			t.Errorf("Unexpected report totals: %q", totals[1])
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c, err := client.NewClient(client.Config{Host: "http://localhost:5380", Token: "test-token"})
+		if err != nil {
+			t.Fatalf("NewClient failed: %v", err)
+		}
+
+		var output bytes.Buffer
+		reportOperation(tflogtest.RootLogger(context.Background(), &output), c, "records", client.OperationCreated)
+		if totals := reportedTotals(t, &output); len(totals) != 0 {
+			t.Errorf("Expected no reports, got %v", totals)
+		}
+	})
+
+	t.Run("mocked client", func(t *testing.T) {
+		var output bytes.Buffer
+		reportOperation(tflogtest.RootLogger(context.Background(), &output), mocks.NewClientAPI(t), "records", client.OperationCreated)
+		if totals := reportedTotals(t, &output); len(totals) != 0 {
+			t.Errorf("Expected no reports, got %v", totals)
+		}
+	})
+}
@@ -0,0 +1,372 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// permissionIDSeparator separates the parts of permission IDs. The permissions API uses it to
+// separate table cells, so it cannot appear in section, zone, user or group names.
+const permissionIDSeparator = "|"
+
+// permissionsMu serializes permission changes, which read, modify and write the whole permission
+// table of a section or zone
+var permissionsMu sync.Mutex
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PermissionResource{}
+var _ resource.ResourceWithImportState = &PermissionResource{}
+var _ resource.ResourceWithModifyPlan = &PermissionResource{}
+
+func NewPermissionResource() resource.Resource {
+	return &PermissionResource{}
+}
+
+// PermissionResource defines the resource implementation.
+type PermissionResource struct {
+	client client.ClientAPI
+}
+
+// PermissionResourceModel describes the resource data model.
+type PermissionResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Section   types.String `tfsdk:"section"`
+	Zone      types.String `tfsdk:"zone"`
+	User      types.String `tfsdk:"user"`
+	Group     types.String `tfsdk:"group"`
+	CanView   types.Bool   `tfsdk:"can_view"`
+	CanModify types.Bool   `tfsdk:"can_modify"`
+	CanDelete types.Bool   `tfsdk:"can_delete"`
+}
+
+func (r *PermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_permission"
+}
+
+func (r *PermissionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	noSeparator := stringvalidator.RegexMatches(regexp.MustCompile(`^[^|]+$`), "must not be empty or contain \"|\"")
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a user or group access to a section of the Technitium DNS Server web console and API, or to a single zone. " +
+			"Each resource manages one row of the permission table, so permissions granted outside of Terraform are left alone. " +
+			"This resource is experimental: enable the `admin` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier in the format `section|<section>|user|<user>` or `zone|<zone>|group|<group>`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"section": schema.StringAttribute{
+				MarkdownDescription: "The section to grant access to. Valid values are: " + enumDescription(permissionSectionValues) + ". Conflicts with `zone`",
+				Optional:            true,
+				Validators: []validator.String{
+					enumValidator(permissionSectionValues),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("zone")),
+				},
+				PlanModifiers: replace,
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone to grant access to. Access to a zone also requires view access to the `Zones` section. Conflicts with `section`",
+				Optional:            true,
+				Validators: []validator.String{
+					noSeparator,
+				},
+				PlanModifiers: replace,
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "The username to grant access to. Conflicts with `group`",
+				Optional:            true,
+				Validators: []validator.String{
+					noSeparator,
+					stringvalidator.ExactlyOneOf(path.MatchRoot("group")),
+				},
+				PlanModifiers: replace,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "The group to grant access to. Conflicts with `user`",
+				Optional:            true,
+				Validators: []validator.String{
+					noSeparator,
+				},
+				PlanModifiers: replace,
+			},
+			"can_view": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user or group can view the section or zone. Defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"can_modify": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user or group can modify the section or zone. Defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"can_delete": schema.BoolAttribute{
+				MarkdownDescription: "Whether the user or group can delete in the section or zone. Defaults to `false`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *PermissionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PermissionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the permission is being revoked
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	requireExperimentalFeature(ctx, r.client, client.ExperimentalAdmin, "technitium_permission", &resp.Diagnostics)
+}
+
+func (r *PermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PermissionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Granting permission", map[string]interface{}{
+		"id": permissionID(&data),
+	})
+
+	if err := r.grant(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant permission: %s", err.Error()))
+		return
+	}
+
+	data.ID = types.StringValue(permissionID(&data))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PermissionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissions, err := r.getPermissions(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read permissions: %s", err.Error()))
+		return
+	}
+
+	canView, canModify, canDelete, found := findPermission(permissions, &data)
+	if !found {
+		tflog.Debug(ctx, "Permission not found, removing from state", map[string]interface{}{
+			"id": data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.CanView = types.BoolValue(canView)
+	data.CanModify = types.BoolValue(canModify)
+	data.CanDelete = types.BoolValue(canDelete)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PermissionResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating permission", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+
+	if err := r.grant(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update permission: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PermissionResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Revoking permission", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+
+	permissionsMu.Lock()
+	defer permissionsMu.Unlock()
+
+	permissions, err := r.getPermissions(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read permissions: %s", err.Error()))
+		return
+	}
+
+	removePermission(permissions, &data)
+
+	if err := r.setPermissions(ctx, &data, permissions); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke permission: %s", err.Error()))
+		return
+	}
+}
+
+func (r *PermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, permissionIDSeparator)
+	if len(parts) != 4 || (parts[0] != "section" && parts[0] != "zone") || (parts[2] != "user" && parts[2] != "group") || parts[1] == "" || parts[3] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID in the format section|<section>|user|<user> or zone|<zone>|group|<group>, got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(parts[0]), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(parts[2]), parts[3])...)
+}
+
+// grant adds or updates the permission row of the user or group
+func (r *PermissionResource) grant(ctx context.Context, data *PermissionResourceModel) error {
+	permissionsMu.Lock()
+	defer permissionsMu.Unlock()
+
+	permissions, err := r.getPermissions(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	removePermission(permissions, data)
+	canView, canModify, canDelete := data.CanView.ValueBool(), data.CanModify.ValueBool(), data.CanDelete.ValueBool()
+	if !data.User.IsNull() {
+		permissions.UserPermissions = append(permissions.UserPermissions, client.UserPermission{
+			Username: data.User.ValueString(), CanView: canView, CanModify: canModify, CanDelete: canDelete,
+		})
+	} else {
+		permissions.GroupPermissions = append(permissions.GroupPermissions, client.GroupPermission{
+			Name: data.Group.ValueString(), CanView: canView, CanModify: canModify, CanDelete: canDelete,
+		})
+	}
+
+	return r.setPermissions(ctx, data, permissions)
+}
+
+// getPermissions reads the permission table of the section or zone
+func (r *PermissionResource) getPermissions(ctx context.Context, data *PermissionResourceModel) (*client.Permissions, error) {
+	if !data.Zone.IsNull() {
+		return r.client.GetZonePermissions(ctx, data.Zone.ValueString())
+	}
+	return r.client.GetPermissions(ctx, client.PermissionSection(data.Section.ValueString()))
+}
+
+// setPermissions saves the permission table of the section or zone
+func (r *PermissionResource) setPermissions(ctx context.Context, data *PermissionResourceModel, permissions *client.Permissions) error {
+	var err error
+	if !data.Zone.IsNull() {
+		_, err = r.client.SetZonePermissions(ctx, data.Zone.ValueString(), permissions)
+	} else {
+		_, err = r.client.SetPermissions(ctx, client.PermissionSection(data.Section.ValueString()), permissions)
+	}
+	return err
+}
+
+// findPermission returns the flags of the user or group row, matching names case-insensitively
+func findPermission(permissions *client.Permissions, data *PermissionResourceModel) (canView, canModify, canDelete, found bool) {
+	if !data.User.IsNull() {
+		for _, p := range permissions.UserPermissions {
+			if strings.EqualFold(p.Username, data.User.ValueString()) {
+				return p.CanView, p.CanModify, p.CanDelete, true
+			}
+		}
+		return false, false, false, false
+	}
+
+	for _, p := range permissions.GroupPermissions {
+		if strings.EqualFold(p.Name, data.Group.ValueString()) {
+			return p.CanView, p.CanModify, p.CanDelete, true
+		}
+	}
+	return false, false, false, false
+}
+
+// removePermission removes the user or group row from the permission table
+func removePermission(permissions *client.Permissions, data *PermissionResourceModel) {
+	if !data.User.IsNull() {
+		permissions.UserPermissions = slices.DeleteFunc(permissions.UserPermissions, func(p client.UserPermission) bool {
+			return strings.EqualFold(p.Username, data.User.ValueString())
+		})
+		return
+	}
+
+	permissions.GroupPermissions = slices.DeleteFunc(permissions.GroupPermissions, func(p client.GroupPermission) bool {
+		return strings.EqualFold(p.Name, data.Group.ValueString())
+	})
+}
+
+// permissionID renders the ID of the permission, e.g. "zone|example.com|group|DNS Operators"
+func permissionID(data *PermissionResourceModel) string {
+	target, targetName := "section", data.Section.ValueString()
+	if !data.Zone.IsNull() {
+		target, targetName = "zone", data.Zone.ValueString()
+	}
+	principal, principalName := "group", data.Group.ValueString()
+	if !data.User.IsNull() {
+		principal, principalName = "user", data.User.ValueString()
+	}
+	return strings.Join([]string{target, targetName, principal, principalName}, permissionIDSeparator)
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestPermissionResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewPermissionResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_permission" {
+			t.Errorf("Expected TypeName to be technitium_permission, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewPermissionResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "section", "zone", "user", "group", "can_view", "can_modify", "can_delete"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+	})
+
+	t.Run("ImportState", func(t *testing.T) {
+		r := NewPermissionResource().(*PermissionResource)
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+		newState := func() tfsdk.State {
+			return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)}
+		}
+
+		resp := resource.ImportStateResponse{State: newState()}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: "zone|example.com|group|DNS Operators"}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "import diagnostics: %v", resp.Diagnostics)
+
+		var state PermissionResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "example.com", state.Zone.ValueString())
+		require.Equal(t, "DNS Operators", state.Group.ValueString())
+		require.True(t, state.Section.IsNull())
+		require.True(t, state.User.IsNull())
+
+		for _, id := range []string{"example.com", "zone|example.com|admin|alice", "section||user|alice"} {
+			resp := resource.ImportStateResponse{State: newState()}
+			r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, &resp)
+			require.True(t, resp.Diagnostics.HasError(), "expected %q to be rejected", id)
+		}
+	})
+}
+
+func TestPermissionResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := &PermissionResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	// Rows not managed by the resource are preserved
+	existing := func() *client.Permissions {
+		return &client.Permissions{
+			Section:          "Zones",
+			UserPermissions:  []client.UserPermission{{Username: "admin", CanView: true, CanModify: true, CanDelete: true}},
+			GroupPermissions: []client.GroupPermission{{Name: "Administrators", CanView: true, CanModify: true, CanDelete: true}},
+		}
+	}
+	granted := existing()
+	granted.GroupPermissions = append(granted.GroupPermissions, client.GroupPermission{Name: "DNS Operators", CanView: true, CanModify: true})
+
+	m.On("GetPermissions", mock.Anything, client.PermissionSectionZones).Return(existing(), nil).Once()
+	m.On("SetPermissions", mock.Anything, client.PermissionSectionZones, granted).Return(granted, nil).Once()
+
+	created := createResource(t, r, resourcePlan(t, schemaResp, &PermissionResourceModel{
+		ID:        types.StringUnknown(),
+		Section:   types.StringValue("Zones"),
+		Zone:      types.StringNull(),
+		User:      types.StringNull(),
+		Group:     types.StringValue("DNS Operators"),
+		CanView:   types.BoolValue(true),
+		CanModify: types.BoolValue(true),
+		CanDelete: types.BoolValue(false),
+	}))
+
+	var state PermissionResourceModel
+	require.False(t, created.Get(context.Background(), &state).HasError())
+	require.Equal(t, "section|Zones|group|DNS Operators", state.ID.ValueString())
+
+	// Refresh picks up changes made outside of Terraform, matching names case-insensitively
+	refreshed := existing()
+	refreshed.GroupPermissions = append(refreshed.GroupPermissions, client.GroupPermission{Name: "dns operators", CanView: true})
+	m.On("GetPermissions", mock.Anything, client.PermissionSectionZones).Return(refreshed, nil).Once()
+
+	readResp := resource.ReadResponse{State: created}
+	r.Read(context.Background(), resource.ReadRequest{State: created}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.False(t, readResp.State.Get(context.Background(), &state).HasError())
+	require.True(t, state.CanView.ValueBool())
+	require.False(t, state.CanModify.ValueBool())
+
+	m.On("GetPermissions", mock.Anything, client.PermissionSectionZones).Return(refreshed, nil).Once()
+	m.On("SetPermissions", mock.Anything, client.PermissionSectionZones, existing()).Return(existing(), nil).Once()
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+
+	// A revoked row is removed from state
+	m.On("GetZonePermissions", mock.Anything, "example.com").Return(&client.Permissions{Section: "Zones", SubItem: "example.com"}, nil).Once()
+
+	zoneState := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, zoneState.Set(context.Background(), &PermissionResourceModel{
+		ID:        types.StringValue("zone|example.com|user|alice"),
+		Section:   types.StringNull(),
+		Zone:      types.StringValue("example.com"),
+		User:      types.StringValue("alice"),
+		Group:     types.StringNull(),
+		CanView:   types.BoolValue(true),
+		CanModify: types.BoolValue(false),
+		CanDelete: types.BoolValue(false),
+	}).HasError())
+
+	readResp = resource.ReadResponse{State: zoneState}
+	r.Read(context.Background(), resource.ReadRequest{State: zoneState}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.True(t, readResp.State.Raw.IsNull())
+}
@@ -2,12 +2,19 @@ package provider
 
 import (
 	"context"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -26,15 +33,40 @@ type TechnitiumProvider struct {
 	version string
 }
 
+// Environment variables read when the provider configuration leaves the host or the credentials unset
+const (
+	hostEnvVar     = "TECHNITIUM_HOST"
+	tokenEnvVar    = "TECHNITIUM_TOKEN"
+	usernameEnvVar = "TECHNITIUM_USERNAME"
+	passwordEnvVar = "TECHNITIUM_PASSWORD"
+)
+
 // TechnitiumProviderModel describes the provider data model.
 type TechnitiumProviderModel struct {
-	Host               types.String `tfsdk:"host"`
-	Username           types.String `tfsdk:"username"`
-	Password           types.String `tfsdk:"password"`
-	Token              types.String `tfsdk:"token"`
-	TimeoutSeconds     types.Int64  `tfsdk:"timeout_seconds"`
-	RetryAttempts      types.Int64  `tfsdk:"retry_attempts"`
-	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	Host                  types.String `tfsdk:"host"`
+	Username              types.String `tfsdk:"username"`
+	Password              types.String `tfsdk:"password"`
+	Token                 types.String `tfsdk:"token"`
+	TimeoutSeconds        types.Int64  `tfsdk:"timeout_seconds"`
+	RetryAttempts         types.Int64  `tfsdk:"retry_attempts"`
+	RetryMinDelayMs       types.Int64  `tfsdk:"retry_min_delay_ms"`
+	RetryMaxDelayMs       types.Int64  `tfsdk:"retry_max_delay_ms"`
+	RetryOnAuthFailure    types.Bool   `tfsdk:"retry_on_auth_failure"`
+	FailFast              types.Bool   `tfsdk:"fail_fast"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	RequestsPerSecond     types.Int64  `tfsdk:"requests_per_second"`
+	InsecureSkipVerify    types.Bool   `tfsdk:"insecure_skip_verify"`
+	DefaultComment        types.String `tfsdk:"default_comment"`
+	DefaultZone           types.String `tfsdk:"default_zone"`
+	DefaultTTL            types.Int64  `tfsdk:"default_ttl"`
+	StrictConsistency     types.Bool   `tfsdk:"strict_consistency"`
+	DisableHTTP2          types.Bool   `tfsdk:"disable_http2"`
+	HostAliases           types.Map    `tfsdk:"host_aliases"`
+	OperationsReport      types.Bool   `tfsdk:"operations_report"`
+	ResponseCacheSeconds  types.Int64  `tfsdk:"response_cache_seconds"`
+	BatchReads            types.Bool   `tfsdk:"batch_reads"`
+
+	ExperimentalFeatures types.List `tfsdk:"experimental_features"`
 }
 
 func (p *TechnitiumProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,35 +79,154 @@ func (p *TechnitiumProvider) Schema(ctx context.Context, req provider.SchemaRequ
 		MarkdownDescription: "The Technitium provider is used to manage Technitium DNS Server instances via the REST API.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "Technitium DNS Server host URL (e.g., http://localhost:5380)",
-				Required:            true,
+				MarkdownDescription: "Technitium DNS Server host URL (e.g., http://localhost:5380). IPv6 literals are supported, bracketed as in " +
+					"`https://[fd00::1]:53443` or with a zone as in `https://[fe80::1%eth0]:53443`. The scheme defaults to `http`. " +
+					"Can also be set with the `TECHNITIUM_HOST` environment variable.",
+				Optional: true,
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "Username for authentication. Either username/password or token must be provided.",
-				Optional:            true,
+				MarkdownDescription: "Username for authentication. Either username/password or token must be provided. " +
+					"Can also be set with the `TECHNITIUM_USERNAME` environment variable.",
+				Optional: true,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password for authentication. Either username/password or token must be provided.",
-				Optional:            true,
-				Sensitive:           true,
+				MarkdownDescription: "Password for authentication. Either username/password or token must be provided. " +
+					"Can also be set with the `TECHNITIUM_PASSWORD` environment variable.",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "API token for authentication. Either username/password or token must be provided.",
-				Optional:            true,
-				Sensitive:           true,
+				MarkdownDescription: "API token for authentication. Either username/password or token must be provided. " +
+					"Can also be set with the `TECHNITIUM_TOKEN` environment variable. Each credential unset in the configuration " +
+					"falls back to its environment variable, but the token is not read from the environment when the configuration " +
+					"sets a username or password.",
+				Optional:  true,
+				Sensitive: true,
 			},
 			"timeout_seconds": schema.Int64Attribute{
-				MarkdownDescription: "Request timeout in seconds. Defaults to 30.",
+				MarkdownDescription: "Request timeout in seconds. Defaults to 30. Resources with a `timeouts` block override it per operation.",
 				Optional:            true,
 			},
 			"retry_attempts": schema.Int64Attribute{
-				MarkdownDescription: "Number of retry attempts for failed requests. Defaults to 3.",
-				Optional:            true,
+				MarkdownDescription: "Number of retry attempts for failed requests. Only transient failures are retried, e.g. connection errors, " +
+					"timeouts and HTTP 429 or 5xx responses; errors reported by the API, such as validation failures, fail immediately. Changes are " +
+					"only retried when they cannot have reached the server, i.e. on refused connections and HTTP 429 or 503 responses with a " +
+					"`Retry-After` header. Defaults to 3.",
+				Optional: true,
+			},
+			"retry_min_delay_ms": schema.Int64Attribute{
+				MarkdownDescription: "Delay before the first retry in milliseconds. The delay doubles with every further retry, with random jitter " +
+					"so concurrent requests do not retry in lockstep. Defaults to 1000.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_max_delay_ms": schema.Int64Attribute{
+				MarkdownDescription: "Longest delay between retries in milliseconds. Delays requested by the server with a `Retry-After` header are " +
+					"honored up to this value. Defaults to 30000.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_on_auth_failure": schema.BoolAttribute{
+				MarkdownDescription: "Log in again and retry when the server rejects the session token, e.g. after it expired or the server restarted. " +
+					"Only applies to username/password authentication. Defaults to true.",
+				Optional: true,
+			},
+			"fail_fast": schema.BoolAttribute{
+				MarkdownDescription: "Report the first failed request instead of retrying it, ignoring `retry_attempts` and `retry_on_auth_failure`. " +
+					"Useful in CI, where failing quickly is preferable to retrying for a minute. Defaults to false.",
+				Optional: true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at once, shared by all resources. Requests beyond it wait for a " +
+					"running one to complete. Useful when applies with many records overload the server or a proxy in front of it. Unlimited by default.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests started per second, shared by all resources. Useful when a proxy in front " +
+					"of the server rate limits requests. Unlimited by default.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
 			},
 			"insecure_skip_verify": schema.BoolAttribute{
 				MarkdownDescription: "Skip TLS certificate verification. Defaults to false.",
 				Optional:            true,
 			},
+			"disable_http2": schema.BoolAttribute{
+				MarkdownDescription: "Disable HTTP/2 for HTTPS connections to the management API. HTTP/2 is attempted by default; disable it when a proxy in front of the server handles HTTP/2 incorrectly. Defaults to false.",
+				Optional:            true,
+			},
+			"host_aliases": schema.MapAttribute{
+				MarkdownDescription: "Map of API hosts to the address actually connected to, for when the API URL differs between environments " +
+					"(e.g. CI versus the Docker host running the server). Keys are a host or `host:port` from `host`; values are a host, " +
+					"`host:port` or `unix:///path/to/socket` for a reverse proxy listening on a unix socket. The URL, Host header and TLS " +
+					"server name are unchanged, so resource configurations and certificates keep working.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"default_comment": schema.StringAttribute{
+				MarkdownDescription: "Comment appended to the comments of every record created or updated by the provider (e.g., `managed by terraform`). " +
+					"Makes Terraform managed records recognizable in the Technitium web console. Zones do not support comments and are not tagged.",
+				Optional: true,
+			},
+			"default_zone": schema.StringAttribute{
+				MarkdownDescription: "Zone of `technitium_dns_record` resources that do not set `zone`, for modules creating many records in the same zone. " +
+					"Changing it replaces the records that inherit it.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"default_ttl": schema.Int64Attribute{
+				MarkdownDescription: "TTL in seconds of `technitium_dns_record` resources that do not set `ttl`. Changing it updates the records that inherit it.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					ttlValidator(),
+				},
+			},
+			"strict_consistency": schema.BoolAttribute{
+				MarkdownDescription: "Re-read records after create/update and fail the apply with a detailed diff when the server stored different values than planned, " +
+					"instead of silently adopting the server values. Useful in CI to catch API behavior changes early. Defaults to false.",
+				Optional: true,
+			},
+			"operations_report": schema.BoolAttribute{
+				MarkdownDescription: "Log an operations report at INFO level after every record or zone change, summarizing the records and zones created, " +
+					"updated and deleted, the API calls made and the requests retried by the provider so far. Terraform does not tell providers " +
+					"when an apply ends, so the last report of a run holds its totals; set `TF_LOG_PROVIDER=INFO` to see them. Useful for change records in regulated environments. Defaults to false.",
+				Optional: true,
+			},
+			"response_cache_seconds": schema.Int64Attribute{
+				MarkdownDescription: "How long zone options, zone records and the lists of installed and store apps are cached in seconds, so resources reading " +
+					"the same zone or the app lists do not request it again and again during a run. Changes made by the provider invalidate the cached " +
+					"responses of the zone or app they affect, and changes to A and AAAA records that may touch a reverse zone invalidate every cached response. Set to 0 to disable the cache, e.g. when other tools change the server during an apply. Defaults to 30.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"batch_reads": schema.BoolAttribute{
+				MarkdownDescription: "Read `technitium_dns_record` resources from a single listing of their whole zone, requested once and shared by all " +
+					"records of the zone, instead of requesting the records of every domain separately. Speeds up plans and refreshes of zones with " +
+					"hundreds of records considerably, at the cost of transferring the whole zone. Requires the response cache, see `response_cache_seconds`. Defaults to false.",
+				Optional: true,
+			},
+			"experimental_features": schema.ListAttribute{
+				MarkdownDescription: "Experimental features to enable. New subsystems ship behind these flags before they are considered stable, " +
+					"and their resources fail to plan unless the matching flag is listed. Valid values are: " + experimentalFeatureNames() + ".",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(strings.Split(experimentalFeatureNames(), ", ")...)),
+				},
+			},
 		},
 	}
 }
@@ -89,11 +240,13 @@ func (p *TechnitiumProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
+	applyEnvironment(&data, os.Getenv)
+
 	// Validate configuration
 	if data.Host.IsNull() || data.Host.IsUnknown() {
 		resp.Diagnostics.AddError(
 			"Missing Host Configuration",
-			"The host configuration is required to connect to the Technitium DNS server.",
+			"The host configuration or the TECHNITIUM_HOST environment variable is required to connect to the Technitium DNS server.",
 		)
 		return
 	}
@@ -106,7 +259,8 @@ func (p *TechnitiumProvider) Configure(ctx context.Context, req provider.Configu
 	if !hasUsernamePassword && !hasToken {
 		resp.Diagnostics.AddError(
 			"Missing Authentication Configuration",
-			"Either username/password or token must be provided for authentication.",
+			"Either username/password or token must be provided for authentication, in the configuration or with the "+
+				"TECHNITIUM_USERNAME/TECHNITIUM_PASSWORD or TECHNITIUM_TOKEN environment variables.",
 		)
 		return
 	}
@@ -127,12 +281,92 @@ func (p *TechnitiumProvider) Configure(ctx context.Context, req provider.Configu
 		insecureSkipVerify = data.InsecureSkipVerify.ValueBool()
 	}
 
+	disableHTTP2 := false
+	if !data.DisableHTTP2.IsNull() && !data.DisableHTTP2.IsUnknown() {
+		disableHTTP2 = data.DisableHTTP2.ValueBool()
+	}
+
 	// Create client configuration
 	config := client.Config{
 		Host:               data.Host.ValueString(),
 		TimeoutSeconds:     timeoutSeconds,
 		RetryAttempts:      retryAttempts,
 		InsecureSkipVerify: insecureSkipVerify,
+		DisableHTTP2:       disableHTTP2,
+	}
+
+	if !data.DefaultComment.IsNull() && !data.DefaultComment.IsUnknown() {
+		config.DefaultComment = data.DefaultComment.ValueString()
+	}
+
+	if !data.DefaultZone.IsNull() && !data.DefaultZone.IsUnknown() {
+		config.DefaultZone = data.DefaultZone.ValueString()
+	}
+
+	if !data.DefaultTTL.IsNull() && !data.DefaultTTL.IsUnknown() {
+		config.DefaultTTL = data.DefaultTTL.ValueInt64Pointer()
+	}
+
+	if !data.StrictConsistency.IsNull() && !data.StrictConsistency.IsUnknown() {
+		config.StrictConsistency = data.StrictConsistency.ValueBool()
+	}
+
+	if !data.RetryOnAuthFailure.IsNull() && !data.RetryOnAuthFailure.IsUnknown() {
+		config.DisableAuthRetry = !data.RetryOnAuthFailure.ValueBool()
+	}
+
+	if !data.RetryMinDelayMs.IsNull() && !data.RetryMinDelayMs.IsUnknown() {
+		config.RetryMinDelay = time.Duration(data.RetryMinDelayMs.ValueInt64()) * time.Millisecond
+	}
+
+	if !data.RetryMaxDelayMs.IsNull() && !data.RetryMaxDelayMs.IsUnknown() {
+		config.RetryMaxDelay = time.Duration(data.RetryMaxDelayMs.ValueInt64()) * time.Millisecond
+	}
+
+	if !data.FailFast.IsNull() && !data.FailFast.IsUnknown() {
+		config.FailFast = data.FailFast.ValueBool()
+	}
+
+	if !data.MaxConcurrentRequests.IsNull() && !data.MaxConcurrentRequests.IsUnknown() {
+		config.MaxConcurrentRequests = data.MaxConcurrentRequests.ValueInt64()
+	}
+
+	if !data.RequestsPerSecond.IsNull() && !data.RequestsPerSecond.IsUnknown() {
+		config.RequestsPerSecond = data.RequestsPerSecond.ValueInt64()
+	}
+
+	config.ResponseCacheTTL = 30 * time.Second
+	if !data.ResponseCacheSeconds.IsNull() && !data.ResponseCacheSeconds.IsUnknown() {
+		config.ResponseCacheTTL = time.Duration(data.ResponseCacheSeconds.ValueInt64()) * time.Second
+	}
+
+	if !data.BatchReads.IsNull() && !data.BatchReads.IsUnknown() {
+		config.BatchReads = data.BatchReads.ValueBool()
+	}
+	if config.BatchReads && config.ResponseCacheTTL <= 0 {
+		resp.Diagnostics.AddError(
+			"Invalid Provider Configuration",
+			"batch_reads shares zone listings through the response cache, so response_cache_seconds must be greater than 0.",
+		)
+		return
+	}
+
+	if !data.OperationsReport.IsNull() && !data.OperationsReport.IsUnknown() {
+		config.OperationsReport = data.OperationsReport.ValueBool()
+	}
+
+	if !data.HostAliases.IsNull() && !data.HostAliases.IsUnknown() {
+		resp.Diagnostics.Append(data.HostAliases.ElementsAs(ctx, &config.HostAliases, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !data.ExperimentalFeatures.IsNull() && !data.ExperimentalFeatures.IsUnknown() {
+		resp.Diagnostics.Append(data.ExperimentalFeatures.ElementsAs(ctx, &config.ExperimentalFeatures, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	if hasToken {
@@ -174,21 +408,79 @@ func (p *TechnitiumProvider) Configure(ctx context.Context, req provider.Configu
 	resp.ResourceData = apiClient
 }
 
+// applyEnvironment fills each of the host and credentials the configuration leaves unset from the
+// environment, e.g. a configured username with the password from the environment. Tokens take
+// precedence over a username and password, so the token is only read from the environment when
+// the configuration sets neither.
+func applyEnvironment(data *TechnitiumProviderModel, getenv func(string) string) {
+	configuredLogin := !data.Username.IsNull() || !data.Password.IsNull()
+
+	setFromEnvironment(&data.Host, getenv(hostEnvVar))
+	setFromEnvironment(&data.Username, getenv(usernameEnvVar))
+	setFromEnvironment(&data.Password, getenv(passwordEnvVar))
+	if !configuredLogin {
+		setFromEnvironment(&data.Token, getenv(tokenEnvVar))
+	}
+}
+
+// setFromEnvironment sets an attribute the configuration leaves unset to a non-empty environment
+// variable value
+func setFromEnvironment(value *types.String, env string) {
+	if value.IsNull() && env != "" {
+		*value = types.StringValue(env)
+	}
+}
+
+// Resources and data sources are registered before the provider is configured, so the server
+// version is not known yet. Resources instead check the detected server capabilities in
+// ModifyPlan and report unsupported features as plan-time errors.
 func (p *TechnitiumProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewZoneResource,
 		NewDNSRecordResource,
+		NewDNSRecordSetResource,
+		NewZoneDNSSECKeyResource,
+		NewPTRZoneAutoResource,
+		NewTsigKeyResource,
 		NewDNSAppResource,
 		NewDNSAppConfigResource,
+		NewDNSSettingsResource,
+		NewBlocklistResource,
+		NewAllowedDomainResource,
+		NewBlockedDomainResource,
+		NewAllowedDomainsImportResource,
+		NewBlockedDomainsImportResource,
+		NewCacheFlushResource,
+		NewGroupResource,
+		NewPermissionResource,
+		NewAPITokenResource,
+		NewZoneFileResource,
+		NewZoneDelegationResource,
 	}
 }
 
 func (p *TechnitiumProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewZoneDataSource,
+		NewZonesDataSource,
+		NewCatalogZonesDataSource,
 		NewDNSRecordsDataSource,
 		NewDNSAppsDataSource,
 		NewDNSStoreAppsDataSource,
+		NewZoneDiffDataSource,
+		NewForwardersDataSource,
+		NewRecordImportMapDataSource,
+		NewDNSAppComponentDataSource,
+		NewZoneTransferStatusDataSource,
+		NewZoneDNSSECRolloversDataSource,
+		NewDHCPScopesDataSource,
+		NewDHCPLeasesDataSource,
+		NewCachedZoneDataSource,
+		NewDNSStatsDataSource,
+		NewDNSClientQueryDataSource,
+		NewAllowedDomainsDataSource,
+		NewBlockedDomainsDataSource,
+		NewGroupDataSource,
 	}
 }
 
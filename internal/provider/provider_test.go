@@ -1,12 +1,38 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/stretchr/testify/require"
 )
 
+// resourcePlan builds a plan of the schema holding the given model
+func resourcePlan(t *testing.T, schemaResp resource.SchemaResponse, model interface{}) tfsdk.Plan {
+	t.Helper()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	diags := plan.Set(context.Background(), model)
+	require.False(t, diags.HasError(), "plan diagnostics: %v", diags)
+	return plan
+}
+
+// createResource creates resource r from the plan, failing the test on errors, and returns the
+// resulting state
+func createResource(t *testing.T, r resource.Resource, plan tfsdk.Plan) tfsdk.State {
+	t.Helper()
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: plan.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+	return resp.State
+}
+
 func TestProvider(t *testing.T) {
 	t.Parallel()
 
@@ -23,6 +49,87 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestApplyEnvironment(t *testing.T) {
+	t.Parallel()
+
+	environment := map[string]string{
+		"TECHNITIUM_HOST":     "http://dns.example.com:5380",
+		"TECHNITIUM_TOKEN":    "env-token",
+		"TECHNITIUM_USERNAME": "env-user",
+		"TECHNITIUM_PASSWORD": "env-password",
+	}
+	getenv := func(key string) string { return environment[key] }
+
+	unset := func() TechnitiumProviderModel {
+		return TechnitiumProviderModel{
+			Host:     types.StringNull(),
+			Username: types.StringNull(),
+			Password: types.StringNull(),
+			Token:    types.StringNull(),
+		}
+	}
+
+	t.Run("fills unset values", func(t *testing.T) {
+		data := unset()
+		applyEnvironment(&data, getenv)
+
+		if data.Host.ValueString() != "http://dns.example.com:5380" {
+			t.Errorf("Expected host from environment, got %s", data.Host)
+		}
+		if data.Token.ValueString() != "env-token" || data.Username.ValueString() != "env-user" || data.Password.ValueString() != "env-password" {
+			t.Errorf("Expected credentials from environment, got %s/%s/%s", data.Token, data.Username, data.Password)
+		}
+	})
+
+	t.Run("configuration takes precedence", func(t *testing.T) {
+		data := unset()
+		data.Host = types.StringValue("http://localhost:5380")
+		data.Username = types.StringValue("admin")
+		data.Password = types.StringValue("admin")
+		applyEnvironment(&data, getenv)
+
+		if data.Host.ValueString() != "http://localhost:5380" {
+			t.Errorf("Expected configured host, got %s", data.Host)
+		}
+		if !data.Token.IsNull() {
+			t.Errorf("Expected no token from the environment when credentials are configured, got %s", data.Token)
+		}
+		if data.Username.ValueString() != "admin" || data.Password.ValueString() != "admin" {
+			t.Errorf("Expected configured credentials, got %s/%s", data.Username, data.Password)
+		}
+	})
+
+	t.Run("fills each unset value", func(t *testing.T) {
+		data := unset()
+		data.Username = types.StringValue("admin")
+		applyEnvironment(&data, getenv)
+
+		if data.Username.ValueString() != "admin" || data.Password.ValueString() != "env-password" {
+			t.Errorf("Expected configured username and password from environment, got %s/%s", data.Username, data.Password)
+		}
+		if !data.Token.IsNull() {
+			t.Errorf("Expected no token from the environment when a username is configured, got %s", data.Token)
+		}
+
+		data = unset()
+		data.Token = types.StringValue("token")
+		applyEnvironment(&data, getenv)
+
+		if data.Token.ValueString() != "token" || data.Host.ValueString() != "http://dns.example.com:5380" {
+			t.Errorf("Expected configured token and host from environment, got %s/%s", data.Token, data.Host)
+		}
+	})
+
+	t.Run("leaves values unset without environment", func(t *testing.T) {
+		data := unset()
+		applyEnvironment(&data, func(string) string { return "" })
+
+		if !data.Host.IsNull() || !data.Token.IsNull() || !data.Username.IsNull() || !data.Password.IsNull() {
+			t.Errorf("Expected values to stay unset, got %+v", data)
+		}
+	})
+}
+
 // ProviderServerFactory is used for acceptance testing
 func ProviderServerFactory() func() tfprotov6.ProviderServer {
 	return providerserver.NewProtocol6(New("test")())
@@ -0,0 +1,344 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Default prefix lengths of reverse zones, matching the usual subnet sizes
+const (
+	defaultIPv4ReverseZonePrefix = 24
+	defaultIPv6ReverseZonePrefix = 64
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PTRZoneAutoResource{}
+var _ resource.ResourceWithModifyPlan = &PTRZoneAutoResource{}
+
+func NewPTRZoneAutoResource() resource.Resource {
+	return &PTRZoneAutoResource{}
+}
+
+// PTRZoneAutoResource defines the resource implementation.
+type PTRZoneAutoResource struct {
+	client client.ClientAPI
+}
+
+// PTRZoneAutoResourceModel describes the resource data model.
+type PTRZoneAutoResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Address      types.String `tfsdk:"address"`
+	PrefixLength types.Int64  `tfsdk:"prefix_length"`
+	Zone         types.String `tfsdk:"zone"`
+	Created      types.Bool   `tfsdk:"created"`
+}
+
+func (r *PTRZoneAutoResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ptr_zone_auto"
+}
+
+func (r *PTRZoneAutoResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Ensures the reverse zone for an IPv4 or IPv6 address exists, creating a primary zone when it is missing. " +
+			"Set `address` to the data of an A or AAAA `technitium_dns_record` so its PTR record has a zone to go in, and reference `zone` " +
+			"from PTR records to order them after it. A zone that already existed is adopted and left in place on destroy; " +
+			"a zone created by this resource is deleted with it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier (the reverse zone name)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"address": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 or IPv6 address the reverse zone is for, typically the data of an A or AAAA record. " +
+					"Changing it only replaces the resource when the address falls in a different reverse zone",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						reverseZoneChanged,
+						"Replaces the resource when the address falls in a different reverse zone",
+						"Replaces the resource when the address falls in a different reverse zone",
+					),
+				},
+				Validators: []validator.String{
+					ipAddressValidator{family: ipFamilyAny},
+				},
+			},
+			"prefix_length": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The prefix length of the network the reverse zone covers: 8, 16 or 24 for IPv4 (default `%d`) "+
+					"and a multiple of 4 up to 124 for IPv6 (default `%d`)", defaultIPv4ReverseZonePrefix, defaultIPv6ReverseZonePrefix),
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The name of the reverse zone, e.g. `1.168.192.in-addr.arpa`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created": schema.BoolAttribute{
+				MarkdownDescription: "Whether the zone was created by this resource, and is therefore deleted on destroy",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *PTRZoneAutoResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *PTRZoneAutoResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compute when the zone is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data PTRZoneAutoResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Address.IsUnknown() {
+		return
+	}
+
+	var configPrefixLength types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("prefix_length"), &configPrefixLength)...)
+	if resp.Diagnostics.HasError() || configPrefixLength.IsUnknown() {
+		return
+	}
+
+	// Compute the zone at plan time so records can reference it before apply
+	zone, prefixLength, err := reverseZoneName(data.Address.ValueString(), configPrefixLength.ValueInt64Pointer())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("address"), "Invalid reverse zone", err.Error())
+		return
+	}
+
+	if !data.PrefixLength.IsUnknown() && data.PrefixLength.ValueInt64() != prefixLength && !req.State.Raw.IsNull() {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("prefix_length"))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("prefix_length"), prefixLength)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone"), zone)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), zone)...)
+}
+
+// reverseZoneChanged reports whether a new address falls in a different reverse zone than the one
+// in state, so addresses within the same network update the resource in place
+func reverseZoneChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var prefixLength types.Int64
+	var zone types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("prefix_length"), &prefixLength)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values may land in any zone
+	if req.PlanValue.IsUnknown() || prefixLength.IsUnknown() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	planned, _, err := reverseZoneName(req.PlanValue.ValueString(), prefixLength.ValueInt64Pointer())
+	resp.RequiresReplace = err != nil || planned != zone.ValueString()
+}
+
+func (r *PTRZoneAutoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PTRZoneAutoResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configuredLength *int64
+	if !data.PrefixLength.IsUnknown() {
+		configuredLength = data.PrefixLength.ValueInt64Pointer()
+	}
+
+	zone, prefixLength, err := reverseZoneName(data.Address.ValueString(), configuredLength)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("address"), "Invalid reverse zone", err.Error())
+		return
+	}
+
+	exists, err := r.client.ZoneExists(ctx, zone)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read zones: %s", err.Error()))
+		return
+	}
+
+	if exists {
+		tflog.Debug(ctx, "Reverse zone already exists, adopting it", map[string]interface{}{
+			"zone": zone,
+		})
+	} else {
+		tflog.Debug(ctx, "Creating reverse zone", map[string]interface{}{
+			"zone": zone,
+		})
+
+		if err := r.client.CreateZone(ctx, zone, "Primary"); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create reverse zone %s: %s", zone, err.Error()))
+			return
+		}
+	}
+
+	data.ID = types.StringValue(zone)
+	data.Zone = types.StringValue(zone)
+	data.PrefixLength = types.Int64Value(prefixLength)
+	data.Created = types.BoolValue(!exists)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PTRZoneAutoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PTRZoneAutoResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exists, err := r.client.ZoneExists(ctx, data.Zone.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read zones: %s", err.Error()))
+		return
+	}
+
+	if !exists {
+		tflog.Debug(ctx, "Reverse zone not found, removing from state", map[string]interface{}{
+			"zone": data.Zone.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PTRZoneAutoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Changes of the reverse zone require replacement, so only the address within it is updated
+	var data PTRZoneAutoResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PTRZoneAutoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PTRZoneAutoResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Created.ValueBool() {
+		tflog.Debug(ctx, "Leaving adopted reverse zone in place", map[string]interface{}{
+			"zone": data.Zone.ValueString(),
+		})
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting reverse zone", map[string]interface{}{
+		"zone": data.Zone.ValueString(),
+	})
+
+	if err := r.client.DeleteZone(ctx, data.Zone.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete reverse zone %s: %s", data.Zone.ValueString(), err.Error()))
+		return
+	}
+}
+
+// reverseZoneName returns the in-addr.arpa or ip6.arpa zone covering the address, along with the
+// prefix length used. A nil prefix length picks the default for the address family.
+func reverseZoneName(address string, prefixLength *int64) (string, int64, error) {
+	addr, err := netip.ParseAddr(address)
+	if err != nil {
+		return "", 0, fmt.Errorf("%q is not an IPv4 or IPv6 address", address)
+	}
+	addr = addr.Unmap().WithZone("")
+
+	var labels []string
+	if addr.Is4() {
+		length := int64(defaultIPv4ReverseZonePrefix)
+		if prefixLength != nil {
+			length = *prefixLength
+		}
+		if length < 8 || length > 24 || length%8 != 0 {
+			return "", 0, fmt.Errorf("the prefix length of an IPv4 reverse zone must be 8, 16 or 24, got %d", length)
+		}
+
+		octets := addr.As4()
+		for _, octet := range octets[:length/8] {
+			labels = append(labels, strconv.Itoa(int(octet)))
+		}
+		slices.Reverse(labels)
+		return strings.Join(append(labels, "in-addr.arpa"), "."), length, nil
+	}
+
+	length := int64(defaultIPv6ReverseZonePrefix)
+	if prefixLength != nil {
+		length = *prefixLength
+	}
+	if length < 4 || length > 124 || length%4 != 0 {
+		return "", 0, fmt.Errorf("the prefix length of an IPv6 reverse zone must be a multiple of 4 between 4 and 124, got %d", length)
+	}
+
+	for _, b := range addr.As16() {
+		labels = append(labels, strconv.FormatUint(uint64(b>>4), 16), strconv.FormatUint(uint64(b&0xf), 16))
+	}
+	labels = labels[:length/4]
+	slices.Reverse(labels)
+	return strings.Join(append(labels, "ip6.arpa"), "."), length, nil
+}
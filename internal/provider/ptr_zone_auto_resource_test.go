@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestPTRZoneAutoResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewPTRZoneAutoResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_ptr_zone_auto" {
+			t.Errorf("Expected TypeName to be technitium_ptr_zone_auto, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewPTRZoneAutoResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "address", "prefix_length", "zone", "created"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+	})
+}
+
+func TestReverseZoneName(t *testing.T) {
+	t.Parallel()
+
+	length := func(l int64) *int64 { return &l }
+
+	tests := []struct {
+		address        string
+		prefixLength   *int64
+		expectedZone   string
+		expectedLength int64
+	}{
+		{"192.168.1.10", nil, "1.168.192.in-addr.arpa", 24},
+		{"10.20.30.40", length(8), "10.in-addr.arpa", 8},
+		{"10.20.30.40", length(16), "20.10.in-addr.arpa", 16},
+		{"::ffff:192.168.1.10", nil, "1.168.192.in-addr.arpa", 24},
+		{"2001:db8::1", nil, "0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa", 64},
+		{"2001:db8:abcd:12::1", length(48), "d.c.b.a.8.b.d.0.1.0.0.2.ip6.arpa", 48},
+		{"fe80::1%eth0", length(12), "8.e.f.ip6.arpa", 12},
+	}
+
+	for _, tt := range tests {
+		zone, prefixLength, err := reverseZoneName(tt.address, tt.prefixLength)
+		require.NoError(t, err, tt.address)
+		require.Equal(t, tt.expectedZone, zone, tt.address)
+		require.Equal(t, tt.expectedLength, prefixLength, tt.address)
+	}
+
+	for _, tt := range []struct {
+		address      string
+		prefixLength *int64
+	}{
+		{"www.example.com", nil},
+		{"192.168.1.10", length(20)},
+		{"192.168.1.10", length(32)},
+		{"2001:db8::1", length(66)},
+		{"2001:db8::1", length(128)},
+	} {
+		_, _, err := reverseZoneName(tt.address, tt.prefixLength)
+		require.Error(t, err, "%s/%v", tt.address, tt.prefixLength)
+	}
+}
+
+func TestPTRZoneAutoResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := &PTRZoneAutoResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	newPlan := func(address string) tfsdk.Plan {
+		return resourcePlan(t, schemaResp, &PTRZoneAutoResourceModel{
+			ID:           types.StringUnknown(),
+			Address:      types.StringValue(address),
+			PrefixLength: types.Int64Unknown(),
+			Zone:         types.StringUnknown(),
+			Created:      types.BoolUnknown(),
+		})
+	}
+
+	// The zone is known at plan time
+	plan := newPlan("192.168.1.10")
+	configState := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, configState.Set(context.Background(), &PTRZoneAutoResourceModel{
+		ID:           types.StringNull(),
+		Address:      types.StringValue("192.168.1.10"),
+		PrefixLength: types.Int64Null(),
+		Zone:         types.StringNull(),
+		Created:      types.BoolNull(),
+	}).HasError())
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw}
+	modifyResp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+		Plan:   plan,
+		Config: config,
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)},
+	}, &modifyResp)
+	require.False(t, modifyResp.Diagnostics.HasError(), "modify plan diagnostics: %v", modifyResp.Diagnostics)
+
+	var planned PTRZoneAutoResourceModel
+	require.False(t, modifyResp.Plan.Get(context.Background(), &planned).HasError())
+	require.Equal(t, "1.168.192.in-addr.arpa", planned.Zone.ValueString())
+	require.Equal(t, int64(24), planned.PrefixLength.ValueInt64())
+
+	// A missing zone is created and deleted with the resource
+	m.On("ZoneExists", mock.Anything, "1.168.192.in-addr.arpa").Return(false, nil).Once()
+	m.On("CreateZone", mock.Anything, "1.168.192.in-addr.arpa", "Primary").Return(nil).Once()
+
+	created := createResource(t, r, modifyResp.Plan)
+
+	var state PTRZoneAutoResourceModel
+	require.False(t, created.Get(context.Background(), &state).HasError())
+	require.True(t, state.Created.ValueBool())
+
+	m.On("DeleteZone", mock.Anything, "1.168.192.in-addr.arpa").Return(nil).Once()
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: created}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+
+	// An existing zone is adopted and left in place
+	m.On("ZoneExists", mock.Anything, "0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa").Return(true, nil).Twice()
+
+	created = createResource(t, r, newPlan("2001:db8::1"))
+	require.False(t, created.Get(context.Background(), &state).HasError())
+	require.False(t, state.Created.ValueBool())
+	require.Equal(t, int64(64), state.PrefixLength.ValueInt64())
+
+	readResp := resource.ReadResponse{State: created}
+	r.Read(context.Background(), resource.ReadRequest{State: created}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.False(t, readResp.State.Raw.IsNull())
+
+	deleteResp = resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+}
+
+func TestReverseZoneChanged(t *testing.T) {
+	t.Parallel()
+
+	r := &PTRZoneAutoResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(context.Background(), &PTRZoneAutoResourceModel{
+		ID:           types.StringValue("1.168.192.in-addr.arpa"),
+		Address:      types.StringValue("192.168.1.10"),
+		PrefixLength: types.Int64Value(24),
+		Zone:         types.StringValue("1.168.192.in-addr.arpa"),
+		Created:      types.BoolValue(true),
+	}).HasError())
+
+	for name, tt := range map[string]struct {
+		address       types.String
+		prefixLength  types.Int64
+		expectReplace bool
+	}{
+		"same network":    {address: types.StringValue("192.168.1.20"), prefixLength: types.Int64Null()},
+		"other network":   {address: types.StringValue("192.168.2.10"), prefixLength: types.Int64Null(), expectReplace: true},
+		"shorter prefix":  {address: types.StringValue("192.168.1.20"), prefixLength: types.Int64Value(16), expectReplace: true},
+		"unknown address": {address: types.StringUnknown(), prefixLength: types.Int64Null(), expectReplace: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			configState := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, configState.Set(context.Background(), &PTRZoneAutoResourceModel{
+				ID:           types.StringNull(),
+				Address:      tt.address,
+				PrefixLength: tt.prefixLength,
+				Zone:         types.StringNull(),
+				Created:      types.BoolNull(),
+			}).HasError())
+
+			var resp stringplanmodifier.RequiresReplaceIfFuncResponse
+			reverseZoneChanged(context.Background(), planmodifier.StringRequest{
+				Config:     tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw},
+				State:      state,
+				PlanValue:  tt.address,
+				StateValue: types.StringValue("192.168.1.10"),
+			}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+			require.Equal(t, tt.expectReplace, resp.RequiresReplace)
+		})
+	}
+}
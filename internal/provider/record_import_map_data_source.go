@@ -0,0 +1,400 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &RecordImportMapDataSource{}
+
+func NewRecordImportMapDataSource() datasource.DataSource {
+	return &RecordImportMapDataSource{}
+}
+
+// RecordImportMapDataSource converts generic record sets into technitium_dns_record arguments.
+// It only transforms its input and never calls the DNS server.
+type RecordImportMapDataSource struct{}
+
+// RecordImportMapDataSourceModel describes the data source data model.
+type RecordImportMapDataSourceModel struct {
+	ID       types.String              `tfsdk:"id"`
+	Zone     types.String              `tfsdk:"zone"`
+	Records  []RecordImportMapInput    `tfsdk:"records"`
+	Mappings []RecordImportMapResult   `tfsdk:"mappings"`
+	Skipped  []RecordImportMapSkipItem `tfsdk:"skipped"`
+}
+
+// RecordImportMapInput is a record set in the shape exported by other DNS providers
+type RecordImportMapInput struct {
+	Name   types.String   `tfsdk:"name"`
+	Type   types.String   `tfsdk:"type"`
+	TTL    types.Int64    `tfsdk:"ttl"`
+	Values []types.String `tfsdk:"values"`
+}
+
+// RecordImportMapResult holds the technitium_dns_record arguments for a single record value
+type RecordImportMapResult struct {
+	Key      types.String `tfsdk:"key"`
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Data     types.String `tfsdk:"data"`
+	Priority types.Int64  `tfsdk:"priority"`
+	Weight   types.Int64  `tfsdk:"weight"`
+	Port     types.Int64  `tfsdk:"port"`
+	Flags    types.Int64  `tfsdk:"flags"`
+	Tag      types.String `tfsdk:"tag"`
+	ImportID types.String `tfsdk:"import_id"`
+}
+
+// RecordImportMapSkipItem describes an input value that could not be mapped
+type RecordImportMapSkipItem struct {
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Value  types.String `tfsdk:"value"`
+	Reason types.String `tfsdk:"reason"`
+}
+
+func (d *RecordImportMapDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_import_map"
+}
+
+func (d *RecordImportMapDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Maps generic record sets exported from other DNS providers to technitium_dns_record arguments",
+		MarkdownDescription: "Maps generic record sets (name, type, ttl, values), the shape exported from Cloudflare, Route 53 and similar providers, " +
+			"to `technitium_dns_record` arguments and import IDs. Values are split into one mapping per record since Technitium manages each " +
+			"record value separately. MX, SRV and CAA values are parsed into their priority, weight, port, flags and tag fields, TXT values are unquoted and " +
+			"trailing dots are removed from names and targets. The data source only transforms its input and does not contact the DNS server.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The Technitium zone the records are migrated into. Record names are made relative to this zone.",
+				Required:            true,
+			},
+			"records": schema.ListNestedAttribute{
+				MarkdownDescription: "Record sets to map.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Record name. Relative names, '@' and fully qualified names with or without a trailing dot are accepted.",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Record type (e.g., A, CNAME, MX).",
+							Required:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "Time-to-live value in seconds.",
+							Required:            true,
+							Validators: []validator.Int64{
+								ttlValidator(),
+							},
+						},
+						"values": schema.ListAttribute{
+							MarkdownDescription: "Record values in zone file presentation format (e.g., `10 mail.example.com.` for MX).",
+							ElementType:         types.StringType,
+							Required:            true,
+						},
+					},
+				},
+			},
+			"mappings": schema.ListNestedAttribute{
+				MarkdownDescription: "One entry per record value, holding the matching `technitium_dns_record` arguments.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "Stable key for use with `for_each`, built from the name, type, priority, weight, port, flags, tag and data.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The record name relative to the zone, '@' for the zone apex.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The DNS record type.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "Time-to-live value in seconds.",
+							Computed:            true,
+						},
+						"data": schema.StringAttribute{
+							MarkdownDescription: "The record data.",
+							Computed:            true,
+						},
+						"priority": schema.Int64Attribute{
+							MarkdownDescription: "Priority for MX and SRV records, null otherwise.",
+							Computed:            true,
+						},
+						"weight": schema.Int64Attribute{
+							MarkdownDescription: "Weight for SRV records, null otherwise.",
+							Computed:            true,
+						},
+						"port": schema.Int64Attribute{
+							MarkdownDescription: "Port for SRV records, null otherwise.",
+							Computed:            true,
+						},
+						"flags": schema.Int64Attribute{
+							MarkdownDescription: "Flags for CAA records, null otherwise.",
+							Computed:            true,
+						},
+						"tag": schema.StringAttribute{
+							MarkdownDescription: "Property tag for CAA records, null otherwise.",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							MarkdownDescription: "Import ID for adopting an existing record with `terraform import` or an `import` block.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"skipped": schema.ListNestedAttribute{
+				MarkdownDescription: "Record values that could not be mapped, with the reason.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The record name from the input.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The record type from the input.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The record value from the input.",
+							Computed:            true,
+						},
+						"reason": schema.StringAttribute{
+							MarkdownDescription: "Why the value was skipped.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RecordImportMapDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RecordImportMapDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := strings.ToLower(strings.TrimSuffix(data.Zone.ValueString(), "."))
+
+	mappings := make([]RecordImportMapResult, 0)
+	skipped := make([]RecordImportMapSkipItem, 0)
+	for _, input := range data.Records {
+		recordType := strings.ToUpper(input.Type.ValueString())
+		name, ok := relativeRecordName(input.Name.ValueString(), zoneName)
+
+		for _, value := range input.Values {
+			skip := func(reason string) {
+				skipped = append(skipped, RecordImportMapSkipItem{
+					Name:   input.Name,
+					Type:   input.Type,
+					Value:  value,
+					Reason: types.StringValue(reason),
+				})
+			}
+
+			if !ok {
+				skip(fmt.Sprintf("name is outside of zone %s", zoneName))
+				continue
+			}
+
+			record, err := mapRecordValue(recordType, value.ValueString())
+			if err != nil {
+				skip(err.Error())
+				continue
+			}
+			record.Name = name
+			if record.TTL, err = ttlToInt(input.TTL); err != nil {
+				skip(err.Error())
+				continue
+			}
+
+			mappings = append(mappings, recordImportMapResult(zoneName, record))
+		}
+	}
+
+	data.ID = types.StringValue(zoneName)
+	data.Mappings = mappings
+	data.Skipped = skipped
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// relativeRecordName converts a record name from another provider into a name relative to the zone.
+// It reports false when a fully qualified name does not belong to the zone.
+func relativeRecordName(name, zoneName string) (string, bool) {
+	fqdn := strings.HasSuffix(name, ".")
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+	switch {
+	case name == "" || name == "@" || name == zoneName:
+		return "@", true
+	case strings.HasSuffix(name, "."+zoneName):
+		return strings.TrimSuffix(name, "."+zoneName), true
+	case fqdn:
+		return "", false
+	default:
+		return name, true
+	}
+}
+
+// mapRecordValue parses a zone file presentation value into the record data used by the provider
+func mapRecordValue(recordType, value string) (client.DNSRecord, error) {
+	record := client.DNSRecord{Type: recordType}
+	fields := strings.Fields(value)
+
+	switch recordType {
+	case "A", "AAAA":
+		record.RData.IPAddress = value
+	case "CNAME":
+		record.RData.CNAME = strings.TrimSuffix(value, ".")
+	case "NS":
+		record.RData.NameServer = strings.TrimSuffix(value, ".")
+	case "PTR":
+		record.RData.PTRName = strings.TrimSuffix(value, ".")
+	case "TXT":
+		record.RData.Text = unquoteTXT(value)
+	case "MX":
+		if len(fields) != 2 {
+			return record, fmt.Errorf("MX value must be in the format '<preference> <exchange>'")
+		}
+		preference, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return record, fmt.Errorf("invalid MX preference %q", fields[0])
+		}
+		record.RData.Preference = preference
+		record.RData.Exchange = strings.TrimSuffix(fields[1], ".")
+	case "SRV":
+		if len(fields) != 4 {
+			return record, fmt.Errorf("SRV value must be in the format '<priority> <weight> <port> <target>'")
+		}
+		numbers := make([]int, 3)
+		for i, field := range fields[:3] {
+			number, err := strconv.Atoi(field)
+			if err != nil {
+				return record, fmt.Errorf("invalid SRV number %q", field)
+			}
+			numbers[i] = number
+		}
+		record.RData.Priority, record.RData.Weight, record.RData.Port = numbers[0], numbers[1], numbers[2]
+		record.RData.Target = strings.TrimSuffix(fields[3], ".")
+	case "CAA":
+		if len(fields) < 3 {
+			return record, fmt.Errorf("CAA value must be in the format '<flags> <tag> <value>'")
+		}
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			return record, fmt.Errorf("invalid CAA flags %q", fields[0])
+		}
+		record.RData.Flags = client.RecordFlags(fields[0])
+		record.RData.Tag = fields[1]
+		// The value is everything after the tag, usually quoted
+		_, rest, _ := strings.Cut(strings.TrimSpace(value), fields[0])
+		_, rest, _ = strings.Cut(rest, fields[1])
+		record.RData.Value = unquoteTXT(rest)
+	default:
+		return record, fmt.Errorf("record type %s is not supported by technitium_dns_record", recordType)
+	}
+
+	return record, nil
+}
+
+// unquoteTXT joins the quoted character strings of a TXT value, e.g. `"v=spf1 " "-all"`.
+// Values without quotes are returned unchanged.
+func unquoteTXT(value string) string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, `"`) {
+		return value
+	}
+
+	var text strings.Builder
+	inQuotes, escaped := false, false
+	for _, r := range value {
+		switch {
+		case escaped:
+			text.WriteRune(r)
+			escaped = false
+		case r == '\\' && inQuotes:
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+			text.WriteRune(r)
+		}
+	}
+	return text.String()
+}
+
+// recordImportMapResult converts a parsed record into the technitium_dns_record arguments
+func recordImportMapResult(zoneName string, record client.DNSRecord) RecordImportMapResult {
+	result := RecordImportMapResult{
+		Name:     types.StringValue(record.Name),
+		Type:     types.StringValue(record.Type),
+		TTL:      types.Int64Value(int64(record.TTL)),
+		Priority: types.Int64Null(),
+		Weight:   types.Int64Null(),
+		Port:     types.Int64Null(),
+		Flags:    types.Int64Null(),
+		Tag:      types.StringNull(),
+		ImportID: types.StringValue(recordImportID(zoneName, record.Name, record)),
+	}
+
+	switch record.Type {
+	case "MX":
+		result.Data = types.StringValue(record.RData.Exchange)
+		result.Priority = types.Int64Value(int64(record.RData.Preference))
+	case "SRV":
+		result.Data = types.StringValue(record.RData.Target)
+		result.Priority = types.Int64Value(int64(record.RData.Priority))
+		result.Weight = types.Int64Value(int64(record.RData.Weight))
+		result.Port = types.Int64Value(int64(record.RData.Port))
+	case "CAA":
+		result.Data = types.StringValue(record.RData.Value)
+		result.Flags = types.Int64Value(int64(record.RData.Flags.Int()))
+		result.Tag = types.StringValue(record.RData.Tag)
+	default:
+		result.Data = types.StringValue(formatRecordData(record))
+	}
+
+	// Values with the same data but a different priority, weight, port, flags or tag need their own keys
+	key := []string{record.Name, record.Type}
+	for _, number := range []types.Int64{result.Priority, result.Weight, result.Port, result.Flags} {
+		if !number.IsNull() {
+			key = append(key, strconv.FormatInt(number.ValueInt64(), 10))
+		}
+	}
+	if !result.Tag.IsNull() {
+		key = append(key, result.Tag.ValueString())
+	}
+	result.Key = types.StringValue(strings.Join(append(key, result.Data.ValueString()), "_"))
+
+	return result
+}
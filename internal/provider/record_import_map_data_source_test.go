@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+)
+
+func TestRecordImportMapDataSource(t *testing.T) {
+	t.Parallel()
+
+	// Unit test - verify data source creation
+	t.Run("NewRecordImportMapDataSource", func(t *testing.T) {
+		ds := NewRecordImportMapDataSource()
+		if ds == nil {
+			t.Fatal("NewRecordImportMapDataSource should return a non-nil data source")
+		}
+
+		// Test metadata
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{
+			ProviderTypeName: "technitium",
+		}, &resp)
+
+		if resp.TypeName != "technitium_record_import_map" {
+			t.Errorf("Expected TypeName to be technitium_record_import_map, got %s", resp.TypeName)
+		}
+	})
+
+	// Unit test - verify schema
+	t.Run("Schema", func(t *testing.T) {
+		ds := NewRecordImportMapDataSource()
+		var resp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"zone", "records"} {
+			if attr, ok := resp.Schema.Attributes[name]; !ok || !attr.IsRequired() {
+				t.Errorf("Schema should have required '%s' attribute", name)
+			}
+		}
+		for _, name := range []string{"id", "mappings", "skipped"} {
+			if attr, ok := resp.Schema.Attributes[name]; !ok || !attr.IsComputed() {
+				t.Errorf("Schema should have computed '%s' attribute", name)
+			}
+		}
+	})
+}
+
+func TestRelativeRecordName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"@", "@", true},
+		{"example.com.", "@", true},
+		{"WWW.Example.com", "www", true},
+		{"mail.example.com.", "mail", true},
+		{"api", "api", true},
+		{"other.org.", "", false},
+	}
+
+	for _, tt := range tests {
+		name, ok := relativeRecordName(tt.input, "example.com")
+		if name != tt.expected || ok != tt.ok {
+			t.Errorf("relativeRecordName(%q) = %q, %v; expected %q, %v", tt.input, name, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestMapRecordValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		recordType string
+		value      string
+		expected   RecordImportMapResult
+		errorText  string
+	}{
+		{
+			name:       "A record",
+			recordType: "A",
+			value:      "192.0.2.10",
+		},
+		{
+			name:       "MX record with trailing dot",
+			recordType: "MX",
+			value:      "10 mail.example.com.",
+		},
+		{
+			name:       "SRV record",
+			recordType: "SRV",
+			value:      "10 5 5060 sip.example.com.",
+		},
+		{
+			name:       "split TXT record",
+			recordType: "TXT",
+			value:      `"v=spf1 include:_spf.example.net " "-all"`,
+		},
+		{
+			name:       "invalid MX record",
+			recordType: "MX",
+			value:      "mail.example.com",
+			errorText:  "MX value must be in the format '<preference> <exchange>'",
+		},
+		{
+			name:       "CAA record",
+			recordType: "CAA",
+			value:      `0 iodef "mailto:security@example.com"`,
+		},
+		{
+			name:       "unsupported type",
+			recordType: "HINFO",
+			value:      `"PC" "Linux"`,
+			errorText:  "record type HINFO is not supported by technitium_dns_record",
+		},
+	}
+
+	expected := map[string]struct {
+		data     string
+		priority int64
+		importID string
+		key      string
+	}{
+		"A record":                    {"192.0.2.10", -1, "example.com:www:A:192.0.2.10", "www_A_192.0.2.10"},
+		"MX record with trailing dot": {"mail.example.com", 10, "example.com:www:MX:10:mail.example.com", "www_MX_10_mail.example.com"},
+		"SRV record":                  {"sip.example.com", 10, "example.com:www:SRV:10:sip.example.com", "www_SRV_10_5_5060_sip.example.com"},
+		"split TXT record":            {"v=spf1 include:_spf.example.net -all", -1, "example.com:www:TXT", "www_TXT_v=spf1 include:_spf.example.net -all"},
+		"CAA record":                  {"mailto:security@example.com", -1, "example.com:www:CAA:iodef:mailto%3Asecurity@example.com", "www_CAA_0_iodef_mailto:security@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := mapRecordValue(tt.recordType, tt.value)
+			if tt.errorText != "" {
+				if err == nil || err.Error() != tt.errorText {
+					t.Errorf("Expected error %q, got %v", tt.errorText, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			record.Name = "www"
+			record.TTL = 300
+			result := recordImportMapResult("example.com", record)
+			want := expected[tt.name]
+
+			if result.Data.ValueString() != want.data {
+				t.Errorf("Expected data %q, got %q", want.data, result.Data.ValueString())
+			}
+			if want.priority < 0 && !result.Priority.IsNull() {
+				t.Errorf("Expected null priority, got %d", result.Priority.ValueInt64())
+			}
+			if want.priority >= 0 && result.Priority.ValueInt64() != want.priority {
+				t.Errorf("Expected priority %d, got %d", want.priority, result.Priority.ValueInt64())
+			}
+			if result.ImportID.ValueString() != want.importID {
+				t.Errorf("Expected import ID %q, got %q", want.importID, result.ImportID.ValueString())
+			}
+			if result.Key.ValueString() != want.key {
+				t.Errorf("Expected key %q, got %q", want.key, result.Key.ValueString())
+			}
+			if result.TTL.ValueInt64() != 300 {
+				t.Errorf("Expected TTL 300, got %d", result.TTL.ValueInt64())
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// recordTypeAttributes lists the record types that use each of the numeric attributes shared
+// between record types. Other record types always store 0 for them.
+var recordTypeAttributes = map[string][]string{
+	"priority": {"MX", "SRV", "SVCB", "HTTPS"},
+	"weight":   {"SRV"},
+	"port":     {"SRV"},
+}
+
+// recordTypeUsesAttribute reports whether records of recordType carry a value for attribute
+func recordTypeUsesAttribute(recordType, attribute string) bool {
+	return slices.Contains(recordTypeAttributes[attribute], recordType)
+}
+
+// recordTypeAwareInt64 plans unconfigured shared numeric attributes based on the record type.
+// Record types that do not use the attribute get 0, matching what Read stores. Record types that
+// use it keep the prior value only while the record keeps its type, so a value never leaks from a
+// replaced record of another type into the new plan.
+func recordTypeAwareInt64(attribute string) planmodifier.Int64 {
+	return recordTypeAwareInt64Modifier{attribute: attribute}
+}
+
+type recordTypeAwareInt64Modifier struct {
+	attribute string
+}
+
+func (m recordTypeAwareInt64Modifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("Defaults to 0 for record types other than %s, otherwise keeps the prior value while the record type is unchanged.",
+		strings.Join(recordTypeAttributes[m.attribute], ", "))
+}
+
+func (m recordTypeAwareInt64Modifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recordTypeAwareInt64Modifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	// Configured values and values already known are planned as is
+	if !req.ConfigValue.IsNull() || !req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var recordType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	if resp.Diagnostics.HasError() || recordType.IsUnknown() {
+		return
+	}
+
+	if !recordTypeUsesAttribute(recordType.ValueString(), m.attribute) {
+		resp.PlanValue = types.Int64Value(0)
+		return
+	}
+
+	// Nothing to carry over on create
+	if req.State.Raw.IsNull() || req.StateValue.IsNull() {
+		return
+	}
+
+	var priorType types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("type"), &priorType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if priorType.Equal(recordType) {
+		resp.PlanValue = req.StateValue
+	}
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordTypeAwareInt64(t *testing.T) {
+	t.Parallel()
+
+	_, _, schemaResp := newMockedDNSRecordResource(t)
+
+	mx := DNSRecordResourceModel{
+		Zone:     types.StringValue("example.com"),
+		Name:     types.StringValue("@"),
+		Type:     types.StringValue("MX"),
+		TTL:      types.Int64Value(3600),
+		Data:     types.StringValue("mail.example.com"),
+		Priority: types.Int64Value(10),
+		Weight:   types.Int64Value(0),
+		Port:     types.Int64Value(0),
+	}
+	srv := DNSRecordResourceModel{
+		Zone:     types.StringValue("example.com"),
+		Name:     types.StringValue("_sip._tcp"),
+		Type:     types.StringValue("SRV"),
+		TTL:      types.Int64Value(3600),
+		Data:     types.StringValue("sip.example.com"),
+		Priority: types.Int64Value(10),
+		Weight:   types.Int64Value(5),
+		Port:     types.Int64Value(5060),
+	}
+
+	tests := []struct {
+		name      string
+		attribute string
+		prior     *DNSRecordResourceModel
+		planType  string
+		config    types.Int64
+		expected  types.Int64
+	}{
+		{
+			name:      "configured MX priority change is planned as configured",
+			attribute: "priority",
+			prior:     &mx,
+			planType:  "MX",
+			config:    types.Int64Value(20),
+			expected:  types.Int64Value(20),
+		},
+		{
+			name:      "unconfigured MX priority keeps the prior value",
+			attribute: "priority",
+			prior:     &mx,
+			planType:  "MX",
+			config:    types.Int64Null(),
+			expected:  types.Int64Value(10),
+		},
+		{
+			name:      "MX priority does not leak into a replacing SRV record",
+			attribute: "priority",
+			prior:     &mx,
+			planType:  "SRV",
+			config:    types.Int64Null(),
+			expected:  types.Int64Unknown(),
+		},
+		{
+			name:      "MX priority does not leak into a replacing CNAME record",
+			attribute: "priority",
+			prior:     &mx,
+			planType:  "CNAME",
+			config:    types.Int64Null(),
+			expected:  types.Int64Value(0),
+		},
+		{
+			name:      "SRV port is not carried over to an MX record",
+			attribute: "port",
+			prior:     &srv,
+			planType:  "MX",
+			config:    types.Int64Null(),
+			expected:  types.Int64Value(0),
+		},
+		{
+			name:      "SRV weight is kept for the same SRV record",
+			attribute: "weight",
+			prior:     &srv,
+			planType:  "SRV",
+			config:    types.Int64Null(),
+			expected:  types.Int64Value(5),
+		},
+		{
+			name:      "new record of a type without weight",
+			attribute: "weight",
+			planType:  "A",
+			config:    types.Int64Null(),
+			expected:  types.Int64Value(0),
+		},
+		{
+			name:      "new SRV record leaves port unknown",
+			attribute: "port",
+			planType:  "SRV",
+			config:    types.Int64Null(),
+			expected:  types.Int64Unknown(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planned := DNSRecordResourceModel{
+				Zone:     types.StringValue("example.com"),
+				Name:     types.StringValue("@"),
+				Type:     types.StringValue(tt.planType),
+				TTL:      types.Int64Value(3600),
+				Data:     types.StringValue("target.example.com"),
+				Priority: types.Int64Unknown(),
+				Weight:   types.Int64Unknown(),
+				Port:     types.Int64Unknown(),
+			}
+
+			// A null state means the record is being created
+			state := tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+			}
+			stateValue := types.Int64Null()
+			if tt.prior != nil {
+				state = recordState(t, schemaResp, *tt.prior)
+				require.False(t, state.GetAttribute(context.Background(), path.Root(tt.attribute), &stateValue).HasError())
+			}
+
+			planValue := tt.config
+			if planValue.IsNull() {
+				planValue = types.Int64Unknown()
+			}
+
+			req := planmodifier.Int64Request{
+				Path:        path.Root(tt.attribute),
+				Plan:        recordPlan(t, schemaResp, planned),
+				State:       state,
+				ConfigValue: tt.config,
+				PlanValue:   planValue,
+				StateValue:  stateValue,
+			}
+			resp := &planmodifier.Int64Response{PlanValue: req.PlanValue}
+			recordTypeAwareInt64(tt.attribute).PlanModifyInt64(context.Background(), req, resp)
+
+			require.False(t, resp.Diagnostics.HasError(), "plan modifier diagnostics: %v", resp.Diagnostics)
+			require.True(t, tt.expected.Equal(resp.PlanValue), "expected %s, got %s", tt.expected, resp.PlanValue)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// stackApplyBudget bounds the apply time of the realistic stack, so regressions in request volume
+// or dependency ordering fail the test instead of slowly creeping the test times up
+const stackApplyBudget = 5 * time.Minute
+
+// TestAccRealisticStack applies a typical environment in a single configuration: a primary zone
+// with mail and service records, a conditional forwarder zone, the Split Horizon app with its
+// configuration and an APP record using it, and a secondary zone on a second server transferring
+// the primary zone. It acts as a regression canary for changes spanning several resources.
+func TestAccRealisticStack(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping acceptance test in short mode")
+	}
+
+	primary := setupTestContainer(t)
+	secondary := setupTestContainer(t)
+
+	// The secondary server reaches the primary over the container network
+	primaryAddress, err := primary.container.ContainerIP(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get the address of the primary server: %v", err)
+	}
+
+	zoneName := sweepZonePrefix + "stack.example.com"
+	var started time.Time
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"technitium": providerserver.NewProtocol6WithError(New("test")()),
+		},
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testAccCheckZoneDestroy(primary),
+			testAccCheckZoneDestroy(secondary),
+			testAccCheckDNSAppDestroy(primary),
+		),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() { started = time.Now() },
+				Config:    testAccRealisticStackConfig(primary, secondary, zoneName, primaryAddress),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckZoneExists(primary, "technitium_zone.primary"),
+					testAccCheckZoneExists(primary, "technitium_zone.forwarder"),
+					testAccCheckZoneExists(secondary, "technitium_zone.secondary"),
+					testAccCheckDNSAppExists(primary, "technitium_dns_app.split_horizon"),
+					resource.TestCheckResourceAttr("technitium_zone.secondary", "type", "Secondary"),
+					resource.TestCheckResourceAttr("technitium_dns_record.mx", "priority", "10"),
+					resource.TestCheckResourceAttr("technitium_dns_record.sip", "port", "5060"),
+					resource.TestCheckResourceAttr("technitium_dns_record.intranet", "class_path", "SplitHorizon.SimpleAddress"),
+					resource.TestCheckResourceAttr("data.technitium_dns_records.mail", "record_type_counts.TXT", "3"),
+					testAccCheckApplyDuration(t, &started, stackApplyBudget),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckApplyDuration fails when more than budget passed since *started
+func testAccCheckApplyDuration(t *testing.T, started *time.Time, budget time.Duration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		elapsed := time.Since(*started)
+		t.Logf("Applied the stack in %s", elapsed.Round(time.Millisecond))
+
+		if elapsed > budget {
+			return fmt.Errorf("applying the stack took %s, more than the budget of %s", elapsed.Round(time.Second), budget)
+		}
+		return nil
+	}
+}
+
+func testAccRealisticStackConfig(primary, secondary *testAccConfig, zoneName, primaryAddress string) string {
+	return primary.getProviderConfig() + fmt.Sprintf(`
+provider "technitium" {
+  alias    = "secondary"
+  host     = "%s"
+  username = "%s"
+  password = "%s"
+}
+
+resource "technitium_zone" "primary" {
+  name          = "%[4]s"
+  type          = "Primary"
+  zone_transfer = "Allow"
+}
+
+resource "technitium_dns_record" "mail" {
+  zone = technitium_zone.primary.name
+  name = "mail"
+  type = "A"
+  ttl  = 3600
+  data = "192.0.2.25"
+}
+
+resource "technitium_dns_record" "mx" {
+  zone     = technitium_zone.primary.name
+  name     = "@"
+  type     = "MX"
+  ttl      = 3600
+  priority = 10
+  data     = "mail.%[4]s"
+}
+
+resource "technitium_dns_record" "spf" {
+  zone = technitium_zone.primary.name
+  name = "@"
+  type = "TXT"
+  ttl  = 3600
+  data = "v=spf1 mx -all"
+}
+
+resource "technitium_dns_record" "dkim" {
+  zone = technitium_zone.primary.name
+  name = "selector1._domainkey"
+  type = "TXT"
+  ttl  = 3600
+  data = "v=DKIM1; k=rsa; p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC1TaNgLlSyQMNWVLNLvyY/neDgaL2oqQE8T5illKqCgDtFHc8eHVAU+nlcaGmrKmDMw9dbgiGk1ocgZ56NR4ycfUHwQhvQPMUZw0cveel/8EAGoi/UyPmqfcPibytH81NFtTMAxUeM4Op8A6iHkvAMj5qLf4YRNsTkKAV"
+}
+
+resource "technitium_dns_record" "dmarc" {
+  zone = technitium_zone.primary.name
+  name = "_dmarc"
+  type = "TXT"
+  ttl  = 3600
+  data = "v=DMARC1; p=quarantine; rua=mailto:dmarc@%[4]s"
+}
+
+resource "technitium_dns_record" "sip" {
+  zone     = technitium_zone.primary.name
+  name     = "_sip._tcp"
+  type     = "SRV"
+  ttl      = 3600
+  priority = 10
+  weight   = 5
+  port     = 5060
+  data     = "sip.%[4]s"
+}
+
+resource "technitium_zone" "forwarder" {
+  name                 = "tf-acc-corp.internal"
+  type                 = "Forwarder"
+  initialize_forwarder = true
+  forwarder            = "8.8.8.8"
+  protocol             = "Udp"
+}
+
+resource "technitium_dns_app" "split_horizon" {
+  name           = "Split Horizon"
+  install_method = "url"
+  url            = "https://download.technitium.com/dns/apps/SplitHorizonApp.zip"
+}
+
+resource "technitium_dns_app_config" "split_horizon" {
+  name = technitium_dns_app.split_horizon.name
+  config = jsonencode({
+    networks = {
+      "office" = ["10.0.0.0/8"]
+    }
+  })
+}
+
+resource "technitium_dns_record" "intranet" {
+  zone       = technitium_zone.primary.name
+  name       = "intranet"
+  type       = "APP"
+  ttl        = 3600
+  app_name   = technitium_dns_app_config.split_horizon.name
+  class_path = "SplitHorizon.SimpleAddress"
+  data = jsonencode({
+    public  = ["203.0.113.10"]
+    private = ["10.0.0.10"]
+  })
+}
+
+data "technitium_dns_records" "mail" {
+  zone         = technitium_zone.primary.name
+  record_types = ["MX", "TXT"]
+
+  depends_on = [
+    technitium_dns_record.mx,
+    technitium_dns_record.spf,
+    technitium_dns_record.dkim,
+    technitium_dns_record.dmarc,
+  ]
+}
+
+resource "technitium_zone" "secondary" {
+  provider = technitium.secondary
+
+  name                          = technitium_zone.primary.name
+  type                          = "Secondary"
+  primary_name_server_addresses = "%[5]s"
+
+  # The transferred records are not managed by this configuration
+  force_destroy = true
+
+  # The zone is transferred once its records exist on the primary
+  depends_on = [
+    technitium_dns_record.mail,
+    technitium_dns_record.mx,
+    technitium_dns_record.spf,
+    technitium_dns_record.dkim,
+    technitium_dns_record.dmarc,
+    technitium_dns_record.sip,
+    technitium_dns_record.intranet,
+  ]
+}
+`, secondary.Host, secondary.Username, secondary.Password, zoneName, primaryAddress)
+}
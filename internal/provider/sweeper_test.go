@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/testhelpers"
+)
+
+// sweepZonePrefix starts the names of the zones created by the acceptance tests (e.g.
+// tf-acc-arecord.example.com), so the sweeper leaves every other zone on the server alone
+const sweepZonePrefix = "tf-acc-"
+
+func init() {
+	resource.AddTestSweepers("technitium_zone", &resource.Sweeper{
+		Name: "technitium_zone",
+		F:    sweepZones,
+	})
+}
+
+// TestMain enables running the sweepers with `go test ./internal/provider -v -sweep=local`
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// sweepZones deletes zones left behind by aborted acceptance test runs. The server is read from
+// TECHNITIUM_HOST and TECHNITIUM_USERNAME/TECHNITIUM_PASSWORD; the sweep region is not used.
+func sweepZones(_ string) error {
+	host := os.Getenv("TECHNITIUM_HOST")
+	if host == "" {
+		return fmt.Errorf("TECHNITIUM_HOST must be set to run the zone sweeper")
+	}
+
+	c, err := testhelpers.CreateTestClient(host, os.Getenv("TECHNITIUM_USERNAME"), os.Getenv("TECHNITIUM_PASSWORD"))
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	deleted, err := c.DeleteZonesByPrefix(ctx, sweepZonePrefix, client.DeleteZonesOptions{
+		Concurrency: 4,
+		Interval:    100 * time.Millisecond,
+	})
+	for _, zone := range deleted {
+		log.Printf("[INFO] Swept zone %s", zone)
+	}
+
+	return err
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// TimeoutsModel describes the timeouts block of resources whose operations can be slow
+type TimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsBlock returns the schema of the timeouts block. Each timeout bounds the whole operation,
+// including retries, and replaces the provider's timeout_seconds for every API request it makes.
+func timeoutsBlock() schema.Block {
+	attribute := func(operation string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("How long to wait for the %s operation, as a Go duration such as `30s` or `10m`. "+
+				"Defaults to the provider `timeout_seconds` for each API request.", operation),
+			Optional:   true,
+			Validators: []validator.String{durationValidator{}},
+		}
+	}
+
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Per-operation timeouts overriding the provider `timeout_seconds`.",
+		Attributes: map[string]schema.Attribute{
+			"create": attribute("create"),
+			"read":   attribute("read"),
+			"update": attribute("update"),
+			"delete": attribute("delete"),
+		},
+	}
+}
+
+// operationContext bounds an operation by its configured timeout. The returned context carries
+// the timeout as both its deadline and the client's per-request timeout; without a configured
+// timeout it is the original context.
+func operationContext(ctx context.Context, timeouts *TimeoutsModel, operation string) (context.Context, context.CancelFunc, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if timeouts == nil {
+		return ctx, func() {}, diags
+	}
+
+	var value types.String
+	switch operation {
+	case "create":
+		value = timeouts.Create
+	case "read":
+		value = timeouts.Read
+	case "update":
+		value = timeouts.Update
+	case "delete":
+		value = timeouts.Delete
+	}
+	if value.IsNull() || value.IsUnknown() {
+		return ctx, func() {}, diags
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Timeout", fmt.Sprintf("The %s timeout %q is not a valid duration: %s", operation, value.ValueString(), err.Error()))
+		return ctx, func() {}, diags
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return client.WithRequestTimeout(ctx, timeout), cancel, diags
+}
+
+// durationValidator checks that a string is a positive Go duration
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 30s or 10m"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if duration, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration",
+			fmt.Sprintf("%q is not a positive duration such as 30s or 10m", req.ConfigValue.ValueString()))
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("without timeouts", func(t *testing.T) {
+		ctx, cancel, diags := operationContext(context.Background(), nil, "create")
+		defer cancel()
+		require.False(t, diags.HasError())
+
+		_, ok := ctx.Deadline()
+		require.False(t, ok)
+	})
+
+	t.Run("with a configured timeout", func(t *testing.T) {
+		timeouts := &TimeoutsModel{Create: types.StringValue("10m"), Read: types.StringNull()}
+
+		ctx, cancel, diags := operationContext(context.Background(), timeouts, "create")
+		defer cancel()
+		require.False(t, diags.HasError())
+
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		require.WithinDuration(t, time.Now().Add(10*time.Minute), deadline, time.Minute)
+
+		ctx, cancel, diags = operationContext(context.Background(), timeouts, "read")
+		defer cancel()
+		require.False(t, diags.HasError())
+		_, ok = ctx.Deadline()
+		require.False(t, ok)
+	})
+
+	t.Run("with an invalid timeout", func(t *testing.T) {
+		_, cancel, diags := operationContext(context.Background(), &TimeoutsModel{Delete: types.StringValue("soon")}, "delete")
+		defer cancel()
+		require.True(t, diags.HasError())
+	})
+}
+
+func TestDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	for value, valid := range map[string]bool{"30s": true, "1h30m": true, "0s": false, "-1m": false, "10": false} {
+		resp := validator.StringResponse{}
+		durationValidator{}.ValidateString(context.Background(), validator.StringRequest{
+			Path:        path.Root("create"),
+			ConfigValue: types.StringValue(value),
+		}, &resp)
+		require.Equal(t, !valid, resp.Diagnostics.HasError(), "value %q", value)
+	}
+}
@@ -0,0 +1,338 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// tsigKeysMu serializes TSIG key changes, which read, modify and write every key of the server
+var tsigKeysMu sync.Mutex
+
+// tsigSecretLengths holds the length in bytes of generated shared secrets, the output size of
+// each algorithm's hash
+var tsigSecretLengths = map[client.TsigAlgorithm]int{
+	client.TsigAlgorithmHmacMd5:       16,
+	client.TsigAlgorithmHmacSha1:      20,
+	client.TsigAlgorithmHmacSha256:    32,
+	client.TsigAlgorithmHmacSha256128: 32,
+	client.TsigAlgorithmHmacSha384:    48,
+	client.TsigAlgorithmHmacSha384192: 48,
+	client.TsigAlgorithmHmacSha512:    64,
+	client.TsigAlgorithmHmacSha512256: 64,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TsigKeyResource{}
+var _ resource.ResourceWithImportState = &TsigKeyResource{}
+var _ resource.ResourceWithModifyPlan = &TsigKeyResource{}
+
+func NewTsigKeyResource() resource.Resource {
+	return &TsigKeyResource{}
+}
+
+// TsigKeyResource defines the resource implementation.
+type TsigKeyResource struct {
+	client client.ClientAPI
+}
+
+// TsigKeyResourceModel describes the resource data model.
+type TsigKeyResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Algorithm    types.String `tfsdk:"algorithm"`
+	SharedSecret types.String `tfsdk:"shared_secret"`
+}
+
+func (r *TsigKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tsig_key"
+}
+
+func (r *TsigKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a TSIG key of the Technitium DNS Server, used to authenticate zone transfers and dynamic updates. " +
+			"Reference the key name from `tsig_key_name` and `zone_transfer_tsig_key_names` of `technitium_zone`. " +
+			"Keys not managed by Terraform are left alone. " +
+			"This resource is experimental: enable the `settings` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier (the key name)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the key, e.g. `xfr.example.com`. It must match the key name configured on the other server",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^|]+$`), "must not be empty or contain \"|\""),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "The HMAC algorithm of the key. Valid values are: " + enumDescription(tsigAlgorithmValues) +
+					". Defaults to `" + string(client.DefaultTsigAlgorithm) + "`",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(string(client.DefaultTsigAlgorithm)),
+				Validators: []validator.String{
+					enumValidator(tsigAlgorithmValues),
+				},
+			},
+			"shared_secret": schema.StringAttribute{
+				MarkdownDescription: "The base64 encoded shared secret, to import an existing key. When not set, a random secret " +
+					"as long as the algorithm's hash is generated",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Za-z0-9+/]+={0,2}$`), "must be base64 encoded"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TsigKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TsigKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the key is being deleted
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	requireExperimentalFeature(ctx, r.client, client.ExperimentalSettings, "technitium_tsig_key", &resp.Diagnostics)
+}
+
+func (r *TsigKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TsigKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating TSIG key", map[string]interface{}{
+		"name":      data.Name.ValueString(),
+		"algorithm": data.Algorithm.ValueString(),
+	})
+
+	if data.SharedSecret.IsUnknown() || data.SharedSecret.IsNull() {
+		secret, err := generateTsigSecret(client.TsigAlgorithm(data.Algorithm.ValueString()))
+		if err != nil {
+			resp.Diagnostics.AddError("Shared Secret Error", fmt.Sprintf("Unable to generate a shared secret: %s", err.Error()))
+			return
+		}
+		data.SharedSecret = types.StringValue(secret)
+	}
+
+	key, err := r.saveKey(ctx, &data, true)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create TSIG key: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, tsigKeyModel(key))...)
+}
+
+func (r *TsigKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TsigKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := r.client.GetDNSSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS settings: %s", err.Error()))
+		return
+	}
+
+	index := findTsigKey(settings.TsigKeys, data.Name.ValueString())
+	if index < 0 {
+		tflog.Debug(ctx, "TSIG key not found, removing from state", map[string]interface{}{
+			"name": data.Name.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, tsigKeyModel(&settings.TsigKeys[index]))...)
+}
+
+func (r *TsigKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TsigKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating TSIG key", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+
+	key, err := r.saveKey(ctx, &data, false)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update TSIG key: %s", err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, tsigKeyModel(key))...)
+}
+
+func (r *TsigKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TsigKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting TSIG key", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+
+	tsigKeysMu.Lock()
+	defer tsigKeysMu.Unlock()
+
+	settings, err := r.client.GetDNSSettings(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNS settings: %s", err.Error()))
+		return
+	}
+
+	index := findTsigKey(settings.TsigKeys, data.Name.ValueString())
+	if index < 0 {
+		return
+	}
+
+	keys := slices.Delete(settings.TsigKeys, index, index+1)
+	if _, err := r.client.SetDNSSettings(ctx, map[string]string{"tsigKeys": client.TsigKeysParam(keys)}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete TSIG key: %s", err.Error()))
+		return
+	}
+}
+
+func (r *TsigKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using the key name as the ID
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
+
+// saveKey adds or replaces the key among the server's TSIG keys and returns it as saved. Creating
+// a key whose name is already taken fails rather than overwriting the existing key.
+func (r *TsigKeyResource) saveKey(ctx context.Context, data *TsigKeyResourceModel, create bool) (*client.TsigKey, error) {
+	tsigKeysMu.Lock()
+	defer tsigKeysMu.Unlock()
+
+	settings, err := r.client.GetDNSSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := client.TsigKey{
+		KeyName:       data.Name.ValueString(),
+		SharedSecret:  data.SharedSecret.ValueString(),
+		AlgorithmName: data.Algorithm.ValueString(),
+	}
+
+	keys := settings.TsigKeys
+	if index := findTsigKey(keys, key.KeyName); index < 0 {
+		keys = append(keys, key)
+	} else if create {
+		return nil, fmt.Errorf("a TSIG key named %s already exists, import it instead", key.KeyName)
+	} else {
+		keys[index] = key
+	}
+
+	settings, err = r.client.SetDNSSettings(ctx, map[string]string{"tsigKeys": client.TsigKeysParam(keys)})
+	if err != nil {
+		return nil, err
+	}
+
+	index := findTsigKey(settings.TsigKeys, key.KeyName)
+	if index < 0 {
+		return nil, fmt.Errorf("TSIG key %s was not saved by the server", key.KeyName)
+	}
+	return &settings.TsigKeys[index], nil
+}
+
+// findTsigKey returns the index of the named key, or -1. Key names are domain names, so they
+// are compared case-insensitively.
+func findTsigKey(keys []client.TsigKey, name string) int {
+	return slices.IndexFunc(keys, func(key client.TsigKey) bool {
+		return strings.EqualFold(strings.TrimSuffix(key.KeyName, "."), strings.TrimSuffix(name, "."))
+	})
+}
+
+// tsigKeyModel converts a TSIG key to the resource model
+func tsigKeyModel(key *client.TsigKey) *TsigKeyResourceModel {
+	return &TsigKeyResourceModel{
+		ID:           types.StringValue(key.KeyName),
+		Name:         types.StringValue(key.KeyName),
+		Algorithm:    types.StringValue(key.AlgorithmName),
+		SharedSecret: types.StringValue(key.SharedSecret),
+	}
+}
+
+// generateTsigSecret returns a random base64 encoded shared secret as long as the algorithm's hash
+func generateTsigSecret(algorithm client.TsigAlgorithm) (string, error) {
+	length, ok := tsigSecretLengths[algorithm]
+	if !ok {
+		length = tsigSecretLengths[client.DefaultTsigAlgorithm]
+	}
+
+	secret := make([]byte, length)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(secret), nil
+}
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestTsigKeyResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewTsigKeyResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_tsig_key" {
+			t.Errorf("Expected TypeName to be technitium_tsig_key, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewTsigKeyResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "name", "algorithm", "shared_secret"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+		if !resp.Schema.Attributes["shared_secret"].IsSensitive() {
+			t.Error("shared_secret should be sensitive")
+		}
+	})
+}
+
+func TestGenerateTsigSecret(t *testing.T) {
+	t.Parallel()
+
+	for algorithm, length := range tsigSecretLengths {
+		secret, err := generateTsigSecret(algorithm)
+		require.NoError(t, err)
+
+		decoded, err := base64.StdEncoding.DecodeString(secret)
+		require.NoError(t, err)
+		require.Len(t, decoded, length, string(algorithm))
+	}
+}
+
+func TestTsigKeyResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := &TsigKeyResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	// Keys not managed by the resource are preserved
+	home := client.TsigKey{KeyName: "home", SharedSecret: "E9crgbHbzgEI+e+/pBmARRif70ScKf2sc/FjrgnCWyc=", AlgorithmName: "hmac-sha256"}
+	xfr := client.TsigKey{KeyName: "xfr.example.com", SharedSecret: "c2VjcmV0", AlgorithmName: "hmac-sha512"}
+
+	m.On("GetDNSSettings", mock.Anything).Return(&client.DNSSettings{TsigKeys: []client.TsigKey{home}}, nil).Once()
+	m.On("SetDNSSettings", mock.Anything, map[string]string{"tsigKeys": client.TsigKeysParam([]client.TsigKey{home, xfr})}).
+		Return(&client.DNSSettings{TsigKeys: []client.TsigKey{home, xfr}}, nil).Once()
+
+	plan := resourcePlan(t, schemaResp, &TsigKeyResourceModel{
+		ID:           types.StringUnknown(),
+		Name:         types.StringValue("xfr.example.com"),
+		Algorithm:    types.StringValue("hmac-sha512"),
+		SharedSecret: types.StringValue("c2VjcmV0"),
+	})
+	created := createResource(t, r, plan)
+
+	var state TsigKeyResourceModel
+	require.False(t, created.Get(context.Background(), &state).HasError())
+	require.Equal(t, "xfr.example.com", state.ID.ValueString())
+
+	// Creating a key whose name is taken fails instead of overwriting it
+	m.On("GetDNSSettings", mock.Anything).Return(&client.DNSSettings{TsigKeys: []client.TsigKey{home, xfr}}, nil).Once()
+
+	conflictResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &conflictResp)
+	require.True(t, conflictResp.Diagnostics.HasError())
+
+	// A secret changed outside of Terraform is picked up on refresh
+	rotated := xfr
+	rotated.SharedSecret = "cm90YXRlZA=="
+	m.On("GetDNSSettings", mock.Anything).Return(&client.DNSSettings{TsigKeys: []client.TsigKey{home, rotated}}, nil).Once()
+
+	readResp := resource.ReadResponse{State: created}
+	r.Read(context.Background(), resource.ReadRequest{State: created}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.False(t, readResp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "cm90YXRlZA==", state.SharedSecret.ValueString())
+
+	m.On("GetDNSSettings", mock.Anything).Return(&client.DNSSettings{TsigKeys: []client.TsigKey{home, rotated}}, nil).Once()
+	m.On("SetDNSSettings", mock.Anything, map[string]string{"tsigKeys": client.TsigKeysParam([]client.TsigKey{home})}).
+		Return(&client.DNSSettings{TsigKeys: []client.TsigKey{home}}, nil).Once()
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+}
+
+func TestTsigKeyResourceGeneratesSecret(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := &TsigKeyResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	// The generated secret is sent as the second cell of the key's row
+	m.On("GetDNSSettings", mock.Anything).Return(&client.DNSSettings{}, nil).Once()
+	m.On("SetDNSSettings", mock.Anything, mock.MatchedBy(func(settings map[string]string) bool {
+		cells := strings.Split(settings["tsigKeys"], "|")
+		if len(cells) != 3 || cells[0] != "xfr.example.com" || cells[2] != "hmac-sha256" {
+			return false
+		}
+		secret, err := base64.StdEncoding.DecodeString(cells[1])
+		return err == nil && len(secret) == 32
+	})).Return(&client.DNSSettings{TsigKeys: []client.TsigKey{
+		{KeyName: "xfr.example.com", SharedSecret: "E9crgbHbzgEI+e+/pBmARRif70ScKf2sc/FjrgnCWyc=", AlgorithmName: "hmac-sha256"},
+	}}, nil).Once()
+
+	created := createResource(t, r, resourcePlan(t, schemaResp, &TsigKeyResourceModel{
+		ID:           types.StringUnknown(),
+		Name:         types.StringValue("xfr.example.com"),
+		Algorithm:    types.StringValue("hmac-sha256"),
+		SharedSecret: types.StringUnknown(),
+	}))
+
+	var state TsigKeyResourceModel
+	require.False(t, created.Get(context.Background(), &state).HasError())
+	require.Equal(t, "E9crgbHbzgEI+e+/pBmARRif70ScKf2sc/FjrgnCWyc=", state.SharedSecret.ValueString())
+}
@@ -0,0 +1,398 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// updateSecurityPolicyAttrTypes describes the objects of the update_security_policies attribute
+var updateSecurityPolicyAttrTypes = map[string]attr.Type{
+	"tsig_key_name": types.StringType,
+	"domain":        types.StringType,
+	"allowed_types": types.SetType{ElemType: types.StringType},
+}
+
+// zoneAccessAttributes returns the zone attributes controlling who may query, transfer and
+// dynamically update the zone, and which servers are notified of its updates
+func zoneAccessAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"query_access": schema.StringAttribute{
+			MarkdownDescription: "Which clients may query the zone. Valid values are: " + enumDescription(zoneQueryAccessValues) +
+				". When not set, the server default is kept. Not supported for SecondaryCatalog zones.",
+			Optional: true,
+			Computed: true,
+			Validators: []validator.String{
+				enumValidator(zoneQueryAccessValues),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"query_access_network_acl": schema.ListAttribute{
+			MarkdownDescription: "The networks allowed to query the zone, processed in order, when `query_access` uses a network ACL. " +
+				"Each entry is an IP address or network address; prefix it with `!` to deny it. An empty list removes the entries.",
+			ElementType: types.StringType,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"zone_transfer": schema.StringAttribute{
+			MarkdownDescription: "Which servers may transfer the zone out. Valid values are: " + enumDescription(zoneTransferAccessValues) +
+				". When not set, the server default is kept. Valid only for Primary, Secondary, Forwarder, and Catalog zones.",
+			Optional: true,
+			Computed: true,
+			Validators: []validator.String{
+				enumValidator(zoneTransferAccessValues),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"zone_transfer_network_acl": schema.ListAttribute{
+			MarkdownDescription: "The networks allowed to transfer the zone out, processed in order, when `zone_transfer` uses a network ACL. " +
+				"Each entry is an IP address or network address; prefix it with `!` to deny it. An empty list removes the entries.",
+			ElementType: types.StringType,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"notify": schema.StringAttribute{
+			MarkdownDescription: "Which servers are notified of zone updates. Valid values are: " + enumDescription(zoneNotifyValues) +
+				". Forwarder and Catalog zones only support `None` and `SpecifiedNameServers`, and `SeparateNameServersForCatalogAndMemberZones` " +
+				"is only valid for Catalog zones. When not set, the server default is kept. Valid only for Primary, Secondary, Forwarder, and Catalog zones.",
+			Optional: true,
+			Computed: true,
+			Validators: []validator.String{
+				enumValidator(zoneNotifyValues),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"notify_name_servers": schema.SetAttribute{
+			MarkdownDescription: "The IP addresses of the servers notified of zone updates when `notify` includes specified name servers. " +
+				"An empty set removes the addresses.",
+			ElementType: types.StringType,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.Set{
+				setplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"update": schema.StringAttribute{
+			MarkdownDescription: "Which clients may update the zone dynamically (RFC 2136). Valid values are: " + enumDescription(zoneUpdateValues) +
+				". Secondary and Forwarder zones only support `Deny`, `Allow` and `UseSpecifiedNetworkACL`. When not set, the server default is kept. " +
+				"Valid only for Primary, Secondary, and Forwarder zones.",
+			Optional: true,
+			Computed: true,
+			Validators: []validator.String{
+				enumValidator(zoneUpdateValues),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"update_network_acl": schema.ListAttribute{
+			MarkdownDescription: "The networks allowed to update the zone dynamically, processed in order, when `update` uses a network ACL. " +
+				"Each entry is an IP address or network address; prefix it with `!` to deny it. An empty list removes the entries.",
+			ElementType: types.StringType,
+			Optional:    true,
+			Computed:    true,
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"update_security_policies": schema.ListNestedAttribute{
+			MarkdownDescription: "The TSIG keys allowed to update records dynamically, and the records each may update. When set, dynamic updates " +
+				"must be signed with one of the keys. An empty list removes the policies and stops requiring TSIG authentication. " +
+				"Valid only for Primary and Forwarder zones.",
+			Optional: true,
+			Computed: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"tsig_key_name": schema.StringAttribute{
+						MarkdownDescription: "The name of the TSIG key",
+						Required:            true,
+					},
+					"domain": schema.StringAttribute{
+						MarkdownDescription: "The domain name the key may update; use a wildcard name (e.g., `*.example.com`) for every subdomain",
+						Required:            true,
+					},
+					"allowed_types": schema.SetAttribute{
+						MarkdownDescription: "The record types the key may update, or `ANY` for every type",
+						ElementType:         types.StringType,
+						Required:            true,
+					},
+				},
+			},
+			PlanModifiers: []planmodifier.List{
+				listplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+}
+
+// Zone types supporting each group of access settings
+var (
+	queryAccessZoneTypes          = []string{"Primary", "Secondary", "Stub", "Forwarder", "SecondaryForwarder", "Catalog"}
+	zoneTransferZoneTypes         = []string{"Primary", "Secondary", "Forwarder", "Catalog"}
+	updateZoneTypes               = []string{"Primary", "Secondary", "Forwarder"}
+	updateSecurityPolicyZoneTypes = []string{"Primary", "Forwarder"}
+)
+
+// Policies using the network ACLs and the notify name servers
+var (
+	networkACLPolicies        = []string{"UseSpecifiedNetworkACL", "AllowZoneNameServersAndUseSpecifiedNetworkACL"}
+	notifyNameServersPolicies = []string{
+		string(client.ZoneNotifySpecifiedNameServers),
+		string(client.ZoneNotifyBothZoneAndSpecifiedNameServers),
+		string(client.ZoneNotifySeparateNameServersForCatalogAndMemberZones),
+	}
+)
+
+// The notify and dynamic update policies supported by each zone type; zone types not listed
+// support every policy
+var (
+	zoneNotifyPolicies = map[string][]string{
+		"Primary":   {"None", "ZoneNameServers", "SpecifiedNameServers", "BothZoneAndSpecifiedNameServers"},
+		"Secondary": {"None", "ZoneNameServers", "SpecifiedNameServers", "BothZoneAndSpecifiedNameServers"},
+		"Forwarder": {"None", "SpecifiedNameServers"},
+		"Catalog":   {"None", "SpecifiedNameServers", "SeparateNameServersForCatalogAndMemberZones"},
+	}
+	zoneUpdatePolicies = map[string][]string{
+		"Secondary": {"Deny", "Allow", "UseSpecifiedNetworkACL"},
+		"Forwarder": {"Deny", "Allow", "UseSpecifiedNetworkACL"},
+	}
+)
+
+// modifyZoneAccessPlan validates the query, zone transfer, notify and dynamic update settings
+// against the zone type and each other. Only configured settings are checked, as the server
+// reports settings for every zone.
+func (r *ZoneResource) modifyZoneAccessPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, data *ZoneResourceModel) {
+	var config ZoneResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || data.Type.IsUnknown() {
+		return
+	}
+	zoneType := data.Type.ValueString()
+
+	supported := true
+	for _, setting := range []struct {
+		name      string
+		value     attr.Value
+		zoneTypes []string
+	}{
+		{"query_access", config.QueryAccess, queryAccessZoneTypes},
+		{"query_access_network_acl", config.QueryAccessNetworkACL, queryAccessZoneTypes},
+		{"zone_transfer", config.ZoneTransfer, zoneTransferZoneTypes},
+		{"zone_transfer_network_acl", config.ZoneTransferNetworkACL, zoneTransferZoneTypes},
+		{"notify", config.Notify, zoneTransferZoneTypes},
+		{"notify_name_servers", config.NotifyNameServers, zoneTransferZoneTypes},
+		{"update", config.Update, updateZoneTypes},
+		{"update_network_acl", config.UpdateNetworkACL, updateZoneTypes},
+		{"update_security_policies", config.UpdateSecurityPolicies, updateSecurityPolicyZoneTypes},
+	} {
+		if !setting.value.IsNull() && !setting.value.IsUnknown() && !slices.Contains(setting.zoneTypes, zoneType) {
+			supported = false
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.name),
+				"Unsupported zone type",
+				fmt.Sprintf("%s is only supported for %s zones, not %s zones.", setting.name, zoneTypeList(setting.zoneTypes), zoneType),
+			)
+		}
+	}
+	if !supported {
+		return
+	}
+
+	for _, setting := range []struct {
+		name     string
+		value    types.String
+		policies map[string][]string
+	}{
+		{"notify", config.Notify, zoneNotifyPolicies},
+		{"update", config.Update, zoneUpdatePolicies},
+	} {
+		policies, restricted := setting.policies[zoneType]
+		if restricted && !setting.value.IsNull() && !setting.value.IsUnknown() && !slices.Contains(policies, setting.value.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.name),
+				fmt.Sprintf("Unsupported %s setting", setting.name),
+				fmt.Sprintf("%s = %q is not supported for %s zones.", setting.name, setting.value.ValueString(), zoneType),
+			)
+		}
+	}
+
+	// Entries only take effect with a policy using them, so reject entries that would be ignored
+	for _, setting := range []struct {
+		name       string
+		entries    int
+		policyName string
+		policy     types.String
+		policies   []string
+		summary    string
+	}{
+		{"query_access_network_acl", len(config.QueryAccessNetworkACL.Elements()), "query_access", config.QueryAccess, networkACLPolicies, "Conflicting query access settings"},
+		{"zone_transfer_network_acl", len(config.ZoneTransferNetworkACL.Elements()), "zone_transfer", config.ZoneTransfer, networkACLPolicies, "Conflicting zone transfer settings"},
+		{"notify_name_servers", len(config.NotifyNameServers.Elements()), "notify", config.Notify, notifyNameServersPolicies, "Conflicting notify settings"},
+		{"update_network_acl", len(config.UpdateNetworkACL.Elements()), "update", config.Update, networkACLPolicies, "Conflicting dynamic update settings"},
+	} {
+		if setting.entries > 0 && !setting.policy.IsNull() && !setting.policy.IsUnknown() && !slices.Contains(setting.policies, setting.policy.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.name),
+				setting.summary,
+				fmt.Sprintf("%s is only used when %s is one of %s.", setting.name, setting.policyName, enumDescription(setting.policies)),
+			)
+		}
+	}
+}
+
+// zoneTypeList renders zone types for error messages, e.g. "Primary, Secondary and Forwarder"
+func zoneTypeList(zoneTypes []string) string {
+	if len(zoneTypes) < 2 {
+		return strings.Join(zoneTypes, "")
+	}
+	return strings.Join(zoneTypes[:len(zoneTypes)-1], ", ") + " and " + zoneTypes[len(zoneTypes)-1]
+}
+
+// zoneAccessOptions returns the zone options for the query, zone transfer, notify and dynamic
+// update settings. Settings that are not known are left out, so the server keeps its current
+// values; empty lists clear them.
+func zoneAccessOptions(data *ZoneResourceModel) map[string]string {
+	options := make(map[string]string)
+
+	for name, value := range map[string]types.String{
+		"queryAccess":  data.QueryAccess,
+		"zoneTransfer": data.ZoneTransfer,
+		"notify":       data.Notify,
+		"update":       data.Update,
+	} {
+		if !value.IsNull() && !value.IsUnknown() {
+			options[name] = value.ValueString()
+		}
+	}
+
+	for name, value := range map[string]types.List{
+		"queryAccessNetworkACL":  data.QueryAccessNetworkACL,
+		"zoneTransferNetworkACL": data.ZoneTransferNetworkACL,
+		"updateNetworkACL":       data.UpdateNetworkACL,
+	} {
+		if !value.IsNull() && !value.IsUnknown() {
+			options[name] = listOption(stringElements(value.Elements()))
+		}
+	}
+
+	if !data.NotifyNameServers.IsNull() && !data.NotifyNameServers.IsUnknown() {
+		servers := stringElements(data.NotifyNameServers.Elements())
+		slices.Sort(servers)
+		options["notifyNameServers"] = listOption(servers)
+	}
+
+	if !data.UpdateSecurityPolicies.IsNull() && !data.UpdateSecurityPolicies.IsUnknown() {
+		options["updateSecurityPolicies"] = updateSecurityPoliciesOption(data.UpdateSecurityPolicies)
+	}
+
+	return options
+}
+
+// updateSecurityPoliciesOption renders the update security policies as the pipe separated table
+// the API expects, one key name, domain and comma separated record types per row
+func updateSecurityPoliciesOption(policies types.List) string {
+	var cells []string
+	for _, element := range policies.Elements() {
+		policy, ok := element.(types.Object)
+		if !ok || policy.IsNull() || policy.IsUnknown() {
+			continue
+		}
+
+		attributes := policy.Attributes()
+		keyName, _ := attributes["tsig_key_name"].(types.String)
+		domain, _ := attributes["domain"].(types.String)
+		allowedTypes, _ := attributes["allowed_types"].(types.Set)
+
+		recordTypes := stringElements(allowedTypes.Elements())
+		slices.Sort(recordTypes)
+		cells = append(cells, keyName.ValueString(), domain.ValueString(), strings.Join(recordTypes, ","))
+	}
+
+	if len(cells) == 0 {
+		// The API clears the policies when the option is set to false
+		return "false"
+	}
+	return strings.Join(cells, "|")
+}
+
+// updateSecurityPoliciesValue converts the update security policies returned by the API into a
+// list value
+func updateSecurityPoliciesValue(policies []client.ZoneUpdateSecurityPolicy) types.List {
+	elements := make([]attr.Value, 0, len(policies))
+	for _, policy := range policies {
+		elements = append(elements, types.ObjectValueMust(updateSecurityPolicyAttrTypes, map[string]attr.Value{
+			"tsig_key_name": types.StringValue(policy.TsigKeyName),
+			"domain":        types.StringValue(policy.Domain),
+			"allowed_types": stringSetValue(policy.AllowedTypes),
+		}))
+	}
+	return types.ListValueMust(types.ObjectType{AttrTypes: updateSecurityPolicyAttrTypes}, elements)
+}
+
+// readZoneAccess sets the query, zone transfer, notify and dynamic update settings from the zone
+// options
+func readZoneAccess(data *ZoneResourceModel, options *client.ZoneOptions) {
+	data.QueryAccess = optionalStringValue(options.QueryAccess)
+	data.QueryAccessNetworkACL = stringListValue(options.QueryAccessNetworkACL)
+	data.ZoneTransfer = optionalStringValue(options.ZoneTransfer)
+	data.ZoneTransferNetworkACL = stringListValue(options.ZoneTransferNetworkACL)
+	data.Notify = optionalStringValue(options.Notify)
+	data.NotifyNameServers = stringSetValue(options.NotifyNameServers)
+	data.Update = optionalStringValue(options.Update)
+	data.UpdateNetworkACL = stringListValue(options.UpdateNetworkACL)
+	data.UpdateSecurityPolicies = updateSecurityPoliciesValue(options.UpdateSecurityPolicies)
+}
+
+// optionalStringValue returns a null value for settings the API did not return
+func optionalStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// listOption renders a list as a comma separated zone option; the API clears the list when the
+// option is set to false
+func listOption(values []string) string {
+	if len(values) == 0 {
+		return "false"
+	}
+	return strings.Join(values, ",")
+}
+
+// stringElements returns the known string values of list or set elements
+func stringElements(elements []attr.Value) []string {
+	values := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			values = append(values, value.ValueString())
+		}
+	}
+	return values
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// zoneCatalog returns the configured catalog of a member zone, or an empty string when it is not
+// set or not known yet
+func zoneCatalog(data *ZoneResourceModel) string {
+	return strings.TrimSuffix(knownString(data.Catalog), ".")
+}
+
+// sameZoneName reports whether two zone names are the same, ignoring case and the trailing dot
+func sameZoneName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// findZone returns the zone of the given name from the zone list, or nil when there is none
+func findZone(ctx context.Context, c client.ClientAPI, name string) (*client.Zone, error) {
+	zones, err := listAllZones(ctx, c, defaultZonesPageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, zone := range zones {
+		if sameZoneName(zone.Name, name) {
+			return &zones[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// validateZoneCatalog checks the catalog of a member zone while planning. A missing catalog zone
+// is only a warning, since it may be created in the same apply; it is checked again then.
+func validateZoneCatalog(ctx context.Context, c client.ClientAPI, data *ZoneResourceModel, diags *diag.Diagnostics) {
+	catalog := zoneCatalog(data)
+	if c == nil || catalog == "" {
+		return
+	}
+
+	zone, err := findZone(ctx, c, catalog)
+	if err != nil {
+		tflog.Warn(ctx, "Could not list zones, skipping catalog zone check", map[string]interface{}{
+			"catalog": catalog,
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	if zone == nil {
+		diags.AddAttributeWarning(
+			path.Root("catalog"),
+			"Catalog zone not found",
+			fmt.Sprintf("The catalog zone %s does not exist on the DNS server. "+
+				"Unless it is created in the same apply, adding zone %s to it will fail.", catalog, data.Name.ValueString()),
+		)
+		return
+	}
+	if zone.Type != "Catalog" {
+		diags.AddAttributeError(
+			path.Root("catalog"),
+			"Invalid catalog zone",
+			fmt.Sprintf("Zone %s can only become a member of a Catalog zone, but %s is a %s zone.",
+				data.Name.ValueString(), zone.Name, zone.Type),
+		)
+	}
+}
+
+// requireZoneCatalog checks that the catalog of a member zone exists and is a Catalog zone
+// before it is written, when the catalog changed since the prior state
+func (r *ZoneResource) requireZoneCatalog(ctx context.Context, data *ZoneResourceModel, prior types.String) error {
+	catalog := zoneCatalog(data)
+	if catalog == "" || sameZoneName(catalog, knownString(prior)) {
+		return nil
+	}
+
+	zone, err := findZone(ctx, r.client, catalog)
+	if err != nil {
+		// The server reports its own error if the catalog turns out to be invalid
+		tflog.Warn(ctx, "Could not list zones, skipping catalog zone check", map[string]interface{}{
+			"catalog": catalog,
+			"error":   err.Error(),
+		})
+		return nil
+	}
+
+	if zone == nil {
+		return fmt.Errorf("catalog zone %s does not exist", catalog)
+	}
+	if zone.Type != "Catalog" {
+		return fmt.Errorf("catalog %s is a %s zone, not a Catalog zone", zone.Name, zone.Type)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// bootstrapZoneFileRecords returns the bootstrap records of a zone as zone file records, so they
+// compare equal to the records of the exported zone
+func bootstrapZoneFileRecords(data *ZoneResourceModel) ([]zoneFileRecord, error) {
+	zoneName := data.Name.ValueString()
+
+	var content strings.Builder
+	for _, record := range data.BootstrapRecords {
+		name := record.Name.ValueString()
+		if name == "@" || name == "" {
+			name = zoneName
+		}
+
+		value := record.Data.ValueString()
+		switch record.Type.ValueString() {
+		case "CNAME", "NS", "PTR":
+			value = strings.TrimSuffix(value, ".") + "."
+		case "MX":
+			value = fmt.Sprintf("%d %s.", record.Priority.ValueInt64(), strings.TrimSuffix(value, "."))
+		case "TXT":
+			value = zoneFileQuote(value)
+		}
+
+		fmt.Fprintf(&content, "%s. %d IN %s %s\n",
+			strings.TrimSuffix(formatRecordName(name, zoneName), "."), record.TTL.ValueInt64(), record.Type.ValueString(), value)
+	}
+	return parseZoneFile(zoneName, content.String())
+}
+
+// createdZoneRecords returns the records created with a zone from its bootstrap records and the
+// records cloned from another zone
+func createdZoneRecords(data *ZoneResourceModel, cloned []zoneFileRecord) (types.Set, error) {
+	bootstrap, err := bootstrapZoneFileRecords(data)
+	if err != nil {
+		return types.SetNull(types.StringType), fmt.Errorf("failed to read bootstrap records: %w", err)
+	}
+
+	zoneName := canonicalZoneName(data.Name.ValueString())
+	keys := make([]string, 0, len(cloned)+len(bootstrap))
+	for _, record := range append(cloned, bootstrap...) {
+		// The apex NS records are never counted as data records
+		if record.Type == "NS" && record.Name == zoneName {
+			continue
+		}
+		keys = append(keys, record.key())
+	}
+	return stringSetValue(keys), nil
+}
+
+// remainingUncreatedZoneRecords lists the data records of a zone other than the records created
+// with it. The zone is exported, so the records compare the same way as the created ones.
+func (r *ZoneResource) remainingUncreatedZoneRecords(ctx context.Context, data *ZoneResourceModel) ([]client.DNSRecord, error) {
+	zoneName := data.Name.ValueString()
+	exported, err := r.client.ExportZone(ctx, zoneName)
+	if err != nil {
+		return nil, err
+	}
+	records, err := parseZoneFile(zoneName, exported)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the exported zone file: %w", err)
+	}
+
+	created := make(map[string]bool, len(data.CreatedRecords.Elements()))
+	for _, element := range data.CreatedRecords.Elements() {
+		if value, ok := element.(types.String); ok {
+			created[value.ValueString()] = true
+		}
+	}
+
+	live := make([]client.DNSRecord, 0, len(records))
+	for _, record := range records {
+		if created[record.key()] || zoneFileServerTypes[record.Type] {
+			continue
+		}
+		live = append(live, client.DNSRecord{Name: record.Name, Type: record.Type})
+	}
+	return zoneDataRecords(zoneName, data.Type.ValueString(), live), nil
+}
@@ -3,7 +3,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -23,7 +22,7 @@ func NewZoneDataSource() datasource.DataSource {
 
 // ZoneDataSource defines the data source implementation.
 type ZoneDataSource struct {
-	client *client.Client
+	client client.ClientAPI
 }
 
 // ZoneDataSourceModel describes the data source data model.
@@ -40,6 +39,17 @@ type ZoneDataSourceModel struct {
 	ZoneTransferProtocol       types.String `tfsdk:"zone_transfer_protocol"`
 	TsigKeyName                types.String `tfsdk:"tsig_key_name"`
 	ValidateZone               types.Bool   `tfsdk:"validate_zone"`
+	ZoneTransferTsigKeyNames   types.Set    `tfsdk:"zone_transfer_tsig_key_names"`
+	ZoneTransferRequireTsig    types.Bool   `tfsdk:"zone_transfer_require_tsig"`
+	ZoneTransfer               types.String `tfsdk:"zone_transfer"`
+	ZoneTransferNetworkACL     types.List   `tfsdk:"zone_transfer_network_acl"`
+	Notify                     types.String `tfsdk:"notify"`
+	NotifyNameServers          types.Set    `tfsdk:"notify_name_servers"`
+	QueryAccess                types.String `tfsdk:"query_access"`
+	QueryAccessNetworkACL      types.List   `tfsdk:"query_access_network_acl"`
+	Update                     types.String `tfsdk:"update"`
+	UpdateNetworkACL           types.List   `tfsdk:"update_network_acl"`
+	UpdateSecurityPolicies     types.List   `tfsdk:"update_security_policies"`
 	InitializeForwarder        types.Bool   `tfsdk:"initialize_forwarder"`
 	Protocol                   types.String `tfsdk:"protocol"`
 	Forwarder                  types.String `tfsdk:"forwarder"`
@@ -69,13 +79,13 @@ func (d *ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 		Attributes: map[string]schema.Attribute{
 			// Required input
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The domain name for the zone to retrieve.",
+				MarkdownDescription: "The domain name for the zone to retrieve. The lookup ignores case and a trailing dot.",
 				Required:            true,
 			},
 
 			// Output attributes - using the same structure as ZoneResource for consistency
 			"id": schema.StringAttribute{
-				MarkdownDescription: "The unique identifier for the zone resource.",
+				MarkdownDescription: "The unique identifier for the zone, its name in lower case without a trailing dot.",
 				Computed:            true,
 			},
 			"type": schema.StringAttribute{
@@ -106,12 +116,78 @@ func (d *ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				MarkdownDescription: "Indicates if ZONEMD validation is enabled. Valid only for Secondary zones.",
 				Computed:            true,
 			},
+			"zone_transfer_tsig_key_names": schema.SetAttribute{
+				MarkdownDescription: "The TSIG key names authorized to transfer the zone out.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"zone_transfer_require_tsig": schema.BoolAttribute{
+				MarkdownDescription: "Indicates if zone transfers out of this zone must be TSIG authenticated.",
+				Computed:            true,
+			},
+			"zone_transfer": schema.StringAttribute{
+				MarkdownDescription: "Which servers may transfer the zone out. Valid values are: " + enumDescription(zoneTransferAccessValues) + ".",
+				Computed:            true,
+			},
+			"zone_transfer_network_acl": schema.ListAttribute{
+				MarkdownDescription: "The networks allowed to transfer the zone out when the zone transfer policy uses a network ACL.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"notify": schema.StringAttribute{
+				MarkdownDescription: "Which servers are notified of zone updates. Valid values are: " + enumDescription(zoneNotifyValues) + ".",
+				Computed:            true,
+			},
+			"notify_name_servers": schema.SetAttribute{
+				MarkdownDescription: "The IP addresses of the servers notified of zone updates when the notify policy includes specified name servers.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"query_access": schema.StringAttribute{
+				MarkdownDescription: "Which clients may query the zone. Valid values are: " + enumDescription(zoneQueryAccessValues) + ".",
+				Computed:            true,
+			},
+			"query_access_network_acl": schema.ListAttribute{
+				MarkdownDescription: "The networks allowed to query the zone when the query access policy uses a network ACL.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"update": schema.StringAttribute{
+				MarkdownDescription: "Which clients may update the zone dynamically (RFC 2136). Valid values are: " + enumDescription(zoneUpdateValues) + ".",
+				Computed:            true,
+			},
+			"update_network_acl": schema.ListAttribute{
+				MarkdownDescription: "The networks allowed to update the zone dynamically when the dynamic update policy uses a network ACL.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"update_security_policies": schema.ListNestedAttribute{
+				MarkdownDescription: "The TSIG keys allowed to update records dynamically, and the records each may update.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tsig_key_name": schema.StringAttribute{
+							MarkdownDescription: "The name of the TSIG key",
+							Computed:            true,
+						},
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name the key may update",
+							Computed:            true,
+						},
+						"allowed_types": schema.SetAttribute{
+							MarkdownDescription: "The record types the key may update",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
 			"initialize_forwarder": schema.BoolAttribute{
 				MarkdownDescription: "Indicates if the Conditional Forwarder zone is initialized with an FWD record. Valid for Forwarder zones.",
 				Computed:            true,
 			},
 			"protocol": schema.StringAttribute{
-				MarkdownDescription: "The DNS transport protocol used by the Conditional Forwarder zone. Valid values are: Udp, Tcp, Tls, Https, Quic.",
+				MarkdownDescription: "The DNS transport protocol used by the Conditional Forwarder zone. Valid values are: " + enumDescription(forwarderProtocolValues) + ".",
 				Computed:            true,
 			},
 			"forwarder": schema.StringAttribute{
@@ -123,7 +199,7 @@ func (d *ZoneDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 			},
 			"proxy_type": schema.StringAttribute{
-				MarkdownDescription: "The type of proxy for conditional forwarding. Valid values are: NoProxy, DefaultProxy, Http, Socks5.",
+				MarkdownDescription: "The type of proxy for conditional forwarding. Valid values are: " + enumDescription(proxyTypeValues) + ".",
 				Computed:            true,
 			},
 			"proxy_address": schema.StringAttribute{
@@ -197,13 +273,15 @@ func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	zoneName := data.Name.ValueString()
+	// Names from other systems often carry a trailing dot or different case, the server stores
+	// zone names lower case without the dot
+	zoneName := normalizeZoneName(data.Name.ValueString())
 	tflog.Debug(ctx, "Reading zone data source", map[string]interface{}{
 		"name": zoneName,
 	})
 
-	// Get zone info from the API
-	zoneInfo, err := d.client.GetZone(ctx, zoneName)
+	// Get zone info and options from the API
+	options, err := d.client.GetZoneOptions(ctx, zoneName)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading zone",
@@ -212,51 +290,19 @@ func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	// Set ID (same as name)
+	// Set ID (the normalized name)
 	data.ID = types.StringValue(zoneName)
-	data.Type = types.StringValue(zoneInfo.Type)
-	data.Internal = types.BoolValue(zoneInfo.Internal)
-	data.DnssecStatus = types.StringValue(zoneInfo.DnssecStatus)
-	data.Disabled = types.BoolValue(zoneInfo.Disabled)
-
-	// Get zone options directly from the API
-	params := url.Values{}
-	params.Set("zone", zoneName)
-	endpoint := "/api/zones/options/get?" + params.Encode()
-
-	type zoneOptionsResponse struct {
-		Name                           string   `json:"name"`
-		Type                           string   `json:"type"`
-		Internal                       bool     `json:"internal"`
-		DnssecStatus                   string   `json:"dnssecStatus"`
-		Disabled                       bool     `json:"disabled"`
-		Catalog                        string   `json:"catalog,omitempty"`
-		UseSoaSerialDateScheme         *bool    `json:"useSoaSerialDateScheme,omitempty"`
-		PrimaryNameServerAddresses     []string `json:"primaryNameServerAddresses,omitempty"`
-		PrimaryZoneTransferProtocol    string   `json:"primaryZoneTransferProtocol,omitempty"`
-		PrimaryZoneTransferTsigKeyName string   `json:"primaryZoneTransferTsigKeyName,omitempty"`
-		ValidateZone                   *bool    `json:"validateZone,omitempty"`
-	}
-
-	var options zoneOptionsResponse
-	if err := d.client.DoRequest(ctx, "GET", endpoint, nil, &options); err != nil {
-		resp.Diagnostics.AddError(
-			"Error reading zone options",
-			fmt.Sprintf("Could not read options for zone %s: %s", zoneName, err.Error()),
-		)
-		return
-	}
+	data.Type = types.StringValue(options.Type)
+	data.Internal = types.BoolValue(options.Internal)
+	data.DnssecStatus = types.StringValue(options.DnssecStatus)
+	data.Disabled = types.BoolValue(options.Disabled)
 
 	// Update model with zone options
 	if options.Catalog != "" {
 		data.Catalog = types.StringValue(options.Catalog)
 	}
 
-	if options.UseSoaSerialDateScheme != nil {
-		data.UseSoaSerialDateScheme = types.BoolValue(*options.UseSoaSerialDateScheme)
-	} else {
-		data.UseSoaSerialDateScheme = types.BoolValue(false)
-	}
+	data.UseSoaSerialDateScheme = optionalBoolField(options.UseSoaSerialDateScheme, types.BoolNull())
 
 	if len(options.PrimaryNameServerAddresses) > 0 {
 		data.PrimaryNameServerAddresses = types.StringValue(strings.Join(options.PrimaryNameServerAddresses, ","))
@@ -272,11 +318,20 @@ func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		data.TsigKeyName = types.StringValue(options.PrimaryZoneTransferTsigKeyName)
 	}
 
-	if options.ValidateZone != nil {
-		data.ValidateZone = types.BoolValue(*options.ValidateZone)
-	} else {
-		data.ValidateZone = types.BoolValue(false)
-	}
+	data.ValidateZone = optionalBoolField(options.ValidateZone, types.BoolNull())
+
+	data.ZoneTransferTsigKeyNames = stringSetValue(options.ZoneTransferTsigKeyNames)
+	data.ZoneTransferRequireTsig = types.BoolValue(len(options.ZoneTransferTsigKeyNames) > 0)
+
+	data.QueryAccess = optionalStringValue(options.QueryAccess)
+	data.QueryAccessNetworkACL = stringListValue(options.QueryAccessNetworkACL)
+	data.ZoneTransfer = optionalStringValue(options.ZoneTransfer)
+	data.ZoneTransferNetworkACL = stringListValue(options.ZoneTransferNetworkACL)
+	data.Notify = optionalStringValue(options.Notify)
+	data.NotifyNameServers = stringSetValue(options.NotifyNameServers)
+	data.Update = optionalStringValue(options.Update)
+	data.UpdateNetworkACL = stringListValue(options.UpdateNetworkACL)
+	data.UpdateSecurityPolicies = updateSecurityPoliciesValue(options.UpdateSecurityPolicies)
 
 	// Set default values for computed fields
 	if data.InitializeForwarder.IsNull() || data.InitializeForwarder.IsUnknown() {
@@ -288,63 +343,22 @@ func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	// Default protocol and proxy type
-	data.Protocol = types.StringValue("Udp")
-	data.ProxyType = types.StringValue("DefaultProxy")
+	data.Protocol = types.StringValue(string(client.DefaultForwarderProtocol))
+	data.ProxyType = types.StringValue(string(client.DefaultProxyType))
 
 	// Get zone records to extract SOA serial
-	// Use the client's DoRequest method directly since the API has specific formats for each record type
-	recordsParams := url.Values{}
-	recordsParams.Set("domain", zoneName)
-	recordsParams.Set("zone", zoneName)
-	recordsParams.Set("listZone", "true")
-	recordsEndpoint := "/api/zones/records/get?" + recordsParams.Encode()
-
-	// Define a simple structure for SOA record responses
-	type soaRData struct {
-		Serial uint32 `json:"serial"`
-	}
-
-	type recordRData struct {
-		SoaRecord *soaRData `json:"soaRecord,omitempty"`
-	}
-
-	type zoneRecord struct {
-		Type  string      `json:"type"`
-		RData recordRData `json:"rData"`
-	}
-
-	type zoneRecordsResponse struct {
-		Records []zoneRecord `json:"records"`
-	}
-
-	var recordsResponse zoneRecordsResponse
-	if err := d.client.DoRequest(ctx, "GET", recordsEndpoint, nil, &recordsResponse); err != nil {
-		// Don't fail if records can't be read, just log it
-		tflog.Warn(ctx, "Failed to read zone records for SOA serial", map[string]interface{}{
-			"zone":  zoneName,
-			"error": err.Error(),
-		})
-	} else {
-		// Find SOA record to get serial
-		soaFound := false
-		for _, record := range recordsResponse.Records {
-			if record.Type == "SOA" && record.RData.SoaRecord != nil {
-				data.SoaSerial = types.Int64Value(int64(record.RData.SoaRecord.Serial))
-				soaFound = true
-				break
-			}
-		}
-		if !soaFound {
-			// Default SOA serial if not found
-			data.SoaSerial = types.Int64Value(1)
-		}
-	}
-
-	// Ensure SoaSerial is set even if records couldn't be read
-	if data.SoaSerial.IsNull() || data.SoaSerial.IsUnknown() {
-		data.SoaSerial = types.Int64Value(1)
-	}
+	data.SoaSerial = types.Int64Value(readZoneSOASerial(ctx, d.client, zoneName))
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// normalizeZoneName converts a zone name to the form stored by the server: lower case, without
+// surrounding spaces and without the trailing dot. The root zone keeps its single dot.
+func normalizeZoneName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "." {
+		return name
+	}
+	return strings.TrimSuffix(name, ".")
+}
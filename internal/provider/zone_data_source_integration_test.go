@@ -19,7 +19,7 @@ func TestAccZoneDataSource(t *testing.T) {
 
 	// Setup test container
 	config := setupTestContainer(t)
-	zoneName := "testdatasource.example.com"
+	zoneName := sweepZonePrefix + "datasource.example.com"
 
 	// Create a zone first
 	ctx := context.Background()
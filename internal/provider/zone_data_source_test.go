@@ -1,12 +1,18 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
 )
 
 // Mock client for testing
@@ -33,3 +39,120 @@ func TestZoneDataSource(t *testing.T) {
 	// This test would normally use mocking but we'll skip it for now
 	t.Skip("Skipping unit test that requires mocking")
 }
+
+func TestUnitZoneDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	// Names are looked up case-insensitively and without the trailing dot
+	for _, name := range []string{"example.com", "Example.COM."} {
+		t.Run(name, func(t *testing.T) {
+			m := mocks.NewClientAPI(t)
+			d := &ZoneDataSource{client: m}
+
+			var schemaResp datasource.SchemaResponse
+			d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+			validateZone := true
+			m.On("GetZoneOptions", mock.Anything, "example.com").Return(&client.ZoneOptions{
+				Name:                        "example.com",
+				Type:                        "Secondary",
+				DnssecStatus:                "Unsigned",
+				PrimaryNameServerAddresses:  []string{"192.168.10.5", "192.168.10.6"},
+				PrimaryZoneTransferProtocol: "Tls",
+				ValidateZone:                &validateZone,
+				ZoneTransferTsigKeyNames:    []string{"key.example.com"},
+				ZoneTransfer:                "UseSpecifiedNetworkACL",
+				ZoneTransferNetworkACL:      []string{"192.168.10.0/24", "!192.168.10.1"},
+				Notify:                      "SpecifiedNameServers",
+				NotifyNameServers:           []string{"192.168.10.5"},
+				QueryAccess:                 "AllowOnlyPrivateNetworks",
+				Update:                      "Allow",
+			}, nil)
+			m.On("GetRecords", mock.Anything, "example.com", "example.com", false).Return(&client.GetRecordsResponse{
+				Records: []client.DNSRecord{
+					{Name: "example.com", Type: "NS", RData: client.DNSRecordData{NameServer: "ns1.example.com"}},
+					{Name: "example.com", Type: "SOA", RData: client.DNSRecordData{Serial: 2024010101}},
+				},
+			}, nil)
+
+			// Build the configuration from a state holding the inputs
+			input := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, input.Set(context.Background(), &ZoneDataSourceModel{
+				Name:                       types.StringValue(name),
+				ID:                         types.StringNull(),
+				Type:                       types.StringNull(),
+				Catalog:                    types.StringNull(),
+				UseSoaSerialDateScheme:     types.BoolNull(),
+				PrimaryNameServerAddresses: types.StringNull(),
+				ZoneTransferProtocol:       types.StringNull(),
+				TsigKeyName:                types.StringNull(),
+				ValidateZone:               types.BoolNull(),
+				ZoneTransferTsigKeyNames:   types.SetNull(types.StringType),
+				ZoneTransferRequireTsig:    types.BoolNull(),
+				ZoneTransfer:               types.StringNull(),
+				ZoneTransferNetworkACL:     types.ListNull(types.StringType),
+				Notify:                     types.StringNull(),
+				NotifyNameServers:          types.SetNull(types.StringType),
+				QueryAccess:                types.StringNull(),
+				QueryAccessNetworkACL:      types.ListNull(types.StringType),
+				Update:                     types.StringNull(),
+				UpdateNetworkACL:           types.ListNull(types.StringType),
+				UpdateSecurityPolicies:     types.ListNull(types.ObjectType{AttrTypes: updateSecurityPolicyAttrTypes}),
+				InitializeForwarder:        types.BoolNull(),
+				Protocol:                   types.StringNull(),
+				Forwarder:                  types.StringNull(),
+				DnssecValidation:           types.BoolNull(),
+				ProxyType:                  types.StringNull(),
+				ProxyAddress:               types.StringNull(),
+				ProxyPort:                  types.Int64Null(),
+				ProxyUsername:              types.StringNull(),
+				ProxyPassword:              types.StringNull(),
+				Internal:                   types.BoolNull(),
+				DnssecStatus:               types.StringNull(),
+				Disabled:                   types.BoolNull(),
+				SoaSerial:                  types.Int64Null(),
+			}).HasError())
+
+			resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+			var state ZoneDataSourceModel
+			require.False(t, resp.State.Get(context.Background(), &state).HasError())
+			require.Equal(t, "Secondary", state.Type.ValueString())
+			require.Equal(t, "192.168.10.5,192.168.10.6", state.PrimaryNameServerAddresses.ValueString())
+			require.Equal(t, "Tls", state.ZoneTransferProtocol.ValueString())
+			require.True(t, state.ValidateZone.ValueBool())
+			require.Equal(t, int64(2024010101), state.SoaSerial.ValueInt64())
+			require.True(t, state.ZoneTransferRequireTsig.ValueBool())
+			require.Equal(t, stringSetValue([]string{"key.example.com"}), state.ZoneTransferTsigKeyNames)
+			require.Equal(t, "UseSpecifiedNetworkACL", state.ZoneTransfer.ValueString())
+			require.Equal(t, stringListValue([]string{"192.168.10.0/24", "!192.168.10.1"}), state.ZoneTransferNetworkACL)
+			require.Equal(t, "SpecifiedNameServers", state.Notify.ValueString())
+			require.Equal(t, stringSetValue([]string{"192.168.10.5"}), state.NotifyNameServers)
+			require.Equal(t, "AllowOnlyPrivateNetworks", state.QueryAccess.ValueString())
+			require.Equal(t, "Allow", state.Update.ValueString())
+			require.Empty(t, state.UpdateSecurityPolicies.Elements())
+			require.Equal(t, "example.com", state.ID.ValueString())
+			require.Equal(t, name, state.Name.ValueString())
+		})
+	}
+}
+
+func TestNormalizeZoneName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"example.com":       "example.com",
+		"Example.COM.":      "example.com",
+		" sub.Example.com ": "sub.example.com",
+		".":                 ".",
+	}
+
+	for input, expected := range tests {
+		if got := normalizeZoneName(input); got != expected {
+			t.Errorf("normalizeZoneName(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,571 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneDelegationResource{}
+var _ resource.ResourceWithImportState = &ZoneDelegationResource{}
+var _ resource.ResourceWithValidateConfig = &ZoneDelegationResource{}
+
+// glueType is the type of the glue attribute, the glue addresses by name server
+var glueType = types.SetType{ElemType: types.StringType}
+
+func NewZoneDelegationResource() resource.Resource {
+	return &ZoneDelegationResource{}
+}
+
+// ZoneDelegationResource defines the resource implementation.
+type ZoneDelegationResource struct {
+	client client.ClientAPI
+}
+
+// ZoneDelegationResourceModel describes the resource data model.
+type ZoneDelegationResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Zone        types.String `tfsdk:"zone"`
+	Name        types.String `tfsdk:"name"`
+	TTL         types.Int64  `tfsdk:"ttl"`
+	NameServers types.Set    `tfsdk:"name_servers"`
+	Glue        types.Map    `tfsdk:"glue"`
+}
+
+func (r *ZoneDelegationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_delegation"
+}
+
+func (r *ZoneDelegationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Delegates a subdomain of a zone to other name servers, managing its NS records and the glue A/AAAA records of name servers " +
+			"inside the delegated subdomain as a unit. The NS records are authoritative: name servers of the subdomain not in `name_servers` are removed. " +
+			"Do not manage the same NS or glue records with `technitium_dns_record` or `technitium_dns_record_set` as well.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Delegation identifier (zone:name)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The parent zone of the delegated subdomain",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The delegated subdomain, relative to the zone",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "Time-to-live value in seconds, shared by the NS and glue records",
+				Required:            true,
+				Validators: []validator.Int64{
+					ttlValidator(),
+				},
+			},
+			"name_servers": schema.SetAttribute{
+				MarkdownDescription: "The host names of the name servers the subdomain is delegated to",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"glue": schema.MapAttribute{
+				MarkdownDescription: "The glue addresses of name servers, by name server host name, e.g. `{ \"ns1.sub.example.com\" = [\"192.0.2.1\", \"2001:db8::1\"] }`. " +
+					"Glue is needed for name servers inside the delegated subdomain, which resolvers cannot look up otherwise. " +
+					"Every host must be one of `name_servers` and inside the zone. Not imported; add it to the configuration after importing.",
+				ElementType: glueType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.ValueSetsAre(setvalidator.SizeAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+func (r *ZoneDelegationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig reports glue of unknown name servers and invalid glue addresses at plan time
+func (r *ZoneDelegationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ZoneDelegationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Glue.IsNull() || data.Glue.IsUnknown() ||
+		data.NameServers.IsUnknown() || data.Zone.IsUnknown() {
+		return
+	}
+
+	glue, diags := delegationGlue(ctx, data.Glue)
+	resp.Diagnostics.Append(diags...)
+	var nameServers []string
+	resp.Diagnostics.Append(data.NameServers.ElementsAs(ctx, &nameServers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listed := make(map[string]bool, len(nameServers))
+	for _, nameServer := range nameServers {
+		listed[canonicalZoneName(nameServer)] = true
+	}
+	zoneName := canonicalZoneName(data.Zone.ValueString())
+
+	for host, addresses := range glue {
+		switch name := canonicalZoneName(host); {
+		case !listed[name]:
+			resp.Diagnostics.AddAttributeError(path.Root("glue").AtMapKey(host), "Invalid Glue",
+				fmt.Sprintf("%s is not one of the name servers of the delegation", host))
+		case !strings.HasSuffix(name, "."+zoneName):
+			resp.Diagnostics.AddAttributeError(path.Root("glue").AtMapKey(host), "Invalid Glue",
+				fmt.Sprintf("glue records of %s cannot be created outside of zone %s", host, zoneName))
+		}
+		for _, address := range addresses {
+			if _, err := netip.ParseAddr(address); err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("glue").AtMapKey(host), "Invalid Glue",
+					fmt.Sprintf("%q is not an IP address", address))
+			}
+		}
+	}
+}
+
+func (r *ZoneDelegationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneDelegationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameServers, glue, diags := delegationValues(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Glue is added first, so the subdomain never resolves to name servers without addresses
+	err := r.addGlue(ctx, &data, glue, true)
+	if err == nil {
+		err = r.addNameServers(ctx, &data, nameServers, true)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating zone delegation",
+			fmt.Sprintf("Could not delegate %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(delegationID(data.Zone.ValueString(), data.Name.ValueString()))
+	reportOperation(ctx, r.client, "records", client.OperationCreated)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneDelegationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneDelegationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := data.Zone.ValueString()
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
+
+	recordsResp, err := r.client.GetRecords(ctx, zoneName, recordName, false)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error reading zone delegation",
+			fmt.Sprintf("Could not read the delegation of %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	records := recordSetRecords(recordsResp.Records, "NS")
+	if len(records) == 0 {
+		// The delegation was removed outside of Terraform
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Name servers equal to a configured one but for case or the trailing dot keep the configured form
+	var configured []string
+	resp.Diagnostics.Append(data.NameServers.ElementsAs(ctx, &configured, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	forms := make(map[string]string, len(configured))
+	for _, nameServer := range configured {
+		forms[canonicalZoneName(nameServer)] = nameServer
+	}
+
+	nameServers := make([]string, 0, len(records))
+	for _, record := range records {
+		nameServer := record.RData.NameServer
+		if form, ok := forms[canonicalZoneName(nameServer)]; ok {
+			nameServer = form
+		}
+		nameServers = append(nameServers, nameServer)
+	}
+	data.NameServers = stringSetValue(nameServers)
+	data.TTL = types.Int64Value(int64(records[0].TTL))
+
+	// Only the glue of the configured hosts is read, hosts without addresses left show up as drift
+	if !data.Glue.IsNull() {
+		glue, diags := delegationGlue(ctx, data.Glue)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		live := make(map[string][]string, len(glue))
+		for host := range glue {
+			addresses, err := r.glueAddresses(ctx, zoneName, host)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error reading zone delegation",
+					fmt.Sprintf("Could not read the glue of %s: %s", host, err.Error()),
+				)
+				return
+			}
+			if len(addresses) > 0 {
+				live[host] = addresses
+			}
+		}
+
+		glueValue, diags := types.MapValueFrom(ctx, glueType, live)
+		resp.Diagnostics.Append(diags...)
+		data.Glue = glueValue
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneDelegationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ZoneDelegationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planned, plannedGlue, diags := delegationValues(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	current, currentGlue, diags := delegationValues(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every record carries the TTL, so a new TTL writes all records again
+	rewrite := !data.TTL.Equal(state.TTL)
+
+	var err error
+	if rewrite {
+		err = r.addGlue(ctx, &data, plannedGlue, true)
+	} else {
+		err = r.addGlue(ctx, &data, glueChanges(currentGlue, plannedGlue), false)
+	}
+	if err == nil {
+		if rewrite {
+			err = r.addNameServers(ctx, &data, planned, true)
+		} else {
+			added, removed := diffDomains(current, planned)
+			if err = r.addNameServers(ctx, &data, added, false); err == nil {
+				err = r.deleteNameServers(ctx, &data, removed)
+			}
+		}
+	}
+	if err == nil {
+		// Glue is deleted last, so name servers never lose their addresses while still listed
+		err = r.deleteGlue(ctx, &data, glueChanges(plannedGlue, currentGlue))
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating zone delegation",
+			fmt.Sprintf("Could not update the delegation of %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+	reportOperation(ctx, r.client, "records", client.OperationUpdated)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneDelegationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneDelegationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameServers, glue, diags := delegationValues(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.deleteNameServers(ctx, &data, nameServers)
+	if err == nil {
+		err = r.deleteGlue(ctx, &data, glue)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting zone delegation",
+			fmt.Sprintf("Could not delete the delegation of %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+	reportOperation(ctx, r.client, "records", client.OperationDeleted)
+}
+
+func (r *ZoneDelegationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: zone:name
+	idParts := strings.Split(req.ID, ":")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			"Import ID must be in the format zone:name",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), idParts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), idParts[1])...)
+}
+
+// addNameServers adds an NS record per name server. With overwrite set, the first record replaces
+// all existing NS records of the subdomain.
+func (r *ZoneDelegationResource) addNameServers(ctx context.Context, data *ZoneDelegationResourceModel, nameServers []string, overwrite bool) error {
+	zoneName := data.Zone.ValueString()
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
+	ttl, err := ttlToInt(data.TTL)
+	if err != nil {
+		return err
+	}
+
+	for i, nameServer := range nameServers {
+		tflog.Debug(ctx, "Adding delegation name server", map[string]interface{}{
+			"zone":        zoneName,
+			"name":        recordName,
+			"name_server": nameServer,
+		})
+
+		record := client.AddRecordRequest{
+			Zone:      zoneName,
+			Domain:    recordName,
+			TTL:       int64(ttl),
+			Data:      client.NSRecordData{NameServer: nameServer},
+			Overwrite: overwrite && i == 0,
+		}
+		if _, err := r.client.AddRecord(ctx, record); err != nil {
+			return fmt.Errorf("could not add name server %s: %w", nameServer, err)
+		}
+	}
+	return nil
+}
+
+// deleteNameServers deletes the NS record of each name server
+func (r *ZoneDelegationResource) deleteNameServers(ctx context.Context, data *ZoneDelegationResourceModel, nameServers []string) error {
+	zoneName := data.Zone.ValueString()
+	recordName := formatRecordName(data.Name.ValueString(), zoneName)
+
+	for _, nameServer := range nameServers {
+		tflog.Debug(ctx, "Deleting delegation name server", map[string]interface{}{
+			"zone":        zoneName,
+			"name":        recordName,
+			"name_server": nameServer,
+		})
+
+		record := client.DeleteRecordRequest{Zone: zoneName, Domain: recordName, Data: client.NSRecordData{NameServer: nameServer}}
+		if err := r.client.DeleteRecord(ctx, record); err != nil {
+			return fmt.Errorf("could not delete name server %s: %w", nameServer, err)
+		}
+	}
+	return nil
+}
+
+// addGlue adds an A or AAAA record per glue address. With overwrite set, the first address of
+// each host and record type replaces the existing records of the host.
+func (r *ZoneDelegationResource) addGlue(ctx context.Context, data *ZoneDelegationResourceModel, glue map[string][]string, overwrite bool) error {
+	zoneName := data.Zone.ValueString()
+	ttl, err := ttlToInt(data.TTL)
+	if err != nil {
+		return err
+	}
+
+	for _, host := range sortedGlueHosts(glue) {
+		written := make(map[string]bool)
+		for _, address := range glue[host] {
+			recordData := glueRecordData(address)
+
+			tflog.Debug(ctx, "Adding delegation glue", map[string]interface{}{
+				"zone":    zoneName,
+				"name":    host,
+				"address": address,
+			})
+
+			record := client.AddRecordRequest{
+				Zone:      zoneName,
+				Domain:    strings.TrimSuffix(host, "."),
+				TTL:       int64(ttl),
+				Data:      recordData,
+				Overwrite: overwrite && !written[recordData.RecordType()],
+			}
+			if _, err := r.client.AddRecord(ctx, record); err != nil {
+				return fmt.Errorf("could not add glue %s of %s: %w", address, host, err)
+			}
+			written[recordData.RecordType()] = true
+		}
+	}
+	return nil
+}
+
+// deleteGlue deletes the A or AAAA record of each glue address
+func (r *ZoneDelegationResource) deleteGlue(ctx context.Context, data *ZoneDelegationResourceModel, glue map[string][]string) error {
+	zoneName := data.Zone.ValueString()
+
+	for _, host := range sortedGlueHosts(glue) {
+		for _, address := range glue[host] {
+			tflog.Debug(ctx, "Deleting delegation glue", map[string]interface{}{
+				"zone":    zoneName,
+				"name":    host,
+				"address": address,
+			})
+
+			record := client.DeleteRecordRequest{Zone: zoneName, Domain: strings.TrimSuffix(host, "."), Data: glueRecordData(address)}
+			if err := r.client.DeleteRecord(ctx, record); err != nil {
+				return fmt.Errorf("could not delete glue %s of %s: %w", address, host, err)
+			}
+		}
+	}
+	return nil
+}
+
+// glueAddresses returns the A and AAAA addresses of a name server host
+func (r *ZoneDelegationResource) glueAddresses(ctx context.Context, zoneName, host string) ([]string, error) {
+	recordsResp, err := r.client.GetRecords(ctx, zoneName, strings.TrimSuffix(host, "."), false)
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var addresses []string
+	for _, record := range recordsResp.Records {
+		if record.Type == "A" || record.Type == "AAAA" {
+			addresses = append(addresses, record.RData.IPAddress)
+		}
+	}
+	return addresses, nil
+}
+
+// delegationValues returns the sorted name servers and the glue of a delegation
+func delegationValues(ctx context.Context, data *ZoneDelegationResourceModel) ([]string, map[string][]string, diag.Diagnostics) {
+	var nameServers []string
+	diags := data.NameServers.ElementsAs(ctx, &nameServers, false)
+	sort.Strings(nameServers)
+
+	glue, glueDiags := delegationGlue(ctx, data.Glue)
+	diags.Append(glueDiags...)
+	return nameServers, glue, diags
+}
+
+// delegationGlue returns the glue addresses by host, sorted
+func delegationGlue(ctx context.Context, value types.Map) (map[string][]string, diag.Diagnostics) {
+	glue := make(map[string][]string)
+	if value.IsNull() || value.IsUnknown() {
+		return glue, nil
+	}
+
+	diags := value.ElementsAs(ctx, &glue, false)
+	for _, addresses := range glue {
+		sort.Strings(addresses)
+	}
+	return glue, diags
+}
+
+// glueChanges returns the glue addresses of to missing from from
+func glueChanges(from, to map[string][]string) map[string][]string {
+	changes := make(map[string][]string)
+	for host, addresses := range to {
+		if added, _ := diffDomains(from[host], addresses); len(added) > 0 {
+			changes[host] = added
+		}
+	}
+	return changes
+}
+
+// sortedGlueHosts returns the hosts of the glue, sorted
+func sortedGlueHosts(glue map[string][]string) []string {
+	hosts := make([]string, 0, len(glue))
+	for host := range glue {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// glueRecordData returns the A or AAAA record of a glue address
+func glueRecordData(address string) client.RecordData {
+	if ip, err := netip.ParseAddr(address); err == nil && ip.Is6() && !ip.Is4In6() {
+		return client.AAAARecordData{IPAddress: address}
+	}
+	return client.ARecordData{IPAddress: address}
+}
+
+// delegationID returns the resource ID of a delegation
+func delegationID(zoneName, name string) string {
+	return fmt.Sprintf("%s:%s", zoneName, name)
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+// delegationModel returns a delegation of sub.example.com with the given name servers and glue
+func delegationModel(t *testing.T, ttl int64, nameServers []string, glue map[string][]string) *ZoneDelegationResourceModel {
+	t.Helper()
+
+	glueValue := types.MapNull(glueType)
+	if glue != nil {
+		value, diags := types.MapValueFrom(context.Background(), glueType, glue)
+		require.False(t, diags.HasError(), "glue diagnostics: %v", diags)
+		glueValue = value
+	}
+
+	return &ZoneDelegationResourceModel{
+		ID:          types.StringUnknown(),
+		Zone:        types.StringValue("example.com"),
+		Name:        types.StringValue("sub"),
+		TTL:         types.Int64Value(ttl),
+		NameServers: stringSetValue(nameServers),
+		Glue:        glueValue,
+	}
+}
+
+func TestZoneDelegationResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewZoneDelegationResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_zone_delegation" {
+			t.Errorf("Expected TypeName to be technitium_zone_delegation, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewZoneDelegationResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "zone", "name", "ttl", "name_servers", "glue"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+	})
+
+	t.Run("ValidateConfig", func(t *testing.T) {
+		r := &ZoneDelegationResource{}
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+		for name, tt := range map[string]struct {
+			glue        map[string][]string
+			expectError bool
+		}{
+			"valid":              {glue: map[string][]string{"ns1.sub.example.com": {"192.0.2.1", "2001:db8::1"}}},
+			"unknown host":       {glue: map[string][]string{"ns3.sub.example.com": {"192.0.2.1"}}, expectError: true},
+			"outside of zone":    {glue: map[string][]string{"ns.example.net": {"192.0.2.1"}}, expectError: true},
+			"invalid address":    {glue: map[string][]string{"ns1.sub.example.com": {"ns1"}}, expectError: true},
+			"without glue":       {},
+			"trailing dot valid": {glue: map[string][]string{"ns1.sub.example.com.": {"192.0.2.1"}}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				model := delegationModel(t, 3600, []string{"ns1.sub.example.com", "ns.example.net"}, tt.glue)
+				config := tfsdk.Config{Schema: schemaResp.Schema}
+				plan := tfsdk.Plan{Schema: schemaResp.Schema}
+				require.False(t, plan.Set(context.Background(), model).HasError())
+				config.Raw = plan.Raw
+
+				var resp resource.ValidateConfigResponse
+				r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: config}, &resp)
+				require.Equal(t, tt.expectError, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+			})
+		}
+	})
+}
+
+func TestZoneDelegationResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := mocks.NewClientAPI(t)
+	r := &ZoneDelegationResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	added := func(domain string, data client.RecordData, overwrite bool) {
+		m.On("AddRecord", mock.Anything, client.AddRecordRequest{
+			Zone: "example.com", Domain: domain, TTL: 3600, Data: data, Overwrite: overwrite,
+		}).Return(&client.AddRecordResponse{}, nil).Once()
+	}
+	deleted := func(domain string, data client.RecordData) {
+		m.On("DeleteRecord", mock.Anything, client.DeleteRecordRequest{Zone: "example.com", Domain: domain, Data: data}).Return(nil).Once()
+	}
+
+	// The glue is added before the NS records, each replacing existing records on first write
+	added("ns1.sub.example.com", client.ARecordData{IPAddress: "192.0.2.1"}, true)
+	added("ns1.sub.example.com", client.AAAARecordData{IPAddress: "2001:db8::1"}, true)
+	added("sub.example.com", client.NSRecordData{NameServer: "ns.example.net"}, true)
+	added("sub.example.com", client.NSRecordData{NameServer: "ns1.sub.example.com"}, false)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, delegationModel(t, 3600,
+		[]string{"ns1.sub.example.com", "ns.example.net"},
+		map[string][]string{"ns1.sub.example.com": {"2001:db8::1", "192.0.2.1"}},
+	)).HasError())
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+	var state ZoneDelegationResourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Equal(t, "example.com:sub", state.ID.ValueString())
+
+	// Name servers and glue changed outside of Terraform show up as drift
+	m.On("GetRecords", mock.Anything, "example.com", "sub.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "sub.example.com", Type: "NS", TTL: 3600, RData: client.DNSRecordData{NameServer: "ns1.sub.example.com"}},
+		}}, nil).Once()
+	m.On("GetRecords", mock.Anything, "example.com", "ns1.sub.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "ns1.sub.example.com", Type: "A", TTL: 3600, RData: client.DNSRecordData{IPAddress: "192.0.2.1"}},
+		}}, nil).Once()
+
+	readResp := resource.ReadResponse{State: resp.State}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.False(t, readResp.State.Get(ctx, &state).HasError())
+	require.True(t, state.NameServers.Equal(stringSetValue([]string{"ns1.sub.example.com"})))
+	glue, diags := delegationGlue(ctx, state.Glue)
+	require.False(t, diags.HasError())
+	require.Equal(t, map[string][]string{"ns1.sub.example.com": {"192.0.2.1"}}, glue)
+
+	// Only the changed records are written: the new glue first, the dropped glue last
+	added("ns2.sub.example.com", client.ARecordData{IPAddress: "192.0.2.2"}, false)
+	added("sub.example.com", client.NSRecordData{NameServer: "ns2.sub.example.com"}, false)
+	deleted("sub.example.com", client.NSRecordData{NameServer: "ns.example.net"})
+	deleted("ns1.sub.example.com", client.AAAARecordData{IPAddress: "2001:db8::1"})
+
+	updatePlan := tfsdk.Plan{Schema: schemaResp.Schema}
+	updated := delegationModel(t, 3600,
+		[]string{"ns1.sub.example.com", "ns2.sub.example.com"},
+		map[string][]string{"ns1.sub.example.com": {"192.0.2.1"}, "ns2.sub.example.com": {"192.0.2.2"}},
+	)
+	updated.ID = types.StringValue("example.com:sub")
+	require.False(t, updatePlan.Set(ctx, updated).HasError())
+
+	updateResp := resource.UpdateResponse{State: resp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: updatePlan, State: resp.State}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "update diagnostics: %v", updateResp.Diagnostics)
+
+	// Deleting removes the NS records, then the glue
+	deleted("sub.example.com", client.NSRecordData{NameServer: "ns1.sub.example.com"})
+	deleted("sub.example.com", client.NSRecordData{NameServer: "ns2.sub.example.com"})
+	deleted("ns1.sub.example.com", client.ARecordData{IPAddress: "192.0.2.1"})
+	deleted("ns2.sub.example.com", client.ARecordData{IPAddress: "192.0.2.2"})
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+
+	// A delegation without NS records left is removed from the state
+	m.On("GetRecords", mock.Anything, "example.com", "sub.example.com", false).
+		Return(&client.GetRecordsResponse{}, nil).Once()
+
+	goneResp := resource.ReadResponse{State: updateResp.State}
+	r.Read(ctx, resource.ReadRequest{State: updateResp.State}, &goneResp)
+	require.False(t, goneResp.Diagnostics.HasError(), "read diagnostics: %v", goneResp.Diagnostics)
+	require.True(t, goneResp.State.Raw.IsNull())
+}
+
+func TestZoneDelegationResourceReadTrailingDot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := mocks.NewClientAPI(t)
+	r := &ZoneDelegationResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	// The server returns name servers without the trailing dot
+	m.On("GetRecords", mock.Anything, "example.com", "sub.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "sub.example.com", Type: "NS", TTL: 3600, RData: client.DNSRecordData{NameServer: "ns.example.net"}},
+			{Name: "sub.example.com", Type: "NS", TTL: 3600, RData: client.DNSRecordData{NameServer: "ns2.example.net"}},
+		}}, nil).Once()
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	model := delegationModel(t, 3600, []string{"NS.example.net."}, nil)
+	model.ID = types.StringValue("example.com:sub")
+	require.False(t, state.Set(ctx, model).HasError())
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var data ZoneDelegationResourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+	require.True(t, data.NameServers.Equal(stringSetValue([]string{"NS.example.net.", "ns2.example.net"})), "name servers: %v", data.NameServers)
+}
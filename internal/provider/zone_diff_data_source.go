@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ZoneDiffDataSource{}
+
+func NewZoneDiffDataSource() datasource.DataSource {
+	return &ZoneDiffDataSource{}
+}
+
+// ZoneDiffDataSource defines the data source implementation.
+type ZoneDiffDataSource struct {
+	client client.ClientAPI
+}
+
+// ZoneDiffDataSourceModel describes the data source data model.
+type ZoneDiffDataSourceModel struct {
+	// Required inputs
+	Zone           types.String          `tfsdk:"zone"`
+	DesiredRecords []ZoneDiffRecordModel `tfsdk:"desired_records"`
+
+	// Optional inputs
+	RecordTypes []types.String `tfsdk:"record_types"`
+
+	// Computed outputs
+	ID        types.String          `tfsdk:"id"`
+	Additions []ZoneDiffRecordModel `tfsdk:"additions"`
+	Removals  []ZoneDiffRecordModel `tfsdk:"removals"`
+	Changes   []ZoneDiffChangeModel `tfsdk:"changes"`
+	InSync    types.Bool            `tfsdk:"in_sync"`
+}
+
+// ZoneDiffRecordModel represents a record on either side of the comparison
+type ZoneDiffRecordModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+	TTL  types.Int64  `tfsdk:"ttl"`
+	Data types.String `tfsdk:"data"`
+}
+
+// ZoneDiffChangeModel represents a record present on both sides with different attributes
+type ZoneDiffChangeModel struct {
+	Name       types.String `tfsdk:"name"`
+	Type       types.String `tfsdk:"type"`
+	Data       types.String `tfsdk:"data"`
+	LiveTTL    types.Int64  `tfsdk:"live_ttl"`
+	DesiredTTL types.Int64  `tfsdk:"desired_ttl"`
+}
+
+func (d *ZoneDiffDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_diff"
+}
+
+func (d *ZoneDiffDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	recordAttributes := func(ttlDescription string, computed bool) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The record name. Relative names (e.g., 'www' or '@') are qualified with the zone name.",
+				Required:            !computed,
+				Computed:            computed,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The DNS record type (A, AAAA, CNAME, MX, TXT, etc.).",
+				Required:            !computed,
+				Computed:            computed,
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: ttlDescription,
+				Optional:            !computed,
+				Computed:            computed,
+			},
+			"data": schema.StringAttribute{
+				MarkdownDescription: "The record data, formatted the same way as the `technitium_dns_records` data source (e.g., '10 mail.example.com' for MX).",
+				Required:            !computed,
+				Computed:            computed,
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description:         "Data source comparing the live contents of a Technitium DNS zone against a desired record set",
+		MarkdownDescription: "Data source comparing the live contents of a Technitium DNS zone against a desired record set. Reports additions, removals and changes without applying anything, enabling guardrail checks and drift reports in pipelines.",
+
+		Attributes: map[string]schema.Attribute{
+			// Required inputs
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone name to compare (e.g., 'example.com').",
+				Required:            true,
+			},
+			"desired_records": schema.ListNestedAttribute{
+				MarkdownDescription: "The desired record set. The `records` output of the `technitium_dns_records` data source can be used as a snapshot.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: recordAttributes("Desired time-to-live in seconds. When not set, TTL differences are ignored.", false),
+				},
+			},
+
+			// Optional inputs
+			"record_types": schema.ListAttribute{
+				MarkdownDescription: "Only compare these record types (e.g., ['A', 'AAAA', 'CNAME']). When not set, all record types except SOA are compared.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+
+			// Computed outputs
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"additions": schema.ListNestedAttribute{
+				MarkdownDescription: "Desired records that do not exist in the live zone.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: recordAttributes("Time-to-live value for the record in seconds.", true),
+				},
+			},
+			"removals": schema.ListNestedAttribute{
+				MarkdownDescription: "Live records that are not part of the desired record set.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: recordAttributes("Time-to-live value for the record in seconds.", true),
+				},
+			},
+			"changes": schema.ListNestedAttribute{
+				MarkdownDescription: "Records present on both sides whose TTL differs.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The DNS record name.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The DNS record type.",
+							Computed:            true,
+						},
+						"data": schema.StringAttribute{
+							MarkdownDescription: "The record data.",
+							Computed:            true,
+						},
+						"live_ttl": schema.Int64Attribute{
+							MarkdownDescription: "The TTL of the live record.",
+							Computed:            true,
+						},
+						"desired_ttl": schema.Int64Attribute{
+							MarkdownDescription: "The desired TTL.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"in_sync": schema.BoolAttribute{
+				MarkdownDescription: "True when there are no additions, removals or changes.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ZoneDiffDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneDiffDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneDiffDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := data.Zone.ValueString()
+
+	tflog.Debug(ctx, "Reading zone diff data source", map[string]interface{}{
+		"zone":            zoneName,
+		"desired_records": len(data.DesiredRecords),
+	})
+
+	recordsResponse, err := d.client.GetRecords(ctx, zoneName, zoneName, true)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DNS records",
+			fmt.Sprintf("Could not read DNS records for zone %s: %s", zoneName, err.Error()),
+		)
+		return
+	}
+
+	recordTypes := make(map[string]bool)
+	for _, recordType := range data.RecordTypes {
+		recordTypes[recordType.ValueString()] = true
+	}
+
+	data.Additions, data.Removals, data.Changes = diffZoneRecords(zoneName, recordsResponse.Records, data.DesiredRecords, recordTypes)
+	data.InSync = types.BoolValue(len(data.Additions) == 0 && len(data.Removals) == 0 && len(data.Changes) == 0)
+	data.ID = types.StringValue(zoneName)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// diffZoneRecords compares live records with the desired record set. Records are matched on
+// name, type and data; matched records whose desired TTL differs are reported as changes.
+func diffZoneRecords(zoneName string, live []client.DNSRecord, desired []ZoneDiffRecordModel, recordTypes map[string]bool) ([]ZoneDiffRecordModel, []ZoneDiffRecordModel, []ZoneDiffChangeModel) {
+	included := func(recordType string) bool {
+		if len(recordTypes) > 0 {
+			return recordTypes[recordType]
+		}
+		return recordType != "SOA"
+	}
+
+	liveByKey := make(map[string]client.DNSRecord)
+	for _, record := range live {
+		if !included(record.Type) {
+			continue
+		}
+		liveByKey[zoneDiffKey(zoneName, record.Name, record.Type, formatRecordData(record))] = record
+	}
+
+	additions := make([]ZoneDiffRecordModel, 0)
+	changes := make([]ZoneDiffChangeModel, 0)
+	matched := make(map[string]bool)
+
+	for _, record := range desired {
+		if !included(record.Type.ValueString()) {
+			continue
+		}
+
+		key := zoneDiffKey(zoneName, record.Name.ValueString(), record.Type.ValueString(), record.Data.ValueString())
+		liveRecord, ok := liveByKey[key]
+		if !ok {
+			additions = append(additions, record)
+			continue
+		}
+		matched[key] = true
+
+		if !record.TTL.IsNull() && !record.TTL.IsUnknown() && record.TTL.ValueInt64() != int64(liveRecord.TTL) {
+			changes = append(changes, ZoneDiffChangeModel{
+				Name:       types.StringValue(liveRecord.Name),
+				Type:       types.StringValue(liveRecord.Type),
+				Data:       types.StringValue(formatRecordData(liveRecord)),
+				LiveTTL:    types.Int64Value(int64(liveRecord.TTL)),
+				DesiredTTL: record.TTL,
+			})
+		}
+	}
+
+	// Sort the keys so removals are reported in a stable order
+	keys := make([]string, 0, len(liveByKey))
+	for key := range liveByKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	removals := make([]ZoneDiffRecordModel, 0)
+	for _, key := range keys {
+		if matched[key] {
+			continue
+		}
+		record := liveByKey[key]
+		removals = append(removals, ZoneDiffRecordModel{
+			Name: types.StringValue(record.Name),
+			Type: types.StringValue(record.Type),
+			TTL:  types.Int64Value(int64(record.TTL)),
+			Data: types.StringValue(formatRecordData(record)),
+		})
+	}
+
+	return additions, removals, changes
+}
+
+// zoneDiffKey builds a case-insensitive comparison key for a record
+func zoneDiffKey(zoneName, name, recordType, data string) string {
+	if name == "" || name == "@" {
+		name = zoneName
+	}
+	name = strings.TrimSuffix(strings.ToLower(formatRecordName(name, zoneName)), ".")
+
+	return name + "|" + strings.ToUpper(recordType) + "|" + strings.Trim(data, "\"")
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+func TestZoneDiffDataSource(t *testing.T) {
+	t.Parallel()
+
+	// Unit test - verify data source creation
+	t.Run("NewZoneDiffDataSource", func(t *testing.T) {
+		ds := NewZoneDiffDataSource()
+		if ds == nil {
+			t.Fatal("NewZoneDiffDataSource should return a non-nil data source")
+		}
+
+		// Test metadata
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{
+			ProviderTypeName: "technitium",
+		}, &resp)
+
+		if resp.TypeName != "technitium_zone_diff" {
+			t.Errorf("Expected TypeName to be technitium_zone_diff, got %s", resp.TypeName)
+		}
+	})
+
+	// Unit test - verify schema
+	t.Run("Schema", func(t *testing.T) {
+		ds := NewZoneDiffDataSource()
+		var resp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		schema := resp.Schema
+		for _, name := range []string{"zone", "desired_records"} {
+			if attr, ok := schema.Attributes[name]; !ok || !attr.IsRequired() {
+				t.Errorf("Schema should have required '%s' attribute", name)
+			}
+		}
+		for _, name := range []string{"additions", "removals", "changes", "in_sync"} {
+			if attr, ok := schema.Attributes[name]; !ok || !attr.IsComputed() {
+				t.Errorf("Schema should have computed '%s' attribute", name)
+			}
+		}
+	})
+}
+
+func TestDiffZoneRecords(t *testing.T) {
+	t.Parallel()
+
+	live := []client.DNSRecord{
+		{Name: "example.com", Type: "SOA", TTL: 900, RData: client.DNSRecordData{PrimaryNameServer: "ns1.example.com"}},
+		{Name: "example.com", Type: "A", TTL: 3600, RData: client.DNSRecordData{IPAddress: "192.168.1.1"}},
+		{Name: "www.example.com", Type: "A", TTL: 300, RData: client.DNSRecordData{IPAddress: "192.168.1.2"}},
+		{Name: "example.com", Type: "MX", TTL: 3600, RData: client.DNSRecordData{Preference: 10, Exchange: "mail.example.com"}},
+		{Name: "old.example.com", Type: "CNAME", TTL: 300, RData: client.DNSRecordData{CNAME: "www.example.com"}},
+	}
+
+	desired := []ZoneDiffRecordModel{
+		{Name: types.StringValue("@"), Type: types.StringValue("A"), TTL: types.Int64Value(3600), Data: types.StringValue("192.168.1.1")},
+		{Name: types.StringValue("WWW"), Type: types.StringValue("A"), TTL: types.Int64Value(600), Data: types.StringValue("192.168.1.2")},
+		{Name: types.StringValue("example.com."), Type: types.StringValue("MX"), TTL: types.Int64Null(), Data: types.StringValue("10 mail.example.com")},
+		{Name: types.StringValue("api"), Type: types.StringValue("A"), TTL: types.Int64Value(300), Data: types.StringValue("192.168.1.3")},
+	}
+
+	t.Run("All types", func(t *testing.T) {
+		additions, removals, changes := diffZoneRecords("example.com", live, desired, map[string]bool{})
+
+		if len(additions) != 1 || additions[0].Name.ValueString() != "api" {
+			t.Errorf("Expected api to be added, got %v", additions)
+		}
+		if len(removals) != 1 || removals[0].Name.ValueString() != "old.example.com" {
+			t.Errorf("Expected old.example.com to be removed, got %v", removals)
+		}
+		if len(changes) != 1 || changes[0].Name.ValueString() != "www.example.com" {
+			t.Fatalf("Expected www.example.com TTL change, got %v", changes)
+		}
+		if changes[0].LiveTTL.ValueInt64() != 300 || changes[0].DesiredTTL.ValueInt64() != 600 {
+			t.Errorf("Unexpected TTL change: %v", changes[0])
+		}
+	})
+
+	t.Run("Filtered types", func(t *testing.T) {
+		additions, removals, changes := diffZoneRecords("example.com", live, desired, map[string]bool{"MX": true})
+
+		if len(additions) != 0 || len(removals) != 0 || len(changes) != 0 {
+			t.Errorf("Expected MX records to be in sync, got additions=%v removals=%v changes=%v", additions, removals, changes)
+		}
+	})
+}
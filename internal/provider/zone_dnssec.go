@@ -0,0 +1,415 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// dnssecUnsigned is the DNSSEC status of zones that are not signed
+const dnssecUnsigned = "Unsigned"
+
+// Key defaults used when signing with RSA, matching the web console
+const (
+	defaultDNSSECHashAlgorithm = client.DNSSECHashAlgorithmSHA256
+	defaultDNSSECKSKKeySize    = 2048
+	defaultDNSSECZSKKeySize    = 1024
+)
+
+// dnssecCurves holds the curves of each algorithm that signs with elliptic curves; the first is
+// the default
+var dnssecCurves = map[client.DNSSECAlgorithm][]client.DNSSECCurve{
+	client.DNSSECAlgorithmECDSA: {client.DNSSECCurveP256, client.DNSSECCurveP384},
+	client.DNSSECAlgorithmEDDSA: {client.DNSSECCurveED25519, client.DNSSECCurveED448},
+}
+
+// zoneDNSSECAttributes returns the zone attributes controlling DNSSEC signing
+func zoneDNSSECAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"dnssec_signed": schema.BoolAttribute{
+			MarkdownDescription: "Set to true to sign the zone with DNSSEC, or false to unsign it. When not set, signing is left as it is on the server. " +
+				"Only supported for Primary zones. Signing generates new keys, so publish the DS records of the zone at the parent once it is signed. " +
+				"This attribute is experimental: enable the `dnssec` feature in the provider's `experimental_features` to use it.",
+			Optional: true,
+		},
+		"dnssec_algorithm": schema.StringAttribute{
+			MarkdownDescription: "The algorithm used to sign the zone. Valid values are: " + enumDescription(dnssecAlgorithmValues) +
+				". Defaults to `" + string(client.DefaultDNSSECAlgorithm) + "`. Changing it re-signs the zone with new keys, which needs `dnssec_allow_resign`",
+			Optional: true,
+			Validators: []validator.String{
+				enumValidator(dnssecAlgorithmValues),
+			},
+		},
+		"dnssec_hash_algorithm": schema.StringAttribute{
+			MarkdownDescription: "The hash algorithm of RSA keys. Valid values are: " + enumDescription(dnssecHashAlgorithmValues) +
+				". Defaults to `" + string(defaultDNSSECHashAlgorithm) + "` with the `RSA` algorithm. Changing it re-signs the zone with new keys, which needs `dnssec_allow_resign`",
+			Optional: true,
+			Validators: []validator.String{
+				enumValidator(dnssecHashAlgorithmValues),
+			},
+		},
+		"dnssec_ksk_key_size": schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("The size in bits of the RSA Key Signing Key. Defaults to `%d` with the `RSA` algorithm. "+
+				"Changing it re-signs the zone with new keys, which needs `dnssec_allow_resign`", defaultDNSSECKSKKeySize),
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.Between(1024, 4096),
+			},
+		},
+		"dnssec_zsk_key_size": schema.Int64Attribute{
+			MarkdownDescription: fmt.Sprintf("The size in bits of the RSA Zone Signing Key. Defaults to `%d` with the `RSA` algorithm. "+
+				"Changing it re-signs the zone with new keys, which needs `dnssec_allow_resign`", defaultDNSSECZSKKeySize),
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.Between(1024, 4096),
+			},
+		},
+		"dnssec_curve": schema.StringAttribute{
+			MarkdownDescription: "The curve of the keys: `P256` or `P384` with the `ECDSA` algorithm (default `P256`), `ED25519` or `ED448` " +
+				"with the `EDDSA` algorithm (default `ED25519`). Changing it re-signs the zone with new keys, which needs `dnssec_allow_resign`",
+			Optional: true,
+			Validators: []validator.String{
+				enumValidator(dnssecCurveValues),
+			},
+		},
+		"dnssec_allow_resign": schema.BoolAttribute{
+			MarkdownDescription: "Set to true to allow changing the algorithm, hash algorithm, curve or key sizes of a signed zone. " +
+				"The zone is then unsigned and signed again with new keys, so the DS records at the parent stop matching and " +
+				"the zone fails validation until they are replaced. Defaults to `false`, which rejects such changes at plan time",
+			Optional: true,
+		},
+		"dnssec_nx_proof": schema.StringAttribute{
+			MarkdownDescription: "The proof of non-existence of the signed zone. Valid values are: " + enumDescription(dnssecNxProofValues) +
+				". Defaults to `" + string(client.DefaultDNSSECNxProof) + "`. Changing it converts the signed zone in place",
+			Optional: true,
+			Validators: []validator.String{
+				enumValidator(dnssecNxProofValues),
+			},
+		},
+		"dnssec_nsec3_iterations": schema.Int64Attribute{
+			MarkdownDescription: "The number of additional NSEC3 hashing iterations. Only used with `NSEC3`. Defaults to `0`",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(0, 50),
+			},
+		},
+		"dnssec_nsec3_salt_length": schema.Int64Attribute{
+			MarkdownDescription: "The length in bytes of the NSEC3 salt. Only used with `NSEC3`. Defaults to `0`",
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(0, 32),
+			},
+		},
+		"dnssec_dnskey_ttl": schema.Int64Attribute{
+			MarkdownDescription: "The TTL of the DNSKEY records. Defaults to `86400`. The server only allows changing it while every key is ready or active",
+			Optional:            true,
+			Validators: []validator.Int64{
+				ttlValidator(),
+			},
+		},
+		"dnssec_zsk_rollover_days": schema.Int64Attribute{
+			MarkdownDescription: "How often in days the server automatically rolls over the Zone Signing Keys, from 0 (disabled) to 365. " +
+				"Defaults to `30`. Only applied when the zone is signed",
+			Optional: true,
+			Validators: []validator.Int64{
+				int64validator.Between(0, 365),
+			},
+		},
+	}
+}
+
+// validateZoneDNSSECPlan checks the DNSSEC attributes of a planned zone
+func validateZoneDNSSECPlan(ctx context.Context, c client.ClientAPI, data *ZoneResourceModel, diags *diag.Diagnostics) {
+	if data.DnssecSigned.IsNull() {
+		if dnssecSettingsConfigured(data) {
+			diags.AddAttributeError(
+				path.Root("dnssec_signed"),
+				"Missing dnssec_signed",
+				"The dnssec_* signing attributes are only used when dnssec_signed = true.",
+			)
+		}
+		return
+	}
+
+	requireExperimentalFeature(ctx, c, client.ExperimentalDNSSEC, "DNSSEC signing of technitium_zone", diags)
+
+	if !data.Type.IsUnknown() && data.Type.ValueString() != "Primary" {
+		diags.AddAttributeError(
+			path.Root("dnssec_signed"),
+			"Unsupported zone type",
+			fmt.Sprintf("DNSSEC signing is only supported for Primary zones, not %s zones.", data.Type.ValueString()),
+		)
+		return
+	}
+
+	if !data.DnssecSigned.ValueBool() {
+		if dnssecSettingsConfigured(data) {
+			diags.AddAttributeError(
+				path.Root("dnssec_signed"),
+				"Conflicting DNSSEC settings",
+				"The dnssec_* signing attributes cannot be combined with dnssec_signed = false.",
+			)
+		}
+		return
+	}
+
+	algorithm := client.DNSSECAlgorithm(data.DnssecAlgorithm.ValueString())
+	if data.DnssecAlgorithm.IsNull() {
+		algorithm = client.DefaultDNSSECAlgorithm
+	}
+	if data.DnssecAlgorithm.IsUnknown() {
+		return
+	}
+
+	if algorithm != client.DNSSECAlgorithmRSA {
+		rejectDNSSECSettings(diags, "is only used with the RSA algorithm", map[string]attrValue{
+			"dnssec_hash_algorithm": data.DnssecHashAlgorithm,
+			"dnssec_ksk_key_size":   data.DnssecKSKKeySize,
+			"dnssec_zsk_key_size":   data.DnssecZSKKeySize,
+		})
+	}
+
+	if !data.DnssecCurve.IsNull() && !data.DnssecCurve.IsUnknown() {
+		curves, ok := dnssecCurves[algorithm]
+		if !ok || !containsCurve(curves, client.DNSSECCurve(data.DnssecCurve.ValueString())) {
+			diags.AddAttributeError(
+				path.Root("dnssec_curve"),
+				"Invalid DNSSEC curve",
+				fmt.Sprintf("Curve %s cannot be used with the %s algorithm.", data.DnssecCurve.ValueString(), algorithm),
+			)
+		}
+	}
+
+	if data.DnssecNxProof.ValueString() != string(client.DNSSECNxProofNSEC3) && !data.DnssecNxProof.IsUnknown() {
+		rejectDNSSECSettings(diags, `is only used with dnssec_nx_proof = "NSEC3"`, map[string]attrValue{
+			"dnssec_nsec3_iterations":  data.DnssecNSEC3Iterations,
+			"dnssec_nsec3_salt_length": data.DnssecNSEC3SaltLength,
+		})
+	}
+}
+
+// modifyZoneDNSSECPlan validates the DNSSEC settings and marks the DNSSEC status unknown when
+// the signing of an existing zone changes. Changing the keys of a signed zone breaks its chain of
+// trust, so it is rejected unless dnssec_allow_resign is set, and warned about when it is.
+func (r *ZoneResource) modifyZoneDNSSECPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, data *ZoneResourceModel) {
+	validateZoneDNSSECPlan(ctx, r.client, data, &resp.Diagnostics)
+	if req.State.Raw.IsNull() || data.DnssecSigned.IsNull() {
+		return
+	}
+
+	var prior ZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	signed := prior.DnssecStatus.ValueString() != dnssecUnsigned
+	if signed && data.DnssecSigned.ValueBool() && dnssecKeysChanged(dnssecSignOptions(data), dnssecSignOptions(&prior)) {
+		if !data.DnssecAllowResign.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dnssec_allow_resign"),
+				"DNSSEC re-signing not allowed",
+				fmt.Sprintf("Changing the keys of zone %s unsigns it and signs it again with new keys, so the DS records at the parent "+
+					"stop matching and the zone fails validation until they are replaced. Set dnssec_allow_resign = true to allow it.",
+					data.Name.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("dnssec_allow_resign"),
+			"Zone will be re-signed",
+			fmt.Sprintf("Zone %s will be unsigned and signed again with new keys. Replace the DS records at the parent "+
+				"with the new ones once it is signed, or the zone fails validation.", data.Name.ValueString()),
+		)
+	}
+
+	if data.DnssecSigned.ValueBool() != signed ||
+		data.DnssecSigned.ValueBool() && !sameDNSSECSigning(dnssecSignOptions(data), dnssecSignOptions(&prior)) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("dnssec_status"), types.StringUnknown())...)
+	}
+}
+
+// attrValue is the part of attribute values needed to check whether they are set
+type attrValue interface {
+	IsNull() bool
+}
+
+// rejectDNSSECSettings reports an error for each of the attributes that is set, in name order
+func rejectDNSSECSettings(diags *diag.Diagnostics, reason string, values map[string]attrValue) {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !values[name].IsNull() {
+			diags.AddAttributeError(path.Root(name), "Unsupported DNSSEC setting", fmt.Sprintf("%s %s.", name, reason))
+		}
+	}
+}
+
+// dnssecSettingsConfigured reports whether any signing parameter is set
+func dnssecSettingsConfigured(data *ZoneResourceModel) bool {
+	for _, value := range []attrValue{
+		data.DnssecAlgorithm, data.DnssecHashAlgorithm, data.DnssecKSKKeySize, data.DnssecZSKKeySize, data.DnssecCurve,
+		data.DnssecNxProof, data.DnssecNSEC3Iterations, data.DnssecNSEC3SaltLength, data.DnssecDNSKeyTTL, data.DnssecZSKRolloverDays,
+	} {
+		if !value.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+func containsCurve(curves []client.DNSSECCurve, curve client.DNSSECCurve) bool {
+	for _, c := range curves {
+		if c == curve {
+			return true
+		}
+	}
+	return false
+}
+
+// dnssecSignOptions returns the sign API parameters of the planned zone, filling in the defaults
+// of the chosen algorithm
+func dnssecSignOptions(data *ZoneResourceModel) client.DNSSECSignOptions {
+	options := client.DNSSECSignOptions{
+		Algorithm:       client.DNSSECAlgorithm(data.DnssecAlgorithm.ValueString()),
+		Curve:           client.DNSSECCurve(data.DnssecCurve.ValueString()),
+		DNSKeyTTL:       data.DnssecDNSKeyTTL.ValueInt64(),
+		ZSKRolloverDays: data.DnssecZSKRolloverDays.ValueInt64Pointer(),
+		NxProof:         client.DNSSECNxProof(data.DnssecNxProof.ValueString()),
+		Iterations:      data.DnssecNSEC3Iterations.ValueInt64(),
+		SaltLength:      data.DnssecNSEC3SaltLength.ValueInt64(),
+	}
+	if options.Algorithm == "" {
+		options.Algorithm = client.DefaultDNSSECAlgorithm
+	}
+	if options.NxProof == "" {
+		options.NxProof = client.DefaultDNSSECNxProof
+	}
+
+	if options.Algorithm == client.DNSSECAlgorithmRSA {
+		options.HashAlgorithm = client.DNSSECHashAlgorithm(data.DnssecHashAlgorithm.ValueString())
+		if options.HashAlgorithm == "" {
+			options.HashAlgorithm = defaultDNSSECHashAlgorithm
+		}
+		options.KSKKeySize = data.DnssecKSKKeySize.ValueInt64()
+		if options.KSKKeySize == 0 {
+			options.KSKKeySize = defaultDNSSECKSKKeySize
+		}
+		options.ZSKKeySize = data.DnssecZSKKeySize.ValueInt64()
+		if options.ZSKKeySize == 0 {
+			options.ZSKKeySize = defaultDNSSECZSKKeySize
+		}
+	} else if options.Curve == "" {
+		options.Curve = dnssecCurves[options.Algorithm][0]
+	}
+
+	return options
+}
+
+// dnssecKeysChanged reports whether the sign options need new keys, rather than an in-place change
+func dnssecKeysChanged(a, b client.DNSSECSignOptions) bool {
+	return a.Algorithm != b.Algorithm || a.HashAlgorithm != b.HashAlgorithm || a.Curve != b.Curve ||
+		a.KSKKeySize != b.KSKKeySize || a.ZSKKeySize != b.ZSKKeySize
+}
+
+// sameDNSSECSigning reports whether two sets of sign options produce the same signed zone. The ZSK
+// rollover interval only applies to new keys, so it is ignored.
+func sameDNSSECSigning(a, b client.DNSSECSignOptions) bool {
+	a.ZSKRolloverDays, b.ZSKRolloverDays = nil, nil
+	return a == b
+}
+
+// signZone signs a newly created zone when dnssec_signed is true
+func (r *ZoneResource) signZone(ctx context.Context, data *ZoneResourceModel) error {
+	if !data.DnssecSigned.ValueBool() {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Signing zone", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+	return r.client.SignZone(ctx, data.Name.ValueString(), dnssecSignOptions(data))
+}
+
+// updateZoneSigning brings the zone's DNSSEC signing in line with the plan. Changes to the keys
+// re-sign the zone when dnssec_allow_resign is set, while the proof of non-existence and DNSKEY
+// TTL are updated in place.
+func (r *ZoneResource) updateZoneSigning(ctx context.Context, plan, prior *ZoneResourceModel) error {
+	if plan.DnssecSigned.IsNull() {
+		return nil
+	}
+
+	zoneName := plan.Name.ValueString()
+	signed := prior.DnssecStatus.ValueString() != "" && prior.DnssecStatus.ValueString() != dnssecUnsigned
+
+	if !plan.DnssecSigned.ValueBool() {
+		if !signed {
+			return nil
+		}
+		tflog.Debug(ctx, "Unsigning zone", map[string]interface{}{"name": zoneName})
+		return r.client.UnsignZone(ctx, zoneName)
+	}
+
+	if !signed {
+		return r.signZone(ctx, plan)
+	}
+
+	planned, current := dnssecSignOptions(plan), dnssecSignOptions(prior)
+	if dnssecKeysChanged(planned, current) {
+		if !plan.DnssecAllowResign.ValueBool() {
+			return fmt.Errorf("changing the DNSSEC keys of zone %s requires dnssec_allow_resign = true", zoneName)
+		}
+		tflog.Info(ctx, "Re-signing zone with new keys", map[string]interface{}{
+			"name":      zoneName,
+			"algorithm": string(planned.Algorithm),
+		})
+		if err := r.client.UnsignZone(ctx, zoneName); err != nil {
+			return err
+		}
+		return r.client.SignZone(ctx, zoneName, planned)
+	}
+
+	if planned.NxProof != current.NxProof {
+		convert := r.client.ConvertZoneToNSEC
+		if planned.NxProof == client.DNSSECNxProofNSEC3 {
+			convert = r.client.ConvertZoneToNSEC3
+		}
+		if err := convert(ctx, zoneName); err != nil {
+			return err
+		}
+	}
+
+	// Converting to NSEC3 uses the default parameters, so apply the configured ones afterwards
+	if planned.NxProof == client.DNSSECNxProofNSEC3 {
+		converted := current.NxProof != client.DNSSECNxProofNSEC3
+		if converted && (planned.Iterations != 0 || planned.SaltLength != 0) ||
+			!converted && (planned.Iterations != current.Iterations || planned.SaltLength != current.SaltLength) {
+			if err := r.client.UpdateNSEC3Params(ctx, zoneName, planned.Iterations, planned.SaltLength); err != nil {
+				return err
+			}
+		}
+	}
+
+	if planned.DNSKeyTTL != current.DNSKeyTTL && planned.DNSKeyTTL > 0 {
+		if err := r.client.UpdateDNSKeyTTL(ctx, zoneName, planned.DNSKeyTTL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
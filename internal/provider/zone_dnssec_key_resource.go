@@ -0,0 +1,663 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// dnssecKeysMu serializes DNSSEC key generation, whose new key is identified by comparing the
+// keys of the zone before and after
+var dnssecKeysMu sync.Mutex
+
+// dnssecKeyAlgorithm describes a DNSSEC algorithm as reported by the server
+type dnssecKeyAlgorithm struct {
+	algorithm     client.DNSSECAlgorithm
+	hashAlgorithm client.DNSSECHashAlgorithm
+	curve         client.DNSSECCurve
+	number        int
+}
+
+// dnssecKeyAlgorithms maps the algorithm mnemonics of the server to the parameters keys are
+// generated with and the algorithm numbers of DS records (RFC 8624)
+var dnssecKeyAlgorithms = map[string]dnssecKeyAlgorithm{
+	"RSAMD5":             {algorithm: client.DNSSECAlgorithmRSA, hashAlgorithm: client.DNSSECHashAlgorithmMD5, number: 1},
+	"RSASHA1":            {algorithm: client.DNSSECAlgorithmRSA, hashAlgorithm: client.DNSSECHashAlgorithmSHA1, number: 5},
+	"RSASHA1-NSEC3-SHA1": {algorithm: client.DNSSECAlgorithmRSA, hashAlgorithm: client.DNSSECHashAlgorithmSHA1, number: 7},
+	"RSASHA256":          {algorithm: client.DNSSECAlgorithmRSA, hashAlgorithm: client.DNSSECHashAlgorithmSHA256, number: 8},
+	"RSASHA512":          {algorithm: client.DNSSECAlgorithmRSA, hashAlgorithm: client.DNSSECHashAlgorithmSHA512, number: 10},
+	"ECDSAP256SHA256":    {algorithm: client.DNSSECAlgorithmECDSA, curve: client.DNSSECCurveP256, number: 13},
+	"ECDSAP384SHA384":    {algorithm: client.DNSSECAlgorithmECDSA, curve: client.DNSSECCurveP384, number: 14},
+	"ED25519":            {algorithm: client.DNSSECAlgorithmEDDSA, curve: client.DNSSECCurveED25519, number: 15},
+	"ED448":              {algorithm: client.DNSSECAlgorithmEDDSA, curve: client.DNSSECCurveED448, number: 16},
+}
+
+// dsDigestTypes maps the DS digest type mnemonics of the server to their numbers (RFC 4509, 6605)
+var dsDigestTypes = map[string]int{
+	"SHA1":   1,
+	"SHA256": 2,
+	"SHA384": 4,
+}
+
+// dsRecordAttrTypes describes the objects of the ds_records attribute
+var dsRecordAttrTypes = map[string]attr.Type{
+	"digest_type": types.StringType,
+	"digest":      types.StringType,
+	"record":      types.StringType,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneDNSSECKeyResource{}
+var _ resource.ResourceWithImportState = &ZoneDNSSECKeyResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneDNSSECKeyResource{}
+
+func NewZoneDNSSECKeyResource() resource.Resource {
+	return &ZoneDNSSECKeyResource{}
+}
+
+// ZoneDNSSECKeyResource defines the resource implementation.
+type ZoneDNSSECKeyResource struct {
+	client client.ClientAPI
+}
+
+// ZoneDNSSECKeyResourceModel describes the resource data model.
+type ZoneDNSSECKeyResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Zone            types.String `tfsdk:"zone"`
+	KeyType         types.String `tfsdk:"key_type"`
+	Algorithm       types.String `tfsdk:"algorithm"`
+	HashAlgorithm   types.String `tfsdk:"hash_algorithm"`
+	KeySize         types.Int64  `tfsdk:"key_size"`
+	Curve           types.String `tfsdk:"curve"`
+	RolloverDays    types.Int64  `tfsdk:"rollover_days"`
+	Publish         types.Bool   `tfsdk:"publish"`
+	RolloverTrigger types.String `tfsdk:"rollover_trigger"`
+	KeyTag          types.Int64  `tfsdk:"key_tag"`
+	State           types.String `tfsdk:"state"`
+	DSRecords       types.List   `tfsdk:"ds_records"`
+}
+
+func (r *ZoneDNSSECKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_dnssec_key"
+}
+
+func (r *ZoneDNSSECKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a DNSSEC private key of a signed primary zone: generates a Key Signing Key or Zone Signing Key, publishes it, " +
+			"initiates rollovers and retires it on destroy. When the key is rolled over, through `rollover_trigger` or automatically, the resource " +
+			"follows the successor key. The DS record values of Key Signing Keys are exported for publication at the registrar. " +
+			"Sign the zone first, e.g. with `dnssec_signed` of `technitium_zone`. " +
+			"This resource is experimental: enable the `dnssec` feature in the provider's `experimental_features` to use it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier (`<zone>:<key_tag>`)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The name of the signed primary zone",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_type": schema.StringAttribute{
+				MarkdownDescription: "The key type. Valid values are: " + enumDescription(dnssecKeyTypeValues),
+				Required:            true,
+				Validators: []validator.String{
+					enumValidator(dnssecKeyTypeValues),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"algorithm": schema.StringAttribute{
+				MarkdownDescription: "The algorithm of the key. Valid values are: " + enumDescription(dnssecAlgorithmValues) +
+					". Defaults to `" + string(client.DefaultDNSSECAlgorithm) + "`",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(string(client.DefaultDNSSECAlgorithm)),
+				Validators: []validator.String{
+					enumValidator(dnssecAlgorithmValues),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hash_algorithm": schema.StringAttribute{
+				MarkdownDescription: "The hash algorithm of RSA keys. Valid values are: " + enumDescription(dnssecHashAlgorithmValues) +
+					". Defaults to `" + string(defaultDNSSECHashAlgorithm) + "` with the `RSA` algorithm",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					enumValidator(dnssecHashAlgorithmValues),
+				},
+			},
+			"key_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The size in bits of RSA keys. Defaults to `%d` for Key Signing Keys and `%d` for Zone Signing Keys. "+
+					"Not read back from the server, so it stays unset after import", defaultDNSSECKSKKeySize, defaultDNSSECZSKKeySize),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1024, 4096),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"curve": schema.StringAttribute{
+				MarkdownDescription: "The curve of the key: `P256` or `P384` with the `ECDSA` algorithm (default `P256`), `ED25519` or `ED448` " +
+					"with the `EDDSA` algorithm (default `ED25519`)",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					enumValidator(dnssecCurveValues),
+				},
+			},
+			"rollover_days": schema.Int64Attribute{
+				MarkdownDescription: "How often in days the server automatically rolls the key over, from 0 (disabled) to 365. " +
+					"Defaults to the server default of 90 days for Zone Signing Keys and 0 for Key Signing Keys",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 365),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"publish": schema.BoolAttribute{
+				MarkdownDescription: "Whether to publish the DNSKEY record of the generated key, which activates the key once ready. " +
+					"Publishing publishes every generated key of the zone. Defaults to `true`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"rollover_trigger": schema.StringAttribute{
+				MarkdownDescription: "Changing this value rolls the key over: the server generates and publishes a successor, and retires the current key " +
+					"once the successor is active. Rolling over a Key Signing Key requires publishing the DS records of the successor at the parent zone",
+				Optional: true,
+			},
+			"key_tag": schema.Int64Attribute{
+				MarkdownDescription: "The key tag of the key",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "The state of the key (Generated, Published, Ready, Active, Retired or Revoked)",
+				Computed:            true,
+			},
+			"ds_records": schema.ListNestedAttribute{
+				MarkdownDescription: "The DS records to publish at the parent zone, one per digest type. Empty for Zone Signing Keys",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"digest_type": schema.StringAttribute{
+							MarkdownDescription: "The digest type (e.g., SHA256)",
+							Computed:            true,
+						},
+						"digest": schema.StringAttribute{
+							MarkdownDescription: "The digest of the DNSKEY record",
+							Computed:            true,
+						},
+						"record": schema.StringAttribute{
+							MarkdownDescription: "The DS record data in presentation format (`<key tag> <algorithm> <digest type> <digest>`)",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ZoneDNSSECKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneDNSSECKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	requireExperimentalFeature(ctx, r.client, client.ExperimentalDNSSEC, "technitium_zone_dnssec_key", &resp.Diagnostics)
+
+	var data, config ZoneDNSSECKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || data.Algorithm.IsUnknown() {
+		return
+	}
+
+	// Fill in the parameters of the algorithm the key is generated with
+	algorithm := client.DNSSECAlgorithm(data.Algorithm.ValueString())
+	if algorithm == client.DNSSECAlgorithmRSA {
+		if config.HashAlgorithm.IsNull() {
+			data.HashAlgorithm = types.StringValue(string(defaultDNSSECHashAlgorithm))
+		}
+		if !config.Curve.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("curve"), "Unsupported DNSSEC setting", "curve is not used with the RSA algorithm.")
+		}
+		data.Curve = types.StringNull()
+	} else {
+		if !config.HashAlgorithm.IsNull() || !config.KeySize.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("algorithm"), "Unsupported DNSSEC setting", "hash_algorithm and key_size are only used with the RSA algorithm.")
+		}
+		data.HashAlgorithm = types.StringNull()
+		if config.Curve.IsNull() {
+			data.Curve = types.StringValue(string(dnssecCurves[algorithm][0]))
+		} else if !config.Curve.IsUnknown() && !containsCurve(dnssecCurves[algorithm], client.DNSSECCurve(config.Curve.ValueString())) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("curve"),
+				"Invalid DNSSEC curve",
+				fmt.Sprintf("Curve %s cannot be used with the %s algorithm.", config.Curve.ValueString(), algorithm),
+			)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state ZoneDNSSECKeyResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// A key cannot change its parameters, so a different one replaces it
+		if !data.HashAlgorithm.Equal(state.HashAlgorithm) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("hash_algorithm"))
+		}
+		if !data.Curve.Equal(state.Curve) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("curve"))
+		}
+
+		// Rolling over replaces the key with its successor
+		if !data.RolloverTrigger.IsNull() && !data.RolloverTrigger.Equal(state.RolloverTrigger) {
+			data.KeyTag = types.Int64Unknown()
+			data.ID = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+func (r *ZoneDNSSECKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneDNSSECKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := normalizeZoneName(data.Zone.ValueString())
+	keyType := client.DNSSECKeyType(data.KeyType.ValueString())
+
+	tflog.Debug(ctx, "Generating DNSSEC key", map[string]interface{}{
+		"zone":     zoneName,
+		"key_type": string(keyType),
+	})
+
+	keyTag, err := r.addKey(ctx, zoneName, dnssecKeyOptions(&data))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to generate the DNSSEC key of zone %s: %s", zoneName, err.Error()))
+		return
+	}
+	data.KeyTag = types.Int64Value(keyTag)
+	data.ID = types.StringValue(dnssecKeyID(zoneName, keyTag))
+
+	if data.Publish.ValueBool() {
+		if err := r.client.PublishAllDNSSECPrivateKeys(ctx, zoneName); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to publish DNSSEC key %d of zone %s: %s", keyTag, zoneName, err.Error()))
+			// Save the key so it is deleted on destroy
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
+	if found, err := r.readKey(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNSSEC key %d of zone %s: %s", keyTag, zoneName, err.Error()))
+		return
+	} else if !found {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("DNSSEC key %d of zone %s not found after creation", keyTag, zoneName))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneDNSSECKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneDNSSECKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, err := r.readKey(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNSSEC key %d of zone %s: %s", data.KeyTag.ValueInt64(), data.Zone.ValueString(), err.Error()))
+		return
+	}
+
+	if !found {
+		tflog.Debug(ctx, "DNSSEC key not found, removing from state", map[string]interface{}{
+			"zone":    data.Zone.ValueString(),
+			"key_tag": data.KeyTag.ValueInt64(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneDNSSECKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ZoneDNSSECKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := normalizeZoneName(data.Zone.ValueString())
+	keyTag := state.KeyTag.ValueInt64()
+
+	if !data.RolloverDays.IsUnknown() && !data.RolloverDays.Equal(state.RolloverDays) {
+		if err := r.client.UpdateDNSSECPrivateKey(ctx, zoneName, keyTag, data.RolloverDays.ValueInt64()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update DNSSEC key %d of zone %s: %s", keyTag, zoneName, err.Error()))
+			return
+		}
+	}
+
+	if data.Publish.ValueBool() && !state.Publish.ValueBool() {
+		if err := r.client.PublishAllDNSSECPrivateKeys(ctx, zoneName); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to publish DNSSEC key %d of zone %s: %s", keyTag, zoneName, err.Error()))
+			return
+		}
+	}
+
+	data.KeyTag = state.KeyTag
+	data.ID = state.ID
+
+	if !data.RolloverTrigger.IsNull() && !data.RolloverTrigger.Equal(state.RolloverTrigger) {
+		tflog.Info(ctx, "Rolling over DNSSEC key", map[string]interface{}{
+			"zone":    zoneName,
+			"key_tag": keyTag,
+		})
+
+		if err := r.client.RolloverDNSKey(ctx, zoneName, keyTag); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to roll over DNSSEC key %d of zone %s: %s", keyTag, zoneName, err.Error()))
+			return
+		}
+	}
+
+	// Reading the key follows the successor of a key rolled over
+	if found, err := r.readKey(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read DNSSEC key %d of zone %s: %s", keyTag, zoneName, err.Error()))
+		return
+	} else if !found {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("DNSSEC key %d of zone %s not found after update", keyTag, zoneName))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneDNSSECKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneDNSSECKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := normalizeZoneName(data.Zone.ValueString())
+	keyTag := data.KeyTag.ValueInt64()
+
+	properties, err := r.client.GetDNSSECProperties(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the DNSSEC keys of zone %s: %s", zoneName, err.Error()))
+		return
+	}
+
+	key := findDNSSECKey(properties.PrivateKeys, keyTag)
+	switch {
+	case key == nil, key.IsRetiring, key.State == "Retired", key.State == "Revoked":
+		// Already on its way out
+		return
+	case key.State == "Generated":
+		// Keys never published can simply be deleted
+		err = r.client.DeleteDNSSECPrivateKey(ctx, zoneName, keyTag)
+	default:
+		err = r.client.RetireDNSKey(ctx, zoneName, keyTag)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove DNSSEC key %d of zone %s: %s", keyTag, zoneName, err.Error()))
+		return
+	}
+}
+
+func (r *ZoneDNSSECKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using <zone>:<key_tag> as the ID
+	zoneName, tag, ok := strings.Cut(req.ID, ":")
+	keyTag, err := strconv.ParseInt(tag, 10, 64)
+	if !ok || zoneName == "" || err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected an import ID of the form <zone>:<key_tag>, got %q.", req.ID),
+		)
+		return
+	}
+
+	zoneName = normalizeZoneName(zoneName)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dnssecKeyID(zoneName, keyTag))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), zoneName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_tag"), keyTag)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("publish"), true)...)
+}
+
+// addKey generates a key and returns its key tag, found by comparing the keys of the zone before
+// and after
+func (r *ZoneDNSSECKeyResource) addKey(ctx context.Context, zoneName string, options client.DNSSECKeyOptions) (int64, error) {
+	dnssecKeysMu.Lock()
+	defer dnssecKeysMu.Unlock()
+
+	before, err := r.client.GetDNSSECProperties(ctx, zoneName)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.client.AddDNSSECPrivateKey(ctx, zoneName, options); err != nil {
+		return 0, err
+	}
+
+	after, err := r.client.GetDNSSECProperties(ctx, zoneName)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, key := range after.PrivateKeys {
+		if key.KeyType == string(options.KeyType) && findDNSSECKey(before.PrivateKeys, int64(key.KeyTag)) == nil {
+			return int64(key.KeyTag), nil
+		}
+	}
+	return 0, fmt.Errorf("the generated %s was not found", options.KeyType)
+}
+
+// readKey reads the key into data, following its successor when the key was rolled over. It
+// returns false when neither the key nor a successor exists.
+func (r *ZoneDNSSECKeyResource) readKey(ctx context.Context, data *ZoneDNSSECKeyResourceModel) (bool, error) {
+	zoneName := normalizeZoneName(data.Zone.ValueString())
+
+	properties, err := r.client.GetDNSSECProperties(ctx, zoneName)
+	if err != nil {
+		return false, err
+	}
+
+	key := findDNSSECKey(properties.PrivateKeys, data.KeyTag.ValueInt64())
+	if key == nil || key.IsRetiring {
+		successor := findSuccessorDNSSECKey(properties.PrivateKeys, key, data.KeyType.ValueString())
+		if successor == nil {
+			return key != nil, nil
+		}
+
+		tflog.Info(ctx, "Following successor of rolled over DNSSEC key", map[string]interface{}{
+			"zone":      zoneName,
+			"key_tag":   data.KeyTag.ValueInt64(),
+			"successor": successor.KeyTag,
+		})
+		key = successor
+	}
+
+	data.ID = types.StringValue(dnssecKeyID(zoneName, int64(key.KeyTag)))
+	data.KeyTag = types.Int64Value(int64(key.KeyTag))
+	data.KeyType = types.StringValue(key.KeyType)
+	data.State = types.StringValue(key.State)
+	data.RolloverDays = types.Int64Value(int64(key.RolloverDays))
+
+	algorithm, known := dnssecKeyAlgorithms[key.Algorithm]
+	if known {
+		data.Algorithm = types.StringValue(string(algorithm.algorithm))
+		data.HashAlgorithm = types.StringNull()
+		if algorithm.hashAlgorithm != "" {
+			data.HashAlgorithm = types.StringValue(string(algorithm.hashAlgorithm))
+		}
+		data.Curve = types.StringNull()
+		if algorithm.curve != "" {
+			data.Curve = types.StringValue(string(algorithm.curve))
+		}
+	}
+
+	dsRecords := []attr.Value{}
+	if key.KeyType == string(client.DNSSECKeyTypeKSK) && key.State != "Generated" {
+		info, err := r.client.GetDSInfo(ctx, zoneName)
+		if err != nil {
+			return false, err
+		}
+
+		for _, record := range info.DSRecords {
+			if record.KeyTag != key.KeyTag {
+				continue
+			}
+			for _, digest := range record.Digests {
+				dsRecords = append(dsRecords, types.ObjectValueMust(dsRecordAttrTypes, map[string]attr.Value{
+					"digest_type": types.StringValue(digest.DigestType),
+					"digest":      types.StringValue(digest.Digest),
+					"record": types.StringValue(fmt.Sprintf("%d %d %d %s",
+						record.KeyTag, dnssecKeyAlgorithms[record.Algorithm].number, dsDigestTypes[digest.DigestType], digest.Digest)),
+				}))
+			}
+		}
+	}
+	data.DSRecords = types.ListValueMust(types.ObjectType{AttrTypes: dsRecordAttrTypes}, dsRecords)
+
+	return true, nil
+}
+
+// dnssecKeyOptions returns the addPrivateKey parameters of the planned key
+func dnssecKeyOptions(data *ZoneDNSSECKeyResourceModel) client.DNSSECKeyOptions {
+	options := client.DNSSECKeyOptions{
+		KeyType:   client.DNSSECKeyType(data.KeyType.ValueString()),
+		Algorithm: client.DNSSECAlgorithm(data.Algorithm.ValueString()),
+		Curve:     client.DNSSECCurve(data.Curve.ValueString()),
+	}
+	if !data.RolloverDays.IsUnknown() && !data.RolloverDays.IsNull() {
+		options.RolloverDays = data.RolloverDays.ValueInt64Pointer()
+	}
+
+	if options.Algorithm == client.DNSSECAlgorithmRSA {
+		options.HashAlgorithm = client.DNSSECHashAlgorithm(data.HashAlgorithm.ValueString())
+		options.KeySize = data.KeySize.ValueInt64()
+		if options.KeySize == 0 {
+			options.KeySize = defaultDNSSECZSKKeySize
+			if options.KeyType == client.DNSSECKeyTypeKSK {
+				options.KeySize = defaultDNSSECKSKKeySize
+			}
+		}
+	}
+
+	return options
+}
+
+// findDNSSECKey returns the key with the given key tag, or nil
+func findDNSSECKey(keys []client.DNSSECPrivateKey, keyTag int64) *client.DNSSECPrivateKey {
+	for i := range keys {
+		if int64(keys[i].KeyTag) == keyTag {
+			return &keys[i]
+		}
+	}
+	return nil
+}
+
+// findSuccessorDNSSECKey returns the newest key of the same type and algorithm that is not being
+// retired, or nil. Without the old key, any algorithm matches.
+func findSuccessorDNSSECKey(keys []client.DNSSECPrivateKey, old *client.DNSSECPrivateKey, keyType string) *client.DNSSECPrivateKey {
+	var successor *client.DNSSECPrivateKey
+	var newest time.Time
+	for i := range keys {
+		key := &keys[i]
+		if key == old || key.KeyType != keyType || key.IsRetiring || key.State == "Retired" || key.State == "Revoked" {
+			continue
+		}
+		if old != nil && key.Algorithm != old.Algorithm {
+			continue
+		}
+
+		changed, _ := time.Parse(time.RFC3339Nano, key.StateChangedOn)
+		if successor == nil || changed.After(newest) {
+			successor, newest = key, changed
+		}
+	}
+	return successor
+}
+
+// dnssecKeyID returns the resource ID of a key
+func dnssecKeyID(zoneName string, keyTag int64) string {
+	return fmt.Sprintf("%s:%d", zoneName, keyTag)
+}
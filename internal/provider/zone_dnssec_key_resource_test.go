@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func dnssecKeyPlanModel(keyType, algorithm string) *ZoneDNSSECKeyResourceModel {
+	return &ZoneDNSSECKeyResourceModel{
+		ID:              types.StringUnknown(),
+		Zone:            types.StringValue("example.com"),
+		KeyType:         types.StringValue(keyType),
+		Algorithm:       types.StringValue(algorithm),
+		HashAlgorithm:   types.StringUnknown(),
+		KeySize:         types.Int64Null(),
+		Curve:           types.StringUnknown(),
+		RolloverDays:    types.Int64Unknown(),
+		Publish:         types.BoolValue(true),
+		RolloverTrigger: types.StringNull(),
+		KeyTag:          types.Int64Unknown(),
+		State:           types.StringUnknown(),
+		DSRecords:       types.ListUnknown(types.ObjectType{AttrTypes: dsRecordAttrTypes}),
+	}
+}
+
+func TestZoneDNSSECKeyResource(t *testing.T) {
+	t.Parallel()
+
+	r := NewZoneDNSSECKeyResource()
+
+	var metadataResp resource.MetadataResponse
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &metadataResp)
+	require.Equal(t, "technitium_zone_dnssec_key", metadataResp.TypeName)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	for _, name := range []string{"zone", "key_type", "algorithm", "rollover_trigger", "key_tag", "ds_records"} {
+		require.Contains(t, schemaResp.Schema.Attributes, name)
+	}
+}
+
+func TestZoneDNSSECKeyResourceModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	r := &ZoneDNSSECKeyResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	modifyPlan := func(t *testing.T, config, prior *ZoneDNSSECKeyResourceModel) (*ZoneDNSSECKeyResourceModel, resource.ModifyPlanResponse) {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(context.Background(), config).HasError())
+		// An empty state stands for a resource being created
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		if prior != nil {
+			require.False(t, state.Set(context.Background(), prior).HasError())
+		}
+
+		resp := resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{
+			Plan:   plan,
+			State:  state,
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw},
+		}, &resp)
+
+		var data ZoneDNSSECKeyResourceModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.Plan.Get(context.Background(), &data).HasError())
+		}
+		return &data, resp
+	}
+
+	t.Run("defaults the curve", func(t *testing.T) {
+		config := dnssecKeyPlanModel("KeySigningKey", "EDDSA")
+		config.HashAlgorithm = types.StringNull()
+		config.Curve = types.StringNull()
+
+		data, resp := modifyPlan(t, config, nil)
+		require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+		require.Equal(t, "ED25519", data.Curve.ValueString())
+		require.True(t, data.HashAlgorithm.IsNull())
+	})
+
+	t.Run("defaults the hash algorithm", func(t *testing.T) {
+		config := dnssecKeyPlanModel("ZoneSigningKey", "RSA")
+		config.HashAlgorithm = types.StringNull()
+		config.Curve = types.StringNull()
+
+		data, resp := modifyPlan(t, config, nil)
+		require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+		require.Equal(t, "SHA256", data.HashAlgorithm.ValueString())
+		require.True(t, data.Curve.IsNull())
+	})
+
+	t.Run("rejects a curve of another algorithm", func(t *testing.T) {
+		config := dnssecKeyPlanModel("KeySigningKey", "ECDSA")
+		config.HashAlgorithm = types.StringNull()
+		config.Curve = types.StringValue("ED448")
+
+		_, resp := modifyPlan(t, config, nil)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Invalid DNSSEC curve", resp.Diagnostics.Errors()[0].Summary())
+	})
+
+	t.Run("rejects RSA settings", func(t *testing.T) {
+		config := dnssecKeyPlanModel("KeySigningKey", "ECDSA")
+		config.HashAlgorithm = types.StringNull()
+		config.Curve = types.StringNull()
+		config.KeySize = types.Int64Value(2048)
+
+		_, resp := modifyPlan(t, config, nil)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Unsupported DNSSEC setting", resp.Diagnostics.Errors()[0].Summary())
+	})
+
+	t.Run("rollover trigger follows the successor", func(t *testing.T) {
+		prior := dnssecKeyPlanModel("ZoneSigningKey", "ECDSA")
+		prior.ID = types.StringValue("example.com:1234")
+		prior.HashAlgorithm = types.StringNull()
+		prior.Curve = types.StringValue("P256")
+		prior.RolloverDays = types.Int64Value(90)
+		prior.KeyTag = types.Int64Value(1234)
+		prior.State = types.StringValue("Active")
+		prior.DSRecords = types.ListValueMust(types.ObjectType{AttrTypes: dsRecordAttrTypes}, []attr.Value{})
+
+		config := *prior
+		config.HashAlgorithm = types.StringNull()
+		config.Curve = types.StringNull()
+		config.RolloverTrigger = types.StringValue("2026-10")
+
+		data, resp := modifyPlan(t, &config, prior)
+		require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+		require.Empty(t, resp.RequiresReplace)
+		require.True(t, data.KeyTag.IsUnknown())
+
+		config.RolloverTrigger = types.StringNull()
+		config.Curve = types.StringValue("P384")
+		_, resp = modifyPlan(t, &config, prior)
+		require.Equal(t, []path.Path{path.Root("curve")}, []path.Path(resp.RequiresReplace))
+	})
+}
+
+func TestZoneDNSSECKeyResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := &ZoneDNSSECKeyResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	zsk := client.DNSSECPrivateKey{KeyTag: 1111, KeyType: "ZoneSigningKey", Algorithm: "ECDSAP256SHA256", State: "Active", RolloverDays: 90}
+	generated := client.DNSSECPrivateKey{KeyTag: 2222, KeyType: "KeySigningKey", Algorithm: "ECDSAP256SHA256", State: "Generated"}
+	published := generated
+	published.State = "Published"
+
+	m.On("GetDNSSECProperties", mock.Anything, "example.com").
+		Return(&client.DNSSECProperties{PrivateKeys: []client.DNSSECPrivateKey{zsk}}, nil).Once()
+	m.On("AddDNSSECPrivateKey", mock.Anything, "example.com", client.DNSSECKeyOptions{
+		KeyType:   client.DNSSECKeyTypeKSK,
+		Algorithm: client.DNSSECAlgorithmECDSA,
+		Curve:     client.DNSSECCurveP256,
+	}).Return(nil).Once()
+	m.On("GetDNSSECProperties", mock.Anything, "example.com").
+		Return(&client.DNSSECProperties{PrivateKeys: []client.DNSSECPrivateKey{zsk, generated}}, nil).Once()
+	m.On("PublishAllDNSSECPrivateKeys", mock.Anything, "example.com").Return(nil).Once()
+	m.On("GetDNSSECProperties", mock.Anything, "example.com").
+		Return(&client.DNSSECProperties{PrivateKeys: []client.DNSSECPrivateKey{zsk, published}}, nil).Once()
+	m.On("GetDSInfo", mock.Anything, "example.com").Return(&client.DSInfo{DSRecords: []client.DSRecord{{
+		KeyTag:    2222,
+		Algorithm: "ECDSAP256SHA256",
+		Digests:   []client.DSDigest{{DigestType: "SHA256", Digest: "ABCDEF"}},
+	}}}, nil).Once()
+
+	config := dnssecKeyPlanModel("KeySigningKey", "ECDSA")
+	config.HashAlgorithm = types.StringNull()
+	config.Curve = types.StringValue("P256")
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(context.Background(), config).HasError())
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "create diagnostics: %v", createResp.Diagnostics)
+
+	var state ZoneDNSSECKeyResourceModel
+	require.False(t, createResp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "example.com:2222", state.ID.ValueString())
+	require.Equal(t, "Published", state.State.ValueString())
+	require.Equal(t, "P256", state.Curve.ValueString())
+
+	var dsRecords []struct {
+		DigestType types.String `tfsdk:"digest_type"`
+		Digest     types.String `tfsdk:"digest"`
+		Record     types.String `tfsdk:"record"`
+	}
+	require.False(t, state.DSRecords.ElementsAs(context.Background(), &dsRecords, false).HasError())
+	require.Len(t, dsRecords, 1)
+	require.Equal(t, "2222 13 2 ABCDEF", dsRecords[0].Record.ValueString())
+
+	// A key rolled over outside of Terraform is replaced by its successor
+	retiring := published
+	retiring.State = "Active"
+	retiring.IsRetiring = true
+	successor := client.DNSSECPrivateKey{KeyTag: 3333, KeyType: "KeySigningKey", Algorithm: "ECDSAP256SHA256", State: "Generated", StateChangedOn: "2026-10-17T00:00:00Z"}
+	m.On("GetDNSSECProperties", mock.Anything, "example.com").
+		Return(&client.DNSSECProperties{PrivateKeys: []client.DNSSECPrivateKey{zsk, retiring, successor}}, nil).Once()
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.False(t, readResp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, int64(3333), state.KeyTag.ValueInt64())
+	require.Empty(t, state.DSRecords.Elements())
+
+	// Keys never published are deleted, others retired
+	m.On("GetDNSSECProperties", mock.Anything, "example.com").
+		Return(&client.DNSSECProperties{PrivateKeys: []client.DNSSECPrivateKey{zsk, retiring, successor}}, nil).Once()
+	m.On("DeleteDNSSECPrivateKey", mock.Anything, "example.com", int64(3333)).Return(nil).Once()
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+
+	m.On("GetDNSSECProperties", mock.Anything, "example.com").
+		Return(&client.DNSSECProperties{PrivateKeys: []client.DNSSECPrivateKey{zsk}}, nil).Once()
+	m.On("RetireDNSKey", mock.Anything, "example.com", int64(1111)).Return(nil).Once()
+
+	require.False(t, readResp.State.SetAttribute(context.Background(), path.Root("key_tag"), int64(1111)).HasError())
+	r.Delete(context.Background(), resource.DeleteRequest{State: readResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+}
+
+func TestFindSuccessorDNSSECKey(t *testing.T) {
+	t.Parallel()
+
+	keys := []client.DNSSECPrivateKey{
+		{KeyTag: 1, KeyType: "ZoneSigningKey", Algorithm: "ED25519", IsRetiring: true},
+		{KeyTag: 2, KeyType: "ZoneSigningKey", Algorithm: "ED25519", StateChangedOn: "2026-01-01T00:00:00Z"},
+		{KeyTag: 3, KeyType: "ZoneSigningKey", Algorithm: "ED25519", StateChangedOn: "2026-02-01T00:00:00Z"},
+		{KeyTag: 4, KeyType: "ZoneSigningKey", Algorithm: "RSASHA256", StateChangedOn: "2026-03-01T00:00:00Z"},
+		{KeyTag: 5, KeyType: "KeySigningKey", Algorithm: "ED25519", StateChangedOn: "2026-03-01T00:00:00Z"},
+	}
+
+	require.Equal(t, 3, findSuccessorDNSSECKey(keys, &keys[0], "ZoneSigningKey").KeyTag)
+	require.Equal(t, 4, findSuccessorDNSSECKey(keys, nil, "ZoneSigningKey").KeyTag)
+	require.Nil(t, findSuccessorDNSSECKey(keys[:1], &keys[0], "ZoneSigningKey"))
+}
@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ZoneDNSSECRolloversDataSource{}
+
+func NewZoneDNSSECRolloversDataSource() datasource.DataSource {
+	return &ZoneDNSSECRolloversDataSource{}
+}
+
+// ZoneDNSSECRolloversDataSource defines the data source implementation.
+type ZoneDNSSECRolloversDataSource struct {
+	client client.ClientAPI
+}
+
+// ZoneDNSSECRolloversDataSourceModel describes the data source data model.
+type ZoneDNSSECRolloversDataSourceModel struct {
+	// Required inputs
+	Zone types.String `tfsdk:"zone"`
+
+	// Optional inputs
+	WithinDays types.Int64 `tfsdk:"within_days"`
+
+	// Computed outputs
+	ID           types.String          `tfsdk:"id"`
+	DnssecStatus types.String          `tfsdk:"dnssec_status"`
+	Keys         []DNSSECKeyDataItem   `tfsdk:"keys"`
+	Events       []DNSSECRolloverEvent `tfsdk:"events"`
+}
+
+// DNSSECKeyDataItem represents a DNSSEC private key of the zone
+type DNSSECKeyDataItem struct {
+	KeyTag         types.Int64  `tfsdk:"key_tag"`
+	KeyType        types.String `tfsdk:"key_type"`
+	Algorithm      types.String `tfsdk:"algorithm"`
+	State          types.String `tfsdk:"state"`
+	StateChangedOn types.String `tfsdk:"state_changed_on"`
+	StateReadyBy   types.String `tfsdk:"state_ready_by"`
+	IsRetiring     types.Bool   `tfsdk:"is_retiring"`
+	RolloverDays   types.Int64  `tfsdk:"rollover_days"`
+}
+
+// DNSSECRolloverEvent represents a scheduled key state change
+type DNSSECRolloverEvent struct {
+	KeyTag           types.Int64  `tfsdk:"key_tag"`
+	KeyType          types.String `tfsdk:"key_type"`
+	Event            types.String `tfsdk:"event"`
+	Date             types.String `tfsdk:"date"`
+	RequiresDSUpdate types.Bool   `tfsdk:"requires_ds_update"`
+}
+
+// dnssecKeySigningKey is the key type whose changes require DS record updates at the parent zone
+const dnssecKeySigningKey = "KeySigningKey"
+
+func (d *ZoneDNSSECRolloversDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_dnssec_rollovers"
+}
+
+func (d *ZoneDNSSECRolloversDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source listing the DNSSEC keys of a signed zone and their upcoming rollover events",
+		MarkdownDescription: "Data source listing the DNSSEC keys of a signed primary zone and their upcoming rollover events: when published " +
+			"keys become ready and when active keys with automatic rollover are due to be replaced. Events of Key Signing Keys are " +
+			"flagged with `requires_ds_update`, so calendars or alerts can be generated for registrars that need manual DS record updates.",
+
+		Attributes: map[string]schema.Attribute{
+			// Required inputs
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The name of the signed primary zone (e.g., 'example.com').",
+				Required:            true,
+			},
+
+			// Optional inputs
+			"within_days": schema.Int64Attribute{
+				MarkdownDescription: "Only return events due within this many days from now, including overdue events. If not specified, all events are returned.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			// Computed outputs
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"dnssec_status": schema.StringAttribute{
+				MarkdownDescription: "The DNSSEC status of the zone (e.g., Unsigned, SignedWithNSEC3). Unsigned zones have no keys or events.",
+				Computed:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The DNSSEC private keys of the zone.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_tag": schema.Int64Attribute{
+							MarkdownDescription: "The key tag of the key.",
+							Computed:            true,
+						},
+						"key_type": schema.StringAttribute{
+							MarkdownDescription: "The key type (KeySigningKey or ZoneSigningKey).",
+							Computed:            true,
+						},
+						"algorithm": schema.StringAttribute{
+							MarkdownDescription: "The DNSSEC algorithm of the key (e.g., ECDSAP256SHA256).",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "The key state (e.g., Generated, Published, Ready, Active, Retired).",
+							Computed:            true,
+						},
+						"state_changed_on": schema.StringAttribute{
+							MarkdownDescription: "When the key entered its current state.",
+							Computed:            true,
+						},
+						"state_ready_by": schema.StringAttribute{
+							MarkdownDescription: "When a published key becomes ready. Empty for keys in other states.",
+							Computed:            true,
+						},
+						"is_retiring": schema.BoolAttribute{
+							MarkdownDescription: "Whether the key is being retired in favor of a successor.",
+							Computed:            true,
+						},
+						"rollover_days": schema.Int64Attribute{
+							MarkdownDescription: "The automatic rollover frequency of the key in days, 0 when automatic rollover is disabled.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "Scheduled key events ordered by date.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_tag": schema.Int64Attribute{
+							MarkdownDescription: "The key tag of the key the event applies to.",
+							Computed:            true,
+						},
+						"key_type": schema.StringAttribute{
+							MarkdownDescription: "The key type (KeySigningKey or ZoneSigningKey).",
+							Computed:            true,
+						},
+						"event": schema.StringAttribute{
+							MarkdownDescription: "The event: `ready` when a published key becomes ready, `rollover` when an active key is due for automatic rollover.",
+							Computed:            true,
+						},
+						"date": schema.StringAttribute{
+							MarkdownDescription: "When the event is due (RFC 3339).",
+							Computed:            true,
+						},
+						"requires_ds_update": schema.BoolAttribute{
+							MarkdownDescription: "Whether the event requires updating the DS records at the parent zone, which is the case for Key Signing Keys.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ZoneDNSSECRolloversDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneDNSSECRolloversDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneDNSSECRolloversDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := data.Zone.ValueString()
+	tflog.Debug(ctx, "Reading zone DNSSEC rollovers data source", map[string]interface{}{
+		"zone": zoneName,
+	})
+
+	properties, err := d.client.GetDNSSECProperties(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DNSSEC properties",
+			fmt.Sprintf("Could not read DNSSEC properties of zone %s: %s", zoneName, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(zoneName)
+	data.DnssecStatus = types.StringValue(properties.DnssecStatus)
+
+	data.Keys = make([]DNSSECKeyDataItem, 0, len(properties.PrivateKeys))
+	for _, key := range properties.PrivateKeys {
+		data.Keys = append(data.Keys, DNSSECKeyDataItem{
+			KeyTag:         types.Int64Value(int64(key.KeyTag)),
+			KeyType:        types.StringValue(key.KeyType),
+			Algorithm:      types.StringValue(key.Algorithm),
+			State:          types.StringValue(key.State),
+			StateChangedOn: types.StringValue(key.StateChangedOn),
+			StateReadyBy:   types.StringValue(key.StateReadyBy),
+			IsRetiring:     types.BoolValue(key.IsRetiring),
+			RolloverDays:   types.Int64Value(int64(key.RolloverDays)),
+		})
+	}
+
+	var until time.Time
+	if !data.WithinDays.IsNull() {
+		until = time.Now().AddDate(0, 0, int(data.WithinDays.ValueInt64()))
+	}
+	data.Events = dnssecRolloverEvents(properties.PrivateKeys, until)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// dnssecRolloverEvents derives the scheduled state changes of the given keys ordered by date,
+// leaving out events due after until unless it is the zero time. Published keys become ready
+// at their ready-by time and active keys with automatic rollover are replaced rolloverDays after
+// they became active, unless they are already being retired.
+func dnssecRolloverEvents(keys []client.DNSSECPrivateKey, until time.Time) []DNSSECRolloverEvent {
+	type dated struct {
+		date  time.Time
+		event DNSSECRolloverEvent
+	}
+
+	scheduled := make([]dated, 0)
+	add := func(key client.DNSSECPrivateKey, event string, date time.Time) {
+		if !until.IsZero() && date.After(until) {
+			return
+		}
+		scheduled = append(scheduled, dated{date: date, event: DNSSECRolloverEvent{
+			KeyTag:           types.Int64Value(int64(key.KeyTag)),
+			KeyType:          types.StringValue(key.KeyType),
+			Event:            types.StringValue(event),
+			Date:             types.StringValue(date.UTC().Format(time.RFC3339)),
+			RequiresDSUpdate: types.BoolValue(key.KeyType == dnssecKeySigningKey),
+		}})
+	}
+
+	for _, key := range keys {
+		switch key.State {
+		case "Published":
+			if readyBy, err := time.Parse(time.RFC3339Nano, key.StateReadyBy); err == nil {
+				add(key, "ready", readyBy)
+			}
+		case "Active":
+			if key.RolloverDays <= 0 || key.IsRetiring {
+				continue
+			}
+			if activeSince, err := time.Parse(time.RFC3339Nano, key.StateChangedOn); err == nil {
+				add(key, "rollover", activeSince.AddDate(0, 0, key.RolloverDays))
+			}
+		}
+	}
+
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		return scheduled[i].date.Before(scheduled[j].date)
+	})
+
+	events := make([]DNSSECRolloverEvent, 0, len(scheduled))
+	for _, s := range scheduled {
+		events = append(events, s.event)
+	}
+	return events
+}
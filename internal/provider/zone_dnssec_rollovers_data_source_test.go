@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestZoneDNSSECRolloversDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		ds := NewZoneDNSSECRolloversDataSource()
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_zone_dnssec_rollovers" {
+			t.Errorf("Expected TypeName to be technitium_zone_dnssec_rollovers, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		ds := NewZoneDNSSECRolloversDataSource()
+		var resp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		if !resp.Schema.Attributes["zone"].IsRequired() {
+			t.Error("'zone' attribute should be required")
+		}
+		for _, name := range []string{"id", "dnssec_status", "keys", "events"} {
+			attr, ok := resp.Schema.Attributes[name]
+			if !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+				continue
+			}
+			if !attr.IsComputed() {
+				t.Errorf("'%s' attribute should be computed", name)
+			}
+		}
+	})
+
+	t.Run("Configure", func(t *testing.T) {
+		ds := NewZoneDNSSECRolloversDataSource().(*ZoneDNSSECRolloversDataSource)
+
+		var resp datasource.ConfigureResponse
+		ds.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: "wrong-type"}, &resp)
+		if !resp.Diagnostics.HasError() {
+			t.Error("Configure should fail with wrong provider data type")
+		}
+	})
+}
+
+func TestDNSSECRolloverEvents(t *testing.T) {
+	t.Parallel()
+
+	keys := []client.DNSSECPrivateKey{
+		// Published KSK becoming ready
+		{KeyTag: 15048, KeyType: "KeySigningKey", State: "Published", StateChangedOn: "2026-10-01T10:00:00Z", StateReadyBy: "2026-10-01T12:00:00.0328321Z"},
+		// Active ZSK rolling over after 30 days
+		{KeyTag: 46152, KeyType: "ZoneSigningKey", State: "Active", StateChangedOn: "2026-09-20T00:00:00Z", RolloverDays: 30},
+		// Active KSK without automatic rollover
+		{KeyTag: 20326, KeyType: "KeySigningKey", State: "Active", StateChangedOn: "2026-01-01T00:00:00Z"},
+		// Retiring ZSK already being replaced
+		{KeyTag: 11111, KeyType: "ZoneSigningKey", State: "Active", StateChangedOn: "2026-08-01T00:00:00Z", RolloverDays: 30, IsRetiring: true},
+		// Active KSK rolling over after a year
+		{KeyTag: 22222, KeyType: "KeySigningKey", State: "Active", StateChangedOn: "2026-06-01T00:00:00Z", RolloverDays: 365},
+	}
+
+	events := dnssecRolloverEvents(keys, time.Time{})
+	require.Len(t, events, 3)
+
+	require.Equal(t, int64(15048), events[0].KeyTag.ValueInt64())
+	require.Equal(t, "ready", events[0].Event.ValueString())
+	require.Equal(t, "2026-10-01T12:00:00Z", events[0].Date.ValueString())
+	require.True(t, events[0].RequiresDSUpdate.ValueBool())
+
+	require.Equal(t, int64(46152), events[1].KeyTag.ValueInt64())
+	require.Equal(t, "rollover", events[1].Event.ValueString())
+	require.Equal(t, "2026-10-20T00:00:00Z", events[1].Date.ValueString())
+	require.False(t, events[1].RequiresDSUpdate.ValueBool())
+
+	require.Equal(t, int64(22222), events[2].KeyTag.ValueInt64())
+	require.Equal(t, "2027-06-01T00:00:00Z", events[2].Date.ValueString())
+
+	// Events after the cut-off are left out
+	events = dnssecRolloverEvents(keys, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.Len(t, events, 2)
+}
+
+func TestUnitZoneDNSSECRolloversDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &ZoneDNSSECRolloversDataSource{client: m}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	m.On("GetDNSSECProperties", mock.Anything, "example.com").Return(&client.DNSSECProperties{
+		Name:         "example.com",
+		DnssecStatus: "SignedWithNSEC3",
+		PrivateKeys: []client.DNSSECPrivateKey{
+			{KeyTag: 46152, KeyType: "ZoneSigningKey", Algorithm: "ECDSAP256SHA256", State: "Active", StateChangedOn: "2026-09-20T00:00:00Z", RolloverDays: 30},
+		},
+	}, nil)
+
+	// Build the configuration from a state holding the inputs
+	input := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, input.Set(context.Background(), &ZoneDNSSECRolloversDataSourceModel{Zone: types.StringValue("example.com")}).HasError())
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var state ZoneDNSSECRolloversDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "SignedWithNSEC3", state.DnssecStatus.ValueString())
+	require.Len(t, state.Keys, 1)
+	require.Equal(t, "ECDSAP256SHA256", state.Keys[0].Algorithm.ValueString())
+	require.Len(t, state.Events, 1)
+	require.Equal(t, "2026-10-20T00:00:00Z", state.Events[0].Date.ValueString())
+}
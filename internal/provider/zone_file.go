@@ -0,0 +1,530 @@
+package provider
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// zoneFileRecord is a record of an RFC 1035 zone file in canonical form, so zone files differing
+// only in formatting, comments, relative names or record order compare equal
+type zoneFileRecord struct {
+	// Name is fully qualified, lower case and without the trailing dot
+	Name string
+	TTL  int64
+	Type string
+	// Fields holds the canonical record data fields, with names qualified and strings unquoted
+	Fields []string
+	// Strings holds the character strings of a TXT record as written, which Fields joins
+	Strings []string
+}
+
+// Data returns the canonical record data, quoting the fields that are strings
+func (r zoneFileRecord) Data() string {
+	fields := make([]string, len(r.Fields))
+	for i, field := range r.Fields {
+		if zoneFileStringField(r.Type, i) {
+			field = strconv.Quote(field)
+		}
+		fields[i] = field
+	}
+	return strings.Join(fields, " ")
+}
+
+// String formats the record as a zone file line
+func (r zoneFileRecord) String() string {
+	return fmt.Sprintf("%s. %d IN %s %s", r.Name, r.TTL, r.Type, r.Data())
+}
+
+// key identifies the record regardless of its TTL
+func (r zoneFileRecord) key() string {
+	return r.Name + " " + r.Type + " " + r.Data()
+}
+
+// zoneFileServerTypes are the record types the server maintains itself for signed zones, so they
+// never show up as drift and are never deleted
+var zoneFileServerTypes = map[string]bool{
+	"DNSKEY":     true,
+	"RRSIG":      true,
+	"NSEC":       true,
+	"NSEC3":      true,
+	"NSEC3PARAM": true,
+}
+
+// zoneFileClasses are the record classes accepted in zone files
+var zoneFileClasses = map[string]bool{"IN": true, "CH": true, "HS": true, "CS": true}
+
+// zoneFileToken is a word of a zone file line
+type zoneFileToken struct {
+	text   string
+	quoted bool
+}
+
+// zoneFileLine is a logical line of a zone file, spanning several lines inside parentheses
+type zoneFileLine struct {
+	number int
+	// inheritsOwner is set for lines starting with white space, which reuse the previous owner
+	inheritsOwner bool
+	tokens        []zoneFileToken
+}
+
+// zoneFileEntry is a record of a zone file with its owner and TTL resolved, before its record
+// data is canonicalized
+type zoneFileEntry struct {
+	line   int
+	owner  string
+	ttl    int64
+	typ    string
+	tokens []zoneFileToken
+	// origin is the origin relative names of the record data are qualified with
+	origin string
+}
+
+// parseZoneFile parses an RFC 1035 zone file of the given zone into canonical records, sorted.
+// Names are relative to $ORIGIN, or to the zone when the file does not set it.
+func parseZoneFile(zoneName, content string) ([]zoneFileRecord, error) {
+	entries, err := zoneFileEntries(zoneName, content)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]zoneFileRecord, 0, len(entries))
+	for _, entry := range entries {
+		fields, err := canonicalZoneFileFields(entry.typ, entry.tokens, entry.origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s record of %s: %w", entry.line, entry.typ, entry.owner, err)
+		}
+		record := zoneFileRecord{Name: entry.owner, TTL: entry.ttl, Type: entry.typ, Fields: fields}
+		if entry.typ == "TXT" {
+			for _, token := range entry.tokens {
+				record.Strings = append(record.Strings, token.text)
+			}
+		}
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].String() < records[j].String()
+	})
+	return records, nil
+}
+
+// zoneFileEntries resolves the directives, owners and TTLs of a zone file of the given zone
+func zoneFileEntries(zoneName, content string) ([]zoneFileEntry, error) {
+	lines, err := zoneFileLines(content)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := canonicalZoneName(zoneName)
+	var defaultTTL, lastTTL int64 = -1, -1
+	owner := ""
+	var entries []zoneFileEntry
+
+	for _, line := range lines {
+		tokens := line.tokens
+		if !tokens[0].quoted && strings.HasPrefix(tokens[0].text, "$") {
+			switch directive := strings.ToUpper(tokens[0].text); directive {
+			case "$ORIGIN":
+				if len(tokens) < 2 {
+					return nil, fmt.Errorf("line %d: $ORIGIN without a name", line.number)
+				}
+				origin = qualifyZoneFileName(tokens[1].text, origin)
+			case "$TTL":
+				if len(tokens) < 2 {
+					return nil, fmt.Errorf("line %d: $TTL without a value", line.number)
+				}
+				if defaultTTL, err = parseZoneFileTTL(tokens[1].text); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line.number, err)
+				}
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive %s", line.number, directive)
+			}
+			continue
+		}
+
+		if !line.inheritsOwner {
+			owner = qualifyZoneFileName(tokens[0].text, origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record without an owner name", line.number)
+		}
+
+		// The TTL and the class are both optional and may appear in either order
+		ttl := int64(-1)
+		for len(tokens) > 0 {
+			word := strings.ToUpper(tokens[0].text)
+			if zoneFileClasses[word] {
+				if word != "IN" {
+					return nil, fmt.Errorf("line %d: unsupported class %s", line.number, word)
+				}
+			} else if value, err := parseZoneFileTTL(word); err == nil && ttl < 0 {
+				ttl = value
+			} else {
+				break
+			}
+			tokens = tokens[1:]
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: record without a type", line.number)
+		}
+
+		switch {
+		case ttl >= 0:
+			lastTTL = ttl
+		case defaultTTL >= 0:
+			ttl = defaultTTL
+		case lastTTL >= 0:
+			ttl = lastTTL
+		default:
+			return nil, fmt.Errorf("line %d: record without a TTL, set one or add a $TTL directive", line.number)
+		}
+
+		entries = append(entries, zoneFileEntry{
+			line:   line.number,
+			owner:  owner,
+			ttl:    ttl,
+			typ:    strings.ToUpper(tokens[0].text),
+			tokens: tokens[1:],
+			origin: origin,
+		})
+	}
+	return entries, nil
+}
+
+// zoneFileLines splits a zone file into logical lines, dropping comments and empty lines
+func zoneFileLines(content string) ([]zoneFileLine, error) {
+	var lines []zoneFileLine
+	var current *zoneFileLine
+	depth := 0
+
+	for number, text := range strings.Split(content, "\n") {
+		text = strings.TrimSuffix(text, "\r")
+		if depth == 0 {
+			current = &zoneFileLine{number: number + 1, inheritsOwner: text != "" && (text[0] == ' ' || text[0] == '\t')}
+		}
+
+		for i := 0; i < len(text); {
+			switch c := text[i]; {
+			case c == ';':
+				i = len(text)
+			case c == ' ' || c == '\t':
+				i++
+			case c == '(':
+				depth++
+				i++
+			case c == ')':
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parenthesis", number+1)
+				}
+				depth--
+				i++
+			case c == '"':
+				var value strings.Builder
+				j := i + 1
+				for ; j < len(text) && text[j] != '"'; j++ {
+					if text[j] == '\\' && j+1 < len(text) {
+						j++
+					}
+					value.WriteByte(text[j])
+				}
+				if j >= len(text) {
+					return nil, fmt.Errorf("line %d: unterminated string", number+1)
+				}
+				current.tokens = append(current.tokens, zoneFileToken{text: value.String(), quoted: true})
+				i = j + 1
+			default:
+				j := i
+				for j < len(text) && !strings.ContainsRune(" \t;()\"", rune(text[j])) {
+					j++
+				}
+				current.tokens = append(current.tokens, zoneFileToken{text: text[i:j]})
+				i = j
+			}
+		}
+
+		if depth == 0 && len(current.tokens) > 0 {
+			lines = append(lines, *current)
+		}
+	}
+
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parenthesis", current.number)
+	}
+	return lines, nil
+}
+
+// parseZoneFileTTL parses a TTL in seconds or with BIND units, e.g. 3600 or 1h30m
+func parseZoneFileTTL(value string) (int64, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return seconds, nil
+	}
+
+	units := map[rune]int64{'S': 1, 'M': 60, 'H': 3600, 'D': 86400, 'W': 604800}
+	var total, number int64
+	digits := false
+	for _, c := range strings.ToUpper(value) {
+		switch {
+		case c >= '0' && c <= '9':
+			number = number*10 + int64(c-'0')
+			digits = true
+		case units[c] > 0 && digits:
+			total += number * units[c]
+			number, digits = 0, false
+		default:
+			return 0, fmt.Errorf("invalid TTL %q", value)
+		}
+	}
+	if value == "" || digits {
+		return 0, fmt.Errorf("invalid TTL %q", value)
+	}
+	return total, nil
+}
+
+// canonicalZoneName returns a zone or domain name lower case and without the trailing dot
+func canonicalZoneName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+// qualifyZoneFileName qualifies a name of a zone file with the origin
+func qualifyZoneFileName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return canonicalZoneName(name)
+	case origin == "":
+		return strings.ToLower(name)
+	default:
+		return strings.ToLower(name) + "." + origin
+	}
+}
+
+// zoneFileNameFields lists the record data fields holding domain names, which are qualified
+var zoneFileNameFields = map[string][]int{
+	"CNAME": {0},
+	"DNAME": {0},
+	"ANAME": {0},
+	"NS":    {0},
+	"PTR":   {0},
+	"MX":    {1},
+	"SRV":   {3},
+	"SOA":   {0, 1},
+}
+
+// zoneFileStringField reports whether a record data field is a character string
+func zoneFileStringField(recordType string, index int) bool {
+	switch recordType {
+	case "TXT", "SPF":
+		return true
+	case "CAA":
+		return index == 2
+	default:
+		return false
+	}
+}
+
+// canonicalZoneFileFields returns the canonical record data fields of a record
+func canonicalZoneFileFields(recordType string, tokens []zoneFileToken, origin string) ([]string, error) {
+	fields := make([]string, len(tokens))
+	for i, token := range tokens {
+		fields[i] = token.text
+	}
+
+	switch recordType {
+	case "A", "AAAA":
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("expected an IP address")
+		}
+		address, err := netip.ParseAddr(fields[0])
+		if err != nil || address.Is4() != (recordType == "A") {
+			return nil, fmt.Errorf("invalid IP address %q", fields[0])
+		}
+		return []string{address.String()}, nil
+	case "TXT", "SPF":
+		// Long texts are split into several strings, which the server joins
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("expected a text")
+		}
+		return []string{strings.Join(fields, "")}, nil
+	case "SOA":
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("expected 7 fields, got %d", len(fields))
+		}
+		for i := 2; i < 7; i++ {
+			value, err := parseZoneFileTTL(fields[i])
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = strconv.FormatInt(value, 10)
+		}
+		// The serial is maintained by the server, so it does not count as a difference
+		fields = append(fields[:2], fields[3:]...)
+	case "CAA":
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("expected flags, tag and value")
+		}
+		fields[1] = strings.ToLower(fields[1])
+	}
+
+	for _, index := range zoneFileNameFields[recordType] {
+		if index >= len(fields) {
+			return nil, fmt.Errorf("expected at least %d fields", index+1)
+		}
+		fields[index] = qualifyZoneFileName(fields[index], origin)
+	}
+	return fields, nil
+}
+
+// zoneFileDiff compares the records of a zone file with the records of the zone. It returns the
+// records missing from the zone or differing from it, and the records of the zone missing from
+// the file, ignoring TTLs. The SOA and the apex NS records are only compared when the file has
+// them, and the records the server maintains for signed zones are ignored.
+func zoneFileDiff(zoneName string, desired, live []zoneFileRecord) (missing, unlisted []zoneFileRecord) {
+	apex := canonicalZoneName(zoneName)
+	desiredSOA, desiredNS := false, false
+	wanted := make(map[string]bool, len(desired))
+	for _, record := range desired {
+		wanted[record.key()] = true
+		desiredSOA = desiredSOA || record.Type == "SOA"
+		desiredNS = desiredNS || (record.Type == "NS" && record.Name == apex)
+	}
+
+	present := make(map[string]bool, len(live))
+	for _, record := range live {
+		if zoneFileServerTypes[record.Type] || (record.Type == "SOA" && !desiredSOA) ||
+			(record.Type == "NS" && record.Name == apex && !desiredNS) {
+			continue
+		}
+		present[record.String()] = true
+		if !wanted[record.key()] && record.Type != "SOA" {
+			unlisted = append(unlisted, record)
+		}
+	}
+
+	for _, record := range desired {
+		if !present[record.String()] {
+			missing = append(missing, record)
+		}
+	}
+	return missing, unlisted
+}
+
+// zoneFileRecordData returns the record data identifying a zone file record for deletion, or
+// false for record types that cannot be deleted from their zone file form
+func zoneFileRecordData(record zoneFileRecord) (client.RecordData, bool) {
+	fields := record.Fields
+	atoi := func(value string) *int64 {
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return &number
+	}
+
+	switch record.Type {
+	case "A":
+		return client.ARecordData{IPAddress: fields[0]}, true
+	case "AAAA":
+		return client.AAAARecordData{IPAddress: fields[0]}, true
+	case "CNAME":
+		return client.CNAMERecordData{CNAME: fields[0]}, true
+	case "NS":
+		return client.NSRecordData{NameServer: fields[0]}, true
+	case "PTR":
+		return client.PTRRecordData{PTRName: fields[0]}, true
+	case "TXT":
+		// The server only deletes a record whose character strings all match
+		if len(record.Strings) > 1 {
+			return client.TXTRecordData{Text: strings.Join(record.Strings, "\n"), SplitText: true}, true
+		}
+		return client.TXTRecordData{Text: fields[0]}, true
+	case "MX":
+		if len(fields) == 2 {
+			return client.MXRecordData{Exchange: fields[1], Preference: atoi(fields[0])}, true
+		}
+	case "SRV":
+		if len(fields) == 4 {
+			return client.SRVRecordData{Target: fields[3], Priority: atoi(fields[0]), Weight: atoi(fields[1]), Port: atoi(fields[2])}, true
+		}
+	case "CAA":
+		if flags := atoi(fields[0]); flags != nil && len(fields) == 3 {
+			return client.CAARecordData{Flags: *flags, Tag: fields[1], Value: fields[2]}, true
+		}
+	}
+	return nil, false
+}
+
+// cloneZoneFile rewrites the zone file of the source zone into a zone file of the target zone.
+// Owner names are moved into the target zone, and names in the record data too when rewriteData
+// is set; otherwise they keep pointing into the source zone. The SOA record and the records the
+// server maintains for signed zones are left out, as the target zone has its own.
+func cloneZoneFile(content, source, target string, rewriteData bool) (string, error) {
+	entries, err := zoneFileEntries(source, content)
+	if err != nil {
+		return "", err
+	}
+
+	source, target = canonicalZoneName(source), canonicalZoneName(target)
+	rename := func(name string) string {
+		switch {
+		case name == source:
+			return target
+		case strings.HasSuffix(name, "."+source):
+			return strings.TrimSuffix(name, source) + target
+		default:
+			return name
+		}
+	}
+
+	var out strings.Builder
+	origin := ""
+	for _, entry := range entries {
+		if entry.typ == "SOA" || zoneFileServerTypes[entry.typ] {
+			continue
+		}
+
+		// Relative names of record types without known name fields stay relative to the origin
+		entryOrigin := entry.origin
+		if rewriteData {
+			entryOrigin = rename(entryOrigin)
+		}
+		if entryOrigin != origin {
+			origin = entryOrigin
+			fmt.Fprintf(&out, "$ORIGIN %s.\n", origin)
+		}
+
+		nameFields := make(map[int]bool)
+		for _, index := range zoneFileNameFields[entry.typ] {
+			nameFields[index] = true
+		}
+
+		fields := make([]string, len(entry.tokens))
+		for i, token := range entry.tokens {
+			switch {
+			case token.quoted:
+				fields[i] = zoneFileQuote(token.text)
+			case nameFields[i]:
+				name := qualifyZoneFileName(token.text, entry.origin)
+				if rewriteData {
+					name = rename(name)
+				}
+				fields[i] = name + "."
+			default:
+				fields[i] = token.text
+			}
+		}
+
+		fmt.Fprintf(&out, "%s. %d IN %s %s\n", rename(entry.owner), entry.ttl, entry.typ, strings.Join(fields, " "))
+	}
+	return out.String(), nil
+}
+
+// zoneFileQuote quotes a character string of a zone file
+func zoneFileQuote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
@@ -0,0 +1,345 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneFileResource{}
+var _ resource.ResourceWithImportState = &ZoneFileResource{}
+var _ resource.ResourceWithValidateConfig = &ZoneFileResource{}
+
+func NewZoneFileResource() resource.Resource {
+	return &ZoneFileResource{}
+}
+
+// ZoneFileResource defines the resource implementation.
+type ZoneFileResource struct {
+	client client.ClientAPI
+}
+
+// ZoneFileResourceModel describes the resource data model.
+type ZoneFileResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Zone                  types.String `tfsdk:"zone"`
+	Content               types.String `tfsdk:"content"`
+	DeleteUnlistedRecords types.Bool   `tfsdk:"delete_unlisted_records"`
+	OverwriteSOASerial    types.Bool   `tfsdk:"overwrite_soa_serial"`
+}
+
+func (r *ZoneFileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_file"
+}
+
+func (r *ZoneFileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the records of a zone from an RFC 1035 (BIND) zone file, e.g. to migrate existing zones wholesale or to manage zones " +
+			"with thousands of records without a resource per record. The file is imported with the zone import API, and on refresh the zone is exported " +
+			"and compared record by record with the file, ignoring formatting, comments, record order and the SOA serial. When the zone differs, " +
+			"`content` is replaced by the exported zone file, so the plan shows the difference. The zone itself must exist, e.g. managed with `technitium_zone`. " +
+			"Supports the `$ORIGIN` and `$TTL` directives, but not `$INCLUDE` or `$GENERATE`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier, the zone name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				MarkdownDescription: "The zone to manage the records of",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The zone file, e.g. read with `file()`. Names without a trailing dot are relative to `$ORIGIN`, or to the zone when the file does not set it",
+				Required:            true,
+			},
+			"delete_unlisted_records": schema.BoolAttribute{
+				MarkdownDescription: "Delete the records of the zone missing from the zone file, so the file manages the zone's entire contents. " +
+					"The SOA record and the records the server maintains for signed zones are never deleted. When false, records dropped from the file " +
+					"are still deleted, but records added outside of Terraform are kept. Defaults to `false`, so records a file does not list survive " +
+					"until it is known to be complete",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"overwrite_soa_serial": schema.BoolAttribute{
+				MarkdownDescription: "Apply the SOA serial of the zone file instead of keeping the serial of the zone. A serial lower than the current one " +
+					"breaks zone transfers to secondaries. Defaults to `false`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *ZoneFileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneFileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Zone.IsUnknown() || data.Content.IsUnknown() || data.Content.IsNull() {
+		return
+	}
+
+	if _, err := parseZoneFile(data.Zone.ValueString(), data.Content.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("content"), "Invalid Zone File", err.Error())
+	}
+}
+
+func (r *ZoneFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Zone
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := data.Zone.ValueString()
+	exists, err := r.client.ZoneExists(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read zone %s: %s", zoneName, err.Error()))
+		return
+	}
+	if !exists {
+		tflog.Debug(ctx, "Zone not found, removing zone file from state", map[string]interface{}{
+			"zone": zoneName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	exported, err := r.client.ExportZone(ctx, zoneName)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to export zone %s: %s", zoneName, err.Error()))
+		return
+	}
+
+	live, err := parseZoneFile(zoneName, exported)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse the exported zone file of %s: %s", zoneName, err.Error()))
+		return
+	}
+
+	// Keep the configured zone file while the zone matches it, so formatting does not show up as a diff
+	desired, err := parseZoneFile(zoneName, data.Content.ValueString())
+	inSync := err == nil && !data.Content.IsNull()
+	if inSync {
+		missing, unlisted := zoneFileDiff(zoneName, desired, live)
+		inSync = len(missing) == 0 && (len(unlisted) == 0 || !data.DeleteUnlistedRecords.ValueBool())
+		if !inSync {
+			tflog.Debug(ctx, "Zone differs from zone file", map[string]interface{}{
+				"zone":     zoneName,
+				"missing":  len(missing),
+				"unlisted": len(unlisted),
+			})
+		}
+	}
+	if !inSync {
+		data.Content = types.StringValue(exported)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The state holds the exported zone file when the zone drifted, which parses just as well
+	previous, err := parseZoneFile(state.Zone.ValueString(), state.Content.ValueString())
+	if err != nil {
+		previous = nil
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data, previous)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneFileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := data.Zone.ValueString()
+	records, err := parseZoneFile(zoneName, data.Content.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Zone File", fmt.Sprintf("Unable to parse the zone file of %s: %s", zoneName, err.Error()))
+		return
+	}
+
+	// The zone needs its SOA and apex NS records, they go away with the zone itself
+	var deletable []zoneFileRecord
+	for _, record := range records {
+		if record.Type == "SOA" || zoneFileServerTypes[record.Type] || (record.Type == "NS" && record.Name == canonicalZoneName(zoneName)) {
+			continue
+		}
+		deletable = append(deletable, record)
+	}
+
+	resp.Diagnostics.Append(r.deleteRecords(ctx, zoneName, deletable)...)
+}
+
+func (r *ZoneFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	zoneName := strings.TrimSuffix(req.ID, ".")
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), zoneName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), zoneName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("delete_unlisted_records"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("overwrite_soa_serial"), false)...)
+}
+
+// apply imports the zone file and deletes the records dropped from it since the previous zone
+// file, and the other records of the zone unless unlisted records are kept
+func (r *ZoneFileResource) apply(ctx context.Context, data *ZoneFileResourceModel, previous []zoneFileRecord) diag.Diagnostics {
+	var diags diag.Diagnostics
+	zoneName := data.Zone.ValueString()
+
+	desired, err := parseZoneFile(zoneName, data.Content.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("content"), "Invalid Zone File", err.Error())
+		return diags
+	}
+
+	// Overwriting replaces the record sets of the file as a whole, so records dropped from a
+	// record set that is still in the file are removed by the import itself
+	if err := r.client.ImportZone(ctx, zoneName, data.Content.ValueString(), client.ImportZoneOptions{
+		Overwrite:          true,
+		OverwriteSOASerial: data.OverwriteSOASerial.ValueBool(),
+	}); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to import the zone file of %s: %s", zoneName, err.Error()))
+		return diags
+	}
+
+	stale := make(map[string]zoneFileRecord)
+	if data.DeleteUnlistedRecords.ValueBool() {
+		exported, err := r.client.ExportZone(ctx, zoneName)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to export zone %s: %s", zoneName, err.Error()))
+			return diags
+		}
+		live, err := parseZoneFile(zoneName, exported)
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to parse the exported zone file of %s: %s", zoneName, err.Error()))
+			return diags
+		}
+		_, unlisted := zoneFileDiff(zoneName, desired, live)
+		for _, record := range unlisted {
+			stale[record.key()] = record
+		}
+	} else {
+		_, dropped := zoneFileDiff(zoneName, desired, previous)
+		for _, record := range dropped {
+			stale[record.key()] = record
+		}
+	}
+
+	records := make([]zoneFileRecord, 0, len(stale))
+	for _, record := range stale {
+		records = append(records, record)
+	}
+	diags.Append(r.deleteRecords(ctx, zoneName, records)...)
+	return diags
+}
+
+// deleteRecords deletes zone file records from the zone, warning about records of types that
+// cannot be deleted from their zone file form
+func (r *ZoneFileResource) deleteRecords(ctx context.Context, zoneName string, records []zoneFileRecord) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var skipped []string
+
+	for _, record := range records {
+		recordData, ok := zoneFileRecordData(record)
+		if !ok {
+			skipped = append(skipped, record.String())
+			continue
+		}
+
+		tflog.Debug(ctx, "Deleting zone file record", map[string]interface{}{
+			"zone":   zoneName,
+			"record": record.String(),
+		})
+		if err := r.client.DeleteRecord(ctx, client.DeleteRecordRequest{Zone: zoneName, Domain: record.Name, Data: recordData}); err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete record %s: %s", record.String(), err.Error()))
+			return diags
+		}
+	}
+
+	if len(skipped) > 0 {
+		diags.AddWarning(
+			"Records Not Deleted",
+			fmt.Sprintf("The following records of zone %s cannot be deleted from their zone file form and were kept, delete them in the web console:\n%s",
+				zoneName, strings.Join(skipped, "\n")),
+		)
+	}
+	return diags
+}
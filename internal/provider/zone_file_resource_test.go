@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestZoneFileResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		r := NewZoneFileResource()
+		var resp resource.MetadataResponse
+		r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+		if resp.TypeName != "technitium_zone_file" {
+			t.Errorf("Expected TypeName to be technitium_zone_file, got %s", resp.TypeName)
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewZoneFileResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "zone", "content", "delete_unlisted_records", "overwrite_soa_serial"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+	})
+}
+
+func TestZoneFileResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	m := mocks.NewClientAPI(t)
+	r := &ZoneFileResource{client: m}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	content := "$TTL 300\nwww A 192.0.2.1\nmail A 192.0.2.2\n"
+	exported := `example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 7 900 300 604800 900
+example.com. 3600 IN NS ns1.example.com.
+www.example.com. 300 IN A 192.0.2.1
+mail.example.com. 300 IN A 192.0.2.2
+`
+
+	// Records of the zone missing from the file are deleted, except the SOA and the apex NS
+	// records when the file does not list them
+	m.On("ImportZone", mock.Anything, "example.com", content, client.ImportZoneOptions{Overwrite: true}).Return(nil).Once()
+	m.On("ExportZone", mock.Anything, "example.com").Return(exported+"old.example.com. 300 IN A 192.0.2.9\n", nil).Once()
+	m.On("DeleteRecord", mock.Anything, client.DeleteRecordRequest{
+		Zone: "example.com", Domain: "old.example.com", Data: client.ARecordData{IPAddress: "192.0.2.9"},
+	}).Return(nil).Once()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(ctx, &ZoneFileResourceModel{
+		ID:                    types.StringUnknown(),
+		Zone:                  types.StringValue("example.com"),
+		Content:               types.StringValue(content),
+		DeleteUnlistedRecords: types.BoolValue(true),
+		OverwriteSOASerial:    types.BoolValue(false),
+	}).HasError())
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+	var state ZoneFileResourceModel
+	require.False(t, resp.State.Get(ctx, &state).HasError())
+	require.Equal(t, "example.com", state.ID.ValueString())
+
+	// A zone matching the file keeps the configured content
+	m.On("ZoneExists", mock.Anything, "example.com").Return(true, nil).Once()
+	m.On("ExportZone", mock.Anything, "example.com").Return(exported, nil).Once()
+
+	readResp := resource.ReadResponse{State: resp.State}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.False(t, readResp.State.Get(ctx, &state).HasError())
+	require.Equal(t, content, state.Content.ValueString())
+
+	// A record added outside of Terraform replaces the content with the exported zone file
+	drifted := exported + "new.example.com. 300 IN A 192.0.2.3\n"
+	m.On("ZoneExists", mock.Anything, "example.com").Return(true, nil).Once()
+	m.On("ExportZone", mock.Anything, "example.com").Return(drifted, nil).Once()
+
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.False(t, readResp.State.Get(ctx, &state).HasError())
+	require.Equal(t, drifted, state.Content.ValueString())
+
+	// A deleted zone removes the resource from the state
+	m.On("ZoneExists", mock.Anything, "example.com").Return(false, nil).Once()
+
+	goneResp := resource.ReadResponse{State: resp.State}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, &goneResp)
+	require.False(t, goneResp.Diagnostics.HasError(), "read diagnostics: %v", goneResp.Diagnostics)
+	require.True(t, goneResp.State.Raw.IsNull())
+
+	// Without pruning, only the records dropped from the file are deleted
+	updated := "$TTL 300\nwww A 192.0.2.1\n"
+	m.On("ImportZone", mock.Anything, "example.com", updated, client.ImportZoneOptions{Overwrite: true, OverwriteSOASerial: true}).Return(nil).Once()
+	m.On("DeleteRecord", mock.Anything, client.DeleteRecordRequest{
+		Zone: "example.com", Domain: "mail.example.com", Data: client.ARecordData{IPAddress: "192.0.2.2"},
+	}).Return(nil).Once()
+
+	updatePlan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, updatePlan.Set(ctx, &ZoneFileResourceModel{
+		ID:                    types.StringValue("example.com"),
+		Zone:                  types.StringValue("example.com"),
+		Content:               types.StringValue(updated),
+		DeleteUnlistedRecords: types.BoolValue(false),
+		OverwriteSOASerial:    types.BoolValue(true),
+	}).HasError())
+
+	updateResp := resource.UpdateResponse{State: resp.State}
+	r.Update(ctx, resource.UpdateRequest{Plan: updatePlan, State: resp.State}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "update diagnostics: %v", updateResp.Diagnostics)
+
+	// Deleting removes the records of the file
+	m.On("DeleteRecord", mock.Anything, client.DeleteRecordRequest{
+		Zone: "example.com", Domain: "www.example.com", Data: client.ARecordData{IPAddress: "192.0.2.1"},
+	}).Return(nil).Once()
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+}
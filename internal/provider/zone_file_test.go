@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	t.Parallel()
+
+	content := `$TTL 1h
+; Managed by Terraform
+@	IN SOA ns1 hostmaster.example.com. (
+		2024010101 ; serial
+		1d 2h 4w 300 )
+	IN	NS	ns1
+www	300	IN	A	192.0.2.10
+	IN 300	AAAA	2001:DB8::0010
+Mail	MX	10 mx.example.net.
+@	TXT	"v=spf1 " "-all"
+@	CAA	0 ISSUE "letsencrypt.org"
+$ORIGIN sub.example.com.
+app	CNAME	www.example.com.
+`
+
+	records, err := parseZoneFile("Example.com.", content)
+	require.NoError(t, err)
+
+	lines := make([]string, len(records))
+	for i, record := range records {
+		lines[i] = record.String()
+	}
+	require.Equal(t, []string{
+		`app.sub.example.com. 3600 IN CNAME www.example.com`,
+		`example.com. 3600 IN CAA 0 issue "letsencrypt.org"`,
+		`example.com. 3600 IN NS ns1.example.com`,
+		`example.com. 3600 IN SOA ns1.example.com hostmaster.example.com 86400 7200 2419200 300`,
+		`example.com. 3600 IN TXT "v=spf1 -all"`,
+		`mail.example.com. 3600 IN MX 10 mx.example.net`,
+		`www.example.com. 300 IN A 192.0.2.10`,
+		`www.example.com. 300 IN AAAA 2001:db8::10`,
+	}, lines)
+
+	// Without $TTL, records inherit the TTL of the previous record
+	records, err = parseZoneFile("example.com", "www 60 A 192.0.2.1\nftp A 192.0.2.2\n")
+	require.NoError(t, err)
+	require.Equal(t, int64(60), records[0].TTL)
+	require.Equal(t, "ftp.example.com", records[0].Name)
+
+	for name, content := range map[string]string{
+		"no TTL":        "www A 192.0.2.1\n",
+		"include":       "$INCLUDE other.zone\n",
+		"parenthesis":   "$TTL 60\n@ SOA ns1 hostmaster ( 1 2 3 4 5\n",
+		"string":        "$TTL 60\n@ TXT \"unterminated\n",
+		"address":       "$TTL 60\nwww A 2001:db8::1\n",
+		"class":         "$TTL 60\nwww CH A 192.0.2.1\n",
+		"no owner":      "$TTL 60\n A 192.0.2.1\n",
+		"invalid TTL":   "$TTL 1x\n",
+		"SOA fields":    "$TTL 60\n@ SOA ns1 hostmaster 1 2 3\n",
+		"missing type":  "$TTL 60\nwww 60 IN\n",
+		"missing value": "$TTL 60\nwww MX 10\n",
+	} {
+		_, err := parseZoneFile("example.com", content)
+		require.Error(t, err, name)
+	}
+}
+
+func TestZoneFileDiff(t *testing.T) {
+	t.Parallel()
+
+	desired, err := parseZoneFile("example.com", "$TTL 300\nwww A 192.0.2.1\nwww A 192.0.2.2\nmail A 192.0.2.3\n")
+	require.NoError(t, err)
+
+	// The SOA and apex NS records missing from the file, server maintained records and formatting
+	// do not count as differences
+	live, err := parseZoneFile("example.com", `example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 42 900 300 604800 900
+example.com. 3600 IN DNSKEY 257 3 13 AwEAAQ==
+example.com. 3600 IN NS ns1.example.com.
+www.example.com. 300 IN A 192.0.2.2
+WWW.example.com. 300 IN A 192.0.2.1
+mail.example.com. 60 IN A 192.0.2.3
+old.example.com. 300 IN A 192.0.2.4
+`)
+	require.NoError(t, err)
+
+	missing, unlisted := zoneFileDiff("example.com", desired, live)
+	require.Len(t, missing, 1)
+	require.Equal(t, "mail.example.com. 300 IN A 192.0.2.3", missing[0].String())
+	require.Len(t, unlisted, 1)
+	require.Equal(t, "old.example.com. 300 IN A 192.0.2.4", unlisted[0].String())
+
+	missing, unlisted = zoneFileDiff("example.com", desired, nil)
+	require.Len(t, missing, 3)
+	require.Empty(t, unlisted)
+}
+
+func TestZoneFileRecordData(t *testing.T) {
+	t.Parallel()
+
+	records, err := parseZoneFile("example.com", `$TTL 300
+@ MX 10 mail
+_sip._tcp SRV 10 20 5060 sip
+@ CAA 0 issue "letsencrypt.org"
+@ TXT "hello"
+long TXT "v=DKIM1; " "p=MIIB"
+@ HINFO "PC" "Linux"
+`)
+	require.NoError(t, err)
+
+	data := make(map[string]client.RecordData)
+	for _, record := range records {
+		recordData, ok := zoneFileRecordData(record)
+		if !ok {
+			require.Equal(t, "HINFO", record.Type)
+			continue
+		}
+		data[record.Name+" "+record.Type] = recordData
+	}
+
+	preference, priority, weight, port := int64(10), int64(10), int64(20), int64(5060)
+	require.Equal(t, client.MXRecordData{Exchange: "mail.example.com", Preference: &preference}, data["example.com MX"])
+	require.Equal(t, client.SRVRecordData{Target: "sip.example.com", Priority: &priority, Weight: &weight, Port: &port}, data["_sip._tcp.example.com SRV"])
+	require.Equal(t, client.CAARecordData{Flags: 0, Tag: "issue", Value: "letsencrypt.org"}, data["example.com CAA"])
+	require.Equal(t, client.TXTRecordData{Text: "hello"}, data["example.com TXT"])
+	// Every character string of the record is matched
+	require.Equal(t, client.TXTRecordData{Text: "v=DKIM1; \np=MIIB", SplitText: true}, data["long.example.com TXT"])
+}
+
+func TestCloneZoneFile(t *testing.T) {
+	t.Parallel()
+
+	content := `$TTL 300
+@ SOA ns1 hostmaster 1 900 300 604800 900
+@ NS ns1
+@ DNSKEY 257 3 13 AwEAAQ==
+@ MX 10 mail.Example.com.
+@ TXT "say \"hi\""
+www CNAME example.net.
+$ORIGIN sub.example.com.
+app SVCB 1 svc
+`
+
+	cloned, err := cloneZoneFile(content, "example.com", "staging.example.com", true)
+	require.NoError(t, err)
+	require.Equal(t, `$ORIGIN staging.example.com.
+staging.example.com. 300 IN NS ns1.staging.example.com.
+staging.example.com. 300 IN MX 10 mail.staging.example.com.
+staging.example.com. 300 IN TXT "say \"hi\""
+www.staging.example.com. 300 IN CNAME example.net.
+$ORIGIN sub.staging.example.com.
+app.sub.staging.example.com. 300 IN SVCB 1 svc
+`, cloned)
+
+	// Without rewriting, the record data keeps pointing into the source zone
+	cloned, err = cloneZoneFile(content, "example.com", "staging.example.com", false)
+	require.NoError(t, err)
+	require.Equal(t, `$ORIGIN example.com.
+staging.example.com. 300 IN NS ns1.example.com.
+staging.example.com. 300 IN MX 10 mail.example.com.
+staging.example.com. 300 IN TXT "say \"hi\""
+www.staging.example.com. 300 IN CNAME example.net.
+$ORIGIN sub.example.com.
+app.sub.staging.example.com. 300 IN SVCB 1 svc
+`, cloned)
+
+	// The cloned zone file parses as the target zone
+	records, err := parseZoneFile("staging.example.com", cloned)
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+}
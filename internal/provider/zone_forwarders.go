@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// zoneForwarders returns the configured forwarders of a forwarder zone, sorted so the zone is
+// created with the same forwarder on every run
+func zoneForwarders(data *ZoneResourceModel) []string {
+	if data.Forwarders.IsNull() || data.Forwarders.IsUnknown() {
+		return nil
+	}
+
+	forwarders := make([]string, 0, len(data.Forwarders.Elements()))
+	for _, element := range data.Forwarders.Elements() {
+		if value, ok := element.(types.String); ok && !value.IsNull() && !value.IsUnknown() {
+			forwarders = append(forwarders, value.ValueString())
+		}
+	}
+	slices.Sort(forwarders)
+	return forwarders
+}
+
+// zoneForwarderRecordData returns the FWD record of a forwarder, using the forwarding settings
+// of the zone
+func zoneForwarderRecordData(data *ZoneResourceModel, forwarder string) client.FWDRecordData {
+	record := client.FWDRecordData{
+		Protocol:      client.ForwarderProtocol(data.Protocol.ValueString()),
+		Forwarder:     forwarder,
+		ProxyType:     client.ProxyType(data.ProxyType.ValueString()),
+		ProxyAddress:  data.ProxyAddress.ValueString(),
+		ProxyUsername: data.ProxyUsername.ValueString(),
+		ProxyPassword: data.ProxyPassword.ValueString(),
+	}
+	if !data.DnssecValidation.IsNull() && !data.DnssecValidation.IsUnknown() {
+		validation := data.DnssecValidation.ValueBool()
+		record.DNSSECValidation = &validation
+	}
+	if !data.ProxyPort.IsNull() && !data.ProxyPort.IsUnknown() {
+		port := data.ProxyPort.ValueInt64()
+		record.ProxyPort = &port
+	}
+	return record
+}
+
+// zoneApexForwarders returns the FWD records at the apex of a forwarder zone
+func (r *ZoneResource) zoneApexForwarders(ctx context.Context, zoneName string) ([]client.DNSRecord, error) {
+	recordsResponse, err := r.client.GetRecords(ctx, zoneName, zoneName, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read forwarders: %w", err)
+	}
+
+	var records []client.DNSRecord
+	for _, record := range recordsResponse.Records {
+		if record.Type == "FWD" {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// syncZoneForwarders adds an FWD record for every configured forwarder missing from the zone
+// apex and deletes the FWD records of forwarders no longer configured
+func (r *ZoneResource) syncZoneForwarders(ctx context.Context, data *ZoneResourceModel) error {
+	forwarders := zoneForwarders(data)
+	if len(forwarders) == 0 {
+		return nil
+	}
+
+	zoneName := data.Name.ValueString()
+	records, err := r.zoneApexForwarders(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+
+	// New records use the TTL of the record the zone was created with
+	var ttl int64
+	present := make(map[string]bool, len(records))
+	for _, record := range records {
+		present[record.RData.Forwarder] = true
+		ttl = int64(record.TTL)
+	}
+
+	for _, forwarder := range forwarders {
+		if present[forwarder] {
+			continue
+		}
+
+		tflog.Debug(ctx, "Adding zone forwarder", map[string]interface{}{
+			"zone":      zoneName,
+			"forwarder": forwarder,
+		})
+		if _, err := r.client.AddRecord(ctx, client.AddRecordRequest{
+			Zone:   zoneName,
+			Domain: zoneName,
+			TTL:    ttl,
+			Data:   zoneForwarderRecordData(data, forwarder),
+		}); err != nil {
+			return fmt.Errorf("failed to add forwarder %s: %w", forwarder, err)
+		}
+	}
+
+	for _, record := range records {
+		if slices.Contains(forwarders, record.RData.Forwarder) {
+			continue
+		}
+
+		tflog.Debug(ctx, "Deleting zone forwarder", map[string]interface{}{
+			"zone":      zoneName,
+			"forwarder": record.RData.Forwarder,
+		})
+		if err := r.client.DeleteRecord(ctx, client.DeleteRecordRequest{
+			Zone:   zoneName,
+			Domain: zoneName,
+			Data: client.FWDRecordData{
+				Protocol:  client.ForwarderProtocol(record.RData.Protocol),
+				Forwarder: record.RData.Forwarder,
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to delete forwarder %s: %w", record.RData.Forwarder, err)
+		}
+	}
+
+	return nil
+}
+
+// managedZoneForwarders returns the FWD records of the forwarders configured on a zone, sorted by
+// forwarder. Without a configured forwarder, e.g. after import, every FWD record is managed.
+func managedZoneForwarders(data *ZoneResourceModel, records []client.DNSRecord) []client.DNSRecord {
+	forwarders := zoneForwarders(data)
+	if forwarder := knownString(data.Forwarder); forwarder != "" {
+		forwarders = []string{forwarder}
+	}
+
+	managed := make([]client.DNSRecord, 0, len(records))
+	for _, record := range records {
+		if len(forwarders) == 0 || slices.Contains(forwarders, record.RData.Forwarder) {
+			managed = append(managed, record)
+		}
+	}
+	slices.SortFunc(managed, func(a, b client.DNSRecord) int {
+		return strings.Compare(a.RData.Forwarder, b.RData.Forwarder)
+	})
+	return managed
+}
+
+// readZoneForwarderSettings sets the protocol, DNSSEC validation and proxy settings of a forwarder
+// zone from the FWD record of its first forwarder, the one the zone was created with. The proxy
+// password is not compared, so it keeps its configured value.
+func readZoneForwarderSettings(data *ZoneResourceModel, records []client.DNSRecord) {
+	managed := managedZoneForwarders(data, records)
+	if len(managed) == 0 {
+		return
+	}
+
+	record := managed[0].RData
+	if record.Protocol != "" {
+		data.Protocol = types.StringValue(record.Protocol)
+	}
+	if record.ProxyType != "" {
+		data.ProxyType = types.StringValue(record.ProxyType)
+	}
+	data.DnssecValidation = types.BoolValue(record.DnssecValidation)
+
+	data.ProxyAddress = optionalStringValue(record.ProxyAddress)
+	data.ProxyUsername = optionalStringValue(record.ProxyUsername)
+	data.ProxyPort = types.Int64Null()
+	if record.ProxyPort != 0 {
+		data.ProxyPort = types.Int64Value(int64(record.ProxyPort))
+	}
+}
+
+// zoneForwarderSettingsChanged reports whether an FWD record differs from the forwarding settings
+// of the zone
+func zoneForwarderSettingsChanged(record client.DNSRecordData, desired client.FWDRecordData) bool {
+	proxyType := client.ProxyType(record.ProxyType)
+	if proxyType == "" {
+		proxyType = client.DefaultProxyType
+	}
+	var port int64
+	if desired.ProxyPort != nil {
+		port = *desired.ProxyPort
+	}
+
+	return client.ForwarderProtocol(record.Protocol) != desired.Protocol ||
+		(desired.DNSSECValidation != nil && record.DnssecValidation != *desired.DNSSECValidation) ||
+		proxyType != desired.ProxyType ||
+		record.ProxyAddress != desired.ProxyAddress ||
+		int64(record.ProxyPort) != port ||
+		record.ProxyUsername != desired.ProxyUsername
+}
+
+// updateZoneForwarderSettings rewrites the FWD records of a forwarder zone whose protocol, DNSSEC
+// validation or proxy settings differ from the configured ones, or all of them when the proxy
+// password changed since the prior state
+func (r *ZoneResource) updateZoneForwarderSettings(ctx context.Context, data, prior *ZoneResourceModel) error {
+	if data.Type.ValueString() != "Forwarder" {
+		return nil
+	}
+
+	zoneName := data.Name.ValueString()
+	records, err := r.zoneApexForwarders(ctx, zoneName)
+	if err != nil {
+		return err
+	}
+
+	passwordChanged := !data.ProxyPassword.Equal(prior.ProxyPassword)
+	for _, record := range managedZoneForwarders(data, records) {
+		desired := zoneForwarderRecordData(data, record.RData.Forwarder)
+		if !passwordChanged && !zoneForwarderSettingsChanged(record.RData, desired) {
+			continue
+		}
+
+		tflog.Debug(ctx, "Updating zone forwarder settings", map[string]interface{}{
+			"zone":      zoneName,
+			"forwarder": record.RData.Forwarder,
+		})
+		if _, err := r.client.UpdateRecord(ctx, client.UpdateRecordRequest{
+			Zone:   zoneName,
+			Domain: zoneName,
+			TTL:    int64(record.TTL),
+			Current: client.FWDRecordData{
+				Protocol:  client.ForwarderProtocol(record.RData.Protocol),
+				Forwarder: record.RData.Forwarder,
+			},
+			New: desired,
+		}); err != nil {
+			return fmt.Errorf("failed to update forwarder %s: %w", record.RData.Forwarder, err)
+		}
+	}
+
+	return nil
+}
+
+// readZoneForwarders reads the forwarding settings of a forwarder zone from the FWD records at the
+// zone apex, along with the forwarders when they are managed
+func (r *ZoneResource) readZoneForwarders(ctx context.Context, data *ZoneResourceModel) error {
+	if data.Type.ValueString() != "Forwarder" {
+		return nil
+	}
+
+	records, err := r.zoneApexForwarders(ctx, data.Name.ValueString())
+	if err != nil {
+		return err
+	}
+	readZoneForwarderSettings(data, records)
+
+	if data.Forwarders.IsNull() {
+		return nil
+	}
+
+	forwarders := make([]string, 0, len(records))
+	for _, record := range records {
+		forwarders = append(forwarders, record.RData.Forwarder)
+	}
+	data.Forwarders = stringSetValue(forwarders)
+	return nil
+}
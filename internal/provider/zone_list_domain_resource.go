@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneListDomainResource{}
+var _ resource.ResourceWithImportState = &ZoneListDomainResource{}
+
+// NewAllowedDomainResource returns the resource managing a domain of the allowed zones
+func NewAllowedDomainResource() resource.Resource {
+	return &ZoneListDomainResource{list: client.AllowedZoneList, typeSuffix: "_allowed_domain"}
+}
+
+// NewBlockedDomainResource returns the resource managing a domain of the blocked zones
+func NewBlockedDomainResource() resource.Resource {
+	return &ZoneListDomainResource{list: client.BlockedZoneList, typeSuffix: "_blocked_domain"}
+}
+
+// ZoneListDomainResource defines the resource implementation shared by the allowed and blocked
+// domain resources, which differ only in the list they manage.
+type ZoneListDomainResource struct {
+	client     client.ClientAPI
+	list       client.ZoneList
+	typeSuffix string
+}
+
+// ZoneListDomainResourceModel describes the resource data model.
+type ZoneListDomainResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Domain types.String `tfsdk:"domain"`
+}
+
+func (r *ZoneListDomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeSuffix
+}
+
+func (r *ZoneListDomainResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	description := "Adds a domain to the allowed zones of the Technitium DNS Server. The domain and all its subdomains are never blocked, " +
+		"even when a blocked zone or block list contains them."
+	if r.list == client.BlockedZoneList {
+		description = "Adds a domain to the blocked zones of the Technitium DNS Server. The domain and all its subdomains are blocked " +
+			"when blocking is enabled, unless an allowed zone contains them."
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: description,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier (the domain name)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain name, in lower case and without a trailing dot",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-z0-9_-]+(\.[a-z0-9_-]+)*$`),
+						"must be a lower case domain name without a trailing dot",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ZoneListDomainResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneListDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneListDomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+
+	tflog.Debug(ctx, "Adding domain to zone list", map[string]interface{}{
+		"list":   string(r.list),
+		"domain": domain,
+	})
+
+	if err := r.client.AddToZoneList(ctx, r.list, domain); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add %s to the %s zones: %s", domain, r.list, err.Error()))
+		return
+	}
+
+	data.ID = types.StringValue(domain)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneListDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneListDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+
+	found, err := r.client.ZoneListContains(ctx, r.list, domain)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the %s zones: %s", r.list, err.Error()))
+		return
+	}
+
+	if !found {
+		tflog.Debug(ctx, "Domain not found in zone list, removing from state", map[string]interface{}{
+			"list":   string(r.list),
+			"domain": domain,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneListDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// The domain is the only attribute and it requires replacement, so there is nothing to update
+	var data ZoneListDomainResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneListDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneListDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+
+	tflog.Debug(ctx, "Deleting domain from zone list", map[string]interface{}{
+		"list":   string(r.list),
+		"domain": domain,
+	})
+
+	if err := r.client.DeleteFromZoneList(ctx, r.list, domain); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s from the %s zones: %s", domain, r.list, err.Error()))
+		return
+	}
+}
+
+func (r *ZoneListDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import using the domain name as the ID
+	domain := normalizeZoneName(req.ID)
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+}
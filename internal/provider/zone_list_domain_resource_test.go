@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestZoneListDomainResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		for expected, r := range map[string]resource.Resource{
+			"technitium_allowed_domain": NewAllowedDomainResource(),
+			"technitium_blocked_domain": NewBlockedDomainResource(),
+		} {
+			var resp resource.MetadataResponse
+			r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+			if resp.TypeName != expected {
+				t.Errorf("Expected TypeName to be %s, got %s", expected, resp.TypeName)
+			}
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewBlockedDomainResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "domain"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+		if !resp.Schema.Attributes["domain"].IsRequired() {
+			t.Error("'domain' attribute should be required")
+		}
+	})
+}
+
+func TestZoneListDomainResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := NewBlockedDomainResource().(*ZoneListDomainResource)
+	r.client = m
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	m.On("AddToZoneList", mock.Anything, client.BlockedZoneList, "ads.example.com").Return(nil)
+	m.On("ZoneListContains", mock.Anything, client.BlockedZoneList, "ads.example.com").Return(false, nil)
+	m.On("DeleteFromZoneList", mock.Anything, client.BlockedZoneList, "ads.example.com").Return(nil)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(context.Background(), &ZoneListDomainResourceModel{
+		ID:     types.StringUnknown(),
+		Domain: types.StringValue("ads.example.com"),
+	}).HasError())
+
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+	var state ZoneListDomainResourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "ads.example.com", state.ID.ValueString())
+
+	// A domain removed outside of Terraform is dropped from the state
+	readResp := resource.ReadResponse{State: resp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: resp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+	require.True(t, readResp.State.Raw.IsNull())
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: resp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+}
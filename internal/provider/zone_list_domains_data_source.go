@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ZoneListDomainsDataSource{}
+
+// NewAllowedDomainsDataSource returns the data source listing the domains of the allowed zones
+func NewAllowedDomainsDataSource() datasource.DataSource {
+	return &ZoneListDomainsDataSource{list: client.AllowedZoneList, typeSuffix: "_allowed_domains"}
+}
+
+// NewBlockedDomainsDataSource returns the data source listing the domains of the blocked zones
+func NewBlockedDomainsDataSource() datasource.DataSource {
+	return &ZoneListDomainsDataSource{list: client.BlockedZoneList, typeSuffix: "_blocked_domains"}
+}
+
+// ZoneListDomainsDataSource defines the data source implementation shared by the allowed and
+// blocked domains data sources.
+type ZoneListDomainsDataSource struct {
+	client     client.ClientAPI
+	list       client.ZoneList
+	typeSuffix string
+}
+
+// ZoneListDomainsDataSourceModel describes the data source data model.
+type ZoneListDomainsDataSourceModel struct {
+	ID      types.String   `tfsdk:"id"`
+	Domains []types.String `tfsdk:"domains"`
+}
+
+func (d *ZoneListDomainsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + d.typeSuffix
+}
+
+func (d *ZoneListDomainsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         fmt.Sprintf("Data source listing the domains of the %s zones", d.list),
+		MarkdownDescription: fmt.Sprintf("Data source listing every domain added to the %s zones of the server, including domains not managed by Terraform.", d.list),
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"domains": schema.ListAttribute{
+				MarkdownDescription: fmt.Sprintf("The domains of the %s zones, sorted by name.", d.list),
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ZoneListDomainsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneListDomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneListDomainsDataSourceModel
+
+	tflog.Debug(ctx, "Reading zone list domains data source", map[string]interface{}{
+		"list": string(d.list),
+	})
+
+	domains, err := d.client.ListZoneListDomains(ctx, d.list)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error reading %s zones", d.list),
+			fmt.Sprintf("Could not list the %s zones: %s", d.list, err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(string(d.list) + "_domains")
+	data.Domains = make([]types.String, 0, len(domains))
+	for _, domain := range domains {
+		data.Domains = append(data.Domains, types.StringValue(domain))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
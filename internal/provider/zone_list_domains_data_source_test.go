@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestZoneListDomainsDataSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		for expected, ds := range map[string]datasource.DataSource{
+			"technitium_allowed_domains": NewAllowedDomainsDataSource(),
+			"technitium_blocked_domains": NewBlockedDomainsDataSource(),
+		} {
+			var resp datasource.MetadataResponse
+			ds.Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+			if resp.TypeName != expected {
+				t.Errorf("Expected TypeName to be %s, got %s", expected, resp.TypeName)
+			}
+		}
+	})
+
+	t.Run("Read", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		d := NewAllowedDomainsDataSource().(*ZoneListDomainsDataSource)
+		d.client = m
+
+		m.On("ListZoneListDomains", mock.Anything, client.AllowedZoneList).Return([]string{"example.com", "example.net"}, nil)
+
+		var schemaResp datasource.SchemaResponse
+		d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+		require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+		input := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, input.Set(context.Background(), &ZoneListDomainsDataSourceModel{}).HasError())
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+		var state ZoneListDomainsDataSourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.Equal(t, "allowed_domains", state.ID.ValueString())
+		require.Len(t, state.Domains, 2)
+		require.Equal(t, "example.net", state.Domains[1].ValueString())
+	})
+}
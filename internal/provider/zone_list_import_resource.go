@@ -0,0 +1,459 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ZoneListImportResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneListImportResource{}
+
+const (
+	// defaultZoneListImportBatchSize keeps each import call small, so the server is not blocked by
+	// a single large request
+	defaultZoneListImportBatchSize = 100
+
+	// maxDomainListSize limits how much of a domain list is downloaded from its URL
+	maxDomainListSize = 64 << 20
+
+	domainListFetchTimeout = 2 * time.Minute
+)
+
+// domainListNamePattern matches the domain names accepted in domain lists
+var domainListNamePattern = regexp.MustCompile(`^[a-z0-9_-]+(\.[a-z0-9_-]+)*$`)
+
+// hostsFileLocalNames are the host names of hosts files that map the local machine, not blocked domains
+var hostsFileLocalNames = map[string]bool{
+	"localhost":             true,
+	"localhost.localdomain": true,
+	"local":                 true,
+	"broadcasthost":         true,
+	"ip6-localhost":         true,
+	"ip6-loopback":          true,
+	"ip6-localnet":          true,
+	"ip6-mcastprefix":       true,
+	"ip6-allnodes":          true,
+	"ip6-allrouters":        true,
+	"ip6-allhosts":          true,
+}
+
+// NewAllowedDomainsImportResource returns the resource importing a domain list into the allowed zones
+func NewAllowedDomainsImportResource() resource.Resource {
+	return &ZoneListImportResource{list: client.AllowedZoneList, typeSuffix: "_allowed_domains_import"}
+}
+
+// NewBlockedDomainsImportResource returns the resource importing a domain list into the blocked zones
+func NewBlockedDomainsImportResource() resource.Resource {
+	return &ZoneListImportResource{list: client.BlockedZoneList, typeSuffix: "_blocked_domains_import"}
+}
+
+// ZoneListImportResource defines the resource implementation shared by the allowed and blocked
+// domains import resources.
+type ZoneListImportResource struct {
+	client     client.ClientAPI
+	list       client.ZoneList
+	typeSuffix string
+}
+
+// ZoneListImportResourceModel describes the resource data model.
+type ZoneListImportResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Content     types.String `tfsdk:"content"`
+	URL         types.String `tfsdk:"url"`
+	BatchSize   types.Int64  `tfsdk:"batch_size"`
+	ContentHash types.String `tfsdk:"content_hash"`
+	Domains     types.Set    `tfsdk:"domains"`
+	DomainCount types.Int64  `tfsdk:"domain_count"`
+}
+
+func (r *ZoneListImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeSuffix
+}
+
+func (r *ZoneListImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Imports a domain list into the %s zones of the Technitium DNS Server. The list is read from `content` or downloaded from `url` "+
+			"when planning, so changes to the list show up as a diff of `domains`, and is imported in batches. Domains missing from the server are imported again, "+
+			"and domains dropped from the list or the list as a whole on destroy are deleted, even when they were also added outside of this resource. "+
+			"Accepts hosts files (`0.0.0.0 ads.example.com`), plain domain lists and Adblock style `||ads.example.com^` rules; comments, local host names "+
+			"and invalid names are ignored. Every domain is kept in the state, so use `technitium_blocklist` for lists with hundreds of thousands of entries.", r.list),
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Resource identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The domain list, e.g. read with `file()`. Exactly one of `content` and `url` must be set",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("url")),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "HTTP or HTTPS URL the provider downloads the domain list from on every plan",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an HTTP or HTTPS URL"),
+				},
+			},
+			"batch_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The number of domains imported per API call. Defaults to `%d`", defaultZoneListImportBatchSize),
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultZoneListImportBatchSize),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the parsed domain list, which changes whenever the list itself changes",
+				Computed:            true,
+			},
+			"domains": schema.SetAttribute{
+				MarkdownDescription: "The domains of the list that were imported",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"domain_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of domains in the list",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ZoneListImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ZoneListImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ZoneListImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Content.IsUnknown() || data.URL.IsUnknown() {
+		return
+	}
+
+	content := data.Content.ValueString()
+	if !data.URL.IsNull() {
+		var err error
+		if content, err = fetchDomainList(ctx, data.URL.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("url"), "Error downloading domain list", err.Error())
+			return
+		}
+	}
+
+	domains := parseDomainList(content)
+	data.ID = types.StringValue(string(r.list) + "_domains_import")
+	data.ContentHash = types.StringValue(domainListHash(domains))
+	data.Domains = stringSetValue(domains)
+	data.DomainCount = types.Int64Value(int64(len(domains)))
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+func (r *ZoneListImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ZoneListImportResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var domains []string
+	resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &domains, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.importDomains(ctx, domains, data.BatchSize.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import the domain list to the %s zones: %s", r.list, err.Error()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneListImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ZoneListImportResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var domains []string
+	resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &domains, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exported, err := r.client.ExportZoneList(ctx, r.list)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read the %s zones: %s", r.list, err.Error()))
+		return
+	}
+
+	// Keep only the domains still on the server, so the next plan imports the missing ones again
+	present := make(map[string]bool, len(exported))
+	for _, domain := range exported {
+		present[domain] = true
+	}
+	remaining := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		if present[domain] {
+			remaining = append(remaining, domain)
+		}
+	}
+
+	if len(remaining) < len(domains) {
+		tflog.Debug(ctx, "Imported domains missing from zone list", map[string]interface{}{
+			"list":    string(r.list),
+			"missing": len(domains) - len(remaining),
+		})
+	}
+	data.Domains = stringSetValue(remaining)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneListImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ZoneListImportResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planned, current []string
+	resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(state.Domains.ElementsAs(ctx, &current, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	added, removed := diffDomains(current, planned)
+
+	if err := r.importDomains(ctx, added, data.BatchSize.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import the domain list to the %s zones: %s", r.list, err.Error()))
+		return
+	}
+
+	if err := r.deleteDomains(ctx, removed); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ZoneListImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ZoneListImportResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var domains []string
+	resp.Diagnostics.Append(data.Domains.ElementsAs(ctx, &domains, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.deleteDomains(ctx, domains); err != nil {
+		resp.Diagnostics.AddError("Client Error", err.Error())
+		return
+	}
+}
+
+// importDomains imports the domains in batches of the given size
+func (r *ZoneListImportResource) importDomains(ctx context.Context, domains []string, batchSize int64) error {
+	if batchSize <= 0 {
+		batchSize = defaultZoneListImportBatchSize
+	}
+
+	for start := 0; start < len(domains); start += int(batchSize) {
+		end := min(start+int(batchSize), len(domains))
+
+		tflog.Debug(ctx, "Importing domains to zone list", map[string]interface{}{
+			"list":  string(r.list),
+			"from":  start,
+			"count": end - start,
+			"total": len(domains),
+		})
+
+		if err := r.client.ImportToZoneList(ctx, r.list, domains[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteDomains deletes the domains one by one, as the API has no bulk delete
+func (r *ZoneListImportResource) deleteDomains(ctx context.Context, domains []string) error {
+	for _, domain := range domains {
+		tflog.Debug(ctx, "Deleting domain from zone list", map[string]interface{}{
+			"list":   string(r.list),
+			"domain": domain,
+		})
+
+		if err := r.client.DeleteFromZoneList(ctx, r.list, domain); err != nil {
+			return fmt.Errorf("unable to delete %s from the %s zones: %w", domain, r.list, err)
+		}
+	}
+	return nil
+}
+
+// diffDomains returns the domains only in planned and only in current, sorted
+func diffDomains(current, planned []string) (added, removed []string) {
+	inCurrent := make(map[string]bool, len(current))
+	for _, domain := range current {
+		inCurrent[domain] = true
+	}
+	inPlanned := make(map[string]bool, len(planned))
+	for _, domain := range planned {
+		inPlanned[domain] = true
+		if !inCurrent[domain] {
+			added = append(added, domain)
+		}
+	}
+	for _, domain := range current {
+		if !inPlanned[domain] {
+			removed = append(removed, domain)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// parseDomainList returns the sorted, unique domains of a hosts file, plain domain list or list
+// of Adblock style rules
+func parseDomainList(content string) []string {
+	seen := map[string]bool{}
+	var domains []string
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "!") {
+			continue
+		}
+
+		// Hosts files map an address to one or more names
+		names := fields[:1]
+		if net.ParseIP(fields[0]) != nil {
+			names = fields[1:]
+		}
+
+		for _, name := range names {
+			name = strings.TrimSuffix(strings.TrimPrefix(name, "||"), "^")
+			name = strings.TrimSuffix(strings.ToLower(name), ".")
+			if seen[name] || hostsFileLocalNames[name] || !domainListNamePattern.MatchString(name) || net.ParseIP(name) != nil {
+				continue
+			}
+			seen[name] = true
+			domains = append(domains, name)
+		}
+	}
+
+	sort.Strings(domains)
+	return domains
+}
+
+// domainListHash returns the SHA-256 hash of a parsed domain list
+func domainListHash(domains []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(domains, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// fetchDomainList downloads a domain list
+func fetchDomainList(ctx context.Context, url string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, domainListFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %s: %w", url, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("could not download %s: HTTP status %d", url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDomainListSize+1))
+	if err != nil {
+		return "", fmt.Errorf("could not download %s: %w", url, err)
+	}
+	if len(body) > maxDomainListSize {
+		return "", fmt.Errorf("the domain list at %s is larger than %d MiB", url, maxDomainListSize>>20)
+	}
+
+	return string(body), nil
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestZoneListImportResource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Metadata", func(t *testing.T) {
+		for expected, r := range map[string]resource.Resource{
+			"technitium_allowed_domains_import": NewAllowedDomainsImportResource(),
+			"technitium_blocked_domains_import": NewBlockedDomainsImportResource(),
+		} {
+			var resp resource.MetadataResponse
+			r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "technitium"}, &resp)
+
+			if resp.TypeName != expected {
+				t.Errorf("Expected TypeName to be %s, got %s", expected, resp.TypeName)
+			}
+		}
+	})
+
+	t.Run("Schema", func(t *testing.T) {
+		r := NewBlockedDomainsImportResource()
+		var resp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		for _, name := range []string{"id", "content", "url", "batch_size", "content_hash", "domains", "domain_count"} {
+			if _, ok := resp.Schema.Attributes[name]; !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+			}
+		}
+	})
+}
+
+func TestParseDomainList(t *testing.T) {
+	t.Parallel()
+
+	content := `# StevenBlack style hosts file
+127.0.0.1 localhost
+::1 localhost ip6-localhost
+0.0.0.0 Ads.Example.com tracker.example.com # inline comment
+0.0.0.0 0.0.0.0
+
+! Adblock rules
+||metrics.example.net^
+
+plain.example.org.
+ads.example.com
+invalid/name.example.com
+`
+	require.Equal(t, []string{
+		"ads.example.com",
+		"metrics.example.net",
+		"plain.example.org",
+		"tracker.example.com",
+	}, parseDomainList(content))
+	require.Empty(t, parseDomainList("# nothing here\n"))
+}
+
+func TestDiffDomains(t *testing.T) {
+	t.Parallel()
+
+	added, removed := diffDomains([]string{"a.com", "b.com"}, []string{"c.com", "b.com"})
+	require.Equal(t, []string{"c.com"}, added)
+	require.Equal(t, []string{"a.com"}, removed)
+}
+
+func TestZoneListImportResourceModifyPlan(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hosts" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("0.0.0.0 b.example.com\n0.0.0.0 a.example.com\n"))
+	}))
+	defer server.Close()
+
+	r := NewBlockedDomainsImportResource().(*ZoneListImportResource)
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	plan := func(url string) tfsdk.Plan {
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		require.False(t, plan.Set(context.Background(), &ZoneListImportResourceModel{
+			ID:          types.StringUnknown(),
+			Content:     types.StringNull(),
+			URL:         types.StringValue(url),
+			BatchSize:   types.Int64Value(100),
+			ContentHash: types.StringUnknown(),
+			Domains:     types.SetUnknown(types.StringType),
+			DomainCount: types.Int64Unknown(),
+		}).HasError())
+		return plan
+	}
+
+	resp := resource.ModifyPlanResponse{Plan: plan(server.URL + "/hosts")}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Plan: resp.Plan}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+
+	var data ZoneListImportResourceModel
+	require.False(t, resp.Plan.Get(context.Background(), &data).HasError())
+	require.Equal(t, stringSetValue([]string{"a.example.com", "b.example.com"}), data.Domains)
+	require.Equal(t, int64(2), data.DomainCount.ValueInt64())
+	require.Equal(t, domainListHash([]string{"a.example.com", "b.example.com"}), data.ContentHash.ValueString())
+
+	resp = resource.ModifyPlanResponse{Plan: plan(server.URL + "/missing")}
+	r.ModifyPlan(context.Background(), resource.ModifyPlanRequest{Plan: resp.Plan}, &resp)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Error downloading domain list", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestZoneListImportResourceCRUD(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	r := NewBlockedDomainsImportResource().(*ZoneListImportResource)
+	r.client = m
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	model := func(domains []string) *ZoneListImportResourceModel {
+		return &ZoneListImportResourceModel{
+			ID:          types.StringValue("blocked_domains_import"),
+			Content:     types.StringValue("unused"),
+			URL:         types.StringNull(),
+			BatchSize:   types.Int64Value(2),
+			ContentHash: types.StringValue(domainListHash(domains)),
+			Domains:     stringSetValue(domains),
+			DomainCount: types.Int64Value(int64(len(domains))),
+		}
+	}
+
+	// Domains are imported in batches
+	m.On("ImportToZoneList", mock.Anything, client.BlockedZoneList, []string{"a.com", "b.com"}).Return(nil).Once()
+	m.On("ImportToZoneList", mock.Anything, client.BlockedZoneList, []string{"c.com"}).Return(nil).Once()
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(context.Background(), model([]string{"a.com", "b.com", "c.com"})).HasError())
+
+	createResp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan}, &createResp)
+	require.False(t, createResp.Diagnostics.HasError(), "create diagnostics: %v", createResp.Diagnostics)
+
+	// Domains deleted outside of Terraform are dropped from the state
+	m.On("ExportZoneList", mock.Anything, client.BlockedZoneList).Return([]string{"a.com", "c.com", "other.com"}, nil).Once()
+
+	readResp := resource.ReadResponse{State: createResp.State}
+	r.Read(context.Background(), resource.ReadRequest{State: createResp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), "read diagnostics: %v", readResp.Diagnostics)
+
+	var state ZoneListImportResourceModel
+	require.False(t, readResp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, stringSetValue([]string{"a.com", "c.com"}), state.Domains)
+
+	// Updating imports the new and missing domains and deletes the dropped ones
+	m.On("ImportToZoneList", mock.Anything, client.BlockedZoneList, []string{"b.com", "d.com"}).Return(nil).Once()
+	m.On("DeleteFromZoneList", mock.Anything, client.BlockedZoneList, "c.com").Return(nil).Once()
+
+	plan = tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(context.Background(), model([]string{"a.com", "b.com", "d.com"})).HasError())
+
+	updateResp := resource.UpdateResponse{State: readResp.State}
+	r.Update(context.Background(), resource.UpdateRequest{Plan: plan, State: readResp.State}, &updateResp)
+	require.False(t, updateResp.Diagnostics.HasError(), "update diagnostics: %v", updateResp.Diagnostics)
+
+	m.On("DeleteFromZoneList", mock.Anything, client.BlockedZoneList, mock.Anything).Return(nil).Times(3)
+
+	deleteResp := resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: updateResp.State}, &deleteResp)
+	require.False(t, deleteResp.Diagnostics.HasError(), "delete diagnostics: %v", deleteResp.Diagnostics)
+}
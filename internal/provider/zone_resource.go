@@ -3,15 +3,21 @@ package provider
 import (
 	"context"
 	"fmt"
-	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -24,6 +30,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ZoneResource{}
 var _ resource.ResourceWithImportState = &ZoneResource{}
+var _ resource.ResourceWithModifyPlan = &ZoneResource{}
+var _ resource.ResourceWithValidateConfig = &ZoneResource{}
+var _ resource.ResourceWithIdentity = &ZoneResource{}
 
 func NewZoneResource() resource.Resource {
 	return &ZoneResource{}
@@ -31,7 +40,7 @@ func NewZoneResource() resource.Resource {
 
 // ZoneResource defines the resource implementation.
 type ZoneResource struct {
-	client *client.Client
+	client client.ClientAPI
 }
 
 // ZoneResourceModel describes the resource data model.
@@ -45,9 +54,21 @@ type ZoneResourceModel struct {
 	ZoneTransferProtocol       types.String `tfsdk:"zone_transfer_protocol"`
 	TsigKeyName                types.String `tfsdk:"tsig_key_name"`
 	ValidateZone               types.Bool   `tfsdk:"validate_zone"`
+	ZoneTransferTsigKeyNames   types.Set    `tfsdk:"zone_transfer_tsig_key_names"`
+	ZoneTransferRequireTsig    types.Bool   `tfsdk:"zone_transfer_require_tsig"`
+	ZoneTransfer               types.String `tfsdk:"zone_transfer"`
+	ZoneTransferNetworkACL     types.List   `tfsdk:"zone_transfer_network_acl"`
+	Notify                     types.String `tfsdk:"notify"`
+	NotifyNameServers          types.Set    `tfsdk:"notify_name_servers"`
+	QueryAccess                types.String `tfsdk:"query_access"`
+	QueryAccessNetworkACL      types.List   `tfsdk:"query_access_network_acl"`
+	Update                     types.String `tfsdk:"update"`
+	UpdateNetworkACL           types.List   `tfsdk:"update_network_acl"`
+	UpdateSecurityPolicies     types.List   `tfsdk:"update_security_policies"`
 	InitializeForwarder        types.Bool   `tfsdk:"initialize_forwarder"`
 	Protocol                   types.String `tfsdk:"protocol"`
 	Forwarder                  types.String `tfsdk:"forwarder"`
+	Forwarders                 types.Set    `tfsdk:"forwarders"`
 	DnssecValidation           types.Bool   `tfsdk:"dnssec_validation"`
 	ProxyType                  types.String `tfsdk:"proxy_type"`
 	ProxyAddress               types.String `tfsdk:"proxy_address"`
@@ -55,6 +76,45 @@ type ZoneResourceModel struct {
 	ProxyUsername              types.String `tfsdk:"proxy_username"`
 	ProxyPassword              types.String `tfsdk:"proxy_password"`
 
+	// Records created immediately after the zone is created
+	BootstrapRecords []ZoneBootstrapRecordModel `tfsdk:"bootstrap_records"`
+
+	// Zone whose records are copied into the zone when it is created
+	CloneFrom          types.String `tfsdk:"clone_from"`
+	CloneRewriteOrigin types.Bool   `tfsdk:"clone_rewrite_origin"`
+
+	// SOA values applied right after the zone is created and whenever they change
+	SoaPrimaryNameServer types.String `tfsdk:"soa_primary_name_server"`
+	SoaResponsiblePerson types.String `tfsdk:"soa_responsible_person"`
+	SoaRefresh           types.Int64  `tfsdk:"soa_refresh"`
+	SoaRetry             types.Int64  `tfsdk:"soa_retry"`
+	SoaExpire            types.Int64  `tfsdk:"soa_expire"`
+	SoaMinimum           types.Int64  `tfsdk:"soa_minimum"`
+
+	// Allow deleting the zone while it still contains data records
+	ForceDestroy types.Bool `tfsdk:"force_destroy"`
+
+	// Records created with the zone from bootstrap_records and clone_from
+	CreatedRecords types.Set `tfsdk:"created_records"`
+
+	// Changing this value bumps the SOA serial and notifies secondaries
+	SerialBumpTrigger types.String `tfsdk:"serial_bump_trigger"`
+	ResyncTrigger     types.String `tfsdk:"resync_trigger"`
+
+	// DNSSEC signing
+	DnssecSigned          types.Bool   `tfsdk:"dnssec_signed"`
+	DnssecAlgorithm       types.String `tfsdk:"dnssec_algorithm"`
+	DnssecHashAlgorithm   types.String `tfsdk:"dnssec_hash_algorithm"`
+	DnssecKSKKeySize      types.Int64  `tfsdk:"dnssec_ksk_key_size"`
+	DnssecZSKKeySize      types.Int64  `tfsdk:"dnssec_zsk_key_size"`
+	DnssecCurve           types.String `tfsdk:"dnssec_curve"`
+	DnssecNxProof         types.String `tfsdk:"dnssec_nx_proof"`
+	DnssecNSEC3Iterations types.Int64  `tfsdk:"dnssec_nsec3_iterations"`
+	DnssecNSEC3SaltLength types.Int64  `tfsdk:"dnssec_nsec3_salt_length"`
+	DnssecDNSKeyTTL       types.Int64  `tfsdk:"dnssec_dnskey_ttl"`
+	DnssecZSKRolloverDays types.Int64  `tfsdk:"dnssec_zsk_rollover_days"`
+	DnssecAllowResign     types.Bool   `tfsdk:"dnssec_allow_resign"`
+
 	// Read-only computed attributes
 	Internal     types.Bool   `tfsdk:"internal"`
 	DnssecStatus types.String `tfsdk:"dnssec_status"`
@@ -62,10 +122,35 @@ type ZoneResourceModel struct {
 	SoaSerial    types.Int64  `tfsdk:"soa_serial"`
 }
 
+// ZoneBootstrapRecordModel describes a record created together with the zone.
+type ZoneBootstrapRecordModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+	Data     types.String `tfsdk:"data"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
 func (r *ZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_zone"
 }
 
+// ZoneIdentityModel describes the identity of a zone, used by import blocks
+type ZoneIdentityModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *ZoneResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The zone name.",
+			},
+		},
+	}
+}
+
 func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Technitium DNS Server zone resource",
@@ -84,6 +169,9 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					zoneNameValidator{},
+				},
 			},
 			"type": schema.StringAttribute{
 				MarkdownDescription: "The type of zone to create. Valid values are: Primary, Secondary, Stub, Forwarder, SecondaryForwarder, Catalog, SecondaryCatalog.",
@@ -96,13 +184,15 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"catalog": schema.StringAttribute{
-				MarkdownDescription: "The name of the catalog zone to become its member zone. Valid only for Primary, Stub, and Forwarder zones.",
-				Optional:            true,
+				MarkdownDescription: "The name of the catalog zone to become its member zone. Valid only for Primary, Stub, and Forwarder zones. " +
+					"The catalog zone must exist and be of type Catalog; see the `technitium_catalog_zones` data source.",
+				Optional: true,
 			},
 			"use_soa_serial_date_scheme": schema.BoolAttribute{
-				MarkdownDescription: "Set to true to enable using date scheme for SOA serial. Valid for Primary, Forwarder, and Catalog zones.",
-				Optional:            true,
-				Computed:            true,
+				MarkdownDescription: "Set to true to enable using date scheme for SOA serial. Valid for Primary, Forwarder, and Catalog zones. " +
+					"Null when not configured and the server does not report it (servers before 11.0).",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.RequiresReplace(),
 					boolplanmodifier.UseStateForUnknown(),
@@ -113,12 +203,12 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 			},
 			"zone_transfer_protocol": schema.StringAttribute{
-				MarkdownDescription: "The zone transfer protocol to be used. Valid values are: Tcp, Tls, Quic. Used by Secondary, SecondaryForwarder, and SecondaryCatalog zones.",
+				MarkdownDescription: "The zone transfer protocol to be used. Valid values are: " + enumDescription(zoneTransferProtocolValues) + ". Used by Secondary, SecondaryForwarder, and SecondaryCatalog zones.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("Tcp"),
+				Default:             stringdefault.StaticString(string(client.DefaultZoneTransferProtocol)),
 				Validators: []validator.String{
-					stringvalidator.OneOf("Tcp", "Tls", "Quic"),
+					enumValidator(zoneTransferProtocolValues),
 				},
 			},
 			"tsig_key_name": schema.StringAttribute{
@@ -126,13 +216,32 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:            true,
 			},
 			"validate_zone": schema.BoolAttribute{
-				MarkdownDescription: "Set to true to enable ZONEMD validation. Valid only for Secondary zones.",
-				Optional:            true,
-				Computed:            true,
+				MarkdownDescription: "Set to true to enable ZONEMD validation. Valid only for Secondary zones. " +
+					"Null when not configured and the server does not report it, e.g. for other zone types.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"zone_transfer_tsig_key_names": schema.SetAttribute{
+				MarkdownDescription: "The TSIG key names authorized to transfer the zone out. When set, zone transfer requests must be signed with one of these keys, " +
+					"in addition to being allowed by the zone transfer access control list. An empty set removes the TSIG requirement. " +
+					"Valid only for Primary, Secondary, Forwarder, and Catalog zones.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"zone_transfer_require_tsig": schema.BoolAttribute{
+				MarkdownDescription: "Whether zone transfers out of this zone must be TSIG authenticated, so the transfer security posture can be asserted " +
+					"even when the zone transfer access control list is broad. Setting it to true requires `zone_transfer_tsig_key_names`; " +
+					"setting it to false removes any authorized key names. When not set, it reports whether key names are configured on the server.",
+				Optional: true,
+				Computed: true,
+			},
 			"initialize_forwarder": schema.BoolAttribute{
 				MarkdownDescription: "Set to true to initialize the Conditional Forwarder zone with an FWD record. Valid for Forwarder zones.",
 				Optional:            true,
@@ -142,17 +251,32 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"protocol": schema.StringAttribute{
-				MarkdownDescription: "The DNS transport protocol to be used by the Conditional Forwarder zone. Valid values are: Udp, Tcp, Tls, Https, Quic.",
+				MarkdownDescription: "The DNS transport protocol to be used by the Conditional Forwarder zone. Valid values are: " + enumDescription(forwarderProtocolValues) + ".",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("Udp"),
+				Default:             stringdefault.StaticString(string(client.DefaultForwarderProtocol)),
 				Validators: []validator.String{
-					stringvalidator.OneOf("Udp", "Tcp", "Tls", "Https", "Quic"),
+					enumValidator(forwarderProtocolValues),
 				},
 			},
 			"forwarder": schema.StringAttribute{
 				MarkdownDescription: "The address of the DNS server to be used as a forwarder. Use 'this-server' to forward internally. Required for Conditional Forwarder zones.",
 				Optional:            true,
+				Validators: []validator.String{
+					forwarderAddressValidator{},
+					stringvalidator.ConflictsWith(path.MatchRoot("forwarders")),
+				},
+			},
+			"forwarders": schema.SetAttribute{
+				MarkdownDescription: "The addresses of the DNS servers to be used as forwarders, each managed as an FWD record at the zone apex with the zone's protocol, " +
+					"DNSSEC validation and proxy settings. Forwarders added or removed outside of Terraform show up as drift; the order does not matter. " +
+					"Use instead of `forwarder` for Conditional Forwarder zones with several upstream servers.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(forwarderAddressValidator{}),
+				},
 			},
 			"dnssec_validation": schema.BoolAttribute{
 				MarkdownDescription: "Set to true to indicate if DNSSEC validation must be done. Used with Conditional Forwarder zones.",
@@ -163,12 +287,12 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"proxy_type": schema.StringAttribute{
-				MarkdownDescription: "The type of proxy for conditional forwarding. Valid values are: NoProxy, DefaultProxy, Http, Socks5.",
+				MarkdownDescription: "The type of proxy for conditional forwarding. Valid values are: " + enumDescription(proxyTypeValues) + ".",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("DefaultProxy"),
+				Default:             stringdefault.StaticString(string(client.DefaultProxyType)),
 				Validators: []validator.String{
-					stringvalidator.OneOf("NoProxy", "DefaultProxy", "Http", "Socks5"),
+					enumValidator(proxyTypeValues),
 				},
 			},
 			"proxy_address": schema.StringAttribute{
@@ -178,6 +302,9 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"proxy_port": schema.Int64Attribute{
 				MarkdownDescription: "The proxy server port to use when proxy_type is configured.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					uint16Validator(),
+				},
 			},
 			"proxy_username": schema.StringAttribute{
 				MarkdownDescription: "The proxy server username to use when proxy_type is configured.",
@@ -189,6 +316,90 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Sensitive:           true,
 			},
 
+			"bootstrap_records": schema.ListNestedAttribute{
+				MarkdownDescription: "Standard records (e.g. NS targets, apex A/AAAA, MX set) created right after the zone is created. " +
+					"If any record fails to be created, the zone is deleted again so the apply can be retried cleanly. " +
+					"These records are only applied on zone creation: later changes to them are stored in state but not applied, with a warning at plan time. " +
+					"Manage records that change over time with `technitium_dns_record`.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The record name relative to the zone (e.g., 'www'). Use '@' for the zone apex.",
+							Required:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The DNS record type. Valid values are: A, AAAA, CNAME, MX, TXT, NS, PTR.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("A", "AAAA", "CNAME", "MX", "TXT", "NS", "PTR"),
+							},
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "Time-to-live value in seconds.",
+							Required:            true,
+							Validators: []validator.Int64{
+								ttlValidator(),
+							},
+						},
+						"data": schema.StringAttribute{
+							MarkdownDescription: "Record data (IP address for A/AAAA, domain for CNAME/MX/NS/PTR, text for TXT).",
+							Required:            true,
+						},
+						"priority": schema.Int64Attribute{
+							MarkdownDescription: "Preference value. Required for MX records and only used with them.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								uint16Validator(),
+							},
+						},
+					},
+				},
+			},
+
+			"clone_from": schema.StringAttribute{
+				MarkdownDescription: "Name of an existing zone on the server whose records are copied into the zone when it is created, e.g. to create a staging copy of a production zone. " +
+					"The source zone is exported and imported into the new zone, except for its SOA record and the records of DNSSEC signing; the apex NS records of the source zone replace the generated ones. " +
+					"Bootstrap records and the initial SOA values are applied after the copy. Only applied on zone creation; changing it later warns and leaves the zone as is. Valid for Primary and Forwarder zones. " +
+					"The copied records are listed in `created_records`, so they do not block deleting the zone.",
+				Optional: true,
+			},
+			"clone_rewrite_origin": schema.BoolAttribute{
+				MarkdownDescription: "Rewrite names of the source zone in the record data of copied records, e.g. CNAME and MX targets, into names of the new zone. " +
+					"When false, they keep pointing into the source zone. Owner names are always moved into the new zone. Only used with `clone_from`. Defaults to true.",
+				Optional: true,
+			},
+
+			"force_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Allow the zone to be deleted while it still contains records other than the apex SOA and NS records " +
+					"and the records created with it (see `created_records`). " +
+					"When false, destroying a primary or forwarder zone that still holds other data records fails instead of silently removing them. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"created_records": schema.SetAttribute{
+				MarkdownDescription: "The records created with the zone from `bootstrap_records` and `clone_from`, as `name TYPE data`. " +
+					"They are deleted with the zone without setting `force_destroy`.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"serial_bump_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value that forces a SOA serial increment whenever it changes, which makes the server send NOTIFY to its secondaries. " +
+					"Useful after out-of-band fixes or to force downstream refreshes during migrations (e.g. set it to a timestamp or a migration ticket). " +
+					"Only supported for Primary, Forwarder and Catalog zones.",
+				Optional: true,
+			},
+			"resync_trigger": schema.StringAttribute{
+				MarkdownDescription: "Arbitrary value that makes the server transfer the zone from its primary again whenever it changes, " +
+					"e.g. after the primary was restored from a backup. Only supported for Secondary and Stub zones.",
+				Optional: true,
+			},
+
 			// Computed attributes
 			"internal": schema.BoolAttribute{
 				MarkdownDescription: "Indicates if this is an internal zone.",
@@ -205,8 +416,10 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				},
 			},
 			"disabled": schema.BoolAttribute{
-				MarkdownDescription: "Indicates if the zone is disabled.",
-				Computed:            true,
+				MarkdownDescription: "Whether the zone is disabled. A disabled zone is not served, e.g. during a maintenance window. " +
+					"Defaults to the current state of the zone, new zones are enabled.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.Bool{
 					boolplanmodifier.UseStateForUnknown(),
 				},
@@ -217,6 +430,16 @@ func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 		},
 	}
+
+	for name, attribute := range zoneAccessAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range zoneDNSSECAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range zoneSOAAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
 }
 
 func (r *ZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -251,6 +474,15 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		"type": data.Type.ValueString(),
 	})
 
+	if err := r.requireZoneCatalog(ctx, &data, types.StringNull()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("catalog"),
+			"Invalid catalog zone",
+			fmt.Sprintf("Could not add zone %s to its catalog: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
 	// Create zone using the API
 	if err := r.createZone(ctx, &data); err != nil {
 		resp.Diagnostics.AddError(
@@ -260,6 +492,54 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	// Initialize the zone: copy the source zone, apply the forwarders, the initial SOA/NS naming,
+	// the bootstrap records and the zone options, sign and disable it, rolling back the zone if any
+	// step fails
+	cloned, err := r.cloneZone(ctx, &data)
+	if err == nil {
+		err = r.syncZoneForwarders(ctx, &data)
+	}
+	if err == nil {
+		err = r.applyInitialSOA(ctx, &data)
+	}
+	if err == nil {
+		err = r.createBootstrapRecords(ctx, &data)
+	}
+	if err == nil {
+		data.CreatedRecords, err = createdZoneRecords(&data, cloned)
+	}
+	if err == nil {
+		// Zone transfer and notify settings can only be set through the zone options
+		options := zoneTransferTsigOptions(&data)
+		for key, value := range zoneAccessOptions(&data) {
+			options[key] = value
+		}
+		if len(options) > 0 {
+			err = r.client.SetZoneOptions(ctx, data.Name.ValueString(), options)
+		}
+	}
+	if err == nil {
+		err = r.signZone(ctx, &data)
+	}
+	if err == nil && data.Disabled.ValueBool() {
+		err = r.client.DisableZone(ctx, data.Name.ValueString())
+	}
+	if err != nil {
+		if deleteErr := r.deleteZone(ctx, data.Name.ValueString()); deleteErr != nil {
+			tflog.Warn(ctx, "Failed to roll back zone after its initialization failed", map[string]interface{}{
+				"name":  data.Name.ValueString(),
+				"cause": err.Error(),
+				"error": deleteErr.Error(),
+			})
+		}
+
+		resp.Diagnostics.AddError(
+			"Error initializing zone",
+			fmt.Sprintf("Could not initialize zone %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
 	// Set the ID for the resource (zone name serves as the ID)
 	data.ID = data.Name
 
@@ -275,9 +555,13 @@ func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, r
 	tflog.Debug(ctx, "Created zone successfully", map[string]interface{}{
 		"name": data.Name.ValueString(),
 	})
+	reportOperation(ctx, r.client, "zones", client.OperationCreated)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, ZoneIdentityModel{Name: data.Name})...)
+	}
 }
 
 func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -306,6 +590,9 @@ func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, ZoneIdentityModel{Name: data.Name})...)
+	}
 }
 
 func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -321,6 +608,20 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		"name": data.Name.ValueString(),
 	})
 
+	priorCatalog := types.StringNull()
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("catalog"), &priorCatalog)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := r.requireZoneCatalog(ctx, &data, priorCatalog); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("catalog"),
+			"Invalid catalog zone",
+			fmt.Sprintf("Could not add zone %s to its catalog: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
 	// Update zone options using the API
 	if err := r.updateZone(ctx, &data); err != nil {
 		resp.Diagnostics.AddError(
@@ -330,6 +631,84 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	if err := r.syncZoneForwarders(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating zone forwarders",
+			fmt.Sprintf("Could not update the forwarders of zone %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	var prior ZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.updateZoneForwarderSettings(ctx, &data, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating zone forwarders",
+			fmt.Sprintf("Could not update the forwarding settings of zone %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if err := r.updateZoneSOA(ctx, &data, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating zone SOA record",
+			fmt.Sprintf("Could not update the SOA record of zone %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if err := r.updateZoneSigning(ctx, &data, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating zone DNSSEC signing",
+			fmt.Sprintf("Could not update the DNSSEC signing of zone %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	if err := r.updateZoneDisabled(ctx, &data, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating zone",
+			fmt.Sprintf("Could not enable or disable zone %s: %s", data.Name.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Bump the SOA serial when the trigger changed so secondaries refresh the zone
+	if !data.SerialBumpTrigger.IsNull() && !data.SerialBumpTrigger.Equal(prior.SerialBumpTrigger) {
+		serial, err := r.client.BumpZoneSerial(ctx, data.Name.ValueString(), data.UseSoaSerialDateScheme.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error bumping zone SOA serial",
+				fmt.Sprintf("Could not bump the SOA serial of zone %s: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		tflog.Info(ctx, "Bumped zone SOA serial", map[string]interface{}{
+			"name":   data.Name.ValueString(),
+			"serial": serial,
+		})
+	}
+
+	// Resync the zone when the trigger changed so it is transferred from the primary again
+	if !data.ResyncTrigger.IsNull() && !data.ResyncTrigger.Equal(prior.ResyncTrigger) {
+		if err := r.client.ResyncZone(ctx, data.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error resyncing zone",
+				fmt.Sprintf("Could not resync zone %s: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		tflog.Info(ctx, "Resynced zone", map[string]interface{}{
+			"name": data.Name.ValueString(),
+		})
+	}
+
 	// Read the zone back to get updated values
 	if err := r.readZone(ctx, &data); err != nil {
 		resp.Diagnostics.AddError(
@@ -338,9 +717,13 @@ func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		)
 		return
 	}
+	reportOperation(ctx, r.client, "zones", client.OperationUpdated)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Identity != nil {
+		resp.Diagnostics.Append(resp.Identity.Set(ctx, ZoneIdentityModel{Name: data.Name})...)
+	}
 }
 
 func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -356,6 +739,28 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		"name": data.Name.ValueString(),
 	})
 
+	// Refuse to delete a zone that still holds data records unless force_destroy is set
+	if !data.ForceDestroy.ValueBool() {
+		remaining, err := r.remainingZoneRecords(ctx, &data)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error deleting zone",
+				fmt.Sprintf("Could not list records of zone %s before deletion: %s", data.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		if len(remaining) > 0 {
+			resp.Diagnostics.AddError(
+				"Zone still contains records",
+				fmt.Sprintf("Zone %s still contains %d record(s) not created with the zone, e.g. %s. "+
+					"Remove the records or set force_destroy = true to delete the zone anyway.",
+					data.Name.ValueString(), len(remaining), describeZoneRecords(remaining, 5)),
+			)
+			return
+		}
+	}
+
 	// Delete zone using the API
 	if err := r.deleteZone(ctx, data.Name.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
@@ -368,100 +773,556 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	tflog.Debug(ctx, "Deleted zone successfully", map[string]interface{}{
 		"name": data.Name.ValueString(),
 	})
+	reportOperation(ctx, r.client, "zones", client.OperationDeleted)
+}
+
+// ValidateConfig checks the bootstrap records the same way record resources are checked, so an
+// invalid record fails the plan instead of rolling back the zone during apply
+func (r *ZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var records []ZoneBootstrapRecordModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bootstrap_records"), &records)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, record := range records {
+		if record.Type.IsUnknown() {
+			continue
+		}
+
+		if !record.Priority.IsNull() && record.Type.ValueString() != "MX" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bootstrap_records").AtListIndex(i).AtName("priority"),
+				"Invalid bootstrap record",
+				fmt.Sprintf("priority is only valid for MX records, not for %s records", record.Type.ValueString()),
+			)
+		}
+
+		err := (&DNSRecordResource{}).validateRecord(&DNSRecordResourceModel{
+			Type:     record.Type,
+			Data:     record.Data,
+			Priority: record.Priority,
+		})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bootstrap_records").AtListIndex(i),
+				"Invalid bootstrap record",
+				err.Error(),
+			)
+		}
+	}
+}
+
+func (r *ZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the zone is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Reject features the connected server version does not support before anything is applied
+	switch data.Type.ValueString() {
+	case "Catalog", "SecondaryCatalog":
+		requireServerFeature(ctx, r.client, client.FeatureCatalogZones, path.Root("type"), &resp.Diagnostics)
+	case "SecondaryForwarder":
+		requireServerFeature(ctx, r.client, client.FeatureSecondaryForwarderZones, path.Root("type"), &resp.Diagnostics)
+	}
+	if !data.Catalog.IsNull() && !data.Catalog.IsUnknown() && data.Catalog.ValueString() != "" {
+		requireServerFeature(ctx, r.client, client.FeatureCatalogZones, path.Root("catalog"), &resp.Diagnostics)
+	}
+
+	// Check a new catalog against the zones on the server, so a typo or a zone of the wrong type
+	// fails the plan instead of the apply
+	priorCatalog := types.StringNull()
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("catalog"), &priorCatalog)...)
+	}
+	if !sameZoneName(zoneCatalog(&data), knownString(priorCatalog)) {
+		validateZoneCatalog(ctx, r.client, &data, &resp.Diagnostics)
+	}
+
+	if data.ZoneTransferProtocol.ValueString() == string(client.ZoneTransferProtocolQuic) {
+		requireServerFeature(ctx, r.client, client.FeatureQUIC, path.Root("zone_transfer_protocol"), &resp.Diagnostics)
+	}
+	if data.Type.ValueString() == "Forwarder" && data.Protocol.ValueString() == string(client.ForwarderProtocolQuic) {
+		requireServerFeature(ctx, r.client, client.FeatureQUIC, path.Root("protocol"), &resp.Diagnostics)
+	}
+	if data.UseSoaSerialDateScheme.ValueBool() {
+		requireServerFeature(ctx, r.client, client.FeatureSoaSerialDateScheme, path.Root("use_soa_serial_date_scheme"), &resp.Diagnostics)
+	}
+	if data.ValidateZone.ValueBool() {
+		requireServerFeature(ctx, r.client, client.FeatureZoneValidation, path.Root("validate_zone"), &resp.Diagnostics)
+	}
+
+	// Secondary and stub zones take their SOA from the primary server. The SOA values are read
+	// back for every zone, so only configured values are rejected.
+	if !data.Type.IsUnknown() {
+		switch data.Type.ValueString() {
+		case "Primary", "Forwarder", "Catalog":
+		default:
+			for _, name := range []string{"soa_primary_name_server", "soa_responsible_person", "soa_refresh", "soa_retry", "soa_expire", "soa_minimum"} {
+				var value attr.Value
+				resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+				if value != nil && !value.IsNull() {
+					resp.Diagnostics.AddAttributeError(
+						path.Root(name),
+						"Unsupported zone type",
+						fmt.Sprintf("%s is only supported for Primary, Forwarder and Catalog zones, not %s zones.", name, data.Type.ValueString()),
+					)
+				}
+			}
+		}
+	}
+
+	// Only conditional forwarder zones forward to their FWD records
+	if !data.Forwarders.IsNull() && !data.Type.IsUnknown() && data.Type.ValueString() != "Forwarder" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("forwarders"),
+			"Unsupported zone type",
+			fmt.Sprintf("forwarders is only supported for Forwarder zones, not %s zones.", data.Type.ValueString()),
+		)
+	}
+
+	// Records can only be copied into zones holding records of their own
+	if !data.CloneFrom.IsNull() && !data.Type.IsUnknown() {
+		switch data.Type.ValueString() {
+		case "Primary", "Forwarder":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("clone_from"),
+				"Unsupported zone type",
+				fmt.Sprintf("clone_from is only supported for Primary and Forwarder zones, not %s zones.", data.Type.ValueString()),
+			)
+		}
+	}
+	if !data.CloneRewriteOrigin.IsNull() && data.CloneFrom.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("clone_rewrite_origin"),
+			"Missing clone_from",
+			"clone_rewrite_origin is only used when clone_from is set.",
+		)
+	}
+
+	// Bootstrap records and the clone source are only applied when the zone is created
+	if !req.State.Raw.IsNull() {
+		var planned, prior types.List
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("bootstrap_records"), &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("bootstrap_records"), &prior)...)
+		if !planned.IsUnknown() && !planned.Equal(prior) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("bootstrap_records"),
+				"Bootstrap records not applied",
+				fmt.Sprintf("bootstrap_records are only created with zone %s, so the changed records are not applied to the existing zone. "+
+					"Manage the records with technitium_dns_record instead, or replace the zone to create them again.", data.Name.ValueString()),
+			)
+		}
+
+		var priorSource types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("clone_from"), &priorSource)...)
+		if !data.CloneFrom.IsUnknown() && !data.CloneFrom.Equal(priorSource) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("clone_from"),
+				"Clone source not applied",
+				fmt.Sprintf("clone_from is only copied when zone %s is created, so the changed source is not copied into the existing zone. "+
+					"Replace the zone to copy the new source.", data.Name.ValueString()),
+			)
+		}
+	}
+
+	r.modifyZoneTransferTsigPlan(ctx, req, resp, &data)
+	r.modifyZoneAccessPlan(ctx, req, resp, &data)
+	r.modifyZoneDNSSECPlan(ctx, req, resp, &data)
+
+	// Only zones hosted authoritatively by this server have a SOA serial it can bump
+	if !data.SerialBumpTrigger.IsNull() && !data.Type.IsUnknown() {
+		switch data.Type.ValueString() {
+		case "Primary", "Forwarder", "Catalog":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("serial_bump_trigger"),
+				"Unsupported zone type",
+				fmt.Sprintf("serial_bump_trigger is only supported for Primary, Forwarder and Catalog zones, not %s zones.", data.Type.ValueString()),
+			)
+		}
+	}
+
+	// Only zones transferred from a primary can be resynced
+	if !data.ResyncTrigger.IsNull() && !data.Type.IsUnknown() {
+		switch data.Type.ValueString() {
+		case "Secondary", "Stub":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("resync_trigger"),
+				"Unsupported zone type",
+				fmt.Sprintf("resync_trigger is only supported for Secondary and Stub zones, not %s zones.", data.Type.ValueString()),
+			)
+		}
+	}
+}
+
+// modifyZoneTransferTsigPlan validates the zone transfer TSIG settings and keeps the planned key
+// names and requirement flag in step, so the state after apply matches the plan
+func (r *ZoneResource) modifyZoneTransferTsigPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, data *ZoneResourceModel) {
+	var configNames types.Set
+	var configRequire types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone_transfer_tsig_key_names"), &configNames)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone_transfer_require_tsig"), &configRequire)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namesConfigured := !configNames.IsNull() && !configNames.IsUnknown() && len(configNames.Elements()) > 0
+	if (namesConfigured || configRequire.ValueBool()) && !data.Type.IsUnknown() {
+		switch data.Type.ValueString() {
+		case "Primary", "Secondary", "Forwarder", "Catalog":
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("zone_transfer_tsig_key_names"),
+				"Unsupported zone type",
+				fmt.Sprintf("Zone transfer TSIG key names are only supported for Primary, Secondary, Forwarder and Catalog zones, not %s zones.", data.Type.ValueString()),
+			)
+			return
+		}
+	}
+
+	if configRequire.IsNull() || configRequire.IsUnknown() {
+		// Report whether key names will be configured once applied
+		require := types.BoolUnknown()
+		if !data.ZoneTransferTsigKeyNames.IsUnknown() {
+			require = types.BoolValue(!data.ZoneTransferTsigKeyNames.IsNull() && len(data.ZoneTransferTsigKeyNames.Elements()) > 0)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone_transfer_require_tsig"), require)...)
+		return
+	}
+
+	if configRequire.ValueBool() {
+		if !configNames.IsUnknown() && !namesConfigured {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("zone_transfer_require_tsig"),
+				"Missing zone transfer TSIG key names",
+				"zone_transfer_require_tsig = true requires at least one key name in zone_transfer_tsig_key_names.",
+			)
+		}
+		return
+	}
+
+	if namesConfigured {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("zone_transfer_require_tsig"),
+			"Conflicting zone transfer TSIG settings",
+			"zone_transfer_require_tsig = false cannot be combined with zone_transfer_tsig_key_names; remove the key names or require TSIG.",
+		)
+		return
+	}
+
+	// Not requiring TSIG removes any key names configured on the server
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone_transfer_tsig_key_names"), stringSetValue(nil))...)
 }
 
 func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Set both ID and name to the import ID (zone name)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+	// Import blocks may identify the zone by its identity instead of an ID
+	zoneName := req.ID
+	if zoneName == "" && req.Identity != nil {
+		var identity ZoneIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		zoneName = identity.Name.ValueString()
+	}
+
+	// Set both ID and name to the zone name
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), zoneName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), zoneName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
+
+// updateZoneDisabled enables or disables the zone when the planned state differs from the prior state
+func (r *ZoneResource) updateZoneDisabled(ctx context.Context, data, prior *ZoneResourceModel) error {
+	if data.Disabled.IsNull() || data.Disabled.IsUnknown() || data.Disabled.Equal(prior.Disabled) {
+		return nil
+	}
+
+	tflog.Info(ctx, "Changing zone state", map[string]interface{}{
+		"name":     data.Name.ValueString(),
+		"disabled": data.Disabled.ValueBool(),
+	})
+
+	if data.Disabled.ValueBool() {
+		return r.client.DisableZone(ctx, data.Name.ValueString())
+	}
+	return r.client.EnableZone(ctx, data.Name.ValueString())
 }
 
 // createZone creates a new zone via the API
 func (r *ZoneResource) createZone(ctx context.Context, data *ZoneResourceModel) error {
-	params := url.Values{}
-	params.Set("zone", data.Name.ValueString())
-	params.Set("type", data.Type.ValueString())
+	request := client.NewRequest().Path("/api/zones/create").
+		Param("zone", data.Name.ValueString()).
+		Param("type", data.Type.ValueString())
 
 	// Add optional parameters based on zone type and configuration
 	if !data.Catalog.IsNull() && !data.Catalog.IsUnknown() {
-		params.Set("catalog", data.Catalog.ValueString())
+		request.Param("catalog", data.Catalog.ValueString())
 	}
 
 	if !data.UseSoaSerialDateScheme.IsNull() && !data.UseSoaSerialDateScheme.IsUnknown() {
-		params.Set("useSoaSerialDateScheme", fmt.Sprintf("%t", data.UseSoaSerialDateScheme.ValueBool()))
+		request.BoolParam("useSoaSerialDateScheme", data.UseSoaSerialDateScheme.ValueBool())
 	}
 
 	if !data.PrimaryNameServerAddresses.IsNull() && !data.PrimaryNameServerAddresses.IsUnknown() {
-		params.Set("primaryNameServerAddresses", data.PrimaryNameServerAddresses.ValueString())
+		request.Param("primaryNameServerAddresses", data.PrimaryNameServerAddresses.ValueString())
 	}
 
 	if !data.ZoneTransferProtocol.IsNull() && !data.ZoneTransferProtocol.IsUnknown() {
-		params.Set("zoneTransferProtocol", data.ZoneTransferProtocol.ValueString())
+		request.Param("zoneTransferProtocol", data.ZoneTransferProtocol.ValueString())
 	}
 
 	if !data.TsigKeyName.IsNull() && !data.TsigKeyName.IsUnknown() {
-		params.Set("tsigKeyName", data.TsigKeyName.ValueString())
+		request.Param("tsigKeyName", data.TsigKeyName.ValueString())
 	}
 
 	if !data.ValidateZone.IsNull() && !data.ValidateZone.IsUnknown() {
-		params.Set("validateZone", fmt.Sprintf("%t", data.ValidateZone.ValueBool()))
+		request.BoolParam("validateZone", data.ValidateZone.ValueBool())
 	}
 
 	if !data.InitializeForwarder.IsNull() && !data.InitializeForwarder.IsUnknown() {
-		params.Set("initializeForwarder", fmt.Sprintf("%t", data.InitializeForwarder.ValueBool()))
+		request.BoolParam("initializeForwarder", data.InitializeForwarder.ValueBool())
 	}
 
 	if !data.Protocol.IsNull() && !data.Protocol.IsUnknown() {
-		params.Set("protocol", data.Protocol.ValueString())
+		request.Param("protocol", data.Protocol.ValueString())
 	}
 
 	if !data.Forwarder.IsNull() && !data.Forwarder.IsUnknown() {
-		params.Set("forwarder", data.Forwarder.ValueString())
+		request.Param("forwarder", data.Forwarder.ValueString())
+	}
+
+	// The zone is created with the first forwarder, the others are added as FWD records
+	if forwarders := zoneForwarders(data); len(forwarders) > 0 {
+		request.Param("forwarder", forwarders[0])
 	}
 
 	if !data.DnssecValidation.IsNull() && !data.DnssecValidation.IsUnknown() {
-		params.Set("dnssecValidation", fmt.Sprintf("%t", data.DnssecValidation.ValueBool()))
+		request.BoolParam("dnssecValidation", data.DnssecValidation.ValueBool())
 	}
 
 	if !data.ProxyType.IsNull() && !data.ProxyType.IsUnknown() {
-		params.Set("proxyType", data.ProxyType.ValueString())
+		request.Param("proxyType", data.ProxyType.ValueString())
 	}
 
 	if !data.ProxyAddress.IsNull() && !data.ProxyAddress.IsUnknown() {
-		params.Set("proxyAddress", data.ProxyAddress.ValueString())
+		request.Param("proxyAddress", data.ProxyAddress.ValueString())
 	}
 
 	if !data.ProxyPort.IsNull() && !data.ProxyPort.IsUnknown() {
-		params.Set("proxyPort", fmt.Sprintf("%d", data.ProxyPort.ValueInt64()))
+		request.IntParam("proxyPort", data.ProxyPort.ValueInt64())
 	}
 
 	if !data.ProxyUsername.IsNull() && !data.ProxyUsername.IsUnknown() {
-		params.Set("proxyUsername", data.ProxyUsername.ValueString())
+		request.Param("proxyUsername", data.ProxyUsername.ValueString())
 	}
 
 	if !data.ProxyPassword.IsNull() && !data.ProxyPassword.IsUnknown() {
-		params.Set("proxyPassword", data.ProxyPassword.ValueString())
+		request.Param("proxyPassword", data.ProxyPassword.ValueString())
 	}
 
-	endpoint := "/api/zones/create?" + params.Encode()
+	endpoint := request.Endpoint()
 
 	var response struct {
 		Domain string `json:"domain"`
 	}
 
-	return r.client.DoRequest(ctx, "GET", endpoint, nil, &response)
+	return r.client.DoRequest(ctx, "POST", endpoint, nil, &response)
+}
+
+// createBootstrapRecords adds the configured bootstrap records to a newly created zone
+func (r *ZoneResource) createBootstrapRecords(ctx context.Context, data *ZoneResourceModel) error {
+	zoneName := data.Name.ValueString()
+
+	for i, record := range data.BootstrapRecords {
+		recordType := record.Type.ValueString()
+
+		name := record.Name.ValueString()
+		if name == "@" || name == "" {
+			name = zoneName
+		}
+
+		// Reuse the record resource data mapping so bootstrap records behave identically
+		data := recordData(&DNSRecordResourceModel{
+			Type:     record.Type,
+			Data:     record.Data,
+			Priority: record.Priority,
+		})
+
+		tflog.Debug(ctx, "Creating zone bootstrap record", map[string]interface{}{
+			"zone": zoneName,
+			"name": name,
+			"type": recordType,
+		})
+
+		ttl, err := ttlToInt(record.TTL)
+		if err != nil {
+			return fmt.Errorf("bootstrap record %d: %w", i, err)
+		}
+
+		bootstrap := client.AddRecordRequest{Zone: zoneName, Domain: formatRecordName(name, zoneName), TTL: int64(ttl), Data: data}
+		if _, err := r.client.AddRecord(ctx, bootstrap); err != nil {
+			return fmt.Errorf("bootstrap record %d (%s %s): %w", i, recordType, record.Name.ValueString(), err)
+		}
+	}
+
+	return nil
+}
+
+// cloneZone copies the records of the zone configured with clone_from into a newly created zone
+// by importing the exported zone file of the source zone
+func (r *ZoneResource) cloneZone(ctx context.Context, data *ZoneResourceModel) ([]zoneFileRecord, error) {
+	source := data.CloneFrom.ValueString()
+	if source == "" {
+		return nil, nil
+	}
+	zoneName := data.Name.ValueString()
+
+	exported, err := r.client.ExportZone(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export zone %s to clone: %w", source, err)
+	}
+
+	rewriteData := data.CloneRewriteOrigin.IsNull() || data.CloneRewriteOrigin.ValueBool()
+	content, err := cloneZoneFile(exported, source, zoneName, rewriteData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the exported zone file of %s: %w", source, err)
+	}
+	records, err := parseZoneFile(zoneName, content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the cloned zone file of %s: %w", source, err)
+	}
+
+	tflog.Debug(ctx, "Cloning zone records", map[string]interface{}{
+		"zone":           zoneName,
+		"source":         source,
+		"rewrite_origin": rewriteData,
+	})
+
+	// Overwriting replaces the generated apex NS records with the ones of the source zone
+	if err := r.client.ImportZone(ctx, zoneName, content, client.ImportZoneOptions{Overwrite: true}); err != nil {
+		return nil, fmt.Errorf("failed to import the records of zone %s: %w", source, err)
+	}
+	return records, nil
+}
+
+// applyInitialSOA rewrites the generated SOA record (and the apex NS record pointing at the
+// default primary name server) with the configured SOA values
+func (r *ZoneResource) applyInitialSOA(ctx context.Context, data *ZoneResourceModel) error {
+	if !zoneSOAConfigured(data) {
+		return nil
+	}
+	primaryNameServer := knownString(data.SoaPrimaryNameServer)
+	responsiblePerson := knownString(data.SoaResponsiblePerson)
+
+	zoneName := data.Name.ValueString()
+	recordsResponse, err := r.client.GetRecords(ctx, zoneName, zoneName, false)
+	if err != nil {
+		return fmt.Errorf("failed to read SOA record: %w", err)
+	}
+
+	var soa *client.DNSRecord
+	var nameServers []client.DNSRecord
+	for i, record := range recordsResponse.Records {
+		switch record.Type {
+		case "SOA":
+			soa = &recordsResponse.Records[i]
+		case "NS":
+			nameServers = append(nameServers, record)
+		}
+	}
+	if soa == nil {
+		return fmt.Errorf("zone %s has no SOA record", zoneName)
+	}
+
+	// Keep the apex NS record in line with the SOA so both use the organizational name
+	if primaryNameServer != "" {
+		for _, ns := range nameServers {
+			if !strings.EqualFold(strings.TrimSuffix(ns.RData.NameServer, "."), strings.TrimSuffix(soa.RData.PrimaryNameServer, ".")) {
+				continue
+			}
+
+			tflog.Debug(ctx, "Updating zone apex NS record", map[string]interface{}{
+				"zone":            zoneName,
+				"name_server":     ns.RData.NameServer,
+				"new_name_server": primaryNameServer,
+			})
+
+			if _, err := r.client.UpdateRecord(ctx, client.UpdateRecordRequest{
+				Zone:    zoneName,
+				Domain:  zoneName,
+				TTL:     int64(ns.TTL),
+				Current: client.NSRecordData{NameServer: ns.RData.NameServer},
+				New:     client.NSRecordData{NameServer: primaryNameServer},
+			}); err != nil {
+				return fmt.Errorf("failed to update apex NS record: %w", err)
+			}
+		}
+	}
+
+	tflog.Debug(ctx, "Updating zone SOA record", map[string]interface{}{
+		"zone":                zoneName,
+		"primary_name_server": primaryNameServer,
+		"responsible_person":  responsiblePerson,
+	})
+
+	if _, err := r.client.UpdateRecord(ctx, client.UpdateRecordRequest{
+		Zone:   zoneName,
+		Domain: zoneName,
+		TTL:    int64(soa.TTL),
+		New:    zoneSOAData(*soa, data),
+	}); err != nil {
+		return fmt.Errorf("failed to update SOA record: %w", err)
+	}
+
+	return nil
+}
+
+// initialSOAData builds the updated SOA data, keeping every value that is not overridden
+func initialSOAData(soa client.DNSRecord, primaryNameServer, responsiblePerson string) client.SOARecordData {
+	if primaryNameServer == "" {
+		primaryNameServer = soa.RData.PrimaryNameServer
+	}
+	if responsiblePerson == "" {
+		responsiblePerson = soa.RData.ResponsiblePerson
+	}
+
+	return client.SOARecordData{
+		PrimaryNameServer: primaryNameServer,
+		ResponsiblePerson: soaMailbox(responsiblePerson),
+		Serial:            soa.RData.Serial,
+		Refresh:           int64(soa.RData.Refresh),
+		Retry:             int64(soa.RData.Retry),
+		Expire:            int64(soa.RData.Expire),
+		Minimum:           int64(soa.RData.Minimum),
+	}
+}
+
+// soaMailbox converts an email address into the SOA RNAME form, escaping dots in the local part
+func soaMailbox(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found {
+		return email
+	}
+	return strings.ReplaceAll(local, ".", "\\.") + "." + domain
 }
 
 // readZone reads zone information from the API
 func (r *ZoneResource) readZone(ctx context.Context, data *ZoneResourceModel) error {
 	// First, get the zone options
-	params := url.Values{}
-	params.Set("zone", data.Name.ValueString())
-	endpoint := "/api/zones/options/get?" + params.Encode()
-
-	var optionsResponse ZoneOptionsResponse
-	if err := r.client.DoRequest(ctx, "GET", endpoint, nil, &optionsResponse); err != nil {
-		return fmt.Errorf("failed to get zone options: %w", err)
+	optionsResponse, err := r.client.GetZoneOptions(ctx, data.Name.ValueString())
+	if err != nil {
+		return err
 	}
 
 	// Ensure ID is set (zone name serves as the ID)
@@ -471,19 +1332,14 @@ func (r *ZoneResource) readZone(ctx context.Context, data *ZoneResourceModel) er
 	data.Type = types.StringValue(optionsResponse.Type)
 	data.Internal = types.BoolValue(optionsResponse.Internal)
 	data.DnssecStatus = types.StringValue(optionsResponse.DnssecStatus)
+	if !data.DnssecSigned.IsNull() {
+		// Only track signing when it is managed. A zone signed or unsigned outside Terraform then
+		// shows up as drift, which the next apply corrects.
+		data.DnssecSigned = types.BoolValue(optionsResponse.DnssecStatus != dnssecUnsigned)
+	}
 	data.Disabled = types.BoolValue(optionsResponse.Disabled)
 
-	// Set computed attributes - ensure all computed attributes get explicit values
-	if optionsResponse.UseSoaSerialDateScheme != nil {
-		data.UseSoaSerialDateScheme = types.BoolValue(*optionsResponse.UseSoaSerialDateScheme)
-	} else {
-		// API doesn't return this field, preserve the value from create operation
-		// If this is a new resource without a state value, default to false
-		if data.UseSoaSerialDateScheme.IsNull() || data.UseSoaSerialDateScheme.IsUnknown() {
-			data.UseSoaSerialDateScheme = types.BoolValue(false)
-		}
-		// Otherwise, preserve the existing state value (don't modify it)
-	}
+	data.UseSoaSerialDateScheme = optionalBoolField(optionsResponse.UseSoaSerialDateScheme, data.UseSoaSerialDateScheme)
 
 	if optionsResponse.Catalog != "" {
 		data.Catalog = types.StringValue(optionsResponse.Catalog)
@@ -504,11 +1360,11 @@ func (r *ZoneResource) readZone(ctx context.Context, data *ZoneResourceModel) er
 		data.TsigKeyName = types.StringValue(optionsResponse.PrimaryZoneTransferTsigKeyName)
 	}
 
-	if optionsResponse.ValidateZone != nil {
-		data.ValidateZone = types.BoolValue(*optionsResponse.ValidateZone)
-	} else {
-		data.ValidateZone = types.BoolValue(false)
-	}
+	data.ValidateZone = optionalBoolField(optionsResponse.ValidateZone, data.ValidateZone)
+
+	data.ZoneTransferTsigKeyNames = stringSetValue(optionsResponse.ZoneTransferTsigKeyNames)
+	data.ZoneTransferRequireTsig = types.BoolValue(len(optionsResponse.ZoneTransferTsigKeyNames) > 0)
+	readZoneAccess(data, optionsResponse)
 
 	// Set computed attributes that need explicit defaults
 	// Preserve InitializeForwarder value if already set, otherwise default to false
@@ -521,131 +1377,159 @@ func (r *ZoneResource) readZone(ctx context.Context, data *ZoneResourceModel) er
 		data.DnssecValidation = types.BoolValue(false)
 	}
 
-	// Set default values for schema attributes with defaults
-	data.Protocol = types.StringValue("Udp")
-	data.ProxyType = types.StringValue("DefaultProxy")
+	// Set default values for schema attributes with defaults. Forwarder zones read their
+	// forwarding settings from their FWD records below.
+	data.Protocol = types.StringValue(string(client.DefaultForwarderProtocol))
+	data.ProxyType = types.StringValue(string(client.DefaultProxyType))
 
-	// Get zone records to extract SOA serial
-	recordsParams := url.Values{}
-	recordsParams.Set("domain", data.Name.ValueString())
-	recordsParams.Set("zone", data.Name.ValueString())
-	recordsParams.Set("listZone", "true")
-	recordsEndpoint := "/api/zones/records/get?" + recordsParams.Encode()
+	soa := zoneSOARecord(ctx, r.client, data.Name.ValueString())
+	data.SoaSerial = types.Int64Value(soaSerial(soa))
+	readZoneSOA(data, soa)
 
-	var recordsResponse ZoneRecordsResponse
-	if err := r.client.DoRequest(ctx, "GET", recordsEndpoint, nil, &recordsResponse); err != nil {
-		// Don't fail if records can't be read, just log it
-		tflog.Warn(ctx, "Failed to read zone records for SOA serial", map[string]interface{}{
-			"zone":  data.Name.ValueString(),
-			"error": err.Error(),
-		})
-	} else {
-		// Find SOA record to get serial
-		soaFound := false
-		for _, record := range recordsResponse.Records {
-			if record.Type == "SOA" && record.RData.SoaRecord != nil {
-				data.SoaSerial = types.Int64Value(int64(record.RData.SoaRecord.Serial))
-				soaFound = true
-				break
-			}
-		}
-		if !soaFound {
-			// Default SOA serial if not found
-			data.SoaSerial = types.Int64Value(1)
-		}
-	}
+	return r.readZoneForwarders(ctx, data)
+}
 
-	// Ensure SoaSerial is set even if records couldn't be read
-	if data.SoaSerial.IsNull() || data.SoaSerial.IsUnknown() {
-		data.SoaSerial = types.Int64Value(1)
-	}
+// readZoneSOASerial returns the SOA serial of a zone, or 1 when the zone records cannot be read
+// or the zone has no SOA record (e.g. forwarder zones)
+func readZoneSOASerial(ctx context.Context, c client.ClientAPI, zoneName string) int64 {
+	return soaSerial(zoneSOARecord(ctx, c, zoneName))
+}
 
-	return nil
+// soaSerial returns the serial of a SOA record, or 1 without one
+func soaSerial(soa *client.DNSRecord) int64 {
+	if soa == nil {
+		return 1
+	}
+	return int64(soa.RData.Serial)
 }
 
 // updateZone updates zone options via the API
 func (r *ZoneResource) updateZone(ctx context.Context, data *ZoneResourceModel) error {
-	params := url.Values{}
-	params.Set("zone", data.Name.ValueString())
+	options := make(map[string]string)
 
 	// Add parameters that can be updated
 	if !data.Catalog.IsNull() && !data.Catalog.IsUnknown() {
-		params.Set("catalog", data.Catalog.ValueString())
+		options["catalog"] = data.Catalog.ValueString()
 	}
 
 	// Note: useSoaSerialDateScheme cannot be updated after zone creation
 	// This attribute requires zone replacement (handled by RequiresReplace plan modifier)
 
 	if !data.PrimaryNameServerAddresses.IsNull() && !data.PrimaryNameServerAddresses.IsUnknown() {
-		params.Set("primaryNameServerAddresses", data.PrimaryNameServerAddresses.ValueString())
+		options["primaryNameServerAddresses"] = data.PrimaryNameServerAddresses.ValueString()
 	}
 
 	if !data.ZoneTransferProtocol.IsNull() && !data.ZoneTransferProtocol.IsUnknown() {
-		params.Set("primaryZoneTransferProtocol", data.ZoneTransferProtocol.ValueString())
+		options["primaryZoneTransferProtocol"] = data.ZoneTransferProtocol.ValueString()
 	}
 
 	if !data.TsigKeyName.IsNull() && !data.TsigKeyName.IsUnknown() {
-		params.Set("primaryZoneTransferTsigKeyName", data.TsigKeyName.ValueString())
+		options["primaryZoneTransferTsigKeyName"] = data.TsigKeyName.ValueString()
 	}
 
 	if !data.ValidateZone.IsNull() && !data.ValidateZone.IsUnknown() {
-		params.Set("validateZone", fmt.Sprintf("%t", data.ValidateZone.ValueBool()))
+		options["validateZone"] = strconv.FormatBool(data.ValidateZone.ValueBool())
 	}
 
-	endpoint := "/api/zones/options/set?" + params.Encode()
+	for key, value := range zoneTransferTsigOptions(data) {
+		options[key] = value
+	}
+	for key, value := range zoneAccessOptions(data) {
+		options[key] = value
+	}
 
-	return r.client.DoRequest(ctx, "GET", endpoint, nil, nil)
+	return r.client.SetZoneOptions(ctx, data.Name.ValueString(), options)
 }
 
-// deleteZone deletes a zone via the API
-func (r *ZoneResource) deleteZone(ctx context.Context, zoneName string) error {
-	params := url.Values{}
-	params.Set("zone", zoneName)
-	endpoint := "/api/zones/delete?" + params.Encode()
-	return r.client.DoRequest(ctx, "GET", endpoint, nil, nil)
-}
+// zoneTransferTsigOptions returns the zone option for the TSIG key names authorized to transfer
+// the zone out. Nothing is returned while the key names are unknown, so the server keeps its
+// current keys; an empty set clears them.
+func zoneTransferTsigOptions(data *ZoneResourceModel) map[string]string {
+	options := make(map[string]string)
+	if data.ZoneTransferTsigKeyNames.IsNull() || data.ZoneTransferTsigKeyNames.IsUnknown() {
+		return options
+	}
 
-// ZoneOptionsResponse represents the API response for zone options
-type ZoneOptionsResponse struct {
-	Name                           string   `json:"name"`
-	Type                           string   `json:"type"`
-	Internal                       bool     `json:"internal"`
-	DnssecStatus                   string   `json:"dnssecStatus"`
-	Disabled                       bool     `json:"disabled"`
-	Catalog                        string   `json:"catalog,omitempty"`
-	UseSoaSerialDateScheme         *bool    `json:"useSoaSerialDateScheme,omitempty"`
-	PrimaryNameServerAddresses     []string `json:"primaryNameServerAddresses,omitempty"`
-	PrimaryZoneTransferProtocol    string   `json:"primaryZoneTransferProtocol,omitempty"`
-	PrimaryZoneTransferTsigKeyName string   `json:"primaryZoneTransferTsigKeyName,omitempty"`
-	ValidateZone                   *bool    `json:"validateZone,omitempty"`
+	names := make([]string, 0, len(data.ZoneTransferTsigKeyNames.Elements()))
+	for _, element := range data.ZoneTransferTsigKeyNames.Elements() {
+		if name, ok := element.(types.String); ok && !name.IsNull() && !name.IsUnknown() {
+			names = append(names, name.ValueString())
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		// The API clears the key names when the option is set to false
+		options["zoneTransferTsigKeyNames"] = "false"
+	} else {
+		options["zoneTransferTsigKeyNames"] = strings.Join(names, ",")
+	}
+	return options
 }
 
-// ZoneRecordsResponse represents the API response for zone records
-type ZoneRecordsResponse struct {
-	Zone    ZoneInfo     `json:"zone"`
-	Records []ZoneRecord `json:"records"`
+// stringSetValue converts a list of strings returned by the API into a set value
+func stringSetValue(values []string) types.Set {
+	elements := make([]attr.Value, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
 }
 
-type ZoneInfo struct {
-	Name         string `json:"name"`
-	Type         string `json:"type"`
-	Internal     bool   `json:"internal"`
-	DnssecStatus string `json:"dnssecStatus"`
-	Disabled     bool   `json:"disabled"`
+// remainingZoneRecords lists the data records still present in the zone. Secondary and stub zones
+// only hold copies of data managed elsewhere, so they never block deletion.
+func (r *ZoneResource) remainingZoneRecords(ctx context.Context, data *ZoneResourceModel) ([]client.DNSRecord, error) {
+	zoneType := data.Type.ValueString()
+	if zoneType != "Primary" && zoneType != "Forwarder" {
+		return nil, nil
+	}
+
+	zoneName := data.Name.ValueString()
+	if len(data.CreatedRecords.Elements()) > 0 {
+		return r.remainingUncreatedZoneRecords(ctx, data)
+	}
+
+	recordsResponse, err := r.client.GetRecords(ctx, zoneName, zoneName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return zoneDataRecords(zoneName, zoneType, recordsResponse.Records), nil
 }
 
-type ZoneRecord struct {
-	Name     string          `json:"name"`
-	Type     string          `json:"type"`
-	TTL      int             `json:"ttl"`
-	RData    ZoneRecordRData `json:"rData"`
-	Disabled bool            `json:"disabled"`
+// zoneDataRecords filters out the records the server maintains for the zone itself: the apex SOA
+// and NS records, and the apex FWD record of forwarder zones
+func zoneDataRecords(zoneName, zoneType string, records []client.DNSRecord) []client.DNSRecord {
+	remaining := make([]client.DNSRecord, 0)
+	for _, record := range records {
+		if strings.EqualFold(strings.TrimSuffix(record.Name, "."), strings.TrimSuffix(zoneName, ".")) {
+			if record.Type == "SOA" || record.Type == "NS" {
+				continue
+			}
+			if zoneType == "Forwarder" && record.Type == "FWD" {
+				continue
+			}
+		}
+		remaining = append(remaining, record)
+	}
+	return remaining
 }
 
-type ZoneRecordRData struct {
-	SoaRecord *SoaRecordData `json:"soaRecord,omitempty"`
+// describeZoneRecords renders up to limit records as "name TYPE" for diagnostics
+func describeZoneRecords(records []client.DNSRecord, limit int) string {
+	descriptions := make([]string, 0, limit)
+	for i, record := range records {
+		if i == limit {
+			descriptions = append(descriptions, fmt.Sprintf("and %d more", len(records)-limit))
+			break
+		}
+		descriptions = append(descriptions, record.Name+" "+record.Type)
+	}
+	return strings.Join(descriptions, ", ")
 }
 
-type SoaRecordData struct {
-	Serial uint32 `json:"serial"`
+// deleteZone deletes a zone via the API
+func (r *ZoneResource) deleteZone(ctx context.Context, zoneName string) error {
+	endpoint := client.NewRequest().Path("/api/zones/delete").Param("zone", zoneName).Endpoint()
+	return r.client.DoRequest(ctx, "POST", endpoint, nil, nil)
 }
@@ -19,6 +19,9 @@ type testAccConfig struct {
 	Host     string
 	Username string
 	Password string
+
+	// container is the server backing the test, e.g. to look up its address on the container network
+	container *testhelpers.TechnitiumContainer
 }
 
 // setupTestContainer sets up a test container for acceptance tests
@@ -43,9 +46,10 @@ func setupTestContainer(t *testing.T) *testAccConfig {
 	})
 
 	return &testAccConfig{
-		Host:     container.GetAPIURL(),
-		Username: container.Username,
-		Password: container.Password,
+		Host:      container.GetAPIURL(),
+		Username:  container.Username,
+		Password:  container.Password,
+		container: container,
 	}
 }
 
@@ -76,10 +80,10 @@ func TestAccZoneResource_Primary(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccZoneResourceConfig_primary(config, "test-primary.example.com"),
+				Config: testAccZoneResourceConfig_primary(config, sweepZonePrefix+"primary.example.com"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckZoneExists(config, "technitium_zone.test"),
-					resource.TestCheckResourceAttr("technitium_zone.test", "name", "test-primary.example.com"),
+					resource.TestCheckResourceAttr("technitium_zone.test", "name", sweepZonePrefix+"primary.example.com"),
 					resource.TestCheckResourceAttr("technitium_zone.test", "type", "Primary"),
 					resource.TestCheckResourceAttrSet("technitium_zone.test", "dnssec_status"),
 					resource.TestCheckResourceAttrSet("technitium_zone.test", "internal"),
@@ -91,14 +95,14 @@ func TestAccZoneResource_Primary(t *testing.T) {
 				ResourceName:      "technitium_zone.test",
 				ImportState:       true,
 				ImportStateVerify: true,
-				ImportStateId:     "test-primary.example.com",
+				ImportStateId:     sweepZonePrefix + "primary.example.com",
 			},
 			// Update and Read testing
 			{
-				Config: testAccZoneResourceConfig_primaryWithOptions(config, "test-primary.example.com"),
+				Config: testAccZoneResourceConfig_primaryWithOptions(config, sweepZonePrefix+"primary.example.com"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckZoneExists(config, "technitium_zone.test"),
-					resource.TestCheckResourceAttr("technitium_zone.test", "name", "test-primary.example.com"),
+					resource.TestCheckResourceAttr("technitium_zone.test", "name", sweepZonePrefix+"primary.example.com"),
 					resource.TestCheckResourceAttr("technitium_zone.test", "type", "Primary"),
 					resource.TestCheckResourceAttr("technitium_zone.test", "use_soa_serial_date_scheme", "true"),
 				),
@@ -133,10 +137,10 @@ func TestAccZoneResource_Forwarder(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create forwarder zone
 			{
-				Config: testAccZoneResourceConfig_forwarder(config, "test-forwarder.example.com"),
+				Config: testAccZoneResourceConfig_forwarder(config, sweepZonePrefix+"forwarder.example.com"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					testAccCheckZoneExists(config, "technitium_zone.test"),
-					resource.TestCheckResourceAttr("technitium_zone.test", "name", "test-forwarder.example.com"),
+					resource.TestCheckResourceAttr("technitium_zone.test", "name", sweepZonePrefix+"forwarder.example.com"),
 					resource.TestCheckResourceAttr("technitium_zone.test", "type", "Forwarder"),
 					resource.TestCheckResourceAttr("technitium_zone.test", "forwarder", "8.8.8.8"),
 					resource.TestCheckResourceAttr("technitium_zone.test", "protocol", "Udp"),
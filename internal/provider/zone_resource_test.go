@@ -2,9 +2,21 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
 )
 
 func TestZoneResource(t *testing.T) {
@@ -63,5 +75,1433 @@ func TestZoneResource(t *testing.T) {
 		} else {
 			t.Error("Schema should have 'dnssec_status' attribute")
 		}
+
+		// Verify optional bootstrap records
+		if attr, ok := schema.Attributes["bootstrap_records"]; ok {
+			if !attr.IsOptional() {
+				t.Error("'bootstrap_records' attribute should be optional")
+			}
+		} else {
+			t.Error("Schema should have 'bootstrap_records' attribute")
+		}
+
+		// Verify force_destroy
+		if attr, ok := schema.Attributes["force_destroy"]; ok {
+			if !attr.IsOptional() {
+				t.Error("'force_destroy' attribute should be optional")
+			}
+		} else {
+			t.Error("Schema should have 'force_destroy' attribute")
+		}
+
+		// Verify serial_bump_trigger
+		if attr, ok := schema.Attributes["serial_bump_trigger"]; ok {
+			if !attr.IsOptional() {
+				t.Error("'serial_bump_trigger' attribute should be optional")
+			}
+		} else {
+			t.Error("Schema should have 'serial_bump_trigger' attribute")
+		}
+
+		// Verify resync_trigger
+		if attr, ok := schema.Attributes["resync_trigger"]; ok {
+			if !attr.IsOptional() {
+				t.Error("'resync_trigger' attribute should be optional")
+			}
+		} else {
+			t.Error("Schema should have 'resync_trigger' attribute")
+		}
+	})
+}
+
+func TestZoneDataRecords(t *testing.T) {
+	t.Parallel()
+
+	records := []client.DNSRecord{
+		{Name: "example.com", Type: "SOA"},
+		{Name: "example.com", Type: "NS"},
+		{Name: "example.com", Type: "FWD"},
+		{Name: "Example.com.", Type: "A"},
+		{Name: "sub.example.com", Type: "NS"},
+		{Name: "www.example.com", Type: "CNAME"},
+	}
+
+	tests := []struct {
+		name     string
+		zoneType string
+		expected []string
+	}{
+		{
+			name:     "primary zone keeps apex FWD and delegations",
+			zoneType: "Primary",
+			expected: []string{"example.com FWD", "Example.com. A", "sub.example.com NS", "www.example.com CNAME"},
+		},
+		{
+			name:     "forwarder zone ignores apex FWD",
+			zoneType: "Forwarder",
+			expected: []string{"Example.com. A", "sub.example.com NS", "www.example.com CNAME"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining := zoneDataRecords("example.com", tt.zoneType, records)
+			if len(remaining) != len(tt.expected) {
+				t.Fatalf("Expected %d records, got %d: %v", len(tt.expected), len(remaining), remaining)
+			}
+			for i, record := range remaining {
+				if got := record.Name + " " + record.Type; got != tt.expected[i] {
+					t.Errorf("Record %d: expected %q, got %q", i, tt.expected[i], got)
+				}
+			}
+		})
+	}
+
+	if got := describeZoneRecords(records[3:], 2); got != "Example.com. A, sub.example.com NS, and 1 more" {
+		t.Errorf("Unexpected description: %q", got)
+	}
+}
+
+func TestInitialSOAData(t *testing.T) {
+	t.Parallel()
+
+	soa := client.DNSRecord{
+		Name: "example.com",
+		Type: "SOA",
+		TTL:  900,
+		RData: client.DNSRecordData{
+			PrimaryNameServer: "server1",
+			ResponsiblePerson: "hostadmin.example.com",
+			Serial:            1,
+			Refresh:           900,
+			Retry:             300,
+			Expire:            604800,
+			Minimum:           900,
+		},
+	}
+
+	expected := client.SOARecordData{
+		PrimaryNameServer: "ns1.example.com",
+		ResponsiblePerson: `dns\.admin.example.com`,
+		Serial:            1,
+		Refresh:           900,
+		Retry:             300,
+		Expire:            604800,
+		Minimum:           900,
+	}
+	if data := initialSOAData(soa, "ns1.example.com", "dns.admin@example.com"); data != expected {
+		t.Errorf("Expected %+v, got %+v", expected, data)
+	}
+
+	// Values that are not overridden are kept from the generated SOA record
+	data := initialSOAData(soa, "", "hostmaster.example.com")
+	if data.PrimaryNameServer != "server1" || data.ResponsiblePerson != "hostmaster.example.com" {
+		t.Errorf("Unexpected SOA data: %+v", data)
+	}
+}
+
+func TestZoneSOA(t *testing.T) {
+	t.Parallel()
+
+	soa := client.DNSRecord{
+		Name: "example.com",
+		Type: "SOA",
+		TTL:  900,
+		RData: client.DNSRecordData{
+			PrimaryNameServer: "server1",
+			ResponsiblePerson: "hostadmin.example.com",
+			Serial:            7,
+			Refresh:           900,
+			Retry:             300,
+			Expire:            604800,
+			Minimum:           900,
+		},
+	}
+
+	data := zonePlanModel("example.com", "Primary")
+	require.False(t, zoneSOAConfigured(&data))
+
+	// Configured timers replace the generated ones, the others are kept
+	data.SoaRefresh = types.Int64Value(3600)
+	data.SoaMinimum = types.Int64Value(60)
+	data.SoaRetry = types.Int64Unknown()
+	require.True(t, zoneSOAConfigured(&data))
+	require.Equal(t, client.SOARecordData{
+		PrimaryNameServer: "server1",
+		ResponsiblePerson: "hostadmin.example.com",
+		Serial:            7,
+		Refresh:           3600,
+		Retry:             300,
+		Expire:            604800,
+		Minimum:           60,
+	}, zoneSOAData(soa, &data))
+
+	// Reading keeps the configured form of matching names and takes the others from the record
+	data.SoaResponsiblePerson = types.StringValue("hostadmin@example.com")
+	data.SoaPrimaryNameServer = types.StringValue("ns1.example.com")
+	readZoneSOA(&data, &soa)
+	require.Equal(t, "hostadmin@example.com", data.SoaResponsiblePerson.ValueString())
+	require.Equal(t, "server1", data.SoaPrimaryNameServer.ValueString())
+	require.Equal(t, int64(300), data.SoaRetry.ValueInt64())
+	require.Equal(t, int64(900), data.SoaRefresh.ValueInt64())
+
+	// Zones without a SOA record resolve unknown values to null
+	data = zonePlanModel("corp.example.com", "Forwarder")
+	data.SoaRefresh = types.Int64Unknown()
+	readZoneSOA(&data, nil)
+	require.True(t, data.SoaRefresh.IsNull())
+}
+
+func TestZoneResourceUpdateZoneSOA(t *testing.T) {
+	t.Parallel()
+
+	soa := client.DNSRecord{
+		Name: "example.com",
+		Type: "SOA",
+		TTL:  900,
+		RData: client.DNSRecordData{
+			PrimaryNameServer: "ns1.example.com",
+			ResponsiblePerson: "hostmaster.example.com",
+			Serial:            7,
+			Refresh:           900,
+			Retry:             300,
+			Expire:            604800,
+			Minimum:           900,
+		},
+	}
+
+	prior := zonePlanModel("example.com", "Primary")
+	readZoneSOA(&prior, &soa)
+
+	// Unchanged values are not written again
+	m := mocks.NewClientAPI(t)
+	r := &ZoneResource{client: m}
+	data := prior
+	require.NoError(t, r.updateZoneSOA(context.Background(), &data, &prior))
+
+	m.On("GetRecords", mock.Anything, "example.com", "example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{soa}}, nil).Once()
+	m.On("UpdateRecord", mock.Anything, client.UpdateRecordRequest{
+		Zone:   "example.com",
+		Domain: "example.com",
+		TTL:    900,
+		New: client.SOARecordData{
+			PrimaryNameServer: "ns1.example.com",
+			ResponsiblePerson: "hostmaster.example.com",
+			Serial:            7,
+			Refresh:           900,
+			Retry:             300,
+			Expire:            1209600,
+			Minimum:           900,
+		},
+	}).Return(&client.UpdateRecordResponse{}, nil).Once()
+
+	data.SoaExpire = types.Int64Value(1209600)
+	require.NoError(t, r.updateZoneSOA(context.Background(), &data, &prior))
+}
+
+// zonePlanModel returns a zone model with every optional attribute null
+func zonePlanModel(name, zoneType string) ZoneResourceModel {
+	return ZoneResourceModel{
+		ID:                         types.StringUnknown(),
+		Name:                       types.StringValue(name),
+		Type:                       types.StringValue(zoneType),
+		Catalog:                    types.StringNull(),
+		UseSoaSerialDateScheme:     types.BoolUnknown(),
+		PrimaryNameServerAddresses: types.StringNull(),
+		ZoneTransferProtocol:       types.StringValue("Tcp"),
+		TsigKeyName:                types.StringNull(),
+		ValidateZone:               types.BoolUnknown(),
+		ZoneTransferTsigKeyNames:   types.SetUnknown(types.StringType),
+		ZoneTransferRequireTsig:    types.BoolUnknown(),
+		ZoneTransfer:               types.StringUnknown(),
+		ZoneTransferNetworkACL:     types.ListUnknown(types.StringType),
+		Notify:                     types.StringUnknown(),
+		NotifyNameServers:          types.SetUnknown(types.StringType),
+		QueryAccess:                types.StringUnknown(),
+		QueryAccessNetworkACL:      types.ListUnknown(types.StringType),
+		Update:                     types.StringUnknown(),
+		UpdateNetworkACL:           types.ListUnknown(types.StringType),
+		UpdateSecurityPolicies:     types.ListUnknown(types.ObjectType{AttrTypes: updateSecurityPolicyAttrTypes}),
+		InitializeForwarder:        types.BoolUnknown(),
+		Protocol:                   types.StringValue("Udp"),
+		Forwarder:                  types.StringNull(),
+		Forwarders:                 types.SetNull(types.StringType),
+		DnssecValidation:           types.BoolUnknown(),
+		ProxyType:                  types.StringValue("NoProxy"),
+		ProxyAddress:               types.StringNull(),
+		ProxyPort:                  types.Int64Null(),
+		ProxyUsername:              types.StringNull(),
+		ProxyPassword:              types.StringNull(),
+		CloneFrom:                  types.StringNull(),
+		CloneRewriteOrigin:         types.BoolNull(),
+		SoaPrimaryNameServer:       types.StringNull(),
+		SoaResponsiblePerson:       types.StringNull(),
+		SoaRefresh:                 types.Int64Null(),
+		SoaRetry:                   types.Int64Null(),
+		SoaExpire:                  types.Int64Null(),
+		SoaMinimum:                 types.Int64Null(),
+		ForceDestroy:               types.BoolValue(false),
+		CreatedRecords:             types.SetNull(types.StringType),
+		SerialBumpTrigger:          types.StringNull(),
+		ResyncTrigger:              types.StringNull(),
+		DnssecSigned:               types.BoolNull(),
+		DnssecAlgorithm:            types.StringNull(),
+		DnssecHashAlgorithm:        types.StringNull(),
+		DnssecKSKKeySize:           types.Int64Null(),
+		DnssecZSKKeySize:           types.Int64Null(),
+		DnssecCurve:                types.StringNull(),
+		DnssecNxProof:              types.StringNull(),
+		DnssecNSEC3Iterations:      types.Int64Null(),
+		DnssecNSEC3SaltLength:      types.Int64Null(),
+		DnssecDNSKeyTTL:            types.Int64Null(),
+		DnssecZSKRolloverDays:      types.Int64Null(),
+		DnssecAllowResign:          types.BoolNull(),
+		Internal:                   types.BoolUnknown(),
+		DnssecStatus:               types.StringUnknown(),
+		Disabled:                   types.BoolUnknown(),
+		SoaSerial:                  types.Int64Unknown(),
+	}
+}
+
+func TestZoneResourceModifyPlanZoneTransferTsig(t *testing.T) {
+	t.Parallel()
+
+	keys := stringSetValue([]string{"key.example.com"})
+
+	tests := []struct {
+		name          string
+		zoneType      string
+		keyNames      types.Set
+		requireTsig   types.Bool
+		expectError   string
+		expectKeys    types.Set
+		expectRequire types.Bool
+	}{
+		{
+			name:          "key names imply the requirement",
+			zoneType:      "Primary",
+			keyNames:      keys,
+			requireTsig:   types.BoolNull(),
+			expectKeys:    keys,
+			expectRequire: types.BoolValue(true),
+		},
+		{
+			name:          "unknown key names leave the requirement unknown",
+			zoneType:      "Primary",
+			keyNames:      types.SetNull(types.StringType),
+			requireTsig:   types.BoolNull(),
+			expectKeys:    types.SetUnknown(types.StringType),
+			expectRequire: types.BoolUnknown(),
+		},
+		{
+			name:          "not requiring TSIG clears the key names",
+			zoneType:      "Secondary",
+			keyNames:      types.SetNull(types.StringType),
+			requireTsig:   types.BoolValue(false),
+			expectKeys:    stringSetValue(nil),
+			expectRequire: types.BoolValue(false),
+		},
+		{
+			name:        "requiring TSIG needs key names",
+			zoneType:    "Primary",
+			keyNames:    types.SetNull(types.StringType),
+			requireTsig: types.BoolValue(true),
+			expectError: "Missing zone transfer TSIG key names",
+		},
+		{
+			name:        "key names conflict with not requiring TSIG",
+			zoneType:    "Primary",
+			keyNames:    keys,
+			requireTsig: types.BoolValue(false),
+			expectError: "Conflicting zone transfer TSIG settings",
+		},
+		{
+			name:        "stub zones do not transfer out",
+			zoneType:    "Stub",
+			keyNames:    keys,
+			requireTsig: types.BoolNull(),
+			expectError: "Unsupported zone type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ZoneResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			configModel := zonePlanModel("example.com", tt.zoneType)
+			configModel.ZoneTransferTsigKeyNames = tt.keyNames
+			configModel.ZoneTransferRequireTsig = tt.requireTsig
+			config := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, config.Set(context.Background(), &configModel).HasError())
+
+			planModel := configModel
+			if tt.keyNames.IsNull() {
+				planModel.ZoneTransferTsigKeyNames = types.SetUnknown(types.StringType)
+			}
+			if tt.requireTsig.IsNull() {
+				planModel.ZoneTransferRequireTsig = types.BoolUnknown()
+			}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &planModel).HasError())
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}, Plan: plan}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			if tt.expectError != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				require.Equal(t, tt.expectError, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+
+			var result ZoneResourceModel
+			require.False(t, resp.Plan.Get(context.Background(), &result).HasError())
+			require.Equal(t, tt.expectKeys, result.ZoneTransferTsigKeyNames)
+			require.Equal(t, tt.expectRequire, result.ZoneTransferRequireTsig)
+		})
+	}
+}
+
+func TestZoneTransferTsigOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		keyNames types.Set
+		expected map[string]string
+	}{
+		{name: "unknown keeps server keys", keyNames: types.SetUnknown(types.StringType), expected: map[string]string{}},
+		{name: "empty clears keys", keyNames: stringSetValue(nil), expected: map[string]string{"zoneTransferTsigKeyNames": "false"}},
+		{
+			name:     "keys joined in order",
+			keyNames: stringSetValue([]string{"b.example.com", "a.example.com"}),
+			expected: map[string]string{"zoneTransferTsigKeyNames": "a.example.com,b.example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := ZoneResourceModel{ZoneTransferTsigKeyNames: tt.keyNames}
+			require.Equal(t, tt.expected, zoneTransferTsigOptions(&data))
+		})
+	}
+}
+
+func TestZoneResourceModifyPlanDNSSEC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		zoneType    string
+		configure   func(*ZoneResourceModel)
+		expectError string
+	}{
+		{
+			name:     "signing with defaults",
+			zoneType: "Primary",
+			configure: func(data *ZoneResourceModel) {
+				data.DnssecSigned = types.BoolValue(true)
+			},
+		},
+		{
+			name:     "RSA with NSEC3",
+			zoneType: "Primary",
+			configure: func(data *ZoneResourceModel) {
+				data.DnssecSigned = types.BoolValue(true)
+				data.DnssecAlgorithm = types.StringValue("RSA")
+				data.DnssecKSKKeySize = types.Int64Value(4096)
+				data.DnssecNxProof = types.StringValue("NSEC3")
+				data.DnssecNSEC3Iterations = types.Int64Value(0)
+			},
+		},
+		{
+			name:     "only Primary zones are signed",
+			zoneType: "Secondary",
+			configure: func(data *ZoneResourceModel) {
+				data.DnssecSigned = types.BoolValue(true)
+			},
+			expectError: "Unsupported zone type",
+		},
+		{
+			name:     "settings need signing",
+			zoneType: "Primary",
+			configure: func(data *ZoneResourceModel) {
+				data.DnssecAlgorithm = types.StringValue("EDDSA")
+			},
+			expectError: "Missing dnssec_signed",
+		},
+		{
+			name:     "settings conflict with unsigning",
+			zoneType: "Primary",
+			configure: func(data *ZoneResourceModel) {
+				data.DnssecSigned = types.BoolValue(false)
+				data.DnssecNxProof = types.StringValue("NSEC3")
+			},
+			expectError: "Conflicting DNSSEC settings",
+		},
+		{
+			name:     "curve must match the algorithm",
+			zoneType: "Primary",
+			configure: func(data *ZoneResourceModel) {
+				data.DnssecSigned = types.BoolValue(true)
+				data.DnssecCurve = types.StringValue("ED25519")
+			},
+			expectError: "Invalid DNSSEC curve",
+		},
+		{
+			name:     "key sizes are only used with RSA",
+			zoneType: "Primary",
+			configure: func(data *ZoneResourceModel) {
+				data.DnssecSigned = types.BoolValue(true)
+				data.DnssecZSKKeySize = types.Int64Value(2048)
+			},
+			expectError: "Unsupported DNSSEC setting",
+		},
+		{
+			name:     "NSEC3 parameters need NSEC3",
+			zoneType: "Primary",
+			configure: func(data *ZoneResourceModel) {
+				data.DnssecSigned = types.BoolValue(true)
+				data.DnssecNSEC3SaltLength = types.Int64Value(8)
+			},
+			expectError: "Unsupported DNSSEC setting",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ZoneResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			model := zonePlanModel("example.com", tt.zoneType)
+			tt.configure(&model)
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw},
+				Plan:   plan,
+				State:  tfsdk.State{Schema: schemaResp.Schema},
+			}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			if tt.expectError != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				require.Equal(t, tt.expectError, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestDNSSECSignOptions(t *testing.T) {
+	t.Parallel()
+
+	data := zonePlanModel("example.com", "Primary")
+	data.DnssecSigned = types.BoolValue(true)
+	require.Equal(t, client.DNSSECSignOptions{
+		Algorithm: client.DNSSECAlgorithmECDSA,
+		Curve:     client.DNSSECCurveP256,
+		NxProof:   client.DNSSECNxProofNSEC,
+	}, dnssecSignOptions(&data))
+
+	data.DnssecAlgorithm = types.StringValue("RSA")
+	data.DnssecZSKKeySize = types.Int64Value(2048)
+	data.DnssecNxProof = types.StringValue("NSEC3")
+	data.DnssecNSEC3Iterations = types.Int64Value(5)
+	require.Equal(t, client.DNSSECSignOptions{
+		Algorithm:     client.DNSSECAlgorithmRSA,
+		HashAlgorithm: client.DNSSECHashAlgorithmSHA256,
+		KSKKeySize:    2048,
+		ZSKKeySize:    2048,
+		NxProof:       client.DNSSECNxProofNSEC3,
+		Iterations:    5,
+	}, dnssecSignOptions(&data))
+}
+
+func TestZoneResourceUpdateZoneSigning(t *testing.T) {
+	t.Parallel()
+
+	signed := func(status string, configure func(*ZoneResourceModel)) *ZoneResourceModel {
+		data := zonePlanModel("example.com", "Primary")
+		data.DnssecStatus = types.StringValue(status)
+		data.DnssecSigned = types.BoolValue(true)
+		if configure != nil {
+			configure(&data)
+		}
+		return &data
+	}
+
+	t.Run("signs an unsigned zone", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("SignZone", mock.Anything, "example.com", client.DNSSECSignOptions{
+			Algorithm: client.DNSSECAlgorithmEDDSA,
+			Curve:     client.DNSSECCurveED25519,
+			NxProof:   client.DNSSECNxProofNSEC,
+		}).Return(nil)
+
+		plan := signed("", func(data *ZoneResourceModel) {
+			data.DnssecAlgorithm = types.StringValue("EDDSA")
+		})
+		prior := signed("Unsigned", func(data *ZoneResourceModel) {
+			data.DnssecSigned = types.BoolValue(false)
+		})
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneSigning(context.Background(), plan, prior))
+	})
+
+	t.Run("unsigns a signed zone", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("UnsignZone", mock.Anything, "example.com").Return(nil)
+
+		plan := signed("", func(data *ZoneResourceModel) {
+			data.DnssecSigned = types.BoolValue(false)
+		})
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneSigning(context.Background(), plan, signed("SignedWithNSEC", nil)))
+	})
+
+	t.Run("re-signs when the keys change", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("UnsignZone", mock.Anything, "example.com").Return(nil)
+		m.On("SignZone", mock.Anything, "example.com", mock.MatchedBy(func(options client.DNSSECSignOptions) bool {
+			return options.Curve == client.DNSSECCurveP384
+		})).Return(nil)
+
+		plan := signed("", func(data *ZoneResourceModel) {
+			data.DnssecCurve = types.StringValue("P384")
+			data.DnssecAllowResign = types.BoolValue(true)
+		})
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneSigning(context.Background(), plan, signed("SignedWithNSEC", nil)))
+	})
+
+	t.Run("keeps the keys unless re-signing is allowed", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+
+		plan := signed("", func(data *ZoneResourceModel) {
+			data.DnssecCurve = types.StringValue("P384")
+		})
+		err := (&ZoneResource{client: m}).updateZoneSigning(context.Background(), plan, signed("SignedWithNSEC", nil))
+		require.ErrorContains(t, err, "dnssec_allow_resign")
+	})
+
+	t.Run("updates NSEC3 and the DNSKEY TTL in place", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("ConvertZoneToNSEC3", mock.Anything, "example.com").Return(nil)
+		m.On("UpdateNSEC3Params", mock.Anything, "example.com", int64(10), int64(8)).Return(nil)
+		m.On("UpdateDNSKeyTTL", mock.Anything, "example.com", int64(3600)).Return(nil)
+
+		plan := signed("", func(data *ZoneResourceModel) {
+			data.DnssecNxProof = types.StringValue("NSEC3")
+			data.DnssecNSEC3Iterations = types.Int64Value(10)
+			data.DnssecNSEC3SaltLength = types.Int64Value(8)
+			data.DnssecDNSKeyTTL = types.Int64Value(3600)
+		})
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneSigning(context.Background(), plan, signed("SignedWithNSEC", nil)))
+	})
+
+	t.Run("leaves unmanaged signing alone", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+
+		plan := signed("", func(data *ZoneResourceModel) {
+			data.DnssecSigned = types.BoolNull()
+		})
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneSigning(context.Background(), plan, signed("SignedWithNSEC", nil)))
 	})
 }
+
+func TestZoneResourceModifyPlanDNSSECResign(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range map[string]struct {
+		allowResign   types.Bool
+		curve         string
+		expectError   bool
+		expectWarning bool
+	}{
+		"same keys":           {allowResign: types.BoolNull(), curve: "P256"},
+		"new keys rejected":   {allowResign: types.BoolNull(), curve: "P384", expectError: true},
+		"new keys allowed":    {allowResign: types.BoolValue(true), curve: "P384", expectWarning: true},
+		"new keys disallowed": {allowResign: types.BoolValue(false), curve: "P384", expectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := &ZoneResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			prior := zonePlanModel("example.com", "Primary")
+			prior.DnssecSigned = types.BoolValue(true)
+			prior.DnssecStatus = types.StringValue("SignedWithNSEC")
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			require.False(t, state.Set(context.Background(), &prior).HasError())
+
+			model := prior
+			model.DnssecCurve = types.StringValue(tt.curve)
+			model.DnssecAllowResign = tt.allowResign
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, Plan: plan, State: state}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			require.Equal(t, tt.expectError, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+			require.Equal(t, tt.expectWarning, resp.Diagnostics.WarningsCount() > 0, "modify plan diagnostics: %v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestZoneResourceModifyPlanResyncTrigger(t *testing.T) {
+	t.Parallel()
+
+	for zoneType, expectError := range map[string]bool{"Secondary": false, "Stub": false, "Primary": true, "Forwarder": true} {
+		t.Run(zoneType, func(t *testing.T) {
+			r := &ZoneResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			model := zonePlanModel("example.com", zoneType)
+			model.ResyncTrigger = types.StringValue("2024-06-01")
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, Plan: plan}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			if !expectError {
+				require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "resync_trigger is only supported")
+		})
+	}
+}
+
+func TestZoneResourceModifyPlanSOA(t *testing.T) {
+	t.Parallel()
+
+	for zoneType, expectError := range map[string]bool{"Primary": false, "Forwarder": false, "Catalog": false, "Secondary": true, "Stub": true} {
+		t.Run(zoneType, func(t *testing.T) {
+			r := &ZoneResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			model := zonePlanModel("example.com", zoneType)
+			model.SoaRefresh = types.Int64Value(3600)
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, Plan: plan}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			if !expectError {
+				require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "soa_refresh is only supported")
+		})
+	}
+}
+
+func TestZoneResourceModifyPlanClone(t *testing.T) {
+	t.Parallel()
+
+	for zoneType, expectError := range map[string]bool{"Primary": false, "Forwarder": false, "Secondary": true, "Stub": true} {
+		t.Run(zoneType, func(t *testing.T) {
+			r := &ZoneResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			model := zonePlanModel("staging.example.com", zoneType)
+			model.CloneFrom = types.StringValue("example.com")
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, Plan: plan}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			if !expectError {
+				require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "clone_from is only supported")
+		})
+	}
+}
+
+func TestZoneResourceCloneZone(t *testing.T) {
+	t.Parallel()
+
+	exported := `example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 7 900 300 604800 900
+example.com. 3600 IN NS ns1.example.com.
+www.example.com. 300 IN CNAME web.example.com.
+`
+
+	m := mocks.NewClientAPI(t)
+	m.On("ExportZone", mock.Anything, "example.com").Return(exported, nil).Once()
+	m.On("ImportZone", mock.Anything, "staging.example.com", `$ORIGIN staging.example.com.
+staging.example.com. 3600 IN NS ns1.staging.example.com.
+www.staging.example.com. 300 IN CNAME web.staging.example.com.
+`, client.ImportZoneOptions{Overwrite: true}).Return(nil).Once()
+
+	data := zonePlanModel("staging.example.com", "Primary")
+	data.CloneFrom = types.StringValue("example.com")
+	cloned, err := (&ZoneResource{client: m}).cloneZone(context.Background(), &data)
+	require.NoError(t, err)
+	require.Len(t, cloned, 2)
+
+	// Zones without clone_from are left alone
+	data.CloneFrom = types.StringNull()
+	cloned, err = (&ZoneResource{client: m}).cloneZone(context.Background(), &data)
+	require.NoError(t, err)
+	require.Empty(t, cloned)
+}
+
+func TestZoneResourceSyncZoneForwarders(t *testing.T) {
+	t.Parallel()
+
+	data := zonePlanModel("corp.example.com", "Forwarder")
+	data.Forwarders = stringSetValue([]string{"192.0.2.2", "192.0.2.1", "192.0.2.3"})
+	require.Equal(t, []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"}, zoneForwarders(&data))
+
+	m := mocks.NewClientAPI(t)
+	m.On("GetRecords", mock.Anything, "corp.example.com", "corp.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "corp.example.com", Type: "SOA", TTL: 900},
+			{Name: "corp.example.com", Type: "FWD", TTL: 3600, RData: client.DNSRecordData{Forwarder: "192.0.2.1", Protocol: "Udp"}},
+			{Name: "corp.example.com", Type: "FWD", TTL: 3600, RData: client.DNSRecordData{Forwarder: "192.0.2.9", Protocol: "Tcp"}},
+		}}, nil).Once()
+
+	// Missing forwarders are added with the zone's settings and unlisted ones deleted
+	for _, forwarder := range []string{"192.0.2.2", "192.0.2.3"} {
+		m.On("AddRecord", mock.Anything, client.AddRecordRequest{
+			Zone:   "corp.example.com",
+			Domain: "corp.example.com",
+			TTL:    3600,
+			Data:   client.FWDRecordData{Protocol: client.ForwarderProtocolUdp, Forwarder: forwarder, ProxyType: client.ProxyTypeNone},
+		}).Return(&client.AddRecordResponse{}, nil).Once()
+	}
+	m.On("DeleteRecord", mock.Anything, client.DeleteRecordRequest{
+		Zone:   "corp.example.com",
+		Domain: "corp.example.com",
+		Data:   client.FWDRecordData{Protocol: client.ForwarderProtocolTcp, Forwarder: "192.0.2.9"},
+	}).Return(nil).Once()
+
+	r := &ZoneResource{client: m}
+	require.NoError(t, r.syncZoneForwarders(context.Background(), &data))
+
+	// Forwarders are read back from the FWD records, regardless of their order
+	m.On("GetRecords", mock.Anything, "corp.example.com", "corp.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "corp.example.com", Type: "FWD", RData: client.DNSRecordData{Forwarder: "192.0.2.3"}},
+			{Name: "corp.example.com", Type: "FWD", RData: client.DNSRecordData{Forwarder: "192.0.2.1"}},
+			{Name: "corp.example.com", Type: "FWD", RData: client.DNSRecordData{Forwarder: "192.0.2.2"}},
+		}}, nil).Once()
+
+	read := data
+	require.NoError(t, r.readZoneForwarders(context.Background(), &read))
+	require.True(t, read.Forwarders.Equal(data.Forwarders))
+
+	// Zones configured with a single forwarder are left alone
+	data.Forwarders = types.SetNull(types.StringType)
+	require.NoError(t, r.syncZoneForwarders(context.Background(), &data))
+}
+
+func TestZoneResourceReadZoneForwarders(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	m.On("GetRecords", mock.Anything, "corp.example.com", "corp.example.com", false).
+		Return(&client.GetRecordsResponse{Records: []client.DNSRecord{
+			{Name: "corp.example.com", Type: "SOA", TTL: 900},
+			{Name: "corp.example.com", Type: "FWD", TTL: 3600, RData: client.DNSRecordData{
+				Forwarder: "192.0.2.2", Protocol: "Udp", ProxyType: "NoProxy",
+			}},
+			{Name: "corp.example.com", Type: "FWD", TTL: 3600, RData: client.DNSRecordData{
+				Forwarder: "192.0.2.1", Protocol: "Https", DnssecValidation: true,
+				ProxyType: "Socks5", ProxyAddress: "proxy.example.com", ProxyPort: 1080,
+			}},
+		}}, nil)
+	r := &ZoneResource{client: m}
+
+	// The settings changed outside of Terraform are read from the FWD record of the forwarder
+	data := zonePlanModel("corp.example.com", "Forwarder")
+	data.Forwarder = types.StringValue("192.0.2.1")
+	require.NoError(t, r.readZoneForwarders(context.Background(), &data))
+	require.Equal(t, "Https", data.Protocol.ValueString())
+	require.True(t, data.DnssecValidation.ValueBool())
+	require.Equal(t, "Socks5", data.ProxyType.ValueString())
+	require.Equal(t, "proxy.example.com", data.ProxyAddress.ValueString())
+	require.Equal(t, int64(1080), data.ProxyPort.ValueInt64())
+	require.True(t, data.ProxyUsername.IsNull())
+
+	// Without a configured forwarder, the settings come from the first forwarder
+	data = zonePlanModel("corp.example.com", "Forwarder")
+	data.Forwarder = types.StringNull()
+	require.NoError(t, r.readZoneForwarders(context.Background(), &data))
+	require.Equal(t, "Https", data.Protocol.ValueString())
+
+	data.Forwarder = types.StringValue("192.0.2.2")
+	require.NoError(t, r.readZoneForwarders(context.Background(), &data))
+	require.Equal(t, "Udp", data.Protocol.ValueString())
+	require.Equal(t, "NoProxy", data.ProxyType.ValueString())
+	require.False(t, data.DnssecValidation.ValueBool())
+	require.True(t, data.ProxyAddress.IsNull())
+	require.True(t, data.ProxyPort.IsNull())
+
+	// The settings are rewritten on the records that drifted
+	m.On("UpdateRecord", mock.Anything, client.UpdateRecordRequest{
+		Zone:    "corp.example.com",
+		Domain:  "corp.example.com",
+		TTL:     3600,
+		Current: client.FWDRecordData{Protocol: client.ForwarderProtocolHttps, Forwarder: "192.0.2.1"},
+		New:     client.FWDRecordData{Protocol: client.ForwarderProtocolUdp, Forwarder: "192.0.2.1", ProxyType: client.ProxyTypeNone},
+	}).Return(&client.UpdateRecordResponse{}, nil).Once()
+
+	desired := zonePlanModel("corp.example.com", "Forwarder")
+	desired.Forwarder = types.StringNull()
+	require.NoError(t, r.updateZoneForwarderSettings(context.Background(), &desired, &desired))
+
+	// Other zone types have no forwarding settings to read or update
+	primary := zonePlanModel("example.com", "Primary")
+	require.NoError(t, r.readZoneForwarders(context.Background(), &primary))
+	require.NoError(t, r.updateZoneForwarderSettings(context.Background(), &primary, &primary))
+}
+
+func TestZoneResourceUpdateZoneDisabled(t *testing.T) {
+	t.Parallel()
+
+	model := func(disabled types.Bool) *ZoneResourceModel {
+		data := zonePlanModel("example.com", "Primary")
+		data.Disabled = disabled
+		return &data
+	}
+
+	t.Run("disables an enabled zone", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("DisableZone", mock.Anything, "example.com").Return(nil)
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneDisabled(context.Background(), model(types.BoolValue(true)), model(types.BoolValue(false))))
+	})
+
+	t.Run("enables a disabled zone", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("EnableZone", mock.Anything, "example.com").Return(nil)
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneDisabled(context.Background(), model(types.BoolValue(false)), model(types.BoolValue(true))))
+	})
+
+	t.Run("leaves an unchanged zone alone", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneDisabled(context.Background(), model(types.BoolValue(true)), model(types.BoolValue(true))))
+		require.NoError(t, (&ZoneResource{client: m}).updateZoneDisabled(context.Background(), model(types.BoolUnknown()), model(types.BoolValue(false))))
+	})
+}
+
+func TestZoneResourceModifyPlanZoneAccess(t *testing.T) {
+	t.Parallel()
+
+	policies := types.ListValueMust(types.ObjectType{AttrTypes: updateSecurityPolicyAttrTypes}, []attr.Value{
+		types.ObjectValueMust(updateSecurityPolicyAttrTypes, map[string]attr.Value{
+			"tsig_key_name": types.StringValue("key.example.com"),
+			"domain":        types.StringValue("*.example.com"),
+			"allowed_types": stringSetValue([]string{"A", "AAAA"}),
+		}),
+	})
+
+	tests := []struct {
+		name        string
+		zoneType    string
+		configure   func(*ZoneResourceModel)
+		expectError string
+	}{
+		{
+			name:     "network ACLs with ACL policies",
+			zoneType: "Primary",
+			configure: func(m *ZoneResourceModel) {
+				m.QueryAccess = types.StringValue("UseSpecifiedNetworkACL")
+				m.QueryAccessNetworkACL = stringListValue([]string{"192.168.10.0/24"})
+				m.ZoneTransfer = types.StringValue("UseSpecifiedNetworkACL")
+				m.ZoneTransferNetworkACL = stringListValue([]string{"192.168.10.0/24"})
+				m.Notify = types.StringValue("SpecifiedNameServers")
+				m.NotifyNameServers = stringSetValue([]string{"192.168.10.5"})
+				m.Update = types.StringValue("AllowZoneNameServersAndUseSpecifiedNetworkACL")
+				m.UpdateNetworkACL = stringListValue([]string{"192.168.10.0/24"})
+				m.UpdateSecurityPolicies = policies
+			},
+		},
+		{
+			name:     "network ACL ignored by the policy",
+			zoneType: "Primary",
+			configure: func(m *ZoneResourceModel) {
+				m.ZoneTransfer = types.StringValue("Allow")
+				m.ZoneTransferNetworkACL = stringListValue([]string{"192.168.10.0/24"})
+			},
+			expectError: "Conflicting zone transfer settings",
+		},
+		{
+			name:     "query ACL ignored by the policy",
+			zoneType: "Stub",
+			configure: func(m *ZoneResourceModel) {
+				m.QueryAccess = types.StringValue("AllowOnlyPrivateNetworks")
+				m.QueryAccessNetworkACL = stringListValue([]string{"192.168.10.0/24"})
+			},
+			expectError: "Conflicting query access settings",
+		},
+		{
+			name:     "name servers ignored by the policy",
+			zoneType: "Secondary",
+			configure: func(m *ZoneResourceModel) {
+				m.Notify = types.StringValue("ZoneNameServers")
+				m.NotifyNameServers = stringSetValue([]string{"192.168.10.5"})
+			},
+			expectError: "Conflicting notify settings",
+		},
+		{
+			name:     "forwarder zones only notify specified name servers",
+			zoneType: "Forwarder",
+			configure: func(m *ZoneResourceModel) {
+				m.Notify = types.StringValue("ZoneNameServers")
+			},
+			expectError: "Unsupported notify setting",
+		},
+		{
+			name:     "secondary zones do not restrict updates to zone name servers",
+			zoneType: "Secondary",
+			configure: func(m *ZoneResourceModel) {
+				m.Update = types.StringValue("AllowOnlyZoneNameServers")
+			},
+			expectError: "Unsupported update setting",
+		},
+		{
+			name:     "secondary zones have no update security policies",
+			zoneType: "Secondary",
+			configure: func(m *ZoneResourceModel) {
+				m.UpdateSecurityPolicies = policies
+			},
+			expectError: "Unsupported zone type",
+		},
+		{
+			name:     "stub zones do not transfer out",
+			zoneType: "Stub",
+			configure: func(m *ZoneResourceModel) {
+				m.ZoneTransfer = types.StringValue("Deny")
+			},
+			expectError: "Unsupported zone type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ZoneResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			model := zonePlanModel("example.com", tt.zoneType)
+			model.QueryAccess = types.StringNull()
+			model.QueryAccessNetworkACL = types.ListNull(types.StringType)
+			model.ZoneTransfer = types.StringNull()
+			model.ZoneTransferNetworkACL = types.ListNull(types.StringType)
+			model.Notify = types.StringNull()
+			model.NotifyNameServers = types.SetNull(types.StringType)
+			model.Update = types.StringNull()
+			model.UpdateNetworkACL = types.ListNull(types.StringType)
+			model.UpdateSecurityPolicies = types.ListNull(types.ObjectType{AttrTypes: updateSecurityPolicyAttrTypes})
+			tt.configure(&model)
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, Plan: plan}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			if tt.expectError != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				require.Equal(t, tt.expectError, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestZoneAccessOptions(t *testing.T) {
+	t.Parallel()
+
+	data := zonePlanModel("example.com", "Primary")
+	require.Empty(t, zoneAccessOptions(&data))
+
+	data.QueryAccess = types.StringValue("AllowOnlyPrivateNetworks")
+	data.QueryAccessNetworkACL = stringListValue(nil)
+	data.ZoneTransfer = types.StringValue("UseSpecifiedNetworkACL")
+	data.ZoneTransferNetworkACL = stringListValue([]string{"192.168.10.0/24", "!192.168.10.1"})
+	data.Notify = types.StringValue("None")
+	data.NotifyNameServers = stringSetValue(nil)
+	data.Update = types.StringValue("Allow")
+	data.UpdateSecurityPolicies = updateSecurityPoliciesValue([]client.ZoneUpdateSecurityPolicy{
+		{TsigKeyName: "key.example.com", Domain: "example.com", AllowedTypes: []string{"AAAA", "A"}},
+		{TsigKeyName: "key.example.com", Domain: "*.example.com", AllowedTypes: []string{"ANY"}},
+	})
+	require.Equal(t, map[string]string{
+		"queryAccess":            "AllowOnlyPrivateNetworks",
+		"queryAccessNetworkACL":  "false",
+		"zoneTransfer":           "UseSpecifiedNetworkACL",
+		"zoneTransferNetworkACL": "192.168.10.0/24,!192.168.10.1",
+		"notify":                 "None",
+		"notifyNameServers":      "false",
+		"update":                 "Allow",
+		"updateSecurityPolicies": "key.example.com|example.com|A,AAAA|key.example.com|*.example.com|ANY",
+	}, zoneAccessOptions(&data))
+
+	data.UpdateSecurityPolicies = updateSecurityPoliciesValue(nil)
+	require.Equal(t, "false", zoneAccessOptions(&data)["updateSecurityPolicies"])
+}
+
+func TestZoneResourceImportIdentity(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := &ZoneResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	var identityResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identityResp)
+
+	identity := &tfsdk.ResourceIdentity{
+		Schema: identityResp.IdentitySchema,
+		Raw:    tftypes.NewValue(identityResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+	require.False(t, identity.Set(ctx, ZoneIdentityModel{Name: types.StringValue("example.com")}).HasError())
+
+	resp := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: identity}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "import diagnostics: %v", resp.Diagnostics)
+
+	var id, name types.String
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("name"), &name).HasError())
+	require.Equal(t, "example.com", id.ValueString())
+	require.Equal(t, "example.com", name.ValueString())
+}
+
+func TestOptionalBoolField(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	require.Equal(t, types.BoolValue(true), optionalBoolField(&enabled, types.BoolValue(false)))
+	require.Equal(t, types.BoolValue(true), optionalBoolField(nil, types.BoolValue(true)), "configured values are kept when the server leaves the field out")
+	require.Equal(t, types.BoolNull(), optionalBoolField(nil, types.BoolUnknown()), "unknown values are not defaulted")
+	require.Equal(t, types.BoolNull(), optionalBoolField(nil, types.BoolNull()))
+}
+
+func TestZoneResourceModifyPlanCatalog(t *testing.T) {
+	t.Parallel()
+
+	zones := &client.ZoneListResponse{Zones: []client.Zone{
+		{Name: "catalog.example", Type: "Catalog"},
+		{Name: "example.com", Type: "Primary"},
+	}}
+
+	for name, tt := range map[string]struct {
+		catalog        string
+		expectError    bool
+		expectWarning  bool
+		expectedDetail string
+	}{
+		"catalog zone":    {catalog: "Catalog.example."},
+		"missing catalog": {catalog: "other.example", expectWarning: true, expectedDetail: "does not exist"},
+		"not a catalog":   {catalog: "example.com", expectError: true, expectedDetail: "example.com is a Primary zone"},
+		"without catalog": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := mocks.NewClientAPI(t)
+			r := &ZoneResource{client: m}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			model := zonePlanModel("member.example.com", "Primary")
+			if tt.catalog != "" {
+				model.Catalog = types.StringValue(tt.catalog)
+				m.On("SupportsFeature", mock.Anything, client.FeatureCatalogZones).Return(true, "13.0", "12.0", nil)
+				m.On("ListZonesPage", mock.Anything, 1, defaultZonesPageSize).Return(zones, nil).Once()
+			}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw},
+				Plan:   plan,
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil)},
+			}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			require.Equal(t, tt.expectError, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+			require.Equal(t, tt.expectWarning, resp.Diagnostics.WarningsCount() > 0, "modify plan diagnostics: %v", resp.Diagnostics)
+			if tt.expectedDetail != "" {
+				require.Contains(t, resp.Diagnostics[0].Detail(), tt.expectedDetail)
+			}
+		})
+	}
+}
+
+func TestZoneResourceRequireZoneCatalog(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	m.On("ListZonesPage", mock.Anything, 1, defaultZonesPageSize).Return(&client.ZoneListResponse{Zones: []client.Zone{
+		{Name: "catalog.example", Type: "Catalog"},
+	}}, nil).Twice()
+	r := &ZoneResource{client: m}
+
+	// A catalog created in the same apply exists by the time the member zone is written
+	data := zonePlanModel("member.example.com", "Primary")
+	data.Catalog = types.StringValue("catalog.example")
+	require.NoError(t, r.requireZoneCatalog(context.Background(), &data, types.StringNull()))
+
+	// An unchanged catalog is not checked again
+	require.NoError(t, r.requireZoneCatalog(context.Background(), &data, types.StringValue("catalog.example.")))
+
+	data.Catalog = types.StringValue("other.example")
+	require.ErrorContains(t, r.requireZoneCatalog(context.Background(), &data, types.StringValue("catalog.example")), "does not exist")
+}
+
+func TestZoneResourceCreatedRecords(t *testing.T) {
+	t.Parallel()
+
+	data := zonePlanModel("example.com", "Primary")
+	data.BootstrapRecords = []ZoneBootstrapRecordModel{
+		{Name: types.StringValue("www"), Type: types.StringValue("A"), TTL: types.Int64Value(300), Data: types.StringValue("192.0.2.1"), Priority: types.Int64Null()},
+		{Name: types.StringValue("@"), Type: types.StringValue("MX"), TTL: types.Int64Value(300), Data: types.StringValue("mail.example.com"), Priority: types.Int64Value(10)},
+		{Name: types.StringValue("@"), Type: types.StringValue("TXT"), TTL: types.Int64Value(300), Data: types.StringValue(`say "hi"`), Priority: types.Int64Null()},
+	}
+	cloned, err := parseZoneFile("example.com", "$TTL 300\n@ NS ns1.example.com.\napp CNAME www\n")
+	require.NoError(t, err)
+
+	created, err := createdZoneRecords(&data, cloned)
+	require.NoError(t, err)
+	require.True(t, created.Equal(stringSetValue([]string{
+		`app.example.com CNAME www.example.com`,
+		`example.com MX 10 mail.example.com`,
+		`example.com TXT "say \"hi\""`,
+		`www.example.com A 192.0.2.1`,
+	})), "created records: %s", created)
+	data.CreatedRecords = created
+
+	// Records created with the zone do not block its deletion, whatever their TTL
+	exported := `example.com. 3600 IN SOA ns1.example.com. hostmaster.example.com. 7 900 300 604800 900
+example.com. 3600 IN NS ns1.example.com.
+example.com. 3600 IN MX 10 mail.example.com.
+example.com. 3600 IN TXT "say \"hi\""
+www.example.com. 3600 IN A 192.0.2.1
+app.example.com. 300 IN CNAME www.example.com.
+`
+	m := mocks.NewClientAPI(t)
+	m.On("ExportZone", mock.Anything, "example.com").Return(exported, nil).Once()
+	r := &ZoneResource{client: m}
+
+	remaining, err := r.remainingZoneRecords(context.Background(), &data)
+	require.NoError(t, err)
+	require.Empty(t, remaining)
+
+	// Records added later still do
+	m.On("ExportZone", mock.Anything, "example.com").Return(exported+"extra.example.com. 300 IN A 192.0.2.9\n", nil).Once()
+	remaining, err = r.remainingZoneRecords(context.Background(), &data)
+	require.NoError(t, err)
+	require.Equal(t, []client.DNSRecord{{Name: "extra.example.com", Type: "A"}}, remaining)
+}
+
+func TestZoneResourceCreateBootstrapRecords(t *testing.T) {
+	t.Parallel()
+
+	bootstrapPlan := func(t *testing.T, schema resource.SchemaResponse) tfsdk.Plan {
+		data := zonePlanModel("example.com", "Primary")
+		data.BootstrapRecords = []ZoneBootstrapRecordModel{
+			{Name: types.StringValue("www"), Type: types.StringValue("A"), TTL: types.Int64Value(300), Data: types.StringValue("192.0.2.1"), Priority: types.Int64Null()},
+			{Name: types.StringValue("@"), Type: types.StringValue("MX"), TTL: types.Int64Value(3600), Data: types.StringValue("mail.example.com"), Priority: types.Int64Value(10)},
+		}
+		plan := tfsdk.Plan{Schema: schema.Schema}
+		require.False(t, plan.Set(context.Background(), &data).HasError())
+		return plan
+	}
+	priority := int64(10)
+	addA := client.AddRecordRequest{Zone: "example.com", Domain: "www.example.com", TTL: 300, Data: client.ARecordData{IPAddress: "192.0.2.1"}}
+	addMX := client.AddRecordRequest{Zone: "example.com", Domain: "example.com", TTL: 3600, Data: client.MXRecordData{Exchange: "mail.example.com", Preference: &priority}}
+
+	t.Run("adds the records after creating the zone", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		r := &ZoneResource{client: m}
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+		m.On("DoRequest", mock.Anything, "POST", mock.MatchedBy(func(endpoint string) bool {
+			return strings.HasPrefix(endpoint, "/api/zones/create?")
+		}), nil, mock.Anything).Return(nil).Once()
+		m.On("AddRecord", mock.Anything, addA).Return(&client.AddRecordResponse{}, nil).Once()
+		m.On("AddRecord", mock.Anything, addMX).Return(&client.AddRecordResponse{}, nil).Once()
+		m.On("GetZoneOptions", mock.Anything, "example.com").Return(&client.ZoneOptions{Name: "example.com", Type: "Primary", DnssecStatus: "Unsigned"}, nil)
+		m.On("GetRecords", mock.Anything, "example.com", "example.com", false).Return(&client.GetRecordsResponse{}, nil)
+
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: bootstrapPlan(t, schemaResp)}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "create diagnostics: %v", resp.Diagnostics)
+
+		var state ZoneResourceModel
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+		require.True(t, state.CreatedRecords.Equal(stringSetValue([]string{
+			"example.com MX 10 mail.example.com",
+			"www.example.com A 192.0.2.1",
+		})), "created records: %s", state.CreatedRecords)
+	})
+
+	t.Run("deletes the zone when a record fails", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		r := &ZoneResource{client: m}
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+		m.On("DoRequest", mock.Anything, "POST", mock.MatchedBy(func(endpoint string) bool {
+			return strings.HasPrefix(endpoint, "/api/zones/create?")
+		}), nil, mock.Anything).Return(nil).Once()
+		m.On("AddRecord", mock.Anything, addA).Return(&client.AddRecordResponse{}, nil).Once()
+		m.On("AddRecord", mock.Anything, addMX).Return(nil, errors.New("invalid exchange")).Once()
+		m.On("DoRequest", mock.Anything, "POST", mock.MatchedBy(func(endpoint string) bool {
+			return strings.HasPrefix(endpoint, "/api/zones/delete?")
+		}), nil, nil).Return(nil).Once()
+
+		resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Create(context.Background(), resource.CreateRequest{Plan: bootstrapPlan(t, schemaResp)}, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "bootstrap record 1 (MX @): invalid exchange")
+	})
+}
+
+func TestZoneResourceValidateConfigBootstrapRecords(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range map[string]struct {
+		record      ZoneBootstrapRecordModel
+		expectError string
+	}{
+		"valid MX": {
+			record: ZoneBootstrapRecordModel{Type: types.StringValue("MX"), Data: types.StringValue("mail.example.com"), Priority: types.Int64Value(10)},
+		},
+		"MX without priority": {
+			record:      ZoneBootstrapRecordModel{Type: types.StringValue("MX"), Data: types.StringValue("mail.example.com"), Priority: types.Int64Null()},
+			expectError: "priority is required for MX records",
+		},
+		"priority on A record": {
+			record:      ZoneBootstrapRecordModel{Type: types.StringValue("A"), Data: types.StringValue("192.0.2.1"), Priority: types.Int64Value(10)},
+			expectError: "priority is only valid for MX records",
+		},
+		"invalid IPv4 address": {
+			record:      ZoneBootstrapRecordModel{Type: types.StringValue("A"), Data: types.StringValue("2001:db8::1"), Priority: types.Int64Null()},
+			expectError: "invalid IPv4 address for A record",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := &ZoneResource{}
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			model := zonePlanModel("example.com", "Primary")
+			tt.record.Name = types.StringValue("@")
+			tt.record.TTL = types.Int64Value(300)
+			model.BootstrapRecords = []ZoneBootstrapRecordModel{tt.record}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			resp := resource.ValidateConfigResponse{}
+			r.ValidateConfig(context.Background(), resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}}, &resp)
+
+			if tt.expectError == "" {
+				require.False(t, resp.Diagnostics.HasError(), "validate diagnostics: %v", resp.Diagnostics)
+				return
+			}
+			require.True(t, resp.Diagnostics.HasError())
+			require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tt.expectError)
+		})
+	}
+}
+
+func TestZoneResourceModifyPlanBootstrapRecords(t *testing.T) {
+	t.Parallel()
+
+	r := &ZoneResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	prior := zonePlanModel("example.com", "Primary")
+	prior.BootstrapRecords = []ZoneBootstrapRecordModel{
+		{Name: types.StringValue("@"), Type: types.StringValue("A"), TTL: types.Int64Value(300), Data: types.StringValue("192.0.2.1"), Priority: types.Int64Null()},
+	}
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(context.Background(), &prior).HasError())
+
+	model := prior
+	model.BootstrapRecords = []ZoneBootstrapRecordModel{
+		{Name: types.StringValue("@"), Type: types.StringValue("A"), TTL: types.Int64Value(300), Data: types.StringValue("192.0.2.2"), Priority: types.Int64Null()},
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	require.False(t, plan.Set(context.Background(), &model).HasError())
+
+	req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, Plan: plan, State: state}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(context.Background(), req, &resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	require.Equal(t, "Bootstrap records not applied", resp.Diagnostics.Warnings()[0].Summary())
+}
+
+func TestZoneResourceModifyPlanCloneFrom(t *testing.T) {
+	t.Parallel()
+
+	r := &ZoneResource{}
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	prior := zonePlanModel("staging.example.com", "Primary")
+	prior.CloneFrom = types.StringValue("example.com")
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(context.Background(), &prior).HasError())
+
+	for name, tt := range map[string]struct {
+		source       types.String
+		expectWarned bool
+	}{
+		"unchanged": {source: types.StringValue("example.com")},
+		"changed":   {source: types.StringValue("example.net"), expectWarned: true},
+		"removed":   {source: types.StringNull(), expectWarned: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			model := prior
+			model.CloneFrom = tt.source
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			require.False(t, plan.Set(context.Background(), &model).HasError())
+
+			req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, Plan: plan, State: state}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(context.Background(), req, &resp)
+
+			require.False(t, resp.Diagnostics.HasError(), "modify plan diagnostics: %v", resp.Diagnostics)
+			warned := false
+			for _, warning := range resp.Diagnostics.Warnings() {
+				warned = warned || warning.Summary() == "Clone source not applied"
+			}
+			require.Equal(t, tt.expectWarned, warned)
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// zoneSOATimerAttributes describes the SOA timer attributes by name
+var zoneSOATimerAttributes = map[string]string{
+	"soa_refresh": "How often secondaries check the zone for updates, in seconds.",
+	"soa_retry":   "How long secondaries wait before retrying a failed refresh, in seconds.",
+	"soa_expire":  "How long secondaries keep answering for the zone while its primary is unreachable, in seconds.",
+	"soa_minimum": "How long resolvers cache negative answers for the zone (the negative caching TTL), in seconds.",
+}
+
+// zoneSOAAttributes returns the zone attributes of the SOA record values
+func zoneSOAAttributes() map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"soa_primary_name_server": schema.StringAttribute{
+			MarkdownDescription: "Primary name server host of the SOA record, instead of the server's own hostname. When the zone is created, the matching apex NS record " +
+				"is renamed too. Valid for Primary, Forwarder and Catalog zones. When not set, the server's value is kept.",
+			Optional: true,
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"soa_responsible_person": schema.StringAttribute{
+			MarkdownDescription: "Responsible person of the SOA record. " +
+				"Accepts an email address (`hostmaster@example.com`) or the SOA mailbox form (`hostmaster.example.com`). " +
+				"Valid for Primary, Forwarder and Catalog zones. When not set, the server's value is kept.",
+			Optional: true,
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+	}
+
+	for name, description := range zoneSOATimerAttributes {
+		attributes[name] = schema.Int64Attribute{
+			MarkdownDescription: description + " Valid for Primary, Forwarder and Catalog zones. When not set, the server's value is kept.",
+			Optional:            true,
+			Computed:            true,
+			Validators: []validator.Int64{
+				ttlValidator(),
+			},
+			PlanModifiers: []planmodifier.Int64{
+				int64planmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+	return attributes
+}
+
+// knownString returns the value of a string attribute, or an empty string while it is null or
+// unknown
+func knownString(value types.String) string {
+	if value.IsNull() || value.IsUnknown() {
+		return ""
+	}
+	return value.ValueString()
+}
+
+// zoneSOATimers returns the configured SOA timers, with null or unknown timers as nil
+func zoneSOATimers(data *ZoneResourceModel) []*int64 {
+	timers := make([]*int64, 0, 4)
+	for _, value := range []types.Int64{data.SoaRefresh, data.SoaRetry, data.SoaExpire, data.SoaMinimum} {
+		if value.IsNull() || value.IsUnknown() {
+			timers = append(timers, nil)
+			continue
+		}
+		timer := value.ValueInt64()
+		timers = append(timers, &timer)
+	}
+	return timers
+}
+
+// zoneSOAConfigured reports whether any SOA value is configured
+func zoneSOAConfigured(data *ZoneResourceModel) bool {
+	if knownString(data.SoaPrimaryNameServer) != "" || knownString(data.SoaResponsiblePerson) != "" {
+		return true
+	}
+	for _, timer := range zoneSOATimers(data) {
+		if timer != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneSOAData builds the updated SOA data, keeping every value that is not configured
+func zoneSOAData(soa client.DNSRecord, data *ZoneResourceModel) client.SOARecordData {
+	record := initialSOAData(soa, knownString(data.SoaPrimaryNameServer), knownString(data.SoaResponsiblePerson))
+
+	timers := zoneSOATimers(data)
+	for i, field := range []*int64{&record.Refresh, &record.Retry, &record.Expire, &record.Minimum} {
+		if timers[i] != nil {
+			*field = *timers[i]
+		}
+	}
+	return record
+}
+
+// sameSOAName reports whether two names of a SOA record are the same, ignoring case and the
+// trailing dot
+func sameSOAName(a, b string) bool {
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// readZoneSOA sets the SOA values of a zone from its SOA record. Configured names matching the
+// record keep their configured form, e.g. an email address as the responsible person. Without a
+// SOA record, known values are kept.
+func readZoneSOA(data *ZoneResourceModel, soa *client.DNSRecord) {
+	if soa == nil {
+		for _, value := range []*types.Int64{&data.SoaRefresh, &data.SoaRetry, &data.SoaExpire, &data.SoaMinimum} {
+			if value.IsUnknown() {
+				*value = types.Int64Null()
+			}
+		}
+		for _, value := range []*types.String{&data.SoaPrimaryNameServer, &data.SoaResponsiblePerson} {
+			if value.IsUnknown() {
+				*value = types.StringNull()
+			}
+		}
+		return
+	}
+
+	data.SoaRefresh = types.Int64Value(int64(soa.RData.Refresh))
+	data.SoaRetry = types.Int64Value(int64(soa.RData.Retry))
+	data.SoaExpire = types.Int64Value(int64(soa.RData.Expire))
+	data.SoaMinimum = types.Int64Value(int64(soa.RData.Minimum))
+
+	if !sameSOAName(knownString(data.SoaPrimaryNameServer), soa.RData.PrimaryNameServer) {
+		data.SoaPrimaryNameServer = types.StringValue(soa.RData.PrimaryNameServer)
+	}
+	responsiblePerson := knownString(data.SoaResponsiblePerson)
+	if !sameSOAName(responsiblePerson, soa.RData.ResponsiblePerson) && !sameSOAName(soaMailbox(responsiblePerson), soa.RData.ResponsiblePerson) {
+		data.SoaResponsiblePerson = types.StringValue(soa.RData.ResponsiblePerson)
+	}
+}
+
+// zoneSOARecord returns the SOA record of a zone, or nil when the zone records cannot be read or
+// the zone has no SOA record (e.g. forwarder zones)
+func zoneSOARecord(ctx context.Context, c client.ClientAPI, zoneName string) *client.DNSRecord {
+	recordsResponse, err := c.GetRecords(ctx, zoneName, zoneName, false)
+	if err != nil {
+		// Don't fail if records can't be read, just log it
+		tflog.Warn(ctx, "Failed to read zone records for SOA record", map[string]interface{}{
+			"zone":  zoneName,
+			"error": err.Error(),
+		})
+		return nil
+	}
+
+	for i, record := range recordsResponse.Records {
+		if record.Type == "SOA" {
+			return &recordsResponse.Records[i]
+		}
+	}
+	return nil
+}
+
+// updateZoneSOA writes the configured SOA values when they changed since the prior state
+func (r *ZoneResource) updateZoneSOA(ctx context.Context, data, prior *ZoneResourceModel) error {
+	if !zoneSOAConfigured(data) {
+		return nil
+	}
+
+	changed := false
+	for _, values := range [][2]types.Int64{
+		{data.SoaRefresh, prior.SoaRefresh}, {data.SoaRetry, prior.SoaRetry},
+		{data.SoaExpire, prior.SoaExpire}, {data.SoaMinimum, prior.SoaMinimum},
+	} {
+		changed = changed || (!values[0].IsNull() && !values[0].IsUnknown() && !values[0].Equal(values[1]))
+	}
+	for _, values := range [][2]types.String{
+		{data.SoaPrimaryNameServer, prior.SoaPrimaryNameServer}, {data.SoaResponsiblePerson, prior.SoaResponsiblePerson},
+	} {
+		changed = changed || (knownString(values[0]) != "" && !values[0].Equal(values[1]))
+	}
+	if !changed {
+		return nil
+	}
+
+	zoneName := data.Name.ValueString()
+	recordsResponse, err := r.client.GetRecords(ctx, zoneName, zoneName, false)
+	if err != nil {
+		return fmt.Errorf("failed to read SOA record: %w", err)
+	}
+
+	for _, record := range recordsResponse.Records {
+		if record.Type != "SOA" {
+			continue
+		}
+
+		tflog.Debug(ctx, "Updating zone SOA record", map[string]interface{}{
+			"zone": zoneName,
+		})
+		if _, err := r.client.UpdateRecord(ctx, client.UpdateRecordRequest{
+			Zone:   zoneName,
+			Domain: zoneName,
+			TTL:    int64(record.TTL),
+			New:    zoneSOAData(record, data),
+		}); err != nil {
+			return fmt.Errorf("failed to update SOA record: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("zone %s has no SOA record", zoneName)
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ZoneTransferStatusDataSource{}
+
+func NewZoneTransferStatusDataSource() datasource.DataSource {
+	return &ZoneTransferStatusDataSource{}
+}
+
+// ZoneTransferStatusDataSource defines the data source implementation.
+type ZoneTransferStatusDataSource struct {
+	client client.ClientAPI
+}
+
+// ZoneTransferStatusDataSourceModel describes the data source data model.
+type ZoneTransferStatusDataSourceModel struct {
+	// Required inputs
+	Name types.String `tfsdk:"name"`
+
+	// Computed outputs
+	ID              types.String   `tfsdk:"id"`
+	Type            types.String   `tfsdk:"type"`
+	SoaSerial       types.Int64    `tfsdk:"soa_serial"`
+	LastModified    types.String   `tfsdk:"last_modified"`
+	Expiry          types.String   `tfsdk:"expiry"`
+	IsExpired       types.Bool     `tfsdk:"is_expired"`
+	SyncFailed      types.Bool     `tfsdk:"sync_failed"`
+	NotifyFailed    types.Bool     `tfsdk:"notify_failed"`
+	NotifyFailedFor []types.String `tfsdk:"notify_failed_for"`
+}
+
+func (d *ZoneTransferStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_transfer_status"
+}
+
+func (d *ZoneTransferStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source reporting the zone transfer state of a zone",
+		MarkdownDescription: "Data source reporting the zone transfer state of a zone as listed by the server: the SOA serial, " +
+			"when the zone last changed, and whether refreshes of a secondary zone or notifies of a primary zone are failing. " +
+			"Use it to check replication after applying large record changes. The Technitium API does not report whether the " +
+			"last refresh used AXFR or IXFR or how much data was transferred, so compare `soa_serial` and `last_modified` on " +
+			"the primary and its secondaries instead.",
+
+		Attributes: map[string]schema.Attribute{
+			// Required inputs
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the zone (e.g., 'example.com').",
+				Required:            true,
+			},
+
+			// Computed outputs
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the zone (e.g., Primary, Secondary, Stub).",
+				Computed:            true,
+			},
+			"soa_serial": schema.Int64Attribute{
+				MarkdownDescription: "The SOA serial number the server currently holds for the zone.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "When the zone was last modified on this server. For secondary zones this is the last successful transfer that changed the zone.",
+				Computed:            true,
+			},
+			"expiry": schema.StringAttribute{
+				MarkdownDescription: "When a secondary or stub zone expires unless it is refreshed. Empty for other zone types.",
+				Computed:            true,
+			},
+			"is_expired": schema.BoolAttribute{
+				MarkdownDescription: "Whether a secondary or stub zone has expired because it could not be refreshed.",
+				Computed:            true,
+			},
+			"sync_failed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the last refresh of a secondary or stub zone from its primary failed.",
+				Computed:            true,
+			},
+			"notify_failed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the last notify of a primary zone to its secondaries failed.",
+				Computed:            true,
+			},
+			"notify_failed_for": schema.ListAttribute{
+				MarkdownDescription: "The name servers that failed to acknowledge the last notify of a primary zone.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *ZoneTransferStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZoneTransferStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZoneTransferStatusDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zoneName := data.Name.ValueString()
+	tflog.Debug(ctx, "Reading zone transfer status data source", map[string]interface{}{
+		"name": zoneName,
+	})
+
+	// The transfer state is only reported by the zone list, not by the zone options
+	zones, err := d.client.ListZones(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing zones",
+			fmt.Sprintf("Could not list zones: %s", err.Error()),
+		)
+		return
+	}
+
+	zone := findListedZone(zones, zoneName)
+	if zone == nil {
+		resp.Diagnostics.AddError(
+			"Zone not found",
+			fmt.Sprintf("Zone %s does not exist on the server", zoneName),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(zone.Name)
+	data.Type = types.StringValue(zone.Type)
+	data.SoaSerial = types.Int64Value(int64(zone.SoaSerial))
+	data.LastModified = types.StringValue(zone.LastModified)
+	data.Expiry = types.StringValue(zone.Expiry)
+	data.IsExpired = types.BoolValue(zone.IsExpired)
+	data.SyncFailed = types.BoolValue(zone.SyncFailed)
+	data.NotifyFailed = types.BoolValue(zone.NotifyFailed)
+	data.NotifyFailedFor = make([]types.String, 0, len(zone.NotifyFailedFor))
+	for _, nameServer := range zone.NotifyFailedFor {
+		data.NotifyFailedFor = append(data.NotifyFailedFor, types.StringValue(nameServer))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findListedZone returns the zone with the given name from a zone list, ignoring case and a trailing dot
+func findListedZone(zones []client.Zone, name string) *client.Zone {
+	name = strings.TrimSuffix(name, ".")
+	for i := range zones {
+		if strings.EqualFold(zones[i].Name, name) {
+			return &zones[i]
+		}
+	}
+	return nil
+}
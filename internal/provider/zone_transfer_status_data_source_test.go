@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestZoneTransferStatusDataSource(t *testing.T) {
+	t.Parallel()
+
+	// Unit test - verify data source creation
+	t.Run("NewZoneTransferStatusDataSource", func(t *testing.T) {
+		ds := NewZoneTransferStatusDataSource()
+		if ds == nil {
+			t.Fatal("NewZoneTransferStatusDataSource should return a non-nil data source")
+		}
+
+		var resp datasource.MetadataResponse
+		ds.Metadata(context.Background(), datasource.MetadataRequest{
+			ProviderTypeName: "technitium",
+		}, &resp)
+
+		if resp.TypeName != "technitium_zone_transfer_status" {
+			t.Errorf("Expected TypeName to be technitium_zone_transfer_status, got %s", resp.TypeName)
+		}
+	})
+
+	// Unit test - verify schema
+	t.Run("Schema", func(t *testing.T) {
+		ds := NewZoneTransferStatusDataSource()
+		var resp datasource.SchemaResponse
+		ds.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Schema validation failed: %v", resp.Diagnostics.Errors())
+		}
+
+		if !resp.Schema.Attributes["name"].IsRequired() {
+			t.Error("'name' attribute should be required")
+		}
+		for _, name := range []string{"id", "type", "soa_serial", "last_modified", "expiry", "is_expired", "sync_failed", "notify_failed", "notify_failed_for"} {
+			attr, ok := resp.Schema.Attributes[name]
+			if !ok {
+				t.Errorf("Schema should have '%s' attribute", name)
+				continue
+			}
+			if !attr.IsComputed() {
+				t.Errorf("'%s' attribute should be computed", name)
+			}
+		}
+	})
+
+	// Unit test - verify configure method
+	t.Run("Configure", func(t *testing.T) {
+		ds := NewZoneTransferStatusDataSource().(*ZoneTransferStatusDataSource)
+
+		var resp datasource.ConfigureResponse
+		ds.Configure(context.Background(), datasource.ConfigureRequest{
+			ProviderData: "wrong-type",
+		}, &resp)
+
+		if !resp.Diagnostics.HasError() {
+			t.Error("Configure should fail with wrong provider data type")
+		}
+	})
+}
+
+func TestFindListedZone(t *testing.T) {
+	t.Parallel()
+
+	zones := []client.Zone{{Name: "example.com", Type: "Primary"}, {Name: "example.org", Type: "Secondary"}}
+
+	tests := []struct {
+		name     string
+		zoneName string
+		wantType string
+	}{
+		{name: "exact match", zoneName: "example.org", wantType: "Secondary"},
+		{name: "case and trailing dot", zoneName: "Example.COM.", wantType: "Primary"},
+		{name: "missing zone", zoneName: "example.net"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone := findListedZone(zones, tt.zoneName)
+			if tt.wantType == "" {
+				require.Nil(t, zone)
+				return
+			}
+			require.NotNil(t, zone)
+			require.Equal(t, tt.wantType, zone.Type)
+		})
+	}
+}
+
+func TestUnitZoneTransferStatusDataSourceRead(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewClientAPI(t)
+	d := &ZoneTransferStatusDataSource{client: m}
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+	m.On("ListZones", mock.Anything).Return([]client.Zone{
+		{Name: "example.com", Type: "Primary", SoaSerial: 12, NotifyFailed: true, NotifyFailedFor: []string{"ns2.example.com"}},
+		{Name: "example.org", Type: "Secondary", SoaSerial: 7, Expiry: "2022-02-26T07:57:08.1842183Z", SyncFailed: true, LastModified: "2022-02-19T07:57:08.1842183Z"},
+	}, nil)
+
+	// Build the configuration from a state holding the inputs
+	input := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, input.Set(context.Background(), &ZoneTransferStatusDataSourceModel{Name: types.StringValue("example.org")}).HasError())
+
+	resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(context.Background(), datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: input.Raw}}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+
+	var state ZoneTransferStatusDataSourceModel
+	require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	require.Equal(t, "Secondary", state.Type.ValueString())
+	require.Equal(t, int64(7), state.SoaSerial.ValueInt64())
+	require.Equal(t, "2022-02-26T07:57:08.1842183Z", state.Expiry.ValueString())
+	require.True(t, state.SyncFailed.ValueBool())
+	require.False(t, state.IsExpired.ValueBool())
+	require.Empty(t, state.NotifyFailedFor)
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &ZonesDataSource{}
+
+// defaultZonesPageSize is the number of zones requested per page when page_size is not set
+const defaultZonesPageSize = 100
+
+func NewZonesDataSource() datasource.DataSource {
+	return &ZonesDataSource{}
+}
+
+// ZonesDataSource defines the data source implementation.
+type ZonesDataSource struct {
+	client client.ClientAPI
+}
+
+// ZonesDataSourceModel describes the data source data model.
+type ZonesDataSourceModel struct {
+	ID       types.String   `tfsdk:"id"`
+	PageSize types.Int64    `tfsdk:"page_size"`
+	Zones    []ZoneDataItem `tfsdk:"zones"`
+}
+
+// ZoneDataItem represents a zone in the zone list
+type ZoneDataItem struct {
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	DnssecStatus types.String `tfsdk:"dnssec_status"`
+	Disabled     types.Bool   `tfsdk:"disabled"`
+	Internal     types.Bool   `tfsdk:"internal"`
+	SoaSerial    types.Int64  `tfsdk:"soa_serial"`
+}
+
+func (d *ZonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones"
+}
+
+func (d *ZonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Data source listing all zones of the DNS server",
+		MarkdownDescription: "Data source listing all zones of the DNS server, e.g. to iterate over existing zones with `for_each` or to audit them for drift. " +
+			"The zones are fetched page by page, so servers with many zones are not read in a single response.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier for the data source.",
+				Computed:            true,
+			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The number of zones requested per page. Defaults to `%d`.", defaultZonesPageSize),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 10000),
+				},
+			},
+			"zones": schema.ListNestedAttribute{
+				MarkdownDescription: "The zones on the server, in the order the server lists them.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the zone.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the zone, e.g. Primary, Secondary or Forwarder.",
+							Computed:            true,
+						},
+						"dnssec_status": schema.StringAttribute{
+							MarkdownDescription: "The DNSSEC status of the zone, e.g. Unsigned or SignedWithNSEC.",
+							Computed:            true,
+						},
+						"disabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the zone is disabled.",
+							Computed:            true,
+						},
+						"internal": schema.BoolAttribute{
+							MarkdownDescription: "Whether the zone is an internal zone created by the server.",
+							Computed:            true,
+						},
+						"soa_serial": schema.Int64Attribute{
+							MarkdownDescription: "The serial number of the zone's SOA record. 0 for zones without one, e.g. Stub zones that were not loaded yet.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ZonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ZonesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	pageSize := defaultZonesPageSize
+	if !data.PageSize.IsNull() && !data.PageSize.IsUnknown() {
+		pageSize = int(data.PageSize.ValueInt64())
+	}
+
+	tflog.Debug(ctx, "Reading zones data source", map[string]interface{}{
+		"page_size": pageSize,
+	})
+
+	zones, err := listAllZones(ctx, d.client, pageSize)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading zones",
+			fmt.Sprintf("Could not list zones: %s", err.Error()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("zones")
+	data.Zones = make([]ZoneDataItem, 0, len(zones))
+	for _, zone := range zones {
+		data.Zones = append(data.Zones, ZoneDataItem{
+			Name:         types.StringValue(zone.Name),
+			Type:         types.StringValue(zone.Type),
+			DnssecStatus: types.StringValue(zone.DnssecStatus),
+			Disabled:     types.BoolValue(zone.Disabled),
+			Internal:     types.BoolValue(zone.Internal),
+			SoaSerial:    types.Int64Value(int64(zone.SoaSerial)),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listAllZones reads every page of the zone list
+func listAllZones(ctx context.Context, c client.ClientAPI, pageSize int) ([]client.Zone, error) {
+	var zones []client.Zone
+	for pageNumber := 1; ; pageNumber++ {
+		page, err := c.ListZonesPage(ctx, pageNumber, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, page.Zones...)
+
+		// Servers without paging support return all zones without page counts
+		if len(page.Zones) == 0 || pageNumber >= page.TotalPages {
+			return zones, nil
+		}
+	}
+}
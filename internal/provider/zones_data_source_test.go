@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client"
+	"github.com/kusold/terraform-provider-technitium-dns-server/internal/client/mocks"
+)
+
+func TestZonesDataSource(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	read := func(t *testing.T, m *mocks.ClientAPI, pageSize types.Int64) (ZonesDataSourceModel, datasource.ReadResponse) {
+		t.Helper()
+
+		d := &ZonesDataSource{client: m}
+		var schemaResp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+		require.False(t, schemaResp.Diagnostics.HasError(), "schema diagnostics: %v", schemaResp.Diagnostics)
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(ctx, &ZonesDataSourceModel{ID: types.StringNull(), PageSize: pageSize}).HasError())
+
+		resp := datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}, &resp)
+
+		var data ZonesDataSourceModel
+		if !resp.Diagnostics.HasError() {
+			require.False(t, resp.State.Get(ctx, &data).HasError())
+		}
+		return data, resp
+	}
+
+	t.Run("reads all pages", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("ListZonesPage", mock.Anything, 1, 2).Return(&client.ZoneListResponse{
+			PageNumber: 1, TotalPages: 2, TotalZones: 3,
+			Zones: []client.Zone{
+				{Name: "example.com", Type: "Primary", DnssecStatus: "SignedWithNSEC", SoaSerial: 2026101701},
+				{Name: "localhost", Type: "Primary", Internal: true, DnssecStatus: "Unsigned", SoaSerial: 1},
+			},
+		}, nil).Once()
+		m.On("ListZonesPage", mock.Anything, 2, 2).Return(&client.ZoneListResponse{
+			PageNumber: 2, TotalPages: 2, TotalZones: 3,
+			Zones: []client.Zone{{Name: "corp.example.com", Type: "Forwarder", Disabled: true, DnssecStatus: "Unsigned"}},
+		}, nil).Once()
+
+		data, resp := read(t, m, types.Int64Value(2))
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Len(t, data.Zones, 3)
+
+		require.Equal(t, "example.com", data.Zones[0].Name.ValueString())
+		require.Equal(t, "SignedWithNSEC", data.Zones[0].DnssecStatus.ValueString())
+		require.Equal(t, int64(2026101701), data.Zones[0].SoaSerial.ValueInt64())
+		require.True(t, data.Zones[1].Internal.ValueBool())
+		require.Equal(t, "Forwarder", data.Zones[2].Type.ValueString())
+		require.True(t, data.Zones[2].Disabled.ValueBool())
+	})
+
+	t.Run("server without paging", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("ListZonesPage", mock.Anything, 1, defaultZonesPageSize).Return(&client.ZoneListResponse{
+			Zones: []client.Zone{{Name: "example.com", Type: "Primary"}},
+		}, nil).Once()
+
+		data, resp := read(t, m, types.Int64Null())
+		require.False(t, resp.Diagnostics.HasError(), "read diagnostics: %v", resp.Diagnostics)
+		require.Len(t, data.Zones, 1)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		m := mocks.NewClientAPI(t)
+		m.On("ListZonesPage", mock.Anything, 1, defaultZonesPageSize).Return(nil, errors.New("connection refused"))
+
+		_, resp := read(t, m, types.Int64Null())
+		require.True(t, resp.Diagnostics.HasError())
+	})
+}